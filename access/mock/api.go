@@ -10,6 +10,8 @@ import (
 	flow "github.com/onflow/flow-go/model/flow"
 
 	mock "github.com/stretchr/testify/mock"
+
+	profiling "github.com/onflow/flow-go/fvm/profiling"
 )
 
 // API is an autogenerated mock type for the API type
@@ -445,6 +447,52 @@ func (_m *API) GetNetworkParameters(ctx context.Context) access.NetworkParameter
 	return r0
 }
 
+// GetProtocolStateSnapshotByBlockID provides a mock function with given fields: ctx, blockID
+func (_m *API) GetProtocolStateSnapshotByBlockID(ctx context.Context, blockID flow.Identifier) ([]byte, error) {
+	ret := _m.Called(ctx, blockID)
+
+	var r0 []byte
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Identifier) []byte); ok {
+		r0 = rf(ctx, blockID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, flow.Identifier) error); ok {
+		r1 = rf(ctx, blockID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetProtocolStateSnapshotByHeight provides a mock function with given fields: ctx, height
+func (_m *API) GetProtocolStateSnapshotByHeight(ctx context.Context, height uint64) ([]byte, error) {
+	ret := _m.Called(ctx, height)
+
+	var r0 []byte
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) []byte); ok {
+		r0 = rf(ctx, height)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, height)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetTransaction provides a mock function with given fields: ctx, id
 func (_m *API) GetTransaction(ctx context.Context, id flow.Identifier) (*flow.TransactionBody, error) {
 	ret := _m.Called(ctx, id)
@@ -505,6 +553,70 @@ func (_m *API) Ping(ctx context.Context) error {
 	return r0
 }
 
+// ProfileScriptAtBlockID provides a mock function with given fields: ctx, blockID, script, arguments
+func (_m *API) ProfileScriptAtBlockID(ctx context.Context, blockID flow.Identifier, script []byte, arguments [][]byte) ([]byte, *profiling.Profile, error) {
+	ret := _m.Called(ctx, blockID, script, arguments)
+
+	var r0 []byte
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Identifier, []byte, [][]byte) []byte); ok {
+		r0 = rf(ctx, blockID, script, arguments)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	var r1 *profiling.Profile
+	if rf, ok := ret.Get(1).(func(context.Context, flow.Identifier, []byte, [][]byte) *profiling.Profile); ok {
+		r1 = rf(ctx, blockID, script, arguments)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*profiling.Profile)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, flow.Identifier, []byte, [][]byte) error); ok {
+		r2 = rf(ctx, blockID, script, arguments)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ProfileScriptAtLatestBlock provides a mock function with given fields: ctx, script, arguments
+func (_m *API) ProfileScriptAtLatestBlock(ctx context.Context, script []byte, arguments [][]byte) ([]byte, *profiling.Profile, error) {
+	ret := _m.Called(ctx, script, arguments)
+
+	var r0 []byte
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, [][]byte) []byte); ok {
+		r0 = rf(ctx, script, arguments)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	var r1 *profiling.Profile
+	if rf, ok := ret.Get(1).(func(context.Context, []byte, [][]byte) *profiling.Profile); ok {
+		r1 = rf(ctx, script, arguments)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*profiling.Profile)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, []byte, [][]byte) error); ok {
+		r2 = rf(ctx, script, arguments)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // SendTransaction provides a mock function with given fields: ctx, tx
 func (_m *API) SendTransaction(ctx context.Context, tx *flow.TransactionBody) error {
 	ret := _m.Called(ctx, tx)
@@ -518,3 +630,26 @@ func (_m *API) SendTransaction(ctx context.Context, tx *flow.TransactionBody) er
 
 	return r0
 }
+
+// SimulateTransaction provides a mock function with given fields: ctx, tx, opts
+func (_m *API) SimulateTransaction(ctx context.Context, tx *flow.TransactionBody, opts flow.TransactionSimulationOptions) (*flow.TransactionSimulationResult, error) {
+	ret := _m.Called(ctx, tx, opts)
+
+	var r0 *flow.TransactionSimulationResult
+	if rf, ok := ret.Get(0).(func(context.Context, *flow.TransactionBody, flow.TransactionSimulationOptions) *flow.TransactionSimulationResult); ok {
+		r0 = rf(ctx, tx, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*flow.TransactionSimulationResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *flow.TransactionBody, flow.TransactionSimulationOptions) error); ok {
+		r1 = rf(ctx, tx, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
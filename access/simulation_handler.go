@@ -0,0 +1,91 @@
+package access
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	"github.com/onflow/flow-go/engine/common/rpc/simulation"
+	simulationpb "github.com/onflow/flow-go/engine/common/rpc/simulation/simulation"
+	"github.com/onflow/flow-go/fvm/profiling"
+)
+
+// SimulationHandler serves the simulation API of the access node on top of the
+// access API.
+type SimulationHandler struct {
+	simulationpb.UnimplementedSimulationAPIServer
+	api API
+}
+
+var _ simulationpb.SimulationAPIServer = (*SimulationHandler)(nil)
+
+func NewSimulationHandler(api API) *SimulationHandler {
+	return &SimulationHandler{
+		api: api,
+	}
+}
+
+// SimulateTransaction executes a transaction against the latest sealed
+// execution state without committing its effects.
+func (h *SimulationHandler) SimulateTransaction(
+	ctx context.Context,
+	req *simulationpb.SimulateTransactionRequest,
+) (*simulationpb.SimulateTransactionResponse, error) {
+
+	if len(req.GetBlockId()) != 0 {
+		return nil, status.Error(codes.InvalidArgument, "transactions can only be simulated against the latest sealed block")
+	}
+	if req.GetTransaction() == nil {
+		return nil, status.Error(codes.InvalidArgument, "transaction must be set")
+	}
+
+	tx := simulation.MessageToTransaction(req.GetTransaction())
+	result, err := h.api.SimulateTransaction(ctx, &tx, simulation.MessageToOptions(req.GetOptions()))
+	if err != nil {
+		return nil, err
+	}
+
+	return &simulationpb.SimulateTransactionResponse{Result: simulation.ResultToMessage(*result)}, nil
+}
+
+// ExecuteScript executes a script against the execution state of the requested
+// block, or of the latest sealed block if no block is requested, and returns
+// the profile of its execution if requested.
+func (h *SimulationHandler) ExecuteScript(
+	ctx context.Context,
+	req *simulationpb.ExecuteScriptRequest,
+) (*simulationpb.ExecuteScriptResponse, error) {
+
+	var (
+		value   []byte
+		profile *profiling.Profile
+		err     error
+	)
+	if len(req.GetBlockId()) == 0 {
+		if req.GetProfile() {
+			value, profile, err = h.api.ProfileScriptAtLatestBlock(ctx, req.GetScript(), req.GetArguments())
+		} else {
+			value, err = h.api.ExecuteScriptAtLatestBlock(ctx, req.GetScript(), req.GetArguments())
+		}
+	} else {
+		blockID, convErr := convert.BlockID(req.GetBlockId())
+		if convErr != nil {
+			return nil, convErr
+		}
+		if req.GetProfile() {
+			value, profile, err = h.api.ProfileScriptAtBlockID(ctx, blockID, req.GetScript(), req.GetArguments())
+		} else {
+			value, err = h.api.ExecuteScriptAtBlockID(ctx, blockID, req.GetScript(), req.GetArguments())
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &simulationpb.ExecuteScriptResponse{
+		Value:   value,
+		Profile: simulation.ProfileToMessage(profile),
+	}, nil
+}
@@ -0,0 +1,56 @@
+package access
+
+import (
+	"context"
+
+	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	snapshotspb "github.com/onflow/flow-go/engine/common/rpc/snapshots/snapshots"
+)
+
+// SnapshotHandler serves the protocol state snapshots as of past finalized
+// blocks on top of the access API.
+type SnapshotHandler struct {
+	snapshotspb.UnimplementedSnapshotAPIServer
+	api API
+}
+
+var _ snapshotspb.SnapshotAPIServer = (*SnapshotHandler)(nil)
+
+func NewSnapshotHandler(api API) *SnapshotHandler {
+	return &SnapshotHandler{
+		api: api,
+	}
+}
+
+// GetProtocolStateSnapshotByHeight returns the serialized snapshot as of the
+// finalized block at the given height.
+func (h *SnapshotHandler) GetProtocolStateSnapshotByHeight(
+	ctx context.Context,
+	req *snapshotspb.GetProtocolStateSnapshotByHeightRequest,
+) (*snapshotspb.ProtocolStateSnapshotResponse, error) {
+	snapshot, err := h.api.GetProtocolStateSnapshotByHeight(ctx, req.GetHeight())
+	if err != nil {
+		return nil, err
+	}
+
+	return &snapshotspb.ProtocolStateSnapshotResponse{SerializedSnapshot: snapshot}, nil
+}
+
+// GetProtocolStateSnapshotByBlockID returns the serialized snapshot as of the
+// given finalized block.
+func (h *SnapshotHandler) GetProtocolStateSnapshotByBlockID(
+	ctx context.Context,
+	req *snapshotspb.GetProtocolStateSnapshotByBlockIDRequest,
+) (*snapshotspb.ProtocolStateSnapshotResponse, error) {
+	blockID, err := convert.BlockID(req.GetBlockId())
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot, err := h.api.GetProtocolStateSnapshotByBlockID(ctx, blockID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &snapshotspb.ProtocolStateSnapshotResponse{SerializedSnapshot: snapshot}, nil
+}
@@ -0,0 +1,76 @@
+package access_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/onflow/flow-go/access"
+	accessmock "github.com/onflow/flow-go/access/mock"
+	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	snapshotspb "github.com/onflow/flow-go/engine/common/rpc/snapshots/snapshots"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestSnapshotHandler tests that the snapshots are served without loss over a
+// gRPC connection.
+func TestSnapshotHandler(t *testing.T) {
+	height := uint64(100)
+	blockID := unittest.IdentifierFixture()
+	snapshot := unittest.RandomBytes(1024)
+
+	api := new(accessmock.API)
+	api.On("GetProtocolStateSnapshotByHeight", mock.Anything, height).Return(snapshot, nil)
+	api.On("GetProtocolStateSnapshotByHeight", mock.Anything, mock.Anything).Return(nil, status.Error(codes.NotFound, "not found"))
+	api.On("GetProtocolStateSnapshotByBlockID", mock.Anything, blockID).Return(snapshot, nil)
+	api.On("GetProtocolStateSnapshotByBlockID", mock.Anything, mock.Anything).Return(nil, status.Error(codes.NotFound, "not found"))
+
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	snapshotspb.RegisterSnapshotAPIServer(server, access.NewSnapshotHandler(api))
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	defer server.Stop()
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return listener.Dial()
+		}),
+		grpc.WithInsecure(), //nolint:staticcheck
+	)
+	require.NoError(t, err)
+	defer conn.Close()
+	client := snapshotspb.NewSnapshotAPIClient(conn)
+
+	t.Run("by height", func(t *testing.T) {
+		resp, err := client.GetProtocolStateSnapshotByHeight(context.Background(), &snapshotspb.GetProtocolStateSnapshotByHeightRequest{Height: height})
+		require.NoError(t, err)
+		assert.Equal(t, snapshot, resp.GetSerializedSnapshot())
+
+		_, err = client.GetProtocolStateSnapshotByHeight(context.Background(), &snapshotspb.GetProtocolStateSnapshotByHeightRequest{Height: height + 1})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("by block ID", func(t *testing.T) {
+		resp, err := client.GetProtocolStateSnapshotByBlockID(context.Background(), &snapshotspb.GetProtocolStateSnapshotByBlockIDRequest{BlockId: convert.IdentifierToMessage(blockID)})
+		require.NoError(t, err)
+		assert.Equal(t, snapshot, resp.GetSerializedSnapshot())
+
+		_, err = client.GetProtocolStateSnapshotByBlockID(context.Background(), &snapshotspb.GetProtocolStateSnapshotByBlockIDRequest{BlockId: convert.IdentifierToMessage(unittest.IdentifierFixture())})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("without block ID", func(t *testing.T) {
+		_, err := client.GetProtocolStateSnapshotByBlockID(context.Background(), &snapshotspb.GetProtocolStateSnapshotByBlockIDRequest{})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
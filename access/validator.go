@@ -282,7 +282,8 @@ func (v *TransactionValidator) checkSignatureFormat(tx *flow.TransactionBody) er
 
 	for _, signature := range append(tx.PayloadSignatures, tx.EnvelopeSignatures...) {
 		// check the format of the signature is valid.
-		// a valid signature is an ECDSA signature of either P-256 or secp256k1 curve.
+		// a valid signature is an ECDSA signature of either P-256 or secp256k1 curve,
+		// or an Ed25519 signature.
 		ecdsaSignature := signature.Signature
 
 		// check if the signature could be a P-256 signature
@@ -303,6 +304,15 @@ func (v *TransactionValidator) checkSignatureFormat(tx *flow.TransactionBody) er
 			continue
 		}
 
+		// check if the signature could be an Ed25519 signature
+		valid, err = crypto.SignatureFormatCheck(crypto.Ed25519, ecdsaSignature)
+		if err != nil {
+			return fmt.Errorf("could not check the signature format (%s): %w", signature, err)
+		}
+		if valid {
+			continue
+		}
+
 		return InvalidSignatureError{Signature: signature}
 	}
 
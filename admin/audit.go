@@ -0,0 +1,141 @@
+package admin
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// DefaultAuditLogRetained is the number of latest audit entries kept in memory
+// for the list-audit command.
+const DefaultAuditLogRetained = 1000
+
+// AuditEntry records an invocation of a command.
+type AuditEntry struct {
+	Time    time.Time   `json:"time"`
+	Caller  string      `json:"caller"`
+	Role    Role        `json:"role,omitempty"`
+	Command string      `json:"command"`
+	Data    interface{} `json:"data,omitempty"`
+	// Job is the ID of the job the command ran as, if any.
+	Job string `json:"job,omitempty"`
+	// Outcome is the gRPC status code of the invocation, OK if it succeeded.
+	Outcome  string        `json:"outcome"`
+	Error    string        `json:"error,omitempty"`
+	Duration time.Duration `json:"duration"`
+}
+
+// AuditLog is the audit log of the command invocations. The latest entries are
+// kept in memory, and all entries are appended to a file if one is given.
+type AuditLog struct {
+	mu       sync.Mutex
+	file     *os.File
+	entries  []AuditEntry
+	retained int
+}
+
+// NewAuditLog returns an audit log appending to the given file, which is
+// created if it doesn't exist. The latest entries already in the file are
+// loaded, so they are listed after a restart. If the path is empty, the
+// entries are only kept in memory.
+func NewAuditLog(path string, retained int) (*AuditLog, error) {
+	a := &AuditLog{
+		retained: retained,
+	}
+	if path == "" {
+		return a, nil
+	}
+
+	err := a.load(path)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("could not open audit log: %w", err)
+	}
+	a.file = file
+	return a, nil
+}
+
+// load reads the latest entries of the given audit log file, if it exists.
+func (a *AuditLog) load(path string) error {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("could not open audit log: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var entry AuditEntry
+		err := json.Unmarshal(scanner.Bytes(), &entry)
+		if err != nil {
+			// an interrupted write leaves a partial last line
+			continue
+		}
+		a.retain(entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("could not read audit log: %w", err)
+	}
+	return nil
+}
+
+// Append records the given entry.
+func (a *AuditLog) Append(entry AuditEntry) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.retain(entry)
+
+	if a.file == nil {
+		return nil
+	}
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("could not encode audit entry: %w", err)
+	}
+	_, err = a.file.Write(append(line, '\n'))
+	if err != nil {
+		return fmt.Errorf("could not write audit entry: %w", err)
+	}
+	return nil
+}
+
+// retain keeps the entry in memory, dropping the oldest one beyond the limit.
+func (a *AuditLog) retain(entry AuditEntry) {
+	a.entries = append(a.entries, entry)
+	if len(a.entries) > a.retained {
+		a.entries = a.entries[len(a.entries)-a.retained:]
+	}
+}
+
+// Latest returns the latest n entries retained in memory, oldest first.
+func (a *AuditLog) Latest(n int) []AuditEntry {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if n > len(a.entries) {
+		n = len(a.entries)
+	}
+	latest := make([]AuditEntry, n)
+	copy(latest, a.entries[len(a.entries)-n:])
+	return latest
+}
+
+// Close closes the audit log file.
+func (a *AuditLog) Close() error {
+	if a.file == nil {
+		return nil
+	}
+	return a.file.Close()
+}
@@ -0,0 +1,220 @@
+package admin
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// Permission is the permission required to run a command.
+type Permission int
+
+const (
+	// PermissionMutating is required by commands which change the state of the
+	// node. It is the permission of commands which don't register any.
+	PermissionMutating Permission = iota
+	// PermissionReadOnly is required by commands which only read the state of
+	// the node.
+	PermissionReadOnly
+)
+
+func (p Permission) String() string {
+	switch p {
+	case PermissionMutating:
+		return "mutating"
+	case PermissionReadOnly:
+		return "read-only"
+	default:
+		return fmt.Sprintf("unknown permission %d", int(p))
+	}
+}
+
+// Role is the role of a caller of the admin server.
+type Role string
+
+const (
+	// RoleReader can run read-only commands.
+	RoleReader Role = "reader"
+	// RoleOperator can run all commands.
+	RoleOperator Role = "operator"
+)
+
+// Allows returns whether the role can run commands requiring the given permission.
+func (r Role) Allows(p Permission) bool {
+	switch r {
+	case RoleOperator:
+		return true
+	case RoleReader:
+		return p == PermissionReadOnly
+	default:
+		return false
+	}
+}
+
+func (r Role) valid() bool {
+	return r == RoleReader || r == RoleOperator
+}
+
+// Caller is an authenticated caller of the admin server.
+type Caller struct {
+	Name string
+	Role Role
+}
+
+// anonymousCaller is the caller of all commands when authentication is disabled.
+var anonymousCaller = &Caller{Name: "anonymous", Role: RoleOperator}
+
+// AuthConfig maps the identities of callers to their roles.
+type AuthConfig struct {
+	// Tokens are the bearer tokens accepted in the Authorization header.
+	Tokens []TokenConfig `json:"tokens"`
+	// Clients are the mTLS client certificates, identified by the common name
+	// of their subject. They are only accepted when mutual TLS is enabled.
+	Clients []ClientConfig `json:"clients"`
+}
+
+// TokenConfig is a bearer token, only its hex-encoded SHA-256 hash is configured.
+type TokenConfig struct {
+	Name   string `json:"name"`
+	SHA256 string `json:"sha256"`
+	Role   Role   `json:"role"`
+}
+
+// ClientConfig is an mTLS client certificate.
+type ClientConfig struct {
+	CommonName string `json:"common_name"`
+	Role       Role   `json:"role"`
+}
+
+// LoadAuthConfig reads the JSON encoded auth config from the given file.
+func LoadAuthConfig(path string) (*AuthConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read admin auth config: %w", err)
+	}
+	var config AuthConfig
+	err = json.Unmarshal(data, &config)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode admin auth config: %w", err)
+	}
+	return &config, nil
+}
+
+// Authenticator identifies the callers of the admin server from their bearer
+// token or mTLS client certificate.
+type Authenticator struct {
+	tokens  map[[sha256.Size]byte]*Caller
+	clients map[string]*Caller
+}
+
+// NewAuthenticator returns an authenticator for the callers of the given config.
+func NewAuthenticator(config *AuthConfig) (*Authenticator, error) {
+	a := &Authenticator{
+		tokens:  make(map[[sha256.Size]byte]*Caller),
+		clients: make(map[string]*Caller),
+	}
+
+	for _, token := range config.Tokens {
+		if token.Name == "" {
+			return nil, fmt.Errorf("token has no name")
+		}
+		if !token.Role.valid() {
+			return nil, fmt.Errorf("token %s has invalid role %q", token.Name, token.Role)
+		}
+		hash, err := hex.DecodeString(token.SHA256)
+		if err != nil || len(hash) != sha256.Size {
+			return nil, fmt.Errorf("token %s has invalid sha256 hash", token.Name)
+		}
+		var key [sha256.Size]byte
+		copy(key[:], hash)
+		if _, ok := a.tokens[key]; ok {
+			return nil, fmt.Errorf("token %s is configured twice", token.Name)
+		}
+		a.tokens[key] = &Caller{Name: "token:" + token.Name, Role: token.Role}
+	}
+
+	for _, client := range config.Clients {
+		if client.CommonName == "" {
+			return nil, fmt.Errorf("client has no common name")
+		}
+		if !client.Role.valid() {
+			return nil, fmt.Errorf("client %s has invalid role %q", client.CommonName, client.Role)
+		}
+		if _, ok := a.clients[client.CommonName]; ok {
+			return nil, fmt.Errorf("client %s is configured twice", client.CommonName)
+		}
+		a.clients[client.CommonName] = &Caller{Name: "client:" + client.CommonName, Role: client.Role}
+	}
+
+	return a, nil
+}
+
+// authenticate returns the caller of the given request, or nil if it is not
+// authenticated. A bearer token takes precedence over the client certificate.
+func (a *Authenticator) authenticate(r *http.Request) *Caller {
+	if header := r.Header.Get("Authorization"); header != "" {
+		token := strings.TrimPrefix(header, "Bearer ")
+		if token == header {
+			return nil
+		}
+		// tokens are looked up by hash, so the lookup leaks nothing about them
+		return a.tokens[sha256.Sum256([]byte(token))]
+	}
+
+	// the chain of the client certificate is verified by the TLS server
+	if r.TLS != nil && len(r.TLS.VerifiedChains) > 0 {
+		return a.clients[r.TLS.VerifiedChains[0][0].Subject.CommonName]
+	}
+
+	return nil
+}
+
+type callerKey struct{}
+
+// withCaller wraps the given HTTP handler, authenticating the caller of each request.
+func (a *Authenticator) withCaller(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		caller := a.authenticate(r)
+		if caller != nil {
+			r = r.WithContext(context.WithValue(r.Context(), callerKey{}, caller))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// metadata keys of the caller, set by the HTTP gateway for the gRPC server
+const (
+	callerNameMetadata = "admin-caller-name"
+	callerRoleMetadata = "admin-caller-role"
+)
+
+// callerMetadata forwards the authenticated caller of an HTTP request to the gRPC server.
+func callerMetadata(_ context.Context, r *http.Request) metadata.MD {
+	caller, ok := r.Context().Value(callerKey{}).(*Caller)
+	if !ok {
+		return nil
+	}
+	return metadata.Pairs(callerNameMetadata, caller.Name, callerRoleMetadata, string(caller.Role))
+}
+
+// callerFromMetadata returns the caller forwarded by the HTTP gateway, or nil
+// if the request is not authenticated.
+func callerFromMetadata(ctx context.Context) *Caller {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil
+	}
+	names := md.Get(callerNameMetadata)
+	roles := md.Get(callerRoleMetadata)
+	if len(names) != 1 || len(roles) != 1 {
+		return nil
+	}
+	return &Caller{Name: names[0], Role: Role(roles[0])}
+}
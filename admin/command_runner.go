@@ -3,11 +3,14 @@ package admin
 import (
 	"context"
 	"crypto/tls"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
 	"net"
 	"net/http"
 	"os"
+	"strings"
 	"sync"
 	"time"
 
@@ -47,24 +50,94 @@ func WithGRPCAddress(address string) CommandRunnerOption {
 	}
 }
 
+// WithAuthenticator requires the callers of the HTTP server to authenticate,
+// and only lets them run the commands their role allows.
+func WithAuthenticator(authenticator *Authenticator) CommandRunnerOption {
+	return func(r *CommandRunner) {
+		r.authenticator = authenticator
+	}
+}
+
+// WithAuditLog records the command invocations in the given audit log, instead
+// of an audit log only kept in memory.
+func WithAuditLog(auditLog *AuditLog) CommandRunnerOption {
+	return func(r *CommandRunner) {
+		r.auditLog = auditLog
+	}
+}
+
+// WithJobsRetained sets the number of finished jobs kept for the job-status and
+// job-result commands, DefaultJobsRetained by default.
+func WithJobsRetained(retained int) CommandRunnerOption {
+	return func(r *CommandRunner) {
+		r.jobsRetained = retained
+	}
+}
+
+// jobStartCommand runs the given command as a job. It is handled by the command
+// runner itself, as it runs the command with the permission of that command.
+const jobStartCommand = "job-start"
+
 type CommandRunnerBootstrapper struct {
-	handlers   map[string]CommandHandler
-	validators map[string]CommandValidator
+	handlers    map[string]CommandHandler
+	validators  map[string]CommandValidator
+	permissions map[string]Permission
+	jobs        map[string]bool
 }
 
 func NewCommandRunnerBootstrapper() *CommandRunnerBootstrapper {
 	return &CommandRunnerBootstrapper{
-		handlers:   make(map[string]CommandHandler),
-		validators: make(map[string]CommandValidator),
+		handlers:    make(map[string]CommandHandler),
+		validators:  make(map[string]CommandValidator),
+		permissions: make(map[string]Permission),
+		jobs:        make(map[string]bool),
 	}
 }
 
 func (r *CommandRunnerBootstrapper) Bootstrap(logger zerolog.Logger, bindAddress string, opts ...CommandRunnerOption) *CommandRunner {
 	handlers := make(map[string]CommandHandler)
-	commands := make([]interface{}, 0, len(r.handlers))
+	commands := []interface{}{jobStartCommand}
 	r.RegisterHandler("list-commands", func(ctx context.Context, req *CommandRequest) (interface{}, error) {
 		return commands, nil
 	})
+	r.RegisterPermission("list-commands", PermissionReadOnly)
+
+	var commandRunner *CommandRunner
+	r.RegisterHandler("list-audit", func(ctx context.Context, req *CommandRequest) (interface{}, error) {
+		return toJSONValue(commandRunner.auditLog.Latest(req.ValidatorData.(int)))
+	})
+	r.RegisterValidator("list-audit", validateListAudit)
+	r.RegisterPermission("list-audit", PermissionReadOnly)
+
+	r.RegisterHandler("job-status", func(ctx context.Context, req *CommandRequest) (interface{}, error) {
+		id := req.ValidatorData.(string)
+		if id == "" {
+			return toJSONValue(commandRunner.jobs.list())
+		}
+		j, err := commandRunner.jobs.get(id)
+		if err != nil {
+			return nil, err
+		}
+		return toJSONValue(j.getStatus())
+	})
+	r.RegisterValidator("job-status", validateJobStatus)
+	r.RegisterPermission("job-status", PermissionReadOnly)
+
+	r.RegisterHandler("job-result", func(ctx context.Context, req *CommandRequest) (interface{}, error) {
+		return commandRunner.jobs.result(req.ValidatorData.(string))
+	})
+	r.RegisterValidator("job-result", validateJobID)
+	r.RegisterPermission("job-result", PermissionReadOnly)
+
+	r.RegisterHandler("job-cancel", func(ctx context.Context, req *CommandRequest) (interface{}, error) {
+		jobStatus, err := commandRunner.jobs.cancel(req.ValidatorData.(string))
+		if err != nil {
+			return nil, err
+		}
+		return toJSONValue(jobStatus)
+	})
+	r.RegisterValidator("job-cancel", validateJobID)
+
 	for command, handler := range r.handlers {
 		handlers[command] = handler
 		commands = append(commands, command)
@@ -75,9 +148,22 @@ func (r *CommandRunnerBootstrapper) Bootstrap(logger zerolog.Logger, bindAddress
 		validators[command] = validator
 	}
 
-	commandRunner := &CommandRunner{
+	permissions := make(map[string]Permission)
+	for command, permission := range r.permissions {
+		permissions[command] = permission
+	}
+
+	jobCommands := make(map[string]bool)
+	for command := range r.jobs {
+		jobCommands[command] = true
+	}
+
+	commandRunner = &CommandRunner{
 		handlers:         handlers,
 		validators:       validators,
+		permissions:      permissions,
+		jobCommands:      jobCommands,
+		jobsRetained:     DefaultJobsRetained,
 		grpcAddress:      fmt.Sprintf("%s/flow-node-admin.sock", os.TempDir()),
 		httpAddress:      bindAddress,
 		logger:           logger.With().Str("admin", "command_runner").Logger(),
@@ -88,6 +174,15 @@ func (r *CommandRunnerBootstrapper) Bootstrap(logger zerolog.Logger, bindAddress
 		opt(commandRunner)
 	}
 
+	if commandRunner.auditLog == nil {
+		// an audit log without file never fails to open
+		commandRunner.auditLog, _ = NewAuditLog("", DefaultAuditLogRetained)
+	}
+	commandRunner.jobs = newJobs(commandRunner.jobsRetained)
+	if commandRunner.authenticator == nil {
+		commandRunner.logger.Warn().Msg("admin server authentication is disabled, all callers can run all commands")
+	}
+
 	return commandRunner
 }
 
@@ -107,13 +202,39 @@ func (r *CommandRunnerBootstrapper) RegisterValidator(command string, validator
 	return true
 }
 
+// RegisterPermission sets the permission required to run the command. Commands
+// without registered permission require PermissionMutating.
+func (r *CommandRunnerBootstrapper) RegisterPermission(command string, permission Permission) bool {
+	if _, ok := r.permissions[command]; ok {
+		return false
+	}
+	r.permissions[command] = permission
+	return true
+}
+
+// RegisterJob makes the command always run as a job, returning the ID of the job
+// at once. Other commands only run as a job when started with job-start.
+func (r *CommandRunnerBootstrapper) RegisterJob(command string) bool {
+	if _, ok := r.jobs[command]; ok {
+		return false
+	}
+	r.jobs[command] = true
+	return true
+}
+
 type CommandRunner struct {
-	handlers    map[string]CommandHandler
-	validators  map[string]CommandValidator
-	grpcAddress string
-	httpAddress string
-	tlsConfig   *tls.Config
-	logger      zerolog.Logger
+	handlers      map[string]CommandHandler
+	validators    map[string]CommandValidator
+	permissions   map[string]Permission
+	jobCommands   map[string]bool
+	jobs          *jobs
+	jobsRetained  int
+	grpcAddress   string
+	httpAddress   string
+	tlsConfig     *tls.Config
+	authenticator *Authenticator
+	auditLog      *AuditLog
+	logger        zerolog.Logger
 
 	// wait for worker routines to be ready
 	workersStarted sync.WaitGroup
@@ -133,6 +254,13 @@ func (r *CommandRunner) getValidator(command string) CommandValidator {
 	return r.validators[command]
 }
 
+func (r *CommandRunner) getPermission(command string) Permission {
+	if permission, ok := r.permissions[command]; ok {
+		return permission
+	}
+	return PermissionMutating
+}
+
 func (r *CommandRunner) Start(ctx irrecoverable.SignalerContext) {
 	if err := r.runAdminServer(ctx); err != nil {
 		ctx.Throw(fmt.Errorf("failed to start admin server: %w", err))
@@ -174,11 +302,21 @@ func (r *CommandRunner) runAdminServer(ctx irrecoverable.SignalerContext) error
 
 	r.logger.Info().Msg("admin server starting up")
 
+	// jobs run in the background until they finish or the node shuts down
+	r.jobs.run(ctx)
+
 	listener, err := net.Listen("unix", r.grpcAddress)
 	if err != nil {
 		return fmt.Errorf("failed to listen on admin server address: %w", err)
 	}
 
+	// the gRPC server trusts the caller forwarded by the HTTP gateway, so only
+	// the user of the node may connect to it
+	err = os.Chmod(r.grpcAddress, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to restrict access to admin server socket: %w", err)
+	}
+
 	grpcServer := grpc.NewServer()
 	pb.RegisterAdminServer(grpcServer, NewAdminServer(r))
 
@@ -195,7 +333,10 @@ func (r *CommandRunner) runAdminServer(ctx irrecoverable.SignalerContext) error
 	}()
 
 	// Register gRPC server endpoint
-	mux := runtime.NewServeMux()
+	mux := runtime.NewServeMux(
+		runtime.WithMetadata(callerMetadata),
+		runtime.WithIncomingHeaderMatcher(headerMatcher),
+	)
 	opts := []grpc.DialOption{grpc.WithInsecure()} //nolint:staticcheck
 
 	err = pb.RegisterAdminHandlerFromEndpoint(ctx, mux, "unix:///"+r.grpcAddress, opts)
@@ -203,9 +344,14 @@ func (r *CommandRunner) runAdminServer(ctx irrecoverable.SignalerContext) error
 		return fmt.Errorf("failed to register http handlers for admin service: %w", err)
 	}
 
+	var handler http.Handler = mux
+	if r.authenticator != nil {
+		handler = r.authenticator.withCaller(mux)
+	}
+
 	httpServer := &http.Server{
 		Addr:      r.httpAddress,
-		Handler:   mux,
+		Handler:   handler,
 		TLSConfig: r.tlsConfig,
 	}
 
@@ -249,13 +395,47 @@ func (r *CommandRunner) runAdminServer(ctx irrecoverable.SignalerContext) error
 				ctx.Throw(err)
 			}
 		}
+
+		r.jobs.wait()
 	}()
 
 	return nil
 }
 
-func (r *CommandRunner) runCommand(ctx context.Context, command string, data interface{}) (interface{}, error) {
-	r.logger.Info().Str("command", command).Msg("received new command")
+func (r *CommandRunner) runCommand(ctx context.Context, caller *Caller, command string, data interface{}) (result interface{}, err error) {
+	if r.authenticator == nil {
+		caller = anonymousCaller
+	}
+
+	r.logger.Info().Str("command", command).Str("caller", callerName(caller)).Msg("received new command")
+
+	start := time.Now()
+	var jobID string
+	defer func() {
+		r.audit(caller, command, data, jobID, start, err)
+	}()
+
+	if caller == nil {
+		return nil, status.Error(codes.Unauthenticated, "caller is not authenticated")
+	}
+
+	runAsJob := r.jobCommands[command]
+	if command == jobStartCommand {
+		jobCommand, jobData, parseErr := parseJobStart(data)
+		if parseErr != nil {
+			return nil, status.Error(codes.InvalidArgument, parseErr.Error())
+		}
+		command, data, runAsJob = jobCommand, jobData, true
+	}
+
+	handler := r.getHandler(command)
+	if handler == nil {
+		return nil, status.Error(codes.Unimplemented, "invalid command")
+	}
+
+	if permission := r.getPermission(command); !caller.Role.Allows(permission) {
+		return nil, status.Errorf(codes.PermissionDenied, "role %s cannot run %s command %s", caller.Role, permission, command)
+	}
 
 	req := &CommandRequest{Data: data}
 
@@ -265,23 +445,121 @@ func (r *CommandRunner) runCommand(ctx context.Context, command string, data int
 		}
 	}
 
-	var handleResult interface{}
-	var handleErr error
+	if !runAsJob {
+		return r.handle(ctx, handler, req)
+	}
 
-	if handler := r.getHandler(command); handler != nil {
-		if handleResult, handleErr = handler(ctx, req); handleErr != nil {
-			if errors.Is(handleErr, context.Canceled) {
-				return nil, status.Error(codes.Canceled, "client canceled")
-			} else if errors.Is(handleErr, context.DeadlineExceeded) {
-				return nil, status.Error(codes.DeadlineExceeded, "request timed out")
-			} else {
-				s, _ := status.FromError(handleErr)
-				return nil, s.Err()
-			}
+	jobStart := time.Now()
+	jobID, err = r.jobs.start(command, caller, func(ctx context.Context) (interface{}, error) {
+		result, err := r.handle(ctx, handler, req)
+		if status.Code(err) == codes.Canceled {
+			return nil, status.Error(codes.Canceled, "job canceled")
+		}
+		return result, err
+	}, func(id string, jobErr error) {
+		r.audit(caller, command, data, id, jobStart, jobErr)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{"job_id": jobID}, nil
+}
+
+// handle runs the handler of a validated command, converting its error to a status.
+func (r *CommandRunner) handle(ctx context.Context, handler CommandHandler, req *CommandRequest) (interface{}, error) {
+	handleResult, handleErr := handler(ctx, req)
+	if handleErr != nil {
+		if errors.Is(handleErr, context.Canceled) {
+			return nil, status.Error(codes.Canceled, "client canceled")
+		} else if errors.Is(handleErr, context.DeadlineExceeded) {
+			return nil, status.Error(codes.DeadlineExceeded, "request timed out")
+		} else {
+			s, _ := status.FromError(handleErr)
+			return nil, s.Err()
 		}
-	} else {
-		return nil, status.Error(codes.Unimplemented, "invalid command")
 	}
 
 	return handleResult, nil
 }
+
+// audit records the invocation of a command in the audit log. Commands run as a
+// job are recorded both when the job starts and when it finishes.
+func (r *CommandRunner) audit(caller *Caller, command string, data interface{}, jobID string, start time.Time, err error) {
+	entry := AuditEntry{
+		Time:     start.UTC(),
+		Caller:   callerName(caller),
+		Command:  command,
+		Data:     data,
+		Job:      jobID,
+		Outcome:  status.Code(err).String(),
+		Duration: time.Since(start),
+	}
+	if caller != nil {
+		entry.Role = caller.Role
+	}
+	if err != nil {
+		entry.Error = status.Convert(err).Message()
+	}
+
+	r.logger.Info().
+		Str("command", entry.Command).
+		Str("caller", entry.Caller).
+		Str("job", entry.Job).
+		Str("outcome", entry.Outcome).
+		Dur("duration", entry.Duration).
+		Msg("command completed")
+
+	if err := r.auditLog.Append(entry); err != nil {
+		r.logger.Err(err).Str("command", command).Msg("failed to append to audit log")
+	}
+}
+
+func callerName(caller *Caller) string {
+	if caller == nil {
+		return "unauthenticated"
+	}
+	return caller.Name
+}
+
+// headerMatcher forwards the HTTP headers as the default gateway matcher, except
+// those which would let callers forge their identity.
+func headerMatcher(key string) (string, bool) {
+	forwarded, ok := runtime.DefaultHeaderMatcher(key)
+	if !ok {
+		return "", false
+	}
+	switch strings.ToLower(forwarded) {
+	case callerNameMetadata, callerRoleMetadata:
+		return "", false
+	}
+	return forwarded, true
+}
+
+// validateListAudit parses the number of latest audit entries to list.
+func validateListAudit(req *CommandRequest) error {
+	n := 100
+	if input, ok := req.Data.(map[string]interface{}); ok {
+		if value, ok := input["n"]; ok {
+			number, ok := value.(float64)
+			if !ok || number < 1 || number != math.Trunc(number) {
+				return fmt.Errorf("invalid value for \"n\": %v", value)
+			}
+			n = int(number)
+		}
+	}
+	req.ValidatorData = n
+	return nil
+}
+
+// toJSONValue converts the given value to the JSON values the command output
+// must only hold.
+func toJSONValue(v interface{}) (interface{}, error) {
+	bytes, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var value interface{}
+	err = json.Unmarshal(bytes, &value)
+	return value, err
+}
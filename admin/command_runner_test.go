@@ -6,9 +6,11 @@ import (
 	"crypto/ecdsa"
 	"crypto/elliptic"
 	"crypto/rand"
+	"crypto/sha256"
 	"crypto/tls"
 	"crypto/x509"
 	"crypto/x509/pkix"
+	"encoding/hex"
 	"encoding/json"
 	"encoding/pem"
 	"errors"
@@ -16,6 +18,7 @@ import (
 	"math/big"
 	"net"
 	"net/http"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -385,6 +388,7 @@ func generateCerts(t *testing.T) (tls.Certificate, *x509.CertPool, tls.Certifica
 		SerialNumber: big.NewInt(3),
 		Subject: pkix.Name{
 			Organization: []string{"Dapper Labs, Inc."},
+			CommonName:   "admin-client",
 		},
 		NotBefore:   time.Now(),
 		NotAfter:    time.Now().Add(time.Hour * 24 * 180),
@@ -413,8 +417,10 @@ func generateCerts(t *testing.T) (tls.Certificate, *x509.CertPool, tls.Certifica
 	require.NoError(t, err)
 	clientCert.Leaf, err = x509.ParseCertificate(clientCert.Certificate[0])
 	require.NoError(t, err)
+	caCert, err := x509.ParseCertificate(caBytes)
+	require.NoError(t, err)
 	clientCertPool := x509.NewCertPool()
-	clientCertPool.AddCert(clientCert.Leaf)
+	clientCertPool.AddCert(caCert)
 
 	return serverCert, serverCertPool, clientCert, clientCertPool
 }
@@ -464,3 +470,404 @@ func (suite *CommandRunnerSuite) TestTLS() {
 	suite.True(called)
 	suite.Equal("200 OK", resp.Status)
 }
+
+// tokenConfig returns the config of the given bearer token.
+func tokenConfig(name string, token string, role Role) TokenConfig {
+	hash := sha256.Sum256([]byte(token))
+	return TokenConfig{
+		Name:   name,
+		SHA256: hex.EncodeToString(hash[:]),
+		Role:   role,
+	}
+}
+
+// post runs the command through the HTTP server with the given bearer token and
+// extra headers, and returns the response status code.
+func (suite *CommandRunnerSuite) post(client *http.Client, url string, command string, token string, headers map[string]string) int {
+	reqBody := bytes.NewBuffer([]byte(fmt.Sprintf(`{"commandName": "%s"}`, command)))
+	req, err := http.NewRequest(http.MethodPost, url, reqBody)
+	require.NoError(suite.T(), err)
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := client.Do(req)
+	require.NoError(suite.T(), err)
+	defer resp.Body.Close()
+	return resp.StatusCode
+}
+
+func (suite *CommandRunnerSuite) TestAuthentication() {
+	suite.bootstrapper.RegisterHandler("read", func(ctx context.Context, req *CommandRequest) (interface{}, error) {
+		return "ok", nil
+	})
+	suite.bootstrapper.RegisterPermission("read", PermissionReadOnly)
+	suite.bootstrapper.RegisterHandler("mutate", func(ctx context.Context, req *CommandRequest) (interface{}, error) {
+		return "ok", nil
+	})
+
+	authenticator, err := NewAuthenticator(&AuthConfig{
+		Tokens: []TokenConfig{
+			tokenConfig("reader", "reader-token", RoleReader),
+			tokenConfig("operator", "operator-token", RoleOperator),
+		},
+	})
+	suite.Require().NoError(err)
+
+	suite.SetupCommandRunner(WithAuthenticator(authenticator))
+
+	url := fmt.Sprintf("http://%s/admin/run_command", suite.httpAddress)
+	client := http.DefaultClient
+
+	suite.Run("unauthenticated", func() {
+		suite.Equal(http.StatusUnauthorized, suite.post(client, url, "read", "", nil))
+		suite.Equal(http.StatusUnauthorized, suite.post(client, url, "read", "unknown-token", nil))
+	})
+
+	suite.Run("forged caller", func() {
+		headers := map[string]string{
+			"Grpc-Metadata-Admin-Caller-Name": "operator",
+			"Grpc-Metadata-Admin-Caller-Role": string(RoleOperator),
+		}
+		suite.Equal(http.StatusUnauthorized, suite.post(client, url, "mutate", "", headers))
+		suite.Equal(http.StatusForbidden, suite.post(client, url, "mutate", "reader-token", headers))
+	})
+
+	suite.Run("reader", func() {
+		suite.Equal(http.StatusOK, suite.post(client, url, "read", "reader-token", nil))
+		suite.Equal(http.StatusOK, suite.post(client, url, "list-commands", "reader-token", nil))
+		suite.Equal(http.StatusForbidden, suite.post(client, url, "mutate", "reader-token", nil))
+	})
+
+	suite.Run("operator", func() {
+		suite.Equal(http.StatusOK, suite.post(client, url, "read", "operator-token", nil))
+		suite.Equal(http.StatusOK, suite.post(client, url, "mutate", "operator-token", nil))
+	})
+}
+
+func (suite *CommandRunnerSuite) TestClientCertificateAuthentication() {
+	suite.bootstrapper.RegisterHandler("mutate", func(ctx context.Context, req *CommandRequest) (interface{}, error) {
+		return "ok", nil
+	})
+
+	serverCert, serverCertPool, clientCert, clientCertPool := generateCerts(suite.T())
+	serverConfig := &tls.Config{
+		MinVersion:   tls.VersionTLS13,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		Certificates: []tls.Certificate{serverCert},
+		ClientCAs:    clientCertPool,
+	}
+	clientConfig := &tls.Config{
+		MinVersion:   tls.VersionTLS13,
+		Certificates: []tls.Certificate{clientCert},
+		RootCAs:      serverCertPool,
+	}
+
+	authenticator, err := NewAuthenticator(&AuthConfig{
+		Clients: []ClientConfig{{CommonName: "admin-client", Role: RoleReader}},
+		Tokens:  []TokenConfig{tokenConfig("operator", "operator-token", RoleOperator)},
+	})
+	suite.Require().NoError(err)
+
+	suite.SetupCommandRunner(WithTLS(serverConfig), WithAuthenticator(authenticator))
+
+	client := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: clientConfig,
+		},
+	}
+	url := fmt.Sprintf("https://%s/admin/run_command", suite.httpAddress)
+
+	// the client certificate maps to the reader role
+	suite.Equal(http.StatusOK, suite.post(client, url, "list-commands", "", nil))
+	suite.Equal(http.StatusForbidden, suite.post(client, url, "mutate", "", nil))
+
+	// a bearer token takes precedence over the client certificate
+	suite.Equal(http.StatusOK, suite.post(client, url, "mutate", "operator-token", nil))
+}
+
+func (suite *CommandRunnerSuite) TestAuditLog() {
+	handlerErr := errors.New("handler error")
+	suite.bootstrapper.RegisterHandler("foo", func(ctx context.Context, req *CommandRequest) (interface{}, error) {
+		return "ok", nil
+	})
+	suite.bootstrapper.RegisterHandler("fail", func(ctx context.Context, req *CommandRequest) (interface{}, error) {
+		return nil, handlerErr
+	})
+
+	path := filepath.Join(suite.T().TempDir(), "audit.jsonl")
+	auditLog, err := NewAuditLog(path, DefaultAuditLogRetained)
+	suite.Require().NoError(err)
+
+	suite.SetupCommandRunner(WithAuditLog(auditLog))
+
+	url := fmt.Sprintf("http://%s/admin/run_command", suite.httpAddress)
+	suite.Equal(http.StatusOK, suite.post(http.DefaultClient, url, "foo", "", nil))
+	suite.NotEqual(http.StatusOK, suite.post(http.DefaultClient, url, "fail", "", nil))
+
+	reqBody := bytes.NewBuffer([]byte(`{"commandName": "list-audit", "data": {"n": 2}}`))
+	resp, err := http.Post(url, "application/json", reqBody)
+	suite.Require().NoError(err)
+	defer resp.Body.Close()
+	suite.Equal(http.StatusOK, resp.StatusCode)
+
+	var response struct {
+		Output []AuditEntry `json:"output"`
+	}
+	suite.Require().NoError(json.NewDecoder(resp.Body).Decode(&response))
+	suite.Require().Len(response.Output, 2)
+	suite.Equal("foo", response.Output[0].Command)
+	suite.Equal("anonymous", response.Output[0].Caller)
+	suite.Equal(codes.OK.String(), response.Output[0].Outcome)
+	suite.Equal("fail", response.Output[1].Command)
+	suite.Equal(codes.Unknown.String(), response.Output[1].Outcome)
+	suite.Equal(handlerErr.Error(), response.Output[1].Error)
+
+	// the entries are loaded from the file after a restart
+	reopened, err := NewAuditLog(path, DefaultAuditLogRetained)
+	suite.Require().NoError(err)
+	defer reopened.Close()
+	entries := reopened.Latest(10)
+	suite.Require().Len(entries, 3)
+	suite.Equal("list-audit", entries[2].Command)
+}
+
+func TestAuditLogRetained(t *testing.T) {
+	auditLog, err := NewAuditLog("", 2)
+	require.NoError(t, err)
+
+	for _, command := range []string{"a", "b", "c"} {
+		require.NoError(t, auditLog.Append(AuditEntry{Command: command}))
+	}
+
+	entries := auditLog.Latest(10)
+	require.Len(t, entries, 2)
+	require.Equal(t, "b", entries[0].Command)
+	require.Equal(t, "c", entries[1].Command)
+}
+
+func TestNewAuthenticatorInvalidConfig(t *testing.T) {
+	_, err := NewAuthenticator(&AuthConfig{
+		Tokens: []TokenConfig{tokenConfig("admin", "token", Role("root"))},
+	})
+	require.Error(t, err)
+
+	_, err = NewAuthenticator(&AuthConfig{
+		Tokens: []TokenConfig{{Name: "admin", SHA256: "not hex", Role: RoleOperator}},
+	})
+	require.Error(t, err)
+
+	_, err = NewAuthenticator(&AuthConfig{
+		Clients: []ClientConfig{
+			{CommonName: "admin", Role: RoleReader},
+			{CommonName: "admin", Role: RoleOperator},
+		},
+	})
+	require.Error(t, err)
+}
+
+// run runs the given command through the gRPC server, and returns its output.
+func (suite *CommandRunnerSuite) run(command string, data interface{}) (interface{}, error) {
+	val, err := structpb.NewValue(data)
+	suite.Require().NoError(err)
+
+	resp, err := suite.client.RunCommand(context.Background(), &pb.RunCommandRequest{
+		CommandName: command,
+		Data:        val,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetOutput().AsInterface(), nil
+}
+
+// startJob runs the given command, which must run as a job, and returns the ID of its job.
+func (suite *CommandRunnerSuite) startJob(command string, data interface{}) string {
+	output, err := suite.run(command, data)
+	suite.Require().NoError(err)
+	id, ok := output.(map[string]interface{})["job_id"].(string)
+	suite.Require().True(ok)
+	return id
+}
+
+// jobStatus returns the status of the job with the given ID.
+func (suite *CommandRunnerSuite) jobStatus(id string) JobStatus {
+	output, err := suite.run("job-status", map[string]interface{}{"id": id})
+	suite.Require().NoError(err)
+
+	bytes, err := json.Marshal(output)
+	suite.Require().NoError(err)
+	var jobStatus JobStatus
+	suite.Require().NoError(json.Unmarshal(bytes, &jobStatus))
+	return jobStatus
+}
+
+func (suite *CommandRunnerSuite) requireJobState(id string, state JobState) JobStatus {
+	var jobStatus JobStatus
+	suite.Require().Eventually(func() bool {
+		jobStatus = suite.jobStatus(id)
+		return jobStatus.State == state
+	}, 5*time.Second, 10*time.Millisecond)
+	return jobStatus
+}
+
+func (suite *CommandRunnerSuite) TestJob() {
+	proceed := make(chan struct{})
+	suite.bootstrapper.RegisterHandler("slow", func(ctx context.Context, req *CommandRequest) (interface{}, error) {
+		ReportProgress(ctx, 1, 2)
+		<-proceed
+		ReportProgress(ctx, 2, 2)
+		return req.ValidatorData, nil
+	})
+	suite.bootstrapper.RegisterValidator("slow", func(req *CommandRequest) error {
+		input, ok := req.Data.(map[string]interface{})
+		if !ok {
+			return errors.New("invalid data")
+		}
+		req.ValidatorData = input["value"]
+		return nil
+	})
+	suite.bootstrapper.RegisterJob("slow")
+
+	suite.SetupCommandRunner()
+
+	// the command is validated before the job starts
+	_, err := suite.run("slow", "invalid")
+	suite.Equal(codes.InvalidArgument, status.Code(err))
+
+	id := suite.startJob("slow", map[string]interface{}{"value": "done"})
+
+	jobStatus := suite.requireJobState(id, JobRunning)
+	suite.Require().Eventually(func() bool {
+		progress := suite.jobStatus(id).Progress
+		return progress != nil && progress.Completed == 1 && progress.Total == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	suite.Equal("slow", jobStatus.Command)
+	suite.Equal("anonymous", jobStatus.Caller)
+	suite.Nil(jobStatus.FinishedAt)
+
+	_, err = suite.run("job-result", map[string]interface{}{"id": id})
+	suite.Equal(codes.FailedPrecondition, status.Code(err))
+
+	close(proceed)
+
+	jobStatus = suite.requireJobState(id, JobSucceeded)
+	suite.NotNil(jobStatus.FinishedAt)
+	suite.Equal(&JobProgress{Completed: 2, Total: 2}, jobStatus.Progress)
+
+	result, err := suite.run("job-result", map[string]interface{}{"id": id})
+	suite.Require().NoError(err)
+	suite.Equal("done", result)
+
+	// the job is listed when no ID is given
+	output, err := suite.run("job-status", nil)
+	suite.Require().NoError(err)
+	suite.Len(output, 1)
+
+	// both the start and the end of the job are audited
+	entries := suite.runner.auditLog.Latest(DefaultAuditLogRetained)
+	var audited []AuditEntry
+	for _, entry := range entries {
+		if entry.Job == id {
+			audited = append(audited, entry)
+		}
+	}
+	suite.Require().Len(audited, 2)
+	suite.Equal("slow", audited[1].Command)
+	suite.Equal(codes.OK.String(), audited[1].Outcome)
+}
+
+func (suite *CommandRunnerSuite) TestJobStart() {
+	handlerErr := errors.New("handler error")
+	suite.bootstrapper.RegisterHandler("foo", func(ctx context.Context, req *CommandRequest) (interface{}, error) {
+		return "ok", nil
+	})
+	suite.bootstrapper.RegisterHandler("fail", func(ctx context.Context, req *CommandRequest) (interface{}, error) {
+		return nil, status.Error(codes.NotFound, handlerErr.Error())
+	})
+
+	suite.SetupCommandRunner()
+
+	id := suite.startJob(jobStartCommand, map[string]interface{}{"command": "foo"})
+	suite.requireJobState(id, JobSucceeded)
+	result, err := suite.run("job-result", map[string]interface{}{"id": id})
+	suite.Require().NoError(err)
+	suite.Equal("ok", result)
+
+	// the result of a failed job is its error
+	id = suite.startJob(jobStartCommand, map[string]interface{}{"command": "fail"})
+	jobStatus := suite.requireJobState(id, JobFailed)
+	suite.Equal(handlerErr.Error(), jobStatus.Error)
+	_, err = suite.run("job-result", map[string]interface{}{"id": id})
+	suite.Equal(codes.NotFound, status.Code(err))
+
+	_, err = suite.run(jobStartCommand, map[string]interface{}{"command": "unknown"})
+	suite.Equal(codes.Unimplemented, status.Code(err))
+	_, err = suite.run(jobStartCommand, map[string]interface{}{"command": jobStartCommand})
+	suite.Equal(codes.InvalidArgument, status.Code(err))
+	_, err = suite.run("job-status", map[string]interface{}{"id": "unknown"})
+	suite.Equal(codes.NotFound, status.Code(err))
+}
+
+func (suite *CommandRunnerSuite) TestJobCancel() {
+	suite.bootstrapper.RegisterHandler("wait", func(ctx context.Context, req *CommandRequest) (interface{}, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+	suite.bootstrapper.RegisterJob("wait")
+
+	suite.SetupCommandRunner()
+
+	id := suite.startJob("wait", nil)
+	suite.requireJobState(id, JobRunning)
+
+	_, err := suite.run("job-cancel", map[string]interface{}{"id": id})
+	suite.Require().NoError(err)
+	suite.requireJobState(id, JobCanceled)
+
+	_, err = suite.run("job-cancel", map[string]interface{}{"id": id})
+	suite.Equal(codes.FailedPrecondition, status.Code(err))
+	_, err = suite.run("job-result", map[string]interface{}{"id": id})
+	suite.Equal(codes.Canceled, status.Code(err))
+}
+
+func (suite *CommandRunnerSuite) TestJobsRetained() {
+	suite.bootstrapper.RegisterHandler("foo", func(ctx context.Context, req *CommandRequest) (interface{}, error) {
+		return "ok", nil
+	})
+	suite.bootstrapper.RegisterJob("foo")
+
+	suite.SetupCommandRunner(WithJobsRetained(1))
+
+	first := suite.startJob("foo", nil)
+	suite.requireJobState(first, JobSucceeded)
+	second := suite.startJob("foo", nil)
+	suite.requireJobState(second, JobSucceeded)
+
+	// only the latest finished job is retained
+	_, err := suite.run("job-status", map[string]interface{}{"id": first})
+	suite.Equal(codes.NotFound, status.Code(err))
+}
+
+func (suite *CommandRunnerSuite) TestJobCanceledOnShutdown() {
+	suite.bootstrapper.RegisterHandler("wait", func(ctx context.Context, req *CommandRequest) (interface{}, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+	suite.bootstrapper.RegisterJob("wait")
+
+	suite.SetupCommandRunner()
+
+	id := suite.startJob("wait", nil)
+	suite.requireJobState(id, JobRunning)
+	j, err := suite.runner.jobs.get(id)
+	suite.Require().NoError(err)
+
+	suite.cancel()
+	<-suite.runner.Done()
+	suite.Equal(JobCanceled, j.getStatus().State)
+}
@@ -10,3 +10,19 @@ type AdminCommand interface {
 	Handler(ctx context.Context, request *admin.CommandRequest) (interface{}, error)
 	Validator(request *admin.CommandRequest) error
 }
+
+// ReadOnlyAdminCommand is implemented by the admin commands which only read the
+// state of the node, so callers with the reader role can run them. All other
+// commands require the operator role.
+type ReadOnlyAdminCommand interface {
+	AdminCommand
+	ReadOnly()
+}
+
+// JobAdminCommand is implemented by the admin commands which take too long to
+// run within a request. They always run as a job, returning the ID of the job
+// at once, and their result is read with the job-result command.
+type JobAdminCommand interface {
+	AdminCommand
+	Job()
+}
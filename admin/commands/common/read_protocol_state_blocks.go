@@ -17,7 +17,7 @@ import (
 	"github.com/onflow/flow-go/storage"
 )
 
-var _ commands.AdminCommand = (*ReadProtocolStateBlocksCommand)(nil)
+var _ commands.ReadOnlyAdminCommand = (*ReadProtocolStateBlocksCommand)(nil)
 
 type requestType int
 
@@ -212,3 +212,5 @@ func NewReadProtocolStateBlocksCommand(state protocol.State, storage storage.Blo
 		storage,
 	}
 }
+
+func (r *ReadProtocolStateBlocksCommand) ReadOnly() {}
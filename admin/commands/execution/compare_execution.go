@@ -0,0 +1,97 @@
+package execution
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/admin/commands"
+	"github.com/onflow/flow-go/engine/execution/comparison"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+var _ commands.ReadOnlyAdminCommand = (*CompareExecutionCommand)(nil)
+
+type compareExecutionRequest struct {
+	blockID flow.Identifier
+	node    string
+}
+
+// CompareExecutionCommand compares the execution of a block by this node with the execution
+// by another execution node, fetched from its gRPC API, and returns the first difference.
+type CompareExecutionCommand struct {
+	// local returns the executions of this node, it returns nil until the execution state is loaded
+	local func() comparison.Source
+}
+
+func NewCompareExecutionCommand(local func() comparison.Source) commands.AdminCommand {
+	return &CompareExecutionCommand{
+		local: local,
+	}
+}
+
+func (c *CompareExecutionCommand) Handler(ctx context.Context, req *admin.CommandRequest) (interface{}, error) {
+	data := req.ValidatorData.(*compareExecutionRequest)
+
+	local := c.local()
+	if local == nil {
+		return nil, errors.New("execution state is not loaded yet")
+	}
+
+	localExecution, err := local.BlockExecution(ctx, data.blockID)
+	if err != nil {
+		return nil, fmt.Errorf("could not get execution of block %v by this node: %w", data.blockID, err)
+	}
+
+	remoteExecution, err := comparison.FetchFromNode(ctx, data.node, data.blockID)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := comparison.Compare(localExecution, remoteExecution)
+	if diff == nil {
+		return map[string]interface{}{
+			"identical": true,
+		}, nil
+	}
+
+	difference, err := commands.ConvertToMap(diff)
+	if err != nil {
+		return nil, fmt.Errorf("could not convert difference: %w", err)
+	}
+	return map[string]interface{}{
+		"identical":  false,
+		"summary":    diff.String(),
+		"difference": difference,
+	}, nil
+}
+
+func (c *CompareExecutionCommand) Validator(req *admin.CommandRequest) error {
+	input, ok := req.Data.(map[string]interface{})
+	if !ok {
+		return errors.New("wrong input format")
+	}
+
+	id, ok := input["block_id"].(string)
+	if !ok {
+		return errors.New("the \"block_id\" field is required and must be a string")
+	}
+	blockID, err := flow.HexStringToIdentifier(id)
+	if err != nil {
+		return fmt.Errorf("invalid value for \"block_id\": %w", err)
+	}
+
+	node, ok := input["node"].(string)
+	if !ok || node == "" {
+		return errors.New("the \"node\" field is required and must be the gRPC address of an execution node")
+	}
+
+	req.ValidatorData = &compareExecutionRequest{
+		blockID: blockID,
+		node:    node,
+	}
+	return nil
+}
+
+func (c *CompareExecutionCommand) ReadOnly() {}
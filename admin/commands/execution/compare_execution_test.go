@@ -0,0 +1,142 @@
+package execution
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/onflow/flow-go/admin"
+	rpccomparison "github.com/onflow/flow-go/engine/common/rpc/comparison"
+	comparisonpb "github.com/onflow/flow-go/engine/common/rpc/comparison/comparison"
+	"github.com/onflow/flow-go/engine/execution/comparison"
+	"github.com/onflow/flow-go/engine/execution/state/delta"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/messages"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+type staticSource struct {
+	comparisonpb.UnimplementedComparisonAPIServer
+	execution *comparison.BlockExecution
+}
+
+func (s *staticSource) BlockExecution(context.Context, flow.Identifier) (*comparison.BlockExecution, error) {
+	return s.execution, nil
+}
+
+func (s *staticSource) GetBlockExecution(context.Context, *comparisonpb.GetBlockExecutionRequest) (*comparisonpb.GetBlockExecutionResponse, error) {
+	result, err := rpccomparison.ExecutionResultToMessage(s.execution.Result)
+	if err != nil {
+		return nil, err
+	}
+	chunkDataPacks, err := rpccomparison.ChunkDataPacksToMessages(s.execution.ChunkDataPacks)
+	if err != nil {
+		return nil, err
+	}
+	stateDelta, err := rpccomparison.StateDeltaToMessage(s.execution.StateDelta)
+	if err != nil {
+		return nil, err
+	}
+	return &comparisonpb.GetBlockExecutionResponse{
+		Result:         result,
+		ChunkDataPacks: chunkDataPacks,
+		StateDelta:     stateDelta,
+	}, nil
+}
+
+func executionFixture() *comparison.BlockExecution {
+	result := unittest.ExecutionResultFixture()
+	execution := &comparison.BlockExecution{
+		Result:         result,
+		ChunkDataPacks: make([]*flow.ChunkDataPack, len(result.Chunks)),
+		StateDelta:     &messages.ExecutionStateDelta{},
+	}
+	for _, chunk := range result.Chunks {
+		chunk.NumberOfTransactions = 0
+		execution.StateDelta.StateInteractions = append(execution.StateDelta.StateInteractions, &delta.Snapshot{Delta: delta.NewDelta()})
+	}
+	return execution
+}
+
+// serveExecution serves the given execution over gRPC, and returns the address of the server.
+func serveExecution(t *testing.T, execution *comparison.BlockExecution) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer()
+	comparisonpb.RegisterComparisonAPIServer(server, &staticSource{execution: execution})
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	t.Cleanup(server.Stop)
+	return listener.Addr().String()
+}
+
+func TestCompareExecutionValidator(t *testing.T) {
+	command := NewCompareExecutionCommand(func() comparison.Source { return nil })
+
+	blockID := unittest.IdentifierFixture()
+	for _, data := range []interface{}{
+		"invalid",
+		map[string]interface{}{"node": "localhost:9000"},
+		map[string]interface{}{"block_id": "invalid", "node": "localhost:9000"},
+		map[string]interface{}{"block_id": blockID.String()},
+	} {
+		req := &admin.CommandRequest{Data: data}
+		assert.Error(t, command.Validator(req), "input %v should be invalid", data)
+	}
+
+	req := &admin.CommandRequest{Data: map[string]interface{}{"block_id": blockID.String(), "node": "localhost:9000"}}
+	require.NoError(t, command.Validator(req))
+	assert.Equal(t, &compareExecutionRequest{blockID: blockID, node: "localhost:9000"}, req.ValidatorData)
+}
+
+func TestCompareExecutionHandler(t *testing.T) {
+	local := executionFixture()
+
+	request := func(node string) *admin.CommandRequest {
+		return &admin.CommandRequest{Data: map[string]interface{}{"block_id": local.Result.BlockID.String(), "node": node}}
+	}
+
+	t.Run("identical", func(t *testing.T) {
+		command := NewCompareExecutionCommand(func() comparison.Source { return &staticSource{execution: local} })
+		req := request(serveExecution(t, local))
+		require.NoError(t, command.Validator(req))
+
+		result, err := command.Handler(context.Background(), req)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"identical": true}, result)
+	})
+
+	t.Run("different", func(t *testing.T) {
+		remote := executionFixture()
+		remote.Result.BlockID = local.Result.BlockID
+		remote.Result.PreviousResultID = local.Result.PreviousResultID
+		remote.Result.Chunks = local.Result.Chunks
+
+		command := NewCompareExecutionCommand(func() comparison.Source { return &staticSource{execution: local} })
+		req := request(serveExecution(t, remote))
+		require.NoError(t, command.Validator(req))
+
+		result, err := command.Handler(context.Background(), req)
+		require.NoError(t, err)
+
+		info := result.(map[string]interface{})
+		assert.Equal(t, false, info["identical"])
+		difference := info["difference"].(map[string]interface{})
+		assert.Equal(t, string(comparison.KindResult), difference["Kind"])
+		assert.Equal(t, "ExecutionDataID", difference["Field"])
+	})
+
+	t.Run("execution state not loaded", func(t *testing.T) {
+		command := NewCompareExecutionCommand(func() comparison.Source { return nil })
+		req := request("localhost:0")
+		require.NoError(t, command.Validator(req))
+
+		_, err := command.Handler(context.Background(), req)
+		assert.Error(t, err)
+	})
+}
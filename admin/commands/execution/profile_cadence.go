@@ -0,0 +1,89 @@
+package execution
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/admin/commands"
+	"github.com/onflow/flow-go/fvm/profiling"
+)
+
+var _ commands.AdminCommand = (*ProfileCadenceCommand)(nil)
+
+// maxProfileDuration limits how long transactions are profiled for, as profiling slows down execution.
+const maxProfileDuration = 10 * time.Minute
+
+// ProfileCadenceCommand profiles the transactions executed by the node for the given duration,
+// and writes the profile in the pprof format into a directory.
+type ProfileCadenceCommand struct {
+	collector *profiling.Collector
+	dir       string
+}
+
+func NewProfileCadenceCommand(collector *profiling.Collector, dir string) commands.AdminCommand {
+	return &ProfileCadenceCommand{
+		collector: collector,
+		dir:       dir,
+	}
+}
+
+func (p *ProfileCadenceCommand) Handler(ctx context.Context, req *admin.CommandRequest) (interface{}, error) {
+	duration := req.ValidatorData.(time.Duration)
+
+	if !p.collector.Start() {
+		return nil, errors.New("transactions are already being profiled")
+	}
+
+	select {
+	case <-ctx.Done():
+		p.collector.Stop()
+		return nil, ctx.Err()
+	case <-time.After(duration):
+	}
+
+	profile, procedures, elapsed := p.collector.Stop()
+
+	err := os.MkdirAll(p.dir, 0755)
+	if err != nil {
+		return nil, fmt.Errorf("could not create profile directory: %w", err)
+	}
+
+	path := filepath.Join(p.dir, fmt.Sprintf("cadence-%s.pb.gz", time.Now().UTC().Format("20060102T150405Z")))
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not create profile file: %w", err)
+	}
+	defer file.Close()
+
+	err = profiling.WritePprof(file, profile, elapsed)
+	if err != nil {
+		return nil, fmt.Errorf("could not write profile: %w", err)
+	}
+
+	return map[string]interface{}{
+		"path":         path,
+		"transactions": procedures,
+		"computation":  profile.ComputationUsed(),
+	}, nil
+}
+
+func (p *ProfileCadenceCommand) Validator(req *admin.CommandRequest) error {
+	input, ok := req.Data.(string)
+	if !ok {
+		return errors.New("the input must be a duration, e.g. \"30s\"")
+	}
+	duration, err := time.ParseDuration(input)
+	if err != nil {
+		return fmt.Errorf("failed to parse duration: %w", err)
+	}
+	if duration <= 0 || duration > maxProfileDuration {
+		return fmt.Errorf("duration must be positive and at most %s", maxProfileDuration)
+	}
+	req.ValidatorData = duration
+	return nil
+}
@@ -0,0 +1,67 @@
+package execution
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/fvm/profiling"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestProfileCadenceValidator(t *testing.T) {
+	command := NewProfileCadenceCommand(profiling.NewCollector(), "")
+
+	for _, data := range []interface{}{true, "invalid", "0s", "-1s", "1h"} {
+		req := &admin.CommandRequest{Data: data}
+		assert.Error(t, command.Validator(req), "input %v should be invalid", data)
+	}
+
+	req := &admin.CommandRequest{Data: "30s"}
+	require.NoError(t, command.Validator(req))
+	assert.Equal(t, 30*time.Second, req.ValidatorData)
+}
+
+func TestProfileCadenceHandler(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		collector := profiling.NewCollector()
+		command := NewProfileCadenceCommand(collector, dir)
+
+		req := &admin.CommandRequest{Data: "100ms"}
+		require.NoError(t, command.Validator(req))
+
+		go func() {
+			// add a profile once the command started collecting
+			assert.Eventually(t, collector.Started, time.Second, time.Millisecond)
+			collector.Add(&profiling.Profile{
+				Invocations: []profiling.Entry{{Name: "transaction", Calls: 1, Computation: 10}},
+			})
+		}()
+
+		result, err := command.Handler(context.Background(), req)
+		require.NoError(t, err)
+
+		info := result.(map[string]interface{})
+		assert.Equal(t, uint64(1), info["transactions"])
+		assert.Equal(t, uint64(10), info["computation"])
+
+		stat, err := os.Stat(info["path"].(string))
+		require.NoError(t, err)
+		assert.NotZero(t, stat.Size())
+		assert.False(t, collector.Started())
+
+		t.Run("cancelled", func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			_, err := command.Handler(ctx, req)
+			assert.ErrorIs(t, err, context.Canceled)
+			assert.False(t, collector.Started())
+		})
+	})
+}
@@ -12,7 +12,7 @@ import (
 	"github.com/onflow/flow-go/module/state_synchronization"
 )
 
-var _ commands.AdminCommand = (*ReadExecutionDataCommand)(nil)
+var _ commands.ReadOnlyAdminCommand = (*ReadExecutionDataCommand)(nil)
 
 type requestData struct {
 	rootID flow.Identifier
@@ -74,3 +74,5 @@ func NewReadExecutionDataCommand(eds state_synchronization.ExecutionDataService)
 		eds,
 	}
 }
+
+func (r *ReadExecutionDataCommand) ReadOnly() {}
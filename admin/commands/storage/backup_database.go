@@ -0,0 +1,76 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/admin/commands"
+	"github.com/onflow/flow-go/storage/backup"
+)
+
+var _ commands.JobAdminCommand = (*BackupDatabaseCommand)(nil)
+
+type backupDatabaseRequest struct {
+	path string
+}
+
+// BackupDatabaseCommand takes an online backup of the protocol database into a
+// local directory, together with the chunk data packs database and a checkpoint
+// of the execution state on execution nodes. It runs as a job, whose result is
+// the manifest of the backup.
+type BackupDatabaseCommand struct {
+	db               *badger.DB
+	chunkDataPacksDB *badger.DB
+	checkpointer     backup.Checkpointer
+}
+
+// NewBackupDatabaseCommand returns the command backing up the given protocol
+// database. The chunk data packs database and the checkpointer are only given
+// on execution nodes.
+func NewBackupDatabaseCommand(db *badger.DB, chunkDataPacksDB *badger.DB, checkpointer backup.Checkpointer) commands.AdminCommand {
+	return &BackupDatabaseCommand{
+		db:               db,
+		chunkDataPacksDB: chunkDataPacksDB,
+		checkpointer:     checkpointer,
+	}
+}
+
+func (b *BackupDatabaseCommand) Handler(ctx context.Context, req *admin.CommandRequest) (interface{}, error) {
+	data := req.ValidatorData.(*backupDatabaseRequest)
+
+	manifest, err := backup.Create(ctx, data.path, b.db, b.chunkDataPacksDB, b.checkpointer, func(completed uint64, total uint64) {
+		admin.ReportProgress(ctx, completed, total)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to back up database: %w", err)
+	}
+
+	return commands.ConvertToMap(manifest)
+}
+
+func (b *BackupDatabaseCommand) Validator(req *admin.CommandRequest) error {
+	input, ok := req.Data.(map[string]interface{})
+	if !ok {
+		return ErrValidatorReqDataFormat
+	}
+
+	path, ok := input["path"].(string)
+	if !ok {
+		return errors.New("the \"path\" field is required and must be a string")
+	}
+	if !filepath.IsAbs(path) {
+		return fmt.Errorf("the \"path\" field must be an absolute path, but got: %v", path)
+	}
+
+	req.ValidatorData = &backupDatabaseRequest{
+		path: filepath.Clean(path),
+	}
+	return nil
+}
+
+func (b *BackupDatabaseCommand) Job() {}
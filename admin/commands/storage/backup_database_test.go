@@ -0,0 +1,41 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/admin"
+)
+
+func TestBackupDatabaseValidator(t *testing.T) {
+	t.Parallel()
+
+	command := NewBackupDatabaseCommand(nil, nil, nil)
+
+	t.Run("missing path", func(t *testing.T) {
+		req := &admin.CommandRequest{
+			Data: map[string]interface{}{},
+		}
+		require.Error(t, command.Validator(req))
+	})
+
+	t.Run("relative path", func(t *testing.T) {
+		req := &admin.CommandRequest{
+			Data: map[string]interface{}{
+				"path": "backups/today",
+			},
+		}
+		require.Error(t, command.Validator(req))
+	})
+
+	t.Run("absolute path", func(t *testing.T) {
+		req := &admin.CommandRequest{
+			Data: map[string]interface{}{
+				"path": "/var/flow/backups/../backups/today/",
+			},
+		}
+		require.NoError(t, command.Validator(req))
+		require.Equal(t, "/var/flow/backups/today", req.ValidatorData.(*backupDatabaseRequest).path)
+	})
+}
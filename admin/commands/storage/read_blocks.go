@@ -12,7 +12,7 @@ import (
 	"github.com/onflow/flow-go/storage"
 )
 
-var _ commands.AdminCommand = (*ReadBlocksCommand)(nil)
+var _ commands.ReadOnlyAdminCommand = (*ReadBlocksCommand)(nil)
 
 type readBlocksRequest struct {
 	blocksRequest    *blocksRequest
@@ -36,6 +36,11 @@ func (r *ReadBlocksCommand) Handler(ctx context.Context, req *admin.CommandReque
 	}
 
 	for i := uint64(0); i < data.numBlocksToQuery; i++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		admin.ReportProgress(ctx, i, data.numBlocksToQuery)
+
 		block, err := r.blocks.ByID(blockID)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get block by ID: %w", err)
@@ -90,3 +95,5 @@ func NewReadBlocksCommand(state protocol.State, storage storage.Blocks) commands
 		storage,
 	}
 }
+
+func (r *ReadBlocksCommand) ReadOnly() {}
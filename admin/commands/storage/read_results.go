@@ -12,7 +12,7 @@ import (
 	"github.com/onflow/flow-go/storage"
 )
 
-var _ commands.AdminCommand = (*ReadResultsCommand)(nil)
+var _ commands.ReadOnlyAdminCommand = (*ReadResultsCommand)(nil)
 
 type readResultsRequestType int
 
@@ -117,3 +117,5 @@ func NewReadResultsCommand(state protocol.State, storage storage.ExecutionResult
 		storage,
 	}
 }
+
+func (r *ReadResultsCommand) ReadOnly() {}
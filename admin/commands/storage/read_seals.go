@@ -12,7 +12,7 @@ import (
 	"github.com/onflow/flow-go/storage"
 )
 
-var _ commands.AdminCommand = (*ReadSealsCommand)(nil)
+var _ commands.ReadOnlyAdminCommand = (*ReadSealsCommand)(nil)
 
 type readSealsRequestType int
 
@@ -174,3 +174,5 @@ func NewReadSealsCommand(state protocol.State, storage storage.Seals, index stor
 		index,
 	}
 }
+
+func (r *ReadSealsCommand) ReadOnly() {}
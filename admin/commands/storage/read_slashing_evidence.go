@@ -0,0 +1,108 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/admin/commands"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+)
+
+var _ commands.ReadOnlyAdminCommand = (*ReadSlashingEvidenceCommand)(nil)
+
+type readSlashingEvidenceRequest struct {
+	evidenceID *flow.Identifier
+	startView  uint64
+	endView    uint64
+}
+
+// ReadSlashingEvidenceCommand reads the evidence of slashable consensus violations
+// persisted by the node, either by evidence ID or by view range.
+type ReadSlashingEvidenceCommand struct {
+	evidence storage.SlashingEvidence
+}
+
+func (r *ReadSlashingEvidenceCommand) Handler(ctx context.Context, req *admin.CommandRequest) (interface{}, error) {
+	data := req.ValidatorData.(*readSlashingEvidenceRequest)
+
+	if data.evidenceID != nil {
+		evidence, err := r.evidence.ByID(*data.evidenceID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get slashing evidence by ID: %w", err)
+		}
+		return commands.ConvertToMap(evidence)
+	}
+
+	evidence, err := r.evidence.ByViewRange(data.startView, data.endView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get slashing evidence by view range: %w", err)
+	}
+	return commands.ConvertToInterfaceList(evidence)
+}
+
+func (r *ReadSlashingEvidenceCommand) Validator(req *admin.CommandRequest) error {
+	input, ok := req.Data.(map[string]interface{})
+	if !ok {
+		return ErrValidatorReqDataFormat
+	}
+
+	data := &readSlashingEvidenceRequest{
+		endView: math.MaxUint64,
+	}
+
+	if evidence, ok := input["evidence"]; ok {
+		errInvalidEvidenceValue := fmt.Errorf("invalid value for \"evidence\": expected an evidence ID represented as a 64 character long hex string, but got: %v", evidence)
+		evidence, ok := evidence.(string)
+		if !ok {
+			return errInvalidEvidenceValue
+		}
+		evidenceID, err := flow.HexStringToIdentifier(evidence)
+		if err != nil {
+			return errInvalidEvidenceValue
+		}
+		data.evidenceID = &evidenceID
+	} else {
+		// without an evidence ID, all evidence in the (possibly open-ended) view range is returned
+		if startView, ok := input["start_view"]; ok {
+			view, err := parseView("start_view", startView)
+			if err != nil {
+				return err
+			}
+			data.startView = view
+		}
+		if endView, ok := input["end_view"]; ok {
+			view, err := parseView("end_view", endView)
+			if err != nil {
+				return err
+			}
+			data.endView = view
+		}
+		if data.startView > data.endView {
+			return errors.New("\"start_view\" must not be greater than \"end_view\"")
+		}
+	}
+
+	req.ValidatorData = data
+
+	return nil
+}
+
+func parseView(field string, view interface{}) (uint64, error) {
+	v, ok := view.(float64)
+	if !ok || v < 0 || math.Trunc(v) != v {
+		return 0, fmt.Errorf("invalid value for %q: expected a non-negative integer, but got: %v", field, view)
+	}
+	return uint64(v), nil
+}
+
+func NewReadSlashingEvidenceCommand(evidence storage.SlashingEvidence) commands.AdminCommand {
+	return &ReadSlashingEvidenceCommand{
+		evidence,
+	}
+}
+
+func (r *ReadSlashingEvidenceCommand) ReadOnly() {}
@@ -0,0 +1,98 @@
+package storage
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gotest.tools/assert"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/admin/commands"
+	"github.com/onflow/flow-go/model/flow"
+	storagemock "github.com/onflow/flow-go/storage/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestReadSlashingEvidenceByID(t *testing.T) {
+	t.Parallel()
+
+	evidence := unittest.SlashingEvidenceFixture()
+	store := new(storagemock.SlashingEvidence)
+	store.On("ByID", evidence.ID()).Return(evidence, nil)
+
+	command := NewReadSlashingEvidenceCommand(store)
+
+	req := &admin.CommandRequest{
+		Data: map[string]interface{}{
+			"evidence": evidence.ID().String(),
+		},
+	}
+	require.NoError(t, command.Validator(req))
+	result, err := command.Handler(context.Background(), req)
+	require.NoError(t, err)
+
+	resultMap, err := commands.ConvertToMap(evidence)
+	require.NoError(t, err)
+
+	assert.DeepEqual(t, result, resultMap)
+}
+
+func TestReadSlashingEvidenceByViewRange(t *testing.T) {
+	t.Parallel()
+
+	evidence := []*flow.SlashingEvidence{
+		unittest.SlashingEvidenceFixture(unittest.WithSlashingEvidenceView(10)),
+		unittest.SlashingEvidenceFixture(unittest.WithSlashingEvidenceView(12)),
+	}
+	store := new(storagemock.SlashingEvidence)
+	store.On("ByViewRange", uint64(10), uint64(20)).Return(evidence, nil)
+	store.On("ByViewRange", uint64(0), uint64(math.MaxUint64)).Return(evidence, nil)
+
+	command := NewReadSlashingEvidenceCommand(store)
+
+	expected, err := commands.ConvertToInterfaceList(evidence)
+	require.NoError(t, err)
+
+	t.Run("bounded range", func(t *testing.T) {
+		req := &admin.CommandRequest{
+			Data: map[string]interface{}{
+				"start_view": float64(10),
+				"end_view":   float64(20),
+			},
+		}
+		require.NoError(t, command.Validator(req))
+		result, err := command.Handler(context.Background(), req)
+		require.NoError(t, err)
+		assert.DeepEqual(t, result, expected)
+	})
+
+	t.Run("all evidence", func(t *testing.T) {
+		req := &admin.CommandRequest{
+			Data: map[string]interface{}{},
+		}
+		require.NoError(t, command.Validator(req))
+		result, err := command.Handler(context.Background(), req)
+		require.NoError(t, err)
+		assert.DeepEqual(t, result, expected)
+	})
+}
+
+func TestReadSlashingEvidenceValidator(t *testing.T) {
+	t.Parallel()
+
+	command := NewReadSlashingEvidenceCommand(new(storagemock.SlashingEvidence))
+
+	invalid := []map[string]interface{}{
+		{"evidence": "not an ID"},
+		{"evidence": float64(1)},
+		{"start_view": float64(-1)},
+		{"start_view": float64(1.5)},
+		{"end_view": "final"},
+		{"start_view": float64(20), "end_view": float64(10)},
+	}
+	for _, data := range invalid {
+		require.Error(t, command.Validator(&admin.CommandRequest{Data: data}), data)
+	}
+}
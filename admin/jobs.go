@@ -0,0 +1,312 @@
+package admin
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DefaultJobsRetained is the number of finished jobs kept for the job-status
+// and job-result commands.
+const DefaultJobsRetained = 100
+
+// JobState is the state of a job.
+type JobState string
+
+const (
+	JobRunning   JobState = "running"
+	JobSucceeded JobState = "succeeded"
+	JobFailed    JobState = "failed"
+	JobCanceled  JobState = "canceled"
+)
+
+// JobProgress is the progress of a job, as last reported by its command.
+type JobProgress struct {
+	Completed uint64 `json:"completed"`
+	// Total is zero if the command doesn't know how much work is left.
+	Total uint64 `json:"total,omitempty"`
+}
+
+// JobStatus is the status of a job, as returned by the job-status command.
+type JobStatus struct {
+	ID         string       `json:"id"`
+	Command    string       `json:"command"`
+	Caller     string       `json:"caller"`
+	State      JobState     `json:"state"`
+	Progress   *JobProgress `json:"progress,omitempty"`
+	StartedAt  time.Time    `json:"started_at"`
+	FinishedAt *time.Time   `json:"finished_at,omitempty"`
+	Error      string       `json:"error,omitempty"`
+}
+
+// job is a command running in the background.
+type job struct {
+	mu     sync.Mutex
+	status JobStatus
+	result interface{}
+	err    error
+	cancel context.CancelFunc
+}
+
+func (j *job) getStatus() JobStatus {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	s := j.status
+	if s.Progress != nil {
+		progress := *s.Progress
+		s.Progress = &progress
+	}
+	return s
+}
+
+type jobKey struct{}
+
+// ReportProgress reports the progress of the command run as a job by the given
+// context. It does nothing if the command is not run as a job, so commands can
+// always report their progress.
+func ReportProgress(ctx context.Context, completed uint64, total uint64) {
+	j, ok := ctx.Value(jobKey{}).(*job)
+	if !ok {
+		return
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.status.Progress = &JobProgress{Completed: completed, Total: total}
+}
+
+// jobs tracks the jobs of the command runner. Running jobs are always tracked,
+// while only the latest finished jobs are retained.
+type jobs struct {
+	mu       sync.Mutex
+	ctx      context.Context
+	closed   bool
+	byID     map[string]*job
+	finished []string // IDs of the retained finished jobs, oldest first
+	retained int
+	running  sync.WaitGroup
+}
+
+func newJobs(retained int) *jobs {
+	return &jobs{
+		byID:     make(map[string]*job),
+		retained: retained,
+	}
+}
+
+// run starts tracking the jobs, which run under the given context.
+func (js *jobs) run(ctx context.Context) {
+	js.mu.Lock()
+	defer js.mu.Unlock()
+	js.ctx = ctx
+}
+
+// start runs the given function in the background, and returns the ID of its job.
+// The onFinish callback is called with the ID and the error of the job once it
+// finished.
+func (js *jobs) start(
+	command string,
+	caller *Caller,
+	run func(ctx context.Context) (interface{}, error),
+	onFinish func(id string, err error),
+) (string, error) {
+	id, err := newJobID()
+	if err != nil {
+		return "", status.Errorf(codes.Internal, "could not generate job id: %v", err)
+	}
+
+	js.mu.Lock()
+	defer js.mu.Unlock()
+
+	if js.ctx == nil || js.closed || js.ctx.Err() != nil {
+		return "", status.Error(codes.Unavailable, "admin server is not running")
+	}
+
+	ctx, cancel := context.WithCancel(js.ctx)
+	j := &job{
+		status: JobStatus{
+			ID:        id,
+			Command:   command,
+			Caller:    callerName(caller),
+			State:     JobRunning,
+			StartedAt: time.Now().UTC(),
+		},
+		cancel: cancel,
+	}
+	js.byID[id] = j
+
+	js.running.Add(1)
+	go func() {
+		defer js.running.Done()
+		defer cancel()
+
+		result, err := run(context.WithValue(ctx, jobKey{}, j))
+		js.finish(j, result, err)
+		onFinish(id, err)
+	}()
+
+	return id, nil
+}
+
+// finish records the outcome of the given job, and drops the oldest finished
+// jobs beyond the retained ones.
+func (js *jobs) finish(j *job, result interface{}, err error) {
+	j.mu.Lock()
+	finishedAt := time.Now().UTC()
+	j.status.FinishedAt = &finishedAt
+	j.result = result
+	j.err = err
+	switch status.Code(err) {
+	case codes.OK:
+		j.status.State = JobSucceeded
+	case codes.Canceled:
+		j.status.State = JobCanceled
+		j.status.Error = status.Convert(err).Message()
+	default:
+		j.status.State = JobFailed
+		j.status.Error = status.Convert(err).Message()
+	}
+	id := j.status.ID
+	j.mu.Unlock()
+
+	js.mu.Lock()
+	defer js.mu.Unlock()
+
+	js.finished = append(js.finished, id)
+	for len(js.finished) > js.retained {
+		delete(js.byID, js.finished[0])
+		js.finished = js.finished[1:]
+	}
+}
+
+// get returns the job with the given ID.
+func (js *jobs) get(id string) (*job, error) {
+	js.mu.Lock()
+	defer js.mu.Unlock()
+
+	j, ok := js.byID[id]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "job %s not found", id)
+	}
+	return j, nil
+}
+
+// list returns the status of all tracked jobs, ordered by start time.
+func (js *jobs) list() []JobStatus {
+	js.mu.Lock()
+	defer js.mu.Unlock()
+
+	statuses := make([]JobStatus, 0, len(js.byID))
+	for _, j := range js.byID {
+		statuses = append(statuses, j.getStatus())
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].StartedAt.Before(statuses[j].StartedAt)
+	})
+	return statuses
+}
+
+// result returns the result of the job with the given ID. It fails if the job
+// is still running, and returns the error of the job if it didn't succeed.
+func (js *jobs) result(id string) (interface{}, error) {
+	j, err := js.get(id)
+	if err != nil {
+		return nil, err
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.status.State == JobRunning {
+		return nil, status.Errorf(codes.FailedPrecondition, "job %s is still running", id)
+	}
+	return j.result, j.err
+}
+
+// cancel cancels the job with the given ID. It fails if the job already finished.
+func (js *jobs) cancel(id string) (JobStatus, error) {
+	j, err := js.get(id)
+	if err != nil {
+		return JobStatus{}, err
+	}
+
+	j.mu.Lock()
+	running := j.status.State == JobRunning
+	j.mu.Unlock()
+
+	if !running {
+		return JobStatus{}, status.Errorf(codes.FailedPrecondition, "job %s already finished", id)
+	}
+
+	j.cancel()
+	return j.getStatus(), nil
+}
+
+// wait stops accepting new jobs and waits for the running ones to exit. The
+// context of the jobs must be done, so they are canceled.
+func (js *jobs) wait() {
+	js.mu.Lock()
+	js.closed = true
+	js.mu.Unlock()
+
+	js.running.Wait()
+}
+
+func newJobID() (string, error) {
+	id := make([]byte, 8)
+	_, err := rand.Read(id)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(id), nil
+}
+
+// validateJobID parses the ID of the job the command applies to.
+func validateJobID(req *CommandRequest) error {
+	input, ok := req.Data.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("the \"id\" field is required")
+	}
+	id, ok := input["id"].(string)
+	if !ok || id == "" {
+		return fmt.Errorf("the \"id\" field is required and must be a string")
+	}
+	req.ValidatorData = id
+	return nil
+}
+
+// validateJobStatus parses the optional ID of the job to return the status of.
+// All tracked jobs are listed if no ID is given.
+func validateJobStatus(req *CommandRequest) error {
+	if input, ok := req.Data.(map[string]interface{}); ok {
+		if _, ok := input["id"]; ok {
+			return validateJobID(req)
+		}
+	}
+	req.ValidatorData = ""
+	return nil
+}
+
+// parseJobStart parses the command and the data of the command run by job-start.
+func parseJobStart(data interface{}) (string, interface{}, error) {
+	input, ok := data.(map[string]interface{})
+	if !ok {
+		return "", nil, fmt.Errorf("the \"command\" field is required")
+	}
+	command, ok := input["command"].(string)
+	if !ok || command == "" {
+		return "", nil, fmt.Errorf("the \"command\" field is required and must be a string")
+	}
+	if command == jobStartCommand {
+		return "", nil, fmt.Errorf("%s cannot run as a job", jobStartCommand)
+	}
+	return command, input["data"], nil
+}
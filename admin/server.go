@@ -16,7 +16,7 @@ type adminServer struct {
 }
 
 func (s *adminServer) RunCommand(ctx context.Context, in *pb.RunCommandRequest) (*pb.RunCommandResponse, error) {
-	result, err := s.cr.runCommand(ctx, in.GetCommandName(), in.GetData().AsInterface())
+	result, err := s.cr.runCommand(ctx, callerFromMetadata(ctx), in.GetCommandName(), in.GetData().AsInterface())
 	if err != nil {
 		return nil, err
 	}
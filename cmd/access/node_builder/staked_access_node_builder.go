@@ -8,6 +8,7 @@ import (
 	"github.com/libp2p/go-libp2p-core/host"
 	"github.com/libp2p/go-libp2p-core/routing"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/opentracing/opentracing-go"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 
@@ -201,6 +202,10 @@ func (builder *StakedAccessNodeBuilder) Build() (cmd.Node, error) {
 			return nil
 		}).
 		Component("RPC engine", func(node *cmd.NodeConfig) (module.ReadyDoneAware, error) {
+			// trace the requests and propagate the spans to the execution nodes if tracing is enabled
+			if tracer, ok := node.Tracer.(opentracing.Tracer); ok {
+				builder.rpcConf.Tracer = tracer
+			}
 			builder.RpcEng = rpc.New(
 				node.Logger,
 				node.State,
@@ -213,6 +218,7 @@ func (builder *StakedAccessNodeBuilder) Build() (cmd.Node, error) {
 				node.Storage.Transactions,
 				node.Storage.Receipts,
 				node.Storage.Results,
+				node.Storage.SlashingEvidence,
 				node.RootChainID,
 				builder.TransactionMetrics,
 				builder.collectionGRPCPort,
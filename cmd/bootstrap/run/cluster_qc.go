@@ -23,7 +23,7 @@ func GenerateClusterRootQC(signers []bootstrap.NodeInfo, allCommitteeMembers flo
 	clusterRootBlock := model.GenesisBlockFromFlow(clusterBlock.Header)
 
 	// STEP 1: create votes for cluster root block
-	votes, err := createRootBlockVotes(signers, clusterRootBlock)
+	votes, err := createRootBlockVotes(signers, clusterBlock.Header.ChainID, clusterRootBlock)
 	if err != nil {
 		return nil, err
 	}
@@ -72,7 +72,7 @@ func createClusterValidator(committee hotstuff.Committee) (hotstuff.Validator, e
 }
 
 // createRootBlockVotes generates a vote for the rootBlock from each participant
-func createRootBlockVotes(participants []bootstrap.NodeInfo, rootBlock *model.Block) ([]*model.Vote, error) {
+func createRootBlockVotes(participants []bootstrap.NodeInfo, clusterID flow.ChainID, rootBlock *model.Block) ([]*model.Vote, error) {
 	votes := make([]*model.Vote, 0, len(participants))
 	for _, participant := range participants {
 		// create the participant's local identity
@@ -86,7 +86,7 @@ func createRootBlockVotes(participants []bootstrap.NodeInfo, rootBlock *model.Bl
 		}
 
 		// generate root block vote
-		vote, err := verification.NewStakingSigner(me).CreateVote(rootBlock)
+		vote, err := verification.NewStakingSigner(me, clusterID).CreateVote(rootBlock)
 		if err != nil {
 			return nil, fmt.Errorf("could not create cluster vote for participant %v: %w", me.NodeID(), err)
 		}
@@ -15,6 +15,7 @@ import (
 
 	"github.com/onflow/flow-go/cmd"
 	"github.com/onflow/flow-go/consensus"
+	"github.com/onflow/flow-go/consensus/hotstuff"
 	"github.com/onflow/flow-go/consensus/hotstuff/committees"
 	"github.com/onflow/flow-go/consensus/hotstuff/notifications/pubsub"
 	"github.com/onflow/flow-go/consensus/hotstuff/pacemaker/timeout"
@@ -85,10 +86,11 @@ func main() {
 		err               error
 
 		// epoch qc contract client
-		machineAccountInfo *bootstrap.NodeMachineAccountInfo
-		flowClientConfigs  []*common.FlowClientConfig
-		insecureAccessAPI  bool
-		accessNodeIDS      []string
+		machineAccountInfo          *bootstrap.NodeMachineAccountInfo
+		machineAccountCheckInterval time.Duration
+		flowClientConfigs           []*common.FlowClientConfig
+		insecureAccessAPI           bool
+		accessNodeIDS               []string
 	)
 
 	nodeBuilder := cmd.FlowNode(flow.RoleCollection.String())
@@ -145,6 +147,7 @@ func main() {
 		// epoch qc contract flags
 		flags.BoolVar(&insecureAccessAPI, "insecure-access-api", false, "required if insecure GRPC connection should be used")
 		flags.StringSliceVar(&accessNodeIDS, "access-node-ids", []string{}, fmt.Sprintf("array of access node IDs sorted in priority order where the first ID in this array will get the first connection attempt and each subsequent ID after serves as a fallback. Minimum length %d. Use '*' for all IDs in protocol state.", common.DefaultAccessNodeIDSMinimum))
+		flags.DurationVar(&machineAccountCheckInterval, "machine-account-check-interval", epochs.DefaultMachineAccountCheckInterval, "interval between two checks of the machine account balance and key")
 
 	}).ValidateFlags(func() error {
 		if startupTimeString != cmd.NotSet {
@@ -237,6 +240,28 @@ func main() {
 
 			return validator, err
 		}).
+		Component("machine account monitor", func(node *cmd.NodeConfig) (module.ReadyDoneAware, error) {
+			//@TODO use fallback logic for flowClient similar to DKG/QC contract clients
+			flowClient, err := common.FlowClient(flowClientConfigs[0])
+			if err != nil {
+				return nil, fmt.Errorf("failed to get flow client connection option for access node (0): %s %w", flowClientConfigs[0].AccessAddress, err)
+			}
+
+			// disable balance checks for transient networks, which do not have transaction fees
+			var opts []epochs.MachineAccountValidatorConfigOption
+			if node.RootChainID.Transient() {
+				opts = append(opts, epochs.WithoutBalanceChecks)
+			}
+			return epochs.NewMachineAccountMonitor(
+				node.Logger,
+				metrics.NewMachineAccountCollector(),
+				flowClient,
+				flow.RoleCollection,
+				*machineAccountInfo,
+				machineAccountCheckInterval,
+				opts...,
+			)
+		}).
 		Component("follower engine", func(node *cmd.NodeConfig) (module.ReadyDoneAware, error) {
 
 			// initialize cleaner for DB
@@ -459,8 +484,6 @@ func main() {
 				return nil, err
 			}
 
-			signer := verification.NewStakingSigner(node.Me)
-
 			// construct QC contract client
 			qcContractClients, err := createQCContractClients(node, machineAccountInfo, flowClientConfigs)
 			if err != nil {
@@ -470,7 +493,9 @@ func main() {
 			rootQCVoter := epochs.NewRootQCVoter(
 				node.Logger,
 				node.Me,
-				signer,
+				func(clusterID flow.ChainID) hotstuff.Signer {
+					return verification.NewStakingSigner(node.Me, clusterID)
+				},
 				node.State,
 				qcContractClients,
 			)
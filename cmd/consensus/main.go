@@ -21,6 +21,7 @@ import (
 	"github.com/onflow/flow-go/consensus/hotstuff"
 	"github.com/onflow/flow-go/consensus/hotstuff/blockproducer"
 	"github.com/onflow/flow-go/consensus/hotstuff/committees"
+	"github.com/onflow/flow-go/consensus/hotstuff/journal"
 	"github.com/onflow/flow-go/consensus/hotstuff/notifications/pubsub"
 	"github.com/onflow/flow-go/consensus/hotstuff/pacemaker/timeout"
 	"github.com/onflow/flow-go/consensus/hotstuff/persister"
@@ -56,6 +57,7 @@ import (
 	"github.com/onflow/flow-go/module/metrics"
 	"github.com/onflow/flow-go/module/synchronization"
 	"github.com/onflow/flow-go/module/validation"
+	"github.com/onflow/flow-go/remotesigner"
 	"github.com/onflow/flow-go/state/protocol"
 	badgerState "github.com/onflow/flow-go/state/protocol/badger"
 	"github.com/onflow/flow-go/state/protocol/blocktimer"
@@ -90,12 +92,14 @@ func main() {
 		dkgControllerConfig                    dkgmodule.ControllerConfig
 		startupTimeString                      string
 		startupTime                            time.Time
+		hotstuffJournalPath                    string
 
 		// DKG contract client
-		machineAccountInfo *bootstrap.NodeMachineAccountInfo
-		flowClientConfigs  []*common.FlowClientConfig
-		insecureAccessAPI  bool
-		accessNodeIDS      []string
+		machineAccountInfo          *bootstrap.NodeMachineAccountInfo
+		machineAccountCheckInterval time.Duration
+		flowClientConfigs           []*common.FlowClientConfig
+		insecureAccessAPI           bool
+		accessNodeIDS               []string
 
 		err                     error
 		mutableState            protocol.MutableState
@@ -119,6 +123,7 @@ func main() {
 		hotstuffModules         *consensus.HotstuffModules
 		dkgState                *bstorage.DKGState
 		safeBeaconKeys          *bstorage.SafeBeaconPrivateKeys
+		hotstuffJournal         *journal.Journal
 	)
 
 	nodeBuilder := cmd.FlowNode(flow.RoleConsensus.String())
@@ -146,9 +151,11 @@ func main() {
 		flags.BoolVar(&emergencySealing, "emergency-sealing-active", sealing.DefaultEmergencySealingActive, "(de)activation of emergency sealing")
 		flags.BoolVar(&insecureAccessAPI, "insecure-access-api", false, "required if insecure GRPC connection should be used")
 		flags.StringSliceVar(&accessNodeIDS, "access-node-ids", []string{}, fmt.Sprintf("array of access node IDs sorted in priority order where the first ID in this array will get the first connection attempt and each subsequent ID after serves as a fallback. Minimum length %d. Use '*' for all IDs in protocol state.", common.DefaultAccessNodeIDSMinimum))
+		flags.DurationVar(&machineAccountCheckInterval, "machine-account-check-interval", epochs.DefaultMachineAccountCheckInterval, "interval between two checks of the machine account balance and key")
 		flags.DurationVar(&dkgControllerConfig.BaseStartDelay, "dkg-controller-base-start-delay", dkgmodule.DefaultBaseStartDelay, "used to define the range for jitter prior to DKG start (eg. 500µs) - the base value is scaled quadratically with the # of DKG participants")
 		flags.DurationVar(&dkgControllerConfig.BaseHandleFirstBroadcastDelay, "dkg-controller-base-handle-first-broadcast-delay", dkgmodule.DefaultBaseHandleFirstBroadcastDelay, "used to define the range for jitter prior to DKG handling the first broadcast messages (eg. 50ms) - the base value is scaled quadratically with the # of DKG participants")
 		flags.DurationVar(&dkgControllerConfig.HandleSubsequentBroadcastDelay, "dkg-controller-handle-subsequent-broadcast-delay", dkgmodule.DefaultHandleSubsequentBroadcastDelay, "used to define the constant delay introduced prior to DKG handling subsequent broadcast messages (eg. 2s)")
+		flags.StringVar(&hotstuffJournalPath, "hotstuff-journal", "", "path of a file to record the inputs and outputs of the hotstuff event handler to, for replaying consensus incidents offline (disabled if empty)")
 		flags.StringVar(&startupTimeString, "hotstuff-startup-time", cmd.NotSet, "specifies date and time (in ISO 8601 format) after which the consensus participant may enter the first view (e.g 1996-04-24T15:04:05-07:00)")
 	}).ValidateFlags(func() error {
 		nodeBuilder.Logger.Info().Str("startup_time_str", startupTimeString).Msg("got startup_time_str")
@@ -250,6 +257,14 @@ func main() {
 			// epoch boundary, so they have no beacon key file (they will generate
 			// their first beacon private key through the DKG in the EpochSetup phase
 			// prior to their first epoch as network participant).
+			//
+			// If the node uses a remote signer, the signer holds the beacon key for
+			// the first epoch, so the key file is neither read nor stored.
+
+			if node.BaseConfig.RemoteSignerSocket != cmd.NotSet {
+				node.Logger.Info().Msg("random beacon keys are held by the remote signer, will not read spork random beacon key file")
+				return nil
+			}
 
 			rootSnapshot := node.State.AtBlockID(node.RootBlock.ID())
 			isSporkRoot, err := protocol.IsSporkRootSnapshot(rootSnapshot)
@@ -337,6 +352,17 @@ func main() {
 			mainMetrics = metrics.NewHotstuffCollector(node.RootChainID)
 			return nil
 		}).
+		Module("hotstuff journal", func(node *cmd.NodeConfig) error {
+			if hotstuffJournalPath == "" {
+				return nil
+			}
+			hotstuffJournal, err = journal.Open(node.Logger, hotstuffJournalPath)
+			if err != nil {
+				return fmt.Errorf("could not open hotstuff journal: %w", err)
+			}
+			nodeBuilder.ShutdownFunc(hotstuffJournal.Close)
+			return nil
+		}).
 		Module("sync core", func(node *cmd.NodeConfig) error {
 			syncCore, err = synchronization.New(node.Logger, synchronization.DefaultConfig())
 			return err
@@ -383,6 +409,28 @@ func main() {
 			)
 			return validator, err
 		}).
+		Component("machine account monitor", func(node *cmd.NodeConfig) (module.ReadyDoneAware, error) {
+			//@TODO use fallback logic for flowClient similar to DKG/QC contract clients
+			flowClient, err := common.FlowClient(flowClientConfigs[0])
+			if err != nil {
+				return nil, fmt.Errorf("failed to get flow client connection option for access node (0): %s %w", flowClientConfigs[0].AccessAddress, err)
+			}
+
+			// disable balance checks for transient networks, which do not have transaction fees
+			var opts []epochs.MachineAccountValidatorConfigOption
+			if node.RootChainID.Transient() {
+				opts = append(opts, epochs.WithoutBalanceChecks)
+			}
+			return epochs.NewMachineAccountMonitor(
+				node.Logger,
+				metrics.NewMachineAccountCollector(),
+				flowClient,
+				flow.RoleConsensus,
+				*machineAccountInfo,
+				machineAccountCheckInterval,
+				opts...,
+			)
+		}).
 		Component("sealing engine", func(node *cmd.NodeConfig) (module.ReadyDoneAware, error) {
 
 			sealingTracker := tracker.NewSealingTracker(node.Logger, node.Storage.Headers, node.Storage.Receipts, seals)
@@ -528,7 +576,13 @@ func main() {
 			committee = committees.NewMetricsWrapper(committee, mainMetrics) // wrapper for measuring time spent determining consensus committee relations
 
 			epochLookup := epochs.NewEpochLookup(node.State)
-			beaconKeyStore := hotsignature.NewEpochAwareRandomBeaconKeyStore(epochLookup, safeBeaconKeys)
+			var beaconKeyStore module.RandomBeaconKeyStore
+			if remoteSigner, ok := node.Me.(*remotesigner.Client); ok {
+				// the random beacon keys are held by the remote signer, alongside the staking key
+				beaconKeyStore = remoteSigner.RandomBeaconKeyStore(epochLookup)
+			} else {
+				beaconKeyStore = hotsignature.NewEpochAwareRandomBeaconKeyStore(epochLookup, safeBeaconKeys)
+			}
 
 			// initialize the combined signer for hotstuff
 			var signer hotstuff.Signer
@@ -544,9 +598,14 @@ func main() {
 				mainMetrics,
 				node.Tracer,
 				node.RootChainID,
+				node.Storage.Headers,
+				node.Storage.SlashingEvidence,
 			)
 
 			notifier.AddConsumer(finalizationDistributor)
+			if hotstuffJournal != nil {
+				notifier.AddConsumer(journal.NewConsumer(hotstuffJournal))
+			}
 
 			// initialize the persister
 			persist := persister.New(node.DB, node.RootChainID)
@@ -630,6 +689,10 @@ func main() {
 				opts = append(opts, consensus.WithStartupTime(startupTime))
 			}
 
+			if hotstuffJournal != nil {
+				opts = append(opts, consensus.WithJournal(hotstuffJournal))
+			}
+
 			finalizedBlock, pending, err := recovery.FindLatest(node.State, node.Storage.Headers)
 			if err != nil {
 				return nil, err
@@ -8,14 +8,18 @@ import (
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module"
 	metricsconsumer "github.com/onflow/flow-go/module/metrics/hotstuff"
+	"github.com/onflow/flow-go/storage"
 )
 
 func createNotifier(log zerolog.Logger, metrics module.HotstuffMetrics, tracer module.Tracer, chain flow.ChainID,
+	headers storage.Headers, evidence storage.SlashingEvidence,
 ) *pubsub.Distributor {
 	telemetryConsumer := notifications.NewTelemetryConsumer(log, chain)
 	metricsConsumer := metricsconsumer.NewMetricsConsumer(metrics)
+	slashingConsumer := notifications.NewSlashingViolationsConsumer(log, chain, headers, evidence)
 	dis := pubsub.NewDistributor()
 	dis.AddConsumer(telemetryConsumer)
 	dis.AddConsumer(metricsConsumer)
+	dis.AddConsumer(slashingConsumer)
 	return dis
 }
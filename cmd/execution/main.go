@@ -11,14 +11,20 @@ import (
 
 	awsconfig "github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	badgerdb "github.com/dgraph-io/badger/v2"
 	"github.com/ipfs/go-bitswap"
 	badger "github.com/ipfs/go-ds-badger2"
+	"github.com/onflow/cadence/runtime"
+	"github.com/opentracing/opentracing-go"
 	"github.com/spf13/pflag"
+	"google.golang.org/grpc/credentials"
 
 	"github.com/onflow/flow-core-contracts/lib/go/templates"
 
 	"github.com/onflow/flow-go/admin/commands"
+	executionCommands "github.com/onflow/flow-go/admin/commands/execution"
 	stateSyncCommands "github.com/onflow/flow-go/admin/commands/state_synchronization"
+	storageCommands "github.com/onflow/flow-go/admin/commands/storage"
 	uploaderCommands "github.com/onflow/flow-go/admin/commands/uploader"
 	"github.com/onflow/flow-go/cmd"
 	"github.com/onflow/flow-go/consensus"
@@ -31,21 +37,29 @@ import (
 	followereng "github.com/onflow/flow-go/engine/common/follower"
 	"github.com/onflow/flow-go/engine/common/provider"
 	"github.com/onflow/flow-go/engine/common/requester"
+	checkpointspb "github.com/onflow/flow-go/engine/common/rpc/checkpoints/checkpoints"
+	comparisonpb "github.com/onflow/flow-go/engine/common/rpc/comparison/comparison"
 	"github.com/onflow/flow-go/engine/common/synchronization"
 	"github.com/onflow/flow-go/engine/execution/checker"
+	"github.com/onflow/flow-go/engine/execution/checkpointsync"
+	executionComparison "github.com/onflow/flow-go/engine/execution/comparison"
 	"github.com/onflow/flow-go/engine/execution/computation"
 	"github.com/onflow/flow-go/engine/execution/computation/committer"
+	"github.com/onflow/flow-go/engine/execution/computation/computer"
 	"github.com/onflow/flow-go/engine/execution/computation/computer/uploader"
 	"github.com/onflow/flow-go/engine/execution/ingestion"
 	exeprovider "github.com/onflow/flow-go/engine/execution/provider"
+	"github.com/onflow/flow-go/engine/execution/pruner"
 	"github.com/onflow/flow-go/engine/execution/rpc"
 	"github.com/onflow/flow-go/engine/execution/state"
 	"github.com/onflow/flow-go/engine/execution/state/bootstrap"
 	"github.com/onflow/flow-go/engine/execution/state/delta"
 	"github.com/onflow/flow-go/fvm"
 	"github.com/onflow/flow-go/fvm/extralog"
+	"github.com/onflow/flow-go/fvm/profiling"
 	"github.com/onflow/flow-go/fvm/programs"
 	"github.com/onflow/flow-go/fvm/systemcontracts"
+	flowledger "github.com/onflow/flow-go/ledger"
 	"github.com/onflow/flow-go/ledger/common/pathfinder"
 	ledger "github.com/onflow/flow-go/ledger/complete"
 	"github.com/onflow/flow-go/ledger/complete/wal"
@@ -64,7 +78,11 @@ import (
 	"github.com/onflow/flow-go/state/protocol"
 	badgerState "github.com/onflow/flow-go/state/protocol/badger"
 	"github.com/onflow/flow-go/state/protocol/blocktimer"
+	realstorage "github.com/onflow/flow-go/storage"
 	storage "github.com/onflow/flow-go/storage/badger"
+	"github.com/onflow/flow-go/storage/operation/badgerimpl"
+	"github.com/onflow/flow-go/storage/store"
+	"github.com/onflow/flow-go/utils/grpcutils"
 )
 
 func main() {
@@ -72,13 +90,17 @@ func main() {
 	var (
 		followerState                 protocol.MutableState
 		ledgerStorage                 *ledger.Ledger
-		events                        *storage.Events
-		serviceEvents                 *storage.ServiceEvents
-		txResults                     *storage.TransactionResults
+		ledgerLoaded                  = make(chan struct{}) // closed once ledgerStorage is set
+		events                        *store.Events
+		serviceEvents                 *store.ServiceEvents
+		txResults                     *store.TransactionResults
+		registerSets                  *storage.TransactionRegisterSets
 		results                       *storage.ExecutionResults
+		chunkDataPacks                *storage.ChunkDataPacks
 		myReceipts                    *storage.MyExecutionReceipts
 		providerEngine                *exeprovider.Engine
 		checkerEng                    *checker.Engine
+		chunkDataPacksPruner          *pruner.ChunkDataPacks
 		syncCore                      *chainsync.Core
 		pendingBlocks                 *buffer.PendingBlocks // used in follower engine
 		deltas                        *ingestion.Deltas
@@ -103,6 +125,9 @@ func main() {
 		stateDeltasLimit              uint
 		cadenceExecutionCache         uint
 		chdpCacheSize                 uint
+		chunkDataPackDir              string
+		chunkDataPacksDB              *badgerdb.DB
+		chunkDataPackPruningThreshold uint64
 		requestInterval               time.Duration
 		preferredExeNodeIDStr         string
 		syncByBlocks                  bool
@@ -113,6 +138,17 @@ func main() {
 		checkAuthorizedAtBlock        func(blockID flow.Identifier) (bool, error)
 		diskWAL                       *wal.DiskWAL
 		scriptLogThreshold            time.Duration
+		parallelExecutionWorkers      int
+		storeRegisterSets             bool
+		cadenceFunctionProfiling      bool
+		cadenceProfileDir             string
+		cadenceProfiles               = profiling.NewCollector()
+		programsWarmUp                bool
+		serveCheckpoints              bool
+		serveBlockExecutions          bool
+		blockExecutions               *executionComparison.Server
+		checkpointSyncPeer            string
+		checkpointSyncPeerID          string
 		chdpQueryTimeout              uint
 		chdpDeliveryTimeout           uint
 		enableBlockDataUpload         bool
@@ -134,6 +170,7 @@ func main() {
 			datadir := filepath.Join(homedir, ".flow", "execution")
 
 			flags.StringVarP(&rpcConf.ListenAddr, "rpc-addr", "i", "localhost:9000", "the address the gRPC server listens on")
+			flags.StringVar(&rpcConf.SecureListenAddr, "secure-rpc-addr", "localhost:9001", "the address the secure gRPC server serving the execution state to other execution nodes listens on")
 			flags.BoolVar(&rpcConf.RpcMetricsEnabled, "rpc-metrics-enabled", false, "whether to enable the rpc metrics")
 			flags.StringVar(&triedir, "triedir", datadir, "directory to store the execution State")
 			flags.StringVar(&executionDataDir, "execution-data-dir", filepath.Join(homedir, ".flow", "execution_data_blobstore"), "directory to use for Execution Data blobstore")
@@ -143,8 +180,19 @@ func main() {
 			flags.UintVar(&stateDeltasLimit, "state-deltas-limit", 100, "maximum number of state deltas in the memory pool")
 			flags.UintVar(&cadenceExecutionCache, "cadence-execution-cache", computation.DefaultProgramsCacheSize, "cache size for Cadence execution")
 			flags.UintVar(&chdpCacheSize, "chdp-cache", storage.DefaultCacheSize, "cache size for Chunk Data Packs")
+			flags.StringVar(&chunkDataPackDir, "chunk-data-pack-dir", filepath.Join(homedir, ".flow", "chunk_data_packs"), "directory to store the chunk data packs database")
+			flags.Uint64Var(&chunkDataPackPruningThreshold, "chunk-data-pack-pruning-threshold", 0, "number of heights below the latest sealed block to keep chunk data packs for (0 to keep all)")
 			flags.DurationVar(&requestInterval, "request-interval", 60*time.Second, "the interval between requests for the requester engine")
 			flags.DurationVar(&scriptLogThreshold, "script-log-threshold", computation.DefaultScriptLogThreshold, "threshold for logging script execution")
+			flags.IntVar(&parallelExecutionWorkers, "parallel-execution-workers", 0, "number of workers executing the transactions of a collection optimistically in parallel (less than 2 disables parallel execution)")
+			flags.BoolVar(&programsWarmUp, "programs-warm-up", false, "keep a list of the contracts whose programs were loaded, and load them again when the node starts")
+			flags.BoolVar(&serveCheckpoints, "serve-checkpoints", false, "serve the execution state at sealed blocks to execution nodes bootstrapping from this node")
+			flags.BoolVar(&serveBlockExecutions, "serve-block-executions", false, "serve the execution results, chunk data packs and state deltas of executed blocks, to compare them with other execution nodes")
+			flags.StringVar(&checkpointSyncPeer, "checkpoint-sync-peer", "", "secure gRPC address of a trusted execution node to download the root checkpoint from when bootstrapping, instead of the bootstrap directory")
+			flags.StringVar(&checkpointSyncPeerID, "checkpoint-sync-peer-id", "", "node ID of the execution node given by --checkpoint-sync-peer, whose networking key authenticates the connection")
+			flags.BoolVar(&storeRegisterSets, "store-register-sets", false, "store the registers read and written by each executed transaction")
+			flags.BoolVar(&cadenceFunctionProfiling, "cadence-function-profiling", false, "enable tracing in the Cadence runtime, so that profiles of transactions include the Cadence functions they invoke")
+			flags.StringVar(&cadenceProfileDir, "cadence-profile-dir", filepath.Join(homedir, ".flow", "cadence_profiles"), "directory to write the profiles of transactions collected with the profile-cadence admin command")
 			flags.StringVar(&preferredExeNodeIDStr, "preferred-exe-node-id", "", "node ID for preferred execution node used for state sync")
 			flags.UintVar(&transactionResultsCacheSize, "transaction-results-cache-size", 10000, "number of transaction results to be cached")
 			flags.BoolVar(&syncByBlocks, "sync-by-blocks", true, "deprecated, sync by blocks instead of execution state deltas")
@@ -179,6 +227,35 @@ func main() {
 		AdminCommand("set-uploader-enabled", func(config *cmd.NodeConfig) commands.AdminCommand {
 			return uploaderCommands.NewToggleUploaderCommand()
 		}).
+		AdminCommand("compare-execution", func(config *cmd.NodeConfig) commands.AdminCommand {
+			// the execution state is only loaded after the admin server is started
+			return executionCommands.NewCompareExecutionCommand(func() executionComparison.Source {
+				if blockExecutions == nil {
+					return nil
+				}
+				return blockExecutions
+			})
+		}).
+		AdminCommand("profile-cadence", func(config *cmd.NodeConfig) commands.AdminCommand {
+			return executionCommands.NewProfileCadenceCommand(cadenceProfiles, cadenceProfileDir)
+		}).
+		AdminCommand("backup-database", func(config *cmd.NodeConfig) commands.AdminCommand {
+			// the chunk data packs database is opened by a module, so before the admin server is
+			// started, but the execution state is only loaded after by another component, the
+			// checkpoint waits for it
+			return storageCommands.NewBackupDatabaseCommand(config.DB, chunkDataPacksDB, func(ctx context.Context, commit flow.StateCommitment, w io.Writer) error {
+				select {
+				case <-ledgerLoaded:
+				case <-ctx.Done():
+					return fmt.Errorf("execution state is not loaded yet: %w", ctx.Err())
+				}
+				t, err := ledgerStorage.Trie(flowledger.State(commit))
+				if err != nil {
+					return err
+				}
+				return wal.StoreCheckpoint(w, t)
+			})
+		}).
 		Module("mutable follower state", func(node *cmd.NodeConfig) error {
 			// For now, we only support state implementations from package badger.
 			// If we ever support different implementations, the following can be replaced by a type-aware factory
@@ -208,6 +285,44 @@ func main() {
 			syncCore, err = chainsync.New(node.Logger, chainsync.DefaultConfig())
 			return err
 		}).
+		Module("chunk data packs database", func(node *cmd.NodeConfig) error {
+			// Pre-create DB path (Badger creates only one-level dirs)
+			err := os.MkdirAll(chunkDataPackDir, 0700)
+			if err != nil {
+				return fmt.Errorf("could not create chunk data packs dir: %w", err)
+			}
+
+			opts := badgerdb.
+				DefaultOptions(chunkDataPackDir).
+				WithKeepL0InMemory(true).
+				WithLogger(nil).
+				WithValueLogFileSize(128 << 23).
+				WithValueLogMaxEntries(100000)
+
+			chunkDataPacksDB, err = storage.InitChunkDataPacks(opts)
+			if err != nil {
+				return fmt.Errorf("could not open chunk data packs db: %w", err)
+			}
+			nodeBuilder.ShutdownFunc(func() error {
+				if err := chunkDataPacksDB.Close(); err != nil {
+					return fmt.Errorf("error closing chunk data packs database: %w", err)
+				}
+				return nil
+			})
+
+			// chunk data packs stored before the separate database was introduced are only looked up
+			// in it, so they are moved before anything is served. The migrate-chunk-data-packs util
+			// command can be used to move them ahead of the upgrade, as this delays the startup.
+			moved, err := storage.MigrateChunkDataPacks(node.Logger, node.DB, chunkDataPacksDB, 1000)
+			if err != nil {
+				return fmt.Errorf("could not move chunk data packs to the chunk data packs db, %d moved so far: %w", moved, err)
+			}
+			if moved > 0 {
+				node.Logger.Info().Int("moved", moved).Msg("chunk data packs moved from the protocol db to the chunk data packs db")
+			}
+
+			return nil
+		}).
 		Module("execution receipts storage", func(node *cmd.NodeConfig) error {
 			results = storage.NewExecutionResults(node.Metrics.Cache, node.DB)
 			myReceipts = storage.NewMyExecutionReceipts(node.Metrics.Cache, node.DB, node.Storage.Receipts.(*storage.ExecutionReceipts))
@@ -307,11 +422,24 @@ func main() {
 
 			// if the execution database does not exist, then we need to bootstrap the execution database.
 			if !bootstrapped {
-				// when bootstrapping, the bootstrap folder must have a checkpoint file
-				// we need to cover this file to the trie folder to restore the trie to restore the execution state.
-				err = copyBootstrapState(node.BootstrapDir, triedir)
-				if err != nil {
-					return nil, fmt.Errorf("could not load bootstrap state from checkpoint file: %w", err)
+				if checkpointSyncPeer != "" {
+					peer, err := checkpointSyncPeerIdentity(node.State, checkpointSyncPeer, checkpointSyncPeerID)
+					if err != nil {
+						return nil, err
+					}
+					// download the execution state at the root block from the peer, verified against
+					// the root seal, and store it as the root checkpoint in the trie folder.
+					err = checkpointsync.BootstrapFromPeer(context.Background(), node.Logger, peer, node.RootSeal.BlockID, node.RootSeal.FinalState, triedir)
+					if err != nil {
+						return nil, fmt.Errorf("could not load bootstrap state from peer: %w", err)
+					}
+				} else {
+					// when bootstrapping, the bootstrap folder must have a checkpoint file
+					// we need to cover this file to the trie folder to restore the trie to restore the execution state.
+					err = copyBootstrapState(node.BootstrapDir, triedir)
+					if err != nil {
+						return nil, fmt.Errorf("could not load bootstrap state from checkpoint file: %w", err)
+					}
 				}
 
 				// TODO: check that the checkpoint file contains the root block's statecommit hash
@@ -331,7 +459,11 @@ func main() {
 			}
 
 			ledgerStorage, err = ledger.NewLedger(diskWAL, int(mTrieCacheSize), collector, node.Logger.With().Str("subcomponent", "ledger").Logger(), ledger.DefaultPathFinderVersion)
-			return ledgerStorage, err
+			if err != nil {
+				return nil, err
+			}
+			close(ledgerLoaded)
+			return ledgerStorage, nil
 		}).
 		Component("execution state ledger WAL compactor", func(node *cmd.NodeConfig) (module.ReadyDoneAware, error) {
 
@@ -422,12 +554,23 @@ func main() {
 
 			extralog.ExtraLogDumpPath = extraLogPath
 
-			rt := fvm.NewInterpreterRuntime()
+			rt := fvm.NewInterpreterRuntime(runtime.WithTracingEnabled(cadenceFunctionProfiling))
 
 			vm := fvm.NewVirtualMachine(rt)
 			vmCtx := fvm.NewContext(node.Logger, node.FvmOptions...)
 
 			committer := committer.NewLedgerViewCommitter(ledgerStorage, node.Tracer)
+			blockComputerOpts := []computer.BlockComputerOption{
+				computer.WithParallelExecution(parallelExecutionWorkers),
+				computer.WithProfiles(cadenceProfiles),
+			}
+			if storeRegisterSets {
+				blockComputerOpts = append(blockComputerOpts, computer.WithRegisterSets())
+			}
+			var warmUpContracts realstorage.WarmUpContracts
+			if programsWarmUp {
+				warmUpContracts = storage.NewWarmUpContracts(node.DB)
+			}
 			manager, err := computation.New(
 				node.Logger,
 				collector,
@@ -437,24 +580,29 @@ func main() {
 				vm,
 				vmCtx,
 				cadenceExecutionCache,
+				warmUpContracts,
 				committer,
 				scriptLogThreshold,
 				blockDataUploaders,
 				executionDataService,
 				executionDataCIDCache,
+				blockComputerOpts...,
 			)
 			if err != nil {
 				return nil, err
 			}
 			computationManager = manager
 
-			chunkDataPacks := storage.NewChunkDataPacks(node.Metrics.Cache, node.DB, node.Storage.Collections, chdpCacheSize)
-			stateCommitments := storage.NewCommits(node.Metrics.Cache, node.DB)
+			chunkDataPacks = storage.NewChunkDataPacks(node.Metrics.Cache, chunkDataPacksDB, node.Storage.Collections, chdpCacheSize)
+			kvdb := badgerimpl.ToDB(node.DB)
+			stateCommitments := store.NewCommits(node.Metrics.Cache, kvdb)
 
 			// Needed for gRPC server, make sure to assign to main scoped vars
-			events = storage.NewEvents(node.Metrics.Cache, node.DB)
-			serviceEvents = storage.NewServiceEvents(node.Metrics.Cache, node.DB)
-			txResults = storage.NewTransactionResults(node.Metrics.Cache, node.DB, transactionResultsCacheSize)
+			events = store.NewEvents(node.Metrics.Cache, kvdb)
+			serviceEvents = store.NewServiceEvents(node.Metrics.Cache, kvdb)
+			txResults = store.NewTransactionResults(node.Metrics.Cache, kvdb, transactionResultsCacheSize)
+			// register sets are cached per block, and can be large
+			registerSets = storage.NewTransactionRegisterSets(node.Metrics.Cache, node.DB, 100)
 
 			executionState = state.NewExecutionState(
 				ledgerStorage,
@@ -469,9 +617,12 @@ func main() {
 				events,
 				serviceEvents,
 				txResults,
+				registerSets,
 				node.DB,
+				chunkDataPacksDB,
 				node.Tracer,
 			)
+			blockExecutions = executionComparison.NewServer(node.Logger, executionState, results)
 
 			providerEngine, err = exeprovider.New(
 				node.Logger,
@@ -501,6 +652,18 @@ func main() {
 			}
 			blockView := executionState.NewView(stateCommit)
 
+			if programsWarmUp {
+				header, err := node.Storage.Headers.ByBlockID(blockID)
+				if err != nil {
+					return nil, fmt.Errorf("cannot get the latest executed block %s: %w", blockID.String(), err)
+				}
+				// failing to warm up the programs only slows down the first blocks executed
+				err = manager.WarmUpPrograms(header, blockView.NewChild())
+				if err != nil {
+					node.Logger.Warn().Err(err).Msg("could not warm up the programs")
+				}
+			}
+
 			// Get the epoch counter from the smart contract at the last executed block.
 			contractEpochCounter, err := getContractEpochCounter(vm, vmCtx, blockView)
 			// Failing to fetch the epoch counter from the smart contract is a fatal error.
@@ -547,6 +710,22 @@ func main() {
 			)
 			return checkerEng, nil
 		}).
+		Component("chunk data packs pruner", func(node *cmd.NodeConfig) (module.ReadyDoneAware, error) {
+			if chunkDataPackPruningThreshold == 0 {
+				return &module.NoopReadyDoneAware{}, nil
+			}
+			chunkDataPacksPruner = pruner.NewChunkDataPacks(
+				node.Logger,
+				node.State,
+				executionState,
+				node.Storage.Headers,
+				results,
+				chunkDataPacks,
+				chunkDataPacksDB,
+				chunkDataPackPruningThreshold,
+			)
+			return chunkDataPacksPruner, nil
+		}).
 		Component("ingestion engine", func(node *cmd.NodeConfig) (module.ReadyDoneAware, error) {
 			collectionRequester, err = requester.New(node.Logger, node.Metrics.Engine, node.Network, node.Me, node.State,
 				engine.RequestCollections,
@@ -629,6 +808,9 @@ func main() {
 
 			finalizationDistributor = pubsub.NewFinalizationDistributor()
 			finalizationDistributor.AddConsumer(checkerEng)
+			if chunkDataPacksPruner != nil {
+				finalizationDistributor.AddConsumer(chunkDataPacksPruner)
+			}
 
 			// creates a consensus follower with ingestEngine as the notifier
 			// so that it gets notified upon each new finalized block
@@ -706,7 +888,30 @@ func main() {
 			return syncEngine, nil
 		}).
 		Component("grpc server", func(node *cmd.NodeConfig) (module.ReadyDoneAware, error) {
-			rpcEng := rpc.New(node.Logger, rpcConf, ingestionEng, node.Storage.Blocks, node.Storage.Headers, node.State, events, results, txResults, node.RootChainID)
+			var checkpointServer checkpointspb.CheckpointAPIServer
+			if serveCheckpoints {
+				checkpointer, err := ledgerStorage.Checkpointer()
+				if err != nil {
+					return nil, fmt.Errorf("cannot create checkpointer: %w", err)
+				}
+				checkpointServer = checkpointsync.NewServer(node.Logger, node.State, executionState, ledgerStorage, checkpointer, checkpointsync.DefaultChunkSize)
+
+				// the execution state is served on a secure server, authenticated by the networking key
+				x509Certificate, err := grpcutils.X509Certificate(node.NetworkKey)
+				if err != nil {
+					return nil, fmt.Errorf("could not create server certificate: %w", err)
+				}
+				rpcConf.TransportCredentials = credentials.NewTLS(grpcutils.DefaultServerTLSConfig(x509Certificate))
+			}
+			var comparisonServer comparisonpb.ComparisonAPIServer
+			if serveBlockExecutions {
+				comparisonServer = blockExecutions
+			}
+			// propagate the spans of the access nodes if tracing is enabled
+			if tracer, ok := node.Tracer.(opentracing.Tracer); ok {
+				rpcConf.Tracer = tracer
+			}
+			rpcEng := rpc.New(node.Logger, rpcConf, ingestionEng, node.Storage.Blocks, node.Storage.Headers, node.State, events, results, txResults, registerSets, checkpointServer, comparisonServer, node.RootChainID)
 			return rpcEng, nil
 		})
 
@@ -751,6 +956,26 @@ func getContractEpochCounter(vm *fvm.VirtualMachine, vmCtx fvm.Context, view *de
 	return epochCounter, nil
 }
 
+// checkpointSyncPeerIdentity returns the execution node to download the root checkpoint from. Its networking
+// key, which authenticates the connection, is taken from the identity table of the root snapshot.
+func checkpointSyncPeerIdentity(state protocol.State, address string, nodeID string) (checkpointsync.Peer, error) {
+	if nodeID == "" {
+		return checkpointsync.Peer{}, fmt.Errorf("--checkpoint-sync-peer-id must be set with --checkpoint-sync-peer")
+	}
+	peerID, err := flow.HexStringToIdentifier(nodeID)
+	if err != nil {
+		return checkpointsync.Peer{}, fmt.Errorf("invalid checkpoint sync peer ID %s: %w", nodeID, err)
+	}
+	identity, err := state.Final().Identity(peerID)
+	if err != nil {
+		return checkpointsync.Peer{}, fmt.Errorf("could not get identity of checkpoint sync peer %v: %w", peerID, err)
+	}
+	if identity.Role != flow.RoleExecution {
+		return checkpointsync.Peer{}, fmt.Errorf("checkpoint sync peer %v is not an execution node, but a %s node", peerID, identity.Role)
+	}
+	return checkpointsync.Peer{Address: address, NetworkPubKey: identity.NetworkPubKey}, nil
+}
+
 // copy the checkpoint files from the bootstrap folder to the execution state folder
 // Checkpoint file is required to restore the trie, and has to be placed in the execution
 // state folder.
@@ -19,6 +19,7 @@ import (
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module"
 	"github.com/onflow/flow-go/module/id"
+	"github.com/onflow/flow-go/module/trace"
 	"github.com/onflow/flow-go/network"
 	"github.com/onflow/flow-go/network/p2p"
 	"github.com/onflow/flow-go/network/topology"
@@ -115,6 +116,10 @@ type BaseConfig struct {
 	AdminCert                       string
 	AdminKey                        string
 	AdminClientCAs                  string
+	AdminAuthConfig                 string
+	AdminAuditLog                   string
+	RemoteSignerSocket              string
+	RemoteSignerToken               string
 	BindAddr                        string
 	NodeRole                        string
 	DynamicStartupANAddress         string
@@ -139,6 +144,10 @@ type BaseConfig struct {
 	profilerMemProfileRate          int
 	tracerEnabled                   bool
 	tracerSensitivity               uint
+	tracerExporter                  string
+	tracerFile                      string
+	tracerSamplingRatio             float64
+	tracerSampling                  map[string]string
 	MetricsEnabled                  bool
 	guaranteesCacheSize             uint
 	receiptsCacheSize               uint
@@ -204,6 +213,10 @@ func DefaultBaseConfig() *BaseConfig {
 		AdminCert:                       NotSet,
 		AdminKey:                        NotSet,
 		AdminClientCAs:                  NotSet,
+		AdminAuthConfig:                 NotSet,
+		AdminAuditLog:                   NotSet,
+		RemoteSignerSocket:              NotSet,
+		RemoteSignerToken:               NotSet,
 		BindAddr:                        NotSet,
 		BootstrapDir:                    "bootstrap",
 		datadir:                         datadir,
@@ -220,6 +233,10 @@ func DefaultBaseConfig() *BaseConfig {
 		profilerMemProfileRate:          runtime.MemProfileRate,
 		tracerEnabled:                   false,
 		tracerSensitivity:               4,
+		tracerExporter:                  trace.ExporterOTLP,
+		tracerFile:                      "traces.json",
+		tracerSamplingRatio:             1,
+		tracerSampling:                  map[string]string{},
 		MetricsEnabled:                  true,
 		receiptsCacheSize:               bstorage.DefaultCacheSize,
 		guaranteesCacheSize:             bstorage.DefaultCacheSize,
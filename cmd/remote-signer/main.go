@@ -0,0 +1,156 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/rs/zerolog"
+	"github.com/spf13/pflag"
+	"google.golang.org/grpc"
+
+	"github.com/onflow/flow-go/cmd"
+	"github.com/onflow/flow-go/crypto"
+	"github.com/onflow/flow-go/model/encodable"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/remotesigner"
+	pb "github.com/onflow/flow-go/remotesigner/remotesigner"
+	storage "github.com/onflow/flow-go/storage/badger"
+)
+
+var (
+	flagSocket        string
+	flagToken         string
+	flagGenerateToken bool
+	flagBootstrapDir  string
+	flagNodeID        string
+	flagBeaconKeys    []string
+	flagDatadir       string
+	flagLogLevel      string
+)
+
+func main() {
+	pflag.StringVar(&flagSocket, "socket", filepath.Join(os.TempDir(), "flow-remote-signer.sock"), "path of the unix socket to listen on")
+	pflag.StringVar(&flagToken, "token", "", "path of the file containing the token authenticating the node")
+	pflag.BoolVar(&flagGenerateToken, "generate-token", false, "generate the token file if it doesn't exist")
+	pflag.StringVar(&flagBootstrapDir, "bootstrapdir", "bootstrap", "path to the bootstrap directory containing the private node info")
+	pflag.StringVar(&flagNodeID, "nodeid", "", "ID of the node the keys belong to")
+	pflag.StringSliceVar(&flagBeaconKeys, "beacon-key", nil, "random beacon private key file for an epoch, formatted as <epoch counter>=<path> (can be repeated)")
+	pflag.StringVar(&flagDatadir, "datadir", "remote-signer", "directory of the slashing protection database")
+	pflag.StringVar(&flagLogLevel, "loglevel", "info", "level for logging output")
+	pflag.Parse()
+
+	log := zerolog.New(os.Stderr).With().Timestamp().Logger()
+	level, err := zerolog.ParseLevel(strings.ToLower(flagLogLevel))
+	if err != nil {
+		log.Fatal().Err(err).Msg("invalid log level")
+	}
+	log = log.Level(level)
+
+	if flagToken == "" {
+		log.Fatal().Msg("--token is required")
+	}
+	token, err := remotesigner.LoadToken(flagToken)
+	if errors.Is(err, os.ErrNotExist) && flagGenerateToken {
+		token, err = remotesigner.GenerateToken(flagToken)
+		if err != nil {
+			log.Fatal().Err(err).Msg("could not generate token")
+		}
+		log.Info().Str("path", flagToken).Msg("generated token")
+	}
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not load token")
+	}
+
+	nodeID, err := flow.HexStringToIdentifier(flagNodeID)
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not parse node ID")
+	}
+	info, err := cmd.LoadPrivateNodeInfo(flagBootstrapDir, nodeID)
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not load private node info")
+	}
+
+	beaconKeys, err := loadBeaconKeys(flagBeaconKeys)
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not load random beacon keys")
+	}
+
+	db, err := storage.InitSlashingProtection(badger.DefaultOptions(flagDatadir).WithLogger(nil))
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not open slashing protection database")
+	}
+	defer db.Close()
+
+	protection, err := remotesigner.NewSlashingProtection(db)
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not initialize slashing protection")
+	}
+
+	listener, err := remotesigner.Listen(flagSocket)
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not listen")
+	}
+
+	server := grpc.NewServer(remotesigner.TokenInterceptor(token))
+	pb.RegisterRemoteSignerServer(server, remotesigner.NewServer(log, info.StakingPrivKey.PrivateKey, beaconKeys, protection))
+
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-sig
+		log.Info().Msg("remote signer shutting down")
+		server.GracefulStop()
+	}()
+
+	log.Info().
+		Str("socket", flagSocket).
+		Hex("node_id", nodeID[:]).
+		Int("beacon_keys", len(beaconKeys)).
+		Msg("remote signer listening")
+
+	err = server.Serve(listener)
+	if err != nil {
+		log.Error().Err(err).Msg("remote signer failed")
+	}
+}
+
+// loadBeaconKeys loads the random beacon keys from files given as <epoch counter>=<path>.
+func loadBeaconKeys(files []string) (map[uint64]crypto.PrivateKey, error) {
+	keys := make(map[uint64]crypto.PrivateKey, len(files))
+	for _, file := range files {
+		parts := strings.SplitN(file, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid random beacon key %s, expected <epoch counter>=<path>", file)
+		}
+
+		epoch, err := strconv.ParseUint(parts[0], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid epoch counter %s: %w", parts[0], err)
+		}
+		if _, ok := keys[epoch]; ok {
+			return nil, fmt.Errorf("duplicate random beacon key for epoch %d", epoch)
+		}
+
+		data, err := ioutil.ReadFile(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("could not read random beacon key for epoch %d: %w", epoch, err)
+		}
+
+		var priv encodable.RandomBeaconPrivKey
+		err = json.Unmarshal(data, &priv)
+		if err != nil {
+			return nil, fmt.Errorf("could not decode random beacon key for epoch %d: %w", epoch, err)
+		}
+		keys[epoch] = priv.PrivateKey
+	}
+	return keys, nil
+}
@@ -26,8 +26,10 @@ import (
 	storageCommands "github.com/onflow/flow-go/admin/commands/storage"
 	"github.com/onflow/flow-go/cmd/build"
 	"github.com/onflow/flow-go/consensus/hotstuff/persister"
+	"github.com/onflow/flow-go/crypto"
 	"github.com/onflow/flow-go/fvm"
 	"github.com/onflow/flow-go/model/bootstrap"
+	"github.com/onflow/flow-go/model/encodable"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/model/flow/filter"
 	"github.com/onflow/flow-go/module"
@@ -44,6 +46,7 @@ import (
 	"github.com/onflow/flow-go/network/p2p/dns"
 	"github.com/onflow/flow-go/network/p2p/unicast"
 	"github.com/onflow/flow-go/network/topology"
+	"github.com/onflow/flow-go/remotesigner"
 	"github.com/onflow/flow-go/state/protocol"
 	badgerState "github.com/onflow/flow-go/state/protocol/badger"
 	"github.com/onflow/flow-go/state/protocol/events"
@@ -127,11 +130,23 @@ func (fnb *FlowNodeBuilder) BaseFlags() {
 		"whether to enable tracer")
 	fnb.flags.UintVar(&fnb.BaseConfig.tracerSensitivity, "tracer-sensitivity", defaultConfig.tracerSensitivity,
 		"adjusts the level of sampling when tracing is enabled. 0 means capture everything, higher value results in less samples")
+	fnb.flags.StringVar(&fnb.BaseConfig.tracerExporter, "tracer-exporter", defaultConfig.tracerExporter,
+		"where to export the spans: otlp (configured with the OTEL_EXPORTER_OTLP_* environment variables) or file")
+	fnb.flags.StringVar(&fnb.BaseConfig.tracerFile, "tracer-file", defaultConfig.tracerFile, "file to write the spans to with the file exporter")
+	fnb.flags.Float64Var(&fnb.BaseConfig.tracerSamplingRatio, "tracer-sampling-ratio", defaultConfig.tracerSamplingRatio,
+		"ratio of the spans to sample, between 0 and 1, for the spans without a ratio in tracer-sampling")
+	fnb.flags.StringToStringVar(&fnb.BaseConfig.tracerSampling, "tracer-sampling", defaultConfig.tracerSampling,
+		"ratio of the spans to sample per span name, e.g. exe.ingestion.executeBlock=0.5,con.builder.buildOn=0.1")
 
 	fnb.flags.StringVar(&fnb.BaseConfig.AdminAddr, "admin-addr", defaultConfig.AdminAddr, "address to bind on for admin HTTP server")
 	fnb.flags.StringVar(&fnb.BaseConfig.AdminCert, "admin-cert", defaultConfig.AdminCert, "admin cert file (for TLS)")
 	fnb.flags.StringVar(&fnb.BaseConfig.AdminKey, "admin-key", defaultConfig.AdminKey, "admin key file (for TLS)")
 	fnb.flags.StringVar(&fnb.BaseConfig.AdminClientCAs, "admin-client-certs", defaultConfig.AdminClientCAs, "admin client certs (for mutual TLS)")
+	fnb.flags.StringVar(&fnb.BaseConfig.AdminAuthConfig, "admin-auth-config", defaultConfig.AdminAuthConfig, "JSON file mapping admin bearer token hashes and client certificate common names to roles, enables admin authentication")
+	fnb.flags.StringVar(&fnb.BaseConfig.AdminAuditLog, "admin-audit-log", defaultConfig.AdminAuditLog, "file the admin command invocations are appended to, they are only kept in memory if not set")
+
+	fnb.flags.StringVar(&fnb.BaseConfig.RemoteSignerSocket, "remote-signer-socket", defaultConfig.RemoteSignerSocket, "unix socket of the remote signer holding the staking and random beacon keys, if not set the keys are used in process")
+	fnb.flags.StringVar(&fnb.BaseConfig.RemoteSignerToken, "remote-signer-token", defaultConfig.RemoteSignerToken, "file containing the token authenticating the node to the remote signer")
 
 	fnb.flags.DurationVar(&fnb.BaseConfig.DNSCacheTTL, "dns-cache-ttl", defaultConfig.DNSCacheTTL, "time-to-live for dns cache")
 	fnb.flags.StringSliceVar(&fnb.BaseConfig.PreferredUnicastProtocols, "preferred-unicast-protocols", nil, "preferred unicast protocols in ascending order of preference")
@@ -304,6 +319,12 @@ func (fnb *FlowNodeBuilder) EnqueueAdminServerInit() {
 				command := commandFunc(fnb.NodeConfig)
 				fnb.adminCommandBootstrapper.RegisterHandler(commandName, command.Handler)
 				fnb.adminCommandBootstrapper.RegisterValidator(commandName, command.Validator)
+				if _, ok := command.(commands.ReadOnlyAdminCommand); ok {
+					fnb.adminCommandBootstrapper.RegisterPermission(commandName, admin.PermissionReadOnly)
+				}
+				if _, ok := command.(commands.JobAdminCommand); ok {
+					fnb.adminCommandBootstrapper.RegisterJob(commandName)
+				}
 			}
 
 			var opts []admin.CommandRunnerOption
@@ -329,6 +350,27 @@ func (fnb *FlowNodeBuilder) EnqueueAdminServerInit() {
 				opts = append(opts, admin.WithTLS(config))
 			}
 
+			if node.AdminAuthConfig != NotSet {
+				authConfig, err := admin.LoadAuthConfig(node.AdminAuthConfig)
+				if err != nil {
+					return nil, err
+				}
+				authenticator, err := admin.NewAuthenticator(authConfig)
+				if err != nil {
+					return nil, fmt.Errorf("invalid admin auth config: %w", err)
+				}
+				opts = append(opts, admin.WithAuthenticator(authenticator))
+			}
+
+			if node.AdminAuditLog != NotSet {
+				auditLog, err := admin.NewAuditLog(node.AdminAuditLog, admin.DefaultAuditLogRetained)
+				if err != nil {
+					return nil, err
+				}
+				fnb.ShutdownFunc(auditLog.Close)
+				opts = append(opts, admin.WithAuditLog(auditLog))
+			}
+
 			command_runner := fnb.adminCommandBootstrapper.Bootstrap(fnb.Logger, fnb.AdminAddr, opts...)
 
 			return command_runner, nil
@@ -381,12 +423,23 @@ func (fnb *FlowNodeBuilder) initNodeInfo() {
 		fnb.Logger.Fatal().Err(err).Msgf("could not parse node ID from string: %v", fnb.BaseConfig.nodeIDHex)
 	}
 
+	fnb.NodeID = nodeID
+
+	if fnb.BaseConfig.RemoteSignerSocket != NotSet {
+		// the staking key is held by the remote signer, only load the networking key
+		networkKey, err := loadPrivateNetworkKey(fnb.BaseConfig.BootstrapDir, nodeID)
+		if err != nil {
+			fnb.Logger.Fatal().Err(err).Msg("failed to load private networking key")
+		}
+		fnb.NetworkKey = networkKey
+		return
+	}
+
 	info, err := LoadPrivateNodeInfo(fnb.BaseConfig.BootstrapDir, nodeID)
 	if err != nil {
 		fnb.Logger.Fatal().Err(err).Msg("failed to load private node info")
 	}
 
-	fnb.NodeID = nodeID
 	fnb.NetworkKey = info.NetworkPrivKey.PrivateKey
 	fnb.StakingKey = info.StakingPrivKey.PrivateKey
 }
@@ -420,10 +473,16 @@ func (fnb *FlowNodeBuilder) initMetrics() {
 	fnb.Tracer = trace.NewNoopTracer()
 	if fnb.BaseConfig.tracerEnabled {
 		serviceName := fnb.BaseConfig.NodeRole + "-" + fnb.BaseConfig.nodeIDHex[:8]
+		exporter, err := trace.NewExporter(fnb.BaseConfig.tracerExporter, fnb.BaseConfig.tracerFile)
+		fnb.MustNot(err).Msg("could not initialize trace exporter")
+		ratios, err := trace.ParseSamplingRatios(fnb.BaseConfig.tracerSampling)
+		fnb.MustNot(err).Msg("could not parse trace sampling ratios")
 		tracer, err := trace.NewTracer(fnb.Logger,
 			serviceName,
 			fnb.RootChainID.String(),
-			fnb.tracerSensitivity)
+			fnb.tracerSensitivity,
+			trace.WithExporter(exporter),
+			trace.WithSampler(trace.NewSpanNameSampler(fnb.BaseConfig.tracerSamplingRatio, ratios)))
 		fnb.MustNot(err).Msg("could not initialize tracer")
 		fnb.Logger.Info().Msg("Tracer Started")
 		fnb.Tracer = tracer
@@ -596,21 +655,23 @@ func (fnb *FlowNodeBuilder) initStorage() {
 	setups := bstorage.NewEpochSetups(fnb.Metrics.Cache, fnb.DB)
 	commits := bstorage.NewEpochCommits(fnb.Metrics.Cache, fnb.DB)
 	statuses := bstorage.NewEpochStatuses(fnb.Metrics.Cache, fnb.DB)
+	evidence := bstorage.NewSlashingEvidence(fnb.DB)
 
 	fnb.Storage = Storage{
-		Headers:      headers,
-		Guarantees:   guarantees,
-		Receipts:     receipts,
-		Results:      results,
-		Seals:        seals,
-		Index:        index,
-		Payloads:     payloads,
-		Blocks:       blocks,
-		Transactions: transactions,
-		Collections:  collections,
-		Setups:       setups,
-		EpochCommits: commits,
-		Statuses:     statuses,
+		Headers:          headers,
+		Guarantees:       guarantees,
+		Receipts:         receipts,
+		Results:          results,
+		Seals:            seals,
+		Index:            index,
+		Payloads:         payloads,
+		Blocks:           blocks,
+		Transactions:     transactions,
+		Collections:      collections,
+		Setups:           setups,
+		EpochCommits:     commits,
+		Statuses:         statuses,
+		SlashingEvidence: evidence,
 	}
 }
 
@@ -774,6 +835,17 @@ func (fnb *FlowNodeBuilder) initLocal() {
 	if !self.NetworkPubKey.Equals(fnb.NetworkKey.PublicKey()) {
 		fnb.Logger.Fatal().Msg("configured networking key does not match protocol state")
 	}
+
+	if fnb.RemoteSignerSocket != NotSet {
+		// the staking key is held by the remote signer, the client checks it against the protocol state
+		token, err := remotesigner.LoadToken(fnb.RemoteSignerToken)
+		fnb.MustNot(err).Msg("could not load remote signer token")
+
+		fnb.Me, err = remotesigner.NewClient(self, fnb.RemoteSignerSocket, token)
+		fnb.MustNot(err).Msg("could not initialize remote signer client")
+		return
+	}
+
 	if !self.StakingPubKey.Equals(fnb.StakingKey.PublicKey()) {
 		fnb.Logger.Fatal().Msg("configured staking key does not match protocol state")
 	}
@@ -1077,6 +1149,10 @@ func (fnb *FlowNodeBuilder) RegisterDefaultAdminCommands() {
 		return storageCommands.NewReadResultsCommand(config.State, config.Storage.Results)
 	}).AdminCommand("read-seals", func(config *NodeConfig) commands.AdminCommand {
 		return storageCommands.NewReadSealsCommand(config.State, config.Storage.Seals, config.Storage.Index)
+	}).AdminCommand("read-slashing-evidence", func(config *NodeConfig) commands.AdminCommand {
+		return storageCommands.NewReadSlashingEvidenceCommand(config.Storage.SlashingEvidence)
+	}).AdminCommand("backup-database", func(config *NodeConfig) commands.AdminCommand {
+		return storageCommands.NewBackupDatabaseCommand(config.DB, nil, nil)
 	})
 }
 
@@ -1213,6 +1289,28 @@ func LoadPrivateNodeInfo(dir string, myID flow.Identifier) (*bootstrap.NodeInfoP
 	return &info, err
 }
 
+// loadPrivateNetworkKey loads the networking private key from the private node info,
+// without decoding the staking private key, which may be missing from the file when
+// it is held by a remote signer.
+func loadPrivateNetworkKey(dir string, myID flow.Identifier) (crypto.PrivateKey, error) {
+	path := filepath.Join(dir, fmt.Sprintf(bootstrap.PathNodeInfoPriv, myID))
+	data, err := io.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read private node info (path=%s): %w", path, err)
+	}
+	var info struct {
+		NetworkPrivKey encodable.NetworkPrivKey
+	}
+	err = json.Unmarshal(data, &info)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode private node info (path=%s): %w", path, err)
+	}
+	if info.NetworkPrivKey.PrivateKey == nil {
+		return nil, fmt.Errorf("missing networking key in private node info (path=%s)", path)
+	}
+	return info.NetworkPrivKey.PrivateKey, nil
+}
+
 // loadSecretsEncryptionKey loads the encryption key for the secrets database.
 // If the file does not exist, returns os.ErrNotExist.
 func loadSecretsEncryptionKey(dir string, myID flow.Identifier) ([]byte, error) {
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
@@ -16,6 +17,8 @@ import (
 
 	"github.com/onflow/flow-go/cmd/bootstrap/utils"
 	"github.com/onflow/flow-go/model/bootstrap"
+	"github.com/onflow/flow-go/model/encodable"
+	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module"
 	"github.com/onflow/flow-go/module/component"
 	"github.com/onflow/flow-go/module/irrecoverable"
@@ -52,6 +55,44 @@ func TestLoadSecretsEncryptionKey(t *testing.T) {
 	})
 }
 
+// TestLoadPrivateNetworkKey checks that the networking key is loaded from private node
+// info missing the staking key, as when the staking key is held by a remote signer.
+func TestLoadPrivateNetworkKey(t *testing.T) {
+	myID := unittest.IdentifierFixture()
+
+	unittest.RunWithTempDir(t, func(dir string) {
+		path := filepath.Join(dir, fmt.Sprintf(bootstrap.PathNodeInfoPriv, myID))
+		err := os.MkdirAll(filepath.Dir(path), 0700)
+		require.NoError(t, err)
+
+		t.Run("should return key if the staking key is missing", func(t *testing.T) {
+			networkKey := unittest.NetworkingPrivKeyFixture()
+			data, err := json.Marshal(struct {
+				NodeID         flow.Identifier
+				NetworkPrivKey encodable.NetworkPrivKey
+			}{
+				NodeID:         myID,
+				NetworkPrivKey: encodable.NetworkPrivKey{PrivateKey: networkKey},
+			})
+			require.NoError(t, err)
+			err = ioutil.WriteFile(path, data, 0600)
+			require.NoError(t, err)
+
+			key, err := loadPrivateNetworkKey(dir, myID)
+			require.NoError(t, err)
+			assert.True(t, networkKey.Equals(key))
+		})
+
+		t.Run("should return error if the networking key is missing", func(t *testing.T) {
+			err := ioutil.WriteFile(path, []byte(`{"NodeID":"`+myID.String()+`"}`), 0600)
+			require.NoError(t, err)
+
+			_, err = loadPrivateNetworkKey(dir, myID)
+			assert.Error(t, err)
+		})
+	})
+}
+
 type testReadyDone struct {
 	name    string
 	readyFn func(string) <-chan struct{}
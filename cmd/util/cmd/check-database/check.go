@@ -0,0 +1,549 @@
+package check_database
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/storage/badger/operation"
+)
+
+// names of the checked invariants
+const (
+	CheckFinalizedChain    = "finalized_chain"
+	CheckHeightIndex       = "height_index"
+	CheckPayloadGuarantees = "payload_guarantees"
+	CheckPayloadSeals      = "payload_seals"
+	CheckPayloadReceipts   = "payload_receipts"
+	CheckPayloadResults    = "payload_results"
+	CheckBlockSeal         = "block_seal"
+)
+
+// Violation is an invariant of the database which doesn't hold.
+type Violation struct {
+	Check    string          `json:"check"`
+	Height   uint64          `json:"height"`
+	BlockID  flow.Identifier `json:"block_id"`
+	EntityID flow.Identifier `json:"entity_id,omitempty"`
+	Message  string          `json:"message"`
+	Repaired bool            `json:"repaired"`
+}
+
+// Summary is the result of a check of the database.
+type Summary struct {
+	CheckedBlocks uint64 `json:"checked_blocks"`
+	Violations    uint64 `json:"violations"`
+	Repaired      uint64 `json:"repaired"`
+}
+
+// Checker checks that the indexes of the protocol database are consistent
+// with each other, from the root block to the latest finalized block.
+//
+// Only the indexes which can be derived from primary data are repaired:
+//   * the height index, from the parents of the finalized blocks, if they lead to the root block
+//   * the latest seal of each finalized block, from the seals in the payloads
+// The other violations are only reported, as the missing data can't be restored.
+type Checker struct {
+	log    zerolog.Logger
+	db     *badger.DB
+	repair bool
+	report func(Violation)
+
+	summary Summary
+}
+
+func NewChecker(log zerolog.Logger, db *badger.DB, repair bool, report func(Violation)) *Checker {
+	return &Checker{
+		log:    log,
+		db:     db,
+		repair: repair,
+		report: report,
+	}
+}
+
+// Run checks the database, reporting the violations found. It returns an
+// error if the database can't be checked at all, e.g. because the root or
+// the finalized height is missing.
+func (c *Checker) Run() (Summary, error) {
+	c.summary = Summary{}
+
+	var rootHeight, finalizedHeight uint64
+	err := c.db.View(operation.RetrieveRootHeight(&rootHeight))
+	if err != nil {
+		return c.summary, fmt.Errorf("could not retrieve root height: %w", err)
+	}
+	err = c.db.View(operation.RetrieveFinalizedHeight(&finalizedHeight))
+	if err != nil {
+		return c.summary, fmt.Errorf("could not retrieve finalized height: %w", err)
+	}
+	var rootQC flow.QuorumCertificate
+	err = c.db.View(operation.RetrieveRootQuorumCertificate(&rootQC))
+	if err != nil {
+		return c.summary, fmt.Errorf("could not retrieve root quorum certificate: %w", err)
+	}
+
+	c.log.Info().
+		Uint64("root_height", rootHeight).
+		Uint64("finalized_height", finalizedHeight).
+		Msg("checking finalized chain")
+
+	err = c.checkFinalizedChain(rootHeight, finalizedHeight, rootQC.BlockID)
+	if err != nil {
+		return c.summary, fmt.Errorf("could not check finalized chain: %w", err)
+	}
+
+	c.log.Info().Msg("checking payloads of finalized blocks")
+
+	err = c.checkPayloads(rootHeight, finalizedHeight)
+	if err != nil {
+		return c.summary, fmt.Errorf("could not check payloads: %w", err)
+	}
+
+	return c.summary, nil
+}
+
+func (c *Checker) violation(v Violation) {
+	c.summary.Violations++
+	if v.Repaired {
+		c.summary.Repaired++
+	}
+	c.report(v)
+}
+
+// checkFinalizedChain walks the finalized blocks from the latest finalized
+// block down to the root block through their parents, checking that each
+// block is stored, and that the chain ends at the root block. Only then are
+// the blocks checked to be indexed at their height: a broken chain doesn't
+// tell which blocks are finalized, so its height index violations are only
+// reported, never repaired.
+func (c *Checker) checkFinalizedChain(rootHeight uint64, finalizedHeight uint64, rootID flow.Identifier) error {
+	var blockID flow.Identifier
+	err := c.db.View(operation.LookupBlockHeight(finalizedHeight, &blockID))
+	if err != nil {
+		return fmt.Errorf("could not look up latest finalized block at height %d: %w", finalizedHeight, err)
+	}
+
+	// finalized block IDs, from the latest finalized block down
+	var chain []flow.Identifier
+	valid, err := c.walkFinalizedChain(rootHeight, finalizedHeight, rootID, blockID, &chain)
+	if err != nil {
+		return err
+	}
+
+	for i, blockID := range chain {
+		err = c.checkHeightIndex(finalizedHeight-uint64(i), blockID, valid)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// walkFinalizedChain appends the finalized blocks from the given block down
+// to the root block to the chain, and returns whether the chain is valid. The
+// violations breaking the chain are reported.
+func (c *Checker) walkFinalizedChain(rootHeight uint64, finalizedHeight uint64, rootID flow.Identifier, blockID flow.Identifier, chain *[]flow.Identifier) (bool, error) {
+	for height := finalizedHeight; ; height-- {
+		var header flow.Header
+		err := c.db.View(operation.RetrieveHeader(blockID, &header))
+		if errors.Is(err, storage.ErrNotFound) {
+			c.violation(Violation{
+				Check:   CheckFinalizedChain,
+				Height:  height,
+				BlockID: blockID,
+				Message: "finalized block header is missing, the blocks below can't be checked",
+			})
+			return false, nil
+		}
+		if err != nil {
+			return false, fmt.Errorf("could not retrieve header %v: %w", blockID, err)
+		}
+		if header.Height != height {
+			c.violation(Violation{
+				Check:   CheckFinalizedChain,
+				Height:  height,
+				BlockID: blockID,
+				Message: fmt.Sprintf("finalized block has height %d", header.Height),
+			})
+			return false, nil
+		}
+
+		*chain = append(*chain, blockID)
+
+		if height == rootHeight {
+			if blockID != rootID {
+				c.violation(Violation{
+					Check:    CheckFinalizedChain,
+					Height:   height,
+					BlockID:  blockID,
+					EntityID: rootID,
+					Message:  "finalized chain doesn't end at the root block",
+				})
+				return false, nil
+			}
+			return true, nil
+		}
+
+		blockID = header.ParentID
+	}
+}
+
+// checkHeightIndex checks that the given block is indexed at the given height,
+// repairing the index if repair is true.
+func (c *Checker) checkHeightIndex(height uint64, blockID flow.Identifier, repair bool) error {
+	var indexed flow.Identifier
+	err := c.db.View(operation.LookupBlockHeight(height, &indexed))
+	if errors.Is(err, storage.ErrNotFound) {
+		repaired := false
+		if repair {
+			repaired, err = c.repairWith(operation.IndexBlockHeight(height, blockID))
+			if err != nil {
+				return fmt.Errorf("could not index height %d: %w", height, err)
+			}
+		}
+		c.violation(Violation{
+			Check:    CheckHeightIndex,
+			Height:   height,
+			BlockID:  blockID,
+			Message:  "finalized block is not indexed by height",
+			Repaired: repaired,
+		})
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("could not look up height %d: %w", height, err)
+	}
+
+	if indexed != blockID {
+		repaired := false
+		if repair {
+			repaired, err = c.repairWith(operation.ReindexBlockHeight(height, blockID))
+			if err != nil {
+				return fmt.Errorf("could not reindex height %d: %w", height, err)
+			}
+		}
+		c.violation(Violation{
+			Check:    CheckHeightIndex,
+			Height:   height,
+			BlockID:  blockID,
+			EntityID: indexed,
+			Message:  "height is indexed to another block than the finalized block",
+			Repaired: repaired,
+		})
+	}
+	return nil
+}
+
+// checkPayloads checks the payload indexes and the latest seal of the
+// finalized blocks, in ascending height order so the latest seal of each block
+// can be derived from the one of its parent.
+func (c *Checker) checkPayloads(rootHeight uint64, finalizedHeight uint64) error {
+	var parentSealID *flow.Identifier
+	for height := rootHeight; height <= finalizedHeight; height++ {
+		var blockID flow.Identifier
+		err := c.db.View(operation.LookupBlockHeight(height, &blockID))
+		if errors.Is(err, storage.ErrNotFound) {
+			// already reported by the finalized chain check
+			parentSealID = nil
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("could not look up height %d: %w", height, err)
+		}
+
+		c.summary.CheckedBlocks++
+
+		err = c.checkGuarantees(height, blockID)
+		if err != nil {
+			return err
+		}
+		err = c.checkReceipts(height, blockID)
+		if err != nil {
+			return err
+		}
+		err = c.checkResults(height, blockID)
+		if err != nil {
+			return err
+		}
+		sealIDs, err := c.checkSeals(height, blockID)
+		if err != nil {
+			return err
+		}
+
+		parentSealID, err = c.checkBlockSeal(height, blockID, sealIDs, parentSealID)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// lookupIndex looks up a payload index of the given block, reporting a
+// violation if it is missing.
+func (c *Checker) lookupIndex(check string, height uint64, blockID flow.Identifier, lookup func(flow.Identifier, *[]flow.Identifier) func(*badger.Txn) error) ([]flow.Identifier, bool, error) {
+	var ids []flow.Identifier
+	err := c.db.View(lookup(blockID, &ids))
+	if errors.Is(err, storage.ErrNotFound) {
+		c.violation(Violation{
+			Check:   check,
+			Height:  height,
+			BlockID: blockID,
+			Message: "payload index is missing",
+		})
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("could not look up %s of block %v: %w", check, blockID, err)
+	}
+	return ids, true, nil
+}
+
+// exists returns whether the entity retrieved by the given function is stored.
+func (c *Checker) exists(retrieve func(*badger.Txn) error) (bool, error) {
+	err := c.db.View(retrieve)
+	if errors.Is(err, storage.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (c *Checker) checkGuarantees(height uint64, blockID flow.Identifier) error {
+	guaranteeIDs, ok, err := c.lookupIndex(CheckPayloadGuarantees, height, blockID, operation.LookupPayloadGuarantees)
+	if err != nil || !ok {
+		return err
+	}
+	for _, guaranteeID := range guaranteeIDs {
+		var guarantee flow.CollectionGuarantee
+		found, err := c.exists(operation.RetrieveGuarantee(guaranteeID, &guarantee))
+		if err != nil {
+			return fmt.Errorf("could not retrieve guarantee %v: %w", guaranteeID, err)
+		}
+		if !found {
+			c.violation(Violation{
+				Check:    CheckPayloadGuarantees,
+				Height:   height,
+				BlockID:  blockID,
+				EntityID: guaranteeID,
+				Message:  "payload guarantee is missing",
+			})
+		}
+	}
+	return nil
+}
+
+func (c *Checker) checkReceipts(height uint64, blockID flow.Identifier) error {
+	receiptIDs, ok, err := c.lookupIndex(CheckPayloadReceipts, height, blockID, operation.LookupPayloadReceipts)
+	if err != nil || !ok {
+		return err
+	}
+	for _, receiptID := range receiptIDs {
+		var meta flow.ExecutionReceiptMeta
+		found, err := c.exists(operation.RetrieveExecutionReceiptMeta(receiptID, &meta))
+		if err != nil {
+			return fmt.Errorf("could not retrieve receipt %v: %w", receiptID, err)
+		}
+		if !found {
+			c.violation(Violation{
+				Check:    CheckPayloadReceipts,
+				Height:   height,
+				BlockID:  blockID,
+				EntityID: receiptID,
+				Message:  "payload receipt is missing",
+			})
+			continue
+		}
+
+		var result flow.ExecutionResult
+		found, err = c.exists(operation.RetrieveExecutionResult(meta.ResultID, &result))
+		if err != nil {
+			return fmt.Errorf("could not retrieve result %v: %w", meta.ResultID, err)
+		}
+		if !found {
+			c.violation(Violation{
+				Check:    CheckPayloadReceipts,
+				Height:   height,
+				BlockID:  blockID,
+				EntityID: receiptID,
+				Message:  fmt.Sprintf("result %v of payload receipt is missing", meta.ResultID),
+			})
+		}
+	}
+	return nil
+}
+
+func (c *Checker) checkResults(height uint64, blockID flow.Identifier) error {
+	resultIDs, ok, err := c.lookupIndex(CheckPayloadResults, height, blockID, operation.LookupPayloadResults)
+	if err != nil || !ok {
+		return err
+	}
+	for _, resultID := range resultIDs {
+		var result flow.ExecutionResult
+		found, err := c.exists(operation.RetrieveExecutionResult(resultID, &result))
+		if err != nil {
+			return fmt.Errorf("could not retrieve result %v: %w", resultID, err)
+		}
+		if !found {
+			c.violation(Violation{
+				Check:    CheckPayloadResults,
+				Height:   height,
+				BlockID:  blockID,
+				EntityID: resultID,
+				Message:  "payload result is missing",
+			})
+		}
+	}
+	return nil
+}
+
+// checkSeals checks the payload seals of the given block. It returns the seals
+// if they are all stored, and nil otherwise.
+func (c *Checker) checkSeals(height uint64, blockID flow.Identifier) ([]*flow.Seal, error) {
+	sealIDs, ok, err := c.lookupIndex(CheckPayloadSeals, height, blockID, operation.LookupPayloadSeals)
+	if err != nil || !ok {
+		return nil, err
+	}
+	complete := true
+	seals := make([]*flow.Seal, 0, len(sealIDs))
+	for _, sealID := range sealIDs {
+		var seal flow.Seal
+		found, err := c.exists(operation.RetrieveSeal(sealID, &seal))
+		if err != nil {
+			return nil, fmt.Errorf("could not retrieve seal %v: %w", sealID, err)
+		}
+		if !found {
+			c.violation(Violation{
+				Check:    CheckPayloadSeals,
+				Height:   height,
+				BlockID:  blockID,
+				EntityID: sealID,
+				Message:  "payload seal is missing",
+			})
+			complete = false
+			continue
+		}
+		seals = append(seals, &seal)
+	}
+	if !complete {
+		return nil, nil
+	}
+	return seals, nil
+}
+
+// checkBlockSeal checks the latest seal indexed for the given block, which is
+// the seal for the highest block in its payload, or the latest seal of its
+// parent if the payload has no seals. It returns the ID of the latest seal of
+// the block, or nil if it can't be determined.
+func (c *Checker) checkBlockSeal(height uint64, blockID flow.Identifier, seals []*flow.Seal, parentSealID *flow.Identifier) (*flow.Identifier, error) {
+	var indexed flow.Identifier
+	err := c.db.View(operation.LookupBlockSeal(blockID, &indexed))
+	missing := errors.Is(err, storage.ErrNotFound)
+	if err != nil && !missing {
+		return nil, fmt.Errorf("could not look up seal of block %v: %w", blockID, err)
+	}
+
+	expected, err := c.expectedBlockSeal(seals, parentSealID)
+	if err != nil {
+		return nil, err
+	}
+
+	if missing {
+		repaired := false
+		if expected != nil {
+			repaired, err = c.repairWith(operation.IndexBlockSeal(blockID, *expected))
+			if err != nil {
+				return nil, fmt.Errorf("could not index seal of block %v: %w", blockID, err)
+			}
+		}
+		c.violation(Violation{
+			Check:    CheckBlockSeal,
+			Height:   height,
+			BlockID:  blockID,
+			Message:  "latest seal of finalized block is not indexed",
+			Repaired: repaired,
+		})
+		return expected, nil
+	}
+
+	if expected != nil && *expected != indexed {
+		repaired, err := c.repairWith(operation.ReindexBlockSeal(blockID, *expected))
+		if err != nil {
+			return nil, fmt.Errorf("could not reindex seal of block %v: %w", blockID, err)
+		}
+		c.violation(Violation{
+			Check:    CheckBlockSeal,
+			Height:   height,
+			BlockID:  blockID,
+			EntityID: indexed,
+			Message:  fmt.Sprintf("latest seal of finalized block is indexed to %v instead of %v", indexed, *expected),
+			Repaired: repaired,
+		})
+		return expected, nil
+	}
+
+	var seal flow.Seal
+	found, err := c.exists(operation.RetrieveSeal(indexed, &seal))
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve seal %v: %w", indexed, err)
+	}
+	if !found {
+		c.violation(Violation{
+			Check:    CheckBlockSeal,
+			Height:   height,
+			BlockID:  blockID,
+			EntityID: indexed,
+			Message:  "latest seal of finalized block is missing",
+		})
+	}
+
+	return &indexed, nil
+}
+
+// expectedBlockSeal derives the latest seal of a block from its payload seals
+// and the latest seal of its parent. It returns nil if either is unknown.
+func (c *Checker) expectedBlockSeal(seals []*flow.Seal, parentSealID *flow.Identifier) (*flow.Identifier, error) {
+	if seals == nil {
+		return nil, nil
+	}
+	if len(seals) == 0 {
+		return parentSealID, nil
+	}
+
+	var highest *flow.Seal
+	var highestHeight uint64
+	for _, seal := range seals {
+		var header flow.Header
+		err := c.db.View(operation.RetrieveHeader(seal.BlockID, &header))
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("could not retrieve sealed block %v: %w", seal.BlockID, err)
+		}
+		if highest == nil || header.Height > highestHeight {
+			highest = seal
+			highestHeight = header.Height
+		}
+	}
+	sealID := highest.ID()
+	return &sealID, nil
+}
+
+// repairWith applies the given repair if repairing is enabled, and returns
+// whether it was applied.
+func (c *Checker) repairWith(repair func(*badger.Txn) error) (bool, error) {
+	if !c.repair {
+		return false, nil
+	}
+	err := operation.RetryOnConflict(c.db.Update, repair)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
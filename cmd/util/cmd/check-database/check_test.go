@@ -0,0 +1,228 @@
+package check_database
+
+import (
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/storage"
+	bstorage "github.com/onflow/flow-go/storage/badger"
+	"github.com/onflow/flow-go/storage/badger/operation"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// chain is a finalized chain of three blocks: the root block, a block sealing
+// the root block, and a block with an empty payload.
+type chain struct {
+	root, sealing, last *flow.Block
+	rootSeal, seal      *flow.Seal
+}
+
+// storeChain stores the chain like the protocol state, except for the
+// corruptions applied by the given function before the indexes are written.
+func storeChain(t *testing.T, db *badger.DB, corrupt func(*chain, map[string]bool)) *chain {
+	rootHeader := unittest.BlockHeaderFixture()
+	rootHeader.Height = 10
+	root := &flow.Block{Header: &rootHeader, Payload: &flow.Payload{}}
+	root.Header.PayloadHash = root.Payload.Hash()
+
+	seal := unittest.Seal.Fixture(unittest.Seal.WithBlockID(root.ID()))
+	sealing := unittest.BlockWithParentFixture(root.Header)
+	sealing.SetPayload(flow.Payload{
+		Guarantees: []*flow.CollectionGuarantee{unittest.CollectionGuaranteeFixture()},
+		Seals:      []*flow.Seal{seal},
+	})
+	last := unittest.BlockWithParentFixture(sealing.Header)
+	last.SetPayload(flow.Payload{})
+
+	c := &chain{
+		root:     root,
+		sealing:  sealing,
+		last:     last,
+		rootSeal: unittest.Seal.Fixture(),
+		seal:     seal,
+	}
+	skip := make(map[string]bool)
+	if corrupt != nil {
+		corrupt(c, skip)
+	}
+
+	metrics := metrics.NewNoopCollector()
+	headers := bstorage.NewHeaders(metrics, db)
+	index := bstorage.NewIndex(metrics, db)
+	guarantees := bstorage.NewGuarantees(metrics, db, bstorage.DefaultCacheSize)
+	seals := bstorage.NewSeals(metrics, db)
+	results := bstorage.NewExecutionResults(metrics, db)
+	receipts := bstorage.NewExecutionReceipts(metrics, db, results, bstorage.DefaultCacheSize)
+	payloads := bstorage.NewPayloads(db, index, guarantees, seals, receipts, results)
+	blocks := bstorage.NewBlocks(db, headers, payloads)
+
+	require.NoError(t, db.Update(operation.InsertSeal(c.rootSeal.ID(), c.rootSeal)))
+	require.NoError(t, db.Update(operation.InsertRootHeight(root.Header.Height)))
+	require.NoError(t, db.Update(operation.InsertFinalizedHeight(last.Header.Height)))
+	require.NoError(t, db.Update(operation.InsertRootQuorumCertificate(unittest.QuorumCertificateFixture(func(qc *flow.QuorumCertificate) {
+		qc.BlockID = root.ID()
+		if skip["root qc"] {
+			qc.BlockID = unittest.IdentifierFixture()
+		}
+	}))))
+
+	latestSeals := map[flow.Identifier]flow.Identifier{
+		root.ID():    c.rootSeal.ID(),
+		sealing.ID(): seal.ID(),
+		last.ID():    seal.ID(),
+	}
+	for _, block := range []*flow.Block{root, sealing, last} {
+		if skip["guarantee"] && block == sealing {
+			// index the payload without storing the guarantee
+			require.NoError(t, db.Update(operation.InsertHeader(block.ID(), block.Header)))
+			require.NoError(t, db.Update(operation.InsertSeal(seal.ID(), seal)))
+			require.NoError(t, db.Update(operation.IndexPayloadGuarantees(block.ID(), flow.GetIDs(block.Payload.Guarantees))))
+			require.NoError(t, db.Update(operation.IndexPayloadSeals(block.ID(), flow.GetIDs(block.Payload.Seals))))
+			require.NoError(t, db.Update(operation.IndexPayloadReceipts(block.ID(), nil)))
+			require.NoError(t, db.Update(operation.IndexPayloadResults(block.ID(), nil)))
+		} else {
+			require.NoError(t, blocks.Store(block))
+		}
+		if !skip["height "+block.ID().String()] {
+			require.NoError(t, db.Update(operation.IndexBlockHeight(block.Header.Height, block.ID())))
+		}
+		if !skip["seal "+block.ID().String()] {
+			require.NoError(t, db.Update(operation.IndexBlockSeal(block.ID(), latestSeals[block.ID()])))
+		}
+	}
+	return c
+}
+
+func runChecker(t *testing.T, db *badger.DB, repair bool) (Summary, []Violation) {
+	var violations []Violation
+	summary, err := NewChecker(zerolog.Nop(), db, repair, func(v Violation) {
+		violations = append(violations, v)
+	}).Run()
+	require.NoError(t, err)
+	return summary, violations
+}
+
+func TestCheckConsistentDatabase(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		storeChain(t, db, nil)
+
+		summary, violations := runChecker(t, db, false)
+		assert.Empty(t, violations)
+		assert.Equal(t, Summary{CheckedBlocks: 3}, summary)
+	})
+}
+
+func TestCheckReportsViolations(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		c := storeChain(t, db, func(c *chain, skip map[string]bool) {
+			skip["guarantee"] = true
+			skip["height "+c.sealing.ID().String()] = true
+			skip["seal "+c.last.ID().String()] = true
+		})
+
+		// the block missing in the height index isn't checked, so the
+		// missing guarantee isn't found yet
+		summary, violations := runChecker(t, db, false)
+		assert.Equal(t, Summary{CheckedBlocks: 2, Violations: 2}, summary)
+		assert.ElementsMatch(t, []Violation{
+			{
+				Check:   CheckHeightIndex,
+				Height:  c.sealing.Header.Height,
+				BlockID: c.sealing.ID(),
+				Message: "finalized block is not indexed by height",
+			},
+			{
+				Check:   CheckBlockSeal,
+				Height:  c.last.Header.Height,
+				BlockID: c.last.ID(),
+				Message: "latest seal of finalized block is not indexed",
+			},
+		}, violations)
+
+		// repair the derivable indexes
+		summary, violations = runChecker(t, db, true)
+		assert.Equal(t, Summary{CheckedBlocks: 3, Violations: 3, Repaired: 2}, summary)
+		for _, v := range violations {
+			assert.Equal(t, v.Check != CheckPayloadGuarantees, v.Repaired)
+		}
+
+		var blockID flow.Identifier
+		require.NoError(t, db.View(operation.LookupBlockHeight(c.sealing.Header.Height, &blockID)))
+		assert.Equal(t, c.sealing.ID(), blockID)
+		var sealID flow.Identifier
+		require.NoError(t, db.View(operation.LookupBlockSeal(c.last.ID(), &sealID)))
+		assert.Equal(t, c.seal.ID(), sealID)
+
+		// the missing guarantee can't be repaired
+		summary, violations = runChecker(t, db, true)
+		assert.Equal(t, Summary{CheckedBlocks: 3, Violations: 1}, summary)
+		assert.Equal(t, Violation{
+			Check:    CheckPayloadGuarantees,
+			Height:   c.sealing.Header.Height,
+			BlockID:  c.sealing.ID(),
+			EntityID: c.sealing.Payload.Guarantees[0].ID(),
+			Message:  "payload guarantee is missing",
+		}, violations[0])
+	})
+}
+
+func TestCheckWrongIndexes(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		c := storeChain(t, db, nil)
+
+		// index another block at the height of the last block, and a stale
+		// seal for the sealing block
+		other := unittest.IdentifierFixture()
+		require.NoError(t, db.Update(operation.ReindexBlockHeight(c.last.Header.Height, other)))
+		require.NoError(t, db.Update(operation.ReindexBlockSeal(c.sealing.ID(), c.rootSeal.ID())))
+
+		// the finalized chain starts at the indexed finalized block, which is unknown
+		summary, violations := runChecker(t, db, false)
+		require.NotEmpty(t, violations)
+		assert.Equal(t, CheckFinalizedChain, violations[0].Check)
+		assert.Equal(t, other, violations[0].BlockID)
+
+		// with the right finalized block, the stale seal is found and repaired
+		require.NoError(t, db.Update(operation.ReindexBlockHeight(c.last.Header.Height, c.last.ID())))
+		summary, violations = runChecker(t, db, true)
+		assert.Equal(t, Summary{CheckedBlocks: 3, Violations: 1, Repaired: 1}, summary)
+		assert.Equal(t, CheckBlockSeal, violations[0].Check)
+		assert.Equal(t, c.rootSeal.ID(), violations[0].EntityID)
+
+		summary, violations = runChecker(t, db, false)
+		assert.Empty(t, violations)
+		assert.Equal(t, Summary{CheckedBlocks: 3}, summary)
+	})
+}
+
+func TestCheckDoesNotRepairBrokenChain(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		c := storeChain(t, db, func(c *chain, skip map[string]bool) {
+			skip["height "+c.sealing.ID().String()] = true
+			// the finalized chain doesn't end at the root block
+			skip["root qc"] = true
+		})
+
+		// so the height index isn't repaired from it
+		_, violations := runChecker(t, db, true)
+		require.Len(t, violations, 2)
+		assert.Equal(t, CheckFinalizedChain, violations[0].Check)
+		assert.Equal(t, "finalized chain doesn't end at the root block", violations[0].Message)
+		assert.Equal(t, Violation{
+			Check:   CheckHeightIndex,
+			Height:  c.sealing.Header.Height,
+			BlockID: c.sealing.ID(),
+			Message: "finalized block is not indexed by height",
+		}, violations[1])
+
+		var blockID flow.Identifier
+		err := db.View(operation.LookupBlockHeight(c.sealing.Header.Height, &blockID))
+		assert.ErrorIs(t, err, storage.ErrNotFound)
+	})
+}
@@ -0,0 +1,68 @@
+package check_database
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-go/cmd/util/cmd/common"
+)
+
+var (
+	flagDatadir string
+	flagRepair  bool
+)
+
+// run with `./util check-database --datadir /var/flow/data/protocol > violations.jsonl`
+var Cmd = &cobra.Command{
+	Use:   "check-database",
+	Short: "Check the consistency of the indexes of the protocol database, printing each violation as a JSON line (the node must be stopped to repair)",
+	Run:   run,
+}
+
+func init() {
+
+	Cmd.Flags().StringVar(&flagDatadir, "datadir", "",
+		"directory that stores the protocol state")
+	_ = Cmd.MarkFlagRequired("datadir")
+
+	Cmd.Flags().BoolVar(&flagRepair, "repair", false,
+		"repair the indexes which can be derived from primary data")
+}
+
+func run(*cobra.Command, []string) {
+	log.Info().
+		Str("datadir", flagDatadir).
+		Bool("repair", flagRepair).
+		Msg("flags")
+
+	db := common.InitStorage(flagDatadir)
+	defer db.Close()
+
+	encoder := json.NewEncoder(os.Stdout)
+	report := func(v Violation) {
+		err := encoder.Encode(v)
+		if err != nil {
+			log.Fatal().Err(err).Msg("could not write violation")
+		}
+	}
+
+	summary, err := NewChecker(log.Logger, db, flagRepair, report).Run()
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not check database")
+	}
+
+	log.Info().
+		Uint64("checked_blocks", summary.CheckedBlocks).
+		Uint64("violations", summary.Violations).
+		Uint64("repaired", summary.Repaired).
+		Msg("database checked")
+
+	if summary.Violations > summary.Repaired {
+		// the deferred close isn't run when exiting
+		db.Close()
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,88 @@
+package compare_execution
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-go/engine/execution/comparison"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/logging"
+)
+
+var (
+	flagBlockID string
+	flagNodes   []string
+	flagTimeout time.Duration
+)
+
+// run with `./util compare-execution --block-id <id> --nodes execution-001:9000,execution-002:9000`
+var Cmd = &cobra.Command{
+	Use:   "compare-execution",
+	Short: "Compare the executions of a block by two execution nodes, and show the first chunk, transaction, event or register value which differs",
+	Run:   run,
+}
+
+func init() {
+
+	Cmd.Flags().StringVar(&flagBlockID, "block-id", "",
+		"ID of the block to compare the executions of")
+	_ = Cmd.MarkFlagRequired("block-id")
+
+	Cmd.Flags().StringSliceVar(&flagNodes, "nodes", nil,
+		"gRPC addresses of the two execution nodes to compare, which must serve block executions (--serve-block-executions)")
+	_ = Cmd.MarkFlagRequired("nodes")
+
+	Cmd.Flags().DurationVar(&flagTimeout, "timeout", time.Minute,
+		"timeout for fetching the executions from the nodes")
+}
+
+func run(*cobra.Command, []string) {
+	log.Info().
+		Str("block_id", flagBlockID).
+		Strs("nodes", flagNodes).
+		Msg("flags")
+
+	blockID, err := flow.HexStringToIdentifier(flagBlockID)
+	if err != nil {
+		log.Fatal().Err(err).Msg("invalid block ID")
+	}
+	if len(flagNodes) != 2 {
+		log.Fatal().Int("nodes", len(flagNodes)).Msg("the executions of exactly two nodes can be compared")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), flagTimeout)
+	defer cancel()
+
+	executions := make([]*comparison.BlockExecution, len(flagNodes))
+	for i, node := range flagNodes {
+		executions[i], err = comparison.FetchFromNode(ctx, node, blockID)
+		if err != nil {
+			log.Fatal().Err(err).Str("node", node).Msg("could not fetch block execution")
+		}
+		log.Info().
+			Str("node", node).
+			Hex("result_id", logging.ID(executions[i].Result.ID())).
+			Msg("fetched block execution")
+	}
+
+	diff := comparison.Compare(executions[0], executions[1])
+	if diff == nil {
+		log.Info().Msg("the executions of the block are identical")
+		return
+	}
+
+	log.Warn().
+		Str("kind", string(diff.Kind)).
+		Str("field", diff.Field).
+		Uint64("chunk_index", diff.ChunkIndex).
+		Uint32("transaction_index", diff.TransactionIndex).
+		Hex("transaction_id", logging.ID(diff.TransactionID)).
+		Uint32("event_index", diff.EventIndex).
+		Str("register_id", diff.RegisterID).
+		Str(flagNodes[0], diff.Left).
+		Str(flagNodes[1], diff.Right).
+		Msg(diff.String())
+}
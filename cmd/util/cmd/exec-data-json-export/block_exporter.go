@@ -13,6 +13,8 @@ import (
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module/metrics"
 	"github.com/onflow/flow-go/storage/badger"
+	"github.com/onflow/flow-go/storage/operation/badgerimpl"
+	"github.com/onflow/flow-go/storage/store"
 )
 
 type blockSummary struct {
@@ -46,7 +48,7 @@ func ExportBlocks(blockID flow.Identifier, dbPath string, outputPath string) (fl
 	receipts := badger.NewExecutionReceipts(cacheMetrics, db, results, badger.DefaultCacheSize)
 	payloads := badger.NewPayloads(db, index, guarantees, seals, receipts, results)
 	blocks := badger.NewBlocks(db, headers, payloads)
-	commits := badger.NewCommits(&metrics.NoopCollector{}, db)
+	commits := store.NewCommits(&metrics.NoopCollector{}, badgerimpl.ToDB(db))
 
 	activeBlockID := blockID
 	outputFile := filepath.Join(outputPath, "blocks.jsonl")
@@ -69,6 +69,12 @@ func run(*cobra.Command, []string) {
 		log.Fatal().Err(err).Msg("cannot get export transactions")
 	}
 
+	log.Info().Msg("start exporting register sets")
+	err = ExportRegisterSets(blockID, flagDatadir, flagOutputDir)
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot get export register sets")
+	}
+
 	log.Info().Msg("start exporting delta snapshots")
 	err = ExportDeltaSnapshots(blockID, flagDatadir, flagOutputDir)
 	if err != nil {
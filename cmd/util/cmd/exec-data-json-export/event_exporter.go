@@ -12,6 +12,8 @@ import (
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module/metrics"
 	"github.com/onflow/flow-go/storage/badger"
+	"github.com/onflow/flow-go/storage/operation/badgerimpl"
+	"github.com/onflow/flow-go/storage/store"
 )
 
 type event struct {
@@ -33,7 +35,7 @@ func ExportEvents(blockID flow.Identifier, dbPath string, outputPath string) err
 
 	cacheMetrics := &metrics.NoopCollector{}
 	headers := badger.NewHeaders(cacheMetrics, db)
-	events := badger.NewEvents(cacheMetrics, db)
+	events := store.NewEvents(cacheMetrics, badgerimpl.ToDB(db))
 	activeBlockID := blockID
 
 	outputFile := filepath.Join(outputPath, "events.jsonl")
@@ -0,0 +1,91 @@
+package jsonexporter
+
+import (
+	"bufio"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/onflow/flow-go/cmd/util/cmd/common"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/storage/badger"
+)
+
+// register IDs are encoded in the form 'owner/controller/key', with each part hex-encoded
+type registerSet struct {
+	TxID        string   `json:"tx_id"`
+	TxIndex     uint32   `json:"tx_index"`
+	BlockID     string   `json:"block_id"`
+	BlockHeight uint64   `json:"block_height"`
+	ReadSet     []string `json:"read_set"`
+	WriteSet    []string `json:"write_set"`
+}
+
+// ExportRegisterSets exports the registers read and written by each transaction.
+// Register sets are only available for blocks executed while storing them was enabled.
+func ExportRegisterSets(blockID flow.Identifier, dbPath string, outputPath string) error {
+
+	// traverse backward from the given block (parent block) and fetch by blockHash
+	db := common.InitStorage(dbPath)
+	defer db.Close()
+
+	cacheMetrics := &metrics.NoopCollector{}
+	headers := badger.NewHeaders(cacheMetrics, db)
+	registerSets := badger.NewTransactionRegisterSets(cacheMetrics, db, 1)
+	activeBlockID := blockID
+
+	outputFile := filepath.Join(outputPath, "register_sets.jsonl")
+	fi, err := os.Create(outputFile)
+	if err != nil {
+		return fmt.Errorf("could not create register sets output file %w", err)
+	}
+	defer fi.Close()
+
+	registerSetWriter := bufio.NewWriter(fi)
+	defer registerSetWriter.Flush()
+
+	for {
+		header, err := headers.ByBlockID(activeBlockID)
+		if err != nil {
+			// no more header is available
+			return nil
+		}
+
+		sets, err := registerSets.ByBlockID(activeBlockID)
+		if err != nil {
+			return fmt.Errorf("could not fetch register sets %w", err)
+		}
+
+		for _, set := range sets {
+			r := registerSet{
+				TxID:        hex.EncodeToString(set.TransactionID[:]),
+				TxIndex:     set.TransactionIndex,
+				BlockID:     hex.EncodeToString(activeBlockID[:]),
+				BlockHeight: header.Height,
+				ReadSet:     registerIDStrings(set.ReadSet),
+				WriteSet:    registerIDStrings(set.WriteSet),
+			}
+			jsonData, err := json.Marshal(r)
+			if err != nil {
+				return fmt.Errorf("could not create a json obj for a register set: %w", err)
+			}
+			_, err = registerSetWriter.WriteString(string(jsonData) + "\n")
+			if err != nil {
+				return fmt.Errorf("could not write register set json to the file: %w", err)
+			}
+			registerSetWriter.Flush()
+		}
+		activeBlockID = header.ParentID
+	}
+}
+
+func registerIDStrings(ids []flow.RegisterID) []string {
+	strs := make([]string, len(ids))
+	for i := range ids {
+		strs[i] = ids[i].String()
+	}
+	return strs
+}
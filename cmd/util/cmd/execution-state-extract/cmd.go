@@ -14,7 +14,8 @@ import (
 	"github.com/onflow/flow-go/model/bootstrap"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module/metrics"
-	"github.com/onflow/flow-go/storage/badger"
+	"github.com/onflow/flow-go/storage/operation/badgerimpl"
+	"github.com/onflow/flow-go/storage/store"
 )
 
 var (
@@ -90,7 +91,7 @@ func run(*cobra.Command, []string) {
 		defer db.Close()
 
 		cache := &metrics.NoopCollector{}
-		commits := badger.NewCommits(cache, db)
+		commits := store.NewCommits(cache, badgerimpl.ToDB(db))
 
 		stateCommitment, err = getStateCommitment(commits, blockID)
 		if err != nil {
@@ -14,7 +14,8 @@ import (
 	"github.com/onflow/flow-go/ledger/complete/wal"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module/metrics"
-	"github.com/onflow/flow-go/storage/badger"
+	"github.com/onflow/flow-go/storage/operation/badgerimpl"
+	"github.com/onflow/flow-go/storage/store"
 	"github.com/onflow/flow-go/utils/unittest"
 )
 
@@ -30,7 +31,7 @@ func TestExtractExecutionState(t *testing.T) {
 
 		withDirs(t, func(datadir, execdir, outdir string) {
 			db := common.InitStorage(datadir)
-			commits := badger.NewCommits(metr, db)
+			commits := store.NewCommits(metr, badgerimpl.ToDB(db))
 
 			_, err := getStateCommitment(commits, unittest.IdentifierFixture())
 			require.Error(t, err)
@@ -41,7 +42,7 @@ func TestExtractExecutionState(t *testing.T) {
 
 		withDirs(t, func(datadir, execdir, outdir string) {
 			db := common.InitStorage(datadir)
-			commits := badger.NewCommits(metr, db)
+			commits := store.NewCommits(metr, badgerimpl.ToDB(db))
 
 			blockID := unittest.IdentifierFixture()
 			stateCommitment := unittest.StateCommitmentFixture()
@@ -75,7 +76,7 @@ func TestExtractExecutionState(t *testing.T) {
 		withDirs(t, func(datadir, execdir, _ string) {
 
 			db := common.InitStorage(datadir)
-			commits := badger.NewCommits(metr, db)
+			commits := store.NewCommits(metr, badgerimpl.ToDB(db))
 
 			// generate some oldLedger data
 			size := 10
@@ -0,0 +1,75 @@
+package migrate_chunk_data_packs
+
+import (
+	"os"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-go/cmd/util/cmd/common"
+	storage "github.com/onflow/flow-go/storage/badger"
+)
+
+var (
+	flagDatadir          string
+	flagChunkDataPackDir string
+	flagBatchSize        int
+)
+
+// run with `./util migrate-chunk-data-packs --datadir /var/flow/data/protocol --chunk-data-pack-dir /var/flow/data/chunk_data_packs`
+var Cmd = &cobra.Command{
+	Use:   "migrate-chunk-data-packs",
+	Short: "Move the chunk data packs from the protocol database to the chunk data packs database (execution node must be stopped)",
+	Run:   run,
+}
+
+func init() {
+
+	Cmd.Flags().StringVar(&flagDatadir, "datadir", "",
+		"directory that stores the protocol state")
+	_ = Cmd.MarkFlagRequired("datadir")
+
+	Cmd.Flags().StringVar(&flagChunkDataPackDir, "chunk-data-pack-dir", "",
+		"directory that stores the chunk data packs database, created if it does not exist")
+	_ = Cmd.MarkFlagRequired("chunk-data-pack-dir")
+
+	Cmd.Flags().IntVar(&flagBatchSize, "batch-size", 100,
+		"number of chunk data packs moved at once")
+}
+
+func run(*cobra.Command, []string) {
+	log.Info().
+		Str("datadir", flagDatadir).
+		Str("chunk_data_pack_dir", flagChunkDataPackDir).
+		Int("batch_size", flagBatchSize).
+		Msg("flags")
+
+	db := common.InitStorage(flagDatadir)
+	defer db.Close()
+
+	err := os.MkdirAll(flagChunkDataPackDir, 0700)
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not create chunk data packs dir")
+	}
+
+	opts := badger.
+		DefaultOptions(flagChunkDataPackDir).
+		WithKeepL0InMemory(true).
+		WithLogger(nil).
+		WithValueLogFileSize(128 << 23).
+		WithValueLogMaxEntries(100000)
+
+	chunkDataPacksDB, err := storage.InitChunkDataPacks(opts)
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not open chunk data packs db")
+	}
+	defer chunkDataPacksDB.Close()
+
+	moved, err := storage.MigrateChunkDataPacks(log.Logger, db, chunkDataPacksDB, flagBatchSize)
+	if err != nil {
+		log.Fatal().Err(err).Msgf("could not migrate chunk data packs, %d moved so far, run the command again to continue", moved)
+	}
+
+	log.Info().Msgf("%d chunk data packs moved to the chunk data packs database", moved)
+}
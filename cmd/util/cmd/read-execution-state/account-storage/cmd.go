@@ -0,0 +1,215 @@
+package account_storage
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/complete/mtrie"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+var cmd = &cobra.Command{
+	Use:   "account-storage",
+	Short: "Shows what an account stores, or ranks the largest accounts and storage paths",
+	Long: `Decodes the registers of an account into its keys, contracts and the Cadence values stored
+in each storage domain, and prints them as a tree with the size of each node.
+
+Without --address, ranks the largest accounts and storage paths of the execution state instead.`,
+	Run: run,
+}
+
+var stateLoader func() *mtrie.Forest = nil
+var checkpointLoader func(string) []*trie.MTrie = nil
+var flagStateCommitment string
+var flagCheckpoint string
+var flagAddress string
+var flagDepth int
+var flagTop int
+var flagJSON bool
+
+func Init(f func() *mtrie.Forest, c func(string) []*trie.MTrie) *cobra.Command {
+	stateLoader = f
+	checkpointLoader = c
+
+	cmd.Flags().StringVar(&flagStateCommitment, "state-commitment", "",
+		"State commitment (64 chars, hex-encoded), defaults to the last trie of the checkpoint")
+
+	cmd.Flags().StringVar(&flagCheckpoint, "checkpoint", "",
+		"Checkpoint file in the execution state dir to load, instead of replaying the WAL")
+
+	cmd.Flags().StringVar(&flagAddress, "address", "",
+		"Address of the account to show (hex-encoded)")
+
+	cmd.Flags().IntVar(&flagDepth, "depth", 2,
+		"Depth up to which fields of stored composite values are shown")
+
+	cmd.Flags().IntVar(&flagTop, "top", 20,
+		"Number of accounts and storage paths to rank, when no address is given")
+
+	cmd.Flags().BoolVar(&flagJSON, "json", false,
+		"Print JSON instead of human-readable output")
+
+	return cmd
+}
+
+func run(*cobra.Command, []string) {
+	startTime := time.Now()
+
+	payloads := loadPayloads()
+
+	if flagAddress != "" {
+		showAccount(payloads)
+	} else {
+		rank(payloads)
+	}
+
+	duration := time.Since(startTime)
+
+	log.Info().Float64("total_time_s", duration.Seconds()).Msg("finished")
+}
+
+func loadPayloads() []ledger.Payload {
+	var stateCommitment *flow.StateCommitment
+	if flagStateCommitment != "" {
+		stateCommitmentBytes, err := hex.DecodeString(flagStateCommitment)
+		if err != nil {
+			log.Fatal().Err(err).Msg("invalid flag, cannot decode")
+		}
+
+		commit, err := flow.ToStateCommitment(stateCommitmentBytes)
+		if err != nil {
+			log.Fatal().Err(err).Msgf("invalid number of bytes, got %d expected %d", len(stateCommitmentBytes), len(commit))
+		}
+		stateCommitment = &commit
+	}
+
+	var t *trie.MTrie
+	if flagCheckpoint != "" {
+		tries := checkpointLoader(flagCheckpoint)
+		if len(tries) == 0 {
+			log.Fatal().Str("checkpoint", flagCheckpoint).Msg("checkpoint contains no tries")
+		}
+		t = tries[len(tries)-1]
+		if stateCommitment != nil {
+			t = nil
+			for _, tr := range tries {
+				if tr.RootHash() == ledger.RootHash(*stateCommitment) {
+					t = tr
+					break
+				}
+			}
+			if t == nil {
+				log.Fatal().Str("checkpoint", flagCheckpoint).Msg("checkpoint contains no trie with the given state commitment")
+			}
+		}
+	} else {
+		if stateCommitment == nil {
+			log.Fatal().Msg("--state-commitment is required when no checkpoint is given")
+		}
+
+		forest := stateLoader()
+
+		var err error
+		t, err = forest.GetTrie(ledger.RootHash(*stateCommitment))
+		if err != nil {
+			log.Fatal().Err(err).Msg("cannot get trie with the given state commitment")
+		}
+	}
+
+	rootHash := t.RootHash()
+	log.Info().
+		Hex("state_commitment", rootHash[:]).
+		Uint64("registers", t.AllocatedRegCount()).
+		Msg("loaded execution state")
+
+	return t.AllPayloads()
+}
+
+func showAccount(payloads []ledger.Payload) {
+	address := flow.HexToAddress(flagAddress)
+
+	accounts, err := GroupByOwner(payloads)
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot group registers by account")
+	}
+
+	registers, ok := accounts[address]
+	if !ok {
+		log.Fatal().Str("address", address.Hex()).Msg("account has no registers")
+	}
+
+	root, err := ExploreAccount(address, registers, flagDepth)
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot explore account")
+	}
+
+	if flagJSON {
+		printJSON(root)
+		return
+	}
+	PrintTree(os.Stdout, root)
+}
+
+func rank(payloads []ledger.Payload) {
+	accounts, paths, err := Rank(payloads, flagTop)
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot rank accounts")
+	}
+
+	if flagJSON {
+		printJSON(struct {
+			Accounts []AccountSize `json:"accounts"`
+			Paths    []PathSize    `json:"paths"`
+		}{accounts, paths})
+		return
+	}
+
+	fmt.Printf("Largest accounts:\n")
+	for i, account := range accounts {
+		fmt.Printf("%4d. %s %12d bytes %8d registers\n", i+1, account.Address.HexWithPrefix(), account.Size, account.Registers)
+	}
+
+	fmt.Printf("\nLargest storage paths:\n")
+	for i, path := range paths {
+		fmt.Printf("%4d. %s %12d bytes %s (%s)\n", i+1, path.Address.HexWithPrefix(), path.Size, path.Path, path.Type)
+	}
+}
+
+func printJSON(v interface{}) {
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		log.Fatal().Err(err).Msg("error while marshalling output")
+	}
+
+	fmt.Println(string(b))
+}
+
+// PrintTree prints the given tree in a human-readable form.
+func PrintTree(w io.Writer, root *Node) {
+	printNode(w, root, 0)
+}
+
+func printNode(w io.Writer, node *Node, level int) {
+	line := strings.Repeat("  ", level) + node.Name
+	if node.Type != "" {
+		line += ": " + node.Type
+	}
+	if node.Value != "" {
+		line += " = " + node.Value
+	}
+	_, _ = fmt.Fprintf(w, "%s (%d bytes)\n", line, node.Size)
+
+	for _, child := range node.Children {
+		printNode(w, child, level+1)
+	}
+}
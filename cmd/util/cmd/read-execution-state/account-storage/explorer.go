@@ -0,0 +1,529 @@
+package account_storage
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/onflow/atree"
+	"github.com/onflow/cadence/runtime"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/interpreter"
+
+	"github.com/onflow/flow-go/cmd/util/ledger/migrations"
+	"github.com/onflow/flow-go/fvm/state"
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// maxValueLength is the length at which the string representation of values is cut off.
+const maxValueLength = 100
+
+// domains are the Cadence storage domains of an account, in the order they are shown.
+var domains = []string{
+	common.PathDomainStorage.Identifier(),
+	common.PathDomainPublic.Identifier(),
+	common.PathDomainPrivate.Identifier(),
+	runtime.StorageDomainContract,
+}
+
+// Node is a node of the tree describing what an account stores.
+// Size is the number of bytes the node occupies in the execution state,
+// measured the same way as the storage used of the account.
+type Node struct {
+	Name     string  `json:"name"`
+	Type     string  `json:"type,omitempty"`
+	Value    string  `json:"value,omitempty"`
+	Size     uint64  `json:"size"`
+	Children []*Node `json:"children,omitempty"`
+}
+
+// AccountSize is the total size of the registers of an account.
+type AccountSize struct {
+	Address   flow.Address `json:"address"`
+	Size      uint64       `json:"size"`
+	Registers int          `json:"registers"`
+}
+
+// PathSize is the size of the value stored at a path of an account.
+type PathSize struct {
+	Address flow.Address `json:"address"`
+	Path    string       `json:"path"`
+	Type    string       `json:"type,omitempty"`
+	Size    uint64       `json:"size"`
+}
+
+// GroupByOwner groups the given payloads by the address of the account owning them.
+// Payloads which are not owned by an account are skipped.
+func GroupByOwner(payloads []ledger.Payload) (map[flow.Address][]ledger.Payload, error) {
+	accounts := make(map[flow.Address][]ledger.Payload)
+	for _, payload := range payloads {
+		id, err := migrations.KeyToRegisterID(payload.Key)
+		if err != nil {
+			return nil, err
+		}
+		if len(id.Owner) != flow.AddressLength {
+			continue
+		}
+		address := flow.BytesToAddress([]byte(id.Owner))
+		accounts[address] = append(accounts[address], payload)
+	}
+	return accounts, nil
+}
+
+// ExploreAccount decodes the given payloads of the account at the given address into a tree
+// of account keys, contract code, and the values stored in each storage domain.
+// Fields of composite values are expanded up to the given depth.
+func ExploreAccount(address flow.Address, payloads []ledger.Payload, depth int) (root *Node, err error) {
+	// Cadence reports decoding errors by panicking
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("could not decode storage of account %s: %v", address, r)
+		}
+	}()
+
+	e, err := newExplorer(address, payloads, depth)
+	if err != nil {
+		return nil, err
+	}
+	return e.explore()
+}
+
+// Rank returns the n largest accounts of the given payloads, and the n largest values
+// stored in the storage domains of all accounts.
+func Rank(payloads []ledger.Payload, n int) ([]AccountSize, []PathSize, error) {
+	accounts, err := GroupByOwner(payloads)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	accountSizes := make([]AccountSize, 0, len(accounts))
+	var pathSizes []PathSize
+	for address, payloads := range accounts {
+		root, err := ExploreAccount(address, payloads, 0)
+		if err != nil {
+			return nil, nil, err
+		}
+		accountSizes = append(accountSizes, AccountSize{
+			Address:   address,
+			Size:      root.Size,
+			Registers: len(payloads),
+		})
+
+		for _, domain := range root.Children {
+			if !isDomain(domain.Name) {
+				continue
+			}
+			for _, path := range domain.Children {
+				pathSizes = append(pathSizes, PathSize{
+					Address: address,
+					Path:    path.Name,
+					Type:    path.Type,
+					Size:    path.Size,
+				})
+			}
+		}
+
+		// only keep the largest paths, so memory stays bounded when ranking large states
+		if len(pathSizes) > 2*n {
+			pathSizes = largestPaths(pathSizes, n)
+		}
+	}
+
+	sort.Slice(accountSizes, func(i, j int) bool {
+		if accountSizes[i].Size != accountSizes[j].Size {
+			return accountSizes[i].Size > accountSizes[j].Size
+		}
+		return accountSizes[i].Address.Hex() < accountSizes[j].Address.Hex()
+	})
+	if len(accountSizes) > n {
+		accountSizes = accountSizes[:n]
+	}
+
+	return accountSizes, largestPaths(pathSizes, n), nil
+}
+
+func largestPaths(paths []PathSize, n int) []PathSize {
+	sort.Slice(paths, func(i, j int) bool {
+		if paths[i].Size != paths[j].Size {
+			return paths[i].Size > paths[j].Size
+		}
+		if paths[i].Address != paths[j].Address {
+			return paths[i].Address.Hex() < paths[j].Address.Hex()
+		}
+		return paths[i].Path < paths[j].Path
+	})
+	if len(paths) > n {
+		paths = paths[:n]
+	}
+	return paths
+}
+
+func isDomain(name string) bool {
+	for _, domain := range domains {
+		if name == domain {
+			return true
+		}
+	}
+	return false
+}
+
+// register is a register of the explored account.
+type register struct {
+	isController bool
+	value        flow.RegisterValue
+	size         uint64
+}
+
+type explorer struct {
+	address   flow.Address
+	depth     int
+	registers map[string]*register
+	// slabs holds the storage registers of Cadence values, by storage index
+	slabs    map[atree.StorageIndex]*register
+	seen     map[string]bool
+	accounts *state.StatefulAccounts
+	storage  *runtime.Storage
+}
+
+func newExplorer(address flow.Address, payloads []ledger.Payload, depth int) (*explorer, error) {
+	e := &explorer{
+		address:   address,
+		depth:     depth,
+		registers: make(map[string]*register, len(payloads)),
+		slabs:     make(map[atree.StorageIndex]*register),
+		seen:      make(map[string]bool, len(payloads)),
+	}
+
+	for _, payload := range payloads {
+		id, err := migrations.KeyToRegisterID(payload.Key)
+		if err != nil {
+			return nil, err
+		}
+		if id.Owner != string(address.Bytes()) {
+			return nil, fmt.Errorf("register %s is not owned by account %s", id, address)
+		}
+		isController := len(id.Controller) > 0
+		reg := &register{
+			isController: isController,
+			value:        payload.Value,
+			size:         uint64(state.RegisterSize(address, isController, id.Key, payload.Value)),
+		}
+		e.registers[id.Key] = reg
+
+		if !isController && atree.LedgerKeyIsSlabKey(id.Key) && len(id.Key) == len(atree.LedgerBaseStorageSlabPrefix)+len(atree.StorageIndex{}) {
+			var index atree.StorageIndex
+			copy(index[:], id.Key[len(atree.LedgerBaseStorageSlabPrefix):])
+			e.slabs[index] = reg
+		}
+	}
+
+	e.accounts = state.NewAccounts(state.NewStateHolder(state.NewState(migrations.NewView(payloads))))
+	e.storage = runtime.NewStorage(&readOnlyLedger{registers: e.registers})
+
+	return e, nil
+}
+
+func (e *explorer) explore() (*Node, error) {
+	root := &Node{Name: e.address.HexWithPrefix()}
+	for _, reg := range e.registers {
+		root.Size += reg.size
+	}
+
+	keys, err := e.keys()
+	if err != nil {
+		return nil, err
+	}
+	root.Children = append(root.Children, keys)
+
+	contracts, err := e.contracts()
+	if err != nil {
+		return nil, err
+	}
+	root.Children = append(root.Children, contracts)
+
+	for _, domain := range domains {
+		node, err := e.domain(domain)
+		if err != nil {
+			return nil, err
+		}
+		if node != nil {
+			root.Children = append(root.Children, node)
+		}
+	}
+
+	root.Children = append(root.Children, e.remainingRegisters())
+
+	return root, nil
+}
+
+// register returns the size of the given register, and marks it as explored.
+func (e *explorer) register(key string) uint64 {
+	reg, ok := e.registers[key]
+	if !ok {
+		return 0
+	}
+	e.seen[key] = true
+	return reg.size
+}
+
+func (e *explorer) keys() (*Node, error) {
+	node := &Node{Name: "keys"}
+	node.Size += e.register(state.KeyPublicKeyCount)
+
+	count, err := e.accounts.GetPublicKeyCount(e.address)
+	if err != nil {
+		return nil, fmt.Errorf("could not get public key count: %w", err)
+	}
+
+	for i := uint64(0); i < count; i++ {
+		key, err := e.accounts.GetPublicKey(e.address, i)
+		if err != nil {
+			return nil, fmt.Errorf("could not get public key %d: %w", i, err)
+		}
+		size := e.register(fmt.Sprintf("public_key_%d", i))
+		node.Size += size
+		node.Children = append(node.Children, &Node{
+			Name: fmt.Sprintf("%d", i),
+			Type: fmt.Sprintf("%s/%s", key.SignAlgo, key.HashAlgo),
+			Value: fmt.Sprintf("weight %d, sequence number %d, revoked %t, key %s",
+				key.Weight, key.SeqNumber, key.Revoked, key.PublicKey),
+			Size: size,
+		})
+	}
+
+	return node, nil
+}
+
+func (e *explorer) contracts() (*Node, error) {
+	node := &Node{Name: "contracts"}
+	node.Size += e.register(state.KeyContractNames)
+
+	names, err := e.accounts.GetContractNames(e.address)
+	if err != nil {
+		return nil, fmt.Errorf("could not get contract names: %w", err)
+	}
+
+	for _, name := range names {
+		code, err := e.accounts.GetContract(name, e.address)
+		if err != nil {
+			return nil, fmt.Errorf("could not get contract %s: %w", name, err)
+		}
+		size := e.register(state.ContractKey(name))
+		node.Size += size
+		node.Children = append(node.Children, &Node{
+			Name:  name,
+			Type:  "code",
+			Value: fmt.Sprintf("%d lines", strings.Count(string(code), "\n")+1),
+			Size:  size,
+		})
+	}
+
+	return node, nil
+}
+
+// domain returns the values stored in the given storage domain, or nil if the account
+// has no storage in the domain.
+func (e *explorer) domain(domain string) (*Node, error) {
+	if _, ok := e.registers[domain]; !ok {
+		return nil, nil
+	}
+
+	node := &Node{Name: domain}
+	node.Size += e.register(domain)
+
+	storageMap := e.storage.GetStorageMap(common.Address(e.address), domain)
+	node.Size += e.slabSize(storageMap.StorageID(), make(map[atree.StorageID]bool))
+
+	iterator := storageMap.Iterator()
+	for {
+		key, value := iterator.Next()
+		if value == nil {
+			break
+		}
+
+		name := key
+		if domain != runtime.StorageDomainContract {
+			name = fmt.Sprintf("/%s/%s", domain, key)
+		}
+
+		child, err := e.value(name, value, 0)
+		if err != nil {
+			return nil, err
+		}
+		node.Children = append(node.Children, child)
+	}
+
+	sortBySize(node.Children)
+
+	return node, nil
+}
+
+// value describes the given Cadence value. Fields of composite values are described
+// until the depth of the explorer is reached.
+func (e *explorer) value(name string, value interpreter.Value, depth int) (*Node, error) {
+	size, err := e.valueSize(value)
+	if err != nil {
+		return nil, fmt.Errorf("could not get size of %s: %w", name, err)
+	}
+
+	node := &Node{
+		Name: name,
+		Size: size,
+	}
+	if staticType := value.StaticType(); staticType != nil {
+		node.Type = staticType.String()
+	}
+
+	inner := value
+	if some, ok := value.(*interpreter.SomeValue); ok {
+		inner = some.Value
+	}
+
+	switch v := inner.(type) {
+	case *interpreter.CompositeValue:
+		node.Value = v.Kind.Name()
+		if depth >= e.depth {
+			break
+		}
+		var fieldErr error
+		v.ForEachField(func(fieldName string, fieldValue interpreter.Value) {
+			if fieldErr != nil {
+				return
+			}
+			var child *Node
+			child, fieldErr = e.value(fieldName, fieldValue, depth+1)
+			node.Children = append(node.Children, child)
+		})
+		if fieldErr != nil {
+			return nil, fieldErr
+		}
+	case *interpreter.ArrayValue:
+		node.Value = fmt.Sprintf("%d elements", v.Count())
+	case *interpreter.DictionaryValue:
+		node.Value = fmt.Sprintf("%d entries", v.Count())
+	default:
+		node.Value = value.String()
+		if len(node.Value) > maxValueLength {
+			node.Value = node.Value[:maxValueLength] + "..."
+		}
+	}
+
+	return node, nil
+}
+
+// valueSize returns the size of the given value, including all slabs it is stored in.
+func (e *explorer) valueSize(value interpreter.Value) (uint64, error) {
+	// values which are not stored in slabs of their own are inlined, whatever their size
+	storable, err := value.Storable(e.storage, atree.Address(e.address), math.MaxUint64)
+	if err != nil {
+		return 0, err
+	}
+	visited := make(map[atree.StorageID]bool)
+	if id, ok := storable.(atree.StorageIDStorable); ok {
+		return e.slabSize(atree.StorageID(id), visited), nil
+	}
+	return uint64(storable.ByteSize()) + e.childSlabsSize(storable, visited), nil
+}
+
+// slabSize returns the size of the register of the given slab, and all slabs referenced by it
+// which have not been visited yet.
+func (e *explorer) slabSize(id atree.StorageID, visited map[atree.StorageID]bool) uint64 {
+	if _, ok := e.slabs[id.Index]; !ok || visited[id] {
+		return 0
+	}
+	visited[id] = true
+
+	size := e.register(string(atree.SlabIndexToLedgerKey(id.Index)))
+
+	slab, found, err := e.storage.Retrieve(id)
+	if err != nil || !found {
+		// the slab can't be decoded, only the size of its own register is known
+		return size
+	}
+	return size + e.childSlabsSize(slab, visited)
+}
+
+// childSlabsSize returns the size of the slabs referenced by the given storable.
+func (e *explorer) childSlabsSize(storable atree.Storable, visited map[atree.StorageID]bool) uint64 {
+	var size uint64
+	for _, child := range storable.ChildStorables() {
+		if id, ok := child.(atree.StorageIDStorable); ok {
+			size += e.slabSize(atree.StorageID(id), visited)
+			continue
+		}
+		size += e.childSlabsSize(child, visited)
+	}
+	return size
+}
+
+// remainingRegisters describes the registers which are not part of any other node,
+// like the storage used of the account, and slabs which are not referenced anymore.
+func (e *explorer) remainingRegisters() *Node {
+	node := &Node{Name: "registers"}
+	var unreferenced *Node
+	for key, reg := range e.registers {
+		if e.seen[key] {
+			continue
+		}
+		if atree.LedgerKeyIsSlabKey(key) {
+			if unreferenced == nil {
+				unreferenced = &Node{Name: "unreferenced slabs"}
+			}
+			unreferenced.Size += reg.size
+			continue
+		}
+		node.Children = append(node.Children, &Node{
+			Name: key,
+			Size: reg.size,
+		})
+	}
+	if unreferenced != nil {
+		node.Children = append(node.Children, unreferenced)
+	}
+
+	for _, child := range node.Children {
+		node.Size += child.Size
+	}
+	sortBySize(node.Children)
+
+	return node
+}
+
+func sortBySize(nodes []*Node) {
+	sort.SliceStable(nodes, func(i, j int) bool {
+		if nodes[i].Size != nodes[j].Size {
+			return nodes[i].Size > nodes[j].Size
+		}
+		return nodes[i].Name < nodes[j].Name
+	})
+}
+
+// readOnlyLedger provides the non-controlled registers of an account to Cadence.
+type readOnlyLedger struct {
+	registers map[string]*register
+}
+
+var _ atree.Ledger = &readOnlyLedger{}
+
+func (l *readOnlyLedger) GetValue(_, key []byte) ([]byte, error) {
+	reg, ok := l.registers[string(key)]
+	if !ok || reg.isController {
+		return nil, nil
+	}
+	return reg.value, nil
+}
+
+func (l *readOnlyLedger) SetValue(_, _, _ []byte) error {
+	return fmt.Errorf("execution state is read-only")
+}
+
+func (l *readOnlyLedger) ValueExists(owner, key []byte) (bool, error) {
+	value, err := l.GetValue(owner, key)
+	return len(value) > 0, err
+}
+
+func (l *readOnlyLedger) AllocateStorageIndex(_ []byte) (atree.StorageIndex, error) {
+	return atree.StorageIndex{}, fmt.Errorf("execution state is read-only")
+}
@@ -0,0 +1,155 @@
+package account_storage
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	executionState "github.com/onflow/flow-go/engine/execution/state"
+	"github.com/onflow/flow-go/engine/execution/state/delta"
+	"github.com/onflow/flow-go/fvm"
+	"github.com/onflow/flow-go/fvm/programs"
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// bootstrappedPayloads returns the payloads of a bootstrapped execution state.
+func bootstrappedPayloads(t *testing.T, chain flow.Chain) []ledger.Payload {
+	vm := fvm.NewVirtualMachine(fvm.NewInterpreterRuntime())
+	ctx := fvm.NewContext(zerolog.Nop(), fvm.WithChain(chain))
+	view := delta.NewView(func(owner, controller, key string) (flow.RegisterValue, error) {
+		return nil, nil
+	})
+
+	err := vm.Run(ctx, fvm.Bootstrap(
+		unittest.ServiceAccountPublicKey,
+		fvm.WithInitialTokenSupply(unittest.GenesisTokenSupply),
+	), view, programs.NewEmptyPrograms())
+	require.NoError(t, err)
+
+	ids, values := view.RegisterUpdates()
+	payloads := make([]ledger.Payload, 0, len(ids))
+	for i, id := range ids {
+		if len(values[i]) == 0 {
+			continue
+		}
+		payloads = append(payloads, *ledger.NewPayload(executionState.RegisterIDToKey(id), values[i]))
+	}
+	return payloads
+}
+
+func child(t *testing.T, node *Node, name string) *Node {
+	for _, c := range node.Children {
+		if c.Name == name {
+			return c
+		}
+	}
+	require.Failf(t, "missing child", "%s has no child %s", node.Name, name)
+	return nil
+}
+
+func TestExploreAccount(t *testing.T) {
+	chain := flow.Testnet.Chain()
+	payloads := bootstrappedPayloads(t, chain)
+
+	accounts, err := GroupByOwner(payloads)
+	require.NoError(t, err)
+
+	service := chain.ServiceAddress()
+	root, err := ExploreAccount(service, accounts[service], 2)
+	require.NoError(t, err)
+
+	t.Run("sizes add up to the registers of the account", func(t *testing.T) {
+		var total, children uint64
+		for _, payload := range accounts[service] {
+			total += uint64(payload.Size())
+		}
+		assert.NotZero(t, total)
+
+		for _, c := range root.Children {
+			children += c.Size
+		}
+		assert.Equal(t, root.Size, children)
+	})
+
+	t.Run("keys", func(t *testing.T) {
+		keys := child(t, root, "keys")
+		require.Len(t, keys.Children, 1)
+		assert.Equal(t, "0", keys.Children[0].Name)
+		assert.NotZero(t, keys.Children[0].Size)
+	})
+
+	t.Run("contracts", func(t *testing.T) {
+		contracts := child(t, root, "contracts")
+		code := child(t, contracts, "FlowServiceAccount")
+		assert.Equal(t, "code", code.Type)
+		assert.NotZero(t, code.Size)
+
+		value := child(t, child(t, root, "contract"), "FlowServiceAccount")
+		assert.Contains(t, value.Type, "FlowServiceAccount")
+	})
+
+	t.Run("storage", func(t *testing.T) {
+		vault := child(t, child(t, root, "storage"), "/storage/flowTokenVault")
+		assert.Contains(t, vault.Type, "FlowToken.Vault")
+		assert.Equal(t, "resource", vault.Value)
+		assert.NotZero(t, vault.Size)
+
+		balance := child(t, vault, "balance")
+		assert.Equal(t, "UFix64", balance.Type)
+	})
+
+	t.Run("depth", func(t *testing.T) {
+		root, err := ExploreAccount(service, accounts[service], 0)
+		require.NoError(t, err)
+
+		vault := child(t, child(t, root, "storage"), "/storage/flowTokenVault")
+		assert.Empty(t, vault.Children)
+	})
+
+	t.Run("output", func(t *testing.T) {
+		var buf bytes.Buffer
+		PrintTree(&buf, root)
+		assert.Contains(t, buf.String(), "/storage/flowTokenVault")
+
+		var decoded Node
+		b, err := json.Marshal(root)
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(b, &decoded))
+		assert.Equal(t, *root, decoded)
+	})
+}
+
+func TestRank(t *testing.T) {
+	chain := flow.Testnet.Chain()
+	payloads := bootstrappedPayloads(t, chain)
+
+	accounts, err := GroupByOwner(payloads)
+	require.NoError(t, err)
+
+	ranked, paths, err := Rank(payloads, 3)
+	require.NoError(t, err)
+	require.Len(t, ranked, 3)
+	require.Len(t, paths, 3)
+
+	for i, account := range ranked {
+		var size uint64
+		for _, payload := range accounts[account.Address] {
+			size += uint64(payload.Size())
+		}
+		assert.NotZero(t, size)
+		assert.Len(t, accounts[account.Address], account.Registers)
+		if i > 0 {
+			assert.GreaterOrEqual(t, ranked[i-1].Size, account.Size)
+		}
+	}
+
+	for i := 1; i < len(paths); i++ {
+		assert.GreaterOrEqual(t, paths[i-1].Size, paths[i].Size)
+	}
+}
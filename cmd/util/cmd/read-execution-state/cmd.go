@@ -1,12 +1,14 @@
 package read
 
 import (
+	"path/filepath"
 	"time"
 
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
 
+	account_storage "github.com/onflow/flow-go/cmd/util/cmd/read-execution-state/account-storage"
 	list_accounts "github.com/onflow/flow-go/cmd/util/cmd/read-execution-state/list-accounts"
 	list_tries "github.com/onflow/flow-go/cmd/util/cmd/read-execution-state/list-tries"
 	list_wals "github.com/onflow/flow-go/cmd/util/cmd/read-execution-state/list-wals"
@@ -14,6 +16,7 @@ import (
 	"github.com/onflow/flow-go/ledger/common/pathfinder"
 	"github.com/onflow/flow-go/ledger/complete"
 	"github.com/onflow/flow-go/ledger/complete/mtrie"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
 	"github.com/onflow/flow-go/ledger/complete/wal"
 	"github.com/onflow/flow-go/module/metrics"
 )
@@ -41,6 +44,7 @@ func addSubcommands() {
 	Cmd.AddCommand(list_tries.Init(loadExecutionState))
 	Cmd.AddCommand(list_accounts.Init(loadExecutionState))
 	Cmd.AddCommand(list_wals.Init())
+	Cmd.AddCommand(account_storage.Init(loadExecutionState, loadCheckpoint))
 }
 
 func loadExecutionState() *mtrie.Forest {
@@ -74,6 +78,18 @@ func loadExecutionState() *mtrie.Forest {
 	return forest
 }
 
+// loadCheckpoint loads the tries of the given checkpoint file in the execution state dir,
+// which is much faster than replaying the whole WAL.
+func loadCheckpoint(name string) []*trie.MTrie {
+
+	tries, err := wal.LoadCheckpoint(filepath.Join(flagExecutionStateDir, name))
+	if err != nil {
+		log.Fatal().Err(err).Str("checkpoint", name).Msg("error while loading checkpoint")
+	}
+
+	return tries
+}
+
 func run(*cobra.Command, []string) {
 
 	log.Info().Msg("reading")
@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-go/cmd/util/cmd/common"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/state/protocol"
+	"github.com/onflow/flow-go/state/protocol/badger"
+	"github.com/onflow/flow-go/state/protocol/inmem"
+)
+
+var (
+	flagSnapshotHeight  uint64
+	flagSnapshotBlockID string
+	flagSnapshotSealed  bool
+	flagSnapshotOutput  string
+)
+
+// run with `./util read-protocol-state snapshot --datadir /var/flow/data/protocol --height 1000 --output root-protocol-state-snapshot.json`
+var SnapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "Export the protocol state snapshot as of a finalized block, usable as a root snapshot to bootstrap a node",
+	Run:   runSnapshot,
+}
+
+func init() {
+	rootCmd.AddCommand(SnapshotCmd)
+
+	SnapshotCmd.Flags().Uint64Var(&flagSnapshotHeight, "height", 0,
+		"height of the finalized block")
+
+	SnapshotCmd.Flags().StringVar(&flagSnapshotBlockID, "block-id", "",
+		"ID of the finalized block (hex-encoded, 64 characters)")
+
+	SnapshotCmd.Flags().BoolVar(&flagSnapshotSealed, "sealed", false,
+		"use the latest sealed block")
+
+	SnapshotCmd.Flags().StringVar(&flagSnapshotOutput, "output", "",
+		"file to write the snapshot to")
+	_ = SnapshotCmd.MarkFlagRequired("output")
+}
+
+func runSnapshot(*cobra.Command, []string) {
+	db := common.InitStorage(flagDatadir)
+	defer db.Close()
+
+	storages := common.InitStorages(db)
+	state, err := common.InitProtocolState(db, storages)
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not init protocol state")
+	}
+
+	var snapshot protocol.Snapshot
+	switch {
+	case flagSnapshotHeight > 0:
+		snapshot = state.AtHeight(flagSnapshotHeight)
+	case flagSnapshotBlockID != "":
+		blockID, err := flow.HexStringToIdentifier(flagSnapshotBlockID)
+		if err != nil {
+			log.Fatal().Err(err).Msgf("malformed block ID: %v", flagSnapshotBlockID)
+		}
+		snapshot = state.AtBlockID(blockID)
+	case flagSnapshotSealed:
+		snapshot = state.Sealed()
+	default:
+		log.Fatal().Msg("missing flag, try --height or --block-id or --sealed")
+	}
+
+	head, err := snapshot.Head()
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not get snapshot head")
+	}
+	blockID := head.ID()
+	log.Info().
+		Uint64("height", head.Height).
+		Hex("block_id", blockID[:]).
+		Msg("exporting snapshot")
+
+	err = exportSnapshot(state, snapshot, head, flagSnapshotOutput)
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not export snapshot")
+	}
+
+	log.Info().Str("output", flagSnapshotOutput).Msg("snapshot exported")
+}
+
+// exportSnapshot writes the given snapshot to the output file, after checking
+// it is a snapshot of a finalized block that can be used as root snapshot.
+func exportSnapshot(state protocol.State, snapshot protocol.Snapshot, head *flow.Header, output string) error {
+	// only finalized blocks are indexed by height
+	finalized, err := state.AtHeight(head.Height).Head()
+	if err != nil {
+		return fmt.Errorf("could not get finalized block at height %d: %w", head.Height, err)
+	}
+	if finalized.ID() != head.ID() {
+		return fmt.Errorf("block %v is not finalized", head.ID())
+	}
+
+	converted, err := inmem.FromSnapshot(snapshot)
+	if err != nil {
+		return fmt.Errorf("could not convert snapshot: %w", err)
+	}
+
+	// the snapshot is rejected if its sealing segment spans an epoch transition
+	err = badger.IsValidRootSnapshot(converted, true)
+	if err != nil {
+		return fmt.Errorf("snapshot is not a valid root snapshot: %w", err)
+	}
+
+	data, err := json.MarshalIndent(converted.Encodable(), "", "  ")
+	if err != nil {
+		return fmt.Errorf("could not encode snapshot: %w", err)
+	}
+	err = os.WriteFile(output, data, 0644)
+	if err != nil {
+		return fmt.Errorf("could not write snapshot: %w", err)
+	}
+	return nil
+}
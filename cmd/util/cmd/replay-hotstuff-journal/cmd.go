@@ -0,0 +1,94 @@
+package replay_hotstuff_journal
+
+import (
+	"errors"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-go/cmd/util/cmd/common"
+	"github.com/onflow/flow-go/consensus/hotstuff/committees"
+	"github.com/onflow/flow-go/consensus/hotstuff/journal"
+	"github.com/onflow/flow-go/consensus/hotstuff/signature"
+	"github.com/onflow/flow-go/consensus/hotstuff/verification"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+var (
+	flagJournal string
+	flagDatadir string
+	flagNodeID  string
+)
+
+// run with `./util replay-hotstuff-journal --journal /var/flow/hotstuff.journal --datadir /var/flow/data/protocol --node-id 1234...`
+var Cmd = &cobra.Command{
+	Use:   "replay-hotstuff-journal",
+	Short: "Replay the journal of a consensus node's hotstuff event handler and check that it produces the same votes, proposals and finalized blocks",
+	Run:   run,
+}
+
+func init() {
+
+	Cmd.Flags().StringVar(&flagJournal, "journal", "",
+		"path of the journal recorded with the --hotstuff-journal flag of the consensus node")
+	_ = Cmd.MarkFlagRequired("journal")
+
+	Cmd.Flags().StringVar(&flagDatadir, "datadir", "",
+		"directory that stores the protocol state of the consensus node, or a copy of it")
+	_ = Cmd.MarkFlagRequired("datadir")
+
+	Cmd.Flags().StringVar(&flagNodeID, "node-id", "",
+		"node ID of the consensus node which recorded the journal")
+	_ = Cmd.MarkFlagRequired("node-id")
+}
+
+func run(*cobra.Command, []string) {
+	log.Info().
+		Str("journal", flagJournal).
+		Str("datadir", flagDatadir).
+		Str("node_id", flagNodeID).
+		Msg("flags")
+
+	nodeID, err := flow.HexStringToIdentifier(flagNodeID)
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not parse node ID")
+	}
+
+	entries, err := journal.ReadFile(flagJournal)
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not read journal")
+	}
+
+	db := common.InitStorage(flagDatadir)
+	defer db.Close()
+
+	storages := common.InitStorages(db)
+	state, err := common.InitProtocolState(db, storages)
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not init protocol state")
+	}
+
+	committee, err := committees.NewConsensusCommittee(state, nodeID)
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not create consensus committee")
+	}
+	verifier := verification.NewCombinedVerifier(committee, signature.NewConsensusSigDataPacker(committee))
+
+	outputs, err := journal.Replay(log.Logger, entries, committee, verifier)
+	var divergence *journal.DivergenceError
+	if errors.As(err, &divergence) {
+		log.Fatal().
+			Int("index", divergence.Index).
+			Str("recorded", divergence.Recorded.String()).
+			Str("replayed", divergence.Replayed.String()).
+			Msg("replay diverged from journal")
+	}
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not replay journal")
+	}
+
+	log.Info().
+		Int("entries", len(entries)).
+		Int("outputs", len(outputs)).
+		Msg("replay matches journal")
+}
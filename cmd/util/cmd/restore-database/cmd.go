@@ -0,0 +1,156 @@
+package restore_database
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-go/cmd/util/cmd/common"
+	"github.com/onflow/flow-go/storage/backup"
+	storage "github.com/onflow/flow-go/storage/badger"
+)
+
+var (
+	flagBackupDir        string
+	flagDatadir          string
+	flagChunkDataPackDir string
+	flagTriedir          string
+)
+
+// run with `./util restore-database --backup-dir /backups/2022-08-01 --datadir /var/flow/data/protocol --chunk-data-pack-dir /var/flow/data/chunk_data_packs --triedir /var/flow/data/execution`
+var Cmd = &cobra.Command{
+	Use:   "restore-database",
+	Short: "Restore the protocol database, and the chunk data packs database and execution state checkpoint of execution nodes, from a backup taken with the backup-database admin command",
+	Run:   run,
+}
+
+func init() {
+
+	Cmd.Flags().StringVar(&flagBackupDir, "backup-dir", "",
+		"directory of the backup")
+	_ = Cmd.MarkFlagRequired("backup-dir")
+
+	Cmd.Flags().StringVar(&flagDatadir, "datadir", "",
+		"empty directory to restore the protocol state into")
+	_ = Cmd.MarkFlagRequired("datadir")
+
+	Cmd.Flags().StringVar(&flagChunkDataPackDir, "chunk-data-pack-dir", "",
+		"empty directory to restore the chunk data packs database into, required if the backup has one")
+
+	Cmd.Flags().StringVar(&flagTriedir, "triedir", "",
+		"directory to restore the execution state checkpoint into, required if the backup has one")
+}
+
+func run(*cobra.Command, []string) {
+	log.Info().
+		Str("backup_dir", flagBackupDir).
+		Str("datadir", flagDatadir).
+		Str("chunk_data_pack_dir", flagChunkDataPackDir).
+		Str("triedir", flagTriedir).
+		Msg("flags")
+
+	// the manifest and checksums are checked before anything is written
+	manifest, err := backup.Verify(flagBackupDir)
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not verify backup")
+	}
+
+	log.Info().
+		Time("created_at", manifest.CreatedAt).
+		Uint64("finalized_height", manifest.FinalizedHeight).
+		Uint64("sealed_height", manifest.SealedHeight).
+		Uint64("executed_height", manifest.ExecutedHeight).
+		Msg("backup verified")
+
+	if manifest.StateCommitment != nil && flagTriedir == "" {
+		log.Fatal().Msg("backup has an execution state checkpoint, --triedir is required")
+	}
+	hasChunkDataPacks := manifest.File(backup.ChunkDataPacksDBFilename) != nil
+	if hasChunkDataPacks && flagChunkDataPackDir == "" {
+		log.Fatal().Msg("backup has a chunk data packs database, --chunk-data-pack-dir is required")
+	}
+
+	err = ensureEmpty(flagDatadir)
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot restore protocol database")
+	}
+	if hasChunkDataPacks {
+		err = ensureEmpty(flagChunkDataPackDir)
+		if err != nil {
+			log.Fatal().Err(err).Msg("cannot restore chunk data packs database")
+		}
+	}
+
+	db := common.InitStorage(flagDatadir)
+	defer db.Close()
+
+	err = backup.RestoreProtocolDB(flagBackupDir, manifest, db)
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not restore protocol database")
+	}
+
+	if hasChunkDataPacks {
+		restoreChunkDataPacks(manifest)
+	}
+
+	if manifest.StateCommitment != nil {
+		err = backup.RestoreCheckpoint(flagBackupDir, manifest, flagTriedir)
+		if err != nil {
+			log.Fatal().Err(err).Msg("could not restore execution state checkpoint")
+		}
+
+		log.Info().
+			Hex("state_commitment", manifest.StateCommitment[:]).
+			Str("triedir", flagTriedir).
+			Msg("execution state checkpoint restored")
+	}
+
+	log.Info().Msg("backup restored")
+}
+
+// restoreChunkDataPacks restores the chunk data packs database of the backup,
+// opened like the execution node does.
+func restoreChunkDataPacks(manifest *backup.Manifest) {
+	err := os.MkdirAll(flagChunkDataPackDir, 0700)
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not create chunk data packs dir")
+	}
+
+	opts := badger.
+		DefaultOptions(flagChunkDataPackDir).
+		WithKeepL0InMemory(true).
+		WithLogger(nil).
+		WithValueLogFileSize(128 << 23).
+		WithValueLogMaxEntries(100000)
+
+	chunkDataPacksDB, err := storage.InitChunkDataPacks(opts)
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not open chunk data packs db")
+	}
+	defer chunkDataPacksDB.Close()
+
+	err = backup.RestoreChunkDataPacksDB(flagBackupDir, manifest, chunkDataPacksDB)
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not restore chunk data packs database")
+	}
+
+	log.Info().Str("chunk_data_pack_dir", flagChunkDataPackDir).Msg("chunk data packs database restored")
+}
+
+// ensureEmpty checks the given directory doesn't exist or is empty.
+func ensureEmpty(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("could not read directory %s: %w", dir, err)
+	}
+	if len(entries) > 0 {
+		return fmt.Errorf("directory %s is not empty", dir)
+	}
+	return nil
+}
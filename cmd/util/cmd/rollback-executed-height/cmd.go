@@ -0,0 +1,117 @@
+package rollback_executed_height
+
+import (
+	"github.com/dgraph-io/badger/v2"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-go/cmd/util/cmd/common"
+	"github.com/onflow/flow-go/ledger"
+)
+
+var (
+	flagDatadir        string
+	flagTriedir        string
+	flagChunkDataPacks string
+	flagHeight         uint64
+	flagMTrieCacheSize uint32
+)
+
+// run with `./util rollback-executed-height --datadir /var/flow/data/protocol --triedir /var/flow/data/execution --height 1000`
+var Cmd = &cobra.Command{
+	Use:   "rollback-executed-height",
+	Short: "Rollback the execution results above the given height, so that the blocks are executed again (execution node must be stopped)",
+	Long: `Rollback the execution results above the given height, so that the blocks are executed again (execution node must be stopped).
+
+The execution results of each block are removed in a single transaction, and the highest executed
+block is reset last. If the command is interrupted, the node must not be started before running the
+command again with the same height, which rolls back the remaining blocks.`,
+	Run: run,
+}
+
+func init() {
+
+	Cmd.Flags().StringVar(&flagDatadir, "datadir", "",
+		"directory that stores the protocol state")
+	_ = Cmd.MarkFlagRequired("datadir")
+
+	Cmd.Flags().StringVar(&flagTriedir, "triedir", "",
+		"directory that stores the execution state, if set the tries above the height are pruned from the ledger")
+
+	Cmd.Flags().StringVar(&flagChunkDataPacks, "chunk-data-pack-dir", "",
+		"directory that stores the chunk data packs database, if set the chunk data packs above the height are removed from it")
+
+	Cmd.Flags().Uint64Var(&flagHeight, "height", 0,
+		"the height of the finalized block to rollback the execution to, it becomes the highest executed block")
+	_ = Cmd.MarkFlagRequired("height")
+
+	Cmd.Flags().Uint32Var(&flagMTrieCacheSize, "mtrie-cache-size", 500,
+		"cache size for MTrie, must match the execution node")
+}
+
+func run(*cobra.Command, []string) {
+	log.Info().
+		Str("datadir", flagDatadir).
+		Str("triedir", flagTriedir).
+		Str("chunk_data_pack_dir", flagChunkDataPacks).
+		Uint64("height", flagHeight).
+		Msg("flags")
+
+	db := common.InitStorage(flagDatadir)
+	defer db.Close()
+
+	blockID, commit, err := findRollbackBlock(db, flagHeight)
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not find block to rollback to")
+	}
+
+	blocks, err := findExecutedDescendants(db, blockID)
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not find executed blocks to rollback")
+	}
+
+	log.Info().
+		Hex("block_id", blockID[:]).
+		Hex("state_commitment", commit[:]).
+		Int("blocks", len(blocks)).
+		Msg("rolling back executed blocks")
+
+	// the tries are loaded before the database is written, to check that the trie to rollback to is
+	// in the ledger, but they are only pruned once the database is rolled back: if the rollback is
+	// interrupted before, the blocks are still found as executed when running the command again
+	var pruner *triePruner
+	if flagTriedir != "" {
+		prune := make(map[ledger.RootHash]struct{}, len(blocks))
+		for _, block := range blocks {
+			prune[ledger.RootHash(block.Commit)] = struct{}{}
+		}
+
+		pruner, err = newTriePruner(log.Logger, flagTriedir, int(flagMTrieCacheSize), commit, prune)
+		if err != nil {
+			log.Fatal().Err(err).Msg("could not load tries")
+		}
+		defer pruner.Close()
+	}
+
+	var chunkDataPacksDB *badger.DB
+	if flagChunkDataPacks != "" {
+		chunkDataPacksDB = common.InitStorage(flagChunkDataPacks)
+		defer chunkDataPacksDB.Close()
+	}
+
+	err = rollbackDatabase(log.Logger, db, chunkDataPacksDB, blockID, flagHeight, blocks)
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not rollback execution results")
+	}
+
+	// the tries of the rolled back blocks are not used anymore once the database is rolled back, so
+	// failing to prune them only wastes memory on the execution node, which can still be started
+	if pruner != nil {
+		err = pruner.Prune()
+		if err != nil {
+			log.Fatal().Err(err).Msg("could not prune tries")
+		}
+	}
+
+	log.Info().Msgf("executed height rolled back to %d, blocks above will be executed again on restart", flagHeight)
+}
@@ -0,0 +1,404 @@
+package rollback_executed_height
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/pathfinder"
+	"github.com/onflow/flow-go/ledger/complete/mtrie"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
+	"github.com/onflow/flow-go/ledger/complete/wal"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/storage/badger/operation"
+)
+
+// executedBlock is a block whose execution results are rolled back.
+type executedBlock struct {
+	BlockID flow.Identifier
+	Height  uint64
+	Commit  flow.StateCommitment
+}
+
+// findRollbackBlock returns the finalized block at the given height, and the state commitment
+// of its execution, which will be the highest executed block after the rollback.
+func findRollbackBlock(db *badger.DB, height uint64) (flow.Identifier, flow.StateCommitment, error) {
+	var blockID flow.Identifier
+	err := db.View(operation.LookupBlockHeight(height, &blockID))
+	if err != nil {
+		return flow.ZeroID, flow.DummyStateCommitment, fmt.Errorf("could not find finalized block at height %d: %w", height, err)
+	}
+
+	var commit flow.StateCommitment
+	err = db.View(operation.LookupStateCommitment(blockID, &commit))
+	if err != nil {
+		return flow.ZeroID, flow.DummyStateCommitment, fmt.Errorf("could not find state commitment of block %v at height %d, has it been executed?: %w", blockID, height, err)
+	}
+
+	return blockID, commit, nil
+}
+
+// findExecutedDescendants returns all executed descendants of the given block, finalized or not.
+// Descendants are ordered such that every block comes before its ancestors.
+func findExecutedDescendants(db *badger.DB, blockID flow.Identifier) ([]executedBlock, error) {
+	var descendants []executedBlock
+
+	err := db.View(func(tx *badger.Txn) error {
+		queue := []flow.Identifier{blockID}
+		for len(queue) > 0 {
+			parentID := queue[0]
+			queue = queue[1:]
+
+			var childrenIDs []flow.Identifier
+			err := operation.RetrieveBlockChildren(parentID, &childrenIDs)(tx)
+			if errors.Is(err, storage.ErrNotFound) {
+				continue
+			}
+			if err != nil {
+				return fmt.Errorf("could not retrieve children of block %v: %w", parentID, err)
+			}
+
+			for _, childID := range childrenIDs {
+				var commit flow.StateCommitment
+				err := operation.LookupStateCommitment(childID, &commit)(tx)
+				if errors.Is(err, storage.ErrNotFound) {
+					// blocks can only be executed after their parent, so none of
+					// the descendants of a block which is not executed are
+					continue
+				}
+				if err != nil {
+					return fmt.Errorf("could not look up state commitment of block %v: %w", childID, err)
+				}
+
+				var header flow.Header
+				err = operation.RetrieveHeader(childID, &header)(tx)
+				if err != nil {
+					return fmt.Errorf("could not retrieve header of block %v: %w", childID, err)
+				}
+
+				descendants = append(descendants, executedBlock{
+					BlockID: childID,
+					Height:  header.Height,
+					Commit:  commit,
+				})
+				queue = append(queue, childID)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// the blocks were found in breadth-first order, so reversing them
+	// orders every block before its ancestors
+	for i, j := 0, len(descendants)-1; i < j; i, j = i+1, j-1 {
+		descendants[i], descendants[j] = descendants[j], descendants[i]
+	}
+
+	return descendants, nil
+}
+
+// rollbackDatabase removes the execution results of the given blocks, and then resets the highest
+// executed block to the given block. The execution results of each block are removed in a single
+// transaction, descendants first, so an interrupted rollback leaves every block either executed or not
+// executed at all, and no executed block has a block which is not executed as ancestor. Only the highest
+// executed block is out of date until the rollback is completed by running it again, which finds the
+// remaining executed blocks. If the chunk data packs database is given, the chunk data packs of each block
+// are removed from it before its execution results, as they are found through them.
+func rollbackDatabase(log zerolog.Logger, db *badger.DB, chunkDataPacksDB *badger.DB, blockID flow.Identifier, height uint64, blocks []executedBlock) error {
+	for _, block := range blocks {
+		err := rollbackBlock(db, chunkDataPacksDB, block.BlockID)
+		if err != nil {
+			return fmt.Errorf("could not remove execution results of block %v at height %d: %w", block.BlockID, block.Height, err)
+		}
+
+		log.Info().
+			Hex("block_id", block.BlockID[:]).
+			Uint64("height", block.Height).
+			Msg("execution results removed")
+	}
+
+	// the highest executed block is written last, as the blocks above are not found by the
+	// node anymore once it is reset
+	err := db.Update(func(tx *badger.Txn) error {
+		var highestID flow.Identifier
+		err := operation.RetrieveExecutedBlock(&highestID)(tx)
+		if err != nil {
+			return fmt.Errorf("could not retrieve highest executed block: %w", err)
+		}
+
+		var highest flow.Header
+		err = operation.RetrieveHeader(highestID, &highest)(tx)
+		if err != nil {
+			return fmt.Errorf("could not retrieve highest executed header: %w", err)
+		}
+
+		if highest.Height <= height {
+			return nil
+		}
+
+		log.Info().
+			Uint64("from_height", highest.Height).
+			Uint64("to_height", height).
+			Msg("resetting highest executed block")
+
+		return operation.UpdateExecutedBlock(blockID)(tx)
+	})
+	if err != nil {
+		return fmt.Errorf("could not reset highest executed block: %w", err)
+	}
+
+	return nil
+}
+
+// rollbackBlock removes the execution results of the given block in a single transaction of the
+// protocol database, after removing its chunk data packs from the chunk data packs database if given.
+func rollbackBlock(db *badger.DB, chunkDataPacksDB *badger.DB, blockID flow.Identifier) error {
+	if chunkDataPacksDB != nil {
+		var chunkIDs []flow.Identifier
+		err := db.View(lookupChunkIDs(blockID, &chunkIDs))
+		if err != nil {
+			return err
+		}
+
+		err = chunkDataPacksDB.Update(func(tx *badger.Txn) error {
+			for _, chunkID := range chunkIDs {
+				err := operation.RemoveChunkDataPack(chunkID)(tx)
+				if err != nil {
+					return fmt.Errorf("could not remove chunk data pack of chunk %v: %w", chunkID, err)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("could not remove chunk data packs: %w", err)
+		}
+	}
+
+	return db.Update(removeExecutionResults(blockID))
+}
+
+// lookupChunkIDs looks up the IDs of the chunks of the execution result of the given block, if any.
+func lookupChunkIDs(blockID flow.Identifier, chunkIDs *[]flow.Identifier) func(*badger.Txn) error {
+	return func(tx *badger.Txn) error {
+		var resultID flow.Identifier
+		err := operation.LookupExecutionResult(blockID, &resultID)(tx)
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("could not look up execution result: %w", err)
+		}
+
+		var result flow.ExecutionResult
+		err = operation.RetrieveExecutionResult(resultID, &result)(tx)
+		if err != nil {
+			return fmt.Errorf("could not retrieve execution result: %w", err)
+		}
+
+		for _, chunk := range result.Chunks {
+			*chunkIDs = append(*chunkIDs, chunk.ID())
+		}
+		return nil
+	}
+}
+
+// removeExecutionResults removes everything stored when the given block was executed.
+func removeExecutionResults(blockID flow.Identifier) func(*badger.Txn) error {
+	return func(tx *badger.Txn) error {
+		var resultID flow.Identifier
+		err := operation.LookupExecutionResult(blockID, &resultID)(tx)
+		if err == nil {
+			var result flow.ExecutionResult
+			err = operation.RetrieveExecutionResult(resultID, &result)(tx)
+			if err != nil {
+				return fmt.Errorf("could not retrieve execution result: %w", err)
+			}
+
+			for _, chunk := range result.Chunks {
+				chunkID := chunk.ID()
+				// chunk data packs are only in the protocol database if they were not migrated yet
+				err = operation.RemoveChunkDataPack(chunkID)(tx)
+				if err != nil {
+					return fmt.Errorf("could not remove chunk data pack: %w", err)
+				}
+				err = operation.RemoveBlockIDByChunkID(chunkID)(tx)
+				if err != nil {
+					return fmt.Errorf("could not remove block index of chunk: %w", err)
+				}
+			}
+
+			err = operation.RemoveExecutionResult(resultID)(tx)
+			if err != nil {
+				return fmt.Errorf("could not remove execution result: %w", err)
+			}
+			err = operation.RemoveExecutionResultIndex(blockID)(tx)
+			if err != nil {
+				return fmt.Errorf("could not remove execution result index: %w", err)
+			}
+		} else if !errors.Is(err, storage.ErrNotFound) {
+			return fmt.Errorf("could not look up execution result: %w", err)
+		}
+
+		var receiptID flow.Identifier
+		err = operation.LookupOwnExecutionReceipt(blockID, &receiptID)(tx)
+		if err == nil {
+			err = operation.RemoveExecutionReceiptMeta(receiptID)(tx)
+			if err != nil {
+				return fmt.Errorf("could not remove execution receipt: %w", err)
+			}
+			err = operation.RemoveExecutionReceiptIndex(blockID, receiptID)(tx)
+			if err != nil {
+				return fmt.Errorf("could not remove execution receipt index: %w", err)
+			}
+			err = operation.RemoveOwnExecutionReceipt(blockID)(tx)
+			if err != nil {
+				return fmt.Errorf("could not remove own execution receipt index: %w", err)
+			}
+		} else if !errors.Is(err, storage.ErrNotFound) {
+			return fmt.Errorf("could not look up own execution receipt: %w", err)
+		}
+
+		err = operation.RemoveEventsByBlockID(blockID)(tx)
+		if err != nil {
+			return fmt.Errorf("could not remove events: %w", err)
+		}
+
+		err = operation.RemoveServiceEventsByBlockID(blockID)(tx)
+		if err != nil {
+			return fmt.Errorf("could not remove service events: %w", err)
+		}
+
+		err = operation.RemoveTransactionResultsByBlockID(blockID)(tx)
+		if err != nil {
+			return fmt.Errorf("could not remove transaction results: %w", err)
+		}
+
+		err = operation.RemoveTransactionRegisterSetsByBlockID(blockID)(tx)
+		if err != nil {
+			return fmt.Errorf("could not remove transaction register sets: %w", err)
+		}
+
+		err = operation.RemoveStateCommitment(blockID)(tx)
+		if err != nil {
+			return fmt.Errorf("could not remove state commitment: %w", err)
+		}
+
+		return nil
+	}
+}
+
+// triePruner removes tries from the ledger in a directory, by writing a checkpoint without them,
+// which covers all WAL segments.
+type triePruner struct {
+	log     zerolog.Logger
+	diskWAL *wal.DiskWAL
+	kept    []*trie.MTrie
+	pruned  int
+}
+
+// newTriePruner loads the tries of the ledger in the given directory, and keeps the ones which are not
+// to be pruned. The trie at the state commitment the execution is rolled back to must be in the ledger,
+// and is never pruned. Nothing is written until Prune is called, and Close must be called in any case.
+func newTriePruner(log zerolog.Logger, dir string, forestCapacity int, keep flow.StateCommitment, prune map[ledger.RootHash]struct{}) (*triePruner, error) {
+	// opening the WAL starts a new segment, so that the checkpoint covering all
+	// existing segments will not cover the updates written after the rollback
+	diskWAL, err := wal.NewDiskWAL(log.With().Str("subcomponent", "wal").Logger(), nil, &metrics.NoopCollector{}, dir, forestCapacity, pathfinder.PathByteSize, wal.SegmentSize)
+	if err != nil {
+		return nil, fmt.Errorf("could not open WAL: %w", err)
+	}
+	p := &triePruner{
+		log:     log,
+		diskWAL: diskWAL,
+	}
+
+	forest, err := mtrie.NewForest(forestCapacity, &metrics.NoopCollector{}, nil)
+	if err != nil {
+		p.Close()
+		return nil, fmt.Errorf("could not create forest: %w", err)
+	}
+
+	log.Info().Msg("replaying WAL")
+
+	err = diskWAL.ReplayOnForest(forest)
+	if err != nil {
+		p.Close()
+		return nil, fmt.Errorf("could not replay WAL: %w", err)
+	}
+
+	tries, err := forest.GetTries()
+	if err != nil {
+		p.Close()
+		return nil, fmt.Errorf("could not get tries: %w", err)
+	}
+
+	found := false
+	p.kept = make([]*trie.MTrie, 0, len(tries))
+	for _, t := range tries {
+		rootHash := t.RootHash()
+		if rootHash == ledger.RootHash(keep) {
+			found = true
+			p.kept = append(p.kept, t)
+			continue
+		}
+		if _, ok := prune[rootHash]; ok {
+			continue
+		}
+		p.kept = append(p.kept, t)
+	}
+	if !found {
+		p.Close()
+		return nil, fmt.Errorf("trie with root hash %x is not in the ledger, a more recent height must be rolled back to", keep)
+	}
+	p.pruned = len(tries) - len(p.kept)
+
+	return p, nil
+}
+
+// Prune writes the checkpoint without the pruned tries.
+func (p *triePruner) Prune() error {
+	_, last, err := p.diskWAL.Segments()
+	if err != nil {
+		return fmt.Errorf("could not get WAL segments: %w", err)
+	}
+
+	checkpointer, err := p.diskWAL.NewCheckpointer()
+	if err != nil {
+		return fmt.Errorf("could not create checkpointer: %w", err)
+	}
+
+	writer, err := checkpointer.CheckpointWriter(last)
+	if err != nil {
+		return fmt.Errorf("could not create checkpoint writer: %w", err)
+	}
+
+	p.log.Info().
+		Int("checkpoint", last).
+		Int("tries", len(p.kept)).
+		Int("pruned", p.pruned).
+		Msg("writing checkpoint")
+
+	err = wal.StoreCheckpoint(writer, p.kept...)
+	if err != nil {
+		_ = writer.Close()
+		return fmt.Errorf("could not store checkpoint: %w", err)
+	}
+
+	err = writer.Close()
+	if err != nil {
+		return fmt.Errorf("could not close checkpoint: %w", err)
+	}
+
+	return nil
+}
+
+// Close closes the WAL of the ledger.
+func (p *triePruner) Close() {
+	<-p.diskWAL.Done()
+}
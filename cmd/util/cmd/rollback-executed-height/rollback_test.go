@@ -0,0 +1,317 @@
+package rollback_executed_height
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/pathfinder"
+	"github.com/onflow/flow-go/ledger/common/utils"
+	"github.com/onflow/flow-go/ledger/complete"
+	"github.com/onflow/flow-go/ledger/complete/wal"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/storage"
+	badgermodel "github.com/onflow/flow-go/storage/badger/model"
+	"github.com/onflow/flow-go/storage/badger/operation"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// insertBlock inserts a block with the given parent, and indexes it as finalized if requested.
+func insertBlock(t *testing.T, db *badger.DB, parent *flow.Header, finalized bool) *flow.Header {
+	header := unittest.BlockHeaderWithParentFixture(parent)
+	require.NoError(t, db.Update(operation.InsertHeader(header.ID(), &header)))
+
+	var children []flow.Identifier
+	err := db.View(operation.RetrieveBlockChildren(parent.ID(), &children))
+	if errors.Is(err, storage.ErrNotFound) {
+		require.NoError(t, db.Update(operation.InsertBlockChildren(parent.ID(), []flow.Identifier{header.ID()})))
+	} else {
+		require.NoError(t, err)
+		require.NoError(t, db.Update(operation.UpdateBlockChildren(parent.ID(), append(children, header.ID()))))
+	}
+
+	if finalized {
+		require.NoError(t, db.Update(operation.IndexBlockHeight(header.Height, header.ID())))
+	}
+	return &header
+}
+
+// executeBlock stores execution results for the given block, as the execution state does, and
+// returns the IDs of its chunks. Chunk data packs are stored in both databases, as they are
+// in the protocol database until they are migrated.
+func executeBlock(t *testing.T, db *badger.DB, chunkDataPacksDB *badger.DB, header *flow.Header) []flow.Identifier {
+	blockID := header.ID()
+	result := unittest.ExecutionResultFixture(unittest.WithExecutionResultBlockID(blockID))
+	receipt := unittest.ExecutionReceiptFixture(unittest.WithResult(result))
+	txID := unittest.IdentifierFixture()
+
+	chunkIDs := make([]flow.Identifier, 0, len(result.Chunks))
+	for _, chunk := range result.Chunks {
+		err := chunkDataPacksDB.Update(operation.InsertChunkDataPack(&badgermodel.StoredChunkDataPack{ChunkID: chunk.ID()}))
+		require.NoError(t, err)
+		chunkIDs = append(chunkIDs, chunk.ID())
+	}
+
+	err := db.Update(func(tx *badger.Txn) error {
+		for _, chunk := range result.Chunks {
+			err := operation.InsertChunkDataPack(&badgermodel.StoredChunkDataPack{ChunkID: chunk.ID()})(tx)
+			require.NoError(t, err)
+			err = operation.IndexBlockIDByChunkID(chunk.ID(), blockID)(tx)
+			require.NoError(t, err)
+		}
+		require.NoError(t, operation.IndexStateCommitment(blockID, unittest.StateCommitmentFixture())(tx))
+		require.NoError(t, operation.InsertEvent(blockID, unittest.EventFixture(flow.EventAccountCreated, 0, 0, txID, 0))(tx))
+		require.NoError(t, operation.InsertServiceEvent(blockID, unittest.EventFixture(flow.EventAccountCreated, 0, 1, txID, 0))(tx))
+		require.NoError(t, operation.InsertTransactionResult(blockID, &flow.TransactionResult{TransactionID: txID})(tx))
+		require.NoError(t, operation.InsertTransactionRegisterSet(blockID, &flow.TransactionRegisterSet{TransactionID: txID})(tx))
+		require.NoError(t, operation.InsertExecutionResult(result)(tx))
+		require.NoError(t, operation.IndexExecutionResult(blockID, result.ID())(tx))
+		require.NoError(t, operation.InsertExecutionReceiptMeta(receipt.ID(), receipt.Meta())(tx))
+		require.NoError(t, operation.IndexExecutionReceipts(blockID, receipt.ID())(tx))
+		require.NoError(t, operation.IndexOwnExecutionReceipt(blockID, receipt.ID())(tx))
+		return nil
+	})
+	require.NoError(t, err)
+
+	return chunkIDs
+}
+
+// assertExecuted asserts whether anything is stored for the execution of the given block.
+func assertExecuted(t *testing.T, db *badger.DB, blockID flow.Identifier, executed bool) {
+	var commit flow.StateCommitment
+	err := db.View(operation.LookupStateCommitment(blockID, &commit))
+	assert.Equal(t, executed, err == nil)
+
+	var resultID flow.Identifier
+	err = db.View(operation.LookupExecutionResult(blockID, &resultID))
+	assert.Equal(t, executed, err == nil)
+
+	var receiptID flow.Identifier
+	err = db.View(operation.LookupOwnExecutionReceipt(blockID, &receiptID))
+	assert.Equal(t, executed, err == nil)
+
+	var receiptIDs []flow.Identifier
+	require.NoError(t, db.View(operation.LookupExecutionReceipts(blockID, &receiptIDs)))
+	assert.Equal(t, executed, len(receiptIDs) > 0)
+
+	var events []flow.Event
+	require.NoError(t, db.View(operation.LookupEventsByBlockID(blockID, &events)))
+	assert.Equal(t, executed, len(events) > 0)
+
+	var serviceEvents []flow.Event
+	require.NoError(t, db.View(operation.LookupServiceEventsByBlockID(blockID, &serviceEvents)))
+	assert.Equal(t, executed, len(serviceEvents) > 0)
+
+	var results []flow.TransactionResult
+	require.NoError(t, db.View(operation.LookupTransactionResultsByBlockID(blockID, &results)))
+	assert.Equal(t, executed, len(results) > 0)
+
+	var registerSets []flow.TransactionRegisterSet
+	require.NoError(t, db.View(operation.LookupTransactionRegisterSetsByBlockID(blockID, &registerSets)))
+	assert.Equal(t, executed, len(registerSets) > 0)
+}
+
+func TestRollbackDatabase(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		dir := unittest.TempDir(t)
+		defer os.RemoveAll(dir)
+		chunkDataPacksDB := unittest.BadgerDB(t, dir)
+		defer chunkDataPacksDB.Close()
+
+		root := unittest.BlockHeaderFixture()
+		require.NoError(t, db.Update(operation.InsertHeader(root.ID(), &root)))
+		require.NoError(t, db.Update(operation.IndexBlockHeight(root.Height, root.ID())))
+
+		// root <- b1 <- b2 <- b3 <- b4 (not executed)
+		//            <- f2 <- f3 (not finalized)
+		b1 := insertBlock(t, db, &root, true)
+		b2 := insertBlock(t, db, b1, true)
+		b3 := insertBlock(t, db, b2, true)
+		b4 := insertBlock(t, db, b3, false)
+		f2 := insertBlock(t, db, b1, false)
+		f3 := insertBlock(t, db, f2, false)
+
+		chunkIDs := make(map[flow.Identifier][]flow.Identifier)
+		for _, header := range []*flow.Header{&root, b1, b2, b3, f2, f3} {
+			chunkIDs[header.ID()] = executeBlock(t, db, chunkDataPacksDB, header)
+		}
+		require.NoError(t, db.Update(operation.InsertExecutedBlock(b3.ID())))
+
+		blockID, commit, err := findRollbackBlock(db, b1.Height)
+		require.NoError(t, err)
+		assert.Equal(t, b1.ID(), blockID)
+
+		var b1Commit flow.StateCommitment
+		require.NoError(t, db.View(operation.LookupStateCommitment(b1.ID(), &b1Commit)))
+		assert.Equal(t, b1Commit, commit)
+
+		blocks, err := findExecutedDescendants(db, blockID)
+		require.NoError(t, err)
+		require.Len(t, blocks, 4)
+
+		// every block must be removed before its ancestors
+		position := make(map[flow.Identifier]int)
+		for i, block := range blocks {
+			position[block.BlockID] = i
+		}
+		assert.NotContains(t, position, b4.ID())
+		assert.Less(t, position[b3.ID()], position[b2.ID()])
+		assert.Less(t, position[f3.ID()], position[f2.ID()])
+
+		err = rollbackDatabase(zerolog.Nop(), db, chunkDataPacksDB, blockID, b1.Height, blocks)
+		require.NoError(t, err)
+
+		var highest flow.Identifier
+		require.NoError(t, db.View(operation.RetrieveExecutedBlock(&highest)))
+		assert.Equal(t, b1.ID(), highest)
+
+		assertExecuted(t, db, root.ID(), true)
+		assertExecuted(t, db, b1.ID(), true)
+		for _, header := range []*flow.Header{b2, b3, f2, f3} {
+			assertExecuted(t, db, header.ID(), false)
+		}
+
+		for blockID, ids := range chunkIDs {
+			kept := blockID == root.ID() || blockID == b1.ID()
+			for _, chunkID := range ids {
+				var pack badgermodel.StoredChunkDataPack
+				err := db.View(operation.RetrieveChunkDataPack(chunkID, &pack))
+				assert.Equal(t, kept, err == nil)
+				err = chunkDataPacksDB.View(operation.RetrieveChunkDataPack(chunkID, &pack))
+				assert.Equal(t, kept, err == nil)
+			}
+		}
+
+		t.Run("rollback again is a no-op", func(t *testing.T) {
+			blocks, err := findExecutedDescendants(db, blockID)
+			require.NoError(t, err)
+			assert.Empty(t, blocks)
+
+			err = rollbackDatabase(zerolog.Nop(), db, chunkDataPacksDB, blockID, b1.Height, blocks)
+			require.NoError(t, err)
+			assertExecuted(t, db, b1.ID(), true)
+		})
+
+		t.Run("block not executed", func(t *testing.T) {
+			_, _, err := findRollbackBlock(db, b4.Height+1)
+			assert.Error(t, err)
+		})
+	})
+}
+
+func TestRollbackDatabaseInterrupted(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		dir := unittest.TempDir(t)
+		defer os.RemoveAll(dir)
+		chunkDataPacksDB := unittest.BadgerDB(t, dir)
+		defer chunkDataPacksDB.Close()
+
+		root := unittest.BlockHeaderFixture()
+		require.NoError(t, db.Update(operation.InsertHeader(root.ID(), &root)))
+		require.NoError(t, db.Update(operation.IndexBlockHeight(root.Height, root.ID())))
+
+		// root <- b1 <- b2 <- b3
+		b1 := insertBlock(t, db, &root, true)
+		b2 := insertBlock(t, db, b1, true)
+		b3 := insertBlock(t, db, b2, true)
+		for _, header := range []*flow.Header{&root, b1, b2, b3} {
+			executeBlock(t, db, chunkDataPacksDB, header)
+		}
+		require.NoError(t, db.Update(operation.InsertExecutedBlock(b3.ID())))
+
+		blocks, err := findExecutedDescendants(db, b1.ID())
+		require.NoError(t, err)
+		require.Len(t, blocks, 2)
+
+		// interrupted after rolling back the first block
+		require.NoError(t, rollbackBlock(db, chunkDataPacksDB, blocks[0].BlockID))
+		assertExecuted(t, db, b3.ID(), false)
+		assertExecuted(t, db, b2.ID(), true)
+
+		var highest flow.Identifier
+		require.NoError(t, db.View(operation.RetrieveExecutedBlock(&highest)))
+		assert.Equal(t, b3.ID(), highest)
+
+		// running the rollback again completes it
+		blocks, err = findExecutedDescendants(db, b1.ID())
+		require.NoError(t, err)
+		require.Len(t, blocks, 1)
+		assert.Equal(t, b2.ID(), blocks[0].BlockID)
+
+		err = rollbackDatabase(zerolog.Nop(), db, chunkDataPacksDB, b1.ID(), b1.Height, blocks)
+		require.NoError(t, err)
+
+		assertExecuted(t, db, b1.ID(), true)
+		assertExecuted(t, db, b2.ID(), false)
+		require.NoError(t, db.View(operation.RetrieveExecutedBlock(&highest)))
+		assert.Equal(t, b1.ID(), highest)
+	})
+}
+
+func TestPruneTries(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		openLedger := func() (*wal.DiskWAL, *complete.Ledger) {
+			diskWAL, err := wal.NewDiskWAL(zerolog.Nop(), nil, &metrics.NoopCollector{}, dir, 100, pathfinder.PathByteSize, wal.SegmentSize)
+			require.NoError(t, err)
+			led, err := complete.NewLedger(diskWAL, 100, &metrics.NoopCollector{}, zerolog.Nop(), complete.DefaultPathFinderVersion)
+			require.NoError(t, err)
+			return diskWAL, led
+		}
+		update := func(led *complete.Ledger, state ledger.State) ledger.State {
+			keys := utils.RandomUniqueKeys(10, 2, 1, 10)
+			values := utils.RandomValues(10, 1, 32)
+			up, err := ledger.NewUpdate(state, keys, values)
+			require.NoError(t, err)
+			newState, _, err := led.Set(up)
+			require.NoError(t, err)
+			return newState
+		}
+
+		diskWAL, led := openLedger()
+		s0 := led.InitialState()
+		s1 := update(led, s0)
+		s2 := update(led, s1)
+		s3 := update(led, s2)
+		<-diskWAL.Done()
+
+		prune := map[ledger.RootHash]struct{}{
+			ledger.RootHash(s2): {},
+			ledger.RootHash(s3): {},
+		}
+		pruner, err := newTriePruner(zerolog.Nop(), dir, 100, flow.StateCommitment(s1), prune)
+		require.NoError(t, err)
+		err = pruner.Prune()
+		require.NoError(t, err)
+		pruner.Close()
+
+		// the pruned tries are not loaded anymore, and updates after the rollback are replayed
+		diskWAL, led = openLedger()
+		for _, state := range []ledger.State{s0, s1} {
+			_, err := led.Trie(state)
+			assert.NoError(t, err)
+		}
+		for _, state := range []ledger.State{s2, s3} {
+			_, err := led.Trie(state)
+			assert.Error(t, err)
+		}
+		s4 := update(led, s1)
+		<-diskWAL.Done()
+
+		diskWAL, led = openLedger()
+		_, err = led.Trie(s4)
+		assert.NoError(t, err)
+		<-diskWAL.Done()
+
+		t.Run("trie to rollback to is not in the ledger", func(t *testing.T) {
+			_, err := newTriePruner(zerolog.Nop(), dir, 100, flow.StateCommitment(s3), nil)
+			assert.Error(t, err)
+		})
+	})
+}
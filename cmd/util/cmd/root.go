@@ -9,14 +9,20 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
+	check_database "github.com/onflow/flow-go/cmd/util/cmd/check-database"
 	checkpoint_list_tries "github.com/onflow/flow-go/cmd/util/cmd/checkpoint-list-tries"
+	compare_execution "github.com/onflow/flow-go/cmd/util/cmd/compare-execution"
 	epochs "github.com/onflow/flow-go/cmd/util/cmd/epochs/cmd"
 	export "github.com/onflow/flow-go/cmd/util/cmd/exec-data-json-export"
 	edbs "github.com/onflow/flow-go/cmd/util/cmd/execution-data-blobstore/cmd"
 	extract "github.com/onflow/flow-go/cmd/util/cmd/execution-state-extract"
 	ledger_json_exporter "github.com/onflow/flow-go/cmd/util/cmd/export-json-execution-state"
+	migrate_chunk_data_packs "github.com/onflow/flow-go/cmd/util/cmd/migrate-chunk-data-packs"
 	read_badger "github.com/onflow/flow-go/cmd/util/cmd/read-badger/cmd"
 	read_protocol_state "github.com/onflow/flow-go/cmd/util/cmd/read-protocol-state/cmd"
+	replay_hotstuff_journal "github.com/onflow/flow-go/cmd/util/cmd/replay-hotstuff-journal"
+	restore_database "github.com/onflow/flow-go/cmd/util/cmd/restore-database"
+	rollback_executed_height "github.com/onflow/flow-go/cmd/util/cmd/rollback-executed-height"
 	truncate_database "github.com/onflow/flow-go/cmd/util/cmd/truncate-database"
 )
 
@@ -60,6 +66,12 @@ func addCommands() {
 	rootCmd.AddCommand(ledger_json_exporter.Cmd)
 	rootCmd.AddCommand(epochs.RootCmd)
 	rootCmd.AddCommand(edbs.RootCmd)
+	rootCmd.AddCommand(rollback_executed_height.Cmd)
+	rootCmd.AddCommand(migrate_chunk_data_packs.Cmd)
+	rootCmd.AddCommand(compare_execution.Cmd)
+	rootCmd.AddCommand(replay_hotstuff_journal.Cmd)
+	rootCmd.AddCommand(check_database.Cmd)
+	rootCmd.AddCommand(restore_database.Cmd)
 }
 
 func initConfig() {
@@ -17,6 +17,7 @@ import (
 	synceng "github.com/onflow/flow-go/engine/common/synchronization"
 	"github.com/onflow/flow-go/engine/verification/assigner"
 	"github.com/onflow/flow-go/engine/verification/assigner/blockconsumer"
+	"github.com/onflow/flow-go/engine/verification/catchup"
 	"github.com/onflow/flow-go/engine/verification/fetcher"
 	"github.com/onflow/flow-go/engine/verification/fetcher/chunkconsumer"
 	vereq "github.com/onflow/flow-go/engine/verification/requester"
@@ -54,6 +55,12 @@ func main() {
 		blockWorkers uint64 // number of blocks processed in parallel.
 		chunkWorkers uint64 // number of chunks processed in parallel.
 
+		catchUpThreshold    uint64        // lag behind sealed height that triggers catch-up mode, zero disables it.
+		catchUpInterval     time.Duration // time interval catch-up controller evaluates the lag behind sealed height.
+		catchUpBlockWorkers uint64        // number of blocks processed in parallel while catching up.
+		catchUpChunkWorkers uint64        // number of chunks processed in parallel while catching up.
+		catchUpController   *catchup.Controller
+
 		chunkStatuses        *stdmap.ChunkStatuses     // used in fetcher engine
 		chunkRequests        *stdmap.ChunkRequests     // used in requester engine
 		processedChunkIndex  *storage.ConsumerProgress // used in chunk consumer
@@ -87,6 +94,10 @@ func main() {
 		flags.Uint64Var(&requestTargets, "request-targets", vereq.DefaultRequestTargets, "maximum number of execution nodes a chunk data pack request is dispatched to")
 		flags.Uint64Var(&blockWorkers, "block-workers", blockconsumer.DefaultBlockWorkers, "maximum number of blocks being processed in parallel")
 		flags.Uint64Var(&chunkWorkers, "chunk-workers", chunkconsumer.DefaultChunkWorkers, "maximum number of execution nodes a chunk data pack request is dispatched to")
+		flags.Uint64Var(&catchUpThreshold, "catchup-threshold", catchup.DefaultThreshold, "number of blocks behind the sealed height that switches the node into catch-up mode, 0 disables catch-up mode")
+		flags.DurationVar(&catchUpInterval, "catchup-check-interval", catchup.DefaultCheckInterval, "time interval the lag behind the sealed height is evaluated")
+		flags.Uint64Var(&catchUpBlockWorkers, "catchup-block-workers", catchup.DefaultCatchUpBlockWorkers, "maximum number of blocks being processed in parallel while catching up")
+		flags.Uint64Var(&catchUpChunkWorkers, "catchup-chunk-workers", catchup.DefaultCatchUpChunkWorkers, "maximum number of chunks being processed in parallel while catching up")
 
 	})
 
@@ -275,8 +286,41 @@ func main() {
 				Uint64("init_height", initBlockHeight).
 				Msg("block consumer initialized")
 
+			if catchUpThreshold > 0 {
+				// catch-up controller is created before block consumer starts, so that the assigner engine
+				// sees the tracker from the very first block it processes.
+				catchUpController, err = catchup.New(
+					node.Logger,
+					collector,
+					node.State,
+					processedBlockHeight,
+					processedChunkIndex,
+					chunkQueue,
+					blockConsumer,
+					chunkConsumer,
+					catchup.Config{
+						Threshold:           catchUpThreshold,
+						CheckInterval:       catchUpInterval,
+						BlockWorkers:        blockWorkers,
+						ChunkWorkers:        chunkWorkers,
+						CatchUpBlockWorkers: catchUpBlockWorkers,
+						CatchUpChunkWorkers: catchUpChunkWorkers,
+					})
+				if err != nil {
+					return nil, fmt.Errorf("could not initialize catch-up controller: %w", err)
+				}
+				assignerEngine.WithCatchUpTracker(catchUpController)
+			}
+
 			return blockConsumer, nil
 		}).
+		Component("catch-up controller", func(node *cmd.NodeConfig) (module.ReadyDoneAware, error) {
+			if catchUpController == nil {
+				node.Logger.Info().Msg("catch-up mode is disabled")
+				return &module.NoopReadyDoneAware{}, nil
+			}
+			return catchUpController, nil
+		}).
 		Component("follower engine", func(node *cmd.NodeConfig) (module.ReadyDoneAware, error) {
 
 			// initialize cleaner for DB
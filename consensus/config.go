@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/onflow/flow-go/consensus/hotstuff"
+	"github.com/onflow/flow-go/consensus/hotstuff/journal"
 	"github.com/onflow/flow-go/consensus/hotstuff/notifications/pubsub"
 )
 
@@ -21,13 +22,14 @@ type HotstuffModules struct {
 }
 
 type ParticipantConfig struct {
-	StartupTime                time.Time     // the time when consensus participant enters first view
-	TimeoutInitial             time.Duration // the initial timeout for the pacemaker
-	TimeoutMinimum             time.Duration // the minimum timeout for the pacemaker
-	TimeoutAggregationFraction float64       // the percentage part of the timeout period reserved for vote aggregation
-	TimeoutIncreaseFactor      float64       // the factor at which the timeout grows when timeouts occur
-	TimeoutDecreaseFactor      float64       // the factor at which the timeout grows when timeouts occur
-	BlockRateDelay             time.Duration // a delay to broadcast block proposal in order to control the block production rate
+	StartupTime                time.Time        // the time when consensus participant enters first view
+	TimeoutInitial             time.Duration    // the initial timeout for the pacemaker
+	TimeoutMinimum             time.Duration    // the minimum timeout for the pacemaker
+	TimeoutAggregationFraction float64          // the percentage part of the timeout period reserved for vote aggregation
+	TimeoutIncreaseFactor      float64          // the factor at which the timeout grows when timeouts occur
+	TimeoutDecreaseFactor      float64          // the factor at which the timeout grows when timeouts occur
+	BlockRateDelay             time.Duration    // a delay to broadcast block proposal in order to control the block production rate
+	Journal                    *journal.Journal // optional journal recording the inputs of the event handler
 }
 
 type Option func(*ParticipantConfig)
@@ -73,3 +75,12 @@ func WithBlockRateDelay(delay time.Duration) Option {
 		cfg.BlockRateDelay = delay
 	}
 }
+
+// WithJournal enables recording the inputs of the event handler to the given
+// journal. To record the outputs as well, a journal.Consumer must be subscribed
+// to the notifications of the participant.
+func WithJournal(journal *journal.Journal) Option {
+	return func(cfg *ParticipantConfig) {
+		cfg.Journal = journal
+	}
+}
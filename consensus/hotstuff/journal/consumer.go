@@ -0,0 +1,35 @@
+package journal
+
+import (
+	"github.com/onflow/flow-go/consensus/hotstuff"
+	"github.com/onflow/flow-go/consensus/hotstuff/model"
+	"github.com/onflow/flow-go/consensus/hotstuff/notifications"
+)
+
+// Consumer records the outputs of the event handler to the journal: the own
+// votes, the own proposals and the finalized blocks.
+type Consumer struct {
+	notifications.NoopConsumer
+	journal *Journal
+}
+
+var _ hotstuff.Consumer = (*Consumer)(nil)
+
+// NewConsumer creates a consumer recording to the given journal.
+func NewConsumer(journal *Journal) *Consumer {
+	return &Consumer{
+		journal: journal,
+	}
+}
+
+func (c *Consumer) OnVoting(vote *model.Vote) {
+	c.journal.record(&Entry{Type: EntryVote, Vote: vote})
+}
+
+func (c *Consumer) OnProposingBlock(proposal *model.Proposal) {
+	c.journal.record(&Entry{Type: EntryOwnProposal, Proposal: proposal})
+}
+
+func (c *Consumer) OnFinalizedBlock(block *model.Block) {
+	c.journal.record(&Entry{Type: EntryFinalizedBlock, Block: block})
+}
@@ -0,0 +1,75 @@
+package journal
+
+import (
+	"time"
+
+	"github.com/onflow/flow-go/consensus/hotstuff/model"
+	"github.com/onflow/flow-go/consensus/hotstuff/pacemaker/timeout"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// EntryType is the type of a journal entry.
+type EntryType string
+
+const (
+	// EntryBootstrap records the state the event handler was started from.
+	EntryBootstrap EntryType = "bootstrap"
+
+	// inputs of the event handler, as fed by the event loop
+
+	EntryStart    EntryType = "start"
+	EntryProposal EntryType = "proposal"
+	EntryQC       EntryType = "qc"
+	EntryTimeout  EntryType = "timeout"
+
+	// outputs of the event handler, as observed through the notifications
+
+	EntryVote           EntryType = "vote"
+	EntryOwnProposal    EntryType = "own_proposal"
+	EntryFinalizedBlock EntryType = "finalized_block"
+)
+
+// Entry is a single record of the journal. Depending on the type, one of the
+// optional fields is set.
+type Entry struct {
+	Type      EntryType
+	Timestamp time.Time
+	Bootstrap *Bootstrap              `json:",omitempty"` // EntryBootstrap
+	Proposal  *model.Proposal         `json:",omitempty"` // EntryProposal, EntryOwnProposal
+	QC        *flow.QuorumCertificate `json:",omitempty"` // EntryQC
+	Vote      *model.Vote             `json:",omitempty"` // EntryVote
+	Block     *model.Block            `json:",omitempty"` // EntryFinalizedBlock
+}
+
+// Bootstrap is the state of a consensus participant when the event handler is
+// created, which is required to recreate the event handler for a replay.
+type Bootstrap struct {
+	Finalized   *flow.Header   // latest finalized block, the root of forks
+	Pending     []*flow.Header // unfinalized descendants of the latest finalized block
+	StartedView uint64         // last view the participant started
+	VotedView   uint64         // last view the participant voted in
+	Timeouts    timeout.Config // pacemaker timeout configuration
+}
+
+// Output is an output of the event handler, reduced to the information which
+// is deterministic in a replay. Signatures aren't compared, as the replay has no
+// access to the keys of the participant.
+type Output struct {
+	Type    EntryType
+	View    uint64
+	BlockID flow.Identifier
+}
+
+// output returns the output of an output entry.
+func (e *Entry) output() Output {
+	out := Output{Type: e.Type}
+	switch e.Type {
+	case EntryVote:
+		out.View, out.BlockID = e.Vote.View, e.Vote.BlockID
+	case EntryOwnProposal:
+		out.View, out.BlockID = e.Proposal.Block.View, e.Proposal.Block.BlockID
+	case EntryFinalizedBlock:
+		out.View, out.BlockID = e.Block.View, e.Block.BlockID
+	}
+	return out
+}
@@ -0,0 +1,51 @@
+package journal
+
+import (
+	"time"
+
+	"github.com/onflow/flow-go/consensus/hotstuff"
+	"github.com/onflow/flow-go/consensus/hotstuff/model"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// EventHandler wraps the HotStuff event handler and records every input to the
+// journal before it is processed. As the event loop feeds the event handler
+// one event at a time, the journal contains the inputs in processing order.
+type EventHandler struct {
+	handler hotstuff.EventHandler
+	journal *Journal
+}
+
+var _ hotstuff.EventHandler = (*EventHandler)(nil)
+
+// NewEventHandler creates a journaling wrapper around the given event handler.
+func NewEventHandler(handler hotstuff.EventHandler, journal *Journal) *EventHandler {
+	return &EventHandler{
+		handler: handler,
+		journal: journal,
+	}
+}
+
+func (e *EventHandler) OnQCConstructed(qc *flow.QuorumCertificate) error {
+	e.journal.record(&Entry{Type: EntryQC, QC: qc})
+	return e.handler.OnQCConstructed(qc)
+}
+
+func (e *EventHandler) OnReceiveProposal(proposal *model.Proposal) error {
+	e.journal.record(&Entry{Type: EntryProposal, Proposal: proposal})
+	return e.handler.OnReceiveProposal(proposal)
+}
+
+func (e *EventHandler) OnLocalTimeout() error {
+	e.journal.record(&Entry{Type: EntryTimeout})
+	return e.handler.OnLocalTimeout()
+}
+
+func (e *EventHandler) TimeoutChannel() <-chan time.Time {
+	return e.handler.TimeoutChannel()
+}
+
+func (e *EventHandler) Start() error {
+	e.journal.record(&Entry{Type: EntryStart})
+	return e.handler.Start()
+}
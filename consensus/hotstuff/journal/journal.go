@@ -0,0 +1,98 @@
+package journal
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// Journal records the inputs and outputs of the HotStuff event handler as
+// newline-delimited JSON entries. The journal is a debugging aid: failures to
+// write an entry are logged, but never interrupt consensus.
+type Journal struct {
+	log     zerolog.Logger
+	mu      sync.Mutex
+	encoder *json.Encoder
+	closer  io.Closer
+	now     func() time.Time
+}
+
+// New creates a journal writing to the given writer.
+func New(log zerolog.Logger, w io.Writer) *Journal {
+	return &Journal{
+		log:     log.With().Str("component", "hotstuff_journal").Logger(),
+		encoder: json.NewEncoder(w),
+		now:     func() time.Time { return time.Now().UTC() },
+	}
+}
+
+// Open creates a journal appending to the file at the given path.
+func Open(log zerolog.Logger, path string) (*Journal, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("could not open journal file: %w", err)
+	}
+	j := New(log, file)
+	j.closer = file
+	return j, nil
+}
+
+// Close closes the file of the journal, if it was opened with Open.
+func (j *Journal) Close() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.closer == nil {
+		return nil
+	}
+	return j.closer.Close()
+}
+
+// RecordBootstrap records the state the event handler is started from. It
+// must be recorded before any other entry for the journal to be replayable.
+func (j *Journal) RecordBootstrap(bootstrap *Bootstrap) {
+	j.record(&Entry{Type: EntryBootstrap, Bootstrap: bootstrap})
+}
+
+func (j *Journal) record(entry *Entry) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	entry.Timestamp = j.now()
+	err := j.encoder.Encode(entry)
+	if err != nil {
+		j.log.Error().Err(err).Str("type", string(entry.Type)).Msg("could not write journal entry")
+	}
+}
+
+// Read reads all entries of a journal.
+func Read(r io.Reader) ([]*Entry, error) {
+	var entries []*Entry
+	decoder := json.NewDecoder(bufio.NewReader(r))
+	for {
+		var entry Entry
+		err := decoder.Decode(&entry)
+		if err == io.EOF {
+			return entries, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("could not decode journal entry %d: %w", len(entries), err)
+		}
+		entries = append(entries, &entry)
+	}
+}
+
+// ReadFile reads all entries of the journal file at the given path.
+func ReadFile(path string) ([]*Entry, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not open journal file: %w", err)
+	}
+	defer file.Close()
+	return Read(file)
+}
@@ -0,0 +1,310 @@
+package journal
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/consensus/hotstuff"
+	"github.com/onflow/flow-go/consensus/hotstuff/eventhandler"
+	"github.com/onflow/flow-go/consensus/hotstuff/forks"
+	"github.com/onflow/flow-go/consensus/hotstuff/forks/finalizer"
+	"github.com/onflow/flow-go/consensus/hotstuff/forks/forkchoice"
+	"github.com/onflow/flow-go/consensus/hotstuff/model"
+	"github.com/onflow/flow-go/consensus/hotstuff/notifications"
+	"github.com/onflow/flow-go/consensus/hotstuff/notifications/pubsub"
+	"github.com/onflow/flow-go/consensus/hotstuff/pacemaker"
+	"github.com/onflow/flow-go/consensus/hotstuff/pacemaker/timeout"
+	"github.com/onflow/flow-go/consensus/hotstuff/validator"
+	"github.com/onflow/flow-go/consensus/hotstuff/voter"
+	"github.com/onflow/flow-go/consensus/recovery"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/module/irrecoverable"
+)
+
+// DivergenceError is returned by Replay when the replayed event handler
+// produces different outputs than the recorded event handler.
+type DivergenceError struct {
+	Index    int     // index of the first diverging output
+	Recorded *Output // nil if the replay produced more outputs than recorded
+	Replayed *Output // nil if the replay produced fewer outputs than recorded
+}
+
+func (e *DivergenceError) Error() string {
+	return fmt.Sprintf("replay diverged at output %d: recorded %s, replayed %s", e.Index, e.Recorded, e.Replayed)
+}
+
+func (o *Output) String() string {
+	if o == nil {
+		return "nothing"
+	}
+	return fmt.Sprintf("%s for block %x at view %d", o.Type, o.BlockID, o.View)
+}
+
+// Replay feeds the inputs recorded in the journal into a fresh event handler,
+// and checks that it produces the same votes, proposals and finalized blocks as
+// recorded. Each bootstrap entry of the journal, recorded when the participant
+// was started, recreates the event handler from the recorded state.
+//
+// The replay uses the given committee, which must be the committee of the
+// recorded participant, and verifies signatures with the given verifier. As the
+// replay has no access to the keys of the participant, own votes are not signed,
+// and own proposals are taken from the journal when they extend the same QC. When
+// the replay proposes a different block, it is timestamped with the time of the
+// recorded event being replayed.
+//
+// Returns the replayed outputs, and a DivergenceError if they differ from the
+// recorded ones.
+func Replay(log zerolog.Logger, entries []*Entry, committee hotstuff.Committee, verifier hotstuff.Verifier) ([]Output, error) {
+	if len(entries) == 0 || entries[0].Type != EntryBootstrap {
+		return nil, fmt.Errorf("journal does not start with a bootstrap entry")
+	}
+
+	var recorded []Output
+	outputs := &outputRecorder{}
+	var handler hotstuff.EventHandler
+	var clock time.Time
+
+	for i, entry := range entries {
+		clock = entry.Timestamp
+
+		var err error
+		switch entry.Type {
+		case EntryBootstrap:
+			handler, err = newReplayHandler(log, entry.Bootstrap, entries[i+1:], committee, verifier, outputs, func() time.Time { return clock })
+		case EntryStart:
+			err = handler.Start()
+		case EntryProposal:
+			err = handler.OnReceiveProposal(entry.Proposal)
+		case EntryQC:
+			err = handler.OnQCConstructed(entry.QC)
+		case EntryTimeout:
+			err = handler.OnLocalTimeout()
+		case EntryVote, EntryOwnProposal, EntryFinalizedBlock:
+			recorded = append(recorded, entry.output())
+		default:
+			err = fmt.Errorf("unknown entry type %q", entry.Type)
+		}
+		if err != nil {
+			return outputs.outputs, fmt.Errorf("could not replay journal entry %d (%s): %w", i, entry.Type, err)
+		}
+	}
+
+	return outputs.outputs, compareOutputs(recorded, outputs.outputs)
+}
+
+func compareOutputs(recorded, replayed []Output) error {
+	for i := 0; i < len(recorded) || i < len(replayed); i++ {
+		var rec, rep *Output
+		if i < len(recorded) {
+			rec = &recorded[i]
+		}
+		if i < len(replayed) {
+			rep = &replayed[i]
+		}
+		if rec == nil || rep == nil || *rec != *rep {
+			return &DivergenceError{Index: i, Recorded: rec, Replayed: rep}
+		}
+	}
+	return nil
+}
+
+// newReplayHandler recreates the event handler of a participant started from
+// the given bootstrap state, as done by consensus.NewParticipant.
+func newReplayHandler(
+	log zerolog.Logger,
+	bootstrap *Bootstrap,
+	session []*Entry,
+	committee hotstuff.Committee,
+	verifier hotstuff.Verifier,
+	consumer hotstuff.Consumer,
+	now func() time.Time,
+) (hotstuff.EventHandler, error) {
+	notifier := pubsub.NewDistributor()
+	notifier.AddConsumer(notifications.NewLogConsumer(log))
+	notifier.AddConsumer(consumer)
+
+	// By convention of Forks, the trusted root doesn't need its own QC. The QC
+	// for the root is only used to build on the root, in which case the recorded
+	// proposal is replayed, so its signers don't matter.
+	finalized := bootstrap.Finalized
+	root := &forks.BlockQC{
+		Block: &model.Block{
+			View:        finalized.View,
+			BlockID:     finalized.ID(),
+			ProposerID:  finalized.ProposerID,
+			PayloadHash: finalized.PayloadHash,
+			Timestamp:   finalized.Timestamp,
+		},
+		QC: &flow.QuorumCertificate{
+			View:    finalized.View,
+			BlockID: finalized.ID(),
+		},
+	}
+	forkalizer, err := finalizer.New(root, &noopFinalizer{}, notifier)
+	if err != nil {
+		return nil, fmt.Errorf("could not initialize finalizer: %w", err)
+	}
+	choice, err := forkchoice.NewNewestForkChoice(forkalizer, notifier)
+	if err != nil {
+		return nil, fmt.Errorf("could not initialize fork choice: %w", err)
+	}
+	forks := forks.New(forkalizer, choice)
+
+	validator := validator.New(committee, forks, verifier)
+	aggregator := &noopAggregator{}
+	err = recovery.Participant(log, forks, aggregator, validator, finalized, bootstrap.Pending)
+	if err != nil {
+		return nil, fmt.Errorf("could not recover hotstuff state: %w", err)
+	}
+
+	controller := timeout.NewController(bootstrap.Timeouts)
+	pacemaker, err := pacemaker.New(bootstrap.StartedView+1, controller, notifier)
+	if err != nil {
+		return nil, fmt.Errorf("could not initialize pacemaker: %w", err)
+	}
+
+	persist := &memoryPersister{started: bootstrap.StartedView, voted: bootstrap.VotedView}
+	signer := &unsignedSigner{self: committee.Self()}
+	producer := newReplayProducer(session, committee.Self(), now)
+	voter := voter.New(signer, forks, persist, committee, bootstrap.VotedView)
+
+	return eventhandler.NewEventHandler(
+		log,
+		pacemaker,
+		producer,
+		forks,
+		persist,
+		&noopCommunicator{},
+		committee,
+		aggregator,
+		voter,
+		validator,
+		notifier,
+	)
+}
+
+// outputRecorder collects the outputs of the replayed event handler.
+type outputRecorder struct {
+	notifications.NoopConsumer
+	outputs []Output
+}
+
+func (r *outputRecorder) OnVoting(vote *model.Vote) {
+	r.outputs = append(r.outputs, Output{Type: EntryVote, View: vote.View, BlockID: vote.BlockID})
+}
+
+func (r *outputRecorder) OnProposingBlock(proposal *model.Proposal) {
+	r.outputs = append(r.outputs, Output{Type: EntryOwnProposal, View: proposal.Block.View, BlockID: proposal.Block.BlockID})
+}
+
+func (r *outputRecorder) OnFinalizedBlock(block *model.Block) {
+	r.outputs = append(r.outputs, Output{Type: EntryFinalizedBlock, View: block.View, BlockID: block.BlockID})
+}
+
+// replayProducer replays the recorded own proposals. The payload of a block
+// can't be rebuilt offline, so a proposal is only replayed if it extends the
+// same QC as recorded. Otherwise, a new block with an empty payload is built.
+type replayProducer struct {
+	self      flow.Identifier
+	proposals map[uint64][]*model.Proposal
+	now       func() time.Time
+}
+
+func newReplayProducer(session []*Entry, self flow.Identifier, now func() time.Time) *replayProducer {
+	proposals := make(map[uint64][]*model.Proposal)
+	for _, entry := range session {
+		if entry.Type == EntryBootstrap {
+			break
+		}
+		if entry.Type == EntryOwnProposal {
+			view := entry.Proposal.Block.View
+			proposals[view] = append(proposals[view], entry.Proposal)
+		}
+	}
+	return &replayProducer{
+		self:      self,
+		proposals: proposals,
+		now:       now,
+	}
+}
+
+func (p *replayProducer) MakeBlockProposal(qc *flow.QuorumCertificate, view uint64) (*model.Proposal, error) {
+	for _, proposal := range p.proposals[view] {
+		if proposal.Block.QC.View == qc.View && proposal.Block.QC.BlockID == qc.BlockID {
+			return proposal, nil
+		}
+	}
+
+	header := &flow.Header{
+		ParentID:           qc.BlockID,
+		View:               view,
+		Timestamp:          p.now(),
+		ParentVoterIDs:     qc.SignerIDs,
+		ParentVoterSigData: qc.SigData,
+		ProposerID:         p.self,
+	}
+	return &model.Proposal{Block: model.BlockFromFlow(header, qc.View)}, nil
+}
+
+// unsignedSigner creates votes and proposals without signatures.
+type unsignedSigner struct {
+	self flow.Identifier
+}
+
+func (s *unsignedSigner) CreateProposal(block *model.Block) (*model.Proposal, error) {
+	return &model.Proposal{Block: block}, nil
+}
+
+func (s *unsignedSigner) CreateVote(block *model.Block) (*model.Vote, error) {
+	return model.VoteFromFlow(s.self, block.BlockID, block.View, nil), nil
+}
+
+// memoryPersister keeps the started and voted views in memory.
+type memoryPersister struct {
+	started uint64
+	voted   uint64
+}
+
+func (p *memoryPersister) GetStarted() (uint64, error) { return p.started, nil }
+func (p *memoryPersister) GetVoted() (uint64, error)   { return p.voted, nil }
+func (p *memoryPersister) PutStarted(view uint64) error {
+	p.started = view
+	return nil
+}
+func (p *memoryPersister) PutVoted(view uint64) error {
+	p.voted = view
+	return nil
+}
+
+// noopCommunicator drops votes and proposals, as the journal contains the
+// inputs the participant received in return.
+type noopCommunicator struct{}
+
+func (*noopCommunicator) SendVote(flow.Identifier, uint64, []byte, flow.Identifier) error {
+	return nil
+}
+func (*noopCommunicator) BroadcastProposal(*flow.Header) error { return nil }
+func (*noopCommunicator) BroadcastProposalWithDelay(*flow.Header, time.Duration) error {
+	return nil
+}
+
+// noopAggregator drops votes and blocks, as the journal contains the QCs the
+// vote aggregator constructed.
+type noopAggregator struct {
+	module.NoopReadyDoneAware
+}
+
+func (*noopAggregator) Start(irrecoverable.SignalerContext) {}
+func (*noopAggregator) AddVote(*model.Vote)                 {}
+func (*noopAggregator) AddBlock(*model.Proposal) error      { return nil }
+func (*noopAggregator) InvalidBlock(*model.Proposal) error  { return nil }
+func (*noopAggregator) PruneUpToView(uint64)                {}
+
+// noopFinalizer ignores finalization, which is observed through the notifications.
+type noopFinalizer struct{}
+
+func (*noopFinalizer) MakeValid(flow.Identifier) error { return nil }
+func (*noopFinalizer) MakeFinal(flow.Identifier) error { return nil }
@@ -0,0 +1,227 @@
+package journal
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/consensus/hotstuff/mocks"
+	"github.com/onflow/flow-go/consensus/hotstuff/model"
+	"github.com/onflow/flow-go/consensus/hotstuff/pacemaker/timeout"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// leaderView is the view the recording participant is the leader of
+const leaderView = 15
+
+type replaySuite struct {
+	participants flow.IdentityList
+	self         *flow.Identity
+	other        *flow.Identity // leader of all views except leaderView
+	committee    *mocks.Committee
+	verifier     *mocks.Verifier
+	root         *flow.Header
+}
+
+func newReplaySuite() *replaySuite {
+	participants := make(flow.IdentityList, 0, 4)
+	for i := 0; i < 4; i++ {
+		participants = append(participants, &flow.Identity{
+			NodeID: unittest.IdentifierFixture(),
+			Role:   flow.RoleConsensus,
+			Weight: 1000,
+		})
+	}
+	s := &replaySuite{
+		participants: participants,
+		self:         participants[0],
+		other:        participants[1],
+		committee:    &mocks.Committee{},
+		verifier:     &mocks.Verifier{},
+	}
+
+	s.committee.On("Identities", mock.Anything, mock.Anything).Return(
+		func(_ flow.Identifier, selector flow.IdentityFilter) flow.IdentityList {
+			return s.participants.Filter(selector)
+		},
+		nil,
+	)
+	for _, participant := range participants {
+		s.committee.On("Identity", mock.Anything, participant.NodeID).Return(participant, nil)
+	}
+	s.committee.On("Self").Return(s.self.NodeID)
+	s.committee.On("LeaderForView", mock.Anything).Return(
+		func(view uint64) flow.Identifier {
+			if view == leaderView {
+				return s.self.NodeID
+			}
+			return s.other.NodeID
+		},
+		nil,
+	)
+	s.verifier.On("VerifyQC", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	s.verifier.On("VerifyVote", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	root := unittest.BlockHeaderFixture()
+	root.View = 10
+	s.root = &root
+	return s
+}
+
+func (s *replaySuite) bootstrap() *Bootstrap {
+	return &Bootstrap{
+		Finalized:   s.root,
+		StartedView: s.root.View,
+		VotedView:   s.root.View,
+		Timeouts:    timeout.DefaultConfig,
+	}
+}
+
+// proposal returns a proposal by the leader of the given view, extending the given parent.
+func (s *replaySuite) proposal(parentID flow.Identifier, parentView uint64, view uint64) *model.Proposal {
+	return &model.Proposal{
+		Block: &model.Block{
+			View:        view,
+			BlockID:     unittest.IdentifierFixture(),
+			ProposerID:  s.other.NodeID,
+			PayloadHash: unittest.IdentifierFixture(),
+			Timestamp:   time.Now().UTC(),
+			QC: &flow.QuorumCertificate{
+				View:      parentView,
+				BlockID:   parentID,
+				SignerIDs: s.participants.NodeIDs(),
+			},
+		},
+		SigData: unittest.RandomBytes(32),
+	}
+}
+
+// record runs an event handler journaling to a buffer: the participant votes
+// for the proposals of views 11 to 14, which finalizes view 11, and proposes a
+// block for view 15 once it receives the QC for view 14.
+func (s *replaySuite) record(t *testing.T) []*Entry {
+	var buffer bytes.Buffer
+	journal := New(zerolog.Nop(), &buffer)
+	bootstrap := s.bootstrap()
+	journal.RecordBootstrap(bootstrap)
+
+	recorded, err := newReplayHandler(zerolog.Nop(), bootstrap, nil, s.committee, s.verifier, NewConsumer(journal), time.Now)
+	require.NoError(t, err)
+	handler := NewEventHandler(recorded, journal)
+
+	require.NoError(t, handler.Start())
+	parentID, parentView := s.root.ID(), s.root.View
+	for view := uint64(11); view < leaderView; view++ {
+		proposal := s.proposal(parentID, parentView, view)
+		require.NoError(t, handler.OnReceiveProposal(proposal))
+		parentID, parentView = proposal.Block.BlockID, view
+	}
+	require.NoError(t, handler.OnQCConstructed(&flow.QuorumCertificate{
+		View:      parentView,
+		BlockID:   parentID,
+		SignerIDs: s.participants.NodeIDs(),
+	}))
+
+	entries, err := Read(&buffer)
+	require.NoError(t, err)
+	return entries
+}
+
+func outputsOf(entries []*Entry) []Output {
+	var outputs []Output
+	for _, entry := range entries {
+		switch entry.Type {
+		case EntryVote, EntryOwnProposal, EntryFinalizedBlock:
+			outputs = append(outputs, entry.output())
+		}
+	}
+	return outputs
+}
+
+func TestReplay(t *testing.T) {
+	s := newReplaySuite()
+	entries := s.record(t)
+
+	recorded := outputsOf(entries)
+	types := make([]EntryType, 0, len(recorded))
+	for _, output := range recorded {
+		types = append(types, output.Type)
+	}
+	assert.Equal(t, []EntryType{
+		EntryVote, EntryVote, EntryVote, EntryFinalizedBlock, EntryVote, EntryOwnProposal,
+	}, types)
+
+	replayed, err := Replay(zerolog.Nop(), entries, s.committee, s.verifier)
+	require.NoError(t, err)
+	assert.Equal(t, recorded, replayed)
+}
+
+func TestReplay_Divergence(t *testing.T) {
+	s := newReplaySuite()
+
+	t.Run("missing input", func(t *testing.T) {
+		entries := s.record(t)
+
+		// drop the QC for view 14, so the participant never proposes
+		last := len(entries) - 1
+		for entries[last].Type != EntryQC {
+			last--
+		}
+		entries = append(entries[:last], entries[last+1:]...)
+
+		_, err := Replay(zerolog.Nop(), entries, s.committee, s.verifier)
+		var divergence *DivergenceError
+		require.True(t, errors.As(err, &divergence))
+		assert.Equal(t, EntryOwnProposal, divergence.Recorded.Type)
+		assert.Nil(t, divergence.Replayed)
+	})
+
+	t.Run("different output", func(t *testing.T) {
+		entries := s.record(t)
+
+		// the participant voted for a different block than recorded
+		first := 0
+		for entries[first].Type != EntryVote {
+			first++
+		}
+		entries[first].Vote.BlockID = unittest.IdentifierFixture()
+
+		_, err := Replay(zerolog.Nop(), entries, s.committee, s.verifier)
+		var divergence *DivergenceError
+		require.True(t, errors.As(err, &divergence))
+		assert.Equal(t, 0, divergence.Index)
+		assert.Equal(t, entries[first].Vote.BlockID, divergence.Recorded.BlockID)
+		assert.Equal(t, divergence.Recorded.View, divergence.Replayed.View)
+	})
+
+	t.Run("invalid proposals", func(t *testing.T) {
+		entries := s.record(t)
+
+		// with a verifier rejecting the proposer signatures, the participant
+		// doesn't vote, and can't process the children of the rejected blocks
+		verifier := &mocks.Verifier{}
+		verifier.On("VerifyQC", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		verifier.On("VerifyVote", mock.Anything, mock.Anything, mock.Anything).Return(model.ErrInvalidSignature)
+
+		replayed, err := Replay(zerolog.Nop(), entries, s.committee, verifier)
+		assert.Error(t, err)
+		assert.Empty(t, replayed)
+	})
+}
+
+func TestReplay_MissingBootstrap(t *testing.T) {
+	s := newReplaySuite()
+	entries := s.record(t)
+
+	_, err := Replay(zerolog.Nop(), entries[1:], s.committee, s.verifier)
+	assert.Error(t, err)
+	var divergence *DivergenceError
+	assert.False(t, errors.As(err, &divergence))
+}
@@ -4,18 +4,27 @@ import (
 	"github.com/rs/zerolog"
 
 	"github.com/onflow/flow-go/consensus/hotstuff/model"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
 )
 
 // SlashingViolationsConsumer is an implementation of the notifications consumer that logs a
-// message for any slashable offences.
+// message for any slashable offences, and persists the signed votes and proposals proving
+// the offence as slashing evidence.
 type SlashingViolationsConsumer struct {
 	NoopConsumer
-	log zerolog.Logger
+	log      zerolog.Logger
+	chainID  flow.ChainID
+	headers  storage.Headers
+	evidence storage.SlashingEvidence
 }
 
-func NewSlashingViolationsConsumer(log zerolog.Logger) *SlashingViolationsConsumer {
+func NewSlashingViolationsConsumer(log zerolog.Logger, chainID flow.ChainID, headers storage.Headers, evidence storage.SlashingEvidence) *SlashingViolationsConsumer {
 	return &SlashingViolationsConsumer{
-		log: log,
+		log:      log,
+		chainID:  chainID,
+		headers:  headers,
+		evidence: evidence,
 	}
 }
 
@@ -26,6 +35,11 @@ func (c *SlashingViolationsConsumer) OnDoubleVotingDetected(vote1 *model.Vote, v
 		Hex("voted_block_id1", vote1.BlockID[:]).
 		Hex("voted_block_id2", vote2.BlockID[:]).
 		Msg("OnDoubleVotingDetected")
+
+	c.store(flow.SlashingViolationDoubleVote, vote1.SignerID, vote1.View,
+		signedVote(vote1),
+		signedVote(vote2),
+	)
 }
 
 func (c *SlashingViolationsConsumer) OnInvalidVoteDetected(vote *model.Vote) {
@@ -34,6 +48,10 @@ func (c *SlashingViolationsConsumer) OnInvalidVoteDetected(vote *model.Vote) {
 		Hex("voted_block_id", vote.BlockID[:]).
 		Hex("voter_id", vote.SignerID[:]).
 		Msg("OnInvalidVoteDetected")
+
+	c.store(flow.SlashingViolationInvalidVote, vote.SignerID, vote.View,
+		signedVote(vote),
+	)
 }
 
 func (c *SlashingViolationsConsumer) OnVoteForInvalidBlockDetected(vote *model.Vote, proposal *model.Proposal) {
@@ -43,6 +61,11 @@ func (c *SlashingViolationsConsumer) OnVoteForInvalidBlockDetected(vote *model.V
 		Hex("voter_id", vote.SignerID[:]).
 		Hex("proposer_id", proposal.Block.ProposerID[:]).
 		Msg("OnVoteForInvalidBlockDetected")
+
+	c.store(flow.SlashingViolationVoteForInvalidBlock, vote.SignerID, vote.View,
+		signedVote(vote),
+		signedProposal(proposal.Block, proposal.SigData),
+	)
 }
 
 func (c *SlashingViolationsConsumer) OnDoubleProposeDetected(block1 *model.Block, block2 *model.Block) {
@@ -52,4 +75,66 @@ func (c *SlashingViolationsConsumer) OnDoubleProposeDetected(block1 *model.Block
 		Hex("block_id1", block1.BlockID[:]).
 		Hex("block_id2", block2.BlockID[:]).
 		Msg("OnDoubleProposeDetected")
+
+	// the blocks passed to HotStuff don't carry the proposer signature, which we
+	// retrieve from the headers stored before the blocks were processed
+	proposals := make([]flow.SignedVote, 0, 2)
+	for _, block := range []*model.Block{block1, block2} {
+		header, err := c.headers.ByBlockID(block.BlockID)
+		if err != nil {
+			c.log.Error().Err(err).
+				Hex("block_id", block.BlockID[:]).
+				Msg("could not retrieve header of double proposal, slashing evidence will be incomplete")
+			proposals = append(proposals, signedProposal(block, nil))
+			continue
+		}
+		proposals = append(proposals, signedProposal(block, header.ProposerSigData))
+	}
+
+	c.store(flow.SlashingViolationDoubleProposal, block1.ProposerID, block1.View, proposals...)
+}
+
+// store persists the slashing evidence. Failing to persist the evidence doesn't
+// affect the liveness of consensus, so errors are only logged.
+func (c *SlashingViolationsConsumer) store(violation flow.SlashingViolation, offenderID flow.Identifier, view uint64, votes ...flow.SignedVote) {
+	evidence := &flow.SlashingEvidence{
+		Violation:  violation,
+		ChainID:    c.chainID,
+		OffenderID: offenderID,
+		View:       view,
+		Votes:      votes,
+	}
+	err := c.evidence.Store(evidence)
+	if err != nil {
+		c.log.Error().Err(err).
+			Str("violation", violation.String()).
+			Hex("offender_id", offenderID[:]).
+			Uint64("view", view).
+			Msg("could not store slashing evidence")
+		return
+	}
+	evidenceID := evidence.ID()
+	c.log.Info().
+		Str("violation", violation.String()).
+		Hex("evidence_id", evidenceID[:]).
+		Msg("stored slashing evidence")
+}
+
+func signedVote(vote *model.Vote) flow.SignedVote {
+	return flow.SignedVote{
+		View:     vote.View,
+		BlockID:  vote.BlockID,
+		SignerID: vote.SignerID,
+		SigData:  vote.SigData,
+	}
+}
+
+// signedProposal represents a proposal as the vote of the proposer for its own block.
+func signedProposal(block *model.Block, sigData []byte) flow.SignedVote {
+	return flow.SignedVote{
+		View:     block.View,
+		BlockID:  block.BlockID,
+		SignerID: block.ProposerID,
+		SigData:  sigData,
+	}
 }
@@ -0,0 +1,122 @@
+package notifications
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/consensus/hotstuff/model"
+	"github.com/onflow/flow-go/model/flow"
+	storagemock "github.com/onflow/flow-go/storage/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// consumerWithStorage returns a slashing violations consumer, and a pointer to the
+// evidence it stored last.
+func consumerWithStorage(headers *storagemock.Headers) (*SlashingViolationsConsumer, **flow.SlashingEvidence) {
+	var stored *flow.SlashingEvidence
+	evidence := new(storagemock.SlashingEvidence)
+	evidence.On("Store", mock.Anything).Run(func(args mock.Arguments) {
+		stored = args.Get(0).(*flow.SlashingEvidence)
+	}).Return(nil)
+	consumer := NewSlashingViolationsConsumer(unittest.Logger(), flow.Emulator, headers, evidence)
+	return consumer, &stored
+}
+
+func blockFixture() *model.Block {
+	header := unittest.BlockHeaderFixture()
+	return &model.Block{
+		View:       header.View,
+		BlockID:    header.ID(),
+		ProposerID: header.ProposerID,
+	}
+}
+
+func TestSlashingViolationsConsumer_DoubleVote(t *testing.T) {
+	consumer, stored := consumerWithStorage(new(storagemock.Headers))
+
+	vote1 := unittest.VoteFixture()
+	vote2 := unittest.VoteFixture(unittest.WithVoteView(vote1.View), unittest.WithVoteSignerID(vote1.SignerID))
+	consumer.OnDoubleVotingDetected(vote1, vote2)
+
+	require.NotNil(t, *stored)
+	evidence := *stored
+	assert.Equal(t, flow.SlashingViolationDoubleVote, evidence.Violation)
+	assert.Equal(t, flow.Emulator, evidence.ChainID)
+	assert.Equal(t, vote1.SignerID, evidence.OffenderID)
+	assert.Equal(t, vote1.View, evidence.View)
+	assert.Equal(t, []flow.SignedVote{signedVote(vote1), signedVote(vote2)}, evidence.Votes)
+	assert.Equal(t, vote1.SigData, evidence.Votes[0].SigData)
+}
+
+func TestSlashingViolationsConsumer_InvalidVote(t *testing.T) {
+	consumer, stored := consumerWithStorage(new(storagemock.Headers))
+
+	vote := unittest.VoteFixture()
+	consumer.OnInvalidVoteDetected(vote)
+
+	require.NotNil(t, *stored)
+	assert.Equal(t, flow.SlashingViolationInvalidVote, (*stored).Violation)
+	assert.Equal(t, vote.SignerID, (*stored).OffenderID)
+	assert.Equal(t, []flow.SignedVote{signedVote(vote)}, (*stored).Votes)
+}
+
+func TestSlashingViolationsConsumer_VoteForInvalidBlock(t *testing.T) {
+	consumer, stored := consumerWithStorage(new(storagemock.Headers))
+
+	proposal := &model.Proposal{
+		Block:   blockFixture(),
+		SigData: unittest.SignatureFixture(),
+	}
+	vote := unittest.VoteForBlockFixture(proposal.Block)
+	consumer.OnVoteForInvalidBlockDetected(vote, proposal)
+
+	require.NotNil(t, *stored)
+	evidence := *stored
+	assert.Equal(t, flow.SlashingViolationVoteForInvalidBlock, evidence.Violation)
+	assert.Equal(t, vote.SignerID, evidence.OffenderID)
+	require.Len(t, evidence.Votes, 2)
+	assert.Equal(t, signedVote(vote), evidence.Votes[0])
+	assert.Equal(t, proposal.Block.ProposerID, evidence.Votes[1].SignerID)
+	assert.Equal(t, proposal.SigData, evidence.Votes[1].SigData)
+}
+
+func TestSlashingViolationsConsumer_DoubleProposal(t *testing.T) {
+	block1 := blockFixture()
+	block2 := blockFixture()
+	block2.View = block1.View
+	block2.ProposerID = block1.ProposerID
+	sigData1 := unittest.SignatureFixture()
+
+	headers := new(storagemock.Headers)
+	headers.On("ByBlockID", block1.BlockID).Return(&flow.Header{ProposerSigData: sigData1}, nil)
+	headers.On("ByBlockID", block2.BlockID).Return(nil, errors.New("not found"))
+
+	consumer, stored := consumerWithStorage(headers)
+	consumer.OnDoubleProposeDetected(block1, block2)
+
+	require.NotNil(t, *stored)
+	evidence := *stored
+	assert.Equal(t, flow.SlashingViolationDoubleProposal, evidence.Violation)
+	assert.Equal(t, block1.ProposerID, evidence.OffenderID)
+	assert.Equal(t, block1.View, evidence.View)
+	// the evidence is stored even if the signature of a proposal is unavailable
+	assert.Equal(t, []flow.SignedVote{
+		signedProposal(block1, sigData1),
+		signedProposal(block2, nil),
+	}, evidence.Votes)
+}
+
+// TestSlashingViolationsConsumer_StorageFailure tests that failing to store the
+// evidence doesn't interrupt the processing of notifications.
+func TestSlashingViolationsConsumer_StorageFailure(t *testing.T) {
+	evidence := new(storagemock.SlashingEvidence)
+	evidence.On("Store", mock.Anything).Return(errors.New("exception"))
+	consumer := NewSlashingViolationsConsumer(unittest.Logger(), flow.Emulator, new(storagemock.Headers), evidence)
+
+	consumer.OnInvalidVoteDetected(unittest.VoteFixture())
+	evidence.AssertExpectations(t)
+}
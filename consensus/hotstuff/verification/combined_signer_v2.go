@@ -97,10 +97,7 @@ func (c *CombinedSigner) CreateVote(block *model.Block) (*model.Vote, error) {
 //  - (nil, error) if there is any exception
 func (c *CombinedSigner) genSigData(block *model.Block) ([]byte, error) {
 
-	// create the message to be signed and generate signatures
-	msg := MakeVoteMessage(block.View, block.BlockID)
-
-	stakingSig, err := c.staking.Sign(msg, c.stakingHasher)
+	stakingSig, err := signVote(c.staking, block.View, block.BlockID, c.stakingHasher)
 	if err != nil {
 		return nil, fmt.Errorf("could not generate staking signature: %w", err)
 	}
@@ -115,7 +112,7 @@ func (c *CombinedSigner) genSigData(block *model.Block) ([]byte, error) {
 
 	// if the node is a Random Beacon participant and has succeeded DKG, then using the random beacon key
 	// to sign the block
-	beaconShare, err := beaconKey.Sign(msg, c.beaconHasher)
+	beaconShare, err := signVote(beaconKey, block.View, block.BlockID, c.beaconHasher)
 	if err != nil {
 		return nil, fmt.Errorf("could not generate beacon signature: %w", err)
 	}
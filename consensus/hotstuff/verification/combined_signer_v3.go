@@ -94,15 +94,12 @@ func (c *CombinedSignerV3) CreateVote(block *model.Block) (*model.Vote, error) {
 // genSigData generates the signature data for our local node for the given block.
 func (c *CombinedSignerV3) genSigData(block *model.Block) ([]byte, error) {
 
-	// create the message to be signed and generate signatures
-	msg := MakeVoteMessage(block.View, block.BlockID)
-
 	beaconKey, err := c.beaconKeyStore.ByView(block.View)
 	if err != nil {
 		if errors.Is(err, module.DKGFailError) {
 			// if the node failed DKG, then using the staking key to sign the block as a
 			// fallback
-			stakingSig, err := c.staking.Sign(msg, c.stakingHasher)
+			stakingSig, err := signVote(c.staking, block.View, block.BlockID, c.stakingHasher)
 			if err != nil {
 				return nil, fmt.Errorf("could not generate staking signature: %w", err)
 			}
@@ -114,7 +111,7 @@ func (c *CombinedSignerV3) genSigData(block *model.Block) ([]byte, error) {
 
 	// if the node is a Random Beacon participant and has succeeded DKG, then using the random beacon key
 	// to sign the block
-	beaconShare, err := beaconKey.Sign(msg, c.beaconHasher)
+	beaconShare, err := signVote(beaconKey, block.View, block.BlockID, c.beaconHasher)
 	if err != nil {
 		return nil, fmt.Errorf("could not generate beacon signature: %w", err)
 	}
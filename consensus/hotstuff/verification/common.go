@@ -1,7 +1,10 @@
 package verification
 
 import (
+	"github.com/onflow/flow-go/crypto"
+	"github.com/onflow/flow-go/crypto/hash"
 	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module"
 )
 
 // MakeVoteMessage generates the message we have to sign in order to be able
@@ -19,3 +22,31 @@ func MakeVoteMessage(view uint64, blockID flow.Identifier) []byte {
 	})
 	return msg[:]
 }
+
+// messageSigner signs messages with a hasher, like the local node for staking
+// signatures or a random beacon private key.
+type messageSigner interface {
+	Sign([]byte, hash.Hasher) (crypto.Signature, error)
+}
+
+// signVote generates the signature of the vote for the block at the given view.
+// Signers implementing module.VoteSigner build and sign the vote themselves, so that
+// they can refuse to vote for different blocks at the same view; other signers sign
+// the vote message with the hasher.
+func signVote(signer messageSigner, view uint64, blockID flow.Identifier, hasher hash.Hasher) (crypto.Signature, error) {
+	if voteSigner, ok := signer.(module.VoteSigner); ok {
+		return voteSigner.SignVote(view, blockID)
+	}
+	return signer.Sign(MakeVoteMessage(view, blockID), hasher)
+}
+
+// signClusterVote generates the signature of the vote for the block at the given view of
+// the chain of a collection cluster. Signers implementing module.VoteSigner build and sign
+// the vote themselves, so that they can refuse to vote for different blocks at the same
+// view of the cluster chain; other signers sign the vote message with the hasher.
+func signClusterVote(signer messageSigner, clusterID flow.ChainID, view uint64, blockID flow.Identifier, hasher hash.Hasher) (crypto.Signature, error) {
+	if voteSigner, ok := signer.(module.VoteSigner); ok {
+		return voteSigner.SignClusterVote(clusterID, view, blockID)
+	}
+	return signer.Sign(MakeVoteMessage(view, blockID), hasher)
+}
@@ -0,0 +1,96 @@
+package verification
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/crypto"
+	"github.com/onflow/flow-go/crypto/hash"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestSignedVoteMessage tests that the message of the votes persisted as slashing
+// evidence is the message signed by HotStuff replicas.
+func TestSignedVoteMessage(t *testing.T) {
+	vote := flow.SignedVote{
+		View:     42,
+		BlockID:  unittest.IdentifierFixture(),
+		SignerID: unittest.IdentifierFixture(),
+	}
+	assert.Equal(t, MakeVoteMessage(vote.View, vote.BlockID), vote.Message())
+}
+
+// voteSigner is a signer building and signing votes itself, like remote signers.
+type voteSigner struct {
+	clusterID flow.ChainID
+	view      uint64
+	blockID   flow.Identifier
+}
+
+func (s *voteSigner) Sign([]byte, hash.Hasher) (crypto.Signature, error) {
+	panic("votes must not be signed as messages")
+}
+
+func (s *voteSigner) SignVote(view uint64, blockID flow.Identifier) (crypto.Signature, error) {
+	s.view, s.blockID = view, blockID
+	return crypto.Signature("vote"), nil
+}
+
+func (s *voteSigner) SignClusterVote(clusterID flow.ChainID, view uint64, blockID flow.Identifier) (crypto.Signature, error) {
+	s.clusterID, s.view, s.blockID = clusterID, view, blockID
+	return crypto.Signature("cluster vote"), nil
+}
+
+// TestSignVote tests that votes are signed by the signers implementing module.VoteSigner
+// themselves, and as a message by other signers.
+func TestSignVote(t *testing.T) {
+	blockID := unittest.IdentifierFixture()
+	hasher := hash.NewSHA3_256()
+
+	t.Run("vote signer", func(t *testing.T) {
+		signer := &voteSigner{}
+		sig, err := signVote(signer, 42, blockID, hasher)
+		require.NoError(t, err)
+		assert.Equal(t, crypto.Signature("vote"), sig)
+		assert.Equal(t, uint64(42), signer.view)
+		assert.Equal(t, blockID, signer.blockID)
+	})
+
+	t.Run("message signer", func(t *testing.T) {
+		key := unittest.PrivateKeyFixture(crypto.ECDSAP256, crypto.KeyGenSeedMinLenECDSAP256)
+		sig, err := signVote(key, 42, blockID, hasher)
+		require.NoError(t, err)
+		valid, err := key.PublicKey().Verify(sig, MakeVoteMessage(42, blockID), hasher)
+		require.NoError(t, err)
+		assert.True(t, valid)
+	})
+}
+
+// TestSignClusterVote tests that cluster votes are signed by the signers implementing
+// module.VoteSigner themselves, and as a message by other signers.
+func TestSignClusterVote(t *testing.T) {
+	blockID := unittest.IdentifierFixture()
+	hasher := hash.NewSHA3_256()
+
+	t.Run("vote signer", func(t *testing.T) {
+		signer := &voteSigner{}
+		sig, err := signClusterVote(signer, "cluster", 42, blockID, hasher)
+		require.NoError(t, err)
+		assert.Equal(t, crypto.Signature("cluster vote"), sig)
+		assert.Equal(t, flow.ChainID("cluster"), signer.clusterID)
+		assert.Equal(t, uint64(42), signer.view)
+		assert.Equal(t, blockID, signer.blockID)
+	})
+
+	t.Run("message signer", func(t *testing.T) {
+		key := unittest.PrivateKeyFixture(crypto.ECDSAP256, crypto.KeyGenSeedMinLenECDSAP256)
+		sig, err := signClusterVote(key, "cluster", 42, blockID, hasher)
+		require.NoError(t, err)
+		valid, err := key.PublicKey().Verify(sig, MakeVoteMessage(42, blockID), hasher)
+		require.NoError(t, err)
+		assert.True(t, valid)
+	})
+}
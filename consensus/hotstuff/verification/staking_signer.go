@@ -19,18 +19,22 @@ type StakingSigner struct {
 	me            module.Local
 	stakingHasher hash.Hasher
 	signerID      flow.Identifier
+	clusterID     flow.ChainID
 }
 
 // NewStakingSigner instantiates a StakingSigner, which signs votes and
-// proposals with the staking key.  The generated signatures are aggregatable.
+// proposals of the given cluster with the staking key.  The generated
+// signatures are aggregatable.
 func NewStakingSigner(
 	me module.Local,
+	clusterID flow.ChainID,
 ) *StakingSigner {
 
 	sc := &StakingSigner{
 		me:            me,
 		stakingHasher: crypto.NewBLSKMAC(encoding.CollectorVoteTag),
 		signerID:      me.NodeID(),
+		clusterID:     clusterID,
 	}
 	return sc
 }
@@ -83,10 +87,8 @@ func (c *StakingSigner) CreateVote(block *model.Block) (*model.Vote, error) {
 //  - (stakingSig, nil) signature signed with staking key.  The sig is 48 bytes long
 //  - (nil, error) if there is any exception
 func (c *StakingSigner) genSigData(block *model.Block) ([]byte, error) {
-	// create the message to be signed and generate signatures
-	msg := MakeVoteMessage(block.View, block.BlockID)
-
-	stakingSig, err := c.me.Sign(msg, c.stakingHasher)
+	// generate the signature of the vote for the block
+	stakingSig, err := signClusterVote(c.me, c.clusterID, block.View, block.BlockID, c.stakingHasher)
 	if err != nil {
 		return nil, fmt.Errorf("could not generate staking signature for block (%v) at view %v: %w", block.BlockID, block.View, err)
 	}
@@ -27,7 +27,7 @@ func TestStakingSigner_CreateProposal(t *testing.T) {
 	t.Run("invalid-signer-id", func(t *testing.T) {
 		me := &modulemock.Local{}
 		me.On("NodeID").Return(signerID)
-		signer := NewStakingSigner(me)
+		signer := NewStakingSigner(me, "cluster")
 
 		block := helper.MakeBlock()
 		proposal, err := signer.CreateProposal(block)
@@ -39,7 +39,7 @@ func TestStakingSigner_CreateProposal(t *testing.T) {
 		me := &modulemock.Local{}
 		me.On("NodeID").Return(signerID)
 		me.On("Sign", mock.Anything, mock.Anything).Return(nil, signException).Once()
-		signer := NewStakingSigner(me)
+		signer := NewStakingSigner(me, "cluster")
 
 		block := helper.MakeBlock()
 		proposal, err := signer.CreateProposal(block)
@@ -53,7 +53,7 @@ func TestStakingSigner_CreateProposal(t *testing.T) {
 		signerIdentity := unittest.IdentityFixture(unittest.WithNodeID(signerID),
 			unittest.WithStakingPubKey(stakingPriv.PublicKey()))
 
-		signer := NewStakingSigner(me)
+		signer := NewStakingSigner(me, "cluster")
 
 		block := helper.MakeBlock(helper.WithBlockProposer(signerID))
 		proposal, err := signer.CreateProposal(block)
@@ -80,7 +80,7 @@ func TestStakingSigner_CreateVote(t *testing.T) {
 		me := &modulemock.Local{}
 		me.On("NodeID").Return(signerID)
 		me.On("Sign", mock.Anything, mock.Anything).Return(nil, signException).Once()
-		signer := NewStakingSigner(me)
+		signer := NewStakingSigner(me, "cluster")
 
 		block := helper.MakeBlock()
 		proposal, err := signer.CreateProposal(block)
@@ -94,7 +94,7 @@ func TestStakingSigner_CreateVote(t *testing.T) {
 		signerIdentity := unittest.IdentityFixture(unittest.WithNodeID(signerID),
 			unittest.WithStakingPubKey(stakingPriv.PublicKey()))
 
-		signer := NewStakingSigner(me)
+		signer := NewStakingSigner(me, "cluster")
 
 		block := helper.MakeBlock(helper.WithBlockProposer(signerID))
 		vote, err := signer.CreateVote(block)
@@ -256,7 +256,7 @@ func TestStakingVoteProcessorV2_BuildVerifyQC(t *testing.T) {
 		me, err := local.New(identity, stakingPriv)
 		require.NoError(t, err)
 
-		signers[identity.NodeID] = verification.NewStakingSigner(me)
+		signers[identity.NodeID] = verification.NewStakingSigner(me, "cluster")
 	})
 
 	leader := stakingSigners[0]
@@ -13,6 +13,7 @@ import (
 	"github.com/onflow/flow-go/consensus/hotstuff/forks"
 	"github.com/onflow/flow-go/consensus/hotstuff/forks/finalizer"
 	"github.com/onflow/flow-go/consensus/hotstuff/forks/forkchoice"
+	"github.com/onflow/flow-go/consensus/hotstuff/journal"
 	"github.com/onflow/flow-go/consensus/hotstuff/model"
 	"github.com/onflow/flow-go/consensus/hotstuff/pacemaker"
 	"github.com/onflow/flow-go/consensus/hotstuff/pacemaker/timeout"
@@ -66,15 +67,6 @@ func NewParticipant(
 		return nil, fmt.Errorf("could not recover last voted: %w", err)
 	}
 
-	// prune vote aggregator to initial view
-	modules.Aggregator.PruneUpToView(finalized.View)
-
-	// recover the hotstuff state, mainly to recover all pending blocks in Forks
-	err = recovery.Participant(log, modules.Forks, modules.Aggregator, modules.Validator, finalized, pending)
-	if err != nil {
-		return nil, fmt.Errorf("could not recover hotstuff state: %w", err)
-	}
-
 	// initialize the timeout config
 	timeoutConfig, err := timeout.NewConfig(
 		cfg.TimeoutInitial,
@@ -88,6 +80,27 @@ func NewParticipant(
 		return nil, fmt.Errorf("could not initialize timeout config: %w", err)
 	}
 
+	// record the state the event handler is started from, before the recovery
+	// adds the pending blocks, so the journal can be replayed
+	if cfg.Journal != nil {
+		cfg.Journal.RecordBootstrap(&journal.Bootstrap{
+			Finalized:   finalized,
+			Pending:     pending,
+			StartedView: started,
+			VotedView:   voted,
+			Timeouts:    timeoutConfig,
+		})
+	}
+
+	// prune vote aggregator to initial view
+	modules.Aggregator.PruneUpToView(finalized.View)
+
+	// recover the hotstuff state, mainly to recover all pending blocks in Forks
+	err = recovery.Participant(log, modules.Forks, modules.Aggregator, modules.Validator, finalized, pending)
+	if err != nil {
+		return nil, fmt.Errorf("could not recover hotstuff state: %w", err)
+	}
+
 	// initialize the pacemaker
 	controller := timeout.NewController(timeoutConfig)
 	pacemaker, err := pacemaker.New(started+1, controller, modules.Notifier)
@@ -122,8 +135,14 @@ func NewParticipant(
 		return nil, fmt.Errorf("could not initialize event handler: %w", err)
 	}
 
+	// record all inputs of the event handler, if journaling is enabled
+	var handler hotstuff.EventHandler = eventHandler
+	if cfg.Journal != nil {
+		handler = journal.NewEventHandler(eventHandler, cfg.Journal)
+	}
+
 	// initialize and return the event loop
-	loop, err := eventloop.NewEventLoop(log, metrics, eventHandler, cfg.StartupTime)
+	loop, err := eventloop.NewEventLoop(log, metrics, handler, cfg.StartupTime)
 	if err != nil {
 		return nil, fmt.Errorf("could not initialize event loop: %w", err)
 	}
@@ -0,0 +1,275 @@
+package crypto
+
+// Ed25519 is implemented as defined in RFC 8032 (PureEdDSA on edwards25519),
+// using the Go standard library.
+
+// Messages are hashed with the provided hasher before being signed, so that
+// Ed25519 keys can be used with the same signing interface as ECDSA keys.
+
+// This implementation does not include any security against side-channel attacks.
+
+import (
+	"bytes"
+	goed25519 "crypto/ed25519"
+	"fmt"
+	"math/big"
+
+	"github.com/onflow/flow-go/crypto/hash"
+)
+
+// ed25519Algo embeds SignAlgo
+type ed25519Algo struct {
+	// the signing algo and parameters
+	algo SigningAlgorithm
+}
+
+// Ed25519 context
+var ed25519Instance *ed25519Algo
+
+var (
+	// field modulus of edwards25519 p = 2^255 - 19
+	ed25519P, _ = new(big.Int).SetString("7fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffed", 16)
+	// curve parameter d = -121665/121666 mod p
+	ed25519D, _ = new(big.Int).SetString("52036cee2b6ffe738cc740797779e89800700a4d4141d8ab75eb4dca135978a3", 16)
+	// order of the prime subgroup L = 2^252 + 27742317777372353535851937790883648493
+	ed25519L, _ = new(big.Int).SetString("1000000000000000000000000000000014def9dea2f79cd65812631a5cf5d3ed", 16)
+)
+
+// littleEndianToInt reads the little endian byte encoding of an integer
+func littleEndianToInt(b []byte) *big.Int {
+	be := make([]byte, len(b))
+	for i := range b {
+		be[len(b)-1-i] = b[i]
+	}
+	return new(big.Int).SetBytes(be)
+}
+
+// Sign signs an array of bytes
+//
+// The private key is read only while sha2 and sha3 hashers are
+// modified temporarily.
+// The data is hashed using the hasher, and the hash is signed using
+// PureEdDSA.
+func (sk *PrKeyEd25519) Sign(data []byte, alg hash.Hasher) (Signature, error) {
+	if alg == nil {
+		return nil, invalidInputsErrorf("hasher is nil")
+	}
+	h := alg.ComputeHash(data)
+	return goed25519.Sign(sk.goPrKey, h), nil
+}
+
+// Verify verifies a signature of an input data under the public key.
+//
+// If the input signature slice has an invalid length or is not canonical,
+// the function returns false without an error.
+//
+// Public keys are read only, sha2 and sha3 hashers are
+// modified temporarily.
+func (pk *PubKeyEd25519) Verify(sig Signature, data []byte, alg hash.Hasher) (bool, error) {
+	if alg == nil {
+		return false, invalidInputsErrorf("hasher is nil")
+	}
+	if !ed25519Instance.signatureFormatCheck(sig) {
+		return false, nil
+	}
+	h := alg.ComputeHash(data)
+	return goed25519.Verify(pk.goPubKey, h, sig), nil
+}
+
+// signatureFormatCheck verifies the format of a serialized signature,
+// regardless of messages or public keys.
+// If FormatCheck returns false then the input is not a valid Ed25519
+// signature and will fail a verification against any message and public key.
+func (a *ed25519Algo) signatureFormatCheck(sig Signature) bool {
+	if len(sig) != SignatureLenEd25519 {
+		return false
+	}
+	// the encoding of R must be a point of the curve
+	if !ed25519IsPoint(sig[:PubKeyLenEd25519]) {
+		return false
+	}
+	// the scalar S must be canonical (RFC 8032 section 5.1.7)
+	s := littleEndianToInt(sig[PubKeyLenEd25519:])
+	return s.Cmp(ed25519L) < 0
+}
+
+// ed25519IsPoint checks that the input is the encoding of a point
+// of edwards25519, as defined in RFC 8032 section 5.1.3.
+func ed25519IsPoint(b []byte) bool {
+	if len(b) != PubKeyLenEd25519 {
+		return false
+	}
+	encoded := make([]byte, len(b))
+	copy(encoded, b)
+	// the most significant bit is the sign of x
+	xSign := encoded[len(encoded)-1] >> 7
+	encoded[len(encoded)-1] &= 0x7f
+
+	y := littleEndianToInt(encoded)
+	if y.Cmp(ed25519P) >= 0 {
+		return false
+	}
+
+	// x^2 = (y^2 - 1) / (d*y^2 + 1)
+	y2 := new(big.Int).Mul(y, y)
+	y2.Mod(y2, ed25519P)
+	u := new(big.Int).Sub(y2, big.NewInt(1))
+	u.Mod(u, ed25519P)
+	v := new(big.Int).Mul(ed25519D, y2)
+	v.Add(v, big.NewInt(1))
+	v.Mod(v, ed25519P)
+	x2 := new(big.Int).ModInverse(v, ed25519P)
+	x2.Mul(x2, u)
+	x2.Mod(x2, ed25519P)
+
+	if x2.Sign() == 0 {
+		// x = 0 has no negative representation
+		return xSign == 0
+	}
+	return new(big.Int).ModSqrt(x2, ed25519P) != nil
+}
+
+// generatePrivateKey generates a private key for Ed25519
+// deterministically using the input seed.
+//
+// The seed is hashed into the 32-byte Ed25519 private key using SHA3-256.
+// It is recommended to use a secure crypto RNG to generate the seed.
+// The seed must have enough entropy and should be sampled uniformly at random.
+func (a *ed25519Algo) generatePrivateKey(seed []byte) (PrivateKey, error) {
+	if len(seed) < KeyGenSeedMinLenEd25519 || len(seed) > KeyGenSeedMaxLenEd25519 {
+		return nil, invalidInputsErrorf("seed byte length should be between %d and %d",
+			KeyGenSeedMinLenEd25519, KeyGenSeedMaxLenEd25519)
+	}
+	h := hash.NewSHA3_256().ComputeHash(seed)
+	return &PrKeyEd25519{
+		alg:     a,
+		goPrKey: goed25519.NewKeyFromSeed(h),
+	}, nil
+}
+
+// decodePrivateKey decodes a private key from its 32-byte seed, as defined in RFC 8032.
+func (a *ed25519Algo) decodePrivateKey(der []byte) (PrivateKey, error) {
+	if len(der) != PrKeyLenEd25519 {
+		return nil, invalidInputsErrorf("input has incorrect %s key size", a.algo)
+	}
+	return &PrKeyEd25519{
+		alg:     a,
+		goPrKey: goed25519.NewKeyFromSeed(der),
+	}, nil
+}
+
+// decodePublicKey decodes a public key from its 32-byte encoding, as defined in RFC 8032.
+func (a *ed25519Algo) decodePublicKey(der []byte) (PublicKey, error) {
+	if len(der) != PubKeyLenEd25519 {
+		return nil, invalidInputsErrorf("input has incorrect %s key size", a.algo)
+	}
+	if !ed25519IsPoint(der) {
+		return nil, invalidInputsErrorf("input %x is not a valid %s key", der, a.algo)
+	}
+	pk := make([]byte, PubKeyLenEd25519)
+	copy(pk, der)
+	return &PubKeyEd25519{
+		alg:      a,
+		goPubKey: goed25519.PublicKey(pk),
+	}, nil
+}
+
+// decodePublicKeyCompressed decodes a public key. Ed25519 public keys are always
+// encoded as compressed points.
+func (a *ed25519Algo) decodePublicKeyCompressed(der []byte) (PublicKey, error) {
+	return a.decodePublicKey(der)
+}
+
+// PrKeyEd25519 is the private key of Ed25519, it implements the generic PrivateKey
+type PrKeyEd25519 struct {
+	// the signature algo
+	alg *ed25519Algo
+	// goed25519 private key
+	goPrKey goed25519.PrivateKey
+}
+
+// Algorithm returns the algo related to the private key
+func (sk *PrKeyEd25519) Algorithm() SigningAlgorithm {
+	return sk.alg.algo
+}
+
+// Size returns the length of the private key in bytes
+func (sk *PrKeyEd25519) Size() int {
+	return PrKeyLenEd25519
+}
+
+// PublicKey returns the public key associated to the private key
+func (sk *PrKeyEd25519) PublicKey() PublicKey {
+	return &PubKeyEd25519{
+		alg:      sk.alg,
+		goPubKey: sk.goPrKey.Public().(goed25519.PublicKey),
+	}
+}
+
+// Encode returns a byte representation of a private key.
+// The 32-byte seed of RFC 8032 is used as the encoding.
+func (sk *PrKeyEd25519) Encode() []byte {
+	return sk.goPrKey.Seed()
+}
+
+// Equals test the equality of two private keys
+func (sk *PrKeyEd25519) Equals(other PrivateKey) bool {
+	// check the key type
+	otherEd25519, ok := other.(*PrKeyEd25519)
+	if !ok {
+		return false
+	}
+	return bytes.Equal(sk.goPrKey.Seed(), otherEd25519.goPrKey.Seed())
+}
+
+// String returns the hex string representation of the key.
+func (sk *PrKeyEd25519) String() string {
+	return fmt.Sprintf("%#x", sk.Encode())
+}
+
+// PubKeyEd25519 is the public key of Ed25519, it implements PublicKey
+type PubKeyEd25519 struct {
+	// the signature algo
+	alg *ed25519Algo
+	// public key data
+	goPubKey goed25519.PublicKey
+}
+
+// Algorithm returns the the algo related to the private key
+func (pk *PubKeyEd25519) Algorithm() SigningAlgorithm {
+	return pk.alg.algo
+}
+
+// Size returns the length of the public key in bytes
+func (pk *PubKeyEd25519) Size() int {
+	return PubKeyLenEd25519
+}
+
+// EncodeCompressed returns the encoding of the public key, which is
+// already a compressed point as defined in RFC 8032.
+func (pk *PubKeyEd25519) EncodeCompressed() []byte {
+	return pk.Encode()
+}
+
+// Encode returns a byte representation of a public key,
+// the 32-byte point encoding of RFC 8032.
+func (pk *PubKeyEd25519) Encode() []byte {
+	encoded := make([]byte, PubKeyLenEd25519)
+	copy(encoded, pk.goPubKey)
+	return encoded
+}
+
+// Equals test the equality of two public keys
+func (pk *PubKeyEd25519) Equals(other PublicKey) bool {
+	// check the key type
+	otherEd25519, ok := other.(*PubKeyEd25519)
+	if !ok {
+		return false
+	}
+	return bytes.Equal(pk.goPubKey, otherEd25519.goPubKey)
+}
+
+// String returns the hex string representation of the key.
+func (pk *PubKeyEd25519) String() string {
+	return fmt.Sprintf("%#x", pk.Encode())
+}
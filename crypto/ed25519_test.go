@@ -0,0 +1,199 @@
+package crypto
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/crypto/hash"
+)
+
+// Ed25519 tests
+func TestEd25519(t *testing.T) {
+	halg := hash.NewSHA3_256()
+	// test key generation seed limits
+	testKeyGenSeed(t, Ed25519, KeyGenSeedMinLenEd25519, KeyGenSeedMaxLenEd25519)
+	// test consistency
+	testGenSignVerify(t, Ed25519, halg)
+}
+
+// Signing bench
+func BenchmarkEd25519Sign(b *testing.B) {
+	halg := hash.NewSHA3_256()
+	benchSign(b, Ed25519, halg)
+}
+
+// Verifying bench
+func BenchmarkEd25519Verify(b *testing.B) {
+	halg := hash.NewSHA3_256()
+	benchVerify(b, Ed25519, halg)
+}
+
+// TestEd25519EncodeDecode tests encoding and decoding of Ed25519 keys
+func TestEd25519EncodeDecode(t *testing.T) {
+	testEncodeDecode(t, Ed25519)
+
+	t.Run("invalid public key", func(t *testing.T) {
+		// y = 2 is not the coordinate of a point of the curve
+		der := make([]byte, PubKeyLenEd25519)
+		der[0] = 2
+		pk, err := DecodePublicKey(Ed25519, der)
+		require.Error(t, err)
+		assert.True(t, IsInvalidInputsError(err))
+		assert.Nil(t, pk)
+
+		// y = p is not canonical
+		der, err = hex.DecodeString("edffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f")
+		require.NoError(t, err)
+		pk, err = DecodePublicKey(Ed25519, der)
+		require.Error(t, err)
+		assert.True(t, IsInvalidInputsError(err))
+		assert.Nil(t, pk)
+	})
+}
+
+// TestEd25519Equals tests equal for Ed25519 keys
+func TestEd25519Equals(t *testing.T) {
+	testEquals(t, Ed25519, ECDSAP256)
+}
+
+// TestEd25519Utils tests some utility functions
+func TestEd25519Utils(t *testing.T) {
+	// generate a key pair
+	seed := make([]byte, KeyGenSeedMinLenEd25519)
+	n, err := rand.Read(seed)
+	require.Equal(t, n, KeyGenSeedMinLenEd25519)
+	require.NoError(t, err)
+	sk, err := GeneratePrivateKey(Ed25519, seed)
+	require.NoError(t, err)
+	testKeysAlgorithm(t, sk, Ed25519)
+	testKeySize(t, sk, PrKeyLenEd25519, PubKeyLenEd25519)
+}
+
+// TestEd25519Vectors checks the implementation against the test vectors
+// of RFC 8032 section 7.1, by decoding the private key seeds.
+func TestEd25519Vectors(t *testing.T) {
+	vectors := []struct {
+		sk  string
+		pk  string
+		msg string
+		sig string
+	}{
+		{
+			sk:  "9d61b19deffd5a60ba844af492ec2cc44449c5697b326919703bac031cae7f60",
+			pk:  "d75a980182b10ab7d54bfed3c964073a0ee172f3daa62325af021a68f707511a",
+			msg: "",
+			sig: "e5564300c360ac729086e2cc806e828a84877f1eb8e5d974d873e065224901555fb8821590a33bacc61e39701cf9b46bd25bf5f0595bbe24655141438e7a100b",
+		},
+		{
+			sk:  "4ccd089b28ff96da9db6c346ec114e0f5b8a319f35aba624da8cf6ed4fb8a6fb",
+			pk:  "3d4017c3e843895a92b70aa74d1b7ebc9c982ccf2ec4968cc0cd55f12af4660c",
+			msg: "72",
+			sig: "92a009a9f0d4cab8720e820b5f642540a2b27b5416503f8fb3762223ebdb69da085ac1e43e15996e458f3613d0f11d8c387b2eaeb4302aeeb00d291612bb0c00",
+		},
+	}
+
+	// the hasher is the identity, so that the message itself is signed
+	identity := &identityHasher{}
+
+	for _, v := range vectors {
+		skBytes, err := hex.DecodeString(v.sk)
+		require.NoError(t, err)
+		pkBytes, err := hex.DecodeString(v.pk)
+		require.NoError(t, err)
+		msg, err := hex.DecodeString(v.msg)
+		require.NoError(t, err)
+		expectedSig, err := hex.DecodeString(v.sig)
+		require.NoError(t, err)
+
+		sk, err := DecodePrivateKey(Ed25519, skBytes)
+		require.NoError(t, err)
+		assert.Equal(t, pkBytes, sk.PublicKey().Encode())
+
+		sig, err := sk.Sign(msg, identity)
+		require.NoError(t, err)
+		assert.Equal(t, Signature(expectedSig), sig)
+
+		pk, err := DecodePublicKey(Ed25519, pkBytes)
+		require.NoError(t, err)
+		valid, err := pk.Verify(sig, msg, identity)
+		require.NoError(t, err)
+		assert.True(t, valid)
+	}
+}
+
+// TestEd25519SignatureFormatCheck tests the format check of Ed25519 signatures
+func TestEd25519SignatureFormatCheck(t *testing.T) {
+	seed := make([]byte, KeyGenSeedMinLenEd25519)
+	_, err := rand.Read(seed)
+	require.NoError(t, err)
+	sk, err := GeneratePrivateKey(Ed25519, seed)
+	require.NoError(t, err)
+
+	sig, err := sk.Sign([]byte("message"), hash.NewSHA3_256())
+	require.NoError(t, err)
+
+	t.Run("valid signature", func(t *testing.T) {
+		valid, err := SignatureFormatCheck(Ed25519, sig)
+		require.NoError(t, err)
+		assert.True(t, valid)
+	})
+
+	t.Run("invalid length", func(t *testing.T) {
+		valid, err := SignatureFormatCheck(Ed25519, sig[1:])
+		require.NoError(t, err)
+		assert.False(t, valid)
+	})
+
+	t.Run("non canonical scalar", func(t *testing.T) {
+		// S = S + L is rejected, although it would verify with a lax implementation
+		invalid := make([]byte, SignatureLenEd25519)
+		copy(invalid, sig[:PubKeyLenEd25519])
+		s := littleEndianToInt(sig[PubKeyLenEd25519:])
+		s.Add(s, ed25519L)
+		sBytes := s.Bytes()
+		for i := range sBytes {
+			invalid[SignatureLenEd25519-1-i] = sBytes[i]
+		}
+		valid, err := SignatureFormatCheck(Ed25519, invalid)
+		require.NoError(t, err)
+		assert.False(t, valid)
+
+		valid, err = sk.PublicKey().Verify(invalid, []byte("message"), hash.NewSHA3_256())
+		require.NoError(t, err)
+		assert.False(t, valid)
+	})
+}
+
+// identityHasher is a hasher returning its input.
+type identityHasher struct {
+	data []byte
+}
+
+func (h *identityHasher) Algorithm() hash.HashingAlgorithm {
+	return hash.UnknownHashingAlgorithm
+}
+
+func (h *identityHasher) Size() int {
+	return len(h.data)
+}
+
+func (h *identityHasher) ComputeHash(data []byte) hash.Hash {
+	return append([]byte{}, data...)
+}
+
+func (h *identityHasher) Write(data []byte) (int, error) {
+	h.data = append(h.data, data...)
+	return len(data), nil
+}
+
+func (h *identityHasher) SumHash() hash.Hash {
+	return h.data
+}
+
+func (h *identityHasher) Reset() {
+	h.data = nil
+}
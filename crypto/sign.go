@@ -33,6 +33,8 @@ func newNonRelicSigner(algo SigningAlgorithm) (signer, error) {
 		return p256Instance, nil
 	case ECDSASecp256k1:
 		return secp256k1Instance, nil
+	case Ed25519:
+		return ed25519Instance, nil
 	default:
 		return nil, invalidInputsErrorf("the signature scheme %s is not supported", algo)
 	}
@@ -51,15 +53,22 @@ func initNonRelic() {
 		curve: btcec.S256(),
 		algo:  ECDSASecp256k1,
 	})
+
+	// Ed25519
+	ed25519Instance = &(ed25519Algo{
+		algo: Ed25519,
+	})
 }
 
-// Signature format Check for non-relic algos (ECDSA)
+// Signature format Check for non-relic algos (ECDSA and Ed25519)
 func signatureFormatCheckNonRelic(algo SigningAlgorithm, s Signature) (bool, error) {
 	switch algo {
 	case ECDSAP256:
 		return p256Instance.signatureFormatCheck(s), nil
 	case ECDSASecp256k1:
 		return secp256k1Instance.signatureFormatCheck(s), nil
+	case Ed25519:
+		return ed25519Instance.signatureFormatCheck(s), nil
 	default:
 		return false, invalidInputsErrorf(
 			"the signature scheme %s is not supported",
@@ -70,7 +79,7 @@ func signatureFormatCheckNonRelic(algo SigningAlgorithm, s Signature) (bool, err
 // SignatureFormatCheck verifies the format of a serialized signature,
 // regardless of messages or public keys.
 //
-// This function is only defined for ECDSA algos for now.
+// This function is only defined for ECDSA and Ed25519 algos for now.
 //
 // If SignatureFormatCheck returns false then the input is not a valid
 // signature and will fail a verification against any message and public key.
@@ -148,6 +148,9 @@ func testEncodeDecode(t *testing.T, salg SigningAlgorithm) {
 
 	// test invalid private keys (equal to the curve group order)
 	t.Run("private keys equal to the group order", func(t *testing.T) {
+		if salg == Ed25519 {
+			t.Skip("Ed25519 private keys are seeds, all 32-byte values are valid")
+		}
 		groupOrder := make(map[SigningAlgorithm][]byte)
 		groupOrder[ECDSAP256] = []byte{255, 255, 255, 255, 0, 0, 0, 0, 255, 255, 255,
 			255, 255, 255, 255, 255, 188, 230, 250, 173, 167,
@@ -174,6 +177,7 @@ func testEncodeDecode(t *testing.T, salg SigningAlgorithm) {
 		skLens[ECDSAP256] = PrKeyLenECDSAP256
 		skLens[ECDSASecp256k1] = PrKeyLenECDSASecp256k1
 		skLens[BLSBLS12381] = PrKeyLenBLSBLS12381
+		skLens[Ed25519] = PrKeyLenEd25519
 
 		bytes := make([]byte, skLens[salg]+1)
 		sk, err := DecodePrivateKey(salg, bytes)
@@ -186,6 +190,7 @@ func testEncodeDecode(t *testing.T, salg SigningAlgorithm) {
 		pkLens[ECDSAP256] = PubKeyLenECDSAP256
 		pkLens[ECDSASecp256k1] = PubKeyLenECDSASecp256k1
 		pkLens[BLSBLS12381] = PubKeyLenBLSBLS12381
+		pkLens[Ed25519] = PubKeyLenEd25519
 
 		bytes = make([]byte, pkLens[salg]+1)
 		pk, err := DecodePublicKey(salg, bytes)
@@ -23,11 +23,13 @@ const (
 	ECDSAP256
 	// ECDSASecp256k1 is ECDSA on secp256k1 curve
 	ECDSASecp256k1
+	// Ed25519 is EdDSA on edwards25519 curve
+	Ed25519
 )
 
 // String returns the string representation of this signing algorithm.
 func (f SigningAlgorithm) String() string {
-	return [...]string{"UNKNOWN", "BLS_BLS12381", "ECDSA_P256", "ECDSA_secp256k1"}[f]
+	return [...]string{"UNKNOWN", "BLS_BLS12381", "ECDSA_P256", "ECDSA_secp256k1", "Ed25519"}[f]
 }
 
 const (
@@ -80,6 +82,16 @@ const (
 	PubKeyLenECDSASecp256k1        = 64
 	KeyGenSeedMinLenECDSASecp256k1 = PrKeyLenECDSASecp256k1 + (securityBits / 8)
 
+	// Ed25519
+
+	SignatureLenEd25519 = 64
+	// PrKeyLenEd25519 is the size of the private key seed
+	PrKeyLenEd25519 = 32
+	// PubKeyLenEd25519 is the size of compressed points on edwards25519
+	PubKeyLenEd25519        = 32
+	KeyGenSeedMinLenEd25519 = 2 * (securityBits / 8)
+	KeyGenSeedMaxLenEd25519 = 2048 // large enough constant accepted by the implementation
+
 	// DKG and Threshold Signatures
 
 	// MinimumThreshold is the minimum value of the threshold parameter in all threshold-based protocols.
@@ -10,12 +10,19 @@ MOD_FILE="./go.mod"
 # the version of onflow/flow-go/crypto used in the project is read from the go.mod file
 if [ -f "${MOD_FILE}" ]
 then
-    # extract the version from the go.mod file
-    VERSION="$(grep ${PKG_NAME} < ${MOD_FILE} | cut -d' ' -f 2)"
-    # go get the package
-    go get "${PKG_NAME}@${VERSION}" || { echo "go get the package failed"; exit 1; }
-    # using the right version, get the package directory path
-    PKG_DIR="$(go env GOPATH)/pkg/mod/${PKG_NAME}@${VERSION}"
+    # the package may be replaced by a local directory in the go.mod file
+    REPLACE_DIR="$(grep "^replace ${PKG_NAME} =>" < ${MOD_FILE} | cut -d' ' -f 4)"
+    if [ -n "${REPLACE_DIR}" ]
+    then
+        PKG_DIR="${REPLACE_DIR}"
+    else
+        # extract the version from the go.mod file
+        VERSION="$(grep ${PKG_NAME} < ${MOD_FILE} | cut -d' ' -f 2)"
+        # go get the package
+        go get "${PKG_NAME}@${VERSION}" || { echo "go get the package failed"; exit 1; }
+        # using the right version, get the package directory path
+        PKG_DIR="$(go env GOPATH)/pkg/mod/${PKG_NAME}@${VERSION}"
+    fi
 else 
    { echo "couldn't find go.mod file - make sure the script is in the project root directory"; exit 1; }
 fi
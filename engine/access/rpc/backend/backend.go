@@ -247,6 +247,65 @@ func (b *Backend) GetLatestProtocolStateSnapshot(_ context.Context) ([]byte, err
 	return convert.SnapshotToBytes(validSnapshot)
 }
 
+// GetProtocolStateSnapshotByHeight returns the snapshot as of the finalized
+// block at the given height. The sealing segment of the snapshot must not span
+// an epoch or phase transition, so it can be used as a root snapshot.
+func (b *Backend) GetProtocolStateSnapshotByHeight(_ context.Context, height uint64) ([]byte, error) {
+	return b.getHistoricalSnapshot(b.state.AtHeight(height))
+}
+
+// GetProtocolStateSnapshotByBlockID returns the snapshot as of the given
+// finalized block. The sealing segment of the snapshot must not span an epoch
+// or phase transition, so it can be used as a root snapshot.
+func (b *Backend) GetProtocolStateSnapshotByBlockID(_ context.Context, blockID flow.Identifier) ([]byte, error) {
+	header, err := b.state.AtBlockID(blockID).Head()
+	if err != nil {
+		return nil, convertStorageError(err)
+	}
+
+	// only finalized blocks are indexed by height
+	finalized, err := b.state.AtHeight(header.Height).Head()
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return nil, convertStorageError(err)
+	}
+	if err != nil || finalized.ID() != blockID {
+		return nil, status.Errorf(codes.InvalidArgument, "block %v is not finalized", blockID)
+	}
+
+	return b.getHistoricalSnapshot(b.state.AtBlockID(blockID))
+}
+
+// getHistoricalSnapshot returns the given snapshot of a finalized block, if its
+// sealing segment doesn't span an epoch or phase transition. Contrary to the
+// latest snapshot, it is never replaced by the snapshot of an ancestor, as the
+// caller asked for this block.
+func (b *Backend) getHistoricalSnapshot(snapshot protocol.Snapshot) ([]byte, error) {
+	head, err := snapshot.Head()
+	if err != nil {
+		return nil, convertStorageError(err)
+	}
+
+	validSnapshot, err := b.getValidSnapshot(snapshot, 0)
+	if errors.Is(err, protocol.ErrSealingSegmentBelowRootBlock) {
+		return nil, status.Errorf(codes.InvalidArgument, "block %v is below the root block of the node", head.ID())
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "could not get snapshot: %v", err)
+	}
+
+	validHead, err := validSnapshot.Head()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "could not get snapshot head: %v", err)
+	}
+	if validHead.ID() != head.ID() {
+		return nil, status.Errorf(codes.FailedPrecondition,
+			"sealing segment of block %v spans an epoch or phase transition, the closest valid snapshot is at height %d",
+			head.ID(), validHead.Height)
+	}
+
+	return convert.SnapshotToBytes(validSnapshot)
+}
+
 // getValidSnapshot will return a valid snapshot that has a sealing segment which
 // 1. does not contain any blocks that span an epoch transition
 // 2. does not contain any blocks that span an epoch phase transition
@@ -0,0 +1,156 @@
+package backend
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hashicorp/go-multierror"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	"github.com/onflow/flow-go/engine/common/rpc/simulation"
+	simulationpb "github.com/onflow/flow-go/engine/common/rpc/simulation/simulation"
+	"github.com/onflow/flow-go/fvm/profiling"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/logging"
+)
+
+// SimulateTransaction executes the transaction on an execution node against the
+// execution state of the latest sealed block, without committing its effects.
+//
+// The transaction is not validated, so that drafts with a missing reference
+// block or missing signatures can be simulated as well.
+func (b *backendTransactions) SimulateTransaction(
+	ctx context.Context,
+	tx *flow.TransactionBody,
+	opts flow.TransactionSimulationOptions,
+) (*flow.TransactionSimulationResult, error) {
+
+	// get the latest sealed header
+	latestHeader, err := b.state.Sealed().Head()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get latest sealed header: %v", err)
+	}
+	blockID := latestHeader.ID()
+
+	req := &simulationpb.SimulateTransactionRequest{
+		BlockId:     convert.IdentifierToMessage(blockID),
+		Transaction: simulation.TransactionToMessage(*tx),
+		Options:     simulation.OptionsToMessage(opts),
+	}
+
+	execNodes, err := executionNodesForBlockID(ctx, blockID, b.executionReceipts, b.state, b.log)
+	if err != nil {
+		// if no execution receipt were found, return a NotFound GRPC error
+		if errors.As(err, &InsufficientExecutionReceipts{}) {
+			return nil, status.Errorf(codes.NotFound, err.Error())
+		}
+		return nil, status.Errorf(codes.Internal, "failed to find execution nodes at blockId %v: %v", blockID, err)
+	}
+
+	var errs *multierror.Error
+	for _, execNode := range execNodes {
+		resp, err := b.trySimulateTransaction(ctx, execNode, req)
+		if err == nil {
+			b.log.Debug().
+				Str("execution_node", execNode.String()).
+				Hex("block_id", blockID[:]).
+				Hex("transaction_id", logging.Entity(tx)).
+				Msg("successfully simulated transaction")
+			result := simulation.MessageToResult(resp.GetResult())
+			return &result, nil
+		}
+		errs = multierror.Append(errs, err)
+	}
+
+	errToReturn := errs.ErrorOrNil()
+	b.log.Error().Err(errToReturn).Msg("transaction simulation failed for execution node internal reasons")
+	return nil, errToReturn
+}
+
+func (b *backendTransactions) trySimulateTransaction(ctx context.Context, execNode *flow.Identity, req *simulationpb.SimulateTransactionRequest) (*simulationpb.SimulateTransactionResponse, error) {
+	simRPCClient, closer, err := b.connFactory.GetSimulationAPIClient(execNode.Address)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create client for execution node %s: %v", execNode.String(), err)
+	}
+	defer closer.Close()
+	resp, err := simRPCClient.SimulateTransaction(ctx, req)
+	if err != nil {
+		return nil, status.Errorf(status.Code(err), "failed to simulate the transaction on the execution node %s: %v", execNode.String(), err)
+	}
+	return resp, nil
+}
+
+// ProfileScriptAtLatestBlock executes the script on an execution node against
+// the execution state of the latest sealed block, and returns its profile.
+func (b *backendScripts) ProfileScriptAtLatestBlock(
+	ctx context.Context,
+	script []byte,
+	arguments [][]byte,
+) ([]byte, *profiling.Profile, error) {
+
+	// get the latest sealed header
+	latestHeader, err := b.state.Sealed().Head()
+	if err != nil {
+		return nil, nil, status.Errorf(codes.Internal, "failed to get latest sealed header: %v", err)
+	}
+
+	return b.ProfileScriptAtBlockID(ctx, latestHeader.ID(), script, arguments)
+}
+
+// ProfileScriptAtBlockID executes the script on an execution node against the
+// execution state of the given block, and returns its profile.
+func (b *backendScripts) ProfileScriptAtBlockID(
+	ctx context.Context,
+	blockID flow.Identifier,
+	script []byte,
+	arguments [][]byte,
+) ([]byte, *profiling.Profile, error) {
+
+	req := &simulationpb.ExecuteScriptRequest{
+		BlockId:   convert.IdentifierToMessage(blockID),
+		Script:    script,
+		Arguments: arguments,
+		Profile:   true,
+	}
+
+	execNodes, err := executionNodesForBlockID(ctx, blockID, b.executionReceipts, b.state, b.log)
+	if err != nil {
+		return nil, nil, status.Errorf(codes.Internal, "failed to find execution nodes at blockId %v: %v", blockID, err)
+	}
+
+	var errs *multierror.Error
+	for _, execNode := range execNodes {
+		resp, err := b.tryProfileScript(ctx, execNode, req)
+		if err == nil {
+			b.log.Debug().
+				Str("execution_node", execNode.String()).
+				Hex("block_id", blockID[:]).
+				Msg("successfully profiled script")
+			return resp.GetValue(), simulation.MessageToProfile(resp.GetProfile()), nil
+		}
+		// return if it's just a script failure as opposed to an EN failure and skip trying other ENs
+		if status.Code(err) == codes.InvalidArgument {
+			return nil, nil, err
+		}
+		errs = multierror.Append(errs, err)
+	}
+
+	errToReturn := errs.ErrorOrNil()
+	b.log.Error().Err(errToReturn).Msg("script profiling failed for execution node internal reasons")
+	return nil, nil, errToReturn
+}
+
+func (b *backendScripts) tryProfileScript(ctx context.Context, execNode *flow.Identity, req *simulationpb.ExecuteScriptRequest) (*simulationpb.ExecuteScriptResponse, error) {
+	simRPCClient, closer, err := b.connFactory.GetSimulationAPIClient(execNode.Address)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create client for execution node %s: %v", execNode.String(), err)
+	}
+	defer closer.Close()
+	resp, err := simRPCClient.ExecuteScript(ctx, req)
+	if err != nil {
+		return nil, status.Errorf(status.Code(err), "failed to execute the script on the execution node %s: %v", execNode.String(), err)
+	}
+	return resp, nil
+}
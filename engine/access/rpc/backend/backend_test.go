@@ -25,6 +25,9 @@ import (
 	access "github.com/onflow/flow-go/engine/access/mock"
 	backendmock "github.com/onflow/flow-go/engine/access/rpc/backend/mock"
 	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	"github.com/onflow/flow-go/engine/common/rpc/simulation"
+	simulationpb "github.com/onflow/flow-go/engine/common/rpc/simulation/simulation"
+	"github.com/onflow/flow-go/fvm/profiling"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module/metrics"
 	protocol "github.com/onflow/flow-go/state/protocol/mock"
@@ -479,6 +482,83 @@ func (suite *Suite) TestGetLatestProtocolStateSnapshot_HistoryLimit() {
 	})
 }
 
+// TestGetProtocolStateSnapshotByHeight tests our GetProtocolStateSnapshotByHeight and
+// GetProtocolStateSnapshotByBlockID RPC endpoints, which return the snapshot at the
+// requested past height, but never fall back to the snapshot of an ancestor.
+func (suite *Suite) TestGetProtocolStateSnapshotByHeight() {
+	identities := unittest.CompleteIdentitySet()
+	rootSnapshot := unittest.RootSnapshotFixture(identities)
+	util.RunWithFullProtocolState(suite.T(), rootSnapshot, func(db *badger.DB, state *bprotocol.MutableState) {
+		epochBuilder := unittest.NewEpochBuilder(suite.T(), state)
+		// build epoch 1
+		// blocks in current state
+		// P <- A(S_P-1) <- B(S_P) <- C(S_A) <- D(S_B) |setup| <- E(S_C) <- F(S_D) |commit| <- G(S_E)
+		epochBuilder.
+			BuildEpoch().
+			CompleteEpoch()
+
+		// get heights of each phase in built epochs
+		epoch1, ok := epochBuilder.EpochHeights(1)
+		require.True(suite.T(), ok)
+
+		// setup AtHeight and AtBlockID mock returns for state
+		for _, height := range epoch1.Range() {
+			snap := state.AtHeight(height)
+			suite.state.On("AtHeight", height).Return(snap)
+			head, err := snap.Head()
+			require.NoError(suite.T(), err)
+			suite.state.On("AtBlockID", head.ID()).Return(state.AtBlockID(head.ID()))
+		}
+
+		backend := New(
+			suite.state,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
+			nil,
+			suite.chainID,
+			metrics.NewNoopCollector(),
+			nil,
+			false,
+			DefaultMaxHeightRange,
+			nil,
+			nil,
+			suite.log,
+			DefaultSnapshotHistoryLimit,
+		)
+
+		// the sealing segment of block D is B <- C <- D, it spans no transition
+		snapD := state.AtHeight(epoch1.Range()[3])
+		expectedSnapshotBytes, err := convert.SnapshotToBytes(snapD)
+		suite.Require().NoError(err)
+
+		suite.Run("by height", func() {
+			bytes, err := backend.GetProtocolStateSnapshotByHeight(context.Background(), epoch1.Range()[3])
+			suite.Require().NoError(err)
+			suite.Require().Equal(expectedSnapshotBytes, bytes)
+		})
+
+		suite.Run("by block ID", func() {
+			headD, err := snapD.Head()
+			suite.Require().NoError(err)
+			bytes, err := backend.GetProtocolStateSnapshotByBlockID(context.Background(), headD.ID())
+			suite.Require().NoError(err)
+			suite.Require().Equal(expectedSnapshotBytes, bytes)
+		})
+
+		// the sealing segment of block E is C(S_A) <- D(S_B) |setup| <- E(S_C), which
+		// spans the epoch setup phase, so it can't be returned
+		suite.Run("spanning a phase transition", func() {
+			_, err := backend.GetProtocolStateSnapshotByHeight(context.Background(), epoch1.Range()[4])
+			suite.Require().Equal(codes.FailedPrecondition, status.Code(err))
+		})
+	})
+}
+
 func (suite *Suite) TestGetLatestSealedBlockHeader() {
 	// setup the mocks
 	suite.state.On("Sealed").Return(suite.snapshot, nil).Maybe()
@@ -1775,6 +1855,173 @@ func (suite *Suite) TestGetAccount() {
 	})
 }
 
+func (suite *Suite) TestSimulateTransaction() {
+	suite.state.On("Sealed").Return(suite.snapshot, nil).Maybe()
+	suite.state.On("Final").Return(suite.snapshot, nil).Maybe()
+
+	ctx := context.Background()
+
+	// setup the latest sealed block
+	block := unittest.BlockFixture()
+	header := block.Header
+
+	suite.snapshot.
+		On("Head").
+		Return(header, nil)
+
+	tx := unittest.TransactionBodyFixture()
+	opts := flow.TransactionSimulationOptions{SkipSignatureCheck: true}
+
+	// create the expected simulation request and response
+	simReq := &simulationpb.SimulateTransactionRequest{
+		BlockId:     convert.IdentifierToMessage(header.ID()),
+		Transaction: simulation.TransactionToMessage(tx),
+		Options:     simulation.OptionsToMessage(opts),
+	}
+	simResult := flow.TransactionSimulationResult{
+		BlockID:         header.ID(),
+		ComputationUsed: 42,
+		Events:          getEvents(2),
+	}
+	simResp := &simulationpb.SimulateTransactionResponse{
+		Result: simulation.ResultToMessage(simResult),
+	}
+
+	receipts, ids := suite.setupReceipts(&block)
+	suite.snapshot.On("Identities", mock.Anything).Return(ids, nil)
+
+	simClient := new(access.SimulationAPIClient)
+	connFactory := new(backendmock.ConnectionFactory)
+	connFactory.On("GetSimulationAPIClient", mock.Anything).Return(simClient, &mockCloser{}, nil)
+
+	backend := New(
+		suite.state,
+		nil,
+		nil,
+		nil,
+		suite.headers,
+		nil,
+		nil,
+		suite.receipts,
+		suite.results,
+		suite.chainID,
+		metrics.NewNoopCollector(),
+		connFactory, // the connection factory should be used to get the execution node client
+		false,
+		DefaultMaxHeightRange,
+		nil,
+		nil,
+		suite.log,
+		DefaultSnapshotHistoryLimit,
+	)
+
+	preferredENIdentifiers = flow.IdentifierList{receipts[0].ExecutorID}
+
+	suite.Run("happy path - valid request and valid response", func() {
+		simClient.On("SimulateTransaction", ctx, simReq).Return(simResp, nil).Once()
+
+		result, err := backend.SimulateTransaction(ctx, &tx, opts)
+		suite.checkResponse(result, err)
+		suite.Require().Equal(simResult, *result)
+
+		simClient.AssertExpectations(suite.T())
+	})
+
+	suite.Run("execution nodes failing returns an error", func() {
+		simClient.On("SimulateTransaction", ctx, simReq).
+			Return(nil, status.Error(codes.Internal, "execution node internal error!"))
+
+		_, err := backend.SimulateTransaction(ctx, &tx, opts)
+		suite.Require().Error(err)
+	})
+}
+
+func (suite *Suite) TestProfileScript() {
+	suite.state.On("Sealed").Return(suite.snapshot, nil).Maybe()
+	suite.state.On("Final").Return(suite.snapshot, nil).Maybe()
+
+	ctx := context.Background()
+
+	// setup the latest sealed block
+	block := unittest.BlockFixture()
+	header := block.Header
+
+	suite.snapshot.
+		On("Head").
+		Return(header, nil)
+
+	script := []byte("pub fun main() { return 1 }")
+	arguments := [][]byte{[]byte("arg1")}
+	value := []byte{4, 5, 6}
+	profile := &profiling.Profile{
+		Invocations: []profiling.Entry{{Name: "script", Calls: 1, Computation: 10}},
+	}
+
+	// create the expected request and response
+	execReq := &simulationpb.ExecuteScriptRequest{
+		BlockId:   convert.IdentifierToMessage(header.ID()),
+		Script:    script,
+		Arguments: arguments,
+		Profile:   true,
+	}
+	execResp := &simulationpb.ExecuteScriptResponse{
+		Value:   value,
+		Profile: simulation.ProfileToMessage(profile),
+	}
+
+	receipts, ids := suite.setupReceipts(&block)
+	suite.snapshot.On("Identities", mock.Anything).Return(ids, nil)
+
+	simClient := new(access.SimulationAPIClient)
+	connFactory := new(backendmock.ConnectionFactory)
+	connFactory.On("GetSimulationAPIClient", mock.Anything).Return(simClient, &mockCloser{}, nil)
+
+	backend := New(
+		suite.state,
+		nil,
+		nil,
+		nil,
+		suite.headers,
+		nil,
+		nil,
+		suite.receipts,
+		suite.results,
+		suite.chainID,
+		metrics.NewNoopCollector(),
+		connFactory, // the connection factory should be used to get the execution node client
+		false,
+		DefaultMaxHeightRange,
+		nil,
+		nil,
+		suite.log,
+		DefaultSnapshotHistoryLimit,
+	)
+
+	preferredENIdentifiers = flow.IdentifierList{receipts[0].ExecutorID}
+
+	suite.Run("happy path - valid request and valid response", func() {
+		simClient.On("ExecuteScript", ctx, execReq).Return(execResp, nil).Once()
+
+		result, resultProfile, err := backend.ProfileScriptAtLatestBlock(ctx, script, arguments)
+		suite.checkResponse(result, err)
+		suite.Require().Equal(value, result)
+		suite.Require().Equal(profile, resultProfile)
+
+		simClient.AssertExpectations(suite.T())
+	})
+
+	suite.Run("script failing on the execution node returns an error", func() {
+		simClient.On("ExecuteScript", ctx, execReq).
+			Return(nil, status.Error(codes.InvalidArgument, "script failed")).Once()
+
+		_, _, err := backend.ProfileScriptAtBlockID(ctx, header.ID(), script, arguments)
+		suite.Require().Error(err)
+		suite.Require().Equal(codes.InvalidArgument, status.Code(err))
+
+		simClient.AssertExpectations(suite.T())
+	})
+}
+
 func (suite *Suite) TestGetAccountAtBlockHeight() {
 	suite.state.On("Sealed").Return(suite.snapshot, nil).Maybe()
 	suite.state.On("Final").Return(suite.snapshot, nil).Maybe()
@@ -9,8 +9,11 @@ import (
 
 	"github.com/onflow/flow/protobuf/go/flow/access"
 	"github.com/onflow/flow/protobuf/go/flow/execution"
+	"github.com/opentracing/opentracing-go"
 	"google.golang.org/grpc"
 
+	simulationpb "github.com/onflow/flow-go/engine/common/rpc/simulation/simulation"
+	"github.com/onflow/flow-go/module/trace"
 	"github.com/onflow/flow-go/utils/grpcutils"
 )
 
@@ -21,6 +24,7 @@ const defaultClientTimeout = 3 * time.Second
 type ConnectionFactory interface {
 	GetAccessAPIClient(address string) (access.AccessAPIClient, io.Closer, error)
 	GetExecutionAPIClient(address string) (execution.ExecutionAPIClient, io.Closer, error)
+	GetSimulationAPIClient(address string) (simulationpb.SimulationAPIClient, io.Closer, error)
 }
 
 type ProxyConnectionFactory struct {
@@ -36,11 +40,16 @@ func (p *ProxyConnectionFactory) GetExecutionAPIClient(address string) (executio
 	return p.ConnectionFactory.GetExecutionAPIClient(p.targetAddress)
 }
 
+func (p *ProxyConnectionFactory) GetSimulationAPIClient(address string) (simulationpb.SimulationAPIClient, io.Closer, error) {
+	return p.ConnectionFactory.GetSimulationAPIClient(p.targetAddress)
+}
+
 type ConnectionFactoryImpl struct {
 	CollectionGRPCPort        uint
 	ExecutionGRPCPort         uint
 	CollectionNodeGRPCTimeout time.Duration
 	ExecutionNodeGRPCTimeout  time.Duration
+	Tracer                    opentracing.Tracer // optional, propagates the spans of the requests if set
 }
 
 // createConnection creates new gRPC connections to remote node
@@ -50,11 +59,16 @@ func (cf *ConnectionFactoryImpl) createConnection(address string, timeout time.D
 		timeout = defaultClientTimeout
 	}
 
-	conn, err := grpc.Dial(
-		address,
+	opts := []grpc.DialOption{
 		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(grpcutils.DefaultMaxMsgSize)),
 		grpc.WithInsecure(), //nolint:staticcheck
-		WithClientUnaryInterceptor(timeout))
+		WithClientUnaryInterceptor(timeout),
+	}
+	if cf.Tracer != nil {
+		opts = append(opts, grpc.WithChainUnaryInterceptor(trace.UnaryClientInterceptor(cf.Tracer)))
+	}
+
+	conn, err := grpc.Dial(address, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to address %s: %w", address, err)
 	}
@@ -92,6 +106,24 @@ func (cf *ConnectionFactoryImpl) GetExecutionAPIClient(address string) (executio
 	return executionAPIClient, closer, nil
 }
 
+// GetSimulationAPIClient returns a client of the simulation service of the execution node,
+// which is served on the same port as the execution API.
+func (cf *ConnectionFactoryImpl) GetSimulationAPIClient(address string) (simulationpb.SimulationAPIClient, io.Closer, error) {
+
+	grpcAddress, err := getGRPCAddress(address, cf.ExecutionGRPCPort)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	conn, err := cf.createConnection(grpcAddress, cf.ExecutionNodeGRPCTimeout)
+	if err != nil {
+		return nil, nil, err
+	}
+	simulationAPIClient := simulationpb.NewSimulationAPIClient(conn)
+	closer := io.Closer(conn)
+	return simulationAPIClient, closer, nil
+}
+
 // getExecutionNodeAddress translates flow.Identity address to the GRPC address of the node by switching the port to the
 // GRPC port from the libp2p port
 func getGRPCAddress(address string, grpcPort uint) (string, error) {
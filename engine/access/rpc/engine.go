@@ -11,6 +11,7 @@ import (
 	grpc_prometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
 	accessproto "github.com/onflow/flow/protobuf/go/flow/access"
 	legacyaccessproto "github.com/onflow/flow/protobuf/go/flow/legacy/access"
+	"github.com/opentracing/opentracing-go"
 	"github.com/rs/zerolog"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
@@ -20,8 +21,13 @@ import (
 	"github.com/onflow/flow-go/engine"
 	"github.com/onflow/flow-go/engine/access/rest"
 	"github.com/onflow/flow-go/engine/access/rpc/backend"
+	simulationpb "github.com/onflow/flow-go/engine/common/rpc/simulation/simulation"
+	"github.com/onflow/flow-go/engine/common/rpc/slashing"
+	slashingpb "github.com/onflow/flow-go/engine/common/rpc/slashing/slashing"
+	snapshotspb "github.com/onflow/flow-go/engine/common/rpc/snapshots/snapshots"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/module/trace"
 	"github.com/onflow/flow-go/state/protocol"
 	"github.com/onflow/flow-go/storage"
 	"github.com/onflow/flow-go/utils/grpcutils"
@@ -44,6 +50,7 @@ type Config struct {
 	MaxHeightRange            uint                             // max size of height range requests
 	PreferredExecutionNodeIDs []string                         // preferred list of upstream execution node IDs
 	FixedExecutionNodeIDs     []string                         // fixed list of execution node IDs to choose from if no node node ID can be chosen from the PreferredExecutionNodeIDs
+	Tracer                    opentracing.Tracer               // optional, traces the requests and propagates the spans to the execution nodes if set
 }
 
 // Engine exposes the server with a simplified version of the Access API.
@@ -76,6 +83,7 @@ func New(log zerolog.Logger,
 	transactions storage.Transactions,
 	executionReceipts storage.ExecutionReceipts,
 	executionResults storage.ExecutionResults,
+	slashingEvidence storage.SlashingEvidence, // optional, the slashing evidence API is only served if set, with the evidence of double proposals
 	chainID flow.ChainID,
 	transactionMetrics module.TransactionMetrics,
 	collectionGRPCPort uint,
@@ -99,7 +107,12 @@ func New(log zerolog.Logger,
 	}
 
 	var interceptors []grpc.UnaryServerInterceptor // ordered list of interceptors
-	// if rpc metrics is enabled, first create the grpc metrics interceptor
+	// if tracing is enabled, first start the span of the request, so it covers all other interceptors
+	if config.Tracer != nil {
+		interceptors = append(interceptors, trace.UnaryServerInterceptor(config.Tracer))
+	}
+
+	// if rpc metrics is enabled, create the grpc metrics interceptor
 	if rpcMetricsEnabled {
 		interceptors = append(interceptors, grpc_prometheus.UnaryServerInterceptor)
 	}
@@ -133,6 +146,7 @@ func New(log zerolog.Logger,
 		ExecutionGRPCPort:         executionGRPCPort,
 		CollectionNodeGRPCTimeout: config.CollectionClientTimeout,
 		ExecutionNodeGRPCTimeout:  config.ExecutionClientTimeout,
+		Tracer:                    config.Tracer,
 	}
 
 	backend := backend.New(state,
@@ -176,6 +190,42 @@ func New(log zerolog.Logger,
 		access.NewHandler(backend, chainID.Chain()),
 	)
 
+	simulationpb.RegisterSimulationAPIServer(
+		eng.unsecureGrpcServer,
+		access.NewSimulationHandler(backend),
+	)
+
+	simulationpb.RegisterSimulationAPIServer(
+		eng.secureGrpcServer,
+		access.NewSimulationHandler(backend),
+	)
+
+	snapshotspb.RegisterSnapshotAPIServer(
+		eng.unsecureGrpcServer,
+		access.NewSnapshotHandler(backend),
+	)
+
+	snapshotspb.RegisterSnapshotAPIServer(
+		eng.secureGrpcServer,
+		access.NewSnapshotHandler(backend),
+	)
+
+	// access nodes only receive the proposals, not the votes, so the slashing evidence
+	// API only serves the evidence of double proposals. The evidence of all violations
+	// is persisted by the consensus nodes, and read with their read-slashing-evidence
+	// admin command.
+	if slashingEvidence != nil {
+		slashingpb.RegisterSlashingEvidenceAPIServer(
+			eng.unsecureGrpcServer,
+			slashing.NewHandler(slashingEvidence),
+		)
+
+		slashingpb.RegisterSlashingEvidenceAPIServer(
+			eng.secureGrpcServer,
+			slashing.NewHandler(slashingEvidence),
+		)
+	}
+
 	if rpcMetricsEnabled {
 		// Not interested in legacy metrics, so initialize here
 		grpc_prometheus.EnableHandlingTimeHistogram()
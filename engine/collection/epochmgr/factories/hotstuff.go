@@ -83,7 +83,7 @@ func (f *HotStuffFactory) CreateModules(
 	committee = committees.NewMetricsWrapper(committee, metrics) // wrapper for measuring time spent determining consensus committee relations
 
 	// create a signing provider
-	var signer hotstuff.Signer = verification.NewStakingSigner(f.me)
+	var signer hotstuff.Signer = verification.NewStakingSigner(f.me, cluster.ChainID())
 	signer = verification.NewMetricsWrapper(signer, metrics) // wrapper for measuring time spent with crypto-related operations
 
 	finalizedBlock, err := clusterState.Final().Head()
@@ -0,0 +1,531 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.27.1
+// 	protoc        v3.17.1
+// source: checkpoints/checkpoints.proto
+
+package checkpoints
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// GetCheckpointPartitionRequest requests the partition of the execution state trie at a sealed block
+type GetCheckpointPartitionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BlockId []byte `protobuf:"bytes,1,opt,name=blockId,proto3" json:"blockId,omitempty"` // The sealed block, the finalized block at the height is used if not set
+	Height  uint64 `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`  // The height of the sealed block, ignored if the block ID is set
+	Depth   uint32 `protobuf:"varint,3,opt,name=depth,proto3" json:"depth,omitempty"`    // The depth the trie is partitioned at
+}
+
+func (x *GetCheckpointPartitionRequest) Reset() {
+	*x = GetCheckpointPartitionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_checkpoints_checkpoints_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetCheckpointPartitionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCheckpointPartitionRequest) ProtoMessage() {}
+
+func (x *GetCheckpointPartitionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_checkpoints_checkpoints_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCheckpointPartitionRequest.ProtoReflect.Descriptor instead.
+func (*GetCheckpointPartitionRequest) Descriptor() ([]byte, []int) {
+	return file_checkpoints_checkpoints_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetCheckpointPartitionRequest) GetBlockId() []byte {
+	if x != nil {
+		return x.BlockId
+	}
+	return nil
+}
+
+func (x *GetCheckpointPartitionRequest) GetHeight() uint64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *GetCheckpointPartitionRequest) GetDepth() uint32 {
+	if x != nil {
+		return x.Depth
+	}
+	return 0
+}
+
+// GetCheckpointPartitionResponse contains the partition of the execution state trie at a sealed block
+type GetCheckpointPartitionResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BlockId         []byte     `protobuf:"bytes,1,opt,name=blockId,proto3" json:"blockId,omitempty"`                 // The sealed block
+	Height          uint64     `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`                  // The height of the sealed block
+	StateCommitment []byte     `protobuf:"bytes,3,opt,name=stateCommitment,proto3" json:"stateCommitment,omitempty"` // The state commitment of the sealed block
+	Subtries        []*Subtrie `protobuf:"bytes,4,rep,name=subtries,proto3" json:"subtries,omitempty"`               // The descriptions of the subtries of the partition, ordered by path
+}
+
+func (x *GetCheckpointPartitionResponse) Reset() {
+	*x = GetCheckpointPartitionResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_checkpoints_checkpoints_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetCheckpointPartitionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCheckpointPartitionResponse) ProtoMessage() {}
+
+func (x *GetCheckpointPartitionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_checkpoints_checkpoints_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCheckpointPartitionResponse.ProtoReflect.Descriptor instead.
+func (*GetCheckpointPartitionResponse) Descriptor() ([]byte, []int) {
+	return file_checkpoints_checkpoints_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetCheckpointPartitionResponse) GetBlockId() []byte {
+	if x != nil {
+		return x.BlockId
+	}
+	return nil
+}
+
+func (x *GetCheckpointPartitionResponse) GetHeight() uint64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *GetCheckpointPartitionResponse) GetStateCommitment() []byte {
+	if x != nil {
+		return x.StateCommitment
+	}
+	return nil
+}
+
+func (x *GetCheckpointPartitionResponse) GetSubtries() []*Subtrie {
+	if x != nil {
+		return x.Subtries
+	}
+	return nil
+}
+
+// Subtrie describes a subtrie of a partition of the execution state trie
+type Subtrie struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Path   []byte `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`      // The path of the root of the subtrie
+	Height uint32 `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"` // The height of the root of the subtrie
+	Hash   []byte `protobuf:"bytes,3,opt,name=hash,proto3" json:"hash,omitempty"`      // The hash of the root of the subtrie
+}
+
+func (x *Subtrie) Reset() {
+	*x = Subtrie{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_checkpoints_checkpoints_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Subtrie) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Subtrie) ProtoMessage() {}
+
+func (x *Subtrie) ProtoReflect() protoreflect.Message {
+	mi := &file_checkpoints_checkpoints_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Subtrie.ProtoReflect.Descriptor instead.
+func (*Subtrie) Descriptor() ([]byte, []int) {
+	return file_checkpoints_checkpoints_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Subtrie) GetPath() []byte {
+	if x != nil {
+		return x.Path
+	}
+	return nil
+}
+
+func (x *Subtrie) GetHeight() uint32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *Subtrie) GetHash() []byte {
+	if x != nil {
+		return x.Hash
+	}
+	return nil
+}
+
+// GetCheckpointSubtriesRequest requests the subtries of the partition of the execution state trie at a sealed block
+type GetCheckpointSubtriesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BlockId    []byte `protobuf:"bytes,1,opt,name=blockId,proto3" json:"blockId,omitempty"`        // The sealed block
+	Depth      uint32 `protobuf:"varint,2,opt,name=depth,proto3" json:"depth,omitempty"`           // The depth the trie is partitioned at
+	StartIndex uint32 `protobuf:"varint,3,opt,name=startIndex,proto3" json:"startIndex,omitempty"` // The index of the first subtrie to stream, so that an interrupted transfer can be resumed
+}
+
+func (x *GetCheckpointSubtriesRequest) Reset() {
+	*x = GetCheckpointSubtriesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_checkpoints_checkpoints_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetCheckpointSubtriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCheckpointSubtriesRequest) ProtoMessage() {}
+
+func (x *GetCheckpointSubtriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_checkpoints_checkpoints_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCheckpointSubtriesRequest.ProtoReflect.Descriptor instead.
+func (*GetCheckpointSubtriesRequest) Descriptor() ([]byte, []int) {
+	return file_checkpoints_checkpoints_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetCheckpointSubtriesRequest) GetBlockId() []byte {
+	if x != nil {
+		return x.BlockId
+	}
+	return nil
+}
+
+func (x *GetCheckpointSubtriesRequest) GetDepth() uint32 {
+	if x != nil {
+		return x.Depth
+	}
+	return 0
+}
+
+func (x *GetCheckpointSubtriesRequest) GetStartIndex() uint32 {
+	if x != nil {
+		return x.StartIndex
+	}
+	return 0
+}
+
+// SubtrieChunk is a chunk of an encoded subtrie, as their encoding can exceed the maximum message size
+type SubtrieChunk struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Index uint32 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"` // The index of the subtrie
+	Data  []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`    // The chunk of the encoded subtrie
+	Last  bool   `protobuf:"varint,3,opt,name=last,proto3" json:"last,omitempty"`   // Whether this is the last chunk of the subtrie
+}
+
+func (x *SubtrieChunk) Reset() {
+	*x = SubtrieChunk{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_checkpoints_checkpoints_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubtrieChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubtrieChunk) ProtoMessage() {}
+
+func (x *SubtrieChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_checkpoints_checkpoints_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubtrieChunk.ProtoReflect.Descriptor instead.
+func (*SubtrieChunk) Descriptor() ([]byte, []int) {
+	return file_checkpoints_checkpoints_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SubtrieChunk) GetIndex() uint32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *SubtrieChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *SubtrieChunk) GetLast() bool {
+	if x != nil {
+		return x.Last
+	}
+	return false
+}
+
+var File_checkpoints_checkpoints_proto protoreflect.FileDescriptor
+
+var file_checkpoints_checkpoints_proto_rawDesc = []byte{
+	0x0a, 0x1d, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x2f, 0x63, 0x68,
+	0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
+	0x0b, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x22, 0x67, 0x0a, 0x1d,
+	0x47, 0x65, 0x74, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x50, 0x61, 0x72,
+	0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a,
+	0x07, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07,
+	0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12,
+	0x14, 0x0a, 0x05, 0x64, 0x65, 0x70, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05,
+	0x64, 0x65, 0x70, 0x74, 0x68, 0x22, 0xae, 0x01, 0x0a, 0x1e, 0x47, 0x65, 0x74, 0x43, 0x68, 0x65,
+	0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x50, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x62, 0x6c, 0x6f, 0x63,
+	0x6b, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x62, 0x6c, 0x6f, 0x63, 0x6b,
+	0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x28, 0x0a, 0x0f, 0x73, 0x74,
+	0x61, 0x74, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x0f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74,
+	0x6d, 0x65, 0x6e, 0x74, 0x12, 0x30, 0x0a, 0x08, 0x73, 0x75, 0x62, 0x74, 0x72, 0x69, 0x65, 0x73,
+	0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f,
+	0x69, 0x6e, 0x74, 0x73, 0x2e, 0x53, 0x75, 0x62, 0x74, 0x72, 0x69, 0x65, 0x52, 0x08, 0x73, 0x75,
+	0x62, 0x74, 0x72, 0x69, 0x65, 0x73, 0x22, 0x49, 0x0a, 0x07, 0x53, 0x75, 0x62, 0x74, 0x72, 0x69,
+	0x65, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x12, 0x0a,
+	0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x68, 0x61, 0x73,
+	0x68, 0x22, 0x6e, 0x0a, 0x1c, 0x47, 0x65, 0x74, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69,
+	0x6e, 0x74, 0x53, 0x75, 0x62, 0x74, 0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x18, 0x0a, 0x07, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x07, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x64,
+	0x65, 0x70, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x64, 0x65, 0x70, 0x74,
+	0x68, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x49, 0x6e, 0x64, 0x65,
+	0x78, 0x22, 0x4c, 0x0a, 0x0c, 0x53, 0x75, 0x62, 0x74, 0x72, 0x69, 0x65, 0x43, 0x68, 0x75, 0x6e,
+	0x6b, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x12, 0x0a, 0x04, 0x6c,
+	0x61, 0x73, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x6c, 0x61, 0x73, 0x74, 0x32,
+	0xe3, 0x01, 0x0a, 0x0d, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x41, 0x50,
+	0x49, 0x12, 0x71, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69,
+	0x6e, 0x74, 0x50, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2a, 0x2e, 0x63, 0x68,
+	0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x68, 0x65,
+	0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x50, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2b, 0x2e, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x70,
+	0x6f, 0x69, 0x6e, 0x74, 0x73, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f,
+	0x69, 0x6e, 0x74, 0x50, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5f, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x43, 0x68, 0x65, 0x63, 0x6b,
+	0x70, 0x6f, 0x69, 0x6e, 0x74, 0x53, 0x75, 0x62, 0x74, 0x72, 0x69, 0x65, 0x73, 0x12, 0x29, 0x2e,
+	0x63, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x2e, 0x47, 0x65, 0x74, 0x43,
+	0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x53, 0x75, 0x62, 0x74, 0x72, 0x69, 0x65,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x63, 0x68, 0x65, 0x63, 0x6b,
+	0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x2e, 0x53, 0x75, 0x62, 0x74, 0x72, 0x69, 0x65, 0x43, 0x68,
+	0x75, 0x6e, 0x6b, 0x30, 0x01, 0x42, 0x45, 0x5a, 0x43, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
+	0x63, 0x6f, 0x6d, 0x2f, 0x6f, 0x6e, 0x66, 0x6c, 0x6f, 0x77, 0x2f, 0x66, 0x6c, 0x6f, 0x77, 0x2d,
+	0x67, 0x6f, 0x2f, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e,
+	0x2f, 0x72, 0x70, 0x63, 0x2f, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73,
+	0x2f, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_checkpoints_checkpoints_proto_rawDescOnce sync.Once
+	file_checkpoints_checkpoints_proto_rawDescData = file_checkpoints_checkpoints_proto_rawDesc
+)
+
+func file_checkpoints_checkpoints_proto_rawDescGZIP() []byte {
+	file_checkpoints_checkpoints_proto_rawDescOnce.Do(func() {
+		file_checkpoints_checkpoints_proto_rawDescData = protoimpl.X.CompressGZIP(file_checkpoints_checkpoints_proto_rawDescData)
+	})
+	return file_checkpoints_checkpoints_proto_rawDescData
+}
+
+var file_checkpoints_checkpoints_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_checkpoints_checkpoints_proto_goTypes = []interface{}{
+	(*GetCheckpointPartitionRequest)(nil),  // 0: checkpoints.GetCheckpointPartitionRequest
+	(*GetCheckpointPartitionResponse)(nil), // 1: checkpoints.GetCheckpointPartitionResponse
+	(*Subtrie)(nil),                        // 2: checkpoints.Subtrie
+	(*GetCheckpointSubtriesRequest)(nil),   // 3: checkpoints.GetCheckpointSubtriesRequest
+	(*SubtrieChunk)(nil),                   // 4: checkpoints.SubtrieChunk
+}
+var file_checkpoints_checkpoints_proto_depIdxs = []int32{
+	2, // 0: checkpoints.GetCheckpointPartitionResponse.subtries:type_name -> checkpoints.Subtrie
+	0, // 1: checkpoints.CheckpointAPI.GetCheckpointPartition:input_type -> checkpoints.GetCheckpointPartitionRequest
+	3, // 2: checkpoints.CheckpointAPI.GetCheckpointSubtries:input_type -> checkpoints.GetCheckpointSubtriesRequest
+	1, // 3: checkpoints.CheckpointAPI.GetCheckpointPartition:output_type -> checkpoints.GetCheckpointPartitionResponse
+	4, // 4: checkpoints.CheckpointAPI.GetCheckpointSubtries:output_type -> checkpoints.SubtrieChunk
+	3, // [3:5] is the sub-list for method output_type
+	1, // [1:3] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_checkpoints_checkpoints_proto_init() }
+func file_checkpoints_checkpoints_proto_init() {
+	if File_checkpoints_checkpoints_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_checkpoints_checkpoints_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetCheckpointPartitionRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_checkpoints_checkpoints_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetCheckpointPartitionResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_checkpoints_checkpoints_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Subtrie); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_checkpoints_checkpoints_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetCheckpointSubtriesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_checkpoints_checkpoints_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubtrieChunk); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_checkpoints_checkpoints_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_checkpoints_checkpoints_proto_goTypes,
+		DependencyIndexes: file_checkpoints_checkpoints_proto_depIdxs,
+		MessageInfos:      file_checkpoints_checkpoints_proto_msgTypes,
+	}.Build()
+	File_checkpoints_checkpoints_proto = out.File
+	file_checkpoints_checkpoints_proto_rawDesc = nil
+	file_checkpoints_checkpoints_proto_goTypes = nil
+	file_checkpoints_checkpoints_proto_depIdxs = nil
+}
@@ -0,0 +1,177 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.17.1
+// source: checkpoints/checkpoints.proto
+
+package checkpoints
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// CheckpointAPIClient is the client API for CheckpointAPI service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type CheckpointAPIClient interface {
+	// GetCheckpointPartition returns the descriptions of the subtries of the
+	// execution state trie at a sealed block.
+	GetCheckpointPartition(ctx context.Context, in *GetCheckpointPartitionRequest, opts ...grpc.CallOption) (*GetCheckpointPartitionResponse, error)
+	// GetCheckpointSubtries streams the subtries of the execution state trie at
+	// a sealed block, split into chunks.
+	GetCheckpointSubtries(ctx context.Context, in *GetCheckpointSubtriesRequest, opts ...grpc.CallOption) (CheckpointAPI_GetCheckpointSubtriesClient, error)
+}
+
+type checkpointAPIClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCheckpointAPIClient(cc grpc.ClientConnInterface) CheckpointAPIClient {
+	return &checkpointAPIClient{cc}
+}
+
+func (c *checkpointAPIClient) GetCheckpointPartition(ctx context.Context, in *GetCheckpointPartitionRequest, opts ...grpc.CallOption) (*GetCheckpointPartitionResponse, error) {
+	out := new(GetCheckpointPartitionResponse)
+	err := c.cc.Invoke(ctx, "/checkpoints.CheckpointAPI/GetCheckpointPartition", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *checkpointAPIClient) GetCheckpointSubtries(ctx context.Context, in *GetCheckpointSubtriesRequest, opts ...grpc.CallOption) (CheckpointAPI_GetCheckpointSubtriesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &CheckpointAPI_ServiceDesc.Streams[0], "/checkpoints.CheckpointAPI/GetCheckpointSubtries", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &checkpointAPIGetCheckpointSubtriesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type CheckpointAPI_GetCheckpointSubtriesClient interface {
+	Recv() (*SubtrieChunk, error)
+	grpc.ClientStream
+}
+
+type checkpointAPIGetCheckpointSubtriesClient struct {
+	grpc.ClientStream
+}
+
+func (x *checkpointAPIGetCheckpointSubtriesClient) Recv() (*SubtrieChunk, error) {
+	m := new(SubtrieChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// CheckpointAPIServer is the server API for CheckpointAPI service.
+// All implementations must embed UnimplementedCheckpointAPIServer
+// for forward compatibility
+type CheckpointAPIServer interface {
+	// GetCheckpointPartition returns the descriptions of the subtries of the
+	// execution state trie at a sealed block.
+	GetCheckpointPartition(context.Context, *GetCheckpointPartitionRequest) (*GetCheckpointPartitionResponse, error)
+	// GetCheckpointSubtries streams the subtries of the execution state trie at
+	// a sealed block, split into chunks.
+	GetCheckpointSubtries(*GetCheckpointSubtriesRequest, CheckpointAPI_GetCheckpointSubtriesServer) error
+	mustEmbedUnimplementedCheckpointAPIServer()
+}
+
+// UnimplementedCheckpointAPIServer must be embedded to have forward compatible implementations.
+type UnimplementedCheckpointAPIServer struct {
+}
+
+func (UnimplementedCheckpointAPIServer) GetCheckpointPartition(context.Context, *GetCheckpointPartitionRequest) (*GetCheckpointPartitionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCheckpointPartition not implemented")
+}
+func (UnimplementedCheckpointAPIServer) GetCheckpointSubtries(*GetCheckpointSubtriesRequest, CheckpointAPI_GetCheckpointSubtriesServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetCheckpointSubtries not implemented")
+}
+func (UnimplementedCheckpointAPIServer) mustEmbedUnimplementedCheckpointAPIServer() {}
+
+// UnsafeCheckpointAPIServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CheckpointAPIServer will
+// result in compilation errors.
+type UnsafeCheckpointAPIServer interface {
+	mustEmbedUnimplementedCheckpointAPIServer()
+}
+
+func RegisterCheckpointAPIServer(s grpc.ServiceRegistrar, srv CheckpointAPIServer) {
+	s.RegisterService(&CheckpointAPI_ServiceDesc, srv)
+}
+
+func _CheckpointAPI_GetCheckpointPartition_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCheckpointPartitionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CheckpointAPIServer).GetCheckpointPartition(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/checkpoints.CheckpointAPI/GetCheckpointPartition",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CheckpointAPIServer).GetCheckpointPartition(ctx, req.(*GetCheckpointPartitionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CheckpointAPI_GetCheckpointSubtries_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetCheckpointSubtriesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CheckpointAPIServer).GetCheckpointSubtries(m, &checkpointAPIGetCheckpointSubtriesServer{stream})
+}
+
+type CheckpointAPI_GetCheckpointSubtriesServer interface {
+	Send(*SubtrieChunk) error
+	grpc.ServerStream
+}
+
+type checkpointAPIGetCheckpointSubtriesServer struct {
+	grpc.ServerStream
+}
+
+func (x *checkpointAPIGetCheckpointSubtriesServer) Send(m *SubtrieChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// CheckpointAPI_ServiceDesc is the grpc.ServiceDesc for CheckpointAPI service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CheckpointAPI_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "checkpoints.CheckpointAPI",
+	HandlerType: (*CheckpointAPIServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetCheckpointPartition",
+			Handler:    _CheckpointAPI_GetCheckpointPartition_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "GetCheckpointSubtries",
+			Handler:       _CheckpointAPI_GetCheckpointSubtries_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "checkpoints/checkpoints.proto",
+}
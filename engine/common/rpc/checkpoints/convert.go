@@ -0,0 +1,45 @@
+// Package checkpoints converts the messages of the gRPC service used by execution
+// nodes to transfer the execution state at a sealed block to other execution nodes,
+// so that they can be bootstrapped without a checkpoint copied out of band. The
+// service is defined in checkpoints/checkpoints.proto.
+//
+// The execution state trie is transferred as a partition of subtries: the
+// descriptions of all subtries are requested first, and verified against the
+// state commitment the block was sealed with, then the subtries are streamed
+// and every subtrie is verified against its description.
+package checkpoints
+
+import (
+	"fmt"
+
+	pb "github.com/onflow/flow-go/engine/common/rpc/checkpoints/checkpoints"
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/hash"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/partition"
+)
+
+// SubtrieToMessage converts a partition.Subtrie to its message representation.
+func SubtrieToMessage(subtrie partition.Subtrie) *pb.Subtrie {
+	return &pb.Subtrie{
+		Path:   subtrie.Path[:],
+		Height: uint32(subtrie.Height),
+		Hash:   subtrie.Hash[:],
+	}
+}
+
+// MessageToSubtrie converts the message representation of a subtrie to a partition.Subtrie.
+func MessageToSubtrie(m *pb.Subtrie) (partition.Subtrie, error) {
+	path, err := ledger.ToPath(m.GetPath())
+	if err != nil {
+		return partition.Subtrie{}, fmt.Errorf("invalid path: %w", err)
+	}
+	h, err := hash.ToHash(m.GetHash())
+	if err != nil {
+		return partition.Subtrie{}, fmt.Errorf("invalid hash: %w", err)
+	}
+	return partition.Subtrie{
+		Path:   path,
+		Height: int(m.GetHeight()),
+		Hash:   h,
+	}, nil
+}
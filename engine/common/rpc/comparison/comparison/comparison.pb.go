@@ -0,0 +1,244 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.27.1
+// 	protoc        v3.17.1
+// source: comparison/comparison.proto
+
+package comparison
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// GetBlockExecutionRequest requests the execution of a block
+type GetBlockExecutionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BlockId []byte `protobuf:"bytes,1,opt,name=blockId,proto3" json:"blockId,omitempty"` // The executed block
+}
+
+func (x *GetBlockExecutionRequest) Reset() {
+	*x = GetBlockExecutionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_comparison_comparison_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetBlockExecutionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBlockExecutionRequest) ProtoMessage() {}
+
+func (x *GetBlockExecutionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_comparison_comparison_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBlockExecutionRequest.ProtoReflect.Descriptor instead.
+func (*GetBlockExecutionRequest) Descriptor() ([]byte, []int) {
+	return file_comparison_comparison_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetBlockExecutionRequest) GetBlockId() []byte {
+	if x != nil {
+		return x.BlockId
+	}
+	return nil
+}
+
+// GetBlockExecutionResponse contains the execution of a block. The execution
+// result, the chunk data packs and the execution state delta are encoded with
+// CBOR, the encoding used for them on the Flow network.
+type GetBlockExecutionResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Result         []byte   `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`                 // The execution result
+	ChunkDataPacks [][]byte `protobuf:"bytes,2,rep,name=chunkDataPacks,proto3" json:"chunkDataPacks,omitempty"` // The chunk data packs of the chunks of the result, in the order of the chunks, empty if pruned
+	StateDelta     []byte   `protobuf:"bytes,3,opt,name=stateDelta,proto3" json:"stateDelta,omitempty"`         // The execution state delta
+}
+
+func (x *GetBlockExecutionResponse) Reset() {
+	*x = GetBlockExecutionResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_comparison_comparison_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetBlockExecutionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBlockExecutionResponse) ProtoMessage() {}
+
+func (x *GetBlockExecutionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_comparison_comparison_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBlockExecutionResponse.ProtoReflect.Descriptor instead.
+func (*GetBlockExecutionResponse) Descriptor() ([]byte, []int) {
+	return file_comparison_comparison_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetBlockExecutionResponse) GetResult() []byte {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *GetBlockExecutionResponse) GetChunkDataPacks() [][]byte {
+	if x != nil {
+		return x.ChunkDataPacks
+	}
+	return nil
+}
+
+func (x *GetBlockExecutionResponse) GetStateDelta() []byte {
+	if x != nil {
+		return x.StateDelta
+	}
+	return nil
+}
+
+var File_comparison_comparison_proto protoreflect.FileDescriptor
+
+var file_comparison_comparison_proto_rawDesc = []byte{
+	0x0a, 0x1b, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x69, 0x73, 0x6f, 0x6e, 0x2f, 0x63, 0x6f, 0x6d,
+	0x70, 0x61, 0x72, 0x69, 0x73, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0a, 0x63,
+	0x6f, 0x6d, 0x70, 0x61, 0x72, 0x69, 0x73, 0x6f, 0x6e, 0x22, 0x34, 0x0a, 0x18, 0x47, 0x65, 0x74,
+	0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x64, 0x22,
+	0x7b, 0x0a, 0x19, 0x47, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x45, 0x78, 0x65, 0x63, 0x75,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06,
+	0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x72, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x12, 0x26, 0x0a, 0x0e, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x44, 0x61, 0x74,
+	0x61, 0x50, 0x61, 0x63, 0x6b, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x0e, 0x63, 0x68,
+	0x75, 0x6e, 0x6b, 0x44, 0x61, 0x74, 0x61, 0x50, 0x61, 0x63, 0x6b, 0x73, 0x12, 0x1e, 0x0a, 0x0a,
+	0x73, 0x74, 0x61, 0x74, 0x65, 0x44, 0x65, 0x6c, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x0a, 0x73, 0x74, 0x61, 0x74, 0x65, 0x44, 0x65, 0x6c, 0x74, 0x61, 0x32, 0x71, 0x0a, 0x0d,
+	0x43, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x69, 0x73, 0x6f, 0x6e, 0x41, 0x50, 0x49, 0x12, 0x60, 0x0a,
+	0x11, 0x47, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x24, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x69, 0x73, 0x6f, 0x6e, 0x2e,
+	0x47, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x61,
+	0x72, 0x69, 0x73, 0x6f, 0x6e, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x45, 0x78,
+	0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42,
+	0x43, 0x5a, 0x41, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6f, 0x6e,
+	0x66, 0x6c, 0x6f, 0x77, 0x2f, 0x66, 0x6c, 0x6f, 0x77, 0x2d, 0x67, 0x6f, 0x2f, 0x65, 0x6e, 0x67,
+	0x69, 0x6e, 0x65, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2f, 0x72, 0x70, 0x63, 0x2f, 0x63,
+	0x6f, 0x6d, 0x70, 0x61, 0x72, 0x69, 0x73, 0x6f, 0x6e, 0x2f, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x72,
+	0x69, 0x73, 0x6f, 0x6e, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_comparison_comparison_proto_rawDescOnce sync.Once
+	file_comparison_comparison_proto_rawDescData = file_comparison_comparison_proto_rawDesc
+)
+
+func file_comparison_comparison_proto_rawDescGZIP() []byte {
+	file_comparison_comparison_proto_rawDescOnce.Do(func() {
+		file_comparison_comparison_proto_rawDescData = protoimpl.X.CompressGZIP(file_comparison_comparison_proto_rawDescData)
+	})
+	return file_comparison_comparison_proto_rawDescData
+}
+
+var file_comparison_comparison_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_comparison_comparison_proto_goTypes = []interface{}{
+	(*GetBlockExecutionRequest)(nil),  // 0: comparison.GetBlockExecutionRequest
+	(*GetBlockExecutionResponse)(nil), // 1: comparison.GetBlockExecutionResponse
+}
+var file_comparison_comparison_proto_depIdxs = []int32{
+	0, // 0: comparison.ComparisonAPI.GetBlockExecution:input_type -> comparison.GetBlockExecutionRequest
+	1, // 1: comparison.ComparisonAPI.GetBlockExecution:output_type -> comparison.GetBlockExecutionResponse
+	1, // [1:2] is the sub-list for method output_type
+	0, // [0:1] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_comparison_comparison_proto_init() }
+func file_comparison_comparison_proto_init() {
+	if File_comparison_comparison_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_comparison_comparison_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetBlockExecutionRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_comparison_comparison_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetBlockExecutionResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_comparison_comparison_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_comparison_comparison_proto_goTypes,
+		DependencyIndexes: file_comparison_comparison_proto_depIdxs,
+		MessageInfos:      file_comparison_comparison_proto_msgTypes,
+	}.Build()
+	File_comparison_comparison_proto = out.File
+	file_comparison_comparison_proto_rawDesc = nil
+	file_comparison_comparison_proto_goTypes = nil
+	file_comparison_comparison_proto_depIdxs = nil
+}
@@ -0,0 +1,109 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.17.1
+// source: comparison/comparison.proto
+
+package comparison
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// ComparisonAPIClient is the client API for ComparisonAPI service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ComparisonAPIClient interface {
+	// GetBlockExecution returns everything the execution node stored about the
+	// execution of a block.
+	GetBlockExecution(ctx context.Context, in *GetBlockExecutionRequest, opts ...grpc.CallOption) (*GetBlockExecutionResponse, error)
+}
+
+type comparisonAPIClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewComparisonAPIClient(cc grpc.ClientConnInterface) ComparisonAPIClient {
+	return &comparisonAPIClient{cc}
+}
+
+func (c *comparisonAPIClient) GetBlockExecution(ctx context.Context, in *GetBlockExecutionRequest, opts ...grpc.CallOption) (*GetBlockExecutionResponse, error) {
+	out := new(GetBlockExecutionResponse)
+	err := c.cc.Invoke(ctx, "/comparison.ComparisonAPI/GetBlockExecution", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ComparisonAPIServer is the server API for ComparisonAPI service.
+// All implementations must embed UnimplementedComparisonAPIServer
+// for forward compatibility
+type ComparisonAPIServer interface {
+	// GetBlockExecution returns everything the execution node stored about the
+	// execution of a block.
+	GetBlockExecution(context.Context, *GetBlockExecutionRequest) (*GetBlockExecutionResponse, error)
+	mustEmbedUnimplementedComparisonAPIServer()
+}
+
+// UnimplementedComparisonAPIServer must be embedded to have forward compatible implementations.
+type UnimplementedComparisonAPIServer struct {
+}
+
+func (UnimplementedComparisonAPIServer) GetBlockExecution(context.Context, *GetBlockExecutionRequest) (*GetBlockExecutionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlockExecution not implemented")
+}
+func (UnimplementedComparisonAPIServer) mustEmbedUnimplementedComparisonAPIServer() {}
+
+// UnsafeComparisonAPIServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ComparisonAPIServer will
+// result in compilation errors.
+type UnsafeComparisonAPIServer interface {
+	mustEmbedUnimplementedComparisonAPIServer()
+}
+
+func RegisterComparisonAPIServer(s grpc.ServiceRegistrar, srv ComparisonAPIServer) {
+	s.RegisterService(&ComparisonAPI_ServiceDesc, srv)
+}
+
+func _ComparisonAPI_GetBlockExecution_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBlockExecutionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ComparisonAPIServer).GetBlockExecution(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/comparison.ComparisonAPI/GetBlockExecution",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ComparisonAPIServer).GetBlockExecution(ctx, req.(*GetBlockExecutionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ComparisonAPI_ServiceDesc is the grpc.ServiceDesc for ComparisonAPI service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ComparisonAPI_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "comparison.ComparisonAPI",
+	HandlerType: (*ComparisonAPIServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetBlockExecution",
+			Handler:    _ComparisonAPI_GetBlockExecution_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "comparison/comparison.proto",
+}
@@ -0,0 +1,82 @@
+// Package comparison converts the messages of the gRPC service used to compare the
+// executions of a block by different execution nodes. The service serves everything
+// an execution node stored about the execution of a block: the execution result, the
+// chunk data packs and the execution state delta, so that the first chunk,
+// transaction, event or register value which differs between two nodes can be found.
+// It is defined in comparison/comparison.proto.
+package comparison
+
+import (
+	"fmt"
+
+	"github.com/onflow/flow-go/model/encoding/cbor"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/messages"
+)
+
+var marshaler = cbor.NewMarshaler()
+
+// ExecutionResultToMessage encodes an execution result for a GetBlockExecutionResponse.
+func ExecutionResultToMessage(result *flow.ExecutionResult) ([]byte, error) {
+	return marshaler.Marshal(result)
+}
+
+// MessageToExecutionResult decodes the execution result of a GetBlockExecutionResponse.
+func MessageToExecutionResult(m []byte) (*flow.ExecutionResult, error) {
+	var result flow.ExecutionResult
+	err := marshaler.Unmarshal(m, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ChunkDataPacksToMessages encodes chunk data packs for a GetBlockExecutionResponse.
+// Chunk data packs which were pruned are nil, and encoded as empty messages.
+func ChunkDataPacksToMessages(chunkDataPacks []*flow.ChunkDataPack) ([][]byte, error) {
+	encoded := make([][]byte, len(chunkDataPacks))
+	for i, chunkDataPack := range chunkDataPacks {
+		if chunkDataPack == nil {
+			continue
+		}
+		m, err := marshaler.Marshal(chunkDataPack)
+		if err != nil {
+			return nil, fmt.Errorf("could not encode chunk data pack %d: %w", i, err)
+		}
+		encoded[i] = m
+	}
+	return encoded, nil
+}
+
+// MessagesToChunkDataPacks decodes the chunk data packs of a GetBlockExecutionResponse.
+// Empty messages are decoded as nil chunk data packs.
+func MessagesToChunkDataPacks(m [][]byte) ([]*flow.ChunkDataPack, error) {
+	chunkDataPacks := make([]*flow.ChunkDataPack, len(m))
+	for i, data := range m {
+		if len(data) == 0 {
+			continue
+		}
+		var chunkDataPack flow.ChunkDataPack
+		err := marshaler.Unmarshal(data, &chunkDataPack)
+		if err != nil {
+			return nil, fmt.Errorf("could not decode chunk data pack %d: %w", i, err)
+		}
+		chunkDataPacks[i] = &chunkDataPack
+	}
+	return chunkDataPacks, nil
+}
+
+// StateDeltaToMessage encodes an execution state delta for a GetBlockExecutionResponse.
+func StateDeltaToMessage(stateDelta *messages.ExecutionStateDelta) ([]byte, error) {
+	return marshaler.Marshal(stateDelta)
+}
+
+// MessageToStateDelta decodes the execution state delta of a GetBlockExecutionResponse.
+func MessageToStateDelta(m []byte) (*messages.ExecutionStateDelta, error) {
+	var stateDelta messages.ExecutionStateDelta
+	err := marshaler.Unmarshal(m, &stateDelta)
+	if err != nil {
+		return nil, err
+	}
+	return &stateDelta, nil
+}
@@ -0,0 +1,50 @@
+// Package registersets converts the messages of the gRPC service used to query
+// the registers read and written by the transactions executed by an execution
+// node. The service is defined in registersets/registersets.proto.
+package registersets
+
+import (
+	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	pb "github.com/onflow/flow-go/engine/common/rpc/registersets/registersets"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// TransactionRegisterSetToMessage converts a flow.TransactionRegisterSet to its message representation.
+func TransactionRegisterSetToMessage(registerSet flow.TransactionRegisterSet) *pb.TransactionRegisterSet {
+	return &pb.TransactionRegisterSet{
+		TransactionId:    convert.IdentifierToMessage(registerSet.TransactionID),
+		TransactionIndex: registerSet.TransactionIndex,
+		ReadSet:          registerIDsToMessages(registerSet.ReadSet),
+		WriteSet:         registerIDsToMessages(registerSet.WriteSet),
+	}
+}
+
+// MessageToTransactionRegisterSet converts the message representation of a register set to a flow.TransactionRegisterSet.
+func MessageToTransactionRegisterSet(m *pb.TransactionRegisterSet) flow.TransactionRegisterSet {
+	return flow.TransactionRegisterSet{
+		TransactionID:    convert.MessageToIdentifier(m.GetTransactionId()),
+		TransactionIndex: m.GetTransactionIndex(),
+		ReadSet:          messagesToRegisterIDs(m.GetReadSet()),
+		WriteSet:         messagesToRegisterIDs(m.GetWriteSet()),
+	}
+}
+
+func registerIDsToMessages(ids []flow.RegisterID) []*pb.RegisterID {
+	messages := make([]*pb.RegisterID, len(ids))
+	for i, id := range ids {
+		messages[i] = &pb.RegisterID{
+			Owner:      []byte(id.Owner),
+			Controller: []byte(id.Controller),
+			Key:        []byte(id.Key),
+		}
+	}
+	return messages
+}
+
+func messagesToRegisterIDs(messages []*pb.RegisterID) []flow.RegisterID {
+	ids := make([]flow.RegisterID, len(messages))
+	for i, m := range messages {
+		ids[i] = flow.NewRegisterID(string(m.GetOwner()), string(m.GetController()), string(m.GetKey()))
+	}
+	return ids
+}
@@ -0,0 +1,93 @@
+package registersets
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	pb "github.com/onflow/flow-go/engine/common/rpc/registersets/registersets"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+type echoServer struct {
+	pb.UnimplementedRegisterSetsAPIServer
+	requests     []*pb.GetTransactionRegisterSetsRequest
+	registerSets []flow.TransactionRegisterSet
+}
+
+func (s *echoServer) GetTransactionRegisterSets(_ context.Context, req *pb.GetTransactionRegisterSetsRequest) (*pb.GetTransactionRegisterSetsResponse, error) {
+	s.requests = append(s.requests, req)
+	resp := &pb.GetTransactionRegisterSetsResponse{}
+	for _, registerSet := range s.registerSets {
+		resp.RegisterSets = append(resp.RegisterSets, TransactionRegisterSetToMessage(registerSet))
+	}
+	return resp, nil
+}
+
+// TestRegisterSetsAPI tests that requests and responses of the register sets
+// API are transmitted without loss over a gRPC connection, including register
+// IDs which are not valid UTF-8.
+func TestRegisterSetsAPI(t *testing.T) {
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+
+	owner := unittest.AddressFixture()
+	srv := &echoServer{
+		registerSets: []flow.TransactionRegisterSet{
+			{
+				TransactionID:    unittest.IdentifierFixture(),
+				TransactionIndex: 0,
+				ReadSet: []flow.RegisterID{
+					flow.NewRegisterID(string(owner.Bytes()), "", "storage_used"),
+					flow.NewRegisterID(string(owner.Bytes()), string(owner.Bytes()), string([]byte{'$', 0xff, 0, 1})),
+				},
+				WriteSet: []flow.RegisterID{
+					flow.NewRegisterID(string(owner.Bytes()), "", "storage_used"),
+				},
+			},
+			{
+				TransactionID:    unittest.IdentifierFixture(),
+				TransactionIndex: 1,
+				ReadSet:          []flow.RegisterID{},
+				WriteSet:         []flow.RegisterID{},
+			},
+		},
+	}
+	pb.RegisterRegisterSetsAPIServer(server, srv)
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	defer server.Stop()
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return listener.Dial()
+		}),
+		grpc.WithInsecure(), //nolint:staticcheck
+	)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	blockID := unittest.IdentifierFixture()
+	req := &pb.GetTransactionRegisterSetsRequest{
+		BlockId: convert.IdentifierToMessage(blockID),
+	}
+	resp, err := pb.NewRegisterSetsAPIClient(conn).GetTransactionRegisterSets(context.Background(), req)
+	require.NoError(t, err)
+
+	require.Len(t, srv.requests, 1)
+	assert.Equal(t, blockID, convert.MessageToIdentifier(srv.requests[0].GetBlockId()))
+	assert.Empty(t, srv.requests[0].GetTransactionId())
+
+	require.Len(t, resp.GetRegisterSets(), len(srv.registerSets))
+	for i, registerSet := range srv.registerSets {
+		assert.Equal(t, registerSet, MessageToTransactionRegisterSet(resp.GetRegisterSets()[i]))
+	}
+}
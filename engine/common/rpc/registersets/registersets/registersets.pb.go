@@ -0,0 +1,423 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.27.1
+// 	protoc        v3.17.1
+// source: registersets/registersets.proto
+
+package registersets
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// GetTransactionRegisterSetsRequest requests the register sets of the transactions of an executed block
+type GetTransactionRegisterSetsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BlockId       []byte `protobuf:"bytes,1,opt,name=blockId,proto3" json:"blockId,omitempty"`             // The executed block
+	TransactionId []byte `protobuf:"bytes,2,opt,name=transactionId,proto3" json:"transactionId,omitempty"` // The transaction to return the register sets of, all transactions if not set
+}
+
+func (x *GetTransactionRegisterSetsRequest) Reset() {
+	*x = GetTransactionRegisterSetsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_registersets_registersets_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetTransactionRegisterSetsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTransactionRegisterSetsRequest) ProtoMessage() {}
+
+func (x *GetTransactionRegisterSetsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_registersets_registersets_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTransactionRegisterSetsRequest.ProtoReflect.Descriptor instead.
+func (*GetTransactionRegisterSetsRequest) Descriptor() ([]byte, []int) {
+	return file_registersets_registersets_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetTransactionRegisterSetsRequest) GetBlockId() []byte {
+	if x != nil {
+		return x.BlockId
+	}
+	return nil
+}
+
+func (x *GetTransactionRegisterSetsRequest) GetTransactionId() []byte {
+	if x != nil {
+		return x.TransactionId
+	}
+	return nil
+}
+
+// GetTransactionRegisterSetsResponse contains the register sets of transactions
+type GetTransactionRegisterSetsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RegisterSets []*TransactionRegisterSet `protobuf:"bytes,1,rep,name=registerSets,proto3" json:"registerSets,omitempty"` // The register sets, in the order of the transactions
+}
+
+func (x *GetTransactionRegisterSetsResponse) Reset() {
+	*x = GetTransactionRegisterSetsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_registersets_registersets_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetTransactionRegisterSetsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTransactionRegisterSetsResponse) ProtoMessage() {}
+
+func (x *GetTransactionRegisterSetsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_registersets_registersets_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTransactionRegisterSetsResponse.ProtoReflect.Descriptor instead.
+func (*GetTransactionRegisterSetsResponse) Descriptor() ([]byte, []int) {
+	return file_registersets_registersets_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetTransactionRegisterSetsResponse) GetRegisterSets() []*TransactionRegisterSet {
+	if x != nil {
+		return x.RegisterSets
+	}
+	return nil
+}
+
+// TransactionRegisterSet contains the registers read and written by a transaction
+type TransactionRegisterSet struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TransactionId    []byte        `protobuf:"bytes,1,opt,name=transactionId,proto3" json:"transactionId,omitempty"`        // The transaction
+	TransactionIndex uint32        `protobuf:"varint,2,opt,name=transactionIndex,proto3" json:"transactionIndex,omitempty"` // The index of the transaction in the block
+	ReadSet          []*RegisterID `protobuf:"bytes,3,rep,name=readSet,proto3" json:"readSet,omitempty"`                    // The registers read by the transaction
+	WriteSet         []*RegisterID `protobuf:"bytes,4,rep,name=writeSet,proto3" json:"writeSet,omitempty"`                  // The registers written by the transaction
+}
+
+func (x *TransactionRegisterSet) Reset() {
+	*x = TransactionRegisterSet{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_registersets_registersets_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TransactionRegisterSet) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransactionRegisterSet) ProtoMessage() {}
+
+func (x *TransactionRegisterSet) ProtoReflect() protoreflect.Message {
+	mi := &file_registersets_registersets_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransactionRegisterSet.ProtoReflect.Descriptor instead.
+func (*TransactionRegisterSet) Descriptor() ([]byte, []int) {
+	return file_registersets_registersets_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *TransactionRegisterSet) GetTransactionId() []byte {
+	if x != nil {
+		return x.TransactionId
+	}
+	return nil
+}
+
+func (x *TransactionRegisterSet) GetTransactionIndex() uint32 {
+	if x != nil {
+		return x.TransactionIndex
+	}
+	return 0
+}
+
+func (x *TransactionRegisterSet) GetReadSet() []*RegisterID {
+	if x != nil {
+		return x.ReadSet
+	}
+	return nil
+}
+
+func (x *TransactionRegisterSet) GetWriteSet() []*RegisterID {
+	if x != nil {
+		return x.WriteSet
+	}
+	return nil
+}
+
+// RegisterID identifies a register, its parts are not necessarily valid UTF-8
+type RegisterID struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Owner      []byte `protobuf:"bytes,1,opt,name=owner,proto3" json:"owner,omitempty"`           // The owner of the register
+	Controller []byte `protobuf:"bytes,2,opt,name=controller,proto3" json:"controller,omitempty"` // The controller of the register
+	Key        []byte `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`               // The key of the register
+}
+
+func (x *RegisterID) Reset() {
+	*x = RegisterID{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_registersets_registersets_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RegisterID) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterID) ProtoMessage() {}
+
+func (x *RegisterID) ProtoReflect() protoreflect.Message {
+	mi := &file_registersets_registersets_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterID.ProtoReflect.Descriptor instead.
+func (*RegisterID) Descriptor() ([]byte, []int) {
+	return file_registersets_registersets_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RegisterID) GetOwner() []byte {
+	if x != nil {
+		return x.Owner
+	}
+	return nil
+}
+
+func (x *RegisterID) GetController() []byte {
+	if x != nil {
+		return x.Controller
+	}
+	return nil
+}
+
+func (x *RegisterID) GetKey() []byte {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+var File_registersets_registersets_proto protoreflect.FileDescriptor
+
+var file_registersets_registersets_proto_rawDesc = []byte{
+	0x0a, 0x1f, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x73, 0x65, 0x74, 0x73, 0x2f, 0x72,
+	0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x73, 0x65, 0x74, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x12, 0x0c, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x73, 0x65, 0x74, 0x73, 0x22,
+	0x63, 0x0a, 0x21, 0x47, 0x65, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x53, 0x65, 0x74, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x64, 0x12, 0x24,
+	0x0a, 0x0d, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0d, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x49, 0x64, 0x22, 0x6e, 0x0a, 0x22, 0x47, 0x65, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x53, 0x65,
+	0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x48, 0x0a, 0x0c, 0x72, 0x65,
+	0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x53, 0x65, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x24, 0x2e, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x73, 0x65, 0x74, 0x73, 0x2e,
+	0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x67, 0x69, 0x73,
+	0x74, 0x65, 0x72, 0x53, 0x65, 0x74, 0x52, 0x0c, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72,
+	0x53, 0x65, 0x74, 0x73, 0x22, 0xd4, 0x01, 0x0a, 0x16, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x53, 0x65, 0x74, 0x12,
+	0x24, 0x0a, 0x0d, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0d, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x2a, 0x0a, 0x10, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x10, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x64, 0x65,
+	0x78, 0x12, 0x32, 0x0a, 0x07, 0x72, 0x65, 0x61, 0x64, 0x53, 0x65, 0x74, 0x18, 0x03, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x18, 0x2e, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x73, 0x65, 0x74,
+	0x73, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x49, 0x44, 0x52, 0x07, 0x72, 0x65,
+	0x61, 0x64, 0x53, 0x65, 0x74, 0x12, 0x34, 0x0a, 0x08, 0x77, 0x72, 0x69, 0x74, 0x65, 0x53, 0x65,
+	0x74, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74,
+	0x65, 0x72, 0x73, 0x65, 0x74, 0x73, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x49,
+	0x44, 0x52, 0x08, 0x77, 0x72, 0x69, 0x74, 0x65, 0x53, 0x65, 0x74, 0x22, 0x54, 0x0a, 0x0a, 0x52,
+	0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x49, 0x44, 0x12, 0x14, 0x0a, 0x05, 0x6f, 0x77, 0x6e,
+	0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x12,
+	0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x0a, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x32, 0x92, 0x01, 0x0a, 0x0f, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x53, 0x65,
+	0x74, 0x73, 0x41, 0x50, 0x49, 0x12, 0x7f, 0x0a, 0x1a, 0x47, 0x65, 0x74, 0x54, 0x72, 0x61, 0x6e,
+	0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x53,
+	0x65, 0x74, 0x73, 0x12, 0x2f, 0x2e, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x73, 0x65,
+	0x74, 0x73, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x53, 0x65, 0x74, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x30, 0x2e, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x73,
+	0x65, 0x74, 0x73, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x53, 0x65, 0x74, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x47, 0x5a, 0x45, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
+	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6f, 0x6e, 0x66, 0x6c, 0x6f, 0x77, 0x2f, 0x66, 0x6c, 0x6f, 0x77,
+	0x2d, 0x67, 0x6f, 0x2f, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f,
+	0x6e, 0x2f, 0x72, 0x70, 0x63, 0x2f, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x73, 0x65,
+	0x74, 0x73, 0x2f, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x73, 0x65, 0x74, 0x73, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_registersets_registersets_proto_rawDescOnce sync.Once
+	file_registersets_registersets_proto_rawDescData = file_registersets_registersets_proto_rawDesc
+)
+
+func file_registersets_registersets_proto_rawDescGZIP() []byte {
+	file_registersets_registersets_proto_rawDescOnce.Do(func() {
+		file_registersets_registersets_proto_rawDescData = protoimpl.X.CompressGZIP(file_registersets_registersets_proto_rawDescData)
+	})
+	return file_registersets_registersets_proto_rawDescData
+}
+
+var file_registersets_registersets_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_registersets_registersets_proto_goTypes = []interface{}{
+	(*GetTransactionRegisterSetsRequest)(nil),  // 0: registersets.GetTransactionRegisterSetsRequest
+	(*GetTransactionRegisterSetsResponse)(nil), // 1: registersets.GetTransactionRegisterSetsResponse
+	(*TransactionRegisterSet)(nil),             // 2: registersets.TransactionRegisterSet
+	(*RegisterID)(nil),                         // 3: registersets.RegisterID
+}
+var file_registersets_registersets_proto_depIdxs = []int32{
+	2, // 0: registersets.GetTransactionRegisterSetsResponse.registerSets:type_name -> registersets.TransactionRegisterSet
+	3, // 1: registersets.TransactionRegisterSet.readSet:type_name -> registersets.RegisterID
+	3, // 2: registersets.TransactionRegisterSet.writeSet:type_name -> registersets.RegisterID
+	0, // 3: registersets.RegisterSetsAPI.GetTransactionRegisterSets:input_type -> registersets.GetTransactionRegisterSetsRequest
+	1, // 4: registersets.RegisterSetsAPI.GetTransactionRegisterSets:output_type -> registersets.GetTransactionRegisterSetsResponse
+	4, // [4:5] is the sub-list for method output_type
+	3, // [3:4] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_registersets_registersets_proto_init() }
+func file_registersets_registersets_proto_init() {
+	if File_registersets_registersets_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_registersets_registersets_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetTransactionRegisterSetsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_registersets_registersets_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetTransactionRegisterSetsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_registersets_registersets_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TransactionRegisterSet); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_registersets_registersets_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RegisterID); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_registersets_registersets_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_registersets_registersets_proto_goTypes,
+		DependencyIndexes: file_registersets_registersets_proto_depIdxs,
+		MessageInfos:      file_registersets_registersets_proto_msgTypes,
+	}.Build()
+	File_registersets_registersets_proto = out.File
+	file_registersets_registersets_proto_rawDesc = nil
+	file_registersets_registersets_proto_goTypes = nil
+	file_registersets_registersets_proto_depIdxs = nil
+}
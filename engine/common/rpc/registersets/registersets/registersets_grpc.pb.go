@@ -0,0 +1,109 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.17.1
+// source: registersets/registersets.proto
+
+package registersets
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// RegisterSetsAPIClient is the client API for RegisterSetsAPI service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type RegisterSetsAPIClient interface {
+	// GetTransactionRegisterSets returns the registers read and written by the
+	// transactions of an executed block.
+	GetTransactionRegisterSets(ctx context.Context, in *GetTransactionRegisterSetsRequest, opts ...grpc.CallOption) (*GetTransactionRegisterSetsResponse, error)
+}
+
+type registerSetsAPIClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRegisterSetsAPIClient(cc grpc.ClientConnInterface) RegisterSetsAPIClient {
+	return &registerSetsAPIClient{cc}
+}
+
+func (c *registerSetsAPIClient) GetTransactionRegisterSets(ctx context.Context, in *GetTransactionRegisterSetsRequest, opts ...grpc.CallOption) (*GetTransactionRegisterSetsResponse, error) {
+	out := new(GetTransactionRegisterSetsResponse)
+	err := c.cc.Invoke(ctx, "/registersets.RegisterSetsAPI/GetTransactionRegisterSets", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RegisterSetsAPIServer is the server API for RegisterSetsAPI service.
+// All implementations must embed UnimplementedRegisterSetsAPIServer
+// for forward compatibility
+type RegisterSetsAPIServer interface {
+	// GetTransactionRegisterSets returns the registers read and written by the
+	// transactions of an executed block.
+	GetTransactionRegisterSets(context.Context, *GetTransactionRegisterSetsRequest) (*GetTransactionRegisterSetsResponse, error)
+	mustEmbedUnimplementedRegisterSetsAPIServer()
+}
+
+// UnimplementedRegisterSetsAPIServer must be embedded to have forward compatible implementations.
+type UnimplementedRegisterSetsAPIServer struct {
+}
+
+func (UnimplementedRegisterSetsAPIServer) GetTransactionRegisterSets(context.Context, *GetTransactionRegisterSetsRequest) (*GetTransactionRegisterSetsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTransactionRegisterSets not implemented")
+}
+func (UnimplementedRegisterSetsAPIServer) mustEmbedUnimplementedRegisterSetsAPIServer() {}
+
+// UnsafeRegisterSetsAPIServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RegisterSetsAPIServer will
+// result in compilation errors.
+type UnsafeRegisterSetsAPIServer interface {
+	mustEmbedUnimplementedRegisterSetsAPIServer()
+}
+
+func RegisterRegisterSetsAPIServer(s grpc.ServiceRegistrar, srv RegisterSetsAPIServer) {
+	s.RegisterService(&RegisterSetsAPI_ServiceDesc, srv)
+}
+
+func _RegisterSetsAPI_GetTransactionRegisterSets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTransactionRegisterSetsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegisterSetsAPIServer).GetTransactionRegisterSets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/registersets.RegisterSetsAPI/GetTransactionRegisterSets",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegisterSetsAPIServer).GetTransactionRegisterSets(ctx, req.(*GetTransactionRegisterSetsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// RegisterSetsAPI_ServiceDesc is the grpc.ServiceDesc for RegisterSetsAPI service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var RegisterSetsAPI_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "registersets.RegisterSetsAPI",
+	HandlerType: (*RegisterSetsAPIServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetTransactionRegisterSets",
+			Handler:    _RegisterSetsAPI_GetTransactionRegisterSets_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "registersets/registersets.proto",
+}
@@ -0,0 +1,225 @@
+// Package simulation converts the messages of the gRPC service used to execute
+// transactions without committing their effects. Execution nodes implement the
+// service on top of their execution state, and access nodes proxy it to
+// execution nodes. The service is defined in simulation/simulation.proto.
+package simulation
+
+import (
+	"time"
+
+	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	pb "github.com/onflow/flow-go/engine/common/rpc/simulation/simulation"
+	"github.com/onflow/flow-go/fvm/profiling"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// TransactionToMessage converts a transaction body to its message representation.
+func TransactionToMessage(tx flow.TransactionBody) *pb.Transaction {
+	authorizers := make([][]byte, len(tx.Authorizers))
+	for i, authorizer := range tx.Authorizers {
+		authorizers[i] = authorizer.Bytes()
+	}
+
+	return &pb.Transaction{
+		Script:           tx.Script,
+		Arguments:        tx.Arguments,
+		ReferenceBlockId: convert.IdentifierToMessage(tx.ReferenceBlockID),
+		GasLimit:         tx.GasLimit,
+		ProposalKey: &pb.ProposalKey{
+			Address:        tx.ProposalKey.Address.Bytes(),
+			KeyIndex:       tx.ProposalKey.KeyIndex,
+			SequenceNumber: tx.ProposalKey.SequenceNumber,
+		},
+		Payer:              tx.Payer.Bytes(),
+		Authorizers:        authorizers,
+		PayloadSignatures:  signaturesToMessages(tx.PayloadSignatures),
+		EnvelopeSignatures: signaturesToMessages(tx.EnvelopeSignatures),
+	}
+}
+
+// MessageToTransaction converts the message representation of a transaction to
+// a transaction body. Transactions can be simulated before they are complete,
+// so missing fields are left empty.
+func MessageToTransaction(m *pb.Transaction) flow.TransactionBody {
+	tx := flow.TransactionBody{
+		Script:             m.GetScript(),
+		Arguments:          m.GetArguments(),
+		ReferenceBlockID:   convert.MessageToIdentifier(m.GetReferenceBlockId()),
+		GasLimit:           m.GetGasLimit(),
+		Payer:              flow.BytesToAddress(m.GetPayer()),
+		PayloadSignatures:  messagesToSignatures(m.GetPayloadSignatures()),
+		EnvelopeSignatures: messagesToSignatures(m.GetEnvelopeSignatures()),
+	}
+	if key := m.GetProposalKey(); key != nil {
+		tx.ProposalKey = flow.ProposalKey{
+			Address:        flow.BytesToAddress(key.GetAddress()),
+			KeyIndex:       key.GetKeyIndex(),
+			SequenceNumber: key.GetSequenceNumber(),
+		}
+	}
+	for _, authorizer := range m.GetAuthorizers() {
+		tx.Authorizers = append(tx.Authorizers, flow.BytesToAddress(authorizer))
+	}
+	return tx
+}
+
+func signaturesToMessages(signatures []flow.TransactionSignature) []*pb.TransactionSignature {
+	messages := make([]*pb.TransactionSignature, len(signatures))
+	for i, s := range signatures {
+		messages[i] = &pb.TransactionSignature{
+			Address:     s.Address.Bytes(),
+			SignerIndex: int64(s.SignerIndex),
+			KeyIndex:    s.KeyIndex,
+			Signature:   s.Signature,
+		}
+	}
+	return messages
+}
+
+func messagesToSignatures(messages []*pb.TransactionSignature) []flow.TransactionSignature {
+	var signatures []flow.TransactionSignature
+	for _, m := range messages {
+		signatures = append(signatures, flow.TransactionSignature{
+			Address:     flow.BytesToAddress(m.GetAddress()),
+			SignerIndex: int(m.GetSignerIndex()),
+			KeyIndex:    m.GetKeyIndex(),
+			Signature:   m.GetSignature(),
+		})
+	}
+	return signatures
+}
+
+// OptionsToMessage converts simulation options to their message representation.
+func OptionsToMessage(opts flow.TransactionSimulationOptions) *pb.SimulationOptions {
+	return &pb.SimulationOptions{
+		SkipSignatureCheck:      opts.SkipSignatureCheck,
+		SkipSequenceNumberCheck: opts.SkipSequenceNumberCheck,
+		Profile:                 opts.Profile,
+	}
+}
+
+// MessageToOptions converts the message representation of simulation options.
+func MessageToOptions(m *pb.SimulationOptions) flow.TransactionSimulationOptions {
+	return flow.TransactionSimulationOptions{
+		SkipSignatureCheck:      m.GetSkipSignatureCheck(),
+		SkipSequenceNumberCheck: m.GetSkipSequenceNumberCheck(),
+		Profile:                 m.GetProfile(),
+	}
+}
+
+// ResultToMessage converts a simulation result to its message representation.
+func ResultToMessage(result flow.TransactionSimulationResult) *pb.SimulationResult {
+	events := make([]*pb.Event, len(result.Events))
+	for i, e := range result.Events {
+		events[i] = &pb.Event{
+			Type:             string(e.Type),
+			TransactionId:    convert.IdentifierToMessage(e.TransactionID),
+			TransactionIndex: e.TransactionIndex,
+			EventIndex:       e.EventIndex,
+			Payload:          e.Payload,
+		}
+	}
+
+	changes := make([]*pb.StorageUsedChange, len(result.StorageUsedChanges))
+	for i, c := range result.StorageUsedChanges {
+		changes[i] = &pb.StorageUsedChange{
+			Address: c.Address.Bytes(),
+			Before:  c.Before,
+			After:   c.After,
+		}
+	}
+
+	return &pb.SimulationResult{
+		BlockId:            convert.IdentifierToMessage(result.BlockID),
+		StatusCode:         uint32(result.StatusCode),
+		ErrorMessage:       result.ErrorMessage,
+		ComputationUsed:    result.ComputationUsed,
+		Events:             events,
+		StorageUsedChanges: changes,
+		EstimatedFees:      result.EstimatedFees,
+		Profile:            ProfileToMessage(result.Profile),
+	}
+}
+
+// MessageToResult converts the message representation of a simulation result.
+func MessageToResult(m *pb.SimulationResult) flow.TransactionSimulationResult {
+	result := flow.TransactionSimulationResult{
+		BlockID:         convert.MessageToIdentifier(m.GetBlockId()),
+		StatusCode:      uint(m.GetStatusCode()),
+		ErrorMessage:    m.GetErrorMessage(),
+		ComputationUsed: m.GetComputationUsed(),
+		EstimatedFees:   m.GetEstimatedFees(),
+		Profile:         MessageToProfile(m.GetProfile()),
+	}
+	for _, e := range m.GetEvents() {
+		result.Events = append(result.Events, flow.Event{
+			Type:             flow.EventType(e.GetType()),
+			TransactionID:    convert.MessageToIdentifier(e.GetTransactionId()),
+			TransactionIndex: e.GetTransactionIndex(),
+			EventIndex:       e.GetEventIndex(),
+			Payload:          e.GetPayload(),
+		})
+	}
+	for _, c := range m.GetStorageUsedChanges() {
+		result.StorageUsedChanges = append(result.StorageUsedChanges, flow.StorageUsedChange{
+			Address: flow.BytesToAddress(c.GetAddress()),
+			Before:  c.GetBefore(),
+			After:   c.GetAfter(),
+		})
+	}
+	return result
+}
+
+// ProfileToMessage converts a profile to its message representation. A nil
+// profile converts to a nil message.
+func ProfileToMessage(profile *profiling.Profile) *pb.Profile {
+	if profile == nil {
+		return nil
+	}
+	return &pb.Profile{
+		Functions:        entriesToMessages(profile.Functions),
+		Invocations:      entriesToMessages(profile.Invocations),
+		EnvironmentCalls: entriesToMessages(profile.EnvironmentCalls),
+	}
+}
+
+// MessageToProfile converts the message representation of a profile. A nil
+// message converts to a nil profile.
+func MessageToProfile(m *pb.Profile) *profiling.Profile {
+	if m == nil {
+		return nil
+	}
+	return &profiling.Profile{
+		Functions:        messagesToEntries(m.GetFunctions()),
+		Invocations:      messagesToEntries(m.GetInvocations()),
+		EnvironmentCalls: messagesToEntries(m.GetEnvironmentCalls()),
+	}
+}
+
+func entriesToMessages(entries []profiling.Entry) []*pb.ProfileEntry {
+	messages := make([]*pb.ProfileEntry, len(entries))
+	for i, e := range entries {
+		messages[i] = &pb.ProfileEntry{
+			Name:        e.Name,
+			Location:    e.Location,
+			Calls:       e.Calls,
+			Duration:    uint64(e.Duration),
+			Computation: e.Computation,
+		}
+	}
+	return messages
+}
+
+func messagesToEntries(messages []*pb.ProfileEntry) []profiling.Entry {
+	var entries []profiling.Entry
+	for _, m := range messages {
+		entries = append(entries, profiling.Entry{
+			Name:        m.GetName(),
+			Location:    m.GetLocation(),
+			Calls:       m.GetCalls(),
+			Duration:    time.Duration(m.GetDuration()),
+			Computation: m.GetComputation(),
+		})
+	}
+	return entries
+}
@@ -0,0 +1,106 @@
+package simulation
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	pb "github.com/onflow/flow-go/engine/common/rpc/simulation/simulation"
+	"github.com/onflow/flow-go/fvm/profiling"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+type echoServer struct {
+	pb.UnimplementedSimulationAPIServer
+	requests []*pb.SimulateTransactionRequest
+	result   flow.TransactionSimulationResult
+}
+
+func (s *echoServer) SimulateTransaction(_ context.Context, req *pb.SimulateTransactionRequest) (*pb.SimulateTransactionResponse, error) {
+	s.requests = append(s.requests, req)
+	return &pb.SimulateTransactionResponse{Result: ResultToMessage(s.result)}, nil
+}
+
+// TestSimulationAPI tests that requests and responses of the simulation API
+// are transmitted without loss over a gRPC connection.
+func TestSimulationAPI(t *testing.T) {
+	event := unittest.EventFixture(flow.EventAccountCreated, 0, 0, unittest.IdentifierFixture(), 0)
+	event.Payload = []byte(`{"type":"Event"}`)
+
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	srv := &echoServer{
+		result: flow.TransactionSimulationResult{
+			BlockID:         unittest.IdentifierFixture(),
+			StatusCode:      flow.TransactionStatusCodeFailed,
+			ErrorMessage:    "execution failed",
+			ComputationUsed: 42,
+			Events:          []flow.Event{event},
+			StorageUsedChanges: []flow.StorageUsedChange{{
+				Address: unittest.AddressFixture(),
+				Before:  100,
+				After:   50,
+			}},
+			EstimatedFees: 10,
+			Profile: &profiling.Profile{
+				Functions: []profiling.Entry{{
+					Name:        "main",
+					Location:    "s.0000000000000000000000000000000000000000000000000000000000000000",
+					Calls:       1,
+					Duration:    time.Millisecond,
+					Computation: 40,
+				}},
+				Invocations: []profiling.Entry{{
+					Name:        "transaction",
+					Calls:       1,
+					Duration:    2 * time.Millisecond,
+					Computation: 42,
+				}},
+			},
+		},
+	}
+	pb.RegisterSimulationAPIServer(server, srv)
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	defer server.Stop()
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return listener.Dial()
+		}),
+		grpc.WithInsecure(), //nolint:staticcheck
+	)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	blockID := unittest.IdentifierFixture()
+	tx := unittest.TransactionBodyFixture()
+	opts := flow.TransactionSimulationOptions{SkipSequenceNumberCheck: true, Profile: true}
+	req := &pb.SimulateTransactionRequest{
+		BlockId:     convert.IdentifierToMessage(blockID),
+		Transaction: TransactionToMessage(tx),
+		Options:     OptionsToMessage(opts),
+	}
+	resp, err := pb.NewSimulationAPIClient(conn).SimulateTransaction(context.Background(), req)
+	require.NoError(t, err)
+
+	require.Len(t, srv.requests, 1)
+	received := srv.requests[0]
+	assert.Equal(t, blockID, convert.MessageToIdentifier(received.GetBlockId()))
+	assert.Equal(t, tx, MessageToTransaction(received.GetTransaction()))
+	assert.Equal(t, tx.ID(), MessageToTransaction(received.GetTransaction()).ID())
+	assert.Equal(t, opts, MessageToOptions(received.GetOptions()))
+
+	result := MessageToResult(resp.GetResult())
+	assert.Equal(t, srv.result, result)
+	assert.Equal(t, int64(-50), result.StorageUsedChanges[0].Delta())
+}
@@ -0,0 +1,149 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.17.1
+// source: simulation/simulation.proto
+
+package simulation
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// SimulationAPIClient is the client API for SimulationAPI service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type SimulationAPIClient interface {
+	// SimulateTransaction executes a transaction against the execution state at a
+	// block, without committing its effects.
+	SimulateTransaction(ctx context.Context, in *SimulateTransactionRequest, opts ...grpc.CallOption) (*SimulateTransactionResponse, error)
+	// ExecuteScript executes a script against the execution state at a block, and
+	// returns the profile of its execution if requested.
+	ExecuteScript(ctx context.Context, in *ExecuteScriptRequest, opts ...grpc.CallOption) (*ExecuteScriptResponse, error)
+}
+
+type simulationAPIClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSimulationAPIClient(cc grpc.ClientConnInterface) SimulationAPIClient {
+	return &simulationAPIClient{cc}
+}
+
+func (c *simulationAPIClient) SimulateTransaction(ctx context.Context, in *SimulateTransactionRequest, opts ...grpc.CallOption) (*SimulateTransactionResponse, error) {
+	out := new(SimulateTransactionResponse)
+	err := c.cc.Invoke(ctx, "/simulation.SimulationAPI/SimulateTransaction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simulationAPIClient) ExecuteScript(ctx context.Context, in *ExecuteScriptRequest, opts ...grpc.CallOption) (*ExecuteScriptResponse, error) {
+	out := new(ExecuteScriptResponse)
+	err := c.cc.Invoke(ctx, "/simulation.SimulationAPI/ExecuteScript", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SimulationAPIServer is the server API for SimulationAPI service.
+// All implementations must embed UnimplementedSimulationAPIServer
+// for forward compatibility
+type SimulationAPIServer interface {
+	// SimulateTransaction executes a transaction against the execution state at a
+	// block, without committing its effects.
+	SimulateTransaction(context.Context, *SimulateTransactionRequest) (*SimulateTransactionResponse, error)
+	// ExecuteScript executes a script against the execution state at a block, and
+	// returns the profile of its execution if requested.
+	ExecuteScript(context.Context, *ExecuteScriptRequest) (*ExecuteScriptResponse, error)
+	mustEmbedUnimplementedSimulationAPIServer()
+}
+
+// UnimplementedSimulationAPIServer must be embedded to have forward compatible implementations.
+type UnimplementedSimulationAPIServer struct {
+}
+
+func (UnimplementedSimulationAPIServer) SimulateTransaction(context.Context, *SimulateTransactionRequest) (*SimulateTransactionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SimulateTransaction not implemented")
+}
+func (UnimplementedSimulationAPIServer) ExecuteScript(context.Context, *ExecuteScriptRequest) (*ExecuteScriptResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExecuteScript not implemented")
+}
+func (UnimplementedSimulationAPIServer) mustEmbedUnimplementedSimulationAPIServer() {}
+
+// UnsafeSimulationAPIServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SimulationAPIServer will
+// result in compilation errors.
+type UnsafeSimulationAPIServer interface {
+	mustEmbedUnimplementedSimulationAPIServer()
+}
+
+func RegisterSimulationAPIServer(s grpc.ServiceRegistrar, srv SimulationAPIServer) {
+	s.RegisterService(&SimulationAPI_ServiceDesc, srv)
+}
+
+func _SimulationAPI_SimulateTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SimulateTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimulationAPIServer).SimulateTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/simulation.SimulationAPI/SimulateTransaction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimulationAPIServer).SimulateTransaction(ctx, req.(*SimulateTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimulationAPI_ExecuteScript_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExecuteScriptRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimulationAPIServer).ExecuteScript(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/simulation.SimulationAPI/ExecuteScript",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimulationAPIServer).ExecuteScript(ctx, req.(*ExecuteScriptRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SimulationAPI_ServiceDesc is the grpc.ServiceDesc for SimulationAPI service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SimulationAPI_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "simulation.SimulationAPI",
+	HandlerType: (*SimulationAPIServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SimulateTransaction",
+			Handler:    _SimulationAPI_SimulateTransaction_Handler,
+		},
+		{
+			MethodName: "ExecuteScript",
+			Handler:    _SimulationAPI_ExecuteScript_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "simulation/simulation.proto",
+}
@@ -0,0 +1,58 @@
+// Package slashing serves the evidence of slashable consensus violations persisted
+// by a node over gRPC. The service is defined in slashing/slashing.proto.
+package slashing
+
+import (
+	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	pb "github.com/onflow/flow-go/engine/common/rpc/slashing/slashing"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// EvidenceToMessage converts a flow.SlashingEvidence to its message representation.
+func EvidenceToMessage(evidence *flow.SlashingEvidence) *pb.SlashingEvidence {
+	votes := make([]*pb.SignedVote, len(evidence.Votes))
+	for i, vote := range evidence.Votes {
+		votes[i] = &pb.SignedVote{
+			View:     vote.View,
+			BlockId:  convert.IdentifierToMessage(vote.BlockID),
+			SignerId: convert.IdentifierToMessage(vote.SignerID),
+			SigData:  vote.SigData,
+		}
+	}
+	return &pb.SlashingEvidence{
+		Violation:  pb.SlashingViolation(evidence.Violation),
+		ChainId:    evidence.ChainID.String(),
+		OffenderId: convert.IdentifierToMessage(evidence.OffenderID),
+		View:       evidence.View,
+		Votes:      votes,
+	}
+}
+
+// MessageToEvidence converts the message representation of slashing evidence to a flow.SlashingEvidence.
+func MessageToEvidence(m *pb.SlashingEvidence) *flow.SlashingEvidence {
+	votes := make([]flow.SignedVote, len(m.GetVotes()))
+	for i, vote := range m.GetVotes() {
+		votes[i] = flow.SignedVote{
+			View:     vote.GetView(),
+			BlockID:  convert.MessageToIdentifier(vote.GetBlockId()),
+			SignerID: convert.MessageToIdentifier(vote.GetSignerId()),
+			SigData:  vote.GetSigData(),
+		}
+	}
+	return &flow.SlashingEvidence{
+		Violation:  flow.SlashingViolation(m.GetViolation()),
+		ChainID:    flow.ChainID(m.GetChainId()),
+		OffenderID: convert.MessageToIdentifier(m.GetOffenderId()),
+		View:       m.GetView(),
+		Votes:      votes,
+	}
+}
+
+// EvidenceToMessages converts a list of flow.SlashingEvidence to their message representation.
+func EvidenceToMessages(evidence []*flow.SlashingEvidence) []*pb.SlashingEvidence {
+	messages := make([]*pb.SlashingEvidence, len(evidence))
+	for i, e := range evidence {
+		messages[i] = EvidenceToMessage(e)
+	}
+	return messages
+}
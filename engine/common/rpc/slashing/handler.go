@@ -0,0 +1,64 @@
+package slashing
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	pb "github.com/onflow/flow-go/engine/common/rpc/slashing/slashing"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+)
+
+// MaxViewRange is the maximum number of views that can be queried in a single request.
+const MaxViewRange = 10_000
+
+// Handler serves the slashing evidence persisted by the node. Consensus nodes persist
+// the evidence of all violations, while access nodes only persist the evidence of
+// double proposals, as they don't receive votes.
+type Handler struct {
+	pb.UnimplementedSlashingEvidenceAPIServer
+	evidence storage.SlashingEvidence
+}
+
+var _ pb.SlashingEvidenceAPIServer = (*Handler)(nil)
+
+func NewHandler(evidence storage.SlashingEvidence) *Handler {
+	return &Handler{
+		evidence: evidence,
+	}
+}
+
+func (h *Handler) GetSlashingEvidenceByID(_ context.Context, req *pb.GetSlashingEvidenceByIDRequest) (*pb.SlashingEvidenceResponse, error) {
+	evidenceID := convert.MessageToIdentifier(req.GetEvidenceId())
+	evidence, err := h.evidence.ByID(evidenceID)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, "slashing evidence %x not found", evidenceID)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "could not retrieve slashing evidence: %v", err)
+	}
+	return &pb.SlashingEvidenceResponse{
+		Evidence: EvidenceToMessages([]*flow.SlashingEvidence{evidence}),
+	}, nil
+}
+
+func (h *Handler) GetSlashingEvidenceByViewRange(_ context.Context, req *pb.GetSlashingEvidenceByViewRangeRequest) (*pb.SlashingEvidenceResponse, error) {
+	startView, endView := req.GetStartView(), req.GetEndView()
+	if startView > endView {
+		return nil, status.Errorf(codes.InvalidArgument, "start view (%d) is greater than end view (%d)", startView, endView)
+	}
+	if endView-startView >= MaxViewRange {
+		return nil, status.Errorf(codes.InvalidArgument, "view range (%d) exceeds maximum (%d)", endView-startView+1, MaxViewRange)
+	}
+	evidence, err := h.evidence.ByViewRange(startView, endView)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "could not retrieve slashing evidence: %v", err)
+	}
+	return &pb.SlashingEvidenceResponse{
+		Evidence: EvidenceToMessages(evidence),
+	}, nil
+}
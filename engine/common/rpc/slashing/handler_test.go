@@ -0,0 +1,78 @@
+package slashing
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	pb "github.com/onflow/flow-go/engine/common/rpc/slashing/slashing"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+	storagemock "github.com/onflow/flow-go/storage/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestSlashingEvidenceAPI tests that the slashing evidence is transmitted without
+// loss over a gRPC connection.
+func TestSlashingEvidenceAPI(t *testing.T) {
+	evidence := unittest.SlashingEvidenceFixture(unittest.WithSlashingEvidenceView(100))
+	store := new(storagemock.SlashingEvidence)
+	store.On("ByID", evidence.ID()).Return(evidence, nil)
+	store.On("ByID", flow.ZeroID).Return(nil, storage.ErrNotFound)
+	store.On("ByViewRange", uint64(90), uint64(110)).Return([]*flow.SlashingEvidence{evidence}, nil)
+
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	pb.RegisterSlashingEvidenceAPIServer(server, NewHandler(store))
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	defer server.Stop()
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return listener.Dial()
+		}),
+		grpc.WithInsecure(), //nolint:staticcheck
+	)
+	require.NoError(t, err)
+	defer conn.Close()
+	client := pb.NewSlashingEvidenceAPIClient(conn)
+
+	t.Run("by ID", func(t *testing.T) {
+		resp, err := client.GetSlashingEvidenceByID(context.Background(), &pb.GetSlashingEvidenceByIDRequest{EvidenceId: convert.IdentifierToMessage(evidence.ID())})
+		require.NoError(t, err)
+		require.Len(t, resp.GetEvidence(), 1)
+		received := MessageToEvidence(resp.GetEvidence()[0])
+		assert.Equal(t, evidence, received)
+		assert.Equal(t, evidence.ID(), received.ID())
+	})
+
+	t.Run("by ID not found", func(t *testing.T) {
+		_, err := client.GetSlashingEvidenceByID(context.Background(), &pb.GetSlashingEvidenceByIDRequest{EvidenceId: convert.IdentifierToMessage(flow.ZeroID)})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("by view range", func(t *testing.T) {
+		resp, err := client.GetSlashingEvidenceByViewRange(context.Background(), &pb.GetSlashingEvidenceByViewRangeRequest{StartView: 90, EndView: 110})
+		require.NoError(t, err)
+		require.Len(t, resp.GetEvidence(), 1)
+		assert.Equal(t, evidence, MessageToEvidence(resp.GetEvidence()[0]))
+	})
+
+	t.Run("invalid view range", func(t *testing.T) {
+		_, err := client.GetSlashingEvidenceByViewRange(context.Background(), &pb.GetSlashingEvidenceByViewRangeRequest{StartView: 110, EndView: 90})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		_, err = client.GetSlashingEvidenceByViewRange(context.Background(), &pb.GetSlashingEvidenceByViewRangeRequest{StartView: 0, EndView: MaxViewRange})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
@@ -0,0 +1,577 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.27.1
+// 	protoc        v3.17.1
+// source: slashing/slashing.proto
+
+package slashing
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// SlashingViolation is the type of a slashable violation of the consensus protocol
+type SlashingViolation int32
+
+const (
+	SlashingViolation_UNKNOWN_VIOLATION      SlashingViolation = 0
+	SlashingViolation_DOUBLE_VOTE            SlashingViolation = 1 // A replica voting for two different blocks at the same view
+	SlashingViolation_DOUBLE_PROPOSAL        SlashingViolation = 2 // A leader proposing two different blocks at the same view
+	SlashingViolation_INVALID_VOTE           SlashingViolation = 3 // A replica sending a vote with an invalid signature
+	SlashingViolation_VOTE_FOR_INVALID_BLOCK SlashingViolation = 4 // A replica voting for an invalid proposal
+)
+
+// Enum value maps for SlashingViolation.
+var (
+	SlashingViolation_name = map[int32]string{
+		0: "UNKNOWN_VIOLATION",
+		1: "DOUBLE_VOTE",
+		2: "DOUBLE_PROPOSAL",
+		3: "INVALID_VOTE",
+		4: "VOTE_FOR_INVALID_BLOCK",
+	}
+	SlashingViolation_value = map[string]int32{
+		"UNKNOWN_VIOLATION":      0,
+		"DOUBLE_VOTE":            1,
+		"DOUBLE_PROPOSAL":        2,
+		"INVALID_VOTE":           3,
+		"VOTE_FOR_INVALID_BLOCK": 4,
+	}
+)
+
+func (x SlashingViolation) Enum() *SlashingViolation {
+	p := new(SlashingViolation)
+	*p = x
+	return p
+}
+
+func (x SlashingViolation) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SlashingViolation) Descriptor() protoreflect.EnumDescriptor {
+	return file_slashing_slashing_proto_enumTypes[0].Descriptor()
+}
+
+func (SlashingViolation) Type() protoreflect.EnumType {
+	return &file_slashing_slashing_proto_enumTypes[0]
+}
+
+func (x SlashingViolation) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SlashingViolation.Descriptor instead.
+func (SlashingViolation) EnumDescriptor() ([]byte, []int) {
+	return file_slashing_slashing_proto_rawDescGZIP(), []int{0}
+}
+
+// GetSlashingEvidenceByIDRequest requests the evidence with the given ID
+type GetSlashingEvidenceByIDRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	EvidenceId []byte `protobuf:"bytes,1,opt,name=evidenceId,proto3" json:"evidenceId,omitempty"` // The ID of the evidence
+}
+
+func (x *GetSlashingEvidenceByIDRequest) Reset() {
+	*x = GetSlashingEvidenceByIDRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_slashing_slashing_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetSlashingEvidenceByIDRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSlashingEvidenceByIDRequest) ProtoMessage() {}
+
+func (x *GetSlashingEvidenceByIDRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_slashing_slashing_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSlashingEvidenceByIDRequest.ProtoReflect.Descriptor instead.
+func (*GetSlashingEvidenceByIDRequest) Descriptor() ([]byte, []int) {
+	return file_slashing_slashing_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetSlashingEvidenceByIDRequest) GetEvidenceId() []byte {
+	if x != nil {
+		return x.EvidenceId
+	}
+	return nil
+}
+
+// GetSlashingEvidenceByViewRangeRequest requests all evidence for violations at views in a range
+type GetSlashingEvidenceByViewRangeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	StartView uint64 `protobuf:"varint,1,opt,name=startView,proto3" json:"startView,omitempty"` // The first view of the range
+	EndView   uint64 `protobuf:"varint,2,opt,name=endView,proto3" json:"endView,omitempty"`     // The last view of the range
+}
+
+func (x *GetSlashingEvidenceByViewRangeRequest) Reset() {
+	*x = GetSlashingEvidenceByViewRangeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_slashing_slashing_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetSlashingEvidenceByViewRangeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSlashingEvidenceByViewRangeRequest) ProtoMessage() {}
+
+func (x *GetSlashingEvidenceByViewRangeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_slashing_slashing_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSlashingEvidenceByViewRangeRequest.ProtoReflect.Descriptor instead.
+func (*GetSlashingEvidenceByViewRangeRequest) Descriptor() ([]byte, []int) {
+	return file_slashing_slashing_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetSlashingEvidenceByViewRangeRequest) GetStartView() uint64 {
+	if x != nil {
+		return x.StartView
+	}
+	return 0
+}
+
+func (x *GetSlashingEvidenceByViewRangeRequest) GetEndView() uint64 {
+	if x != nil {
+		return x.EndView
+	}
+	return 0
+}
+
+// SlashingEvidenceResponse contains evidence of slashable violations
+type SlashingEvidenceResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Evidence []*SlashingEvidence `protobuf:"bytes,1,rep,name=evidence,proto3" json:"evidence,omitempty"` // The evidence, ordered by view
+}
+
+func (x *SlashingEvidenceResponse) Reset() {
+	*x = SlashingEvidenceResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_slashing_slashing_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SlashingEvidenceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SlashingEvidenceResponse) ProtoMessage() {}
+
+func (x *SlashingEvidenceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_slashing_slashing_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SlashingEvidenceResponse.ProtoReflect.Descriptor instead.
+func (*SlashingEvidenceResponse) Descriptor() ([]byte, []int) {
+	return file_slashing_slashing_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SlashingEvidenceResponse) GetEvidence() []*SlashingEvidence {
+	if x != nil {
+		return x.Evidence
+	}
+	return nil
+}
+
+// SlashingEvidence is the evidence of a slashable violation, verifiable by a third party
+type SlashingEvidence struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Violation  SlashingViolation `protobuf:"varint,1,opt,name=violation,proto3,enum=slashing.SlashingViolation" json:"violation,omitempty"` // The type of the violation
+	ChainId    string            `protobuf:"bytes,2,opt,name=chainId,proto3" json:"chainId,omitempty"`                                      // The chain the violation happened on
+	OffenderId []byte            `protobuf:"bytes,3,opt,name=offenderId,proto3" json:"offenderId,omitempty"`                                // The node which committed the violation
+	View       uint64            `protobuf:"varint,4,opt,name=view,proto3" json:"view,omitempty"`                                           // The view of the violation
+	Votes      []*SignedVote     `protobuf:"bytes,5,rep,name=votes,proto3" json:"votes,omitempty"`                                          // The signed messages proving the violation
+}
+
+func (x *SlashingEvidence) Reset() {
+	*x = SlashingEvidence{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_slashing_slashing_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SlashingEvidence) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SlashingEvidence) ProtoMessage() {}
+
+func (x *SlashingEvidence) ProtoReflect() protoreflect.Message {
+	mi := &file_slashing_slashing_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SlashingEvidence.ProtoReflect.Descriptor instead.
+func (*SlashingEvidence) Descriptor() ([]byte, []int) {
+	return file_slashing_slashing_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *SlashingEvidence) GetViolation() SlashingViolation {
+	if x != nil {
+		return x.Violation
+	}
+	return SlashingViolation_UNKNOWN_VIOLATION
+}
+
+func (x *SlashingEvidence) GetChainId() string {
+	if x != nil {
+		return x.ChainId
+	}
+	return ""
+}
+
+func (x *SlashingEvidence) GetOffenderId() []byte {
+	if x != nil {
+		return x.OffenderId
+	}
+	return nil
+}
+
+func (x *SlashingEvidence) GetView() uint64 {
+	if x != nil {
+		return x.View
+	}
+	return 0
+}
+
+func (x *SlashingEvidence) GetVotes() []*SignedVote {
+	if x != nil {
+		return x.Votes
+	}
+	return nil
+}
+
+// SignedVote is a signature by a consensus participant over a block at a view
+type SignedVote struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	View     uint64 `protobuf:"varint,1,opt,name=view,proto3" json:"view,omitempty"`        // The view of the block
+	BlockId  []byte `protobuf:"bytes,2,opt,name=blockId,proto3" json:"blockId,omitempty"`   // The signed block
+	SignerId []byte `protobuf:"bytes,3,opt,name=signerId,proto3" json:"signerId,omitempty"` // The signer
+	SigData  []byte `protobuf:"bytes,4,opt,name=sigData,proto3" json:"sigData,omitempty"`   // The signature data, as received in the vote or proposal
+}
+
+func (x *SignedVote) Reset() {
+	*x = SignedVote{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_slashing_slashing_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SignedVote) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignedVote) ProtoMessage() {}
+
+func (x *SignedVote) ProtoReflect() protoreflect.Message {
+	mi := &file_slashing_slashing_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignedVote.ProtoReflect.Descriptor instead.
+func (*SignedVote) Descriptor() ([]byte, []int) {
+	return file_slashing_slashing_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SignedVote) GetView() uint64 {
+	if x != nil {
+		return x.View
+	}
+	return 0
+}
+
+func (x *SignedVote) GetBlockId() []byte {
+	if x != nil {
+		return x.BlockId
+	}
+	return nil
+}
+
+func (x *SignedVote) GetSignerId() []byte {
+	if x != nil {
+		return x.SignerId
+	}
+	return nil
+}
+
+func (x *SignedVote) GetSigData() []byte {
+	if x != nil {
+		return x.SigData
+	}
+	return nil
+}
+
+var File_slashing_slashing_proto protoreflect.FileDescriptor
+
+var file_slashing_slashing_proto_rawDesc = []byte{
+	0x0a, 0x17, 0x73, 0x6c, 0x61, 0x73, 0x68, 0x69, 0x6e, 0x67, 0x2f, 0x73, 0x6c, 0x61, 0x73, 0x68,
+	0x69, 0x6e, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x08, 0x73, 0x6c, 0x61, 0x73, 0x68,
+	0x69, 0x6e, 0x67, 0x22, 0x40, 0x0a, 0x1e, 0x47, 0x65, 0x74, 0x53, 0x6c, 0x61, 0x73, 0x68, 0x69,
+	0x6e, 0x67, 0x45, 0x76, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x42, 0x79, 0x49, 0x44, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x65, 0x76, 0x69, 0x64, 0x65, 0x6e, 0x63,
+	0x65, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x65, 0x76, 0x69, 0x64, 0x65,
+	0x6e, 0x63, 0x65, 0x49, 0x64, 0x22, 0x5f, 0x0a, 0x25, 0x47, 0x65, 0x74, 0x53, 0x6c, 0x61, 0x73,
+	0x68, 0x69, 0x6e, 0x67, 0x45, 0x76, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x42, 0x79, 0x56, 0x69,
+	0x65, 0x77, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c,
+	0x0a, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x56, 0x69, 0x65, 0x77, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x56, 0x69, 0x65, 0x77, 0x12, 0x18, 0x0a, 0x07,
+	0x65, 0x6e, 0x64, 0x56, 0x69, 0x65, 0x77, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x65,
+	0x6e, 0x64, 0x56, 0x69, 0x65, 0x77, 0x22, 0x52, 0x0a, 0x18, 0x53, 0x6c, 0x61, 0x73, 0x68, 0x69,
+	0x6e, 0x67, 0x45, 0x76, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x36, 0x0a, 0x08, 0x65, 0x76, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x73, 0x6c, 0x61, 0x73, 0x68, 0x69, 0x6e, 0x67, 0x2e,
+	0x53, 0x6c, 0x61, 0x73, 0x68, 0x69, 0x6e, 0x67, 0x45, 0x76, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65,
+	0x52, 0x08, 0x65, 0x76, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x22, 0xc7, 0x01, 0x0a, 0x10, 0x53,
+	0x6c, 0x61, 0x73, 0x68, 0x69, 0x6e, 0x67, 0x45, 0x76, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x12,
+	0x39, 0x0a, 0x09, 0x76, 0x69, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x1b, 0x2e, 0x73, 0x6c, 0x61, 0x73, 0x68, 0x69, 0x6e, 0x67, 0x2e, 0x53, 0x6c,
+	0x61, 0x73, 0x68, 0x69, 0x6e, 0x67, 0x56, 0x69, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x09, 0x76, 0x69, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x68,
+	0x61, 0x69, 0x6e, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x68, 0x61,
+	0x69, 0x6e, 0x49, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x6f, 0x66, 0x66, 0x65, 0x6e, 0x64, 0x65, 0x72,
+	0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x6f, 0x66, 0x66, 0x65, 0x6e, 0x64,
+	0x65, 0x72, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x76, 0x69, 0x65, 0x77, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x04, 0x76, 0x69, 0x65, 0x77, 0x12, 0x2a, 0x0a, 0x05, 0x76, 0x6f, 0x74, 0x65,
+	0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x73, 0x6c, 0x61, 0x73, 0x68, 0x69,
+	0x6e, 0x67, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x56, 0x6f, 0x74, 0x65, 0x52, 0x05, 0x76,
+	0x6f, 0x74, 0x65, 0x73, 0x22, 0x70, 0x0a, 0x0a, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x56, 0x6f,
+	0x74, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x76, 0x69, 0x65, 0x77, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x04, 0x76, 0x69, 0x65, 0x77, 0x12, 0x18, 0x0a, 0x07, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x49,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x64,
+	0x12, 0x1a, 0x0a, 0x08, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x08, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07,
+	0x73, 0x69, 0x67, 0x44, 0x61, 0x74, 0x61, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x73,
+	0x69, 0x67, 0x44, 0x61, 0x74, 0x61, 0x2a, 0x7e, 0x0a, 0x11, 0x53, 0x6c, 0x61, 0x73, 0x68, 0x69,
+	0x6e, 0x67, 0x56, 0x69, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x15, 0x0a, 0x11, 0x55,
+	0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x5f, 0x56, 0x49, 0x4f, 0x4c, 0x41, 0x54, 0x49, 0x4f, 0x4e,
+	0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x44, 0x4f, 0x55, 0x42, 0x4c, 0x45, 0x5f, 0x56, 0x4f, 0x54,
+	0x45, 0x10, 0x01, 0x12, 0x13, 0x0a, 0x0f, 0x44, 0x4f, 0x55, 0x42, 0x4c, 0x45, 0x5f, 0x50, 0x52,
+	0x4f, 0x50, 0x4f, 0x53, 0x41, 0x4c, 0x10, 0x02, 0x12, 0x10, 0x0a, 0x0c, 0x49, 0x4e, 0x56, 0x41,
+	0x4c, 0x49, 0x44, 0x5f, 0x56, 0x4f, 0x54, 0x45, 0x10, 0x03, 0x12, 0x1a, 0x0a, 0x16, 0x56, 0x4f,
+	0x54, 0x45, 0x5f, 0x46, 0x4f, 0x52, 0x5f, 0x49, 0x4e, 0x56, 0x41, 0x4c, 0x49, 0x44, 0x5f, 0x42,
+	0x4c, 0x4f, 0x43, 0x4b, 0x10, 0x04, 0x32, 0xf5, 0x01, 0x0a, 0x13, 0x53, 0x6c, 0x61, 0x73, 0x68,
+	0x69, 0x6e, 0x67, 0x45, 0x76, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x41, 0x50, 0x49, 0x12, 0x67,
+	0x0a, 0x17, 0x47, 0x65, 0x74, 0x53, 0x6c, 0x61, 0x73, 0x68, 0x69, 0x6e, 0x67, 0x45, 0x76, 0x69,
+	0x64, 0x65, 0x6e, 0x63, 0x65, 0x42, 0x79, 0x49, 0x44, 0x12, 0x28, 0x2e, 0x73, 0x6c, 0x61, 0x73,
+	0x68, 0x69, 0x6e, 0x67, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x6c, 0x61, 0x73, 0x68, 0x69, 0x6e, 0x67,
+	0x45, 0x76, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x42, 0x79, 0x49, 0x44, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x73, 0x6c, 0x61, 0x73, 0x68, 0x69, 0x6e, 0x67, 0x2e, 0x53,
+	0x6c, 0x61, 0x73, 0x68, 0x69, 0x6e, 0x67, 0x45, 0x76, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x75, 0x0a, 0x1e, 0x47, 0x65, 0x74, 0x53, 0x6c,
+	0x61, 0x73, 0x68, 0x69, 0x6e, 0x67, 0x45, 0x76, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x42, 0x79,
+	0x56, 0x69, 0x65, 0x77, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x2f, 0x2e, 0x73, 0x6c, 0x61, 0x73,
+	0x68, 0x69, 0x6e, 0x67, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x6c, 0x61, 0x73, 0x68, 0x69, 0x6e, 0x67,
+	0x45, 0x76, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x42, 0x79, 0x56, 0x69, 0x65, 0x77, 0x52, 0x61,
+	0x6e, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x73, 0x6c, 0x61,
+	0x73, 0x68, 0x69, 0x6e, 0x67, 0x2e, 0x53, 0x6c, 0x61, 0x73, 0x68, 0x69, 0x6e, 0x67, 0x45, 0x76,
+	0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x3f,
+	0x5a, 0x3d, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6f, 0x6e, 0x66,
+	0x6c, 0x6f, 0x77, 0x2f, 0x66, 0x6c, 0x6f, 0x77, 0x2d, 0x67, 0x6f, 0x2f, 0x65, 0x6e, 0x67, 0x69,
+	0x6e, 0x65, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2f, 0x72, 0x70, 0x63, 0x2f, 0x73, 0x6c,
+	0x61, 0x73, 0x68, 0x69, 0x6e, 0x67, 0x2f, 0x73, 0x6c, 0x61, 0x73, 0x68, 0x69, 0x6e, 0x67, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_slashing_slashing_proto_rawDescOnce sync.Once
+	file_slashing_slashing_proto_rawDescData = file_slashing_slashing_proto_rawDesc
+)
+
+func file_slashing_slashing_proto_rawDescGZIP() []byte {
+	file_slashing_slashing_proto_rawDescOnce.Do(func() {
+		file_slashing_slashing_proto_rawDescData = protoimpl.X.CompressGZIP(file_slashing_slashing_proto_rawDescData)
+	})
+	return file_slashing_slashing_proto_rawDescData
+}
+
+var file_slashing_slashing_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_slashing_slashing_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_slashing_slashing_proto_goTypes = []interface{}{
+	(SlashingViolation)(0),                        // 0: slashing.SlashingViolation
+	(*GetSlashingEvidenceByIDRequest)(nil),        // 1: slashing.GetSlashingEvidenceByIDRequest
+	(*GetSlashingEvidenceByViewRangeRequest)(nil), // 2: slashing.GetSlashingEvidenceByViewRangeRequest
+	(*SlashingEvidenceResponse)(nil),              // 3: slashing.SlashingEvidenceResponse
+	(*SlashingEvidence)(nil),                      // 4: slashing.SlashingEvidence
+	(*SignedVote)(nil),                            // 5: slashing.SignedVote
+}
+var file_slashing_slashing_proto_depIdxs = []int32{
+	4, // 0: slashing.SlashingEvidenceResponse.evidence:type_name -> slashing.SlashingEvidence
+	0, // 1: slashing.SlashingEvidence.violation:type_name -> slashing.SlashingViolation
+	5, // 2: slashing.SlashingEvidence.votes:type_name -> slashing.SignedVote
+	1, // 3: slashing.SlashingEvidenceAPI.GetSlashingEvidenceByID:input_type -> slashing.GetSlashingEvidenceByIDRequest
+	2, // 4: slashing.SlashingEvidenceAPI.GetSlashingEvidenceByViewRange:input_type -> slashing.GetSlashingEvidenceByViewRangeRequest
+	3, // 5: slashing.SlashingEvidenceAPI.GetSlashingEvidenceByID:output_type -> slashing.SlashingEvidenceResponse
+	3, // 6: slashing.SlashingEvidenceAPI.GetSlashingEvidenceByViewRange:output_type -> slashing.SlashingEvidenceResponse
+	5, // [5:7] is the sub-list for method output_type
+	3, // [3:5] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_slashing_slashing_proto_init() }
+func file_slashing_slashing_proto_init() {
+	if File_slashing_slashing_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_slashing_slashing_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetSlashingEvidenceByIDRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_slashing_slashing_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetSlashingEvidenceByViewRangeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_slashing_slashing_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SlashingEvidenceResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_slashing_slashing_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SlashingEvidence); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_slashing_slashing_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SignedVote); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_slashing_slashing_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_slashing_slashing_proto_goTypes,
+		DependencyIndexes: file_slashing_slashing_proto_depIdxs,
+		EnumInfos:         file_slashing_slashing_proto_enumTypes,
+		MessageInfos:      file_slashing_slashing_proto_msgTypes,
+	}.Build()
+	File_slashing_slashing_proto = out.File
+	file_slashing_slashing_proto_rawDesc = nil
+	file_slashing_slashing_proto_goTypes = nil
+	file_slashing_slashing_proto_depIdxs = nil
+}
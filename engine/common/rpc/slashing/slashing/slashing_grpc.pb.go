@@ -0,0 +1,147 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.17.1
+// source: slashing/slashing.proto
+
+package slashing
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// SlashingEvidenceAPIClient is the client API for SlashingEvidenceAPI service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type SlashingEvidenceAPIClient interface {
+	// GetSlashingEvidenceByID returns the evidence with the given ID.
+	GetSlashingEvidenceByID(ctx context.Context, in *GetSlashingEvidenceByIDRequest, opts ...grpc.CallOption) (*SlashingEvidenceResponse, error)
+	// GetSlashingEvidenceByViewRange returns all evidence for violations at
+	// views in the range [startView, endView].
+	GetSlashingEvidenceByViewRange(ctx context.Context, in *GetSlashingEvidenceByViewRangeRequest, opts ...grpc.CallOption) (*SlashingEvidenceResponse, error)
+}
+
+type slashingEvidenceAPIClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSlashingEvidenceAPIClient(cc grpc.ClientConnInterface) SlashingEvidenceAPIClient {
+	return &slashingEvidenceAPIClient{cc}
+}
+
+func (c *slashingEvidenceAPIClient) GetSlashingEvidenceByID(ctx context.Context, in *GetSlashingEvidenceByIDRequest, opts ...grpc.CallOption) (*SlashingEvidenceResponse, error) {
+	out := new(SlashingEvidenceResponse)
+	err := c.cc.Invoke(ctx, "/slashing.SlashingEvidenceAPI/GetSlashingEvidenceByID", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *slashingEvidenceAPIClient) GetSlashingEvidenceByViewRange(ctx context.Context, in *GetSlashingEvidenceByViewRangeRequest, opts ...grpc.CallOption) (*SlashingEvidenceResponse, error) {
+	out := new(SlashingEvidenceResponse)
+	err := c.cc.Invoke(ctx, "/slashing.SlashingEvidenceAPI/GetSlashingEvidenceByViewRange", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SlashingEvidenceAPIServer is the server API for SlashingEvidenceAPI service.
+// All implementations must embed UnimplementedSlashingEvidenceAPIServer
+// for forward compatibility
+type SlashingEvidenceAPIServer interface {
+	// GetSlashingEvidenceByID returns the evidence with the given ID.
+	GetSlashingEvidenceByID(context.Context, *GetSlashingEvidenceByIDRequest) (*SlashingEvidenceResponse, error)
+	// GetSlashingEvidenceByViewRange returns all evidence for violations at
+	// views in the range [startView, endView].
+	GetSlashingEvidenceByViewRange(context.Context, *GetSlashingEvidenceByViewRangeRequest) (*SlashingEvidenceResponse, error)
+	mustEmbedUnimplementedSlashingEvidenceAPIServer()
+}
+
+// UnimplementedSlashingEvidenceAPIServer must be embedded to have forward compatible implementations.
+type UnimplementedSlashingEvidenceAPIServer struct {
+}
+
+func (UnimplementedSlashingEvidenceAPIServer) GetSlashingEvidenceByID(context.Context, *GetSlashingEvidenceByIDRequest) (*SlashingEvidenceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSlashingEvidenceByID not implemented")
+}
+func (UnimplementedSlashingEvidenceAPIServer) GetSlashingEvidenceByViewRange(context.Context, *GetSlashingEvidenceByViewRangeRequest) (*SlashingEvidenceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSlashingEvidenceByViewRange not implemented")
+}
+func (UnimplementedSlashingEvidenceAPIServer) mustEmbedUnimplementedSlashingEvidenceAPIServer() {}
+
+// UnsafeSlashingEvidenceAPIServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SlashingEvidenceAPIServer will
+// result in compilation errors.
+type UnsafeSlashingEvidenceAPIServer interface {
+	mustEmbedUnimplementedSlashingEvidenceAPIServer()
+}
+
+func RegisterSlashingEvidenceAPIServer(s grpc.ServiceRegistrar, srv SlashingEvidenceAPIServer) {
+	s.RegisterService(&SlashingEvidenceAPI_ServiceDesc, srv)
+}
+
+func _SlashingEvidenceAPI_GetSlashingEvidenceByID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSlashingEvidenceByIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SlashingEvidenceAPIServer).GetSlashingEvidenceByID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/slashing.SlashingEvidenceAPI/GetSlashingEvidenceByID",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SlashingEvidenceAPIServer).GetSlashingEvidenceByID(ctx, req.(*GetSlashingEvidenceByIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SlashingEvidenceAPI_GetSlashingEvidenceByViewRange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSlashingEvidenceByViewRangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SlashingEvidenceAPIServer).GetSlashingEvidenceByViewRange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/slashing.SlashingEvidenceAPI/GetSlashingEvidenceByViewRange",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SlashingEvidenceAPIServer).GetSlashingEvidenceByViewRange(ctx, req.(*GetSlashingEvidenceByViewRangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SlashingEvidenceAPI_ServiceDesc is the grpc.ServiceDesc for SlashingEvidenceAPI service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SlashingEvidenceAPI_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "slashing.SlashingEvidenceAPI",
+	HandlerType: (*SlashingEvidenceAPIServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetSlashingEvidenceByID",
+			Handler:    _SlashingEvidenceAPI_GetSlashingEvidenceByID_Handler,
+		},
+		{
+			MethodName: "GetSlashingEvidenceByViewRange",
+			Handler:    _SlashingEvidenceAPI_GetSlashingEvidenceByViewRange_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "slashing/slashing.proto",
+}
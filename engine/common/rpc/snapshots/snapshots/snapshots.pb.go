@@ -0,0 +1,302 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.27.1
+// 	protoc        v3.17.1
+// source: snapshots/snapshots.proto
+
+package snapshots
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// GetProtocolStateSnapshotByHeightRequest requests the snapshot as of the finalized block at a height
+type GetProtocolStateSnapshotByHeightRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Height uint64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"` // The height of the finalized block
+}
+
+func (x *GetProtocolStateSnapshotByHeightRequest) Reset() {
+	*x = GetProtocolStateSnapshotByHeightRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_snapshots_snapshots_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetProtocolStateSnapshotByHeightRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProtocolStateSnapshotByHeightRequest) ProtoMessage() {}
+
+func (x *GetProtocolStateSnapshotByHeightRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_snapshots_snapshots_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProtocolStateSnapshotByHeightRequest.ProtoReflect.Descriptor instead.
+func (*GetProtocolStateSnapshotByHeightRequest) Descriptor() ([]byte, []int) {
+	return file_snapshots_snapshots_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetProtocolStateSnapshotByHeightRequest) GetHeight() uint64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+// GetProtocolStateSnapshotByBlockIDRequest requests the snapshot as of a finalized block
+type GetProtocolStateSnapshotByBlockIDRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BlockId []byte `protobuf:"bytes,1,opt,name=blockId,proto3" json:"blockId,omitempty"` // The finalized block
+}
+
+func (x *GetProtocolStateSnapshotByBlockIDRequest) Reset() {
+	*x = GetProtocolStateSnapshotByBlockIDRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_snapshots_snapshots_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetProtocolStateSnapshotByBlockIDRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProtocolStateSnapshotByBlockIDRequest) ProtoMessage() {}
+
+func (x *GetProtocolStateSnapshotByBlockIDRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_snapshots_snapshots_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProtocolStateSnapshotByBlockIDRequest.ProtoReflect.Descriptor instead.
+func (*GetProtocolStateSnapshotByBlockIDRequest) Descriptor() ([]byte, []int) {
+	return file_snapshots_snapshots_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetProtocolStateSnapshotByBlockIDRequest) GetBlockId() []byte {
+	if x != nil {
+		return x.BlockId
+	}
+	return nil
+}
+
+// ProtocolStateSnapshotResponse contains a protocol state snapshot
+type ProtocolStateSnapshotResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SerializedSnapshot []byte `protobuf:"bytes,1,opt,name=serializedSnapshot,proto3" json:"serializedSnapshot,omitempty"` // The snapshot, serialized as for the latest snapshot of the access API
+}
+
+func (x *ProtocolStateSnapshotResponse) Reset() {
+	*x = ProtocolStateSnapshotResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_snapshots_snapshots_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProtocolStateSnapshotResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProtocolStateSnapshotResponse) ProtoMessage() {}
+
+func (x *ProtocolStateSnapshotResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_snapshots_snapshots_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProtocolStateSnapshotResponse.ProtoReflect.Descriptor instead.
+func (*ProtocolStateSnapshotResponse) Descriptor() ([]byte, []int) {
+	return file_snapshots_snapshots_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ProtocolStateSnapshotResponse) GetSerializedSnapshot() []byte {
+	if x != nil {
+		return x.SerializedSnapshot
+	}
+	return nil
+}
+
+var File_snapshots_snapshots_proto protoreflect.FileDescriptor
+
+var file_snapshots_snapshots_proto_rawDesc = []byte{
+	0x0a, 0x19, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x73, 0x2f, 0x73, 0x6e, 0x61, 0x70,
+	0x73, 0x68, 0x6f, 0x74, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x09, 0x73, 0x6e, 0x61,
+	0x70, 0x73, 0x68, 0x6f, 0x74, 0x73, 0x22, 0x41, 0x0a, 0x27, 0x47, 0x65, 0x74, 0x50, 0x72, 0x6f,
+	0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x53, 0x74, 0x61, 0x74, 0x65, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68,
+	0x6f, 0x74, 0x42, 0x79, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x22, 0x44, 0x0a, 0x28, 0x47, 0x65, 0x74,
+	0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x53, 0x74, 0x61, 0x74, 0x65, 0x53, 0x6e, 0x61,
+	0x70, 0x73, 0x68, 0x6f, 0x74, 0x42, 0x79, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x44, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x64, 0x22,
+	0x4f, 0x0a, 0x1d, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x53, 0x74, 0x61, 0x74, 0x65,
+	0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x2e, 0x0a, 0x12, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x64, 0x53, 0x6e,
+	0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x12, 0x73, 0x65,
+	0x72, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x64, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74,
+	0x32, 0x95, 0x02, 0x0a, 0x0b, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x41, 0x50, 0x49,
+	0x12, 0x80, 0x01, 0x0a, 0x20, 0x47, 0x65, 0x74, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c,
+	0x53, 0x74, 0x61, 0x74, 0x65, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x42, 0x79, 0x48,
+	0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x32, 0x2e, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74,
+	0x73, 0x2e, 0x47, 0x65, 0x74, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x53, 0x74, 0x61,
+	0x74, 0x65, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x42, 0x79, 0x48, 0x65, 0x69, 0x67,
+	0x68, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x28, 0x2e, 0x73, 0x6e, 0x61, 0x70,
+	0x73, 0x68, 0x6f, 0x74, 0x73, 0x2e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x53, 0x74,
+	0x61, 0x74, 0x65, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x82, 0x01, 0x0a, 0x21, 0x47, 0x65, 0x74, 0x50, 0x72, 0x6f, 0x74, 0x6f,
+	0x63, 0x6f, 0x6c, 0x53, 0x74, 0x61, 0x74, 0x65, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74,
+	0x42, 0x79, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x44, 0x12, 0x33, 0x2e, 0x73, 0x6e, 0x61, 0x70,
+	0x73, 0x68, 0x6f, 0x74, 0x73, 0x2e, 0x47, 0x65, 0x74, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f,
+	0x6c, 0x53, 0x74, 0x61, 0x74, 0x65, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x42, 0x79,
+	0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x44, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x28,
+	0x2e, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x73, 0x2e, 0x50, 0x72, 0x6f, 0x74, 0x6f,
+	0x63, 0x6f, 0x6c, 0x53, 0x74, 0x61, 0x74, 0x65, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x41, 0x5a, 0x3f, 0x67, 0x69, 0x74, 0x68,
+	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6f, 0x6e, 0x66, 0x6c, 0x6f, 0x77, 0x2f, 0x66, 0x6c,
+	0x6f, 0x77, 0x2d, 0x67, 0x6f, 0x2f, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x2f, 0x63, 0x6f, 0x6d,
+	0x6d, 0x6f, 0x6e, 0x2f, 0x72, 0x70, 0x63, 0x2f, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74,
+	0x73, 0x2f, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
+}
+
+var (
+	file_snapshots_snapshots_proto_rawDescOnce sync.Once
+	file_snapshots_snapshots_proto_rawDescData = file_snapshots_snapshots_proto_rawDesc
+)
+
+func file_snapshots_snapshots_proto_rawDescGZIP() []byte {
+	file_snapshots_snapshots_proto_rawDescOnce.Do(func() {
+		file_snapshots_snapshots_proto_rawDescData = protoimpl.X.CompressGZIP(file_snapshots_snapshots_proto_rawDescData)
+	})
+	return file_snapshots_snapshots_proto_rawDescData
+}
+
+var file_snapshots_snapshots_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_snapshots_snapshots_proto_goTypes = []interface{}{
+	(*GetProtocolStateSnapshotByHeightRequest)(nil),  // 0: snapshots.GetProtocolStateSnapshotByHeightRequest
+	(*GetProtocolStateSnapshotByBlockIDRequest)(nil), // 1: snapshots.GetProtocolStateSnapshotByBlockIDRequest
+	(*ProtocolStateSnapshotResponse)(nil),            // 2: snapshots.ProtocolStateSnapshotResponse
+}
+var file_snapshots_snapshots_proto_depIdxs = []int32{
+	0, // 0: snapshots.SnapshotAPI.GetProtocolStateSnapshotByHeight:input_type -> snapshots.GetProtocolStateSnapshotByHeightRequest
+	1, // 1: snapshots.SnapshotAPI.GetProtocolStateSnapshotByBlockID:input_type -> snapshots.GetProtocolStateSnapshotByBlockIDRequest
+	2, // 2: snapshots.SnapshotAPI.GetProtocolStateSnapshotByHeight:output_type -> snapshots.ProtocolStateSnapshotResponse
+	2, // 3: snapshots.SnapshotAPI.GetProtocolStateSnapshotByBlockID:output_type -> snapshots.ProtocolStateSnapshotResponse
+	2, // [2:4] is the sub-list for method output_type
+	0, // [0:2] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_snapshots_snapshots_proto_init() }
+func file_snapshots_snapshots_proto_init() {
+	if File_snapshots_snapshots_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_snapshots_snapshots_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetProtocolStateSnapshotByHeightRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_snapshots_snapshots_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetProtocolStateSnapshotByBlockIDRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_snapshots_snapshots_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProtocolStateSnapshotResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_snapshots_snapshots_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_snapshots_snapshots_proto_goTypes,
+		DependencyIndexes: file_snapshots_snapshots_proto_depIdxs,
+		MessageInfos:      file_snapshots_snapshots_proto_msgTypes,
+	}.Build()
+	File_snapshots_snapshots_proto = out.File
+	file_snapshots_snapshots_proto_rawDesc = nil
+	file_snapshots_snapshots_proto_goTypes = nil
+	file_snapshots_snapshots_proto_depIdxs = nil
+}
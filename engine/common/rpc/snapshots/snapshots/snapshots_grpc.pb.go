@@ -0,0 +1,149 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.17.1
+// source: snapshots/snapshots.proto
+
+package snapshots
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// SnapshotAPIClient is the client API for SnapshotAPI service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type SnapshotAPIClient interface {
+	// GetProtocolStateSnapshotByHeight returns the snapshot as of the finalized
+	// block at the given height.
+	GetProtocolStateSnapshotByHeight(ctx context.Context, in *GetProtocolStateSnapshotByHeightRequest, opts ...grpc.CallOption) (*ProtocolStateSnapshotResponse, error)
+	// GetProtocolStateSnapshotByBlockID returns the snapshot as of the given
+	// finalized block.
+	GetProtocolStateSnapshotByBlockID(ctx context.Context, in *GetProtocolStateSnapshotByBlockIDRequest, opts ...grpc.CallOption) (*ProtocolStateSnapshotResponse, error)
+}
+
+type snapshotAPIClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSnapshotAPIClient(cc grpc.ClientConnInterface) SnapshotAPIClient {
+	return &snapshotAPIClient{cc}
+}
+
+func (c *snapshotAPIClient) GetProtocolStateSnapshotByHeight(ctx context.Context, in *GetProtocolStateSnapshotByHeightRequest, opts ...grpc.CallOption) (*ProtocolStateSnapshotResponse, error) {
+	out := new(ProtocolStateSnapshotResponse)
+	err := c.cc.Invoke(ctx, "/snapshots.SnapshotAPI/GetProtocolStateSnapshotByHeight", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *snapshotAPIClient) GetProtocolStateSnapshotByBlockID(ctx context.Context, in *GetProtocolStateSnapshotByBlockIDRequest, opts ...grpc.CallOption) (*ProtocolStateSnapshotResponse, error) {
+	out := new(ProtocolStateSnapshotResponse)
+	err := c.cc.Invoke(ctx, "/snapshots.SnapshotAPI/GetProtocolStateSnapshotByBlockID", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SnapshotAPIServer is the server API for SnapshotAPI service.
+// All implementations must embed UnimplementedSnapshotAPIServer
+// for forward compatibility
+type SnapshotAPIServer interface {
+	// GetProtocolStateSnapshotByHeight returns the snapshot as of the finalized
+	// block at the given height.
+	GetProtocolStateSnapshotByHeight(context.Context, *GetProtocolStateSnapshotByHeightRequest) (*ProtocolStateSnapshotResponse, error)
+	// GetProtocolStateSnapshotByBlockID returns the snapshot as of the given
+	// finalized block.
+	GetProtocolStateSnapshotByBlockID(context.Context, *GetProtocolStateSnapshotByBlockIDRequest) (*ProtocolStateSnapshotResponse, error)
+	mustEmbedUnimplementedSnapshotAPIServer()
+}
+
+// UnimplementedSnapshotAPIServer must be embedded to have forward compatible implementations.
+type UnimplementedSnapshotAPIServer struct {
+}
+
+func (UnimplementedSnapshotAPIServer) GetProtocolStateSnapshotByHeight(context.Context, *GetProtocolStateSnapshotByHeightRequest) (*ProtocolStateSnapshotResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProtocolStateSnapshotByHeight not implemented")
+}
+func (UnimplementedSnapshotAPIServer) GetProtocolStateSnapshotByBlockID(context.Context, *GetProtocolStateSnapshotByBlockIDRequest) (*ProtocolStateSnapshotResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProtocolStateSnapshotByBlockID not implemented")
+}
+func (UnimplementedSnapshotAPIServer) mustEmbedUnimplementedSnapshotAPIServer() {}
+
+// UnsafeSnapshotAPIServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SnapshotAPIServer will
+// result in compilation errors.
+type UnsafeSnapshotAPIServer interface {
+	mustEmbedUnimplementedSnapshotAPIServer()
+}
+
+func RegisterSnapshotAPIServer(s grpc.ServiceRegistrar, srv SnapshotAPIServer) {
+	s.RegisterService(&SnapshotAPI_ServiceDesc, srv)
+}
+
+func _SnapshotAPI_GetProtocolStateSnapshotByHeight_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProtocolStateSnapshotByHeightRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SnapshotAPIServer).GetProtocolStateSnapshotByHeight(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/snapshots.SnapshotAPI/GetProtocolStateSnapshotByHeight",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SnapshotAPIServer).GetProtocolStateSnapshotByHeight(ctx, req.(*GetProtocolStateSnapshotByHeightRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SnapshotAPI_GetProtocolStateSnapshotByBlockID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProtocolStateSnapshotByBlockIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SnapshotAPIServer).GetProtocolStateSnapshotByBlockID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/snapshots.SnapshotAPI/GetProtocolStateSnapshotByBlockID",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SnapshotAPIServer).GetProtocolStateSnapshotByBlockID(ctx, req.(*GetProtocolStateSnapshotByBlockIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SnapshotAPI_ServiceDesc is the grpc.ServiceDesc for SnapshotAPI service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SnapshotAPI_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "snapshots.SnapshotAPI",
+	HandlerType: (*SnapshotAPIServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetProtocolStateSnapshotByHeight",
+			Handler:    _SnapshotAPI_GetProtocolStateSnapshotByHeight_Handler,
+		},
+		{
+			MethodName: "GetProtocolStateSnapshotByBlockID",
+			Handler:    _SnapshotAPI_GetProtocolStateSnapshotByBlockID_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "snapshots/snapshots.proto",
+}
@@ -0,0 +1,336 @@
+package checkpointsync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	pb "github.com/onflow/flow-go/engine/common/rpc/checkpoints/checkpoints"
+	statemock "github.com/onflow/flow-go/engine/execution/state/mock"
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/utils"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
+	"github.com/onflow/flow-go/ledger/complete/wal"
+	bootstrapFilenames "github.com/onflow/flow-go/model/bootstrap"
+	"github.com/onflow/flow-go/model/flow"
+	protocol "github.com/onflow/flow-go/state/protocol/mock"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/utils/grpcutils"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+type fakeLedger struct {
+	tries map[ledger.State]*trie.MTrie
+}
+
+func (l *fakeLedger) Trie(state ledger.State) (*trie.MTrie, error) {
+	t, ok := l.tries[state]
+	if !ok {
+		return nil, fmt.Errorf("unknown state %v", state)
+	}
+	return t, nil
+}
+
+// fakeCheckpoints holds the tries of the latest and of the root checkpoint, and counts how often they are loaded.
+type fakeCheckpoints struct {
+	latest []*trie.MTrie
+	root   []*trie.MTrie
+	loads  int
+}
+
+func (c *fakeCheckpoints) LatestCheckpoint() (int, error) {
+	if c.latest == nil {
+		return -1, nil
+	}
+	return 1, nil
+}
+
+func (c *fakeCheckpoints) LoadCheckpoint(checkpoint int) ([]*trie.MTrie, error) {
+	if c.latest == nil || checkpoint != 1 {
+		return nil, fmt.Errorf("unknown checkpoint %d", checkpoint)
+	}
+	c.loads++
+	return c.latest, nil
+}
+
+func (c *fakeCheckpoints) HasRootCheckpoint() (bool, error) {
+	return c.root != nil, nil
+}
+
+func (c *fakeCheckpoints) LoadRootCheckpoint() ([]*trie.MTrie, error) {
+	if c.root == nil {
+		return nil, fmt.Errorf("no root checkpoint")
+	}
+	c.loads++
+	return c.root, nil
+}
+
+// interruptingServer fails the first stream of subtries after the given number of chunks.
+type interruptingServer struct {
+	*Server
+	chunks      int
+	interrupted bool
+}
+
+type interruptingStream struct {
+	pb.CheckpointAPI_GetCheckpointSubtriesServer
+	remaining int
+}
+
+func (s *interruptingStream) Send(chunk *pb.SubtrieChunk) error {
+	if s.remaining == 0 {
+		return status.Error(codes.Unavailable, "interrupted")
+	}
+	s.remaining--
+	return s.CheckpointAPI_GetCheckpointSubtriesServer.Send(chunk)
+}
+
+func (s *interruptingServer) GetCheckpointSubtries(req *pb.GetCheckpointSubtriesRequest, stream pb.CheckpointAPI_GetCheckpointSubtriesServer) error {
+	if s.interrupted {
+		return s.Server.GetCheckpointSubtries(req, stream)
+	}
+	s.interrupted = true
+	return s.Server.GetCheckpointSubtries(req, &interruptingStream{stream, s.chunks})
+}
+
+type suite struct {
+	sealed      flow.Header
+	unsealed    flow.Header
+	commit      flow.StateCommitment
+	trie        *trie.MTrie
+	checkpoints *fakeCheckpoints
+	server      *Server
+}
+
+func randomTrie(t *testing.T, registers int) *trie.MTrie {
+	paths := utils.RandomPaths(registers)
+	payloads := make([]ledger.Payload, len(paths))
+	for i, p := range utils.RandomPayloads(len(paths), 1, 100) {
+		payloads[i] = *p
+	}
+	tr, err := trie.NewTrieWithUpdatedRegisters(trie.NewEmptyMTrie(), paths, payloads, true)
+	require.NoError(t, err)
+	return tr
+}
+
+// newSuite creates a server serving the execution state at a sealed block, with the given chunk size.
+func newSuite(t *testing.T, chunkSize int) *suite {
+	s := &suite{}
+	s.sealed = unittest.BlockHeaderFixture()
+	s.unsealed = unittest.BlockHeaderWithParentFixture(&s.sealed)
+	s.trie = randomTrie(t, 1000)
+	s.commit = flow.StateCommitment(s.trie.RootHash())
+
+	state := new(protocol.State)
+	sealedSnapshot := new(protocol.Snapshot)
+	sealedSnapshot.On("Head").Return(&s.sealed, nil)
+	unsealedSnapshot := new(protocol.Snapshot)
+	unsealedSnapshot.On("Head").Return(&s.unsealed, nil)
+	unknownSnapshot := new(protocol.Snapshot)
+	unknownSnapshot.On("Head").Return(nil, storage.ErrNotFound)
+
+	state.On("AtBlockID", s.sealed.ID()).Return(sealedSnapshot)
+	state.On("AtBlockID", s.unsealed.ID()).Return(unsealedSnapshot)
+	state.On("AtBlockID", mock.Anything).Return(unknownSnapshot)
+	state.On("AtHeight", s.sealed.Height).Return(sealedSnapshot)
+	state.On("AtHeight", s.unsealed.Height).Return(unsealedSnapshot)
+	state.On("AtHeight", mock.Anything).Return(unknownSnapshot)
+	state.On("Sealed").Return(sealedSnapshot)
+
+	execState := new(statemock.ReadOnlyExecutionState)
+	execState.On("StateCommitmentByBlockID", mock.Anything, s.sealed.ID()).Return(s.commit, nil)
+
+	ledger := &fakeLedger{tries: map[ledger.State]*trie.MTrie{ledger.State(s.commit): s.trie}}
+
+	s.checkpoints = &fakeCheckpoints{}
+
+	s.server = NewServer(zerolog.Nop(), state, execState, ledger, s.checkpoints, chunkSize)
+	return s
+}
+
+// serve serves the given checkpoint API server, and returns a client connected to it.
+func serve(t *testing.T, srv pb.CheckpointAPIServer) pb.CheckpointAPIClient {
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	pb.RegisterCheckpointAPIServer(server, srv)
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return listener.Dial()
+		}),
+		grpc.WithInsecure(), //nolint:staticcheck
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return pb.NewCheckpointAPIClient(conn)
+}
+
+func TestDownload(t *testing.T) {
+	s := newSuite(t, 64)
+
+	t.Run("download", func(t *testing.T) {
+		client := NewClient(zerolog.Nop(), serve(t, s.server), DefaultPartitionDepth, 0, time.Millisecond)
+		downloaded, err := client.Download(context.Background(), s.sealed.ID(), s.commit)
+		require.NoError(t, err)
+		assert.True(t, downloaded.Equals(s.trie))
+		assert.ElementsMatch(t, s.trie.AllPayloads(), downloaded.AllPayloads())
+	})
+
+	t.Run("download at height", func(t *testing.T) {
+		client := NewClient(zerolog.Nop(), serve(t, s.server), DefaultPartitionDepth, 0, time.Millisecond)
+		downloaded, err := client.DownloadAtHeight(context.Background(), s.sealed.Height, s.commit)
+		require.NoError(t, err)
+		assert.True(t, downloaded.Equals(s.trie))
+	})
+
+	t.Run("resume interrupted transfer", func(t *testing.T) {
+		srv := &interruptingServer{Server: s.server, chunks: 100}
+		client := NewClient(zerolog.Nop(), serve(t, srv), DefaultPartitionDepth, 1, time.Millisecond)
+		downloaded, err := client.Download(context.Background(), s.sealed.ID(), s.commit)
+		require.NoError(t, err)
+		assert.True(t, srv.interrupted)
+		assert.True(t, downloaded.Equals(s.trie))
+	})
+
+	t.Run("mismatching state commitment", func(t *testing.T) {
+		client := NewClient(zerolog.Nop(), serve(t, s.server), DefaultPartitionDepth, 0, time.Millisecond)
+		_, err := client.Download(context.Background(), s.sealed.ID(), unittest.StateCommitmentFixture())
+		assert.ErrorIs(t, err, ErrInvalidData)
+	})
+
+	t.Run("unsealed block", func(t *testing.T) {
+		client := NewClient(zerolog.Nop(), serve(t, s.server), DefaultPartitionDepth, 0, time.Millisecond)
+		_, err := client.Download(context.Background(), s.unsealed.ID(), s.commit)
+		require.Error(t, err)
+		assert.Equal(t, codes.FailedPrecondition, status.Code(errors.Unwrap(err)))
+	})
+
+	t.Run("unsealed height", func(t *testing.T) {
+		client := NewClient(zerolog.Nop(), serve(t, s.server), DefaultPartitionDepth, 0, time.Millisecond)
+		_, err := client.DownloadAtHeight(context.Background(), s.unsealed.Height, s.commit)
+		require.Error(t, err)
+		assert.Equal(t, codes.FailedPrecondition, status.Code(errors.Unwrap(err)))
+	})
+
+	t.Run("unknown block", func(t *testing.T) {
+		client := NewClient(zerolog.Nop(), serve(t, s.server), DefaultPartitionDepth, 0, time.Millisecond)
+		_, err := client.Download(context.Background(), unittest.IdentifierFixture(), s.commit)
+		require.Error(t, err)
+		assert.Equal(t, codes.NotFound, status.Code(errors.Unwrap(err)))
+	})
+
+	t.Run("data not matching state commitment", func(t *testing.T) {
+		// the peer serves a different trie than the one the block was sealed with
+		other := newSuite(t, 64)
+		other.server.ledger = &fakeLedger{tries: map[ledger.State]*trie.MTrie{ledger.State(s.commit): other.trie}}
+		other.server.execState = s.server.execState
+		other.server.state = s.server.state
+
+		client := NewClient(zerolog.Nop(), serve(t, other.server), DefaultPartitionDepth, 0, time.Millisecond)
+		_, err := client.Download(context.Background(), s.sealed.ID(), s.commit)
+		assert.ErrorIs(t, err, ErrInvalidData)
+	})
+}
+
+func TestDownloadFromCheckpoint(t *testing.T) {
+	newSuiteWithoutTrie := func(t *testing.T) *suite {
+		s := newSuite(t, 64)
+		// the trie is no longer held in memory
+		s.server.ledger = &fakeLedger{tries: map[ledger.State]*trie.MTrie{}}
+		return s
+	}
+
+	t.Run("latest checkpoint", func(t *testing.T) {
+		s := newSuiteWithoutTrie(t)
+		s.checkpoints.latest = []*trie.MTrie{randomTrie(t, 10), s.trie}
+
+		client := NewClient(zerolog.Nop(), serve(t, s.server), DefaultPartitionDepth, 0, time.Millisecond)
+		downloaded, err := client.Download(context.Background(), s.sealed.ID(), s.commit)
+		require.NoError(t, err)
+		assert.True(t, downloaded.Equals(s.trie))
+		// the loaded trie is kept for the request of the subtries
+		assert.Equal(t, 1, s.checkpoints.loads)
+	})
+
+	t.Run("root checkpoint", func(t *testing.T) {
+		s := newSuiteWithoutTrie(t)
+		s.checkpoints.latest = []*trie.MTrie{randomTrie(t, 10)}
+		s.checkpoints.root = []*trie.MTrie{s.trie}
+
+		client := NewClient(zerolog.Nop(), serve(t, s.server), DefaultPartitionDepth, 0, time.Millisecond)
+		downloaded, err := client.Download(context.Background(), s.sealed.ID(), s.commit)
+		require.NoError(t, err)
+		assert.True(t, downloaded.Equals(s.trie))
+	})
+
+	t.Run("not in any checkpoint", func(t *testing.T) {
+		s := newSuiteWithoutTrie(t)
+		s.checkpoints.latest = []*trie.MTrie{randomTrie(t, 10)}
+
+		client := NewClient(zerolog.Nop(), serve(t, s.server), DefaultPartitionDepth, 0, time.Millisecond)
+		_, err := client.Download(context.Background(), s.sealed.ID(), s.commit)
+		require.Error(t, err)
+		assert.Equal(t, codes.NotFound, status.Code(errors.Unwrap(err)))
+	})
+}
+
+func TestBootstrapFromPeer(t *testing.T) {
+	s := newSuite(t, DefaultChunkSize)
+
+	networkKey := unittest.NetworkingPrivKeyFixture()
+	cert, err := grpcutils.X509Certificate(networkKey)
+	require.NoError(t, err)
+
+	listener, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	server := grpc.NewServer(grpc.Creds(credentials.NewTLS(grpcutils.DefaultServerTLSConfig(cert))))
+	pb.RegisterCheckpointAPIServer(server, s.server)
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	defer server.Stop()
+
+	t.Run("bootstrap", func(t *testing.T) {
+		unittest.RunWithTempDir(t, func(dir string) {
+			triedir := filepath.Join(dir, "trie")
+			peer := Peer{Address: listener.Addr().String(), NetworkPubKey: networkKey.PublicKey()}
+			err := BootstrapFromPeer(context.Background(), zerolog.Nop(), peer, s.sealed.ID(), s.commit, triedir)
+			require.NoError(t, err)
+
+			tries, err := wal.LoadCheckpoint(filepath.Join(triedir, bootstrapFilenames.FilenameWALRootCheckpoint))
+			require.NoError(t, err)
+			require.Len(t, tries, 1)
+			assert.True(t, tries[0].Equals(s.trie))
+		})
+	})
+
+	t.Run("peer with unexpected network key", func(t *testing.T) {
+		unittest.RunWithTempDir(t, func(dir string) {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			peer := Peer{Address: listener.Addr().String(), NetworkPubKey: unittest.NetworkingPrivKeyFixture().PublicKey()}
+			err := BootstrapFromPeer(ctx, zerolog.Nop(), peer, s.sealed.ID(), s.commit, filepath.Join(dir, "trie"))
+			require.Error(t, err)
+		})
+	})
+}
@@ -0,0 +1,278 @@
+package checkpointsync
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/onflow/flow-go/crypto"
+	"github.com/onflow/flow-go/engine/common/rpc/checkpoints"
+	pb "github.com/onflow/flow-go/engine/common/rpc/checkpoints/checkpoints"
+	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/node"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/partition"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
+	"github.com/onflow/flow-go/ledger/complete/wal"
+	bootstrapFilenames "github.com/onflow/flow-go/model/bootstrap"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/grpcutils"
+)
+
+const (
+	// DefaultPartitionDepth is the depth the execution state trie is partitioned at,
+	// which splits it into at most 4096 subtries.
+	DefaultPartitionDepth = 12
+
+	// DefaultMaxRetries is the number of times the transfer of the subtries is
+	// resumed after it failed, without any subtrie being received in between.
+	DefaultMaxRetries = 5
+
+	// DefaultRetryInterval is the time waited before an interrupted transfer is resumed.
+	DefaultRetryInterval = 5 * time.Second
+)
+
+// ErrInvalidData is returned if the data received from the peer does not match
+// the state commitment the block was sealed with.
+var ErrInvalidData = errors.New("invalid checkpoint data")
+
+// Peer is an execution node serving the execution state at sealed blocks.
+type Peer struct {
+	// Address is the address of the secure gRPC server of the peer.
+	Address string
+	// NetworkPubKey is the networking key of the peer, which its TLS certificate is verified against.
+	NetworkPubKey crypto.PublicKey
+}
+
+// Client downloads the execution state at a sealed block from a peer.
+type Client struct {
+	log           zerolog.Logger
+	client        pb.CheckpointAPIClient
+	depth         int
+	maxRetries    int
+	retryInterval time.Duration
+}
+
+func NewClient(
+	log zerolog.Logger,
+	client pb.CheckpointAPIClient,
+	depth int,
+	maxRetries int,
+	retryInterval time.Duration,
+) *Client {
+	return &Client{
+		log:           log.With().Str("component", "checkpoint_client").Logger(),
+		client:        client,
+		depth:         depth,
+		maxRetries:    maxRetries,
+		retryInterval: retryInterval,
+	}
+}
+
+// Download downloads the execution state trie at the given sealed block, and verifies it
+// against the state commitment the block was sealed with. It returns ErrInvalidData if the
+// peer sent data that doesn't match the state commitment.
+func (c *Client) Download(ctx context.Context, blockID flow.Identifier, commit flow.StateCommitment) (*trie.MTrie, error) {
+	return c.download(ctx, &pb.GetCheckpointPartitionRequest{
+		BlockId: convert.IdentifierToMessage(blockID),
+		Depth:   uint32(c.depth),
+	}, commit)
+}
+
+// DownloadAtHeight downloads the execution state trie at the sealed block at the given height,
+// and verifies it against the state commitment the block was sealed with. It returns
+// ErrInvalidData if the peer sent data that doesn't match the state commitment.
+func (c *Client) DownloadAtHeight(ctx context.Context, height uint64, commit flow.StateCommitment) (*trie.MTrie, error) {
+	return c.download(ctx, &pb.GetCheckpointPartitionRequest{
+		Height: height,
+		Depth:  uint32(c.depth),
+	}, commit)
+}
+
+func (c *Client) download(ctx context.Context, req *pb.GetCheckpointPartitionRequest, commit flow.StateCommitment) (*trie.MTrie, error) {
+	resp, err := c.client.GetCheckpointPartition(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("could not get checkpoint partition: %w", err)
+	}
+
+	if !bytes.Equal(resp.GetStateCommitment(), commit[:]) {
+		return nil, fmt.Errorf("%w: peer has state commitment %x, but block was sealed with %x", ErrInvalidData, resp.GetStateCommitment(), commit)
+	}
+	if len(req.GetBlockId()) > 0 && !bytes.Equal(resp.GetBlockId(), req.GetBlockId()) {
+		return nil, fmt.Errorf("%w: peer sent the partition of block %x, but block %x was requested", ErrInvalidData, resp.GetBlockId(), req.GetBlockId())
+	}
+	blockID, err := convert.BlockID(resp.GetBlockId())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidData, err)
+	}
+
+	subtries := make([]partition.Subtrie, len(resp.GetSubtries()))
+	for i, m := range resp.GetSubtries() {
+		subtries[i], err = checkpoints.MessageToSubtrie(m)
+		if err != nil {
+			return nil, fmt.Errorf("%w: subtrie %d: %v", ErrInvalidData, i, err)
+		}
+	}
+	err = partition.VerifySubtries(subtries, ledger.RootHash(commit))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidData, err)
+	}
+
+	c.log.Info().
+		Hex("block_id", blockID[:]).
+		Uint64("height", resp.GetHeight()).
+		Int("subtries", len(subtries)).
+		Msg("verified checkpoint partition, downloading subtries")
+
+	roots := make([]*node.Node, 0, len(subtries))
+	retries := 0
+	for len(roots) < len(subtries) {
+		received, err := c.downloadSubtries(ctx, blockID, subtries, roots)
+		if errors.Is(err, ErrInvalidData) {
+			return nil, err
+		}
+		if len(received) > len(roots) {
+			retries = 0
+		}
+		roots = received
+		if err == nil {
+			break
+		}
+
+		retries++
+		if retries > c.maxRetries {
+			return nil, fmt.Errorf("could not download subtries after %d retries: %w", c.maxRetries, err)
+		}
+		c.log.Warn().Err(err).
+			Int("received", len(roots)).
+			Int("total", len(subtries)).
+			Msg("subtrie transfer failed, resuming")
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(c.retryInterval):
+		}
+	}
+
+	if len(roots) != len(subtries) {
+		return nil, fmt.Errorf("%w: received %d of %d subtries", ErrInvalidData, len(roots), len(subtries))
+	}
+
+	t, err := partition.Assemble(subtries, roots, ledger.RootHash(commit))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidData, err)
+	}
+
+	c.log.Info().
+		Hex("block_id", blockID[:]).
+		Uint64("registers", t.AllocatedRegCount()).
+		Msg("checkpoint downloaded")
+
+	return t, nil
+}
+
+// downloadSubtries streams the subtries following the given, already received ones. It returns
+// all subtries received so far, also when the stream fails.
+func (c *Client) downloadSubtries(ctx context.Context, blockID flow.Identifier, subtries []partition.Subtrie, roots []*node.Node) ([]*node.Node, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := c.client.GetCheckpointSubtries(ctx, &pb.GetCheckpointSubtriesRequest{
+		BlockId:    convert.IdentifierToMessage(blockID),
+		Depth:      uint32(c.depth),
+		StartIndex: uint32(len(roots)),
+	})
+	if err != nil {
+		return roots, fmt.Errorf("could not request subtries: %w", err)
+	}
+
+	var data []byte
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			return roots, nil
+		}
+		if err != nil {
+			return roots, fmt.Errorf("could not receive subtrie: %w", err)
+		}
+
+		index := len(roots)
+		if int(chunk.GetIndex()) != index {
+			return roots, fmt.Errorf("%w: received chunk of subtrie %d, expected %d", ErrInvalidData, chunk.GetIndex(), index)
+		}
+
+		data = append(data, chunk.GetData()...)
+		if !chunk.GetLast() {
+			continue
+		}
+
+		root, err := partition.DecodeSubtrie(data, subtries[index])
+		if err != nil {
+			return roots, fmt.Errorf("%w: subtrie %d: %v", ErrInvalidData, index, err)
+		}
+		roots = append(roots, root)
+		data = nil
+
+		if len(roots)%100 == 0 {
+			c.log.Info().
+				Int("received", len(roots)).
+				Int("total", len(subtries)).
+				Msg("downloading subtries")
+		}
+	}
+}
+
+// BootstrapFromPeer downloads the execution state at the given sealed block from the given peer,
+// and stores it as the root checkpoint in the given trie directory. The connection to the peer is
+// secured by TLS, and the certificate of the peer is verified against its networking key.
+func BootstrapFromPeer(ctx context.Context, log zerolog.Logger, peer Peer, blockID flow.Identifier, commit flow.StateCommitment, dir string) error {
+	tlsConfig, err := grpcutils.DefaultClientTLSConfig(peer.NetworkPubKey)
+	if err != nil {
+		return fmt.Errorf("could not create TLS config for %s: %w", peer.Address, err)
+	}
+
+	conn, err := grpc.Dial(peer.Address,
+		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(grpcutils.DefaultMaxMsgSize)),
+		grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)),
+	)
+	if err != nil {
+		return fmt.Errorf("could not connect to %s: %w", peer.Address, err)
+	}
+	defer conn.Close()
+
+	client := NewClient(log, pb.NewCheckpointAPIClient(conn), DefaultPartitionDepth, DefaultMaxRetries, DefaultRetryInterval)
+	t, err := client.Download(ctx, blockID, commit)
+	if err != nil {
+		return fmt.Errorf("could not download checkpoint from %s: %w", peer.Address, err)
+	}
+
+	err = os.MkdirAll(dir, 0700)
+	if err != nil {
+		return fmt.Errorf("could not create trie directory: %w", err)
+	}
+
+	writer, err := wal.CreateCheckpointWriterForFile(dir, bootstrapFilenames.FilenameWALRootCheckpoint)
+	if err != nil {
+		return fmt.Errorf("could not create root checkpoint: %w", err)
+	}
+	err = wal.StoreCheckpoint(writer, t)
+	if err != nil {
+		_ = writer.Close()
+		return fmt.Errorf("could not store root checkpoint: %w", err)
+	}
+	err = writer.Close()
+	if err != nil {
+		return fmt.Errorf("could not close root checkpoint: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,308 @@
+package checkpointsync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/engine/common/rpc/checkpoints"
+	pb "github.com/onflow/flow-go/engine/common/rpc/checkpoints/checkpoints"
+	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	"github.com/onflow/flow-go/engine/execution/state"
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/node"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/partition"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/state/protocol"
+	"github.com/onflow/flow-go/storage"
+)
+
+// DefaultChunkSize is the maximum size of the data of a subtrie chunk, in bytes.
+const DefaultChunkSize = 1024 * 1024
+
+// Ledger provides the execution state tries held in memory.
+type Ledger interface {
+	Trie(state ledger.State) (*trie.MTrie, error)
+}
+
+// Checkpoints provides the execution state tries stored in the checkpoints of the ledger.
+// It is implemented by wal.Checkpointer.
+type Checkpoints interface {
+	LatestCheckpoint() (int, error)
+	LoadCheckpoint(checkpoint int) ([]*trie.MTrie, error)
+	HasRootCheckpoint() (bool, error)
+	LoadRootCheckpoint() ([]*trie.MTrie, error)
+}
+
+// Server serves the execution state at sealed blocks to other execution nodes.
+// The states whose tries are no longer held in memory are loaded from the latest
+// checkpoint, or from the root checkpoint.
+type Server struct {
+	pb.UnimplementedCheckpointAPIServer
+
+	log         zerolog.Logger
+	state       protocol.State
+	execState   state.ReadOnlyExecutionState
+	ledger      Ledger
+	checkpoints Checkpoints
+	chunkSize   int
+
+	mu     sync.Mutex
+	loaded *trie.MTrie // the trie last loaded from a checkpoint, kept for the transfers resumed by clients
+}
+
+var _ pb.CheckpointAPIServer = (*Server)(nil)
+
+func NewServer(
+	log zerolog.Logger,
+	state protocol.State,
+	execState state.ReadOnlyExecutionState,
+	ledger Ledger,
+	checkpoints Checkpoints,
+	chunkSize int,
+) *Server {
+	return &Server{
+		log:         log.With().Str("component", "checkpoint_server").Logger(),
+		state:       state,
+		execState:   execState,
+		ledger:      ledger,
+		checkpoints: checkpoints,
+		chunkSize:   chunkSize,
+	}
+}
+
+// GetCheckpointPartition returns the descriptions of the subtries of the execution state trie at a sealed block.
+// The block is identified by its ID, or by its height if no ID is given.
+func (s *Server) GetCheckpointPartition(ctx context.Context, req *pb.GetCheckpointPartitionRequest) (*pb.GetCheckpointPartitionResponse, error) {
+	var header *flow.Header
+	var err error
+	if len(req.GetBlockId()) == 0 {
+		header, err = s.sealedBlockAtHeight(req.GetHeight())
+	} else {
+		header, err = s.sealedBlock(req.GetBlockId())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	blockID := header.ID()
+	commit, subtries, _, err := s.partition(ctx, blockID, req.GetDepth())
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.Info().
+		Hex("block_id", blockID[:]).
+		Uint64("height", header.Height).
+		Int("subtries", len(subtries)).
+		Msg("serving checkpoint partition")
+
+	resp := &pb.GetCheckpointPartitionResponse{
+		BlockId:         convert.IdentifierToMessage(blockID),
+		Height:          header.Height,
+		StateCommitment: convert.StateCommitmentToMessage(commit),
+		Subtries:        make([]*pb.Subtrie, len(subtries)),
+	}
+	for i, subtrie := range subtries {
+		resp.Subtries[i] = checkpoints.SubtrieToMessage(subtrie)
+	}
+	return resp, nil
+}
+
+// GetCheckpointSubtries streams the subtries of the execution state trie at a sealed block,
+// starting with the subtrie at the requested index.
+func (s *Server) GetCheckpointSubtries(req *pb.GetCheckpointSubtriesRequest, stream pb.CheckpointAPI_GetCheckpointSubtriesServer) error {
+	header, err := s.sealedBlock(req.GetBlockId())
+	if err != nil {
+		return err
+	}
+
+	_, _, roots, err := s.partition(stream.Context(), header.ID(), req.GetDepth())
+	if err != nil {
+		return err
+	}
+	startIndex := int(req.GetStartIndex())
+	if startIndex > len(roots) {
+		return status.Errorf(codes.InvalidArgument, "invalid start index %d for %d subtries", startIndex, len(roots))
+	}
+
+	for i := startIndex; i < len(roots); i++ {
+		data := partition.EncodeSubtrie(roots[i])
+		for start := 0; ; start += s.chunkSize {
+			end := start + s.chunkSize
+			if end > len(data) {
+				end = len(data)
+			}
+			err := stream.Send(&pb.SubtrieChunk{
+				Index: uint32(i),
+				Data:  data[start:end],
+				Last:  end == len(data),
+			})
+			if err != nil {
+				return err
+			}
+			if end == len(data) {
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// sealedBlock returns the header of the block with the given ID, which must be sealed.
+func (s *Server) sealedBlock(id []byte) (*flow.Header, error) {
+	blockID, err := convert.BlockID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	header, err := s.state.AtBlockID(blockID).Head()
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "unknown block %v: %v", blockID, err)
+	}
+
+	err = s.checkSealed(header)
+	if err != nil {
+		return nil, err
+	}
+
+	finalized, err := s.state.AtHeight(header.Height).Head()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "could not get finalized block at height %d: %v", header.Height, err)
+	}
+	if finalized.ID() != blockID {
+		return nil, status.Errorf(codes.FailedPrecondition, "block %v is not finalized", blockID)
+	}
+
+	return header, nil
+}
+
+// sealedBlockAtHeight returns the header of the finalized block at the given height, which must be sealed.
+func (s *Server) sealedBlockAtHeight(height uint64) (*flow.Header, error) {
+	header, err := s.state.AtHeight(height).Head()
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "no finalized block at height %d: %v", height, err)
+	}
+
+	err = s.checkSealed(header)
+	if err != nil {
+		return nil, err
+	}
+
+	return header, nil
+}
+
+func (s *Server) checkSealed(header *flow.Header) error {
+	sealed, err := s.state.Sealed().Head()
+	if err != nil {
+		return status.Errorf(codes.Internal, "could not get sealed block: %v", err)
+	}
+	if header.Height > sealed.Height {
+		return status.Errorf(codes.FailedPrecondition, "block %v at height %d is not sealed", header.ID(), header.Height)
+	}
+	return nil
+}
+
+// partition partitions the execution state trie at the given sealed block.
+func (s *Server) partition(ctx context.Context, blockID flow.Identifier, depth uint32) (flow.StateCommitment, []partition.Subtrie, []*node.Node, error) {
+	if depth > partition.MaxDepth {
+		return flow.DummyStateCommitment, nil, nil, status.Errorf(codes.InvalidArgument, "invalid depth %d, must be at most %d", depth, partition.MaxDepth)
+	}
+
+	commit, err := s.execState.StateCommitmentByBlockID(ctx, blockID)
+	if errors.Is(err, storage.ErrNotFound) {
+		return flow.DummyStateCommitment, nil, nil, status.Errorf(codes.NotFound, "block %v is not executed", blockID)
+	}
+	if err != nil {
+		return flow.DummyStateCommitment, nil, nil, status.Errorf(codes.Internal, "could not get state commitment: %v", err)
+	}
+
+	t, err := s.trie(commit)
+	if err != nil {
+		return flow.DummyStateCommitment, nil, nil, status.Errorf(codes.NotFound, "execution state at block %v is not available: %v", blockID, err)
+	}
+
+	subtries, roots, err := partition.Partition(t, int(depth))
+	if err != nil {
+		return flow.DummyStateCommitment, nil, nil, status.Errorf(codes.Internal, "could not partition execution state: %v", err)
+	}
+
+	return commit, subtries, roots, nil
+}
+
+// trie returns the execution state trie with the given state commitment. Tries which are no
+// longer held in memory are loaded from the latest checkpoint, or from the root checkpoint.
+func (s *Server) trie(commit flow.StateCommitment) (*trie.MTrie, error) {
+	t, err := s.ledger.Trie(ledger.State(commit))
+	if err == nil {
+		return t, nil
+	}
+
+	// loading a checkpoint takes long and uses a lot of memory, so only one is loaded at a time,
+	// and the trie is kept for the requests of the subtries following the request of the partition
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.loaded != nil && flow.StateCommitment(s.loaded.RootHash()) == commit {
+		return s.loaded, nil
+	}
+
+	t, err = s.loadFromCheckpoint(commit)
+	if err != nil {
+		return nil, err
+	}
+	s.loaded = t
+	return t, nil
+}
+
+func (s *Server) loadFromCheckpoint(commit flow.StateCommitment) (*trie.MTrie, error) {
+	latest, err := s.checkpoints.LatestCheckpoint()
+	if err != nil {
+		return nil, fmt.Errorf("could not get latest checkpoint: %w", err)
+	}
+	if latest >= 0 {
+		s.log.Info().Int("checkpoint", latest).Hex("state_commitment", commit[:]).Msg("loading trie from latest checkpoint")
+
+		tries, err := s.checkpoints.LoadCheckpoint(latest)
+		if err != nil {
+			return nil, fmt.Errorf("could not load checkpoint %d: %w", latest, err)
+		}
+		if t := findTrie(tries, commit); t != nil {
+			return t, nil
+		}
+	}
+
+	hasRoot, err := s.checkpoints.HasRootCheckpoint()
+	if err != nil {
+		return nil, fmt.Errorf("could not check for root checkpoint: %w", err)
+	}
+	if hasRoot {
+		s.log.Info().Hex("state_commitment", commit[:]).Msg("loading trie from root checkpoint")
+
+		tries, err := s.checkpoints.LoadRootCheckpoint()
+		if err != nil {
+			return nil, fmt.Errorf("could not load root checkpoint: %w", err)
+		}
+		if t := findTrie(tries, commit); t != nil {
+			return t, nil
+		}
+	}
+
+	return nil, fmt.Errorf("trie is neither held in memory nor in the latest or root checkpoint")
+}
+
+func findTrie(tries []*trie.MTrie, commit flow.StateCommitment) *trie.MTrie {
+	for _, t := range tries {
+		if flow.StateCommitment(t.RootHash()) == commit {
+			return t
+		}
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package comparison
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/engine/common/rpc/comparison"
+	pb "github.com/onflow/flow-go/engine/common/rpc/comparison/comparison"
+	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/grpcutils"
+)
+
+// Fetch fetches the execution of a block from the node served by the given client.
+// It returns ErrNotExecuted if the node did not execute the block.
+func Fetch(ctx context.Context, client pb.ComparisonAPIClient, blockID flow.Identifier) (*BlockExecution, error) {
+	resp, err := client.GetBlockExecution(ctx, &pb.GetBlockExecutionRequest{
+		BlockId: convert.IdentifierToMessage(blockID),
+	})
+	if status.Code(err) == codes.NotFound {
+		return nil, ErrNotExecuted
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not get block execution: %w", err)
+	}
+
+	if len(resp.GetResult()) == 0 || len(resp.GetStateDelta()) == 0 {
+		return nil, fmt.Errorf("incomplete block execution received")
+	}
+
+	result, err := comparison.MessageToExecutionResult(resp.GetResult())
+	if err != nil {
+		return nil, fmt.Errorf("could not decode execution result: %w", err)
+	}
+	chunkDataPacks, err := comparison.MessagesToChunkDataPacks(resp.GetChunkDataPacks())
+	if err != nil {
+		return nil, err
+	}
+	if len(chunkDataPacks) != len(result.Chunks) {
+		return nil, fmt.Errorf("received %d chunk data packs for %d chunks", len(chunkDataPacks), len(result.Chunks))
+	}
+	stateDelta, err := comparison.MessageToStateDelta(resp.GetStateDelta())
+	if err != nil {
+		return nil, fmt.Errorf("could not decode state delta: %w", err)
+	}
+
+	return &BlockExecution{
+		Result:         result,
+		ChunkDataPacks: chunkDataPacks,
+		StateDelta:     stateDelta,
+	}, nil
+}
+
+// FetchFromNode fetches the execution of a block from the execution node with the given gRPC address.
+func FetchFromNode(ctx context.Context, address string, blockID flow.Identifier) (*BlockExecution, error) {
+	conn, err := grpc.Dial(address,
+		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(grpcutils.DefaultMaxMsgSize)),
+		grpc.WithInsecure(), //nolint:staticcheck
+	)
+	if err != nil {
+		return nil, fmt.Errorf("could not connect to %s: %w", address, err)
+	}
+	defer conn.Close()
+
+	execution, err := Fetch(ctx, pb.NewComparisonAPIClient(conn), blockID)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch block execution from %s: %w", address, err)
+	}
+	return execution, nil
+}
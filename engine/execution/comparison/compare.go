@@ -0,0 +1,372 @@
+// Package comparison compares the executions of a block by different execution nodes,
+// to find the first chunk, transaction, event or register value which differs when the
+// nodes produced different execution results.
+package comparison
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/onflow/flow-go/engine/execution/state/delta"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/messages"
+)
+
+// BlockExecution is what an execution node stored about the execution of a block.
+type BlockExecution struct {
+	Result *flow.ExecutionResult
+	// ChunkDataPacks are the chunk data packs of the chunks of the result, in the
+	// order of the chunks. Chunk data packs which were pruned are nil.
+	ChunkDataPacks []*flow.ChunkDataPack
+	StateDelta     *messages.ExecutionStateDelta
+}
+
+// Kind is what differs between two executions of a block.
+type Kind string
+
+const (
+	KindResult        Kind = "execution_result"
+	KindChunk         Kind = "chunk"
+	KindTransaction   Kind = "transaction"
+	KindEvent         Kind = "event"
+	KindRegister      Kind = "register"
+	KindChunkDataPack Kind = "chunk_data_pack"
+)
+
+// Difference is the first difference between two executions of a block.
+type Difference struct {
+	Kind Kind
+	// Field is the field which differs, e.g. the end state of a chunk or the error message of a transaction.
+	Field string
+	// ChunkIndex is the index of the chunk the difference is in, unless the kind is KindResult.
+	ChunkIndex uint64
+	// TransactionIndex and TransactionID identify the transaction of differing transactions and events.
+	TransactionIndex uint32
+	TransactionID    flow.Identifier
+	// EventIndex is the index of differing events within their transaction.
+	EventIndex uint32
+	// RegisterID is the ID of differing registers.
+	RegisterID string
+	// Left and Right are the values of the field in the compared executions.
+	Left  string
+	Right string
+}
+
+func (d *Difference) String() string {
+	var location []string
+	if d.Kind != KindResult {
+		location = append(location, fmt.Sprintf("chunk %d", d.ChunkIndex))
+	}
+	switch d.Kind {
+	case KindTransaction, KindEvent:
+		location = append(location, fmt.Sprintf("transaction %d (%v)", d.TransactionIndex, d.TransactionID))
+		if d.Kind == KindEvent {
+			location = append(location, fmt.Sprintf("event %d", d.EventIndex))
+		}
+	case KindRegister:
+		location = append(location, fmt.Sprintf("register %s", d.RegisterID))
+	}
+
+	prefix := string(d.Kind)
+	if len(location) > 0 {
+		prefix = fmt.Sprintf("%s at %s", d.Kind, strings.Join(location, ", "))
+	}
+	return fmt.Sprintf("%s: %s differs: %s != %s", prefix, d.Field, d.Left, d.Right)
+}
+
+// Compare compares two executions of the same block, and returns the first difference between
+// them, or nil if they are the same. The chunks are compared in order, and within a chunk, its
+// start state, its transactions with their events, the registers it wrote, the rest of the chunk
+// and finally its chunk data pack, so that the difference which caused the others is found first.
+// Chunk data packs are only compared if both executions still have them.
+func Compare(left, right *BlockExecution) *Difference {
+	l, r := left.Result, right.Result
+
+	if l.BlockID != r.BlockID {
+		return resultDifference("BlockID", l.BlockID.String(), r.BlockID.String())
+	}
+	// if the results of the parent block differ, the executions diverged before this block
+	if l.PreviousResultID != r.PreviousResultID {
+		return resultDifference("PreviousResultID", l.PreviousResultID.String(), r.PreviousResultID.String())
+	}
+	if len(l.Chunks) != len(r.Chunks) {
+		return resultDifference("number of chunks", fmt.Sprint(len(l.Chunks)), fmt.Sprint(len(r.Chunks)))
+	}
+
+	leftEvents := eventsByTransaction(left.StateDelta.Events)
+	rightEvents := eventsByTransaction(right.StateDelta.Events)
+
+	var txIndex uint32
+	for i := range l.Chunks {
+		lc, rc := l.Chunks[i], r.Chunks[i]
+		index := uint64(i)
+
+		if lc.StartState != rc.StartState {
+			return chunkDifference(index, "StartState", commitString(lc.StartState), commitString(rc.StartState))
+		}
+		if lc.CollectionIndex != rc.CollectionIndex {
+			return chunkDifference(index, "CollectionIndex", fmt.Sprint(lc.CollectionIndex), fmt.Sprint(rc.CollectionIndex))
+		}
+		if lc.NumberOfTransactions != rc.NumberOfTransactions {
+			return chunkDifference(index, "NumberOfTransactions", fmt.Sprint(lc.NumberOfTransactions), fmt.Sprint(rc.NumberOfTransactions))
+		}
+
+		for n := uint64(0); n < lc.NumberOfTransactions; n++ {
+			diff := compareTransaction(index, txIndex, left.StateDelta.TransactionResults, right.StateDelta.TransactionResults)
+			if diff != nil {
+				return diff
+			}
+			diff = compareEvents(index, txIndex, leftEvents[txIndex], rightEvents[txIndex])
+			if diff != nil {
+				return diff
+			}
+			txIndex++
+		}
+
+		diff := compareRegisters(index, left.StateDelta.StateInteractions, right.StateDelta.StateInteractions)
+		if diff != nil {
+			return diff
+		}
+
+		if lc.EventCollection != rc.EventCollection {
+			return chunkDifference(index, "EventCollection", lc.EventCollection.String(), rc.EventCollection.String())
+		}
+		if lc.TotalComputationUsed != rc.TotalComputationUsed {
+			return chunkDifference(index, "TotalComputationUsed", fmt.Sprint(lc.TotalComputationUsed), fmt.Sprint(rc.TotalComputationUsed))
+		}
+		if lc.EndState != rc.EndState {
+			return chunkDifference(index, "EndState", commitString(lc.EndState), commitString(rc.EndState))
+		}
+
+		diff = compareChunkDataPacks(index, left.ChunkDataPacks, right.ChunkDataPacks)
+		if diff != nil {
+			return diff
+		}
+	}
+
+	if len(left.StateDelta.TransactionResults) != len(right.StateDelta.TransactionResults) {
+		return resultDifference("number of transaction results",
+			fmt.Sprint(len(left.StateDelta.TransactionResults)),
+			fmt.Sprint(len(right.StateDelta.TransactionResults)))
+	}
+	if len(left.StateDelta.Events) != len(right.StateDelta.Events) {
+		return resultDifference("number of events", fmt.Sprint(len(left.StateDelta.Events)), fmt.Sprint(len(right.StateDelta.Events)))
+	}
+
+	leftServiceEvents, rightServiceEvents := flow.MakeID(l.ServiceEvents), flow.MakeID(r.ServiceEvents)
+	if leftServiceEvents != rightServiceEvents {
+		return resultDifference("ServiceEvents", leftServiceEvents.String(), rightServiceEvents.String())
+	}
+	if l.ExecutionDataID != r.ExecutionDataID {
+		return resultDifference("ExecutionDataID", l.ExecutionDataID.String(), r.ExecutionDataID.String())
+	}
+
+	return nil
+}
+
+func resultDifference(field string, left string, right string) *Difference {
+	return &Difference{
+		Kind:  KindResult,
+		Field: field,
+		Left:  left,
+		Right: right,
+	}
+}
+
+func chunkDifference(chunkIndex uint64, field string, left string, right string) *Difference {
+	return &Difference{
+		Kind:       KindChunk,
+		Field:      field,
+		ChunkIndex: chunkIndex,
+		Left:       left,
+		Right:      right,
+	}
+}
+
+// compareTransaction compares the results of the transaction with the given index in the block.
+func compareTransaction(chunkIndex uint64, txIndex uint32, left []flow.TransactionResult, right []flow.TransactionResult) *Difference {
+	diff := func(txID flow.Identifier, field string, l string, r string) *Difference {
+		return &Difference{
+			Kind:             KindTransaction,
+			Field:            field,
+			ChunkIndex:       chunkIndex,
+			TransactionIndex: txIndex,
+			TransactionID:    txID,
+			Left:             l,
+			Right:            r,
+		}
+	}
+
+	if int(txIndex) >= len(left) || int(txIndex) >= len(right) {
+		return diff(flow.ZeroID, "number of transaction results", fmt.Sprint(len(left)), fmt.Sprint(len(right)))
+	}
+	l, r := left[txIndex], right[txIndex]
+
+	if l.TransactionID != r.TransactionID {
+		return diff(l.TransactionID, "TransactionID", l.TransactionID.String(), r.TransactionID.String())
+	}
+	if l.ErrorMessage != r.ErrorMessage {
+		return diff(l.TransactionID, "ErrorMessage", fmt.Sprintf("%q", l.ErrorMessage), fmt.Sprintf("%q", r.ErrorMessage))
+	}
+	if l.ComputationUsed != r.ComputationUsed {
+		return diff(l.TransactionID, "ComputationUsed", fmt.Sprint(l.ComputationUsed), fmt.Sprint(r.ComputationUsed))
+	}
+	return nil
+}
+
+// compareEvents compares the events emitted by the transaction with the given index in the block.
+func compareEvents(chunkIndex uint64, txIndex uint32, left []flow.Event, right []flow.Event) *Difference {
+	diff := func(txID flow.Identifier, eventIndex uint32, field string, l string, r string) *Difference {
+		return &Difference{
+			Kind:             KindEvent,
+			Field:            field,
+			ChunkIndex:       chunkIndex,
+			TransactionIndex: txIndex,
+			TransactionID:    txID,
+			EventIndex:       eventIndex,
+			Left:             l,
+			Right:            r,
+		}
+	}
+
+	for i := 0; i < len(left) && i < len(right); i++ {
+		l, r := left[i], right[i]
+		if l.EventIndex != r.EventIndex {
+			return diff(l.TransactionID, l.EventIndex, "EventIndex", fmt.Sprint(l.EventIndex), fmt.Sprint(r.EventIndex))
+		}
+		if l.TransactionID != r.TransactionID {
+			return diff(l.TransactionID, l.EventIndex, "TransactionID", l.TransactionID.String(), r.TransactionID.String())
+		}
+		if l.Type != r.Type {
+			return diff(l.TransactionID, l.EventIndex, "Type", string(l.Type), string(r.Type))
+		}
+		if !bytes.Equal(l.Payload, r.Payload) {
+			return diff(l.TransactionID, l.EventIndex, "Payload", string(l.Payload), string(r.Payload))
+		}
+	}
+
+	if len(left) != len(right) {
+		var txID flow.Identifier
+		var next uint32
+		if len(left) > len(right) {
+			txID, next = left[len(right)].TransactionID, left[len(right)].EventIndex
+		} else {
+			txID, next = right[len(left)].TransactionID, right[len(left)].EventIndex
+		}
+		return diff(txID, next, "number of events", fmt.Sprint(len(left)), fmt.Sprint(len(right)))
+	}
+
+	return nil
+}
+
+// compareRegisters compares the values of the registers written by the chunk with the given index,
+// in the order of the register IDs.
+func compareRegisters(chunkIndex uint64, left []*delta.Snapshot, right []*delta.Snapshot) *Difference {
+	if int(chunkIndex) >= len(left) || int(chunkIndex) >= len(right) {
+		return chunkDifference(chunkIndex, "number of state interactions", fmt.Sprint(len(left)), fmt.Sprint(len(right)))
+	}
+	l, r := left[chunkIndex].Delta.Data, right[chunkIndex].Delta.Data
+
+	keys := make([]string, 0, len(l)+len(r))
+	for key := range l {
+		keys = append(keys, key)
+	}
+	for key := range r {
+		if _, ok := l[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		lEntry, lOK := l[key]
+		rEntry, rOK := r[key]
+		if lOK && rOK && bytes.Equal(lEntry.Value, rEntry.Value) {
+			continue
+		}
+
+		registerID := lEntry.Key
+		if !lOK {
+			registerID = rEntry.Key
+		}
+		return &Difference{
+			Kind:       KindRegister,
+			Field:      "Value",
+			ChunkIndex: chunkIndex,
+			RegisterID: registerID.String(),
+			Left:       registerValueString(lEntry.Value, lOK),
+			Right:      registerValueString(rEntry.Value, rOK),
+		}
+	}
+
+	return nil
+}
+
+// compareChunkDataPacks compares the chunk data packs of the chunk with the given index, if both
+// executions still have them. The proofs are not compared, as they only differ if the start
+// states differ, which is compared first.
+func compareChunkDataPacks(chunkIndex uint64, left []*flow.ChunkDataPack, right []*flow.ChunkDataPack) *Difference {
+	if int(chunkIndex) >= len(left) || int(chunkIndex) >= len(right) {
+		return nil
+	}
+	l, r := left[chunkIndex], right[chunkIndex]
+	if l == nil || r == nil {
+		return nil
+	}
+
+	diff := func(field string, lValue string, rValue string) *Difference {
+		return &Difference{
+			Kind:       KindChunkDataPack,
+			Field:      field,
+			ChunkIndex: chunkIndex,
+			Left:       lValue,
+			Right:      rValue,
+		}
+	}
+
+	if l.StartState != r.StartState {
+		return diff("StartState", commitString(l.StartState), commitString(r.StartState))
+	}
+	lCollection, rCollection := collectionString(l.Collection), collectionString(r.Collection)
+	if lCollection != rCollection {
+		return diff("Collection", lCollection, rCollection)
+	}
+	return nil
+}
+
+// eventsByTransaction groups the events of a block by the index of their transaction,
+// ordered by their index within the transaction.
+func eventsByTransaction(events []flow.Event) map[uint32][]flow.Event {
+	grouped := make(map[uint32][]flow.Event)
+	for _, event := range events {
+		grouped[event.TransactionIndex] = append(grouped[event.TransactionIndex], event)
+	}
+	for _, txEvents := range grouped {
+		sort.SliceStable(txEvents, func(i, j int) bool {
+			return txEvents[i].EventIndex < txEvents[j].EventIndex
+		})
+	}
+	return grouped
+}
+
+func commitString(commit flow.StateCommitment) string {
+	return hex.EncodeToString(commit[:])
+}
+
+func registerValueString(value flow.RegisterValue, written bool) string {
+	if !written {
+		return "<not written>"
+	}
+	return hex.EncodeToString(value)
+}
+
+func collectionString(collection *flow.Collection) string {
+	// the system chunk has no collection
+	if collection == nil {
+		return "<none>"
+	}
+	return collection.ID().String()
+}
@@ -0,0 +1,209 @@
+package comparison
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/engine/execution/state/delta"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/messages"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// executionFixture returns the execution of a block with two chunks of two transactions each,
+// where every transaction emits an event and every chunk writes a register.
+func executionFixture(t *testing.T) *BlockExecution {
+	block := unittest.BlockFixture()
+	result := unittest.ExecutionResultFixture(unittest.WithExecutionResultBlockID(block.ID()))
+
+	execution := &BlockExecution{
+		Result:         result,
+		ChunkDataPacks: make([]*flow.ChunkDataPack, len(result.Chunks)),
+		StateDelta: &messages.ExecutionStateDelta{
+			EndState: result.Chunks[len(result.Chunks)-1].EndState,
+		},
+	}
+
+	var txIndex uint32
+	for i, chunk := range result.Chunks {
+		chunk.NumberOfTransactions = 2
+		execution.ChunkDataPacks[i] = unittest.ChunkDataPackFixture(chunk.ID(), unittest.WithStartState(chunk.StartState))
+
+		for n := 0; n < 2; n++ {
+			txID := unittest.IdentifierFixture()
+			execution.StateDelta.TransactionResults = append(execution.StateDelta.TransactionResults, flow.TransactionResult{
+				TransactionID:   txID,
+				ComputationUsed: 10,
+			})
+			event := unittest.EventFixture("A.0x1.Foo.Bar", txIndex, 0, txID, 0)
+			event.Payload = []byte("payload")
+			execution.StateDelta.Events = append(execution.StateDelta.Events, event)
+			txIndex++
+		}
+
+		interactions := &delta.Snapshot{Delta: delta.NewDelta()}
+		interactions.Delta.Set("owner", "", "key", []byte{byte(i)})
+		execution.StateDelta.StateInteractions = append(execution.StateDelta.StateInteractions, interactions)
+	}
+
+	return execution
+}
+
+// copyExecution copies an execution by encoding it as JSON, as it is sent over the wire.
+func copyExecution(t *testing.T, execution *BlockExecution) *BlockExecution {
+	data, err := json.Marshal(execution)
+	require.NoError(t, err)
+	var copied BlockExecution
+	require.NoError(t, json.Unmarshal(data, &copied))
+	return &copied
+}
+
+func TestCompare(t *testing.T) {
+	execution := executionFixture(t)
+
+	t.Run("same executions", func(t *testing.T) {
+		assert.Nil(t, Compare(execution, copyExecution(t, execution)))
+	})
+
+	t.Run("pruned chunk data packs are not compared", func(t *testing.T) {
+		other := copyExecution(t, execution)
+		other.ChunkDataPacks[0] = nil
+		assert.Nil(t, Compare(execution, other))
+	})
+
+	tests := []struct {
+		name   string
+		mutate func(*BlockExecution)
+		check  func(*testing.T, *Difference)
+	}{
+		{
+			name: "previous result",
+			mutate: func(e *BlockExecution) {
+				e.Result.PreviousResultID = unittest.IdentifierFixture()
+			},
+			check: func(t *testing.T, diff *Difference) {
+				assert.Equal(t, KindResult, diff.Kind)
+				assert.Equal(t, "PreviousResultID", diff.Field)
+			},
+		},
+		{
+			name: "transaction error",
+			mutate: func(e *BlockExecution) {
+				e.StateDelta.TransactionResults[3].ErrorMessage = "failed"
+				// caused by the transaction
+				e.Result.Chunks[1].EndState = unittest.StateCommitmentFixture()
+			},
+			check: func(t *testing.T, diff *Difference) {
+				assert.Equal(t, KindTransaction, diff.Kind)
+				assert.Equal(t, "ErrorMessage", diff.Field)
+				assert.Equal(t, uint64(1), diff.ChunkIndex)
+				assert.Equal(t, uint32(3), diff.TransactionIndex)
+				assert.Equal(t, execution.StateDelta.TransactionResults[3].TransactionID, diff.TransactionID)
+				assert.Equal(t, `""`, diff.Left)
+				assert.Equal(t, `"failed"`, diff.Right)
+			},
+		},
+		{
+			name: "event payload",
+			mutate: func(e *BlockExecution) {
+				e.StateDelta.Events[1].Payload = []byte("other")
+			},
+			check: func(t *testing.T, diff *Difference) {
+				assert.Equal(t, KindEvent, diff.Kind)
+				assert.Equal(t, "Payload", diff.Field)
+				assert.Equal(t, uint64(0), diff.ChunkIndex)
+				assert.Equal(t, uint32(1), diff.TransactionIndex)
+				assert.Equal(t, uint32(0), diff.EventIndex)
+			},
+		},
+		{
+			name: "missing event",
+			mutate: func(e *BlockExecution) {
+				e.StateDelta.Events = e.StateDelta.Events[:3]
+			},
+			check: func(t *testing.T, diff *Difference) {
+				assert.Equal(t, KindEvent, diff.Kind)
+				assert.Equal(t, "number of events", diff.Field)
+				assert.Equal(t, uint32(3), diff.TransactionIndex)
+				assert.Equal(t, "1", diff.Left)
+				assert.Equal(t, "0", diff.Right)
+			},
+		},
+		{
+			name: "register value",
+			mutate: func(e *BlockExecution) {
+				e.StateDelta.StateInteractions[1].Delta.Set("owner", "", "key", []byte{42})
+				e.Result.Chunks[1].EndState = unittest.StateCommitmentFixture()
+			},
+			check: func(t *testing.T, diff *Difference) {
+				registerID := flow.NewRegisterID("owner", "", "key")
+				assert.Equal(t, KindRegister, diff.Kind)
+				assert.Equal(t, uint64(1), diff.ChunkIndex)
+				assert.Equal(t, registerID.String(), diff.RegisterID)
+				assert.Equal(t, "01", diff.Left)
+				assert.Equal(t, "2a", diff.Right)
+			},
+		},
+		{
+			name: "register not written",
+			mutate: func(e *BlockExecution) {
+				e.StateDelta.StateInteractions[0].Delta.Set("owner", "", "other", []byte{1})
+			},
+			check: func(t *testing.T, diff *Difference) {
+				registerID := flow.NewRegisterID("owner", "", "other")
+				assert.Equal(t, KindRegister, diff.Kind)
+				assert.Equal(t, uint64(0), diff.ChunkIndex)
+				assert.Equal(t, registerID.String(), diff.RegisterID)
+				assert.Equal(t, "<not written>", diff.Left)
+			},
+		},
+		{
+			name: "chunk end state",
+			mutate: func(e *BlockExecution) {
+				e.Result.Chunks[0].EndState = unittest.StateCommitmentFixture()
+			},
+			check: func(t *testing.T, diff *Difference) {
+				assert.Equal(t, KindChunk, diff.Kind)
+				assert.Equal(t, "EndState", diff.Field)
+				assert.Equal(t, uint64(0), diff.ChunkIndex)
+			},
+		},
+		{
+			name: "chunk data pack collection",
+			mutate: func(e *BlockExecution) {
+				collection := unittest.CollectionFixture(1)
+				e.ChunkDataPacks[1].Collection = &collection
+			},
+			check: func(t *testing.T, diff *Difference) {
+				assert.Equal(t, KindChunkDataPack, diff.Kind)
+				assert.Equal(t, "Collection", diff.Field)
+				assert.Equal(t, uint64(1), diff.ChunkIndex)
+			},
+		},
+		{
+			name: "service events",
+			mutate: func(e *BlockExecution) {
+				e.Result.ServiceEvents = flow.ServiceEventList{(&flow.EpochSetup{Counter: 1}).ServiceEvent()}
+			},
+			check: func(t *testing.T, diff *Difference) {
+				assert.Equal(t, KindResult, diff.Kind)
+				assert.Equal(t, "ServiceEvents", diff.Field)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			other := copyExecution(t, execution)
+			test.mutate(other)
+
+			diff := Compare(execution, other)
+			require.NotNil(t, diff)
+			test.check(t, diff)
+			assert.NotEmpty(t, diff.String())
+		})
+	}
+}
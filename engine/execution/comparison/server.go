@@ -0,0 +1,132 @@
+package comparison
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/engine/common/rpc/comparison"
+	pb "github.com/onflow/flow-go/engine/common/rpc/comparison/comparison"
+	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	"github.com/onflow/flow-go/engine/execution/state"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+)
+
+// ErrNotExecuted is returned if the block was not executed by the node.
+var ErrNotExecuted = errors.New("block not executed")
+
+// Source provides the executions of blocks by an execution node.
+type Source interface {
+	// BlockExecution returns the execution of a block. It returns ErrNotExecuted if the
+	// block was not executed.
+	BlockExecution(ctx context.Context, blockID flow.Identifier) (*BlockExecution, error)
+}
+
+// Server serves the executions of blocks by this node, so that they can be compared
+// with the executions by other execution nodes.
+type Server struct {
+	pb.UnimplementedComparisonAPIServer
+
+	log       zerolog.Logger
+	execState state.ReadOnlyExecutionState
+	results   storage.ExecutionResults
+}
+
+var _ pb.ComparisonAPIServer = (*Server)(nil)
+var _ Source = (*Server)(nil)
+
+func NewServer(
+	log zerolog.Logger,
+	execState state.ReadOnlyExecutionState,
+	results storage.ExecutionResults,
+) *Server {
+	return &Server{
+		log:       log.With().Str("component", "comparison_server").Logger(),
+		execState: execState,
+		results:   results,
+	}
+}
+
+// GetBlockExecution returns the execution of a block by this node.
+func (s *Server) GetBlockExecution(ctx context.Context, req *pb.GetBlockExecutionRequest) (*pb.GetBlockExecutionResponse, error) {
+	blockID, err := convert.BlockID(req.GetBlockId())
+	if err != nil {
+		return nil, err
+	}
+
+	execution, err := s.BlockExecution(ctx, blockID)
+	if errors.Is(err, ErrNotExecuted) {
+		return nil, status.Errorf(codes.NotFound, "block %v is not executed", blockID)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "could not get execution of block %v: %v", blockID, err)
+	}
+
+	s.log.Info().
+		Hex("block_id", blockID[:]).
+		Msg("serving block execution for comparison")
+
+	result, err := comparison.ExecutionResultToMessage(execution.Result)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "could not encode execution result: %v", err)
+	}
+	chunkDataPacks, err := comparison.ChunkDataPacksToMessages(execution.ChunkDataPacks)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "could not encode chunk data packs: %v", err)
+	}
+	stateDelta, err := comparison.StateDeltaToMessage(execution.StateDelta)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "could not encode state delta: %v", err)
+	}
+
+	return &pb.GetBlockExecutionResponse{
+		Result:         result,
+		ChunkDataPacks: chunkDataPacks,
+		StateDelta:     stateDelta,
+	}, nil
+}
+
+// BlockExecution returns the execution of a block by this node. It returns ErrNotExecuted
+// if the block was not executed. Chunk data packs which were pruned are nil.
+func (s *Server) BlockExecution(ctx context.Context, blockID flow.Identifier) (*BlockExecution, error) {
+	resultID, err := s.execState.GetExecutionResultID(ctx, blockID)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, ErrNotExecuted
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not get execution result ID: %w", err)
+	}
+
+	result, err := s.results.ByID(resultID)
+	if err != nil {
+		return nil, fmt.Errorf("could not get execution result: %w", err)
+	}
+
+	chunkDataPacks := make([]*flow.ChunkDataPack, len(result.Chunks))
+	for i, chunk := range result.Chunks {
+		chunkDataPack, err := s.execState.ChunkDataPackByChunkID(ctx, chunk.ID())
+		if errors.Is(err, storage.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("could not get chunk data pack of chunk %d: %w", i, err)
+		}
+		chunkDataPacks[i] = chunkDataPack
+	}
+
+	delta, err := s.execState.RetrieveStateDelta(ctx, blockID)
+	if err != nil {
+		return nil, fmt.Errorf("could not get state delta: %w", err)
+	}
+
+	return &BlockExecution{
+		Result:         result,
+		ChunkDataPacks: chunkDataPacks,
+		StateDelta:     delta,
+	}, nil
+}
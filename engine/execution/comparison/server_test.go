@@ -0,0 +1,81 @@
+package comparison
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+
+	pb "github.com/onflow/flow-go/engine/common/rpc/comparison/comparison"
+	statemock "github.com/onflow/flow-go/engine/execution/state/mock"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+	storagemock "github.com/onflow/flow-go/storage/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// serve serves the given comparison API server, and returns a client connected to it.
+func serve(t *testing.T, srv pb.ComparisonAPIServer) pb.ComparisonAPIClient {
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	pb.RegisterComparisonAPIServer(server, srv)
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return listener.Dial()
+		}),
+		grpc.WithInsecure(), //nolint:staticcheck
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return pb.NewComparisonAPIClient(conn)
+}
+
+func TestFetch(t *testing.T) {
+	execution := executionFixture(t)
+	blockID := execution.Result.BlockID
+
+	execState := new(statemock.ReadOnlyExecutionState)
+	results := new(storagemock.ExecutionResults)
+
+	execState.On("GetExecutionResultID", mock.Anything, blockID).Return(execution.Result.ID(), nil)
+	execState.On("GetExecutionResultID", mock.Anything, mock.Anything).Return(flow.ZeroID, storage.ErrNotFound)
+	results.On("ByID", execution.Result.ID()).Return(execution.Result, nil)
+	execState.On("RetrieveStateDelta", mock.Anything, blockID).Return(execution.StateDelta, nil)
+
+	// the chunk data pack of the first chunk was pruned
+	execState.On("ChunkDataPackByChunkID", mock.Anything, execution.Result.Chunks[0].ID()).Return(nil, storage.ErrNotFound)
+	execState.On("ChunkDataPackByChunkID", mock.Anything, execution.Result.Chunks[1].ID()).Return(execution.ChunkDataPacks[1], nil)
+
+	client := serve(t, NewServer(zerolog.Nop(), execState, results))
+
+	t.Run("executed block", func(t *testing.T) {
+		fetched, err := Fetch(context.Background(), client, blockID)
+		require.NoError(t, err)
+
+		assert.Equal(t, execution.Result.ID(), fetched.Result.ID())
+		require.Len(t, fetched.ChunkDataPacks, 2)
+		assert.Nil(t, fetched.ChunkDataPacks[0])
+		assert.Equal(t, execution.ChunkDataPacks[1].ID(), fetched.ChunkDataPacks[1].ID())
+
+		// the executions are the same, except for the pruned chunk data pack
+		assert.Nil(t, Compare(execution, fetched))
+	})
+
+	t.Run("unknown block", func(t *testing.T) {
+		_, err := Fetch(context.Background(), client, unittest.IdentifierFixture())
+		assert.True(t, errors.Is(err, ErrNotExecuted))
+	})
+}
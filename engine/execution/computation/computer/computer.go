@@ -9,12 +9,12 @@ import (
 	"github.com/opentracing/opentracing-go"
 	"github.com/opentracing/opentracing-go/log"
 	"github.com/rs/zerolog"
-	"github.com/uber/jaeger-client-go"
 
 	"github.com/onflow/flow-go/engine/execution"
 	"github.com/onflow/flow-go/engine/execution/state/delta"
 	"github.com/onflow/flow-go/fvm"
 	"github.com/onflow/flow-go/fvm/blueprints"
+	"github.com/onflow/flow-go/fvm/profiling"
 	"github.com/onflow/flow-go/fvm/programs"
 	"github.com/onflow/flow-go/fvm/state"
 	"github.com/onflow/flow-go/ledger"
@@ -45,13 +45,44 @@ type BlockComputer interface {
 }
 
 type blockComputer struct {
-	vm             VirtualMachine
-	vmCtx          fvm.Context
-	metrics        module.ExecutionMetrics
-	tracer         module.Tracer
-	log            zerolog.Logger
-	systemChunkCtx fvm.Context
-	committer      ViewCommitter
+	vm              VirtualMachine
+	vmCtx           fvm.Context
+	metrics         module.ExecutionMetrics
+	tracer          module.Tracer
+	log             zerolog.Logger
+	systemChunkCtx  fvm.Context
+	committer       ViewCommitter
+	parallelWorkers int
+	registerSets    bool
+	profiles        *profiling.Collector
+}
+
+// BlockComputerOption configures optional behaviour of the block computer.
+type BlockComputerOption func(*blockComputer)
+
+// WithParallelExecution enables the optimistic parallel execution of the
+// transactions within a collection, using the given number of workers.
+// Parallel execution is disabled if workers is less than 2.
+func WithParallelExecution(workers int) BlockComputerOption {
+	return func(e *blockComputer) {
+		e.parallelWorkers = workers
+	}
+}
+
+// WithRegisterSets enables collecting the registers read and written by each
+// transaction into the computation result.
+func WithRegisterSets() BlockComputerOption {
+	return func(e *blockComputer) {
+		e.registerSets = true
+	}
+}
+
+// WithProfiles enables profiling the transactions while the given collector
+// is started, and adds their profiles to it.
+func WithProfiles(collector *profiling.Collector) BlockComputerOption {
+	return func(e *blockComputer) {
+		e.profiles = collector
+	}
 }
 
 func SystemChunkContext(vmCtx fvm.Context, logger zerolog.Logger) fvm.Context {
@@ -74,8 +105,9 @@ func NewBlockComputer(
 	tracer module.Tracer,
 	logger zerolog.Logger,
 	committer ViewCommitter,
+	opts ...BlockComputerOption,
 ) (BlockComputer, error) {
-	return &blockComputer{
+	e := &blockComputer{
 		vm:             vm,
 		vmCtx:          vmCtx,
 		metrics:        metrics,
@@ -83,7 +115,11 @@ func NewBlockComputer(
 		log:            logger,
 		systemChunkCtx: SystemChunkContext(vmCtx, logger),
 		committer:      committer,
-	}, nil
+	}
+	for _, apply := range opts {
+		apply(e)
+	}
+	return e, nil
 }
 
 // ExecuteBlock executes a block and returns the resulting chunks.
@@ -299,12 +335,23 @@ func (e *blockComputer) executeCollection(
 	}()
 
 	txCtx := fvm.NewContextFromParent(blockCtx, fvm.WithMetricsReporter(e.metrics), fvm.WithTracer(e.tracer))
-	for _, txBody := range collection.Transactions {
-		err := e.executeTransaction(txBody, colSpan, collectionView, programs, txCtx, collectionIndex, txIndex, res, false)
-		txIndex++
+	if e.profiles != nil && e.profiles.Started() {
+		txCtx = fvm.NewContextFromParent(txCtx, fvm.WithProfiling(true))
+	}
+	if e.parallelWorkers > 1 && len(collection.Transactions) > 1 {
+		var err error
+		txIndex, err = e.executeTransactionsInParallel(colSpan, collectionView, programs, txCtx, collectionIndex, txIndex, collection.Transactions, res)
 		if err != nil {
 			return txIndex, err
 		}
+	} else {
+		for _, txBody := range collection.Transactions {
+			err := e.executeTransaction(txBody, colSpan, collectionView, programs, txCtx, collectionIndex, txIndex, res, false)
+			txIndex++
+			if err != nil {
+				return txIndex, err
+			}
+		}
 	}
 	res.AddStateSnapshot(collectionView.(*delta.View).Interactions())
 	e.log.Info().Str("collectionID", collection.Guarantee.CollectionID.String()).
@@ -330,6 +377,49 @@ func (e *blockComputer) executeTransaction(
 	res *execution.ComputationResult,
 	isSystemChunk bool,
 ) error {
+	run := e.runTransaction(txBody, colSpan, collectionView, programs, ctx, collectionIndex, txIndex, res, isSystemChunk)
+	defer run.finish()
+
+	if run.err != nil {
+		return run.err
+	}
+
+	return e.applyTransaction(run, collectionView, res)
+}
+
+// transactionRun is a transaction executed on its own child view, which is not
+// yet merged into the collection view.
+type transactionRun struct {
+	tx              *fvm.TransactionProcedure
+	view            state.View
+	programs        *programs.Programs
+	collectionIndex int
+	startedAt       time.Time
+	traceID         string
+	txSpan          opentracing.Span
+	txInternalSpan  opentracing.Span
+	err             error
+}
+
+// finish finishes the spans of the transaction run.
+func (r *transactionRun) finish() {
+	r.txInternalSpan.Finish()
+	r.txSpan.Finish()
+}
+
+// runTransaction executes the transaction on a new child of the given view.
+// Any error returned by the virtual machine is recorded in the run.
+func (e *blockComputer) runTransaction(
+	txBody *flow.TransactionBody,
+	colSpan opentracing.Span,
+	baseView state.View,
+	programs *programs.Programs,
+	ctx fvm.Context,
+	collectionIndex int,
+	txIndex uint32,
+	res *execution.ComputationResult,
+	isSystemChunk bool,
+) *transactionRun {
 	startedAt := time.Now()
 	txID := txBody.ID()
 
@@ -338,17 +428,13 @@ func (e *blockComputer) executeTransaction(
 	txSpan.LogFields(log.String("tx_id", txID.String()))
 	txSpan.LogFields(log.Uint32("tx_index", txIndex))
 	txSpan.LogFields(log.Int("col_index", collectionIndex))
-	defer txSpan.Finish()
 
 	var traceID string
 	txInternalSpan, _, isSampled := e.tracer.StartTransactionSpan(context.Background(), txID, trace.EXERunTransaction)
 	if isSampled {
 		txInternalSpan.LogFields(log.String("tx_id", txID.String()))
-		if sc, ok := txInternalSpan.Context().(jaeger.SpanContext); ok {
-			traceID = sc.TraceID().String()
-		}
+		traceID = trace.EntityTraceID(txID).String()
 	}
-	defer txInternalSpan.Finish()
 
 	e.log.Info().
 		Str("tx_id", txID.String()).
@@ -363,16 +449,38 @@ func (e *blockComputer) executeTransaction(
 		tx.SetTraceSpan(txInternalSpan)
 	}
 
-	txView := collectionView.NewChild()
-	err := e.vm.Run(ctx, tx, txView, programs)
+	run := &transactionRun{
+		tx:              tx,
+		view:            baseView.NewChild(),
+		programs:        programs,
+		collectionIndex: collectionIndex,
+		startedAt:       startedAt,
+		traceID:         traceID,
+		txSpan:          txSpan,
+		txInternalSpan:  txInternalSpan,
+	}
+
+	err := e.vm.Run(ctx, tx, run.view, programs)
 	if err != nil {
-		return fmt.Errorf("failed to execute transaction %v for block %v at height %v: %w",
+		run.err = fmt.Errorf("failed to execute transaction %v for block %v at height %v: %w",
 			txID.String(),
 			res.ExecutableBlock.ID(),
 			res.ExecutableBlock.Block.Header.Height,
 			err)
 	}
 
+	return run
+}
+
+// applyTransaction merges the view of the transaction run into the collection
+// view, and adds the events and result of the transaction to the computation result.
+func (e *blockComputer) applyTransaction(
+	run *transactionRun,
+	collectionView state.View,
+	res *execution.ComputationResult,
+) error {
+	tx := run.tx
+
 	txResult := flow.TransactionResult{
 		TransactionID:   tx.ID,
 		ComputationUsed: tx.ComputationUsed,
@@ -382,28 +490,36 @@ func (e *blockComputer) executeTransaction(
 		txResult.ErrorMessage = tx.Err.Error()
 	}
 
-	mergeSpan := e.tracer.StartSpanFromParent(txSpan, trace.EXEMergeTransactionView)
+	mergeSpan := e.tracer.StartSpanFromParent(run.txSpan, trace.EXEMergeTransactionView)
 	defer mergeSpan.Finish()
 
 	// always merge the view, fvm take cares of reverting changes
 	// of failed transaction invocation
-	err = collectionView.MergeView(txView)
+	err := collectionView.MergeView(run.view)
 	if err != nil {
 		return fmt.Errorf("merging tx view to collection view failed for tx %v: %w",
-			txID.String(), err)
+			tx.ID.String(), err)
 	}
 
-	res.AddEvents(collectionIndex, tx.Events)
+	res.AddEvents(run.collectionIndex, tx.Events)
 	res.AddServiceEvents(tx.ServiceEvents)
 	res.AddTransactionResult(&txResult)
 	res.AddComputationUsed(tx.ComputationUsed)
 
+	if e.registerSets {
+		res.AddRegisterSet(transactionRegisterSet(tx, run.view))
+	}
+
+	if e.profiles != nil {
+		e.profiles.Add(tx.Profile)
+	}
+
 	lg := e.log.With().
 		Hex("tx_id", txResult.TransactionID[:]).
 		Str("block_id", res.ExecutableBlock.ID().String()).
-		Str("traceID", traceID).
+		Str("traceID", run.traceID).
 		Uint64("computation_used", txResult.ComputationUsed).
-		Int64("timeSpentInMS", time.Since(startedAt).Milliseconds()).
+		Int64("timeSpentInMS", time.Since(run.startedAt).Milliseconds()).
 		Logger()
 
 	if tx.Err != nil {
@@ -415,10 +531,23 @@ func (e *blockComputer) executeTransaction(
 		lg.Info().Msg("transaction executed successfully")
 	}
 
-	e.metrics.ExecutionTransactionExecuted(time.Since(startedAt), tx.ComputationUsed, len(tx.Events), tx.Err != nil)
+	e.metrics.ExecutionTransactionExecuted(time.Since(run.startedAt), tx.ComputationUsed, len(tx.Events), tx.Err != nil)
 	return nil
 }
 
+// transactionRegisterSet returns the registers read and written by the
+// transaction in the given view.
+func transactionRegisterSet(tx *fvm.TransactionProcedure, txView state.View) *flow.TransactionRegisterSet {
+	writeSet, _ := txView.RegisterUpdates()
+
+	return &flow.TransactionRegisterSet{
+		TransactionID:    tx.ID,
+		TransactionIndex: tx.TxIndex,
+		ReadSet:          txView.(*delta.View).ReadSet(),
+		WriteSet:         writeSet,
+	}
+}
+
 type blockCommitter struct {
 	tracer    module.Tracer
 	committer ViewCommitter
@@ -26,6 +26,7 @@ import (
 	"github.com/onflow/flow-go/engine/execution/testutil"
 	"github.com/onflow/flow-go/fvm"
 	fvmErrors "github.com/onflow/flow-go/fvm/errors"
+	"github.com/onflow/flow-go/fvm/profiling"
 	"github.com/onflow/flow-go/fvm/programs"
 	"github.com/onflow/flow-go/fvm/state"
 	"github.com/onflow/flow-go/fvm/systemcontracts"
@@ -91,6 +92,121 @@ func TestBlockExecutor_ExecuteBlock(t *testing.T) {
 		vm.AssertExpectations(t)
 	})
 
+	t.Run("register sets are collected", func(t *testing.T) {
+
+		execCtx := fvm.NewContext(zerolog.Nop())
+
+		// every transaction reads a shared register and writes its own one, and
+		// the second transaction also reads the register written by the first
+		vm := new(computermock.VirtualMachine)
+		vm.On("Run", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(nil).
+			Run(func(args mock.Arguments) {
+				tx := args[1].(*fvm.TransactionProcedure)
+				view := args[2].(state.View)
+
+				_, err := view.Get("owner", "", "shared")
+				require.NoError(t, err)
+				if tx.TxIndex == 1 {
+					_, err = view.Get("owner", "", "tx-0")
+					require.NoError(t, err)
+				}
+				err = view.Set("owner", "", fmt.Sprintf("tx-%d", tx.TxIndex), []byte{1})
+				require.NoError(t, err)
+			}).
+			Times(2 + 1) // 2 txs in collection + system chunk
+
+		exe, err := computer.NewBlockComputer(vm, execCtx, metrics.NewNoopCollector(), trace.NewNoopTracer(), zerolog.Nop(), committer.NewNoopViewCommitter(), computer.WithRegisterSets())
+		require.NoError(t, err)
+
+		block := generateBlock(1, 2, rag)
+
+		view := delta.NewView(func(owner, controller, key string) (flow.RegisterValue, error) {
+			return nil, nil
+		})
+
+		result, err := exe.ExecuteBlock(context.Background(), block, view, programs.NewEmptyPrograms())
+		require.NoError(t, err)
+		require.Len(t, result.RegisterSets, 2+1) // +1 system chunk
+
+		shared := flow.NewRegisterID("owner", "", "shared")
+		for i, registerSet := range result.RegisterSets {
+			assert.Equal(t, result.TransactionResults[i].TransactionID, registerSet.TransactionID)
+			assert.Equal(t, uint32(i), registerSet.TransactionIndex)
+			assert.Equal(t, []flow.RegisterID{flow.NewRegisterID("owner", "", fmt.Sprintf("tx-%d", i))}, registerSet.WriteSet)
+		}
+		assert.Equal(t, []flow.RegisterID{shared}, result.RegisterSets[0].ReadSet)
+		assert.Equal(t, []flow.RegisterID{shared, flow.NewRegisterID("owner", "", "tx-0")}, result.RegisterSets[1].ReadSet)
+
+		vm.AssertExpectations(t)
+	})
+
+	t.Run("register sets are not collected by default", func(t *testing.T) {
+
+		execCtx := fvm.NewContext(zerolog.Nop())
+
+		vm := new(computermock.VirtualMachine)
+		vm.On("Run", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(nil).
+			Times(2 + 1) // 2 txs in collection + system chunk
+
+		exe, err := computer.NewBlockComputer(vm, execCtx, metrics.NewNoopCollector(), trace.NewNoopTracer(), zerolog.Nop(), committer.NewNoopViewCommitter())
+		require.NoError(t, err)
+
+		block := generateBlock(1, 2, rag)
+
+		view := delta.NewView(func(owner, controller, key string) (flow.RegisterValue, error) {
+			return nil, nil
+		})
+
+		result, err := exe.ExecuteBlock(context.Background(), block, view, programs.NewEmptyPrograms())
+		require.NoError(t, err)
+		assert.Empty(t, result.RegisterSets)
+	})
+
+	t.Run("profiles are collected while the collector is started", func(t *testing.T) {
+
+		execCtx := fvm.NewContext(zerolog.Nop())
+
+		vm := new(computermock.VirtualMachine)
+		vm.On("Run", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(nil).
+			Run(func(args mock.Arguments) {
+				ctx := args[0].(fvm.Context)
+				tx := args[1].(*fvm.TransactionProcedure)
+
+				if ctx.ProfilingEnabled {
+					tx.Profile = &profiling.Profile{
+						Invocations: []profiling.Entry{{Name: "transaction", Calls: 1, Computation: 10}},
+					}
+				}
+			}).
+			Times(2 * (2 + 1)) // 2 blocks with 2 txs in collection + system chunk
+
+		collector := profiling.NewCollector()
+		exe, err := computer.NewBlockComputer(vm, execCtx, metrics.NewNoopCollector(), trace.NewNoopTracer(), zerolog.Nop(), committer.NewNoopViewCommitter(), computer.WithProfiles(collector))
+		require.NoError(t, err)
+
+		view := delta.NewView(func(owner, controller, key string) (flow.RegisterValue, error) {
+			return nil, nil
+		})
+
+		// transactions are not profiled while the collector is stopped
+		_, err = exe.ExecuteBlock(context.Background(), generateBlock(1, 2, rag), view, programs.NewEmptyPrograms())
+		require.NoError(t, err)
+
+		require.True(t, collector.Start())
+		_, err = exe.ExecuteBlock(context.Background(), generateBlock(1, 2, rag), view, programs.NewEmptyPrograms())
+		require.NoError(t, err)
+
+		// the system chunk is not profiled
+		profile, procedures, _ := collector.Stop()
+		assert.Equal(t, uint64(2), procedures)
+		assert.Equal(t, uint64(20), profile.ComputationUsed())
+
+		vm.AssertExpectations(t)
+	})
+
 	t.Run("empty block still computes system chunk", func(t *testing.T) {
 
 		execCtx := fvm.NewContext(
@@ -0,0 +1,145 @@
+package computer
+
+import (
+	"sync"
+
+	"github.com/opentracing/opentracing-go"
+
+	"github.com/onflow/flow-go/engine/execution"
+	"github.com/onflow/flow-go/engine/execution/state/delta"
+	"github.com/onflow/flow-go/fvm"
+	"github.com/onflow/flow-go/fvm/programs"
+	"github.com/onflow/flow-go/fvm/state"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/logging"
+)
+
+// executeTransactionsInParallel executes the transactions of a collection
+// optimistically, producing the same result as executing them one after another.
+//
+// All transactions are first run speculatively and concurrently, each on its own
+// child view of the collection view as it is before the first transaction, and
+// with its own child of the programs. The runs are then applied to the collection
+// view in order. A run is discarded, and its transaction executed again on the
+// up-to-date collection view, if:
+//   - it touched a register written by a transaction applied before it, as it
+//     might have observed different values when executed in order
+//   - it updated contracts, as the programs must be invalidated in order
+//   - the virtual machine failed to run it
+func (e *blockComputer) executeTransactionsInParallel(
+	colSpan opentracing.Span,
+	collectionView state.View,
+	programs *programs.Programs,
+	ctx fvm.Context,
+	collectionIndex int,
+	txIndex uint32,
+	transactions []*flow.TransactionBody,
+	res *execution.ComputationResult,
+) (uint32, error) {
+
+	runs := e.runSpeculatively(colSpan, collectionView, programs, ctx, collectionIndex, txIndex, transactions, res)
+
+	// finish the spans of runs which are not applied due to an error
+	defer func() {
+		for _, run := range runs {
+			if run != nil {
+				run.finish()
+			}
+		}
+	}()
+
+	written := make(map[string]struct{})
+	reexecuted := 0
+	for i, run := range runs {
+		runs[i] = nil
+
+		if run.err != nil || run.programs.Cleaned() || touchesAny(run.view, written) {
+			run.finish()
+			reexecuted++
+			run = e.runTransaction(transactions[i], colSpan, collectionView, programs, ctx, collectionIndex, txIndex, res, false)
+		}
+		txIndex++
+
+		if run.err != nil {
+			run.finish()
+			return txIndex, run.err
+		}
+
+		ids, _ := run.view.RegisterUpdates()
+		for _, id := range ids {
+			written[id.String()] = struct{}{}
+		}
+
+		err := e.applyTransaction(run, collectionView, res)
+		run.finish()
+		if err != nil {
+			return txIndex, err
+		}
+	}
+
+	e.log.Debug().
+		Hex("block_id", logging.Entity(ctx.BlockHeader)).
+		Int("collection_index", collectionIndex).
+		Int("transactions", len(transactions)).
+		Int("reexecuted", reexecuted).
+		Msg("collection executed in parallel")
+
+	return txIndex, nil
+}
+
+// runSpeculatively runs all transactions concurrently on children of the
+// collection view, using at most parallelWorkers goroutines. The collection view
+// and the programs must not be modified until all runs are complete.
+func (e *blockComputer) runSpeculatively(
+	colSpan opentracing.Span,
+	collectionView state.View,
+	programs *programs.Programs,
+	ctx fvm.Context,
+	collectionIndex int,
+	txIndex uint32,
+	transactions []*flow.TransactionBody,
+	res *execution.ComputationResult,
+) []*transactionRun {
+
+	runs := make([]*transactionRun, len(transactions))
+
+	indices := make(chan int, len(transactions))
+	for i := range transactions {
+		indices <- i
+	}
+	close(indices)
+
+	workers := e.parallelWorkers
+	if workers > len(transactions) {
+		workers = len(transactions)
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				// every run gets its own child of the programs, so programs
+				// loaded or invalidated by one run are not visible to others
+				runs[i] = e.runTransaction(transactions[i], colSpan, collectionView, programs.ChildPrograms(), ctx, collectionIndex, txIndex+uint32(i), res, false)
+			}
+		}()
+	}
+	wg.Wait()
+
+	return runs
+}
+
+// touchesAny returns true if any of the given registers was read or written in the view.
+func touchesAny(view state.View, registers map[string]struct{}) bool {
+	if len(registers) == 0 {
+		return false
+	}
+	for id := range view.(*delta.View).Interactions().RegisterTouches() {
+		if _, ok := registers[id]; ok {
+			return true
+		}
+	}
+	return false
+}
@@ -6,10 +6,13 @@ import (
 	"fmt"
 	"math/rand"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/ipfs/go-cid"
+	"github.com/onflow/cadence"
 	jsoncdc "github.com/onflow/cadence/encoding/json"
+	"github.com/onflow/cadence/runtime/common"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 	"golang.org/x/sync/errgroup"
@@ -19,6 +22,7 @@ import (
 	"github.com/onflow/flow-go/engine/execution"
 	"github.com/onflow/flow-go/engine/execution/computation/computer"
 	"github.com/onflow/flow-go/fvm"
+	"github.com/onflow/flow-go/fvm/profiling"
 	"github.com/onflow/flow-go/fvm/programs"
 	"github.com/onflow/flow-go/fvm/state"
 	"github.com/onflow/flow-go/model/flow"
@@ -26,6 +30,7 @@ import (
 	"github.com/onflow/flow-go/module/mempool/entity"
 	"github.com/onflow/flow-go/module/state_synchronization"
 	"github.com/onflow/flow-go/state/protocol"
+	"github.com/onflow/flow-go/storage"
 	"github.com/onflow/flow-go/utils/logging"
 )
 
@@ -44,12 +49,19 @@ type VirtualMachine interface {
 
 type ComputationManager interface {
 	ExecuteScript([]byte, [][]byte, *flow.Header, state.View) ([]byte, error)
+	ProfileScript([]byte, [][]byte, *flow.Header, state.View) ([]byte, *profiling.Profile, error)
 	ComputeBlock(
 		ctx context.Context,
 		block *entity.ExecutableBlock,
 		view state.View,
 	) (*execution.ComputationResult, error)
 	GetAccount(addr flow.Address, header *flow.Header, view state.View) (*flow.Account, error)
+	SimulateTransaction(
+		tx *flow.TransactionBody,
+		opts flow.TransactionSimulationOptions,
+		header *flow.Header,
+		view state.View,
+	) (*flow.TransactionSimulationResult, error)
 }
 
 var DefaultScriptLogThreshold = 1 * time.Second
@@ -66,6 +78,10 @@ type Manager struct {
 	vmCtx              fvm.Context
 	blockComputer      computer.BlockComputer
 	programsCache      *ProgramsCache
+	warmUpContracts    storage.WarmUpContracts
+	warmUpMu           sync.Mutex
+	warmUpKnown        map[programs.ContractUpdateKey]struct{} // contracts known to be in the warm-up list
+	warmUpUnused       map[common.LocationID]time.Duration     // warmed up programs not used yet, with their load time
 	scriptLogThreshold time.Duration
 	uploaders          []uploader.Uploader
 	eds                state_synchronization.ExecutionDataService
@@ -81,11 +97,13 @@ func New(
 	vm VirtualMachine,
 	vmCtx fvm.Context,
 	programsCacheSize uint,
+	warmUpContracts storage.WarmUpContracts,
 	committer computer.ViewCommitter,
 	scriptLogThreshold time.Duration,
 	uploaders []uploader.Uploader,
 	eds state_synchronization.ExecutionDataService,
 	edCache state_synchronization.ExecutionDataCIDCache,
+	blockComputerOpts ...computer.BlockComputerOption,
 ) (*Manager, error) {
 	log := logger.With().Str("engine", "computation").Logger()
 
@@ -96,6 +114,7 @@ func New(
 		tracer,
 		log.With().Str("component", "block_computer").Logger(),
 		committer,
+		blockComputerOpts...,
 	)
 
 	if err != nil {
@@ -116,6 +135,7 @@ func New(
 		vmCtx:              vmCtx,
 		blockComputer:      blockComputer,
 		programsCache:      programsCache,
+		warmUpContracts:    warmUpContracts,
 		scriptLogThreshold: scriptLogThreshold,
 		uploaders:          uploaders,
 		eds:                eds,
@@ -134,6 +154,17 @@ func (e *Manager) getChildProgramsOrEmpty(blockID flow.Identifier) *programs.Pro
 }
 
 func (e *Manager) ExecuteScript(code []byte, arguments [][]byte, blockHeader *flow.Header, view state.View) ([]byte, error) {
+	value, _, err := e.executeScript(code, arguments, blockHeader, view, false)
+	return value, err
+}
+
+// ProfileScript executes the script like ExecuteScript, and returns the profile of the
+// computation and the time used by the functions and environment calls of the script.
+func (e *Manager) ProfileScript(code []byte, arguments [][]byte, blockHeader *flow.Header, view state.View) ([]byte, *profiling.Profile, error) {
+	return e.executeScript(code, arguments, blockHeader, view, true)
+}
+
+func (e *Manager) executeScript(code []byte, arguments [][]byte, blockHeader *flow.Header, view state.View, profile bool) ([]byte, *profiling.Profile, error) {
 
 	startedAt := time.Now()
 
@@ -147,7 +178,7 @@ func (e *Manager) ExecuteScript(code []byte, arguments [][]byte, blockHeader *fl
 		e.log.Info().Uint32("trackerID", trackerID).Msg("script execution is complete")
 	}()
 
-	blockCtx := fvm.NewContextFromParent(e.vmCtx, fvm.WithBlockHeader(blockHeader))
+	blockCtx := fvm.NewContextFromParent(e.vmCtx, fvm.WithBlockHeader(blockHeader), fvm.WithProfiling(profile))
 
 	script := fvm.Script(code).WithArguments(arguments...)
 
@@ -190,7 +221,7 @@ func (e *Manager) ExecuteScript(code []byte, arguments [][]byte, blockHeader *fl
 		return e.vm.Run(blockCtx, script, view, programs)
 	}()
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute script (internal error): %w", err)
+		return nil, nil, fmt.Errorf("failed to execute script (internal error): %w", err)
 	}
 
 	if script.Err != nil {
@@ -204,17 +235,17 @@ func (e *Manager) ExecuteScript(code []byte, arguments [][]byte, blockHeader *fl
 			scriptErrMsg = sb.String()
 		}
 
-		return nil, fmt.Errorf("failed to execute script at block (%s): %s", blockHeader.ID(), scriptErrMsg)
+		return nil, nil, fmt.Errorf("failed to execute script at block (%s): %s", blockHeader.ID(), scriptErrMsg)
 	}
 
 	encodedValue, err := jsoncdc.Encode(script.Value)
 	if err != nil {
-		return nil, fmt.Errorf("failed to encode runtime value: %w", err)
+		return nil, nil, fmt.Errorf("failed to encode runtime value: %w", err)
 	}
 
 	e.metrics.ExecutionScriptExecuted(time.Since(startedAt), script.GasUsed)
 
-	return encodedValue, nil
+	return encodedValue, script.Profile, nil
 }
 
 func (e *Manager) ComputeBlock(
@@ -255,6 +286,15 @@ func (e *Manager) ComputeBlock(
 
 	e.programsCache.Set(block.ID(), toInsert)
 
+	if e.warmUpContracts != nil {
+		err = e.updateWarmUpContracts(blockPrograms, view)
+		if err != nil {
+			e.log.Error().Err(err).
+				Hex("block_id", logging.Entity(block.Block)).
+				Msg("failed to update warm-up contracts")
+		}
+	}
+
 	group, uploadCtx := errgroup.WithContext(ctx)
 	var rootID flow.Identifier
 	var blobTree [][]cid.Cid
@@ -319,3 +359,170 @@ func (e *Manager) GetAccount(address flow.Address, blockHeader *flow.Header, vie
 
 	return account, nil
 }
+
+// SimulateTransaction executes the transaction against the given view without
+// committing its effects, and reports the computation used, the events emitted,
+// the change of storage used by the accounts it writes to and the fees the payer
+// would be charged. The view must not be committed afterwards.
+func (e *Manager) SimulateTransaction(
+	tx *flow.TransactionBody,
+	opts flow.TransactionSimulationOptions,
+	blockHeader *flow.Header,
+	view state.View,
+) (*flow.TransactionSimulationResult, error) {
+
+	blockCtx := fvm.NewContextFromParent(e.vmCtx,
+		fvm.WithBlockHeader(blockHeader),
+		fvm.WithTransactionProcessors(simulationProcessors(e.vmCtx.TransactionProcessors, opts)...),
+		fvm.WithProfiling(opts.Profile),
+	)
+
+	proc := fvm.Transaction(tx, 0)
+	programs := e.getChildProgramsOrEmpty(blockHeader.ID())
+
+	txView := view.NewChild()
+	err := func() (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				e.log.Error().
+					Hex("tx_id", logging.Entity(tx)).
+					Interface("recovered", r).
+					Msg("transaction simulation caused runtime panic")
+
+				err = fmt.Errorf("cadence runtime error: %s", r)
+			}
+		}()
+
+		return e.vm.Run(blockCtx, proc, txView, programs)
+	}()
+	if err != nil {
+		return nil, fmt.Errorf("failed to simulate transaction (internal error): %w", err)
+	}
+
+	changes, err := storageUsedChanges(view, txView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute storage used changes: %w", err)
+	}
+
+	result := &flow.TransactionSimulationResult{
+		BlockID:            blockHeader.ID(),
+		ComputationUsed:    proc.ComputationUsed,
+		Events:             proc.Events,
+		StorageUsedChanges: changes,
+		Profile:            proc.Profile,
+	}
+	if proc.Err != nil {
+		result.StatusCode = flow.TransactionStatusCodeFailed
+		result.ErrorMessage = proc.Err.Error()
+	}
+	if blockCtx.TransactionFeesEnabled {
+		// the fees are deducted after the transaction, so under the fee
+		// schedule as left by the transaction
+		fees, err := e.transactionFees(blockCtx, txView.NewChild(), programs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to estimate transaction fees: %w", err)
+		}
+		result.EstimatedFees = fees
+	}
+
+	return result, nil
+}
+
+// transactionFeesScript returns the fee the FlowServiceAccount contract deducts
+// from the payer of every transaction.
+const transactionFeesScript = `
+import FlowServiceAccount from 0x%s
+
+pub fun main(): UFix64 {
+	return FlowServiceAccount.transactionFee
+}
+`
+
+// transactionFees returns the fees a transaction is charged under the fee
+// schedule of the service account in the given view. The schedule is a flat fee
+// set by the service account, which doesn't depend on the computation used.
+func (e *Manager) transactionFees(blockCtx fvm.Context, view state.View, programs *programs.Programs) (uint64, error) {
+	code := fmt.Sprintf(transactionFeesScript, blockCtx.Chain.ServiceAddress().Hex())
+	script := fvm.Script([]byte(code))
+
+	err := e.vm.Run(blockCtx, script, view, programs)
+	if err != nil {
+		return 0, err
+	}
+	if script.Err != nil {
+		return 0, fmt.Errorf("could not read transaction fee schedule: %w", script.Err)
+	}
+
+	fees, ok := script.Value.(cadence.UFix64)
+	if !ok {
+		return 0, fmt.Errorf("invalid transaction fee: %v", script.Value)
+	}
+	return uint64(fees), nil
+}
+
+// simulationProcessors returns the transaction processors with the checks
+// disabled by the simulation options removed.
+func simulationProcessors(processors []fvm.TransactionProcessor, opts flow.TransactionSimulationOptions) []fvm.TransactionProcessor {
+	filtered := make([]fvm.TransactionProcessor, 0, len(processors))
+	for _, p := range processors {
+		switch p.(type) {
+		case *fvm.TransactionSignatureVerifier:
+			if opts.SkipSignatureCheck {
+				continue
+			}
+		case *fvm.TransactionSequenceNumberChecker:
+			if opts.SkipSequenceNumberCheck {
+				continue
+			}
+		}
+		filtered = append(filtered, p)
+	}
+	return filtered
+}
+
+// storageUsedChanges returns the storage used before and after the transaction
+// for every account with a register updated in the transaction view.
+func storageUsedChanges(before state.View, after state.View) ([]flow.StorageUsedChange, error) {
+	beforeAccounts := state.NewAccounts(state.NewStateHolder(state.NewState(before.NewChild())))
+	afterAccounts := state.NewAccounts(state.NewStateHolder(state.NewState(after.NewChild())))
+
+	ids, _ := after.RegisterUpdates()
+	seen := make(map[flow.Address]struct{})
+	changes := make([]flow.StorageUsedChange, 0)
+	for _, id := range ids {
+		if len(id.Owner) != flow.AddressLength {
+			continue
+		}
+		address := flow.BytesToAddress([]byte(id.Owner))
+		if _, ok := seen[address]; ok {
+			continue
+		}
+		seen[address] = struct{}{}
+
+		before, err := storageUsed(beforeAccounts, address)
+		if err != nil {
+			return nil, fmt.Errorf("could not get storage used before transaction: %w", err)
+		}
+		after, err := storageUsed(afterAccounts, address)
+		if err != nil {
+			return nil, fmt.Errorf("could not get storage used after transaction: %w", err)
+		}
+		change := flow.StorageUsedChange{Address: address, Before: before, After: after}
+		changes = append(changes, change)
+	}
+
+	return changes, nil
+}
+
+// storageUsed returns the storage used by the account, or zero if the account
+// does not exist, eg. when it is created by the simulated transaction.
+func storageUsed(accounts *state.StatefulAccounts, address flow.Address) (uint64, error) {
+	exists, err := accounts.Exists(address)
+	if err != nil {
+		return 0, fmt.Errorf("could not check account %s: %w", address, err)
+	}
+	if !exists {
+		return 0, nil
+	}
+	return accounts.GetStorageUsed(address)
+}
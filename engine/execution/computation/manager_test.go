@@ -221,7 +221,7 @@ func TestExecuteScript(t *testing.T) {
 	eds := new(state_synchronization.ExecutionDataService)
 	edCache := new(state_synchronization.ExecutionDataCIDCache)
 
-	engine, err := New(logger, metrics.NewNoopCollector(), nil, me, nil, vm, execCtx, DefaultProgramsCacheSize, committer.NewNoopViewCommitter(), scriptLogThreshold, nil, eds, edCache)
+	engine, err := New(logger, metrics.NewNoopCollector(), nil, me, nil, vm, execCtx, DefaultProgramsCacheSize, nil, committer.NewNoopViewCommitter(), scriptLogThreshold, nil, eds, edCache)
 	require.NoError(t, err)
 
 	header := unittest.BlockHeaderFixture()
@@ -246,7 +246,7 @@ func TestExecuteScripPanicsAreHandled(t *testing.T) {
 	eds := new(state_synchronization.ExecutionDataService)
 	edCache := new(state_synchronization.ExecutionDataCIDCache)
 
-	manager, err := New(log, metrics.NewNoopCollector(), nil, nil, nil, vm, ctx, DefaultProgramsCacheSize, committer.NewNoopViewCommitter(), scriptLogThreshold, nil, eds, edCache)
+	manager, err := New(log, metrics.NewNoopCollector(), nil, nil, nil, vm, ctx, DefaultProgramsCacheSize, nil, committer.NewNoopViewCommitter(), scriptLogThreshold, nil, eds, edCache)
 	require.NoError(t, err)
 
 	_, err = manager.ExecuteScript([]byte("whatever"), nil, &header, view)
@@ -273,7 +273,7 @@ func TestExecuteScript_LongScriptsAreLogged(t *testing.T) {
 	eds := new(state_synchronization.ExecutionDataService)
 	edCache := new(state_synchronization.ExecutionDataCIDCache)
 
-	manager, err := New(log, metrics.NewNoopCollector(), nil, nil, nil, vm, ctx, DefaultProgramsCacheSize, committer.NewNoopViewCommitter(), 1*time.Millisecond, nil, eds, edCache)
+	manager, err := New(log, metrics.NewNoopCollector(), nil, nil, nil, vm, ctx, DefaultProgramsCacheSize, nil, committer.NewNoopViewCommitter(), 1*time.Millisecond, nil, eds, edCache)
 	require.NoError(t, err)
 
 	_, err = manager.ExecuteScript([]byte("whatever"), nil, &header, view)
@@ -300,7 +300,7 @@ func TestExecuteScript_ShortScriptsAreNotLogged(t *testing.T) {
 	eds := new(state_synchronization.ExecutionDataService)
 	edCache := new(state_synchronization.ExecutionDataCIDCache)
 
-	manager, err := New(log, metrics.NewNoopCollector(), nil, nil, nil, vm, ctx, DefaultProgramsCacheSize, committer.NewNoopViewCommitter(), 1*time.Second, nil, eds, edCache)
+	manager, err := New(log, metrics.NewNoopCollector(), nil, nil, nil, vm, ctx, DefaultProgramsCacheSize, nil, committer.NewNoopViewCommitter(), 1*time.Second, nil, eds, edCache)
 	require.NoError(t, err)
 
 	_, err = manager.ExecuteScript([]byte("whatever"), nil, &header, view)
@@ -357,3 +357,92 @@ func (f *FakeUploader) Upload(computationResult *execution.ComputationResult) er
 	f.data[computationResult.ExecutableBlock.ID()] = computationResult
 	return nil
 }
+
+func TestSimulateTransaction(t *testing.T) {
+	rt := fvm.NewInterpreterRuntime()
+
+	chain := flow.Mainnet.Chain()
+
+	vm := fvm.NewVirtualMachine(rt)
+	execCtx := fvm.NewContext(zerolog.Nop(), fvm.WithChain(chain))
+
+	privateKeys, err := testutil.GenerateAccountPrivateKeys(1)
+	require.NoError(t, err)
+
+	ledger := testutil.RootBootstrappedLedger(vm, execCtx)
+	accounts, err := testutil.CreateAccounts(vm, ledger, programs.NewEmptyPrograms(), privateKeys, chain)
+	require.NoError(t, err)
+
+	// an unsigned transaction deploying a contract to the new account
+	tx := testutil.DeployCounterContractTransaction(accounts[0], chain)
+	tx.SetProposalKey(chain.ServiceAddress(), 0, 0).
+		SetGasLimit(1000).
+		SetPayer(chain.ServiceAddress())
+
+	me := new(module.Local)
+	me.On("NodeID").Return(flow.ZeroID)
+
+	newManager := func(ctx fvm.Context) *Manager {
+		eds := new(state_synchronization.ExecutionDataService)
+		edCache := new(state_synchronization.ExecutionDataCIDCache)
+		engine, err := New(zerolog.Nop(), metrics.NewNoopCollector(), nil, me, nil, vm, ctx, DefaultProgramsCacheSize, nil, committer.NewNoopViewCommitter(), scriptLogThreshold, nil, eds, edCache)
+		require.NoError(t, err)
+		return engine
+	}
+	header := unittest.BlockHeaderFixture()
+
+	t.Run("unsigned transaction with checks skipped", func(t *testing.T) {
+		view := delta.NewView(ledger.Get)
+		opts := flow.TransactionSimulationOptions{SkipSignatureCheck: true, SkipSequenceNumberCheck: true}
+
+		result, err := newManager(execCtx).SimulateTransaction(tx, opts, &header, view)
+		require.NoError(t, err)
+
+		assert.False(t, result.Failed(), result.ErrorMessage)
+		assert.Equal(t, header.ID(), result.BlockID)
+		assert.True(t, result.ComputationUsed > 0)
+		assert.NotEmpty(t, result.Events)
+		assert.Zero(t, result.EstimatedFees)
+
+		var change *flow.StorageUsedChange
+		for i := range result.StorageUsedChanges {
+			if result.StorageUsedChanges[i].Address == accounts[0] {
+				change = &result.StorageUsedChanges[i]
+			}
+		}
+		require.NotNil(t, change)
+		assert.True(t, change.Delta() > 0)
+
+		// the simulation must not modify the view it is given
+		assert.Empty(t, view.Delta().Data)
+	})
+
+	t.Run("unsigned transaction", func(t *testing.T) {
+		view := delta.NewView(ledger.Get)
+
+		result, err := newManager(execCtx).SimulateTransaction(tx, flow.TransactionSimulationOptions{}, &header, view)
+		require.NoError(t, err)
+
+		assert.True(t, result.Failed())
+		assert.NotEmpty(t, result.ErrorMessage)
+	})
+
+	t.Run("fees enabled", func(t *testing.T) {
+		// the fees are read from the fee schedule of the service account
+		fee, err := cadence.NewUFix64("0.0005")
+		require.NoError(t, err)
+		ledger := testutil.RootBootstrappedLedger(vm, execCtx, fvm.WithTransactionFee(fee))
+		_, err = testutil.CreateAccounts(vm, ledger, programs.NewEmptyPrograms(), privateKeys, chain)
+		require.NoError(t, err)
+
+		view := delta.NewView(ledger.Get)
+		opts := flow.TransactionSimulationOptions{SkipSignatureCheck: true, SkipSequenceNumberCheck: true}
+		ctx := fvm.NewContextFromParent(execCtx, fvm.WithTransactionFeesEnabled(true))
+
+		result, err := newManager(ctx).SimulateTransaction(tx, opts, &header, view)
+		require.NoError(t, err)
+
+		assert.False(t, result.Failed(), result.ErrorMessage)
+		assert.Equal(t, uint64(fee), result.EstimatedFees)
+	})
+}
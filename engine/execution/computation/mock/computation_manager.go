@@ -12,6 +12,8 @@ import (
 
 	mock "github.com/stretchr/testify/mock"
 
+	profiling "github.com/onflow/flow-go/fvm/profiling"
+
 	state "github.com/onflow/flow-go/fvm/state"
 )
 
@@ -88,3 +90,58 @@ func (_m *ComputationManager) GetAccount(addr flow.Address, header *flow.Header,
 
 	return r0, r1
 }
+
+// ProfileScript provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *ComputationManager) ProfileScript(_a0 []byte, _a1 [][]byte, _a2 *flow.Header, _a3 state.View) ([]byte, *profiling.Profile, error) {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	var r0 []byte
+	if rf, ok := ret.Get(0).(func([]byte, [][]byte, *flow.Header, state.View) []byte); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	var r1 *profiling.Profile
+	if rf, ok := ret.Get(1).(func([]byte, [][]byte, *flow.Header, state.View) *profiling.Profile); ok {
+		r1 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*profiling.Profile)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func([]byte, [][]byte, *flow.Header, state.View) error); ok {
+		r2 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// SimulateTransaction provides a mock function with given fields: tx, opts, header, view
+func (_m *ComputationManager) SimulateTransaction(tx *flow.TransactionBody, opts flow.TransactionSimulationOptions, header *flow.Header, view state.View) (*flow.TransactionSimulationResult, error) {
+	ret := _m.Called(tx, opts, header, view)
+
+	var r0 *flow.TransactionSimulationResult
+	if rf, ok := ret.Get(0).(func(*flow.TransactionBody, flow.TransactionSimulationOptions, *flow.Header, state.View) *flow.TransactionSimulationResult); ok {
+		r0 = rf(tx, opts, header, view)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*flow.TransactionSimulationResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*flow.TransactionBody, flow.TransactionSimulationOptions, *flow.Header, state.View) error); ok {
+		r1 = rf(tx, opts, header, view)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
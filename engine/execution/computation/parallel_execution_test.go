@@ -0,0 +1,259 @@
+package computation
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/engine/execution"
+	"github.com/onflow/flow-go/engine/execution/computation/committer"
+	"github.com/onflow/flow-go/engine/execution/computation/computer"
+	"github.com/onflow/flow-go/engine/execution/state"
+	bootstrapexec "github.com/onflow/flow-go/engine/execution/state/bootstrap"
+	"github.com/onflow/flow-go/engine/execution/state/delta"
+	"github.com/onflow/flow-go/engine/execution/testutil"
+	"github.com/onflow/flow-go/fvm"
+	"github.com/onflow/flow-go/fvm/blueprints"
+	"github.com/onflow/flow-go/fvm/programs"
+	completeLedger "github.com/onflow/flow-go/ledger/complete"
+	"github.com/onflow/flow-go/ledger/complete/wal/fixtures"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/mempool/entity"
+	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/module/trace"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// parallelExecutionWorkers is the number of workers used for parallel execution in the differential tests.
+const parallelExecutionWorkers = 4
+
+// Test_ParallelExecutionMatchesSerialExecution is a differential test, which
+// executes the same blocks with serial and with optimistic parallel execution,
+// and checks that both produce identical results.
+func Test_ParallelExecutionMatchesSerialExecution(t *testing.T) {
+
+	noFees := []fvm.Option{
+		fvm.WithTransactionFeesEnabled(false),
+		fvm.WithAccountStorageLimit(false),
+	}
+	fees := []fvm.Option{
+		fvm.WithTransactionFeesEnabled(true),
+		fvm.WithAccountStorageLimit(true),
+	}
+	feesBootstrap := []fvm.BootstrapProcedureOption{
+		fvm.WithInitialTokenSupply(unittest.GenesisTokenSupply),
+		fvm.WithAccountCreationFee(fvm.DefaultAccountCreationFee),
+		fvm.WithMinimumStorageReservation(fvm.DefaultMinimumStorageReservation),
+		fvm.WithTransactionFee(fvm.DefaultTransactionFees),
+		fvm.WithStorageMBPerFLOW(fvm.DefaultStorageMBPerFLOW),
+	}
+
+	t.Run("empty block", func(t *testing.T) {
+		executeBlockInBothModes(t, [][]*flow.TransactionBody{}, noFees, nil)
+	})
+
+	t.Run("independent transactions", func(t *testing.T) {
+		txs := independentTransactions(t, 4)
+		cr, logs := executeBlockInBothModes(t, txs, noFees, nil)
+
+		requireNoTransactionErrors(t, cr)
+		// the transactions of the second collection don't conflict, so none of them is executed again
+		assert.Regexp(t, `"collection_index":1,"transactions":4,"reexecuted":0`, logs)
+	})
+
+	t.Run("independent transactions with fees", func(t *testing.T) {
+		txs := independentTransactions(t, 4)
+		cr, _ := executeBlockInBothModes(t, txs, fees, feesBootstrap)
+
+		// the new accounts are not funded, so their transactions fail the storage
+		// check, but still emit the fee deduction events
+		for _, result := range cr.TransactionResults[len(txs[0]) : len(txs[0])+len(txs[1])] {
+			assert.Contains(t, result.ErrorMessage, "Error Code: 1103")
+		}
+	})
+
+	t.Run("conflicting transactions", func(t *testing.T) {
+		// all transactions use the proposal key of the service account
+		collection := make([]*flow.TransactionBody, 0, 5)
+		for i := 0; i < 5; i++ {
+			tx := flow.NewTransactionBody().
+				SetScript([]byte(fmt.Sprintf(`transaction { prepare(signer: AuthAccount) { signer.save(%d, to: /storage/value%d) } }`, i, i))).
+				AddAuthorizer(chain.ServiceAddress())
+			err := testutil.SignTransactionAsServiceAccount(tx, uint64(i), chain)
+			require.NoError(t, err)
+			collection = append(collection, tx)
+		}
+
+		cr, _ := executeBlockInBothModes(t, [][]*flow.TransactionBody{collection}, noFees, nil)
+		requireNoTransactionErrors(t, cr)
+	})
+
+	t.Run("contract deployment and use", func(t *testing.T) {
+		deployTx := blueprints.DeployContractTransaction(chain.ServiceAddress(), []byte(`
+			pub contract Foo {
+				pub event FooEvent(x: Int, y: Int)
+
+				pub fun event() {
+					emit FooEvent(x: 2, y: 1)
+				}
+			}`), "Foo")
+		err := testutil.SignTransactionAsServiceAccount(deployTx, 0, chain)
+		require.NoError(t, err)
+
+		emitTxs := make([]*flow.TransactionBody, 3)
+		for i := range emitTxs {
+			emitTxs[i] = &flow.TransactionBody{
+				Script: []byte(fmt.Sprintf(`
+					import Foo from 0x%s
+					transaction {
+						prepare() {}
+						execute {
+							Foo.event()
+						}
+					}`, chain.ServiceAddress())),
+			}
+			err = testutil.SignTransactionAsServiceAccount(emitTxs[i], uint64(i+1), chain)
+			require.NoError(t, err)
+		}
+
+		cr, _ := executeBlockInBothModes(t, [][]*flow.TransactionBody{
+			{deployTx, emitTxs[0], emitTxs[1]},
+			{emitTxs[2]},
+		}, noFees, nil)
+
+		requireNoTransactionErrors(t, cr)
+	})
+
+	t.Run("failing transactions", func(t *testing.T) {
+		txs := independentTransactions(t, 4)
+
+		failingTx := flow.NewTransactionBody().
+			SetScript([]byte(`transaction { prepare(signer: AuthAccount) { panic("fail") } }`)).
+			AddAuthorizer(chain.ServiceAddress())
+		err := testutil.SignTransactionAsServiceAccount(failingTx, 4, chain)
+		require.NoError(t, err)
+		txs[1] = append(txs[1][:2], append([]*flow.TransactionBody{failingTx}, txs[1][2:]...)...)
+
+		cr, _ := executeBlockInBothModes(t, txs, noFees, nil)
+		assert.NotEmpty(t, cr.TransactionResults[len(txs[0])+2].ErrorMessage)
+	})
+}
+
+// independentTransactions returns a block with a first collection creating n
+// accounts, and a second collection with one transaction per new account,
+// each only touching the registers of its own account.
+func independentTransactions(t *testing.T, n int) [][]*flow.TransactionBody {
+	keys := make([]flow.AccountPrivateKey, n)
+	creations := make([]*flow.TransactionBody, n)
+	for i := 0; i < n; i++ {
+		var tx *flow.TransactionBody
+		keys[i], tx = testutil.CreateAccountCreationTransaction(t, chain)
+		err := testutil.SignTransactionAsServiceAccount(tx, uint64(i), chain)
+		require.NoError(t, err)
+		creations[i] = tx
+	}
+
+	// the accounts are created after the accounts created during bootstrapping
+	independent := make([]*flow.TransactionBody, n)
+	for i := 0; i < n; i++ {
+		address, err := chain.AddressAtIndex(uint64(5 + i))
+		require.NoError(t, err)
+
+		tx := flow.NewTransactionBody().
+			SetScript([]byte(fmt.Sprintf(`transaction { prepare(signer: AuthAccount) { signer.save(%d, to: /storage/value) } }`, i))).
+			AddAuthorizer(address)
+		err = testutil.SignTransaction(tx, address, keys[i], 0)
+		require.NoError(t, err)
+		independent[i] = tx
+	}
+
+	return [][]*flow.TransactionBody{creations, independent}
+}
+
+// executeBlockInBothModes executes a block containing the given collections
+// with serial and with parallel execution, each starting from a freshly
+// bootstrapped ledger, and requires that both produce identical results,
+// including the registers read and written by every transaction. It
+// returns the result of the parallel execution, and its logs.
+func executeBlockInBothModes(t *testing.T,
+	txs [][]*flow.TransactionBody,
+	opts []fvm.Option,
+	bootstrapOpts []fvm.BootstrapProcedureOption,
+) (*execution.ComputationResult, string) {
+
+	executableBlock := unittest.ExecutableBlockFromTransactions(txs)
+
+	serial := executeBlockWithOptions(t, zerolog.Nop(), executableBlock, opts, bootstrapOpts, computer.WithRegisterSets())
+
+	var logs bytes.Buffer
+	parallel := executeBlockWithOptions(t, zerolog.New(zerolog.SyncWriter(&logs)), executableBlock, opts, bootstrapOpts, computer.WithParallelExecution(parallelExecutionWorkers), computer.WithRegisterSets())
+
+	require.Equal(t, serial.StateCommitments, parallel.StateCommitments)
+	require.Equal(t, serial.Proofs, parallel.Proofs)
+	require.Equal(t, serial.TrieUpdates, parallel.TrieUpdates)
+	require.Equal(t, serial.Events, parallel.Events)
+	require.Equal(t, serial.EventsHashes, parallel.EventsHashes)
+	require.Equal(t, serial.ServiceEvents, parallel.ServiceEvents)
+	require.Equal(t, serial.TransactionResults, parallel.TransactionResults)
+	require.Equal(t, serial.ComputationUsed, parallel.ComputationUsed)
+	require.Equal(t, serial.StateReads, parallel.StateReads)
+	require.Equal(t, serial.StateSnapshots, parallel.StateSnapshots)
+	require.Equal(t, serial.RegisterSets, parallel.RegisterSets)
+
+	return parallel, logs.String()
+}
+
+// requireNoTransactionErrors requires that none of the transactions of the
+// collections failed. The system chunk transaction is not checked, as the epoch
+// contracts are not deployed when bootstrapping the ledger for these tests.
+func requireNoTransactionErrors(t *testing.T, cr *execution.ComputationResult) {
+	for _, result := range cr.TransactionResults[:len(cr.TransactionResults)-1] {
+		require.Empty(t, result.ErrorMessage)
+	}
+}
+
+func executeBlockWithOptions(t *testing.T,
+	logger zerolog.Logger,
+	executableBlock *entity.ExecutableBlock,
+	opts []fvm.Option,
+	bootstrapOpts []fvm.BootstrapProcedureOption,
+	blockComputerOpts ...computer.BlockComputerOption,
+) *execution.ComputationResult {
+
+	rt := fvm.NewInterpreterRuntime()
+	vm := fvm.NewVirtualMachine(rt)
+
+	fvmContext := fvm.NewContext(logger, append(opts, fvm.WithChain(chain))...)
+
+	collector := metrics.NewNoopCollector()
+	tracer := trace.NewNoopTracer()
+
+	ledger, err := completeLedger.NewLedger(&fixtures.NoopWAL{}, 100, collector, logger, completeLedger.DefaultPathFinderVersion)
+	require.NoError(t, err)
+
+	initialCommit, err := bootstrapexec.NewBootstrapper(logger).BootstrapLedger(
+		ledger,
+		unittest.ServiceAccountPublicKey,
+		chain,
+		bootstrapOpts...,
+	)
+	require.NoError(t, err)
+
+	ledgerCommitter := committer.NewLedgerViewCommitter(ledger, tracer)
+
+	blockComputer, err := computer.NewBlockComputer(vm, fvmContext, collector, tracer, logger, ledgerCommitter, blockComputerOpts...)
+	require.NoError(t, err)
+
+	view := delta.NewView(state.LedgerGetRegister(ledger, initialCommit))
+	executableBlock.StartState = &initialCommit
+
+	computationResult, err := blockComputer.ExecuteBlock(context.Background(), executableBlock, view, programs.NewEmptyPrograms())
+	require.NoError(t, err)
+
+	return computationResult
+}
@@ -2,10 +2,13 @@ package computation
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
+	badgerdb "github.com/dgraph-io/badger/v2"
 	"github.com/onflow/cadence"
 	jsoncdc "github.com/onflow/cadence/encoding/json"
+	"github.com/onflow/cadence/runtime/common"
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -25,6 +28,7 @@ import (
 	module "github.com/onflow/flow-go/module/mock"
 	state_synchronization "github.com/onflow/flow-go/module/state_synchronization/mock"
 	"github.com/onflow/flow-go/module/trace"
+	bstorage "github.com/onflow/flow-go/storage/badger"
 	"github.com/onflow/flow-go/utils/unittest"
 )
 
@@ -429,3 +433,163 @@ func hasValidEventValue(t *testing.T, event flow.Event, value int) {
 	require.NoError(t, err)
 	assert.Equal(t, int16(value), data.(cadence.Event).Fields[0].ToGoValue())
 }
+
+// TestPrograms_WarmUp tests that the contracts whose programs were loaded are added
+// to the warm-up list, that their programs are loaded again after a restart, and that
+// contracts updated by a block are removed from the list.
+func TestPrograms_WarmUp(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badgerdb.DB) {
+		rt := fvm.NewInterpreterRuntime()
+		chain := flow.Mainnet.Chain()
+		vm := fvm.NewVirtualMachine(rt)
+		execCtx := fvm.NewContext(zerolog.Nop(), fvm.WithChain(chain))
+
+		privateKeys, err := testutil.GenerateAccountPrivateKeys(1)
+		require.NoError(t, err)
+		ledger := testutil.RootBootstrappedLedger(vm, execCtx)
+		accounts, err := testutil.CreateAccounts(vm, ledger, programs.NewEmptyPrograms(), privateKeys, chain)
+		require.NoError(t, err)
+
+		account := accounts[0]
+		privKey := privateKeys[0]
+		location := common.AddressLocation{
+			Address: common.Address(account),
+			Name:    "EventContract",
+		}
+
+		warmUpContracts := bstorage.NewWarmUpContracts(db)
+
+		newManager := func() (*Manager, *ProgramsCache, *module.ExecutionMetrics) {
+			me := new(module.Local)
+			me.On("NodeID").Return(flow.ZeroID)
+
+			blockComputer, err := computer.NewBlockComputer(vm, execCtx, metrics.NewNoopCollector(), trace.NewNoopTracer(), zerolog.Nop(), committer.NewNoopViewCommitter())
+			require.NoError(t, err)
+
+			programsCache, err := NewProgramsCache(10)
+			require.NoError(t, err)
+
+			eds := new(state_synchronization.ExecutionDataService)
+			eds.On("Add", mock.Anything, mock.Anything).Return(flow.ZeroID, nil, nil)
+
+			edCache := new(state_synchronization.ExecutionDataCIDCache)
+			edCache.On("Insert", mock.AnythingOfType("*flow.Header"), mock.AnythingOfType("BlobTree"))
+
+			collector := new(module.ExecutionMetrics)
+			collector.On("ExecutionProgramsWarmUpHit", mock.AnythingOfType("time.Duration")).Maybe()
+			collector.On("ExecutionProgramsWarmUpMisses", mock.AnythingOfType("int")).Maybe()
+
+			return &Manager{
+				log:             zerolog.Nop(),
+				metrics:         collector,
+				vm:              vm,
+				vmCtx:           execCtx,
+				blockComputer:   blockComputer,
+				me:              me,
+				programsCache:   programsCache,
+				warmUpContracts: warmUpContracts,
+				eds:             eds,
+				edCache:         edCache,
+			}, programsCache, collector
+		}
+
+		engine, _, _ := newManager()
+		view := delta.NewView(ledger.Get)
+
+		genesis := &flow.Block{
+			Header:  &flow.Header{},
+			Payload: &flow.Payload{},
+		}
+
+		deployTx := testutil.DeployEventContractTransaction(account, chain, 1)
+		prepareTx(t, deployTx, account, privKey, 0, chain)
+		block1View := view.NewChild()
+		block1, _ := createTestBlockAndRun(t, engine, genesis, flow.Collection{Transactions: []*flow.TransactionBody{deployTx}}, block1View)
+
+		emitTx := testutil.CreateEmitEventTransaction(account, account)
+		prepareTx(t, emitTx, account, privKey, 1, chain)
+		block2View := block1View.NewChild()
+		block2, _ := createTestBlockAndRun(t, engine, block1, flow.Collection{Transactions: []*flow.TransactionBody{emitTx}}, block2View)
+
+		// the contract loaded by the emit transaction is added to the warm-up list
+		stored, err := warmUpContracts.All()
+		require.NoError(t, err)
+		assert.Contains(t, stored, flow.WarmUpContract{
+			Address:  account,
+			Name:     "EventContract",
+			CodeHash: codeHash([]byte(fmt.Sprintf(testutil.EventContract, 1))),
+		})
+
+		t.Run("programs are loaded again after a restart", func(t *testing.T) {
+			engine, programsCache, collector := newManager()
+			collector.On("ExecutionProgramsWarmedUp", len(stored), 0, mock.AnythingOfType("time.Duration")).Once()
+
+			err := engine.WarmUpPrograms(block2.Header, block2View.NewChild())
+			require.NoError(t, err)
+			collector.AssertExpectations(t)
+
+			warmedUp := programsCache.Get(block2.ID())
+			require.NotNil(t, warmedUp)
+			_, _, has := warmedUp.Get(location)
+			require.True(t, has)
+
+			// executing a block on top of the warmed up block uses the loaded program
+			emitTx := testutil.CreateEmitEventTransaction(account, account)
+			prepareTx(t, emitTx, account, privKey, 2, chain)
+			block3View := block2View.NewChild()
+			_, res := createTestBlockAndRun(t, engine, block2, flow.Collection{Transactions: []*flow.TransactionBody{emitTx}}, block3View)
+			hasValidEventValue(t, res.Events[0][0], 1)
+
+			collector.AssertCalled(t, "ExecutionProgramsWarmUpHit", mock.AnythingOfType("time.Duration"))
+
+			// each warmed up program is only reported as a hit the first time it is used
+			hits := 0
+			for _, call := range collector.Calls {
+				if call.Method == "ExecutionProgramsWarmUpHit" {
+					hits++
+				}
+			}
+			assert.LessOrEqual(t, hits, len(stored))
+		})
+
+		t.Run("updated contracts are removed", func(t *testing.T) {
+			updateTx := testutil.UpdateEventContractTransaction(account, chain, 2)
+			prepareTx(t, updateTx, account, privKey, 2, chain)
+			block3View := block2View.NewChild()
+			_, res := createTestBlockAndRun(t, engine, block2, flow.Collection{Transactions: []*flow.TransactionBody{updateTx}}, block3View)
+			assert.EqualValues(t, "flow.AccountContractUpdated", res.Events[0][0].Type)
+
+			stored, err := warmUpContracts.All()
+			require.NoError(t, err)
+			for _, program := range stored {
+				assert.NotEqual(t, "EventContract", program.Name)
+			}
+		})
+
+		t.Run("stale programs are removed when warming up", func(t *testing.T) {
+			err := warmUpContracts.Update([]*flow.WarmUpContract{{
+				Address:  account,
+				Name:     "EventContract",
+				CodeHash: unittest.IdentifierFixture(),
+			}}, nil)
+			require.NoError(t, err)
+
+			stored, err := warmUpContracts.All()
+			require.NoError(t, err)
+
+			engine, programsCache, collector := newManager()
+			collector.On("ExecutionProgramsWarmedUp", len(stored)-1, 1, mock.AnythingOfType("time.Duration")).Once()
+
+			err = engine.WarmUpPrograms(block2.Header, block2View.NewChild())
+			require.NoError(t, err)
+			collector.AssertExpectations(t)
+
+			_, _, has := programsCache.Get(block2.ID()).Get(location)
+			require.False(t, has)
+
+			remaining, err := warmUpContracts.All()
+			require.NoError(t, err)
+			assert.Len(t, remaining, len(stored)-1)
+		})
+	})
+}
@@ -0,0 +1,237 @@
+package computation
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/onflow/cadence/runtime/common"
+
+	"github.com/onflow/flow-go/crypto/hash"
+	"github.com/onflow/flow-go/fvm"
+	"github.com/onflow/flow-go/fvm/programs"
+	"github.com/onflow/flow-go/fvm/state"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/logging"
+)
+
+// Programs can't be serialized, so the warm-up list only holds the locations of the contracts
+// whose programs were loaded, along with the hash of their code. When the node starts, the
+// programs of these contracts are loaded again by importing them in a script.
+const warmUpScriptTemplate = `
+import %s from 0x%s
+
+pub fun main() {}
+`
+
+// updateWarmUpContracts adds the contracts whose programs were loaded while executing a block
+// to the warm-up list, and removes the ones whose code was updated by the block. Contracts which
+// are already known to be in the list are skipped, so the list is only written to when it changes,
+// in a single transaction.
+func (e *Manager) updateWarmUpContracts(blockPrograms *programs.Programs, view state.View) error {
+	e.warmUpMu.Lock()
+	defer e.warmUpMu.Unlock()
+
+	if e.warmUpKnown == nil {
+		e.warmUpKnown = make(map[programs.ContractUpdateKey]struct{})
+	}
+
+	misses := 0
+	for _, location := range blockPrograms.Locations() {
+		if _, ok := location.(common.AddressLocation); ok {
+			misses++
+		}
+	}
+	if misses > 0 {
+		e.metrics.ExecutionProgramsWarmUpMisses(misses)
+	}
+
+	var removed []*flow.WarmUpContract
+	updated := make(map[programs.ContractUpdateKey]struct{})
+	ids, _ := view.RegisterUpdates()
+	for _, id := range ids {
+		key, ok := contractCodeKey(id)
+		if !ok {
+			continue
+		}
+		updated[key] = struct{}{}
+		removed = append(removed, &flow.WarmUpContract{
+			Address: key.Address,
+			Name:    key.Name,
+		})
+	}
+
+	var stored []*flow.WarmUpContract
+	accounts := state.NewAccounts(state.NewStateHolder(state.NewState(view.NewChild())))
+	for _, location := range blockPrograms.Locations() {
+		addressLocation, ok := location.(common.AddressLocation)
+		if !ok {
+			continue
+		}
+		key := programs.ContractUpdateKey{
+			Address: flow.Address(addressLocation.Address),
+			Name:    addressLocation.Name,
+		}
+		_, known := e.warmUpKnown[key]
+		_, wasUpdated := updated[key]
+		if known && !wasUpdated {
+			continue
+		}
+		code, err := accounts.GetContract(key.Name, key.Address)
+		if err != nil {
+			return fmt.Errorf("cannot get code of contract %s: %w", location, err)
+		}
+		if len(code) == 0 {
+			continue
+		}
+		stored = append(stored, &flow.WarmUpContract{
+			Address:  key.Address,
+			Name:     key.Name,
+			CodeHash: codeHash(code),
+		})
+	}
+
+	if len(stored) == 0 && len(removed) == 0 {
+		return nil
+	}
+
+	err := e.warmUpContracts.Update(stored, removed)
+	if err != nil {
+		return fmt.Errorf("cannot update warm-up contracts: %w", err)
+	}
+
+	for _, contract := range removed {
+		delete(e.warmUpKnown, programs.ContractUpdateKey{Address: contract.Address, Name: contract.Name})
+	}
+	for _, contract := range stored {
+		e.warmUpKnown[programs.ContractUpdateKey{Address: contract.Address, Name: contract.Name}] = struct{}{}
+	}
+
+	return nil
+}
+
+// WarmUpPrograms loads the programs of the contracts of the warm-up list at the given block, so
+// the blocks executed on top of it don't have to parse and check them again. Contracts whose code
+// changed since they were added to the list, or which can't be loaded, are removed from it.
+func (e *Manager) WarmUpPrograms(header *flow.Header, view state.View) error {
+	if e.warmUpContracts == nil {
+		return nil
+	}
+
+	contracts, err := e.warmUpContracts.All()
+	if err != nil {
+		return fmt.Errorf("cannot get warm-up contracts: %w", err)
+	}
+
+	blockCtx := fvm.NewContextFromParent(e.vmCtx, fvm.WithBlockHeader(header))
+	blockPrograms := programs.NewEmptyPrograms()
+	accounts := state.NewAccounts(state.NewStateHolder(state.NewState(view.NewChild())))
+
+	loaded := make(map[programs.ContractUpdateKey]struct{})
+	// the time to load a program includes the time to load the imports it loaded first
+	loadTimes := make(map[common.LocationID]time.Duration)
+	var stale []*flow.WarmUpContract
+	start := time.Now()
+
+	for i, contract := range contracts {
+		log := e.log.With().
+			Str("address", contract.Address.Hex()).
+			Str("contract", contract.Name).
+			Logger()
+
+		code, err := accounts.GetContract(contract.Name, contract.Address)
+		if err != nil {
+			return fmt.Errorf("cannot get code of contract %s.%s: %w", contract.Address, contract.Name, err)
+		}
+
+		if len(code) == 0 || codeHash(code) != contract.CodeHash {
+			log.Debug().Msg("warm-up contract is stale")
+		} else {
+			loadStart := time.Now()
+			err = e.loadProgram(blockCtx, contract, view.NewChild(), blockPrograms)
+			if err == nil {
+				loaded[programs.ContractUpdateKey{Address: contract.Address, Name: contract.Name}] = struct{}{}
+				location := common.AddressLocation{Address: common.Address(contract.Address), Name: contract.Name}
+				loadTimes[location.ID()] = time.Since(loadStart)
+				continue
+			}
+			log.Warn().Err(err).Msg("could not load program of warm-up contract")
+		}
+
+		stale = append(stale, &contracts[i])
+	}
+
+	duration := time.Since(start)
+
+	if len(stale) > 0 {
+		err = e.warmUpContracts.Update(nil, stale)
+		if err != nil {
+			return fmt.Errorf("cannot remove stale warm-up contracts: %w", err)
+		}
+	}
+
+	e.warmUpMu.Lock()
+	e.warmUpKnown = loaded
+	e.warmUpUnused = loadTimes
+	e.warmUpMu.Unlock()
+
+	// drop the programs of the scripts, keeping only the ones of the contracts. The blocks executed
+	// on top use them through a child, which reports the first use of each of them as a hit
+	blockPrograms.Cleanup(nil)
+	e.programsCache.Set(header.ID(), blockPrograms.ChildProgramsWithHitFunc(e.warmUpHit))
+
+	e.metrics.ExecutionProgramsWarmedUp(len(loaded), len(stale), duration)
+
+	e.log.Info().
+		Hex("block_id", logging.ID(header.ID())).
+		Int("loaded", len(loaded)).
+		Int("stale", len(stale)).
+		Dur("duration", duration).
+		Msg("programs warmed up")
+
+	return nil
+}
+
+// warmUpHit reports the first use of a program loaded by the warm-up.
+func (e *Manager) warmUpHit(location common.Location) {
+	e.warmUpMu.Lock()
+	defer e.warmUpMu.Unlock()
+
+	loadTime, ok := e.warmUpUnused[location.ID()]
+	if !ok {
+		return
+	}
+	delete(e.warmUpUnused, location.ID())
+	e.metrics.ExecutionProgramsWarmUpHit(loadTime)
+}
+
+func (e *Manager) loadProgram(ctx fvm.Context, contract flow.WarmUpContract, view state.View, blockPrograms *programs.Programs) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("cadence runtime error: %s", r)
+		}
+	}()
+
+	script := fvm.Script([]byte(fmt.Sprintf(warmUpScriptTemplate, contract.Name, contract.Address.Hex())))
+	err = e.vm.Run(ctx, script, view, blockPrograms)
+	if err != nil {
+		return err
+	}
+	return script.Err
+}
+
+// contractCodeKey returns the contract whose code is stored in the given register, if any
+func contractCodeKey(id flow.RegisterID) (programs.ContractUpdateKey, bool) {
+	prefix := state.KeyCode + "."
+	if id.Owner != id.Controller || !strings.HasPrefix(id.Key, prefix) {
+		return programs.ContractUpdateKey{}, false
+	}
+	return programs.ContractUpdateKey{
+		Address: flow.BytesToAddress([]byte(id.Owner)),
+		Name:    strings.TrimPrefix(id.Key, prefix),
+	}, true
+}
+
+func codeHash(code []byte) flow.Identifier {
+	return flow.HashToID(hash.NewSHA3_256().ComputeHash(code))
+}
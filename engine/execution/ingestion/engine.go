@@ -20,6 +20,7 @@ import (
 	"github.com/onflow/flow-go/engine/execution/state"
 	"github.com/onflow/flow-go/engine/execution/state/delta"
 	"github.com/onflow/flow-go/engine/execution/utils"
+	"github.com/onflow/flow-go/fvm/profiling"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/model/flow/filter"
 	"github.com/onflow/flow-go/module"
@@ -1012,15 +1013,33 @@ func (e *Engine) matchOrRequestCollections(
 }
 
 func (e *Engine) ExecuteScriptAtBlockID(ctx context.Context, script []byte, arguments [][]byte, blockID flow.Identifier) ([]byte, error) {
+	block, blockView, err := e.scriptView(ctx, script, arguments, blockID)
+	if err != nil {
+		return nil, err
+	}
+	return e.computationManager.ExecuteScript(script, arguments, block, blockView)
+}
+
+func (e *Engine) ProfileScriptAtBlockID(ctx context.Context, script []byte, arguments [][]byte, blockID flow.Identifier) ([]byte, *profiling.Profile, error) {
+	block, blockView, err := e.scriptView(ctx, script, arguments, blockID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return e.computationManager.ProfileScript(script, arguments, block, blockView)
+}
+
+// scriptView returns the header of the given block and a view of the execution state at the
+// block to execute the script against.
+func (e *Engine) scriptView(ctx context.Context, script []byte, arguments [][]byte, blockID flow.Identifier) (*flow.Header, *delta.View, error) {
 
 	stateCommit, err := e.execState.StateCommitmentByBlockID(ctx, blockID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get state commitment for block (%s): %w", blockID, err)
+		return nil, nil, fmt.Errorf("failed to get state commitment for block (%s): %w", blockID, err)
 	}
 
 	block, err := e.state.AtBlockID(blockID).Head()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get block (%s): %w", blockID, err)
+		return nil, nil, fmt.Errorf("failed to get block (%s): %w", blockID, err)
 	}
 
 	blockView := e.execState.NewView(stateCommit)
@@ -1038,7 +1057,7 @@ func (e *Engine) ExecuteScriptAtBlockID(ctx context.Context, script []byte, argu
 			Str("args", strings.Join(args[:], ",")).
 			Msg("extensive log: executed script content")
 	}
-	return e.computationManager.ExecuteScript(script, arguments, block, blockView)
+	return block, blockView, nil
 }
 
 func (e *Engine) GetRegisterAtBlockID(ctx context.Context, owner, controller, key []byte, blockID flow.Identifier) ([]byte, error) {
@@ -1074,6 +1093,40 @@ func (e *Engine) GetAccount(ctx context.Context, addr flow.Address, blockID flow
 	return e.computationManager.GetAccount(addr, block, blockView)
 }
 
+func (e *Engine) SimulateTransactionAtBlockID(
+	ctx context.Context,
+	tx *flow.TransactionBody,
+	opts flow.TransactionSimulationOptions,
+	blockID flow.Identifier,
+) (*flow.TransactionSimulationResult, error) {
+
+	stateCommit, err := e.execState.StateCommitmentByBlockID(ctx, blockID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get state commitment for block (%s): %w", blockID, err)
+	}
+
+	block, err := e.state.AtBlockID(blockID).Head()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block (%s): %w", blockID, err)
+	}
+
+	// the view is discarded after the simulation, so the transaction never
+	// modifies the execution state
+	blockView := e.execState.NewView(stateCommit)
+
+	if e.extensiveLogging {
+		e.log.Debug().
+			Hex("block_id", logging.ID(blockID)).
+			Uint64("block_height", block.Height).
+			Hex("state_commitment", stateCommit[:]).
+			Hex("tx_id", logging.Entity(tx)).
+			Bool("skip_signature_check", opts.SkipSignatureCheck).
+			Bool("skip_sequence_number_check", opts.SkipSequenceNumberCheck).
+			Msg("extensive log: simulated transaction")
+	}
+	return e.computationManager.SimulateTransaction(tx, opts, block, blockView)
+}
+
 func (e *Engine) handleComputationResult(
 	ctx context.Context,
 	result *execution.ComputationResult,
@@ -1148,7 +1201,8 @@ func (e *Engine) saveExecutionResults(
 		executionReceipt,
 		result.Events,
 		result.ServiceEvents,
-		result.TransactionResults)
+		result.TransactionResults,
+		result.RegisterSets)
 	if err != nil {
 		return nil, fmt.Errorf("cannot persist execution state: %w", err)
 	}
@@ -12,6 +12,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 
 	"github.com/onflow/flow-go/crypto"
 	engineCommon "github.com/onflow/flow-go/engine"
@@ -134,7 +135,7 @@ func runWithEngine(t *testing.T, f func(testingContext)) {
 	log := unittest.Logger()
 	metrics := metrics.NewNoopCollector()
 
-	tracer, err := trace.NewTracer(log, "test", "test", trace.SensitivityCaptureAll)
+	tracer, err := trace.NewTracer(log, "test", "test", trace.SensitivityCaptureAll, trace.WithExporter(tracetest.NewNoopExporter()))
 	require.NoError(t, err)
 
 	request.EXPECT().Force().Return().AnyTimes()
@@ -248,6 +249,7 @@ func (ctx *testingContext) assertSuccessfulBlockComputation(
 			mock.Anything,
 			mock.Anything,
 			mock.Anything,
+			mock.Anything,
 		).
 		Return(nil)
 
@@ -902,7 +904,7 @@ func TestExecutionGenerationResultsAreChained(t *testing.T) {
 		Return(previousExecutionResultID, nil)
 
 	execState.
-		On("SaveExecutionResults", mock.Anything, executableBlock.Block.Header, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		On("SaveExecutionResults", mock.Anything, executableBlock.Block.Header, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 		Return(nil)
 
 	e := Engine{
@@ -1121,7 +1123,7 @@ func TestUnauthorizedNodeDoesNotBroadcastReceipts(t *testing.T) {
 func newIngestionEngine(t *testing.T, ps *mocks.ProtocolState, es *mocks.ExecutionState) *Engine {
 	log := unittest.Logger()
 	metrics := metrics.NewNoopCollector()
-	tracer, err := trace.NewTracer(log, "test", "test", trace.SensitivityCaptureAll)
+	tracer, err := trace.NewTracer(log, "test", "test", trace.SensitivityCaptureAll, trace.WithExporter(tracetest.NewNoopExporter()))
 	require.NoError(t, err)
 	ctrl := gomock.NewController(t)
 	net := mocknetwork.NewMockNetwork(ctrl)
@@ -3,6 +3,7 @@ package ingestion
 import (
 	"context"
 
+	"github.com/onflow/flow-go/fvm/profiling"
 	"github.com/onflow/flow-go/model/flow"
 )
 
@@ -12,9 +13,15 @@ type IngestRPC interface {
 	// ExecuteScriptAtBlockID executes a script at the given Block id
 	ExecuteScriptAtBlockID(ctx context.Context, script []byte, arguments [][]byte, blockID flow.Identifier) ([]byte, error)
 
+	// ProfileScriptAtBlockID executes a script at the given Block id, and returns the profile of its execution
+	ProfileScriptAtBlockID(ctx context.Context, script []byte, arguments [][]byte, blockID flow.Identifier) ([]byte, *profiling.Profile, error)
+
 	// GetAccount returns the Account details at the given Block id
 	GetAccount(ctx context.Context, address flow.Address, blockID flow.Identifier) (*flow.Account, error)
 
 	// GetRegisterAtBlockID returns the value of a register at the given Block id (if available)
 	GetRegisterAtBlockID(ctx context.Context, owner, controller, key []byte, blockID flow.Identifier) ([]byte, error)
+
+	// SimulateTransactionAtBlockID executes a transaction at the given Block id without committing its effects
+	SimulateTransactionAtBlockID(ctx context.Context, tx *flow.TransactionBody, opts flow.TransactionSimulationOptions, blockID flow.Identifier) (*flow.TransactionSimulationResult, error)
 }
@@ -8,6 +8,8 @@ import (
 	flow "github.com/onflow/flow-go/model/flow"
 
 	mock "github.com/stretchr/testify/mock"
+
+	profiling "github.com/onflow/flow-go/fvm/profiling"
 )
 
 // IngestRPC is an autogenerated mock type for the IngestRPC type
@@ -83,3 +85,58 @@ func (_m *IngestRPC) GetRegisterAtBlockID(ctx context.Context, owner []byte, con
 
 	return r0, r1
 }
+
+// ProfileScriptAtBlockID provides a mock function with given fields: ctx, script, arguments, blockID
+func (_m *IngestRPC) ProfileScriptAtBlockID(ctx context.Context, script []byte, arguments [][]byte, blockID flow.Identifier) ([]byte, *profiling.Profile, error) {
+	ret := _m.Called(ctx, script, arguments, blockID)
+
+	var r0 []byte
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, [][]byte, flow.Identifier) []byte); ok {
+		r0 = rf(ctx, script, arguments, blockID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	var r1 *profiling.Profile
+	if rf, ok := ret.Get(1).(func(context.Context, []byte, [][]byte, flow.Identifier) *profiling.Profile); ok {
+		r1 = rf(ctx, script, arguments, blockID)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*profiling.Profile)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, []byte, [][]byte, flow.Identifier) error); ok {
+		r2 = rf(ctx, script, arguments, blockID)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// SimulateTransactionAtBlockID provides a mock function with given fields: ctx, tx, opts, blockID
+func (_m *IngestRPC) SimulateTransactionAtBlockID(ctx context.Context, tx *flow.TransactionBody, opts flow.TransactionSimulationOptions, blockID flow.Identifier) (*flow.TransactionSimulationResult, error) {
+	ret := _m.Called(ctx, tx, opts, blockID)
+
+	var r0 *flow.TransactionSimulationResult
+	if rf, ok := ret.Get(0).(func(context.Context, *flow.TransactionBody, flow.TransactionSimulationOptions, flow.Identifier) *flow.TransactionSimulationResult); ok {
+		r0 = rf(ctx, tx, opts, blockID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*flow.TransactionSimulationResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *flow.TransactionBody, flow.TransactionSimulationOptions, flow.Identifier) error); ok {
+		r1 = rf(ctx, tx, opts, blockID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -29,6 +29,7 @@ type ComputationResult struct {
 	StateReads         uint64
 	TrieUpdates        []*ledger.TrieUpdate
 	ExecutionDataID    flow.Identifier
+	RegisterSets       []flow.TransactionRegisterSet
 }
 
 func (cr *ComputationResult) AddEvents(chunkIndex int, inp []flow.Event) {
@@ -50,3 +51,7 @@ func (cr *ComputationResult) AddComputationUsed(inp uint64) {
 func (cr *ComputationResult) AddStateSnapshot(inp *delta.SpockSnapshot) {
 	cr.StateSnapshots = append(cr.StateSnapshots, inp)
 }
+
+func (cr *ComputationResult) AddRegisterSet(inp *flow.TransactionRegisterSet) {
+	cr.RegisterSets = append(cr.RegisterSets, *inp)
+}
@@ -0,0 +1,204 @@
+package pruner
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/consensus/hotstuff/model"
+	"github.com/onflow/flow-go/consensus/hotstuff/notifications"
+	"github.com/onflow/flow-go/engine"
+	"github.com/onflow/flow-go/engine/execution/state"
+	"github.com/onflow/flow-go/state/protocol"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/storage/badger/operation"
+)
+
+// ChunkDataPacks removes the chunk data packs of finalized blocks which were sealed more
+// than a threshold of heights ago, as they are only needed by verification nodes until the
+// block is sealed. Blocks are only pruned once executed, so the chunk data packs of a node
+// lagging behind the sealed height are pruned once it catches up. The height up to which
+// chunk data packs were pruned is stored in the chunk data packs database, so that pruning
+// continues there after a restart.
+type ChunkDataPacks struct {
+	notifications.NoopConsumer // satisfy the FinalizationConsumer interface
+
+	unit           *engine.Unit
+	log            zerolog.Logger
+	state          protocol.State
+	execState      state.ReadOnlyExecutionState
+	headers        storage.Headers
+	results        storage.ExecutionResults
+	chunkDataPacks storage.ChunkDataPacks
+	db             *badger.DB
+	threshold      uint64
+	notifier       engine.Notifier
+}
+
+// NewChunkDataPacks creates a pruner for the chunk data packs stored in the given database, which
+// keeps the chunk data packs of the given number of heights below the latest sealed block.
+func NewChunkDataPacks(
+	log zerolog.Logger,
+	state protocol.State,
+	execState state.ReadOnlyExecutionState,
+	headers storage.Headers,
+	results storage.ExecutionResults,
+	chunkDataPacks storage.ChunkDataPacks,
+	db *badger.DB,
+	threshold uint64,
+) *ChunkDataPacks {
+	return &ChunkDataPacks{
+		unit:           engine.NewUnit(),
+		log:            log.With().Str("component", "chunk_data_packs_pruner").Logger(),
+		state:          state,
+		execState:      execState,
+		headers:        headers,
+		results:        results,
+		chunkDataPacks: chunkDataPacks,
+		db:             db,
+		threshold:      threshold,
+		notifier:       engine.NewNotifier(),
+	}
+}
+
+func (p *ChunkDataPacks) Ready() <-chan struct{} {
+	p.unit.Launch(p.loop)
+	// prune what was sealed while the node was down
+	p.notifier.Notify()
+	return p.unit.Ready()
+}
+
+func (p *ChunkDataPacks) Done() <-chan struct{} {
+	return p.unit.Done()
+}
+
+// OnFinalizedBlock triggers pruning, as finalizing a block can seal new blocks.
+func (p *ChunkDataPacks) OnFinalizedBlock(*model.Block) {
+	p.notifier.Notify()
+}
+
+func (p *ChunkDataPacks) loop() {
+	for {
+		select {
+		case <-p.unit.Quit():
+			return
+		case <-p.notifier.Channel():
+			err := p.prune()
+			if err != nil {
+				p.log.Error().Err(err).Msg("could not prune chunk data packs")
+			}
+		}
+	}
+}
+
+// prune removes the chunk data packs of all finalized blocks up to the latest sealed height
+// minus the threshold, which were not pruned yet. It stops at the highest executed height, and
+// at the first block without execution result.
+func (p *ChunkDataPacks) prune() error {
+	sealed, err := p.state.Sealed().Head()
+	if err != nil {
+		return fmt.Errorf("could not get sealed block: %w", err)
+	}
+	if sealed.Height <= p.threshold {
+		return nil
+	}
+	target := sealed.Height - p.threshold
+
+	executedHeight, _, err := p.execState.GetHighestExecutedBlockID(p.unit.Ctx())
+	if err != nil {
+		return fmt.Errorf("could not get highest executed block: %w", err)
+	}
+	if executedHeight < target {
+		target = executedHeight
+	}
+
+	pruned, err := p.prunedHeight()
+	if err != nil {
+		return err
+	}
+
+	for height := pruned + 1; height <= target; height++ {
+		select {
+		case <-p.unit.Quit():
+			return nil
+		default:
+		}
+
+		executed, err := p.pruneHeight(height)
+		if err != nil {
+			return fmt.Errorf("could not prune chunk data packs at height %d: %w", height, err)
+		}
+		if !executed {
+			// the chunk data packs of the block are stored once it is executed, so it
+			// is pruned again then
+			p.log.Debug().Uint64("height", height).Msg("block not executed yet, pruning stopped")
+			target = height - 1
+			break
+		}
+
+		err = operation.RetryOnConflict(p.db.Update, operation.UpdateChunkDataPacksPrunedHeight(height))
+		if err != nil {
+			return fmt.Errorf("could not update pruned height: %w", err)
+		}
+	}
+
+	if target > pruned {
+		p.log.Debug().
+			Uint64("from_height", pruned+1).
+			Uint64("to_height", target).
+			Msg("chunk data packs pruned")
+	}
+
+	return nil
+}
+
+// prunedHeight returns the height up to which chunk data packs were pruned. It starts with
+// the height of the root block, as the chunk data packs below it were never stored.
+func (p *ChunkDataPacks) prunedHeight() (uint64, error) {
+	var pruned uint64
+	err := p.db.View(operation.RetrieveChunkDataPacksPrunedHeight(&pruned))
+	if err == nil {
+		return pruned, nil
+	}
+	if !errors.Is(err, storage.ErrNotFound) {
+		return 0, fmt.Errorf("could not retrieve pruned height: %w", err)
+	}
+
+	root, err := p.state.Params().Root()
+	if err != nil {
+		return 0, fmt.Errorf("could not get root block: %w", err)
+	}
+	err = operation.RetryOnConflict(p.db.Update, operation.InsertChunkDataPacksPrunedHeight(root.Height))
+	if err != nil {
+		return 0, fmt.Errorf("could not initialize pruned height: %w", err)
+	}
+	return root.Height, nil
+}
+
+// pruneHeight removes the chunk data packs of the finalized block at the given height. It returns
+// false if the block has no execution result, which usually means it is not executed yet.
+func (p *ChunkDataPacks) pruneHeight(height uint64) (bool, error) {
+	header, err := p.headers.ByHeight(height)
+	if err != nil {
+		return false, fmt.Errorf("could not get finalized block: %w", err)
+	}
+
+	result, err := p.results.ByBlockID(header.ID())
+	if errors.Is(err, storage.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("could not get execution result: %w", err)
+	}
+
+	for _, chunk := range result.Chunks {
+		err := p.chunkDataPacks.Remove(chunk.ID())
+		if err != nil && !errors.Is(err, storage.ErrNotFound) {
+			return false, fmt.Errorf("could not remove chunk data pack: %w", err)
+		}
+	}
+
+	return true, nil
+}
@@ -0,0 +1,129 @@
+package pruner
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	statemock "github.com/onflow/flow-go/engine/execution/state/mock"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/metrics"
+	protocol "github.com/onflow/flow-go/state/protocol/mock"
+	"github.com/onflow/flow-go/storage"
+	bstorage "github.com/onflow/flow-go/storage/badger"
+	badgermodel "github.com/onflow/flow-go/storage/badger/model"
+	"github.com/onflow/flow-go/storage/badger/operation"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestPruneChunkDataPacks(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		unittest.RunWithBadgerDB(t, func(chunkDataPacksDB *badger.DB) {
+			collector := &metrics.NoopCollector{}
+			headers := bstorage.NewHeaders(collector, db)
+			results := bstorage.NewExecutionResults(collector, db)
+			chunkDataPacks := bstorage.NewChunkDataPacks(collector, chunkDataPacksDB, bstorage.NewCollections(db, bstorage.NewTransactions(collector, db)), 10)
+
+			// root <- b1 <- ... <- b5, with chunk data packs for all blocks except the root and b3
+			root := unittest.BlockHeaderFixture()
+			blocks := []*flow.Header{&root}
+			chunkIDs := make(map[uint64][]flow.Identifier)
+			for i := 0; i <= 5; i++ {
+				header := blocks[len(blocks)-1]
+				if i > 0 {
+					child := unittest.BlockHeaderWithParentFixture(header)
+					header = &child
+					blocks = append(blocks, header)
+				}
+				require.NoError(t, headers.Store(header))
+				require.NoError(t, db.Update(operation.IndexBlockHeight(header.Height, header.ID())))
+
+				if i == 0 || i == 3 {
+					continue
+				}
+				result := unittest.ExecutionResultFixture(unittest.WithExecutionResultBlockID(header.ID()))
+				require.NoError(t, results.Store(result))
+				require.NoError(t, results.Index(header.ID(), result.ID()))
+				for _, chunk := range result.Chunks {
+					require.NoError(t, chunkDataPacks.Store(unittest.ChunkDataPackFixture(chunk.ID())))
+					chunkIDs[header.Height] = append(chunkIDs[header.Height], chunk.ID())
+				}
+			}
+
+			sealed := blocks[4]
+			snapshot := new(protocol.Snapshot)
+			snapshot.On("Head").Return(func() *flow.Header { return sealed }, nil)
+			params := new(protocol.Params)
+			params.On("Root").Return(&root, nil)
+			state := new(protocol.State)
+			state.On("Sealed").Return(snapshot)
+			state.On("Params").Return(params)
+
+			executed := blocks[1]
+			execState := new(statemock.ReadOnlyExecutionState)
+			execState.On("GetHighestExecutedBlockID", mock.Anything).Return(
+				func(context.Context) uint64 { return executed.Height },
+				func(context.Context) flow.Identifier { return executed.ID() },
+				nil,
+			)
+
+			pruner := NewChunkDataPacks(zerolog.Nop(), state, execState, headers, results, chunkDataPacks, chunkDataPacksDB, 2)
+
+			assertPruned := func(height uint64) {
+				for h, ids := range chunkIDs {
+					for _, chunkID := range ids {
+						var pack badgermodel.StoredChunkDataPack
+						err := chunkDataPacksDB.View(operation.RetrieveChunkDataPack(chunkID, &pack))
+						if h <= height {
+							assert.True(t, errors.Is(err, storage.ErrNotFound), "chunk data pack at height %d should be pruned", h)
+						} else {
+							assert.NoError(t, err, "chunk data pack at height %d should not be pruned", h)
+						}
+					}
+				}
+
+				var pruned uint64
+				require.NoError(t, chunkDataPacksDB.View(operation.RetrieveChunkDataPacksPrunedHeight(&pruned)))
+				assert.Equal(t, height, pruned)
+			}
+
+			// the blocks above the highest executed block are not pruned
+			require.NoError(t, pruner.prune())
+			assertPruned(blocks[1].Height)
+
+			// sealed at b4 with a threshold of 2 prunes up to b2
+			executed = blocks[5]
+			require.NoError(t, pruner.prune())
+			assertPruned(blocks[2].Height)
+
+			t.Run("pruning again is a no-op", func(t *testing.T) {
+				require.NoError(t, pruner.prune())
+				assertPruned(blocks[2].Height)
+			})
+
+			t.Run("pruning stops at blocks without execution results", func(t *testing.T) {
+				sealed = blocks[5]
+				require.NoError(t, pruner.prune())
+				assertPruned(blocks[2].Height)
+
+				// once executed, the block is pruned
+				result := unittest.ExecutionResultFixture(unittest.WithExecutionResultBlockID(blocks[3].ID()))
+				require.NoError(t, results.Store(result))
+				require.NoError(t, results.Index(blocks[3].ID(), result.ID()))
+				for _, chunk := range result.Chunks {
+					require.NoError(t, chunkDataPacks.Store(unittest.ChunkDataPackFixture(chunk.ID())))
+					chunkIDs[blocks[3].Height] = append(chunkIDs[blocks[3].Height], chunk.ID())
+				}
+
+				require.NoError(t, pruner.prune())
+				assertPruned(blocks[3].Height)
+			})
+		})
+	})
+}
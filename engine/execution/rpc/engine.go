@@ -10,17 +10,26 @@ import (
 	"unicode/utf8"
 
 	grpc_prometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
+	"github.com/opentracing/opentracing-go"
 	"github.com/rs/zerolog"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/status"
 
 	"github.com/onflow/flow/protobuf/go/flow/execution"
 
 	"github.com/onflow/flow-go/engine"
+	checkpointspb "github.com/onflow/flow-go/engine/common/rpc/checkpoints/checkpoints"
+	comparisonpb "github.com/onflow/flow-go/engine/common/rpc/comparison/comparison"
 	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	"github.com/onflow/flow-go/engine/common/rpc/registersets"
+	registersetspb "github.com/onflow/flow-go/engine/common/rpc/registersets/registersets"
+	"github.com/onflow/flow-go/engine/common/rpc/simulation"
+	simulationpb "github.com/onflow/flow-go/engine/common/rpc/simulation/simulation"
 	"github.com/onflow/flow-go/engine/execution/ingestion"
 	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/trace"
 	"github.com/onflow/flow-go/state/protocol"
 	"github.com/onflow/flow-go/storage"
 	"github.com/onflow/flow-go/utils/grpcutils"
@@ -28,18 +37,22 @@ import (
 
 // Config defines the configurable options for the gRPC server.
 type Config struct {
-	ListenAddr        string
-	MaxMsgSize        int  // In bytes
-	RpcMetricsEnabled bool // enable GRPC metrics reporting
+	ListenAddr           string
+	SecureListenAddr     string                           // the address of the secure gRPC server serving the execution state to execution nodes
+	TransportCredentials credentials.TransportCredentials // the credentials of the secure gRPC server
+	MaxMsgSize           int                              // In bytes
+	RpcMetricsEnabled    bool                             // enable GRPC metrics reporting
+	Tracer               opentracing.Tracer               // optional, traces the requests if set
 }
 
 // Engine implements a gRPC server with a simplified version of the Observation API.
 type Engine struct {
-	unit    *engine.Unit
-	log     zerolog.Logger
-	handler *handler     // the gRPC service implementation
-	server  *grpc.Server // the gRPC server
-	config  Config
+	unit         *engine.Unit
+	log          zerolog.Logger
+	handler      *handler     // the gRPC service implementation
+	server       *grpc.Server // the gRPC server
+	secureServer *grpc.Server // the secure gRPC server, only created if the execution state is served
+	config       Config
 }
 
 // New returns a new RPC engine.
@@ -53,6 +66,9 @@ func New(
 	events storage.Events,
 	exeResults storage.ExecutionResults,
 	txResults storage.TransactionResults,
+	registerSets storage.TransactionRegisterSets,
+	checkpointServer checkpointspb.CheckpointAPIServer,
+	comparisonServer comparisonpb.ComparisonAPIServer,
 	chainID flow.ChainID) *Engine {
 	log = log.With().Str("engine", "rpc").Logger()
 
@@ -65,10 +81,16 @@ func New(
 		grpc.MaxSendMsgSize(config.MaxMsgSize),
 	}
 
-	// if rpc metrics is enabled, add the grpc metrics interceptor as a server option
+	var interceptors []grpc.UnaryServerInterceptor // ordered list of interceptors
+	// if tracing is enabled, start the span of the request, continuing the trace of the access node
+	if config.Tracer != nil {
+		interceptors = append(interceptors, trace.UnaryServerInterceptor(config.Tracer))
+	}
+	// if rpc metrics is enabled, add the grpc metrics interceptor
 	if config.RpcMetricsEnabled {
-		serverOptions = append(serverOptions, grpc.UnaryInterceptor(grpc_prometheus.UnaryServerInterceptor))
+		interceptors = append(interceptors, grpc_prometheus.UnaryServerInterceptor)
 	}
+	serverOptions = append(serverOptions, grpc.ChainUnaryInterceptor(interceptors...))
 
 	server := grpc.NewServer(serverOptions...)
 
@@ -84,6 +106,7 @@ func New(
 			events:             events,
 			exeResults:         exeResults,
 			transactionResults: txResults,
+			registerSets:       registerSets,
 			log:                log,
 		},
 		server: server,
@@ -96,6 +119,23 @@ func New(
 	}
 
 	execution.RegisterExecutionAPIServer(eng.server, eng.handler)
+	simulationpb.RegisterSimulationAPIServer(eng.server, eng.handler)
+	registersetspb.RegisterRegisterSetsAPIServer(eng.server, eng.handler)
+
+	// the execution state is only served to other execution nodes if enabled, on a secure server
+	// authenticated by the networking key of the node
+	if checkpointServer != nil {
+		eng.secureServer = grpc.NewServer(append(serverOptions, grpc.Creds(config.TransportCredentials))...)
+		checkpointspb.RegisterCheckpointAPIServer(eng.secureServer, checkpointServer)
+		if config.RpcMetricsEnabled {
+			grpc_prometheus.Register(eng.secureServer)
+		}
+	}
+
+	// the executions of blocks are only served for comparison with other execution nodes if enabled
+	if comparisonServer != nil {
+		comparisonpb.RegisterComparisonAPIServer(eng.server, comparisonServer)
+	}
 
 	return eng
 }
@@ -105,13 +145,21 @@ func New(
 // started.
 func (e *Engine) Ready() <-chan struct{} {
 	e.unit.Launch(e.serve)
+	if e.secureServer != nil {
+		e.unit.Launch(e.serveSecure)
+	}
 	return e.unit.Ready()
 }
 
 // Done returns a done channel that is closed once the engine has fully stopped.
-// It sends a signal to stop the gRPC server, then closes the channel.
+// It sends a signal to stop the gRPC servers, then closes the channel.
 func (e *Engine) Done() <-chan struct{} {
-	return e.unit.Done(e.server.GracefulStop)
+	return e.unit.Done(func() {
+		e.server.GracefulStop()
+		if e.secureServer != nil {
+			e.secureServer.GracefulStop()
+		}
+	})
 }
 
 // serve starts the gRPC server .
@@ -132,8 +180,26 @@ func (e *Engine) serve() {
 	}
 }
 
+// serveSecure starts the secure gRPC server.
+func (e *Engine) serveSecure() {
+	e.log.Info().Msgf("starting secure server on address %s", e.config.SecureListenAddr)
+
+	l, err := net.Listen("tcp", e.config.SecureListenAddr)
+	if err != nil {
+		e.log.Err(err).Msg("failed to start secure server")
+		return
+	}
+
+	err = e.secureServer.Serve(l)
+	if err != nil {
+		e.log.Err(err).Msg("fatal error in secure server")
+	}
+}
+
 // handler implements a subset of the Observation API.
 type handler struct {
+	simulationpb.UnimplementedSimulationAPIServer
+	registersetspb.UnimplementedRegisterSetsAPIServer
 	engine             ingestion.IngestRPC
 	chain              flow.ChainID
 	blocks             storage.Blocks
@@ -142,10 +208,13 @@ type handler struct {
 	events             storage.Events
 	exeResults         storage.ExecutionResults
 	transactionResults storage.TransactionResults
+	registerSets       storage.TransactionRegisterSets
 	log                zerolog.Logger
 }
 
 var _ execution.ExecutionAPIServer = &handler{}
+var _ simulationpb.SimulationAPIServer = &handler{}
+var _ registersetspb.RegisterSetsAPIServer = &handler{}
 
 // Ping responds to requests when the server is up.
 func (h *handler) Ping(ctx context.Context, req *execution.PingRequest) (*execution.PingResponse, error) {
@@ -175,6 +244,109 @@ func (h *handler) ExecuteScriptAtBlockID(
 	return res, nil
 }
 
+// SimulateTransaction executes a transaction against the execution state at the
+// requested block, without committing its effects.
+func (h *handler) SimulateTransaction(
+	ctx context.Context,
+	req *simulationpb.SimulateTransactionRequest,
+) (*simulationpb.SimulateTransactionResponse, error) {
+
+	blockID, err := convert.BlockID(req.GetBlockId())
+	if err != nil {
+		return nil, err
+	}
+	if req.GetTransaction() == nil {
+		return nil, status.Error(codes.InvalidArgument, "transaction must be set")
+	}
+
+	tx := simulation.MessageToTransaction(req.GetTransaction())
+	result, err := h.engine.SimulateTransactionAtBlockID(ctx, &tx, simulation.MessageToOptions(req.GetOptions()), blockID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to simulate transaction: %v", err)
+	}
+
+	return &simulationpb.SimulateTransactionResponse{Result: simulation.ResultToMessage(*result)}, nil
+}
+
+// ExecuteScript executes a script against the execution state at the requested
+// block, and profiles its execution if requested.
+func (h *handler) ExecuteScript(
+	ctx context.Context,
+	req *simulationpb.ExecuteScriptRequest,
+) (*simulationpb.ExecuteScriptResponse, error) {
+
+	blockID, err := convert.BlockID(req.GetBlockId())
+	if err != nil {
+		return nil, err
+	}
+
+	if !req.GetProfile() {
+		value, err := h.engine.ExecuteScriptAtBlockID(ctx, req.GetScript(), req.GetArguments(), blockID)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "failed to execute script: %v", err)
+		}
+		return &simulationpb.ExecuteScriptResponse{Value: value}, nil
+	}
+
+	value, profile, err := h.engine.ProfileScriptAtBlockID(ctx, req.GetScript(), req.GetArguments(), blockID)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to execute script: %v", err)
+	}
+
+	return &simulationpb.ExecuteScriptResponse{
+		Value:   value,
+		Profile: simulation.ProfileToMessage(profile),
+	}, nil
+}
+
+// GetTransactionRegisterSets returns the registers read and written by the
+// transactions of an executed block. Register sets are only available for
+// blocks executed while storing them was enabled.
+func (h *handler) GetTransactionRegisterSets(
+	_ context.Context,
+	req *registersetspb.GetTransactionRegisterSetsRequest,
+) (*registersetspb.GetTransactionRegisterSetsResponse, error) {
+
+	blockID, err := convert.BlockID(req.GetBlockId())
+	if err != nil {
+		return nil, err
+	}
+
+	var registerSets []flow.TransactionRegisterSet
+	if len(req.GetTransactionId()) == 0 {
+		registerSets, err = h.registerSets.ByBlockID(blockID)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to get register sets: %v", err)
+		}
+		// every executed block has at least the system transaction
+		if len(registerSets) == 0 {
+			return nil, status.Error(codes.NotFound, "register sets not found")
+		}
+	} else {
+		txID, err := convert.TransactionID(req.GetTransactionId())
+		if err != nil {
+			return nil, err
+		}
+		registerSet, err := h.registerSets.ByBlockIDTransactionID(blockID, txID)
+		if err != nil {
+			if errors.Is(err, storage.ErrNotFound) {
+				return nil, status.Error(codes.NotFound, "register sets not found")
+			}
+			return nil, status.Errorf(codes.Internal, "failed to get register sets: %v", err)
+		}
+		registerSets = []flow.TransactionRegisterSet{*registerSet}
+	}
+
+	res := &registersetspb.GetTransactionRegisterSetsResponse{
+		RegisterSets: make([]*registersetspb.TransactionRegisterSet, 0, len(registerSets)),
+	}
+	for _, registerSet := range registerSets {
+		res.RegisterSets = append(res.RegisterSets, registersets.TransactionRegisterSetToMessage(registerSet))
+	}
+
+	return res, nil
+}
+
 func (h *handler) GetRegisterAtBlockID(
 	ctx context.Context,
 	req *execution.GetRegisterAtBlockIDRequest,
@@ -15,7 +15,12 @@ import (
 	"github.com/onflow/flow/protobuf/go/flow/execution"
 
 	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	"github.com/onflow/flow-go/engine/common/rpc/registersets"
+	registersetspb "github.com/onflow/flow-go/engine/common/rpc/registersets/registersets"
+	"github.com/onflow/flow-go/engine/common/rpc/simulation"
+	simulationpb "github.com/onflow/flow-go/engine/common/rpc/simulation/simulation"
 	ingestion "github.com/onflow/flow-go/engine/execution/ingestion/mock"
+	"github.com/onflow/flow-go/fvm/profiling"
 	"github.com/onflow/flow-go/model/flow"
 	realstorage "github.com/onflow/flow-go/storage"
 	storage "github.com/onflow/flow-go/storage/mock"
@@ -288,6 +293,215 @@ func (suite *Suite) TestGetAccountAtBlockID() {
 	})
 }
 
+// TestSimulateTransaction tests the SimulateTransaction API call
+func (suite *Suite) TestSimulateTransaction() {
+
+	id := unittest.IdentifierFixture()
+	tx := unittest.TransactionBodyFixture()
+	opts := flow.TransactionSimulationOptions{SkipSignatureCheck: true}
+
+	mockEngine := new(ingestion.IngestRPC)
+
+	// create the handler
+	handler := &handler{
+		engine: mockEngine,
+		chain:  flow.Mainnet,
+	}
+
+	suite.Run("happy path with valid request", func() {
+
+		expected := &flow.TransactionSimulationResult{
+			BlockID:         id,
+			ComputationUsed: 10,
+		}
+		// setup mock expectations
+		mockEngine.On("SimulateTransactionAtBlockID", mock.Anything, &tx, opts, id).Return(expected, nil).Once()
+
+		req := &simulationpb.SimulateTransactionRequest{
+			BlockId:     convert.IdentifierToMessage(id),
+			Transaction: simulation.TransactionToMessage(tx),
+			Options:     simulation.OptionsToMessage(opts),
+		}
+
+		resp, err := handler.SimulateTransaction(context.Background(), req)
+
+		suite.Require().NoError(err)
+		suite.Require().Equal(*expected, simulation.MessageToResult(resp.GetResult()))
+		mockEngine.AssertExpectations(suite.T())
+	})
+
+	suite.Run("invalid request without block id", func() {
+
+		req := &simulationpb.SimulateTransactionRequest{
+			Transaction: simulation.TransactionToMessage(tx),
+		}
+
+		_, err := handler.SimulateTransaction(context.Background(), req)
+
+		suite.Require().Error(err)
+		suite.Require().Equal(codes.InvalidArgument, status.Code(err))
+	})
+}
+
+// TestExecuteScript tests the ExecuteScript API call of the simulation API
+func (suite *Suite) TestExecuteScript() {
+
+	id := unittest.IdentifierFixture()
+	script := []byte("pub fun main() { return 1 }")
+	arguments := [][]byte{[]byte("arg1")}
+	value := []byte{1, 2, 3}
+
+	mockEngine := new(ingestion.IngestRPC)
+
+	// create the handler
+	handler := &handler{
+		engine: mockEngine,
+		chain:  flow.Mainnet,
+	}
+
+	suite.Run("happy path without profile", func() {
+
+		mockEngine.On("ExecuteScriptAtBlockID", mock.Anything, script, arguments, id).Return(value, nil).Once()
+
+		req := &simulationpb.ExecuteScriptRequest{
+			BlockId:   convert.IdentifierToMessage(id),
+			Script:    script,
+			Arguments: arguments,
+		}
+
+		resp, err := handler.ExecuteScript(context.Background(), req)
+
+		suite.Require().NoError(err)
+		suite.Require().Equal(value, resp.GetValue())
+		suite.Require().Nil(resp.GetProfile())
+		mockEngine.AssertExpectations(suite.T())
+	})
+
+	suite.Run("happy path with profile", func() {
+
+		profile := &profiling.Profile{
+			Invocations: []profiling.Entry{{Name: "script", Calls: 1, Computation: 10}},
+		}
+		mockEngine.On("ProfileScriptAtBlockID", mock.Anything, script, arguments, id).Return(value, profile, nil).Once()
+
+		req := &simulationpb.ExecuteScriptRequest{
+			BlockId:   convert.IdentifierToMessage(id),
+			Script:    script,
+			Arguments: arguments,
+			Profile:   true,
+		}
+
+		resp, err := handler.ExecuteScript(context.Background(), req)
+
+		suite.Require().NoError(err)
+		suite.Require().Equal(value, resp.GetValue())
+		suite.Require().Equal(profile, simulation.MessageToProfile(resp.GetProfile()))
+		mockEngine.AssertExpectations(suite.T())
+	})
+
+	suite.Run("invalid request without block id", func() {
+
+		_, err := handler.ExecuteScript(context.Background(), &simulationpb.ExecuteScriptRequest{Script: script})
+
+		suite.Require().Error(err)
+	})
+}
+
+// TestGetTransactionRegisterSets tests the GetTransactionRegisterSets API call
+func (suite *Suite) TestGetTransactionRegisterSets() {
+
+	blockID := unittest.IdentifierFixture()
+	owner := string(unittest.AddressFixture().Bytes())
+	registerSets := []flow.TransactionRegisterSet{
+		{
+			TransactionID:    unittest.IdentifierFixture(),
+			TransactionIndex: 0,
+			ReadSet:          []flow.RegisterID{flow.NewRegisterID(owner, "", "storage_used")},
+			WriteSet:         []flow.RegisterID{flow.NewRegisterID(owner, "", "storage_used")},
+		},
+		{
+			TransactionID:    unittest.IdentifierFixture(),
+			TransactionIndex: 1,
+			ReadSet:          []flow.RegisterID{flow.NewRegisterID(owner, owner, "public_key_0")},
+			WriteSet:         []flow.RegisterID{},
+		},
+	}
+
+	registerSetsStorage := new(storage.TransactionRegisterSets)
+
+	// create the handler
+	handler := &handler{
+		chain:        flow.Mainnet,
+		registerSets: registerSetsStorage,
+	}
+
+	suite.Run("happy path for all transactions of a block", func() {
+		registerSetsStorage.On("ByBlockID", blockID).Return(registerSets, nil).Once()
+
+		req := &registersetspb.GetTransactionRegisterSetsRequest{
+			BlockId: convert.IdentifierToMessage(blockID),
+		}
+		resp, err := handler.GetTransactionRegisterSets(context.Background(), req)
+
+		suite.Require().NoError(err)
+		suite.Require().Len(resp.GetRegisterSets(), len(registerSets))
+		for i, registerSet := range registerSets {
+			suite.Assert().Equal(registerSet, registersets.MessageToTransactionRegisterSet(resp.GetRegisterSets()[i]))
+		}
+		registerSetsStorage.AssertExpectations(suite.T())
+	})
+
+	suite.Run("happy path for a single transaction", func() {
+		registerSet := registerSets[1]
+		registerSetsStorage.On("ByBlockIDTransactionID", blockID, registerSet.TransactionID).Return(&registerSet, nil).Once()
+
+		req := &registersetspb.GetTransactionRegisterSetsRequest{
+			BlockId:       convert.IdentifierToMessage(blockID),
+			TransactionId: convert.IdentifierToMessage(registerSet.TransactionID),
+		}
+		resp, err := handler.GetTransactionRegisterSets(context.Background(), req)
+
+		suite.Require().NoError(err)
+		suite.Require().Len(resp.GetRegisterSets(), 1)
+		suite.Assert().Equal(registerSet, registersets.MessageToTransactionRegisterSet(resp.GetRegisterSets()[0]))
+		registerSetsStorage.AssertExpectations(suite.T())
+	})
+
+	suite.Run("block without register sets", func() {
+		unknownBlockID := unittest.IdentifierFixture()
+		registerSetsStorage.On("ByBlockID", unknownBlockID).Return(nil, nil).Once()
+
+		req := &registersetspb.GetTransactionRegisterSetsRequest{
+			BlockId: convert.IdentifierToMessage(unknownBlockID),
+		}
+		_, err := handler.GetTransactionRegisterSets(context.Background(), req)
+
+		suite.Require().Error(err)
+		suite.Require().Equal(codes.NotFound, status.Code(err))
+	})
+
+	suite.Run("unknown transaction", func() {
+		txID := unittest.IdentifierFixture()
+		registerSetsStorage.On("ByBlockIDTransactionID", blockID, txID).Return(nil, realstorage.ErrNotFound).Once()
+
+		req := &registersetspb.GetTransactionRegisterSetsRequest{
+			BlockId:       convert.IdentifierToMessage(blockID),
+			TransactionId: convert.IdentifierToMessage(txID),
+		}
+		_, err := handler.GetTransactionRegisterSets(context.Background(), req)
+
+		suite.Require().Error(err)
+		suite.Require().Equal(codes.NotFound, status.Code(err))
+	})
+
+	suite.Run("invalid request without block id", func() {
+		_, err := handler.GetTransactionRegisterSets(context.Background(), &registersetspb.GetTransactionRegisterSetsRequest{})
+
+		suite.Require().Error(err)
+		suite.Require().Equal(codes.InvalidArgument, status.Code(err))
+	})
+}
+
 // Test GetRegisterAtBlockID tests the GetRegisterAtBlockID API call
 func (suite *Suite) TestGetRegisterAtBlockID() {
 
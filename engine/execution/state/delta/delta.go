@@ -82,7 +82,7 @@ func (d Delta) RegisterIDs() []flow.RegisterID {
 }
 
 func (d Delta) MarshalJSON() ([]byte, error) {
-	m := make(flow.RegisterEntries, len(d.Data))
+	m := make(flow.RegisterEntries, 0, len(d.Data))
 	for _, value := range d.Data {
 		m = append(m, value)
 	}
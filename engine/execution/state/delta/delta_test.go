@@ -1,10 +1,12 @@
 package delta_test
 
 import (
+	"encoding/json"
 	"sort"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/onflow/flow-go/engine/execution/state/delta"
 	"github.com/onflow/flow-go/model/flow"
@@ -147,3 +149,17 @@ func TestDelta_RegisterUpdatesAreSorted(t *testing.T) {
 	assert.Equal(t, data.IDs(), retKeys)
 	assert.Equal(t, data.Values(), retValues)
 }
+
+func TestDelta_JSON(t *testing.T) {
+	d := delta.NewDelta()
+	d.Set("fruit", "", "apple", flow.RegisterValue("red"))
+	d.Set("fruit", "", "banana", flow.RegisterValue("yellow"))
+
+	data, err := json.Marshal(d)
+	require.NoError(t, err)
+
+	var decoded delta.Delta
+	require.NoError(t, json.Unmarshal(data, &decoded))
+
+	assert.Equal(t, d, decoded)
+}
@@ -2,6 +2,7 @@ package delta
 
 import (
 	"fmt"
+	"sort"
 	"sync"
 
 	"github.com/onflow/flow-go/crypto/hash"
@@ -19,6 +20,7 @@ type GetRegisterFunc func(owner, controller, key string) (flow.RegisterValue, er
 type View struct {
 	delta       Delta
 	regTouchSet map[string]flow.RegisterID // contains all the registers that have been touched (either read or written to)
+	regReadSet  map[string]flow.RegisterID // contains all the registers that have been read from the underlying data source
 	readsCount  uint64                     // contains the total number of reads
 	// spockSecret keeps the secret used for SPoCKs
 	// TODO we can add a flag to disable capturing spockSecret
@@ -49,6 +51,7 @@ func NewView(readFunc GetRegisterFunc) *View {
 	return &View{
 		delta:             NewDelta(),
 		regTouchSet:       make(map[string]flow.RegisterID),
+		regReadSet:        make(map[string]flow.RegisterID),
 		readFunc:          readFunc,
 		spockSecretHasher: hash.NewSHA3_256(),
 	}
@@ -127,8 +130,9 @@ func (v *View) Get(owner, controller, key string) (flow.RegisterValue, error) {
 		if err != nil {
 			return nil, fmt.Errorf("get register failed: %w", err)
 		}
-		// capture register touch
+		// capture register touch and read
 		v.regTouchSet[registerID.String()] = registerID
+		v.regReadSet[registerID.String()] = registerID
 		// increase reads
 		v.readsCount++
 	}
@@ -179,8 +183,9 @@ func (v *View) Touch(owner, controller, key string) error {
 
 	k := flow.NewRegisterID(owner, controller, key)
 
-	// capture register touch
+	// capture register touch and read
 	v.regTouchSet[k.String()] = k
+	v.regReadSet[k.String()] = k
 	// increase reads
 	v.readsCount++
 
@@ -212,6 +217,14 @@ func (v *View) MergeView(ch state.View) error {
 		v.regTouchSet[id.String()] = id
 	}
 
+	// registers the child read from this view's delta were not read from the
+	// underlying data source of this view
+	for s, id := range child.regReadSet {
+		if _, ok := v.delta.Data[s]; !ok {
+			v.regReadSet[s] = id
+		}
+	}
+
 	// SpockSecret is order aware
 	// TODO return the error and handle it properly on other places
 
@@ -237,6 +250,20 @@ func (r *Snapshot) RegisterTouches() map[string]flow.RegisterID {
 	return ret
 }
 
+// ReadSet returns the registers read from the underlying data source by this
+// view, or by any merged child view, before being written.
+// ids are returned sorted, in ascending order
+func (v *View) ReadSet() []flow.RegisterID {
+	entries := make(flow.RegisterEntries, 0, len(v.regReadSet))
+	for _, id := range v.regReadSet {
+		entries = append(entries, flow.RegisterEntry{Key: id})
+	}
+
+	sort.Sort(entries)
+
+	return entries.IDs()
+}
+
 // ReadsCount returns the total number of reads performed on this view including all child views
 func (v *View) ReadsCount() uint64 {
 	return v.readsCount
@@ -223,7 +223,7 @@ func TestViewMergeView(t *testing.T) {
 
 	registerID2 := "vegetable"
 
-	registerID3 := "diary"
+	registerID3 := "dairy"
 
 	t.Run("EmptyView", func(t *testing.T) {
 		v := delta.NewView(func(owner, controller, key string) (flow.RegisterValue, error) {
@@ -577,6 +577,70 @@ func TestView_Reads(t *testing.T) {
 	})
 }
 
+func TestView_ReadSet(t *testing.T) {
+	registerID1 := "fruit"
+	registerID2 := "vegetable"
+	registerID3 := "dairy"
+
+	t.Run("Empty", func(t *testing.T) {
+		v := delta.NewView(func(owner, controller, key string) (flow.RegisterValue, error) {
+			return nil, nil
+		})
+		assert.Empty(t, v.ReadSet())
+	})
+
+	t.Run("Set and Get", func(t *testing.T) {
+		v := delta.NewView(func(owner, controller, key string) (flow.RegisterValue, error) {
+			return nil, nil
+		})
+
+		// read before written
+		_, err := v.Get(registerID1, "", "")
+		assert.NoError(t, err)
+		err = v.Set(registerID1, "", "", flow.RegisterValue("apple"))
+		assert.NoError(t, err)
+
+		// written before read
+		err = v.Set(registerID2, "", "", flow.RegisterValue("carrot"))
+		assert.NoError(t, err)
+		_, err = v.Get(registerID2, "", "")
+		assert.NoError(t, err)
+
+		err = v.Touch(registerID3, "", "")
+		assert.NoError(t, err)
+
+		r1 := flow.NewRegisterID(registerID1, "", "")
+		r3 := flow.NewRegisterID(registerID3, "", "")
+		assert.ElementsMatch(t, []flow.RegisterID{r1, r3}, v.ReadSet())
+	})
+
+	t.Run("With Merge", func(t *testing.T) {
+		v := delta.NewView(func(owner, controller, key string) (flow.RegisterValue, error) {
+			return nil, nil
+		})
+
+		err := v.Set(registerID1, "", "", flow.RegisterValue("apple"))
+		assert.NoError(t, err)
+
+		chView := v.NewChild()
+		_, err = chView.Get(registerID1, "", "")
+		assert.NoError(t, err)
+		_, err = chView.Get(registerID2, "", "")
+		assert.NoError(t, err)
+
+		// the child read both registers from its parent
+		r1 := flow.NewRegisterID(registerID1, "", "")
+		r2 := flow.NewRegisterID(registerID2, "", "")
+		assert.ElementsMatch(t, []flow.RegisterID{r1, r2}, chView.(*delta.View).ReadSet())
+
+		err = v.MergeView(chView)
+		assert.NoError(t, err)
+
+		// the parent only read the register it didn't write itself
+		assert.ElementsMatch(t, []flow.RegisterID{r2}, v.ReadSet())
+	})
+}
+
 func hashIt(t *testing.T, spock hash.Hasher, value []byte) {
 	_, err := spock.Write(value)
 	assert.NoError(t, err, "spock write is not supposed to error")
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 
 	"github.com/davecgh/go-spew/spew"
 	"github.com/dgraph-io/badger/v2"
@@ -66,7 +67,8 @@ type ExecutionState interface {
 
 	SaveExecutionResults(ctx context.Context, header *flow.Header, endState flow.StateCommitment,
 		chunkDataPacks []*flow.ChunkDataPack,
-		executionReceipt *flow.ExecutionReceipt, events []flow.EventsList, serviceEvents flow.EventsList, results []flow.TransactionResult) error
+		executionReceipt *flow.ExecutionReceipt, events []flow.EventsList, serviceEvents flow.EventsList, results []flow.TransactionResult,
+		registerSets []flow.TransactionRegisterSet) error
 }
 
 const (
@@ -89,7 +91,9 @@ type state struct {
 	events             storage.Events
 	serviceEvents      storage.ServiceEvents
 	transactionResults storage.TransactionResults
+	registerSets       storage.TransactionRegisterSets
 	db                 *badger.DB
+	chunkDataPacksDB   *badger.DB
 }
 
 func RegisterIDToKey(reg flow.RegisterID) ledger.Key {
@@ -101,6 +105,7 @@ func RegisterIDToKey(reg flow.RegisterID) ledger.Key {
 }
 
 // NewExecutionState returns a new execution state access layer for the given ledger storage.
+// Chunk data packs are stored in their own database, which can be the same as the protocol database.
 func NewExecutionState(
 	ls ledger.Ledger,
 	commits storage.Commits,
@@ -114,7 +119,9 @@ func NewExecutionState(
 	events storage.Events,
 	serviceEvents storage.ServiceEvents,
 	transactionResults storage.TransactionResults,
+	registerSets storage.TransactionRegisterSets,
 	db *badger.DB,
+	chunkDataPacksDB *badger.DB,
 	tracer module.Tracer,
 ) ExecutionState {
 	return &state{
@@ -131,7 +138,9 @@ func NewExecutionState(
 		events:             events,
 		serviceEvents:      serviceEvents,
 		transactionResults: transactionResults,
+		registerSets:       registerSets,
 		db:                 db,
+		chunkDataPacksDB:   chunkDataPacksDB,
 	}
 
 }
@@ -171,7 +180,10 @@ func RegisterValuesToValues(values []flow.RegisterValue) []ledger.Value {
 
 func LedgerGetRegister(ldg ledger.Ledger, commitment flow.StateCommitment) delta.GetRegisterFunc {
 
+	// the cache is guarded, as the returned function might be called concurrently
+	// when transactions are executed in parallel
 	readCache := make(map[flow.RegisterID]flow.RegisterEntry)
+	var readCacheLock sync.RWMutex
 
 	return func(owner, controller, key string) (flow.RegisterValue, error) {
 		regID := flow.RegisterID{
@@ -180,7 +192,10 @@ func LedgerGetRegister(ldg ledger.Ledger, commitment flow.StateCommitment) delta
 			Key:        key,
 		}
 
-		if value, ok := readCache[regID]; ok {
+		readCacheLock.RLock()
+		value, ok := readCache[regID]
+		readCacheLock.RUnlock()
+		if ok {
 			return value.Value, nil
 		}
 
@@ -207,7 +222,9 @@ func LedgerGetRegister(ldg ledger.Ledger, commitment flow.StateCommitment) delta
 		}
 
 		// don't cache value with len zero
+		readCacheLock.Lock()
 		readCache[regID] = flow.RegisterEntry{Key: regID, Value: values[0]}
+		readCacheLock.Unlock()
 
 		return values[0], nil
 	}
@@ -335,7 +352,7 @@ func (s *state) GetExecutionResultID(ctx context.Context, blockID flow.Identifie
 
 func (s *state) SaveExecutionResults(ctx context.Context, header *flow.Header, endState flow.StateCommitment,
 	chunkDataPacks []*flow.ChunkDataPack, executionReceipt *flow.ExecutionReceipt, events []flow.EventsList, serviceEvents flow.EventsList,
-	results []flow.TransactionResult) error {
+	results []flow.TransactionResult, registerSets []flow.TransactionRegisterSet) error {
 
 	spew.Config.DisableMethods = true
 	spew.Config.DisablePointerMethods = true
@@ -352,8 +369,12 @@ func (s *state) SaveExecutionResults(ctx context.Context, header *flow.Header, e
 	// but it's the closes thing to atomicity we could have
 	batch := badgerstorage.NewBatch(s.db)
 
+	// chunk data packs are stored in their own database, so they are flushed before the
+	// execution results are, which mark the block as executed
+	chunkDataPacksBatch := badgerstorage.NewBatch(s.chunkDataPacksDB)
+
 	for _, chunkDataPack := range chunkDataPacks {
-		err := s.chunkDataPacks.BatchStore(chunkDataPack, batch)
+		err := s.chunkDataPacks.BatchStore(chunkDataPack, chunkDataPacksBatch)
 		if err != nil {
 			return fmt.Errorf("cannot store chunk data pack: %w", err)
 		}
@@ -364,7 +385,12 @@ func (s *state) SaveExecutionResults(ctx context.Context, header *flow.Header, e
 		}
 	}
 
-	err := s.commits.BatchStore(blockID, endState, batch)
+	err := chunkDataPacksBatch.Flush()
+	if err != nil {
+		return fmt.Errorf("chunk data packs batch flush error: %w", err)
+	}
+
+	err = s.commits.BatchStore(blockID, endState, batch)
 	if err != nil {
 		return fmt.Errorf("cannot store state commitment: %w", err)
 	}
@@ -384,6 +410,11 @@ func (s *state) SaveExecutionResults(ctx context.Context, header *flow.Header, e
 		return fmt.Errorf("cannot store transaction result: %w", err)
 	}
 
+	err = s.registerSets.BatchStore(blockID, registerSets, batch)
+	if err != nil {
+		return fmt.Errorf("cannot store transaction register sets: %w", err)
+	}
+
 	executionResult := &executionReceipt.ExecutionResult
 	err = s.results.BatchStore(executionResult, batch)
 	if err != nil {
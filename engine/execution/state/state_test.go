@@ -51,9 +51,10 @@ func prepareTest(f func(t *testing.T, es state.ExecutionState, l *ledger.Ledger)
 			results := new(storage.ExecutionResults)
 			receipts := new(storage.ExecutionReceipts)
 			myReceipts := new(storage.MyExecutionReceipts)
+			registerSets := new(storage.TransactionRegisterSets)
 
 			es := state.NewExecutionState(
-				ls, stateCommitments, blocks, headers, collections, chunkDataPacks, results, receipts, myReceipts, events, serviceEvents, txResults, badgerDB, trace.NewNoopTracer(),
+				ls, stateCommitments, blocks, headers, collections, chunkDataPacks, results, receipts, myReceipts, events, serviceEvents, txResults, registerSets, badgerDB, badgerDB, trace.NewNoopTracer(),
 			)
 
 			f(t, es, ls)
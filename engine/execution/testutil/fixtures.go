@@ -232,17 +232,20 @@ func CreateAccountsWithSimpleAddresses(
 	return accounts, nil
 }
 
-func RootBootstrappedLedger(vm *fvm.VirtualMachine, ctx fvm.Context) state.View {
+func RootBootstrappedLedger(vm *fvm.VirtualMachine, ctx fvm.Context, additionalOptions ...fvm.BootstrapProcedureOption) state.View {
 	view := fvmUtils.NewSimpleView()
 	programs := programs.NewEmptyPrograms()
 
 	// set 0 clusters to pass n_collectors >= n_clusters check
 	epochConfig := epochs.DefaultEpochConfig()
 	epochConfig.NumCollectorClusters = 0
-	bootstrap := fvm.Bootstrap(
-		unittest.ServiceAccountPublicKey,
+	options := append([]fvm.BootstrapProcedureOption{
 		fvm.WithInitialTokenSupply(unittest.GenesisTokenSupply),
 		fvm.WithEpochConfig(epochConfig),
+	}, additionalOptions...)
+	bootstrap := fvm.Bootstrap(
+		unittest.ServiceAccountPublicKey,
+		options...,
 	)
 
 	_ = vm.Run(
@@ -10,6 +10,7 @@ import (
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 
 	"github.com/onflow/flow-go/consensus"
 	"github.com/onflow/flow-go/consensus/hotstuff"
@@ -76,6 +77,8 @@ import (
 	"github.com/onflow/flow-go/state/protocol/events/gadgets"
 	"github.com/onflow/flow-go/state/protocol/util"
 	storage "github.com/onflow/flow-go/storage/badger"
+	"github.com/onflow/flow-go/storage/operation/badgerimpl"
+	"github.com/onflow/flow-go/storage/store"
 	"github.com/onflow/flow-go/utils/unittest"
 )
 
@@ -97,7 +100,7 @@ func GenericNodeFromParticipants(t testing.TB, hub *stub.Hub, identity *flow.Ide
 
 	// creates logger, metrics collector and tracer.
 	log := unittest.Logger().With().Int("index", i).Hex("node_id", identity.NodeID[:]).Str("role", identity.Role.String()).Logger()
-	tracer, err := trace.NewTracer(log, "test", "test", trace.SensitivityCaptureAll)
+	tracer, err := trace.NewTracer(log, "test", "test", trace.SensitivityCaptureAll, trace.WithExporter(tracetest.NewNoopExporter()))
 	require.NoError(t, err)
 	metrics := metrics.NewNoopCollector()
 
@@ -465,10 +468,12 @@ func ExecutionNode(t *testing.T, hub *stub.Hub, identity *flow.Identity, identit
 
 	transactionsStorage := storage.NewTransactions(node.Metrics, node.PublicDB)
 	collectionsStorage := storage.NewCollections(node.PublicDB, transactionsStorage)
-	eventsStorage := storage.NewEvents(node.Metrics, node.PublicDB)
-	serviceEventsStorage := storage.NewServiceEvents(node.Metrics, node.PublicDB)
-	txResultStorage := storage.NewTransactionResults(node.Metrics, node.PublicDB, storage.DefaultCacheSize)
-	commitsStorage := storage.NewCommits(node.Metrics, node.PublicDB)
+	kvdb := badgerimpl.ToDB(node.PublicDB)
+	eventsStorage := store.NewEvents(node.Metrics, kvdb)
+	serviceEventsStorage := store.NewServiceEvents(node.Metrics, kvdb)
+	txResultStorage := store.NewTransactionResults(node.Metrics, kvdb, store.DefaultCacheSize)
+	registerSetsStorage := storage.NewTransactionRegisterSets(node.Metrics, node.PublicDB, storage.DefaultCacheSize)
+	commitsStorage := store.NewCommits(node.Metrics, kvdb)
 	chunkDataPackStorage := storage.NewChunkDataPacks(node.Metrics, node.PublicDB, collectionsStorage, 100)
 	results := storage.NewExecutionResults(node.Metrics, node.PublicDB)
 	receipts := storage.NewExecutionReceipts(node.Metrics, node.PublicDB, results, storage.DefaultCacheSize)
@@ -511,7 +516,7 @@ func ExecutionNode(t *testing.T, hub *stub.Hub, identity *flow.Identity, identit
 	require.NoError(t, err)
 
 	execState := executionState.NewExecutionState(
-		ls, commitsStorage, node.Blocks, node.Headers, collectionsStorage, chunkDataPackStorage, results, receipts, myReceipts, eventsStorage, serviceEventsStorage, txResultStorage, node.PublicDB, node.Tracer,
+		ls, commitsStorage, node.Blocks, node.Headers, collectionsStorage, chunkDataPackStorage, results, receipts, myReceipts, eventsStorage, serviceEventsStorage, txResultStorage, registerSetsStorage, node.PublicDB, node.PublicDB, node.Tracer,
 	)
 
 	requestEngine, err := requester.New(
@@ -556,6 +561,7 @@ func ExecutionNode(t *testing.T, hub *stub.Hub, identity *flow.Identity, identit
 		vm,
 		vmCtx,
 		computation.DefaultProgramsCacheSize,
+		nil,
 		committer,
 		computation.DefaultScriptLogThreshold,
 		nil,
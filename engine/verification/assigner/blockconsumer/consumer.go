@@ -88,6 +88,11 @@ func (c *BlockConsumer) Size() uint {
 	return c.consumer.Size()
 }
 
+// SetMaxProcessing updates the number of block jobs that block consumer processes in parallel.
+func (c *BlockConsumer) SetMaxProcessing(workers uint64) {
+	c.consumer.SetMaxProcessing(workers)
+}
+
 // OnFinalizedBlock implements FinalizationConsumer, and is invoked by the follower engine whenever
 // a new block is finalized.
 // In this implementation for block consumer, invoking OnFinalizedBlock is enough to only notify the consumer
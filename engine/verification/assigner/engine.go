@@ -33,6 +33,7 @@ type Engine struct {
 	chunksQueue           storage.ChunksQueue       // to store chunks to be verified.
 	newChunkListener      module.NewJobListener     // to notify chunk queue consumer about a new chunk.
 	blockConsumerNotifier module.ProcessingNotifier // to report a block has been processed.
+	catchUpTracker        CatchUpTracker            // to skip chunks of sealed blocks while catching up (optional).
 }
 
 func New(
@@ -62,6 +63,12 @@ func (e *Engine) WithBlockConsumerNotifier(notifier module.ProcessingNotifier) {
 	e.blockConsumerNotifier = notifier
 }
 
+// WithCatchUpTracker sets the catch-up tracker of this assigner engine. While the tracker reports
+// the node is catching up, chunks of results for already sealed blocks are skipped.
+func (e *Engine) WithCatchUpTracker(tracker CatchUpTracker) {
+	e.catchUpTracker = tracker
+}
+
 func (e *Engine) Ready() <-chan struct{} {
 	return e.unit.Ready()
 }
@@ -200,6 +207,19 @@ func (e *Engine) processFinalizedBlock(ctx context.Context, block *flow.Block) {
 		}
 
 		assignedChunksCount += uint64(len(chunkList))
+
+		skip, err := e.skipSealedResult(result)
+		if err != nil {
+			resultLog.Fatal().Err(err).Msg("could not determine whether result belongs to a sealed block")
+		}
+		if skip {
+			e.metrics.OnSealedChunksSkippedAtAssigner(len(chunkList))
+			resultLog.Debug().
+				Int("skipped_chunks", len(chunkList)).
+				Msg("skips assigned chunks of sealed block while catching up")
+			continue
+		}
+
 		for _, chunk := range chunkList {
 			processed, err := e.processChunkWithTracing(ctx, chunk, resultID, block.Header.Height)
 			if err != nil {
@@ -223,6 +243,26 @@ func (e *Engine) processFinalizedBlock(ctx context.Context, block *flow.Block) {
 		Msg("finished processing finalized block")
 }
 
+// skipSealedResult returns true if the verification node is catching up, and the block executed by
+// the given result is already sealed. Chunks of such results need no approval, hence are not worth verifying.
+func (e *Engine) skipSealedResult(result *flow.ExecutionResult) (bool, error) {
+	if e.catchUpTracker == nil || !e.catchUpTracker.CatchingUp() {
+		return false, nil
+	}
+
+	executed, err := e.state.AtBlockID(result.BlockID).Head()
+	if err != nil {
+		return false, fmt.Errorf("could not retrieve header of executed block: %w", err)
+	}
+
+	lastSealed, err := e.state.Sealed().Head()
+	if err != nil {
+		return false, fmt.Errorf("could not retrieve last sealed block: %w", err)
+	}
+
+	return executed.Height <= lastSealed.Height, nil
+}
+
 // chunkAssignments returns the list of chunks in the chunk list assigned to this verification node.
 func (e *Engine) chunkAssignments(ctx context.Context, result *flow.ExecutionResult, incorporatingBlock flow.Identifier) (flow.ChunkList, error) {
 	var span opentracing.Span
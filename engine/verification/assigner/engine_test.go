@@ -146,6 +146,16 @@ func TestAssignerEngine(t *testing.T) {
 	t.Run("chunk queue unhappy path duplicate", func(t *testing.T) {
 		chunkQueueUnhappyPathDuplicate(t)
 	})
+	t.Run("new block sealed result while catching up", func(t *testing.T) {
+		newBlockSealedResultCatchingUp(t)
+	})
+}
+
+// catchUpTrackerFixture is a catch-up tracker with a fixed mode.
+type catchUpTrackerFixture bool
+
+func (c catchUpTrackerFixture) CatchingUp() bool {
+	return bool(c)
 }
 
 // newBlockHappyPath evaluates that passing a new finalized block to assigner engine that contains
@@ -388,6 +398,50 @@ func chunkQueueUnhappyPathDuplicate(t *testing.T) {
 	s.newChunkListener.AssertNotCalled(t, "Check")
 }
 
+// newBlockSealedResultCatchingUp evaluates that while the verification node is catching up, passing a new finalized
+// block to assigner engine that contains a receipt for an already sealed block, results in the assigned chunks being
+// skipped, i.e., nothing is passed to the chunks queue, and the job listener is not notified.
+func newBlockSealedResultCatchingUp(t *testing.T) {
+	s := SetupTest()
+	e := NewAssignerEngine(s)
+	e.WithCatchUpTracker(catchUpTrackerFixture(true))
+
+	// creates a container block, with a single receipt, that contains
+	// two chunks assigned to verification node.
+	containerBlock, assignment := createContainerBlock(
+		vertestutils.WithChunks(
+			vertestutils.WithAssignee(s.myID()),
+			vertestutils.WithAssignee(s.myID())))
+	result := containerBlock.Payload.Results[0]
+	s.mockStateAtBlockID(result.BlockID)
+	chunksNum := s.mockChunkAssigner(flow.NewIncorporatedResult(containerBlock.ID(), result), assignment)
+	require.Equal(t, chunksNum, 2)
+
+	// executed block of result is at the same height as the last sealed block.
+	executed := unittest.BlockHeaderFixture()
+	s.snapshot.On("Head").Return(&executed, nil)
+	s.state.On("Sealed").Return(s.snapshot)
+	s.metrics.On("OnSealedChunksSkippedAtAssigner", chunksNum).Return().Once()
+
+	// once assigner engine is done processing the block, it should notify the processing notifier.
+	s.notifier.On("Notify", containerBlock.ID()).Return().Once()
+
+	// sends block containing receipt to assigner engine
+	s.metrics.On("OnFinalizedBlockArrivedAtAssigner", containerBlock.Header.Height).Return().Once()
+	s.metrics.On("OnExecutionResultReceivedAtAssignerEngine").Return().Once()
+	e.ProcessFinalizedBlock(containerBlock)
+
+	mock.AssertExpectationsForObjects(t,
+		s.metrics,
+		s.assigner,
+		s.notifier)
+
+	// chunks of sealed block should not be passed to chunks queue, and
+	// job listener should not be notified.
+	s.chunksQueue.AssertNotCalled(t, "StoreChunkLocator")
+	s.newChunkListener.AssertNotCalled(t, "Check")
+}
+
 // mockChunksQueueForAssignment mocks chunks queue against invoking its store functionality for the
 // input assignment.
 // The mocked version of chunks queue evaluates that whatever chunk locator is tried to be stored belongs to the
@@ -25,3 +25,12 @@ type FinalizedBlockProcessor interface {
 	// by the consumer through invoking ProcessFinalizedBlock of this processor.
 	WithBlockConsumerNotifier(module.ProcessingNotifier)
 }
+
+// CatchUpTracker tells whether the verification node is lagging far behind the sealed height.
+//
+// While catching up, the assigner engine does not push chunks of results for already sealed blocks
+// to the chunks queue, since those blocks need no further approvals.
+type CatchUpTracker interface {
+	// CatchingUp returns true if the verification node is currently in catch-up mode.
+	CatchingUp() bool
+}
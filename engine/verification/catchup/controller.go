@@ -0,0 +1,208 @@
+package catchup
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog"
+	"go.uber.org/atomic"
+
+	"github.com/onflow/flow-go/engine"
+	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/state/protocol"
+	"github.com/onflow/flow-go/storage"
+)
+
+const (
+	// DefaultThreshold is the number of blocks the verification node must lag behind the sealed height
+	// before entering catch-up mode.
+	DefaultThreshold = uint64(100)
+
+	// DefaultCheckInterval is the time interval the controller re-evaluates the lag behind the sealed height.
+	DefaultCheckInterval = 10 * time.Second
+
+	// DefaultCatchUpBlockWorkers is the number of blocks processed in parallel while catching up.
+	DefaultCatchUpBlockWorkers = uint64(10)
+
+	// DefaultCatchUpChunkWorkers is the number of chunks processed in parallel while catching up.
+	DefaultCatchUpChunkWorkers = uint64(50)
+)
+
+// WorkerScaler is a job consumer whose number of parallel workers can be adjusted at runtime.
+type WorkerScaler interface {
+	SetMaxProcessing(workers uint64)
+}
+
+// Config holds the worker counts of the block and chunk consumers in both normal and catch-up modes.
+type Config struct {
+	Threshold           uint64        // lag (in blocks) behind the sealed height that triggers catch-up mode.
+	CheckInterval       time.Duration // time interval between two lag evaluations.
+	BlockWorkers        uint64        // block workers in normal mode.
+	ChunkWorkers        uint64        // chunk workers in normal mode.
+	CatchUpBlockWorkers uint64        // block workers in catch-up mode.
+	CatchUpChunkWorkers uint64        // chunk workers in catch-up mode.
+}
+
+// Controller periodically compares the last height processed by the block consumer against the latest
+// sealed height. When the lag reaches the threshold, it switches the verification node into catch-up mode
+// by raising the number of block and chunk workers. It switches back to normal mode once the lag drops to
+// half of the threshold, so that the node does not flap between modes around the threshold.
+//
+// While in catch-up mode, the assigner engine (through CatchingUp) skips chunks of already sealed blocks.
+type Controller struct {
+	unit          *engine.Unit
+	log           zerolog.Logger
+	metrics       module.VerificationMetrics
+	state         protocol.State
+	blockProgress storage.ConsumerProgress // last height processed by block consumer.
+	chunkProgress storage.ConsumerProgress // last chunk index processed by chunk consumer.
+	chunksQueue   storage.ChunksQueue      // to read the latest chunk index.
+	blockScaler   WorkerScaler             // block consumer.
+	chunkScaler   WorkerScaler             // chunk consumer.
+	config        Config
+	catchingUp    *atomic.Bool
+}
+
+// New creates a new catch-up controller. The block and chunk consumers are expected to be initialized
+// with the normal-mode worker counts of the config.
+func New(
+	log zerolog.Logger,
+	metrics module.VerificationMetrics,
+	state protocol.State,
+	blockProgress storage.ConsumerProgress,
+	chunkProgress storage.ConsumerProgress,
+	chunksQueue storage.ChunksQueue,
+	blockScaler WorkerScaler,
+	chunkScaler WorkerScaler,
+	config Config,
+) (*Controller, error) {
+	if config.Threshold == 0 {
+		return nil, fmt.Errorf("catch-up threshold must be positive")
+	}
+	if config.CheckInterval <= 0 {
+		return nil, fmt.Errorf("catch-up check interval must be positive, got: %v", config.CheckInterval)
+	}
+	if config.BlockWorkers == 0 || config.ChunkWorkers == 0 || config.CatchUpBlockWorkers == 0 || config.CatchUpChunkWorkers == 0 {
+		return nil, fmt.Errorf("worker counts must be positive, got: %+v", config)
+	}
+
+	return &Controller{
+		unit:          engine.NewUnit(),
+		log:           log.With().Str("module", "catch_up_controller").Logger(),
+		metrics:       metrics,
+		state:         state,
+		blockProgress: blockProgress,
+		chunkProgress: chunkProgress,
+		chunksQueue:   chunksQueue,
+		blockScaler:   blockScaler,
+		chunkScaler:   chunkScaler,
+		config:        config,
+		catchingUp:    atomic.NewBool(false),
+	}, nil
+}
+
+// CatchingUp returns true if the verification node is currently in catch-up mode.
+func (c *Controller) CatchingUp() bool {
+	return c.catchingUp.Load()
+}
+
+// Ready starts evaluating the lag of the verification node periodically.
+func (c *Controller) Ready() <-chan struct{} {
+	c.unit.LaunchPeriodically(c.check, c.config.CheckInterval, 0)
+	return c.unit.Ready()
+}
+
+// Done stops the periodic lag evaluation.
+func (c *Controller) Done() <-chan struct{} {
+	return c.unit.Done()
+}
+
+// check is a wrapper around checkLag with logging.
+func (c *Controller) check() {
+	err := c.checkLag()
+	if err != nil {
+		c.log.Error().Err(err).Msg("could not evaluate lag behind sealed height")
+	}
+}
+
+// checkLag evaluates the lag of the block consumer behind the latest sealed height as well as the number of
+// pending chunks, reports both as metrics, and switches between normal and catch-up modes if needed.
+func (c *Controller) checkLag() error {
+	lastSealed, err := c.state.Sealed().Head()
+	if err != nil {
+		return fmt.Errorf("could not get last sealed block: %w", err)
+	}
+
+	processedHeight, err := c.blockProgress.ProcessedIndex()
+	if errors.Is(err, storage.ErrNotFound) {
+		// block consumer has not started yet.
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("could not read processed height of block consumer: %w", err)
+	}
+
+	lag := uint64(0)
+	if lastSealed.Height > processedHeight {
+		lag = lastSealed.Height - processedHeight
+	}
+	c.metrics.SetSealedHeightLagAtBlockConsumer(lag)
+
+	pending, err := c.pendingChunks()
+	if err != nil {
+		return fmt.Errorf("could not determine pending chunks: %w", err)
+	}
+	c.metrics.SetPendingChunksAtChunkConsumer(pending)
+
+	lg := c.log.With().
+		Uint64("sealed_height", lastSealed.Height).
+		Uint64("processed_height", processedHeight).
+		Uint64("lag", lag).
+		Uint64("pending_chunks", pending).
+		Logger()
+
+	catchingUp := c.catchingUp.Load()
+	switch {
+	case !catchingUp && lag >= c.config.Threshold:
+		c.setMode(true, c.config.CatchUpBlockWorkers, c.config.CatchUpChunkWorkers)
+		lg.Info().Msg("lagging behind sealed height, entered catch-up mode")
+	case catchingUp && lag <= c.config.Threshold/2:
+		c.setMode(false, c.config.BlockWorkers, c.config.ChunkWorkers)
+		lg.Info().Msg("caught up with sealed height, left catch-up mode")
+	default:
+		lg.Debug().Bool("catching_up", catchingUp).Msg("lag behind sealed height evaluated")
+	}
+
+	return nil
+}
+
+// pendingChunks returns the number of chunks in the chunks queue that the chunk consumer has not processed yet.
+func (c *Controller) pendingChunks() (uint64, error) {
+	latest, err := c.chunksQueue.LatestIndex()
+	if err != nil {
+		return 0, fmt.Errorf("could not read latest index of chunks queue: %w", err)
+	}
+
+	processed, err := c.chunkProgress.ProcessedIndex()
+	if errors.Is(err, storage.ErrNotFound) {
+		// chunk consumer has not started yet.
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("could not read processed index of chunk consumer: %w", err)
+	}
+
+	if latest <= processed {
+		return 0, nil
+	}
+	return latest - processed, nil
+}
+
+// setMode updates the worker counts of both consumers and records the new mode.
+func (c *Controller) setMode(catchingUp bool, blockWorkers uint64, chunkWorkers uint64) {
+	c.blockScaler.SetMaxProcessing(blockWorkers)
+	c.chunkScaler.SetMaxProcessing(chunkWorkers)
+	c.catchingUp.Store(catchingUp)
+	c.metrics.SetCatchUpModeEnabled(catchingUp)
+}
@@ -0,0 +1,158 @@
+package catchup
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	module "github.com/onflow/flow-go/module/mock"
+	protocol "github.com/onflow/flow-go/state/protocol/mock"
+	"github.com/onflow/flow-go/storage"
+	storagemock "github.com/onflow/flow-go/storage/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// recordingScaler keeps track of the latest worker count it was set to.
+type recordingScaler struct {
+	workers uint64
+}
+
+func (r *recordingScaler) SetMaxProcessing(workers uint64) {
+	r.workers = workers
+}
+
+// controllerTestSuite encapsulates the dependencies of the catch-up controller for testing.
+type controllerTestSuite struct {
+	state         *protocol.State
+	snapshot      *protocol.Snapshot
+	metrics       *module.VerificationMetrics
+	blockProgress *storagemock.ConsumerProgress
+	chunkProgress *storagemock.ConsumerProgress
+	chunksQueue   *storagemock.ChunksQueue
+	blockScaler   *recordingScaler
+	chunkScaler   *recordingScaler
+	config        Config
+}
+
+func setupTest(t *testing.T) (*controllerTestSuite, *Controller) {
+	s := &controllerTestSuite{
+		state:         &protocol.State{},
+		snapshot:      &protocol.Snapshot{},
+		metrics:       &module.VerificationMetrics{},
+		blockProgress: &storagemock.ConsumerProgress{},
+		chunkProgress: &storagemock.ConsumerProgress{},
+		chunksQueue:   &storagemock.ChunksQueue{},
+		blockScaler:   &recordingScaler{workers: 2},
+		chunkScaler:   &recordingScaler{workers: 5},
+		config: Config{
+			Threshold:           100,
+			CheckInterval:       time.Second,
+			BlockWorkers:        2,
+			ChunkWorkers:        5,
+			CatchUpBlockWorkers: 10,
+			CatchUpChunkWorkers: 50,
+		},
+	}
+	s.state.On("Sealed").Return(s.snapshot)
+
+	c, err := New(unittest.Logger(),
+		s.metrics,
+		s.state,
+		s.blockProgress,
+		s.chunkProgress,
+		s.chunksQueue,
+		s.blockScaler,
+		s.chunkScaler,
+		s.config)
+	require.NoError(t, err)
+
+	return s, c
+}
+
+// mockProgress mocks the sealed height, the processed height of block consumer, and the
+// latest and processed chunk indices for the next lag evaluation.
+func (s *controllerTestSuite) mockProgress(sealedHeight, processedHeight, latestChunk, processedChunk uint64) {
+	header := unittest.BlockHeaderFixture()
+	header.Height = sealedHeight
+	s.snapshot.On("Head").Return(&header, nil).Once()
+	s.blockProgress.On("ProcessedIndex").Return(processedHeight, nil).Once()
+	s.chunksQueue.On("LatestIndex").Return(latestChunk, nil).Once()
+	s.chunkProgress.On("ProcessedIndex").Return(processedChunk, nil).Once()
+}
+
+// TestCatchUpMode evaluates that the controller enters catch-up mode once the lag reaches the threshold,
+// stays in it while the lag is above half of the threshold, and leaves it afterwards.
+func TestCatchUpMode(t *testing.T) {
+	s, c := setupTest(t)
+
+	// lag below threshold: normal mode.
+	s.mockProgress(150, 100, 20, 10)
+	s.metrics.On("SetSealedHeightLagAtBlockConsumer", uint64(50)).Return().Once()
+	s.metrics.On("SetPendingChunksAtChunkConsumer", uint64(10)).Return().Once()
+	require.NoError(t, c.checkLag())
+	require.False(t, c.CatchingUp())
+	require.Equal(t, uint64(2), s.blockScaler.workers)
+	require.Equal(t, uint64(5), s.chunkScaler.workers)
+
+	// lag reaches threshold: catch-up mode.
+	s.mockProgress(300, 200, 40, 10)
+	s.metrics.On("SetSealedHeightLagAtBlockConsumer", uint64(100)).Return().Once()
+	s.metrics.On("SetPendingChunksAtChunkConsumer", uint64(30)).Return().Once()
+	s.metrics.On("SetCatchUpModeEnabled", true).Return().Once()
+	require.NoError(t, c.checkLag())
+	require.True(t, c.CatchingUp())
+	require.Equal(t, uint64(10), s.blockScaler.workers)
+	require.Equal(t, uint64(50), s.chunkScaler.workers)
+
+	// lag below threshold but above half of it: stays in catch-up mode.
+	s.mockProgress(300, 240, 40, 30)
+	s.metrics.On("SetSealedHeightLagAtBlockConsumer", uint64(60)).Return().Once()
+	s.metrics.On("SetPendingChunksAtChunkConsumer", uint64(10)).Return().Once()
+	require.NoError(t, c.checkLag())
+	require.True(t, c.CatchingUp())
+
+	// lag drops to half of threshold: back to normal mode.
+	s.mockProgress(300, 250, 40, 40)
+	s.metrics.On("SetSealedHeightLagAtBlockConsumer", uint64(50)).Return().Once()
+	s.metrics.On("SetPendingChunksAtChunkConsumer", uint64(0)).Return().Once()
+	s.metrics.On("SetCatchUpModeEnabled", false).Return().Once()
+	require.NoError(t, c.checkLag())
+	require.False(t, c.CatchingUp())
+	require.Equal(t, uint64(2), s.blockScaler.workers)
+	require.Equal(t, uint64(5), s.chunkScaler.workers)
+
+	mock.AssertExpectationsForObjects(t, s.metrics, s.blockProgress, s.chunkProgress, s.chunksQueue)
+}
+
+// TestConsumerNotStarted evaluates that the controller does not report any lag nor change mode before
+// the block consumer initializes its processed height.
+func TestConsumerNotStarted(t *testing.T) {
+	s, c := setupTest(t)
+
+	header := unittest.BlockHeaderFixture()
+	s.snapshot.On("Head").Return(&header, nil).Once()
+	s.blockProgress.On("ProcessedIndex").Return(uint64(0), storage.ErrNotFound).Once()
+
+	require.NoError(t, c.checkLag())
+	require.False(t, c.CatchingUp())
+
+	s.metrics.AssertNotCalled(t, "SetSealedHeightLagAtBlockConsumer", mock.Anything)
+	s.chunksQueue.AssertNotCalled(t, "LatestIndex")
+}
+
+// TestInvalidConfig evaluates that the controller cannot be created with zero threshold or worker counts.
+func TestInvalidConfig(t *testing.T) {
+	s, _ := setupTest(t)
+
+	config := s.config
+	config.Threshold = 0
+	_, err := New(unittest.Logger(), s.metrics, s.state, s.blockProgress, s.chunkProgress, s.chunksQueue, s.blockScaler, s.chunkScaler, config)
+	require.Error(t, err)
+
+	config = s.config
+	config.CatchUpChunkWorkers = 0
+	_, err = New(unittest.Logger(), s.metrics, s.state, s.blockProgress, s.chunkProgress, s.chunksQueue, s.blockScaler, s.chunkScaler, config)
+	require.Error(t, err)
+}
@@ -63,6 +63,11 @@ func (c *ChunkConsumer) Size() uint {
 	return c.consumer.Size()
 }
 
+// SetMaxProcessing updates the number of chunk jobs that chunk consumer processes in parallel.
+func (c *ChunkConsumer) SetMaxProcessing(workers uint64) {
+	c.consumer.SetMaxProcessing(workers)
+}
+
 func (c ChunkConsumer) Check() {
 	c.consumer.Check()
 }
@@ -32,6 +32,7 @@ type Context struct {
 	ServiceEventCollectionEnabled bool
 	AccountFreezeAvailable        bool
 	ExtensiveTracing              bool
+	ProfilingEnabled              bool
 	SignatureVerifier             crypto.SignatureVerifier
 	TransactionProcessors         []TransactionProcessor
 	ScriptProcessors              []ScriptProcessor
@@ -84,6 +85,7 @@ func defaultContext(logger zerolog.Logger) Context {
 		ServiceEventCollectionEnabled: false,
 		AccountFreezeAvailable:        false,
 		ExtensiveTracing:              false,
+		ProfilingEnabled:              false,
 		SignatureVerifier:             crypto.NewDefaultSignatureVerifier(),
 		TransactionProcessors: []TransactionProcessor{
 			NewTransactionAccountFrozenChecker(),
@@ -188,6 +190,18 @@ func WithExtensiveTracing() Option {
 	}
 }
 
+// WithProfiling enables or disables profiling of procedures for a virtual machine context.
+//
+// With profiling enabled, transactions and scripts return a profile of the computation and the
+// time used by the invoked contract functions and environment calls. Cadence functions are only
+// profiled if tracing is enabled on the Cadence runtime.
+func WithProfiling(enabled bool) Option {
+	return func(ctx Context) Context {
+		ctx.ProfilingEnabled = enabled
+		return ctx
+	}
+}
+
 // WithBlocks sets the block storage provider for a virtual machine context.
 //
 // The VM uses the block storage provider to provide historical block information to
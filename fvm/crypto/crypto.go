@@ -32,6 +32,10 @@ func HashWithTag(hashAlgo hash.HashingAlgorithm, tag string, data []byte) ([]byt
 }
 
 // RuntimeToCryptoSigningAlgorithm converts a runtime signature algorithm to a crypto signature algorithm.
+//
+// The Cadence runtime has no Ed25519 signature algorithm, so Ed25519 has no mapping in either direction:
+// Ed25519 account keys can sign transactions, but can't be created, read, revoked or used to verify
+// signatures from Cadence.
 func RuntimeToCryptoSigningAlgorithm(s runtime.SignatureAlgorithm) crypto.SigningAlgorithm {
 	switch s {
 	case runtime.SignatureAlgorithmECDSA_P256:
@@ -46,6 +50,7 @@ func RuntimeToCryptoSigningAlgorithm(s runtime.SignatureAlgorithm) crypto.Signin
 }
 
 // CryptoToRuntimeSigningAlgorithm converts a crypto signature algorithm to a runtime signature algorithm.
+// Ed25519 converts to the unknown runtime signature algorithm, see RuntimeToCryptoSigningAlgorithm.
 func CryptoToRuntimeSigningAlgorithm(s crypto.SigningAlgorithm) runtime.SignatureAlgorithm {
 	switch s {
 	case crypto.ECDSAP256:
@@ -275,6 +275,43 @@ func TestVerifySignatureFromRuntime(t *testing.T) {
 	})
 }
 
+func TestDefaultSignatureVerifier_Ed25519(t *testing.T) {
+
+	seed := make([]byte, gocrypto.KeyGenSeedMinLenEd25519)
+	rand.Read(seed)
+	sk, err := gocrypto.GeneratePrivateKey(gocrypto.Ed25519, seed)
+	require.NoError(t, err)
+
+	hashAlgos := []hash.HashingAlgorithm{
+		hash.SHA2_256,
+		hash.SHA3_256,
+	}
+
+	for _, h := range hashAlgos {
+		t.Run(fmt.Sprintf("hash %v", h), func(t *testing.T) {
+			hasher, err := crypto.NewPrefixedHashing(h, flow.TransactionTagString)
+			require.NoError(t, err)
+
+			sig, err := sk.Sign([]byte("some data"), hasher)
+			require.NoError(t, err)
+
+			verifier := crypto.NewDefaultSignatureVerifier()
+
+			ok, err := verifier.Verify(sig, flow.TransactionTagString, []byte("some data"), sk.PublicKey(), h)
+			require.NoError(t, err)
+			require.True(t, ok)
+
+			ok, err = verifier.Verify(sig, flow.TransactionTagString, []byte("other data"), sk.PublicKey(), h)
+			require.NoError(t, err)
+			require.False(t, ok)
+
+			ok, err = verifier.Verify(sig, flow.UserTagString, []byte("some data"), sk.PublicKey(), h)
+			require.NoError(t, err)
+			require.False(t, ok)
+		})
+	}
+}
+
 func TestValidatePublicKey(t *testing.T) {
 
 	// make sure the seed length is larger than miniumum seed lengths of all signature schemes
@@ -352,6 +389,28 @@ func TestSigningAlgorithmConversion(t *testing.T) {
 		assert.Equal(t, cryptoAlgo, crypto.RuntimeToCryptoSigningAlgorithm(runtimeAlgo))
 		assert.Equal(t, runtimeAlgo, crypto.CryptoToRuntimeSigningAlgorithm(cryptoAlgo))
 	}
+
+	t.Run("Ed25519 has no runtime counterpart", func(t *testing.T) {
+		assert.Equal(t, runtime.SignatureAlgorithmUnknown, crypto.CryptoToRuntimeSigningAlgorithm(gocrypto.Ed25519))
+
+		seed := make([]byte, gocrypto.KeyGenSeedMinLenEd25519)
+		rand.Read(seed)
+		sk, err := gocrypto.GeneratePrivateKey(gocrypto.Ed25519, seed)
+		require.NoError(t, err)
+
+		// signatures of Ed25519 keys can't be verified from the runtime
+		_, err = crypto.VerifySignatureFromRuntime(
+			crypto.NewDefaultSignatureVerifier(),
+			[]byte("signature"),
+			flow.UserTagString,
+			[]byte("some data"),
+			sk.PublicKey().Encode(),
+			crypto.CryptoToRuntimeSigningAlgorithm(gocrypto.Ed25519),
+			runtime.HashAlgorithmSHA3_256,
+		)
+		require.Error(t, err)
+		require.IsType(t, &errors.ValueError{}, err)
+	})
 }
 
 func TestVerifySignatureFromRuntime_error_handling_produces_valid_utf8_for_invalid_sign_algo(t *testing.T) {
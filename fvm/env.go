@@ -2,6 +2,8 @@ package fvm
 
 import (
 	"github.com/onflow/cadence/runtime"
+
+	"github.com/onflow/flow-go/fvm/profiling"
 )
 
 // Environment accepts a context and a virtual machine instance and provides
@@ -9,5 +11,7 @@ import (
 type Environment interface {
 	Context() *Context
 	VM() *VirtualMachine
+	// Profiler returns the profiler of the procedure, or nil if profiling is disabled.
+	Profiler() *profiling.Profiler
 	runtime.Interface
 }
@@ -26,10 +26,12 @@ import (
 	crypto2 "github.com/onflow/flow-go/fvm/crypto"
 	errors "github.com/onflow/flow-go/fvm/errors"
 	fvmmock "github.com/onflow/flow-go/fvm/mock"
+	"github.com/onflow/flow-go/fvm/profiling"
 	"github.com/onflow/flow-go/fvm/programs"
 	"github.com/onflow/flow-go/fvm/state"
 	"github.com/onflow/flow-go/fvm/utils"
 	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/trace"
 	"github.com/onflow/flow-go/utils/unittest"
 )
 
@@ -3492,3 +3494,106 @@ func TestStorageUsed(t *testing.T) {
 
 	assert.Equal(t, cadence.NewUInt64(5), script.Value)
 }
+
+func TestBlockContext_Profiling(t *testing.T) {
+
+	t.Parallel()
+
+	rt := fvm.NewInterpreterRuntime(runtime.WithTracingEnabled(true))
+
+	chain := flow.Testnet.Chain()
+
+	vm := fvm.NewVirtualMachine(rt)
+
+	ctx := fvm.NewContext(
+		zerolog.Nop(),
+		fvm.WithChain(chain),
+		fvm.WithProfiling(true),
+	)
+
+	findEntry := func(entries []profiling.Entry, name string) (profiling.Entry, bool) {
+		for _, entry := range entries {
+			if entry.Name == name {
+				return entry, true
+			}
+		}
+		return profiling.Entry{}, false
+	}
+
+	t.Run("transaction", func(t *testing.T) {
+		txBody := flow.NewTransactionBody().
+			SetScript([]byte(`
+                pub fun double(_ x: Int): Int {
+                    return x * 2
+                }
+
+                transaction {
+                  prepare(signer: AuthAccount) {
+                    signer.save(double(21), to: /storage/answer)
+                  }
+                }
+            `)).
+			AddAuthorizer(chain.ServiceAddress())
+
+		err := testutil.SignTransactionAsServiceAccount(txBody, 0, chain)
+		require.NoError(t, err)
+
+		ledger := testutil.RootBootstrappedLedger(vm, ctx)
+
+		tx := fvm.Transaction(txBody, 0)
+
+		err = vm.Run(ctx, tx, ledger, programs.NewEmptyPrograms())
+		require.NoError(t, err)
+		require.NoError(t, tx.Err)
+
+		require.NotNil(t, tx.Profile)
+
+		invocation, ok := findEntry(tx.Profile.Invocations, "transaction")
+		require.True(t, ok)
+		assert.Equal(t, uint64(1), invocation.Calls)
+		assert.Equal(t, tx.ComputationUsed, tx.Profile.ComputationUsed())
+
+		function, ok := findEntry(tx.Profile.Functions, "double")
+		require.True(t, ok)
+		assert.Equal(t, uint64(1), function.Calls)
+
+		_, ok = findEntry(tx.Profile.EnvironmentCalls, string(trace.FVMEnvSetValue))
+		assert.True(t, ok)
+	})
+
+	t.Run("script", func(t *testing.T) {
+		code := []byte(`
+            pub fun main(): Int {
+                return 42
+            }
+        `)
+
+		ledger := testutil.RootBootstrappedLedger(vm, ctx)
+
+		script := fvm.Script(code)
+
+		err := vm.Run(ctx, script, ledger, programs.NewEmptyPrograms())
+		require.NoError(t, err)
+		require.NoError(t, script.Err)
+
+		require.NotNil(t, script.Profile)
+
+		invocation, ok := findEntry(script.Profile.Invocations, "script")
+		require.True(t, ok)
+		assert.Equal(t, uint64(1), invocation.Calls)
+		assert.Equal(t, script.GasUsed, script.Profile.ComputationUsed())
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		ctx := fvm.NewContextFromParent(ctx, fvm.WithProfiling(false))
+
+		ledger := testutil.RootBootstrappedLedger(vm, ctx)
+
+		script := fvm.Script([]byte(`pub fun main(): Int { return 42 }`))
+
+		err := vm.Run(ctx, script, ledger, programs.NewEmptyPrograms())
+		require.NoError(t, err)
+
+		assert.Nil(t, script.Profile)
+	})
+}
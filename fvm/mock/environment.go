@@ -18,6 +18,8 @@ import (
 
 	opentracing "github.com/opentracing/opentracing-go"
 
+	profiling "github.com/onflow/flow-go/fvm/profiling"
+
 	runtime "github.com/onflow/cadence/runtime"
 
 	sema "github.com/onflow/cadence/runtime/sema"
@@ -599,6 +601,22 @@ func (_m *Environment) ImplementationDebugLog(message string) error {
 	return r0
 }
 
+// Profiler provides a mock function with given fields:
+func (_m *Environment) Profiler() *profiling.Profiler {
+	ret := _m.Called()
+
+	var r0 *profiling.Profiler
+	if rf, ok := ret.Get(0).(func() *profiling.Profiler); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*profiling.Profiler)
+		}
+	}
+
+	return r0
+}
+
 // ProgramLog provides a mock function with given fields: _a0
 func (_m *Environment) ProgramLog(_a0 string) error {
 	ret := _m.Called(_a0)
@@ -0,0 +1,93 @@
+package profiling
+
+import (
+	"sync"
+	"time"
+)
+
+// Collector aggregates the profiles of procedures while it is started, e.g. to profile the
+// transactions executed by a node over some time. It is safe for concurrent use.
+type Collector struct {
+	mu          sync.Mutex
+	started     bool
+	startedAt   time.Time
+	procedures  uint64
+	functions   entries
+	invocations entries
+	envCalls    entries
+}
+
+// NewCollector creates a new collector, which is stopped.
+func NewCollector() *Collector {
+	return &Collector{}
+}
+
+// Start starts collecting profiles. It returns false if the collector is already started.
+func (c *Collector) Start() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.started {
+		return false
+	}
+
+	c.started = true
+	c.startedAt = time.Now()
+	c.procedures = 0
+	c.functions = make(entries)
+	c.invocations = make(entries)
+	c.envCalls = make(entries)
+	return true
+}
+
+// Started returns whether the collector is collecting profiles.
+func (c *Collector) Started() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.started
+}
+
+// Add adds the profile of a procedure, if the collector is started. Nil profiles are ignored.
+func (c *Collector) Add(profile *Profile) {
+	if profile == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.started {
+		return
+	}
+
+	c.procedures++
+	for _, entry := range profile.Functions {
+		c.functions.add(entry)
+	}
+	for _, entry := range profile.Invocations {
+		c.invocations.add(entry)
+	}
+	for _, entry := range profile.EnvironmentCalls {
+		c.envCalls.add(entry)
+	}
+}
+
+// Stop stops collecting profiles, and returns the aggregated profile, the number of procedures
+// profiled and the time spent collecting. It returns a nil profile if the collector was not started.
+func (c *Collector) Stop() (*Profile, uint64, time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.started {
+		return nil, 0, 0
+	}
+
+	c.started = false
+	profile := &Profile{
+		Functions:        c.functions.sorted(),
+		Invocations:      c.invocations.sorted(),
+		EnvironmentCalls: c.envCalls.sorted(),
+	}
+	return profile, c.procedures, time.Since(c.startedAt)
+}
@@ -0,0 +1,177 @@
+package profiling
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// field numbers of the pprof profile format,
+// see https://github.com/google/pprof/blob/master/proto/profile.proto
+const (
+	profileSampleType    protowire.Number = 1
+	profileSample        protowire.Number = 2
+	profileLocation      protowire.Number = 4
+	profileFunction      protowire.Number = 5
+	profileStringTable   protowire.Number = 6
+	profileTimeNanos     protowire.Number = 9
+	profileDurationNanos protowire.Number = 10
+
+	valueTypeType protowire.Number = 1
+	valueTypeUnit protowire.Number = 2
+
+	sampleLocationID protowire.Number = 1
+	sampleValue      protowire.Number = 2
+
+	locationID   protowire.Number = 1
+	locationLine protowire.Number = 4
+
+	lineFunctionID protowire.Number = 1
+
+	functionID       protowire.Number = 1
+	functionName     protowire.Number = 2
+	functionFilename protowire.Number = 4
+)
+
+// the root frames of the samples, which group the entries by their kind
+const (
+	rootFunctions        = "cadence"
+	rootInvocations      = "fvm.invocation"
+	rootEnvironmentCalls = "fvm.env"
+)
+
+// pprofBuilder encodes a profile in the pprof format.
+type pprofBuilder struct {
+	strings   []string
+	stringIDs map[string]int64
+	functions map[entryKey]uint64
+	buf       []byte
+}
+
+func newPprofBuilder() *pprofBuilder {
+	b := &pprofBuilder{
+		stringIDs: make(map[string]int64),
+		functions: make(map[entryKey]uint64),
+	}
+	// the first string of the table must be empty
+	b.string("")
+	return b
+}
+
+func (b *pprofBuilder) string(s string) int64 {
+	id, ok := b.stringIDs[s]
+	if !ok {
+		id = int64(len(b.strings))
+		b.strings = append(b.strings, s)
+		b.stringIDs[s] = id
+	}
+	return id
+}
+
+// function returns the ID of the function with the given name and location, and of its
+// location in the profile, which are the same as every function has a single location.
+func (b *pprofBuilder) function(name string, location string) uint64 {
+	key := entryKey{name: name, location: location}
+	id, ok := b.functions[key]
+	if ok {
+		return id
+	}
+
+	id = uint64(len(b.functions) + 1)
+	b.functions[key] = id
+
+	var function []byte
+	function = appendVarint(function, functionID, id)
+	function = appendVarint(function, functionName, uint64(b.string(name)))
+	function = appendVarint(function, functionFilename, uint64(b.string(location)))
+	b.buf = appendMessage(b.buf, profileFunction, function)
+
+	var line []byte
+	line = appendVarint(line, lineFunctionID, id)
+
+	var loc []byte
+	loc = appendVarint(loc, locationID, id)
+	loc = appendMessage(loc, locationLine, line)
+	b.buf = appendMessage(b.buf, profileLocation, loc)
+
+	return id
+}
+
+func (b *pprofBuilder) sampleType(typ string, unit string) {
+	var valueType []byte
+	valueType = appendVarint(valueType, valueTypeType, uint64(b.string(typ)))
+	valueType = appendVarint(valueType, valueTypeUnit, uint64(b.string(unit)))
+	b.buf = appendMessage(b.buf, profileSampleType, valueType)
+}
+
+func (b *pprofBuilder) samples(root string, entries []Entry) {
+	rootID := b.function(root, "")
+	for _, entry := range entries {
+		leafID := b.function(entry.Name, entry.Location)
+
+		// locations are ordered from the leaf to the root
+		var locations []byte
+		locations = protowire.AppendVarint(locations, leafID)
+		locations = protowire.AppendVarint(locations, rootID)
+
+		var values []byte
+		values = protowire.AppendVarint(values, entry.Calls)
+		values = protowire.AppendVarint(values, uint64(entry.Duration.Nanoseconds()))
+		values = protowire.AppendVarint(values, entry.Computation)
+
+		var sample []byte
+		sample = appendMessage(sample, sampleLocationID, locations)
+		sample = appendMessage(sample, sampleValue, values)
+		b.buf = appendMessage(b.buf, profileSample, sample)
+	}
+}
+
+func (b *pprofBuilder) bytes(start time.Time, duration time.Duration) []byte {
+	buf := b.buf
+	buf = appendVarint(buf, profileTimeNanos, uint64(start.UnixNano()))
+	buf = appendVarint(buf, profileDurationNanos, uint64(duration.Nanoseconds()))
+	for _, s := range b.strings {
+		buf = protowire.AppendTag(buf, profileStringTable, protowire.BytesType)
+		buf = protowire.AppendString(buf, s)
+	}
+	return buf
+}
+
+func appendVarint(buf []byte, num protowire.Number, v uint64) []byte {
+	buf = protowire.AppendTag(buf, num, protowire.VarintType)
+	return protowire.AppendVarint(buf, v)
+}
+
+func appendMessage(buf []byte, num protowire.Number, message []byte) []byte {
+	buf = protowire.AppendTag(buf, num, protowire.BytesType)
+	return protowire.AppendBytes(buf, message)
+}
+
+// WritePprof writes the profile, collected over the given duration, in the gzipped pprof format.
+// Every entry is a sample with the number of calls, the wall time and the computation used,
+// below a root frame for its kind, so the profile can be inspected with `go tool pprof`.
+// Note that the wall time of Cadence functions includes the time spent in nested calls, while
+// their computation doesn't, and is estimated from the time spent in them.
+func WritePprof(w io.Writer, profile *Profile, duration time.Duration) error {
+	b := newPprofBuilder()
+	b.sampleType("calls", "count")
+	b.sampleType("wall", "nanoseconds")
+	b.sampleType("computation", "units")
+	b.samples(rootFunctions, profile.Functions)
+	b.samples(rootInvocations, profile.Invocations)
+	b.samples(rootEnvironmentCalls, profile.EnvironmentCalls)
+
+	gz := gzip.NewWriter(w)
+	_, err := gz.Write(b.bytes(time.Now().Add(-duration), duration))
+	if err != nil {
+		return fmt.Errorf("could not write profile: %w", err)
+	}
+	err = gz.Close()
+	if err != nil {
+		return fmt.Errorf("could not close profile: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package profiling
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func TestWritePprof(t *testing.T) {
+	profile := &Profile{
+		Functions:        []Entry{{Name: "Vault.withdraw", Location: "A.0000000000000001.FlowToken", Calls: 2, Duration: time.Second}},
+		Invocations:      []Entry{{Name: "transaction", Location: "t.01", Calls: 1, Computation: 10}},
+		EnvironmentCalls: []Entry{{Name: "fvm.env.getValue", Calls: 3, Duration: time.Millisecond}},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, WritePprof(&buf, profile, time.Minute))
+
+	gz, err := gzip.NewReader(&buf)
+	require.NoError(t, err)
+	data, err := ioutil.ReadAll(gz)
+	require.NoError(t, err)
+
+	// decode the top level fields of the profile
+	var stringTable []string
+	fields := make(map[protowire.Number]int)
+	for len(data) > 0 {
+		num, typ, n := protowire.ConsumeTag(data)
+		require.GreaterOrEqual(t, n, 0)
+		data = data[n:]
+		fields[num]++
+
+		switch typ {
+		case protowire.BytesType:
+			v, n := protowire.ConsumeBytes(data)
+			require.GreaterOrEqual(t, n, 0)
+			if num == profileStringTable {
+				stringTable = append(stringTable, string(v))
+			}
+			data = data[n:]
+		case protowire.VarintType:
+			_, n := protowire.ConsumeVarint(data)
+			require.GreaterOrEqual(t, n, 0)
+			data = data[n:]
+		default:
+			t.Fatalf("unexpected wire type %v", typ)
+		}
+	}
+
+	assert.Equal(t, 3, fields[profileSampleType])
+	assert.Equal(t, 3, fields[profileSample])
+	// one function and location for each entry and each root
+	assert.Equal(t, 6, fields[profileFunction])
+	assert.Equal(t, 6, fields[profileLocation])
+	assert.Equal(t, 1, fields[profileDurationNanos])
+
+	require.NotEmpty(t, stringTable)
+	assert.Equal(t, "", stringTable[0])
+	for _, s := range []string{"calls", "wall", "computation", "Vault.withdraw", "A.0000000000000001.FlowToken", "transaction", "fvm.env.getValue", rootFunctions, rootInvocations, rootEnvironmentCalls} {
+		assert.Contains(t, stringTable, s)
+	}
+}
@@ -0,0 +1,268 @@
+package profiling
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/onflow/cadence/runtime/common"
+)
+
+// functionTracePrefix prefixes the operations traced by the Cadence runtime for invoked functions.
+const functionTracePrefix = "function."
+
+// Entry is the cost of a function or environment call, accumulated over all its calls.
+type Entry struct {
+	Name     string
+	Location string
+	Calls    uint64
+	// Duration is the wall time spent in the calls, including nested calls.
+	Duration time.Duration
+	// Computation is the computation used by the calls, excluding nested calls of functions.
+	// It is known for invocations, and estimated for functions: the Cadence runtime only reports
+	// the computation used by whole invocations, so it is split between the functions called by
+	// an invocation in proportion to the time spent in them, excluding nested calls.
+	Computation uint64
+}
+
+// Profile is a breakdown of the cost of running procedures.
+type Profile struct {
+	// Functions are the Cadence functions invoked by the procedures. They are only profiled
+	// if tracing is enabled on the Cadence runtime.
+	Functions []Entry
+	// Invocations are the procedures themselves, and the contract functions invoked by
+	// the FVM on their behalf, e.g. to deduct transaction fees.
+	Invocations []Entry
+	// EnvironmentCalls are the calls from the Cadence runtime to the FVM environment,
+	// e.g. storage reads, event emission and crypto operations.
+	EnvironmentCalls []Entry
+}
+
+// ComputationUsed returns the total computation used by the invocations in the profile.
+func (p *Profile) ComputationUsed() uint64 {
+	var used uint64
+	for _, entry := range p.Invocations {
+		used += entry.Computation
+	}
+	return used
+}
+
+type entryKey struct {
+	name     string
+	location string
+}
+
+// entries accumulates the cost of calls by name and location.
+type entries map[entryKey]*Entry
+
+func (e entries) get(name string, location string) *Entry {
+	key := entryKey{name: name, location: location}
+	entry, ok := e[key]
+	if !ok {
+		entry = &Entry{Name: name, Location: location}
+		e[key] = entry
+	}
+	return entry
+}
+
+func (e entries) add(entry Entry) {
+	acc := e.get(entry.Name, entry.Location)
+	acc.Calls += entry.Calls
+	acc.Duration += entry.Duration
+	acc.Computation += entry.Computation
+}
+
+// sorted returns the entries ordered by name and location.
+func (e entries) sorted() []Entry {
+	sorted := make([]Entry, 0, len(e))
+	for _, entry := range e {
+		sorted = append(sorted, *entry)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Name != sorted[j].Name {
+			return sorted[i].Name < sorted[j].Name
+		}
+		return sorted[i].Location < sorted[j].Location
+	})
+	return sorted
+}
+
+// frame is a call of a function, traced by the Cadence runtime once it returned.
+type frame struct {
+	entry    *Entry
+	start    time.Time
+	duration time.Duration
+	// self is the duration excluding the nested calls of functions
+	self time.Duration
+}
+
+// invocation is an invocation of the Cadence runtime in progress.
+type invocation struct {
+	entry       *Entry
+	start       time.Time
+	computation uint64
+	frames      []*frame
+	// callers are the frames whose caller was not traced yet. A caller is traced after the
+	// functions it calls, so the frames traced since it started are the ones it called.
+	callers []*frame
+}
+
+// Profiler profiles a single procedure. It is not safe for concurrent use, as the
+// environment of a procedure is not. All methods are no-ops on a nil profiler, so
+// that environments can call them unconditionally.
+type Profiler struct {
+	functions   entries
+	invocations entries
+	envCalls    entries
+	// stack holds the invocations in progress, the computation reported by the Cadence
+	// runtime and the traced functions are attributed to the innermost one
+	stack []*invocation
+	now   func() time.Time
+}
+
+// NewProfiler creates a new profiler for a procedure.
+func NewProfiler() *Profiler {
+	return &Profiler{
+		functions:   make(entries),
+		invocations: make(entries),
+		envCalls:    make(entries),
+		now:         time.Now,
+	}
+}
+
+// StartInvocation records an invocation of the Cadence runtime, i.e. running the procedure or
+// invoking a contract function from the FVM. The returned function must be called when the
+// invocation returns.
+func (p *Profiler) StartInvocation(name string, location common.Location) func() {
+	if p == nil {
+		return func() {}
+	}
+
+	inv := &invocation{
+		entry: p.invocations.get(name, locationString(location)),
+		start: p.now(),
+	}
+	inv.entry.Calls++
+	p.stack = append(p.stack, inv)
+
+	return func() {
+		duration := p.now().Sub(inv.start)
+		inv.entry.Duration += duration
+		p.stack = p.stack[:len(p.stack)-1]
+		attributeComputation(inv, duration)
+	}
+}
+
+// attributeComputation splits the computation used by an invocation between the functions it
+// called, in proportion to the time spent in each of them, excluding nested calls. The time
+// spent in the invocation outside of any traced function gets its share as well, which is only
+// accounted for in the invocation entry.
+func attributeComputation(inv *invocation, duration time.Duration) {
+	if inv.computation == 0 || len(inv.frames) == 0 {
+		return
+	}
+
+	total := duration
+	for _, caller := range inv.callers {
+		total -= caller.duration
+	}
+	if total < 0 {
+		total = 0
+	}
+	for _, f := range inv.frames {
+		total += f.self
+	}
+	if total <= 0 {
+		return
+	}
+
+	for _, f := range inv.frames {
+		share := float64(f.self) / float64(total)
+		f.entry.Computation += uint64(share * float64(inv.computation))
+	}
+}
+
+// ComputationUsed attributes the computation reported by the Cadence runtime to the innermost
+// invocation in progress.
+func (p *Profiler) ComputationUsed(used uint64) {
+	if p == nil || len(p.stack) == 0 {
+		return
+	}
+	inv := p.stack[len(p.stack)-1]
+	inv.computation += used
+	inv.entry.Computation += used
+}
+
+// RecordTrace records the traces of invoked functions, which the Cadence runtime reports
+// when tracing is enabled. Other traces are ignored. Functions traced during an invocation
+// get a share of the computation it used.
+func (p *Profiler) RecordTrace(operation string, location common.Location, duration time.Duration) {
+	if p == nil || !strings.HasPrefix(operation, functionTracePrefix) {
+		return
+	}
+
+	entry := p.functions.get(strings.TrimPrefix(operation, functionTracePrefix), locationString(location))
+	entry.Calls++
+	entry.Duration += duration
+
+	if len(p.stack) == 0 {
+		return
+	}
+	inv := p.stack[len(p.stack)-1]
+
+	f := &frame{
+		entry:    entry,
+		start:    p.now().Add(-duration),
+		duration: duration,
+		self:     duration,
+	}
+	for len(inv.callers) > 0 {
+		callee := inv.callers[len(inv.callers)-1]
+		if callee.start.Before(f.start) {
+			break
+		}
+		f.self -= callee.duration
+		inv.callers = inv.callers[:len(inv.callers)-1]
+	}
+	if f.self < 0 {
+		f.self = 0
+	}
+	inv.callers = append(inv.callers, f)
+	inv.frames = append(inv.frames, f)
+}
+
+// EnvironmentCall records a call to the FVM environment with the given name. The returned
+// function must be called when the call returns.
+func (p *Profiler) EnvironmentCall(name string) func() {
+	if p == nil {
+		return func() {}
+	}
+
+	entry := p.envCalls.get(name, "")
+	entry.Calls++
+	start := time.Now()
+
+	return func() {
+		entry.Duration += time.Since(start)
+	}
+}
+
+// Profile returns the profile recorded so far, or nil for a nil profiler.
+func (p *Profiler) Profile() *Profile {
+	if p == nil {
+		return nil
+	}
+
+	return &Profile{
+		Functions:        p.functions.sorted(),
+		Invocations:      p.invocations.sorted(),
+		EnvironmentCalls: p.envCalls.sorted(),
+	}
+}
+
+func locationString(location common.Location) string {
+	if location == nil {
+		return ""
+	}
+	return string(location.ID())
+}
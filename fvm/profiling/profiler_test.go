@@ -0,0 +1,150 @@
+package profiling
+
+import (
+	"testing"
+	"time"
+
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProfiler(t *testing.T) {
+	location := common.TransactionLocation{0x1}
+	contract := common.AddressLocation{Address: common.Address{0x1}, Name: "FlowServiceAccount"}
+
+	p := NewProfiler()
+
+	done := p.StartInvocation("transaction", location)
+	p.RecordTrace("function.Vault.withdraw", contract, time.Millisecond)
+	p.RecordTrace("function.Vault.withdraw", contract, 2*time.Millisecond)
+	p.RecordTrace("parseProgram", location, time.Second)
+	p.EnvironmentCall("fvm.env.getValue")()
+	p.EnvironmentCall("fvm.env.getValue")()
+
+	// a nested invocation uses its own computation
+	nested := p.StartInvocation("deductTransactionFee", contract)
+	p.ComputationUsed(3)
+	nested()
+
+	p.ComputationUsed(10)
+	done()
+
+	// computation reported outside of invocations is ignored
+	p.ComputationUsed(100)
+
+	profile := p.Profile()
+
+	require.Len(t, profile.Functions, 1)
+	assert.Equal(t, "Vault.withdraw", profile.Functions[0].Name)
+	assert.Equal(t, string(contract.ID()), profile.Functions[0].Location)
+	assert.Equal(t, uint64(2), profile.Functions[0].Calls)
+	assert.Equal(t, 3*time.Millisecond, profile.Functions[0].Duration)
+
+	require.Len(t, profile.Invocations, 2)
+	assert.Equal(t, "deductTransactionFee", profile.Invocations[0].Name)
+	assert.Equal(t, uint64(3), profile.Invocations[0].Computation)
+	assert.Equal(t, "transaction", profile.Invocations[1].Name)
+	assert.Equal(t, uint64(10), profile.Invocations[1].Computation)
+	assert.Equal(t, uint64(1), profile.Invocations[1].Calls)
+	assert.Equal(t, uint64(13), profile.ComputationUsed())
+
+	require.Len(t, profile.EnvironmentCalls, 1)
+	assert.Equal(t, "fvm.env.getValue", profile.EnvironmentCalls[0].Name)
+	assert.Equal(t, uint64(2), profile.EnvironmentCalls[0].Calls)
+
+	t.Run("nil profiler", func(t *testing.T) {
+		var p *Profiler
+		p.StartInvocation("transaction", location)()
+		p.ComputationUsed(1)
+		p.RecordTrace("function.f", location, time.Second)
+		p.EnvironmentCall("fvm.env.getValue")()
+		assert.Nil(t, p.Profile())
+	})
+}
+
+func TestProfiler_FunctionComputation(t *testing.T) {
+	location := common.TransactionLocation{0x1}
+	contract := common.AddressLocation{Address: common.Address{0x1}, Name: "C"}
+
+	p := NewProfiler()
+	start := time.Now()
+	clock := start
+	p.now = func() time.Time { return clock }
+	at := func(ms int) {
+		clock = start.Add(time.Duration(ms) * time.Millisecond)
+	}
+
+	// the transaction calls a, which calls b, and then calls c
+	done := p.StartInvocation("transaction", location)
+	at(30)
+	p.RecordTrace("function.b", contract, 20*time.Millisecond)
+	at(50)
+	p.RecordTrace("function.a", contract, 45*time.Millisecond)
+	at(70)
+	p.RecordTrace("function.c", contract, 10*time.Millisecond)
+	at(100)
+	p.ComputationUsed(200)
+	done()
+
+	// the invocation spent 45ms outside of the functions, a 25ms, b 20ms and c 10ms
+	profile := p.Profile()
+	require.Len(t, profile.Functions, 3)
+	assert.Equal(t, "a", profile.Functions[0].Name)
+	assert.Equal(t, uint64(50), profile.Functions[0].Computation)
+	assert.Equal(t, 45*time.Millisecond, profile.Functions[0].Duration)
+	assert.Equal(t, "b", profile.Functions[1].Name)
+	assert.Equal(t, uint64(40), profile.Functions[1].Computation)
+	assert.Equal(t, "c", profile.Functions[2].Name)
+	assert.Equal(t, uint64(20), profile.Functions[2].Computation)
+
+	require.Len(t, profile.Invocations, 1)
+	assert.Equal(t, uint64(200), profile.Invocations[0].Computation)
+	assert.Equal(t, 100*time.Millisecond, profile.Invocations[0].Duration)
+
+	t.Run("functions traced outside of invocations use no computation", func(t *testing.T) {
+		p.RecordTrace("function.d", contract, time.Millisecond)
+		profile := p.Profile()
+		require.Len(t, profile.Functions, 4)
+		assert.Equal(t, "d", profile.Functions[3].Name)
+		assert.Equal(t, uint64(0), profile.Functions[3].Computation)
+	})
+}
+
+func TestCollector(t *testing.T) {
+	profile := &Profile{
+		Functions:        []Entry{{Name: "f", Calls: 1, Duration: time.Second}},
+		Invocations:      []Entry{{Name: "transaction", Calls: 1, Computation: 10}},
+		EnvironmentCalls: []Entry{{Name: "fvm.env.getValue", Calls: 2}},
+	}
+
+	c := NewCollector()
+
+	// profiles are not collected before the collector is started
+	c.Add(profile)
+	assert.False(t, c.Started())
+
+	require.True(t, c.Start())
+	assert.False(t, c.Start())
+	assert.True(t, c.Started())
+
+	c.Add(profile)
+	c.Add(profile)
+	c.Add(nil)
+
+	collected, procedures, _ := c.Stop()
+	assert.False(t, c.Started())
+	assert.Equal(t, uint64(2), procedures)
+	assert.Equal(t, []Entry{{Name: "f", Calls: 2, Duration: 2 * time.Second}}, collected.Functions)
+	assert.Equal(t, []Entry{{Name: "transaction", Calls: 2, Computation: 20}}, collected.Invocations)
+	assert.Equal(t, []Entry{{Name: "fvm.env.getValue", Calls: 4}}, collected.EnvironmentCalls)
+
+	stopped, _, _ := c.Stop()
+	assert.Nil(t, stopped)
+
+	// starting again resets the profile
+	require.True(t, c.Start())
+	collected, procedures, _ = c.Stop()
+	assert.Equal(t, uint64(0), procedures)
+	assert.Empty(t, collected.Invocations)
+}
@@ -61,6 +61,21 @@ func (p *Programs) ChildPrograms() *Programs {
 	}
 }
 
+// ChildProgramsWithHitFunc returns a child like ChildPrograms, which calls the given function
+// whenever a program it, or one of its own children, looks up is found in this object or its ancestors.
+func (p *Programs) ChildProgramsWithHitFunc(onHit func(location common.Location)) *Programs {
+	return &Programs{
+		programs: map[common.LocationID]ProgramEntry{},
+		parentFunc: func(location common.Location) (*ProgramEntry, bool) {
+			entry, has := p.get(location)
+			if has {
+				onHit(location)
+			}
+			return entry, has
+		},
+	}
+}
+
 // Get returns stored program, state which contains changes which correspond to loading this program,
 // and boolean indicating if the value was found
 func (p *Programs) Get(location common.Location) (*interpreter.Program, *state.State, bool) {
@@ -100,12 +115,34 @@ func (p *Programs) Set(location common.Location, program *interpreter.Program, s
 	}
 }
 
+// Locations returns the locations of the programs set in this object,
+// excluding the ones inherited from its parent
+func (p *Programs) Locations() []common.Location {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	locations := make([]common.Location, 0, len(p.programs))
+	for _, entry := range p.programs {
+		locations = append(locations, entry.Location)
+	}
+	return locations
+}
+
 // HasChanges indicates if any changes has been introduced
 // essentially telling if this object is identical to its parent
 func (p *Programs) HasChanges() bool {
 	return len(p.programs) > 0 || p.cleaned
 }
 
+// Cleaned indicates if the programs were cleaned up, because contracts were
+// updated or a cleanup was forced, so none of the parent's programs are used anymore
+func (p *Programs) Cleaned() bool {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	return p.cleaned
+}
+
 // ForceCleanup is used to force a complete cleanup
 // It exists temporarily to facilitate a temporary measure which can retry
 // a transaction in case checking fails
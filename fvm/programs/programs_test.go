@@ -169,4 +169,38 @@ func Test_Programs(t *testing.T) {
 		require.True(t, child.HasChanges())
 	})
 
+	t.Run("locations", func(t *testing.T) {
+		parent := NewEmptyPrograms()
+		parent.Set(someLocation, someProgram, newState)
+
+		child := parent.ChildPrograms()
+		require.Empty(t, child.Locations())
+
+		child.Set(addressLocation, someProgram, newState)
+		require.Equal(t, []common.Location{addressLocation}, child.Locations())
+	})
+
+	t.Run("hit func", func(t *testing.T) {
+		parent := NewEmptyPrograms()
+		parent.Set(addressLocation, someProgram, newState)
+
+		var hits []common.Location
+		observed := parent.ChildProgramsWithHitFunc(func(location common.Location) {
+			hits = append(hits, location)
+		})
+		observed.Set(someLocation, someProgram, newState)
+
+		programs := observed.ChildPrograms()
+
+		// programs found in the observed child itself are not hits
+		_, _, has := programs.Get(someLocation)
+		require.True(t, has)
+		_, _, has = programs.Get(common.IdentifierLocation("missing"))
+		require.False(t, has)
+		require.Empty(t, hits)
+
+		_, _, has = programs.Get(addressLocation)
+		require.True(t, has)
+		require.Equal(t, []common.Location{addressLocation}, hits)
+	})
 }
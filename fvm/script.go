@@ -8,6 +8,7 @@ import (
 	"github.com/onflow/cadence/runtime/common"
 
 	"github.com/onflow/flow-go/fvm/errors"
+	"github.com/onflow/flow-go/fvm/profiling"
 	"github.com/onflow/flow-go/fvm/programs"
 	"github.com/onflow/flow-go/fvm/state"
 	"github.com/onflow/flow-go/model/flow"
@@ -31,6 +32,7 @@ type ScriptProcedure struct {
 	Logs      []string
 	Events    []flow.Event
 	GasUsed   uint64
+	Profile   *profiling.Profile
 	Err       errors.Error
 }
 
@@ -79,7 +81,15 @@ func (i ScriptInvoker) Process(
 	programs *programs.Programs,
 ) error {
 	env := NewScriptEnvironment(ctx, vm, sth, programs)
+	if ctx.ProfilingEnabled {
+		env.profiler = profiling.NewProfiler()
+		defer func() {
+			proc.Profile = env.profiler.Profile()
+		}()
+	}
+
 	location := common.ScriptLocation(proc.ID[:])
+	endInvocation := env.profiler.StartInvocation("script", location)
 	value, err := vm.Runtime.ExecuteScript(
 		runtime.Script{
 			Source:    proc.Script,
@@ -90,6 +100,7 @@ func (i ScriptInvoker) Process(
 			Location:  location,
 		},
 	)
+	endInvocation()
 
 	if err != nil {
 		return errors.HandleRuntimeError(err)
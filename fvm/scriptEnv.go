@@ -21,6 +21,7 @@ import (
 	"github.com/onflow/flow-go/fvm/crypto"
 	"github.com/onflow/flow-go/fvm/errors"
 	"github.com/onflow/flow-go/fvm/handler"
+	"github.com/onflow/flow-go/fvm/profiling"
 	"github.com/onflow/flow-go/fvm/programs"
 	"github.com/onflow/flow-go/fvm/state"
 	"github.com/onflow/flow-go/model/flow"
@@ -46,12 +47,17 @@ type ScriptEnv struct {
 	logs               []string
 	rng                *rand.Rand
 	traceSpan          opentracing.Span
+	profiler           *profiling.Profiler
 }
 
 func (e *ScriptEnv) Context() *Context {
 	return &e.ctx
 }
 
+func (e *ScriptEnv) Profiler() *profiling.Profiler {
+	return e.profiler
+}
+
 func (e *ScriptEnv) VM() *VirtualMachine {
 	return e.vm
 }
@@ -112,6 +118,8 @@ func (e *ScriptEnv) isTraceable() bool {
 
 func (e *ScriptEnv) GetValue(owner, key []byte) ([]byte, error) {
 	var valueByteSize int
+	defer e.profiler.EnvironmentCall(string(trace.FVMEnvGetValue))()
+
 	if e.isTraceable() {
 		sp := e.ctx.Tracer.StartSpanFromParent(e.traceSpan, trace.FVMEnvGetValue)
 		defer func() {
@@ -137,6 +145,8 @@ func (e *ScriptEnv) GetValue(owner, key []byte) ([]byte, error) {
 
 // TODO disable SetValue for scripts, right now the view changes are discarded
 func (e *ScriptEnv) SetValue(owner, key, value []byte) error {
+	defer e.profiler.EnvironmentCall(string(trace.FVMEnvSetValue))()
+
 	if e.isTraceable() {
 		sp := e.ctx.Tracer.StartSpanFromParent(e.traceSpan, trace.FVMEnvSetValue)
 		sp.LogFields(
@@ -158,6 +168,8 @@ func (e *ScriptEnv) SetValue(owner, key, value []byte) error {
 }
 
 func (e *ScriptEnv) ValueExists(owner, key []byte) (exists bool, err error) {
+	defer e.profiler.EnvironmentCall(string(trace.FVMEnvValueExists))()
+
 	if e.isTraceable() {
 		sp := e.ctx.Tracer.StartSpanFromParent(e.traceSpan, trace.FVMEnvValueExists)
 		defer sp.Finish()
@@ -176,6 +188,8 @@ func (e *ScriptEnv) AccountExists(address common.Address) (exists bool, err erro
 }
 
 func (e *ScriptEnv) GetStorageUsed(address common.Address) (value uint64, err error) {
+	defer e.profiler.EnvironmentCall(string(trace.FVMEnvGetStorageUsed))()
+
 	if e.isTraceable() {
 		sp := e.ctx.Tracer.StartSpanFromParent(e.traceSpan, trace.FVMEnvGetStorageUsed)
 		defer sp.Finish()
@@ -190,6 +204,8 @@ func (e *ScriptEnv) GetStorageUsed(address common.Address) (value uint64, err er
 }
 
 func (e *ScriptEnv) GetStorageCapacity(address common.Address) (value uint64, err error) {
+	defer e.profiler.EnvironmentCall(string(trace.FVMEnvGetStorageCapacity))()
+
 	if e.isTraceable() {
 		sp := e.ctx.Tracer.StartSpanFromParent(e.traceSpan, trace.FVMEnvGetStorageCapacity)
 		defer sp.Finish()
@@ -213,6 +229,8 @@ func (e *ScriptEnv) GetStorageCapacity(address common.Address) (value uint64, er
 }
 
 func (e *ScriptEnv) GetAccountBalance(address common.Address) (value uint64, err error) {
+	defer e.profiler.EnvironmentCall(string(trace.FVMEnvGetAccountBalance))()
+
 	if e.isTraceable() {
 		sp := e.ctx.Tracer.StartSpanFromParent(e.traceSpan, trace.FVMEnvGetAccountBalance)
 		defer sp.Finish()
@@ -229,6 +247,8 @@ func (e *ScriptEnv) GetAccountBalance(address common.Address) (value uint64, err
 }
 
 func (e *ScriptEnv) GetAccountAvailableBalance(address common.Address) (value uint64, err error) {
+	defer e.profiler.EnvironmentCall(string(trace.FVMEnvGetAccountBalance))()
+
 	if e.isTraceable() {
 		sp := e.ctx.Tracer.StartSpanFromParent(e.traceSpan, trace.FVMEnvGetAccountBalance)
 		defer sp.Finish()
@@ -250,6 +270,8 @@ func (e *ScriptEnv) ResolveLocation(
 	identifiers []runtime.Identifier,
 	location runtime.Location,
 ) ([]runtime.ResolvedLocation, error) {
+	defer e.profiler.EnvironmentCall(string(trace.FVMEnvResolveLocation))()
+
 	if e.isTraceable() && e.ctx.ExtensiveTracing {
 		sp := e.ctx.Tracer.StartSpanFromParent(e.traceSpan, trace.FVMEnvResolveLocation)
 		defer sp.Finish()
@@ -316,6 +338,8 @@ func (e *ScriptEnv) ResolveLocation(
 }
 
 func (e *ScriptEnv) GetAccountContractNames(address runtime.Address) ([]string, error) {
+	defer e.profiler.EnvironmentCall(string(trace.FVMEnvGetAccountContractNames))()
+
 	if e.isTraceable() {
 		sp := e.ctx.Tracer.StartSpanFromParent(e.traceSpan, trace.FVMEnvGetAccountContractNames)
 		defer sp.Finish()
@@ -332,6 +356,8 @@ func (e *ScriptEnv) GetAccountContractNames(address runtime.Address) ([]string,
 }
 
 func (e *ScriptEnv) GetCode(location runtime.Location) ([]byte, error) {
+	defer e.profiler.EnvironmentCall(string(trace.FVMEnvGetCode))()
+
 	if e.isTraceable() {
 		sp := e.ctx.Tracer.StartSpanFromParent(e.traceSpan, trace.FVMEnvGetCode)
 		defer sp.Finish()
@@ -358,6 +384,8 @@ func (e *ScriptEnv) GetCode(location runtime.Location) ([]byte, error) {
 }
 
 func (e *ScriptEnv) GetProgram(location common.Location) (*interpreter.Program, error) {
+	defer e.profiler.EnvironmentCall(string(trace.FVMEnvGetProgram))()
+
 	if e.isTraceable() {
 		sp := e.ctx.Tracer.StartSpanFromParent(e.traceSpan, trace.FVMEnvGetProgram)
 		defer sp.Finish()
@@ -381,6 +409,8 @@ func (e *ScriptEnv) GetProgram(location common.Location) (*interpreter.Program,
 }
 
 func (e *ScriptEnv) SetProgram(location common.Location, program *interpreter.Program) error {
+	defer e.profiler.EnvironmentCall(string(trace.FVMEnvSetProgram))()
+
 	if e.isTraceable() {
 		sp := e.ctx.Tracer.StartSpanFromParent(e.traceSpan, trace.FVMEnvSetProgram)
 		defer sp.Finish()
@@ -394,6 +424,8 @@ func (e *ScriptEnv) SetProgram(location common.Location, program *interpreter.Pr
 }
 
 func (e *ScriptEnv) ProgramLog(message string) error {
+	defer e.profiler.EnvironmentCall(string(trace.FVMEnvProgramLog))()
+
 	if e.isTraceable() && e.ctx.ExtensiveTracing {
 		sp := e.ctx.Tracer.StartSpanFromParent(e.traceSpan, trace.FVMEnvProgramLog)
 		defer sp.Finish()
@@ -418,6 +450,8 @@ func (e *ScriptEnv) Events() []flow.Event {
 }
 
 func (e *ScriptEnv) GenerateUUID() (uint64, error) {
+	defer e.profiler.EnvironmentCall(string(trace.FVMEnvGenerateUUID))()
+
 	if e.isTraceable() && e.ctx.ExtensiveTracing {
 		sp := e.ctx.Tracer.StartSpanFromParent(e.traceSpan, trace.FVMEnvGenerateUUID)
 		defer sp.Finish()
@@ -439,6 +473,7 @@ func (e *ScriptEnv) GetComputationLimit() uint64 {
 }
 
 func (e *ScriptEnv) SetComputationUsed(used uint64) error {
+	e.profiler.ComputationUsed(used)
 	return e.computationHandler.AddUsed(used)
 }
 
@@ -447,6 +482,8 @@ func (e *ScriptEnv) GetComputationUsed() uint64 {
 }
 
 func (e *ScriptEnv) DecodeArgument(b []byte, t cadence.Type) (cadence.Value, error) {
+	defer e.profiler.EnvironmentCall(string(trace.FVMEnvDecodeArgument))()
+
 	if e.isTraceable() && e.ctx.ExtensiveTracing {
 		sp := e.ctx.Tracer.StartSpanFromParent(e.traceSpan, trace.FVMEnvDecodeArgument)
 		defer sp.Finish()
@@ -462,6 +499,8 @@ func (e *ScriptEnv) DecodeArgument(b []byte, t cadence.Type) (cadence.Value, err
 }
 
 func (e *ScriptEnv) Hash(data []byte, tag string, hashAlgorithm runtime.HashAlgorithm) ([]byte, error) {
+	defer e.profiler.EnvironmentCall(string(trace.FVMEnvHash))()
+
 	if e.isTraceable() {
 		sp := e.ctx.Tracer.StartSpanFromParent(e.traceSpan, trace.FVMEnvHash)
 		defer sp.Finish()
@@ -479,6 +518,8 @@ func (e *ScriptEnv) VerifySignature(
 	signatureAlgorithm runtime.SignatureAlgorithm,
 	hashAlgorithm runtime.HashAlgorithm,
 ) (bool, error) {
+	defer e.profiler.EnvironmentCall(string(trace.FVMEnvVerifySignature))()
+
 	if e.isTraceable() {
 		sp := e.ctx.Tracer.StartSpanFromParent(e.traceSpan, trace.FVMEnvVerifySignature)
 		defer sp.Finish()
@@ -509,6 +550,8 @@ func (e *ScriptEnv) ValidatePublicKey(pk *runtime.PublicKey) error {
 
 // GetCurrentBlockHeight returns the current block height.
 func (e *ScriptEnv) GetCurrentBlockHeight() (uint64, error) {
+	defer e.profiler.EnvironmentCall(string(trace.FVMEnvGetCurrentBlockHeight))()
+
 	if e.isTraceable() && e.ctx.ExtensiveTracing {
 		sp := e.ctx.Tracer.StartSpanFromParent(e.traceSpan, trace.FVMEnvGetCurrentBlockHeight)
 		defer sp.Finish()
@@ -523,6 +566,8 @@ func (e *ScriptEnv) GetCurrentBlockHeight() (uint64, error) {
 // UnsafeRandom returns a random uint64, where the process of random number derivation is not cryptographically
 // secure.
 func (e *ScriptEnv) UnsafeRandom() (uint64, error) {
+	defer e.profiler.EnvironmentCall(string(trace.FVMEnvUnsafeRandom))()
+
 	if e.isTraceable() && e.ctx.ExtensiveTracing {
 		sp := e.ctx.Tracer.StartSpanFromParent(e.traceSpan, trace.FVMEnvUnsafeRandom)
 		defer sp.Finish()
@@ -540,6 +585,8 @@ func (e *ScriptEnv) UnsafeRandom() (uint64, error) {
 
 // GetBlockAtHeight returns the block at the given height.
 func (e *ScriptEnv) GetBlockAtHeight(height uint64) (runtime.Block, bool, error) {
+	defer e.profiler.EnvironmentCall(string(trace.FVMEnvGetBlockAtHeight))()
+
 	if e.isTraceable() {
 		sp := e.ctx.Tracer.StartSpanFromParent(e.traceSpan, trace.FVMEnvGetBlockAtHeight)
 		defer sp.Finish()
@@ -581,6 +628,8 @@ func (e *ScriptEnv) AddAccountKey(_ runtime.Address, _ *runtime.PublicKey, _ run
 }
 
 func (e *ScriptEnv) GetAccountKey(address runtime.Address, index int) (*runtime.AccountKey, error) {
+	defer e.profiler.EnvironmentCall(string(trace.FVMEnvGetAccountKey))()
+
 	if e.isTraceable() {
 		sp := e.ctx.Tracer.StartSpanFromParent(e.traceSpan, trace.FVMEnvGetAccountKey)
 		defer sp.Finish()
@@ -606,6 +655,8 @@ func (e *ScriptEnv) UpdateAccountContractCode(address runtime.Address, name stri
 }
 
 func (e *ScriptEnv) GetAccountContractCode(address runtime.Address, name string) (code []byte, err error) {
+	defer e.profiler.EnvironmentCall(string(trace.FVMEnvGetAccountContractCode))()
+
 	if e.isTraceable() {
 		sp := e.ctx.Tracer.StartSpanFromParent(e.traceSpan, trace.FVMEnvGetAccountContractCode)
 		defer sp.Finish()
@@ -636,6 +687,8 @@ func (e *ScriptEnv) ImplementationDebugLog(message string) error {
 }
 
 func (e *ScriptEnv) RecordTrace(operation string, location common.Location, duration time.Duration, logs []opentracing.LogRecord) {
+	e.profiler.RecordTrace(operation, location, duration)
+
 	if !e.isTraceable() {
 		return
 	}
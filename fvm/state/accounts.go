@@ -296,7 +296,7 @@ func (a *StatefulAccounts) AppendPublicKey(address flow.Address, publicKey flow.
 
 func IsValidAccountKeySignAlgo(algo crypto.SigningAlgorithm) bool {
 	switch algo {
-	case crypto.ECDSAP256, crypto.ECDSASecp256k1:
+	case crypto.ECDSAP256, crypto.ECDSASecp256k1, crypto.Ed25519:
 		return true
 	default:
 		return false
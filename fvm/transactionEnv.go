@@ -21,6 +21,7 @@ import (
 	"github.com/onflow/flow-go/fvm/crypto"
 	"github.com/onflow/flow-go/fvm/errors"
 	"github.com/onflow/flow-go/fvm/handler"
+	"github.com/onflow/flow-go/fvm/profiling"
 	"github.com/onflow/flow-go/fvm/programs"
 	"github.com/onflow/flow-go/fvm/state"
 	"github.com/onflow/flow-go/fvm/utils"
@@ -52,6 +53,7 @@ type TransactionEnv struct {
 	txID               flow.Identifier
 	traceSpan          opentracing.Span
 	authorizers        []runtime.Address
+	profiler           *profiling.Profiler
 }
 
 func (e *TransactionEnv) ResourceOwnerChanged(_ *interpreter.CompositeValue, _ common.Address, _ common.Address) {
@@ -136,6 +138,10 @@ func (e *TransactionEnv) Context() *Context {
 	return &e.ctx
 }
 
+func (e *TransactionEnv) Profiler() *profiling.Profiler {
+	return e.profiler
+}
+
 func (e *TransactionEnv) VM() *VirtualMachine {
 	return e.vm
 }
@@ -203,6 +209,8 @@ func (e *TransactionEnv) isAuthorizer(address runtime.Address) bool {
 
 func (e *TransactionEnv) GetValue(owner, key []byte) ([]byte, error) {
 	var valueByteSize int
+	defer e.profiler.EnvironmentCall(string(trace.FVMEnvGetValue))()
+
 	if e.isTraceable() {
 		sp := e.ctx.Tracer.StartSpanFromParent(e.traceSpan, trace.FVMEnvGetValue)
 		defer func() {
@@ -227,6 +235,8 @@ func (e *TransactionEnv) GetValue(owner, key []byte) ([]byte, error) {
 }
 
 func (e *TransactionEnv) SetValue(owner, key, value []byte) error {
+	defer e.profiler.EnvironmentCall(string(trace.FVMEnvSetValue))()
+
 	if e.isTraceable() {
 		sp := e.ctx.Tracer.StartSpanFromParent(e.traceSpan, trace.FVMEnvSetValue)
 		sp.LogFields(
@@ -248,6 +258,8 @@ func (e *TransactionEnv) SetValue(owner, key, value []byte) error {
 }
 
 func (e *TransactionEnv) ValueExists(owner, key []byte) (exists bool, err error) {
+	defer e.profiler.EnvironmentCall(string(trace.FVMEnvValueExists))()
+
 	if e.isTraceable() {
 		sp := e.ctx.Tracer.StartSpanFromParent(e.traceSpan, trace.FVMEnvValueExists)
 		defer sp.Finish()
@@ -271,6 +283,8 @@ func (e *TransactionEnv) AllocateStorageIndex(owner []byte) (atree.StorageIndex,
 }
 
 func (e *TransactionEnv) GetStorageUsed(address common.Address) (value uint64, err error) {
+	defer e.profiler.EnvironmentCall(string(trace.FVMEnvGetStorageUsed))()
+
 	if e.isTraceable() {
 		sp := e.ctx.Tracer.StartSpanFromParent(e.traceSpan, trace.FVMEnvGetStorageUsed)
 		defer sp.Finish()
@@ -285,6 +299,8 @@ func (e *TransactionEnv) GetStorageUsed(address common.Address) (value uint64, e
 }
 
 func (e *TransactionEnv) GetStorageCapacity(address common.Address) (value uint64, err error) {
+	defer e.profiler.EnvironmentCall(string(trace.FVMEnvGetStorageCapacity))()
+
 	if e.isTraceable() {
 		sp := e.ctx.Tracer.StartSpanFromParent(e.traceSpan, trace.FVMEnvGetStorageCapacity)
 		defer sp.Finish()
@@ -313,6 +329,8 @@ func storageMBUFixToBytesUInt(result cadence.Value) uint64 {
 }
 
 func (e *TransactionEnv) GetAccountBalance(address common.Address) (value uint64, err error) {
+	defer e.profiler.EnvironmentCall(string(trace.FVMEnvGetAccountBalance))()
+
 	if e.isTraceable() {
 		sp := e.ctx.Tracer.StartSpanFromParent(e.traceSpan, trace.FVMEnvGetAccountBalance)
 		defer sp.Finish()
@@ -329,6 +347,8 @@ func (e *TransactionEnv) GetAccountBalance(address common.Address) (value uint64
 }
 
 func (e *TransactionEnv) GetAccountAvailableBalance(address common.Address) (value uint64, err error) {
+	defer e.profiler.EnvironmentCall(string(trace.FVMEnvGetAccountBalance))()
+
 	if e.isTraceable() {
 		sp := e.ctx.Tracer.StartSpanFromParent(e.traceSpan, trace.FVMEnvGetAccountBalance)
 		defer sp.Finish()
@@ -350,6 +370,8 @@ func (e *TransactionEnv) ResolveLocation(
 	identifiers []runtime.Identifier,
 	location runtime.Location,
 ) ([]runtime.ResolvedLocation, error) {
+	defer e.profiler.EnvironmentCall(string(trace.FVMEnvResolveLocation))()
+
 	if e.isTraceable() && e.ctx.ExtensiveTracing {
 		sp := e.ctx.Tracer.StartSpanFromParent(e.traceSpan, trace.FVMEnvResolveLocation)
 		defer sp.Finish()
@@ -416,6 +438,8 @@ func (e *TransactionEnv) ResolveLocation(
 }
 
 func (e *TransactionEnv) GetCode(location runtime.Location) ([]byte, error) {
+	defer e.profiler.EnvironmentCall(string(trace.FVMEnvGetCode))()
+
 	if e.isTraceable() {
 		sp := e.ctx.Tracer.StartSpanFromParent(e.traceSpan, trace.FVMEnvGetCode)
 		defer sp.Finish()
@@ -442,6 +466,8 @@ func (e *TransactionEnv) GetCode(location runtime.Location) ([]byte, error) {
 }
 
 func (e *TransactionEnv) GetAccountContractNames(address runtime.Address) ([]string, error) {
+	defer e.profiler.EnvironmentCall(string(trace.FVMEnvGetAccountContractNames))()
+
 	if e.isTraceable() {
 		sp := e.ctx.Tracer.StartSpanFromParent(e.traceSpan, trace.FVMEnvGetAccountContractNames)
 		defer sp.Finish()
@@ -458,6 +484,8 @@ func (e *TransactionEnv) GetAccountContractNames(address runtime.Address) ([]str
 }
 
 func (e *TransactionEnv) GetProgram(location common.Location) (*interpreter.Program, error) {
+	defer e.profiler.EnvironmentCall(string(trace.FVMEnvGetProgram))()
+
 	if e.isTraceable() {
 		sp := e.ctx.Tracer.StartSpanFromParent(e.traceSpan, trace.FVMEnvGetProgram)
 		defer sp.Finish()
@@ -481,6 +509,8 @@ func (e *TransactionEnv) GetProgram(location common.Location) (*interpreter.Prog
 }
 
 func (e *TransactionEnv) SetProgram(location common.Location, program *interpreter.Program) error {
+	defer e.profiler.EnvironmentCall(string(trace.FVMEnvSetProgram))()
+
 	if e.isTraceable() {
 		sp := e.ctx.Tracer.StartSpanFromParent(e.traceSpan, trace.FVMEnvSetProgram)
 		defer sp.Finish()
@@ -494,6 +524,8 @@ func (e *TransactionEnv) SetProgram(location common.Location, program *interpret
 }
 
 func (e *TransactionEnv) ProgramLog(message string) error {
+	defer e.profiler.EnvironmentCall(string(trace.FVMEnvProgramLog))()
+
 	if e.isTraceable() && e.ctx.ExtensiveTracing {
 		sp := e.ctx.Tracer.StartSpanFromParent(e.traceSpan, trace.FVMEnvProgramLog)
 		defer sp.Finish()
@@ -511,6 +543,8 @@ func (e *TransactionEnv) Logs() []string {
 
 func (e *TransactionEnv) EmitEvent(event cadence.Event) error {
 	// only trace when extensive tracing
+	defer e.profiler.EnvironmentCall(string(trace.FVMEnvEmitEvent))()
+
 	if e.isTraceable() && e.ctx.ExtensiveTracing {
 		sp := e.ctx.Tracer.StartSpanFromParent(e.traceSpan, trace.FVMEnvEmitEvent)
 		defer sp.Finish()
@@ -528,6 +562,8 @@ func (e *TransactionEnv) ServiceEvents() []flow.Event {
 }
 
 func (e *TransactionEnv) GenerateUUID() (uint64, error) {
+	defer e.profiler.EnvironmentCall(string(trace.FVMEnvGenerateUUID))()
+
 	if e.isTraceable() && e.ctx.ExtensiveTracing {
 		sp := e.ctx.Tracer.StartSpanFromParent(e.traceSpan, trace.FVMEnvGenerateUUID)
 		defer sp.Finish()
@@ -549,6 +585,7 @@ func (e *TransactionEnv) GetComputationLimit() uint64 {
 }
 
 func (e *TransactionEnv) SetComputationUsed(used uint64) error {
+	e.profiler.ComputationUsed(used)
 	return e.computationHandler.AddUsed(used)
 }
 
@@ -578,6 +615,8 @@ func (e *TransactionEnv) SetAccountFrozen(address common.Address, frozen bool) e
 }
 
 func (e *TransactionEnv) DecodeArgument(b []byte, t cadence.Type) (cadence.Value, error) {
+	defer e.profiler.EnvironmentCall(string(trace.FVMEnvDecodeArgument))()
+
 	if e.isTraceable() && e.ctx.ExtensiveTracing {
 		sp := e.ctx.Tracer.StartSpanFromParent(e.traceSpan, trace.FVMEnvDecodeArgument)
 		defer sp.Finish()
@@ -593,6 +632,8 @@ func (e *TransactionEnv) DecodeArgument(b []byte, t cadence.Type) (cadence.Value
 }
 
 func (e *TransactionEnv) Hash(data []byte, tag string, hashAlgorithm runtime.HashAlgorithm) ([]byte, error) {
+	defer e.profiler.EnvironmentCall(string(trace.FVMEnvHash))()
+
 	if e.isTraceable() {
 		sp := e.ctx.Tracer.StartSpanFromParent(e.traceSpan, trace.FVMEnvHash)
 		defer sp.Finish()
@@ -610,6 +651,8 @@ func (e *TransactionEnv) VerifySignature(
 	signatureAlgorithm runtime.SignatureAlgorithm,
 	hashAlgorithm runtime.HashAlgorithm,
 ) (bool, error) {
+	defer e.profiler.EnvironmentCall(string(trace.FVMEnvVerifySignature))()
+
 	if e.isTraceable() {
 		sp := e.ctx.Tracer.StartSpanFromParent(e.traceSpan, trace.FVMEnvVerifySignature)
 		defer sp.Finish()
@@ -640,6 +683,8 @@ func (e *TransactionEnv) ValidatePublicKey(pk *runtime.PublicKey) error {
 
 // GetCurrentBlockHeight returns the current block height.
 func (e *TransactionEnv) GetCurrentBlockHeight() (uint64, error) {
+	defer e.profiler.EnvironmentCall(string(trace.FVMEnvGetCurrentBlockHeight))()
+
 	if e.isTraceable() && e.ctx.ExtensiveTracing {
 		sp := e.ctx.Tracer.StartSpanFromParent(e.traceSpan, trace.FVMEnvGetCurrentBlockHeight)
 		defer sp.Finish()
@@ -654,6 +699,8 @@ func (e *TransactionEnv) GetCurrentBlockHeight() (uint64, error) {
 // UnsafeRandom returns a random uint64, where the process of random number derivation is not cryptographically
 // secure.
 func (e *TransactionEnv) UnsafeRandom() (uint64, error) {
+	defer e.profiler.EnvironmentCall(string(trace.FVMEnvUnsafeRandom))()
+
 	if e.isTraceable() && e.ctx.ExtensiveTracing {
 		sp := e.ctx.Tracer.StartSpanFromParent(e.traceSpan, trace.FVMEnvUnsafeRandom)
 		defer sp.Finish()
@@ -671,6 +718,8 @@ func (e *TransactionEnv) UnsafeRandom() (uint64, error) {
 
 // GetBlockAtHeight returns the block at the given height.
 func (e *TransactionEnv) GetBlockAtHeight(height uint64) (runtime.Block, bool, error) {
+	defer e.profiler.EnvironmentCall(string(trace.FVMEnvGetBlockAtHeight))()
+
 	if e.isTraceable() {
 		sp := e.ctx.Tracer.StartSpanFromParent(e.traceSpan, trace.FVMEnvGetBlockAtHeight)
 		defer sp.Finish()
@@ -697,6 +746,8 @@ func (e *TransactionEnv) GetBlockAtHeight(height uint64) (runtime.Block, bool, e
 
 func (e *TransactionEnv) CreateAccount(payer runtime.Address) (address runtime.Address, err error) {
 
+	defer e.profiler.EnvironmentCall(string(trace.FVMEnvCreateAccount))()
+
 	if e.isTraceable() {
 		sp := e.ctx.Tracer.StartSpanFromParent(e.traceSpan, trace.FVMEnvCreateAccount)
 		defer sp.Finish()
@@ -733,6 +784,8 @@ func (e *TransactionEnv) CreateAccount(payer runtime.Address) (address runtime.A
 // This function returns an error if the specified account does not exist or
 // if the key insertion fails.
 func (e *TransactionEnv) AddEncodedAccountKey(address runtime.Address, publicKey []byte) error {
+	defer e.profiler.EnvironmentCall(string(trace.FVMEnvAddAccountKey))()
+
 	if e.isTraceable() {
 		sp := e.ctx.Tracer.StartSpanFromParent(e.traceSpan, trace.FVMEnvAddAccountKey)
 		defer sp.Finish()
@@ -758,6 +811,8 @@ func (e *TransactionEnv) AddEncodedAccountKey(address runtime.Address, publicKey
 // This function returns an error if the specified account does not exist, the
 // provided key is invalid, or if key revoking fails.
 func (e *TransactionEnv) RevokeEncodedAccountKey(address runtime.Address, index int) (publicKey []byte, err error) {
+	defer e.profiler.EnvironmentCall(string(trace.FVMEnvRemoveAccountKey))()
+
 	if e.isTraceable() {
 		sp := e.ctx.Tracer.StartSpanFromParent(e.traceSpan, trace.FVMEnvRemoveAccountKey)
 		defer sp.Finish()
@@ -789,6 +844,8 @@ func (e *TransactionEnv) AddAccountKey(
 	*runtime.AccountKey,
 	error,
 ) {
+	defer e.profiler.EnvironmentCall(string(trace.FVMEnvAddAccountKey))()
+
 	if e.isTraceable() {
 		sp := e.ctx.Tracer.StartSpanFromParent(e.traceSpan, trace.FVMEnvAddAccountKey)
 		defer sp.Finish()
@@ -808,6 +865,8 @@ func (e *TransactionEnv) AddAccountKey(
 // An error is returned if the specified account does not exist, the provided index is not valid,
 // or if the key retrieval fails.
 func (e *TransactionEnv) GetAccountKey(address runtime.Address, keyIndex int) (*runtime.AccountKey, error) {
+	defer e.profiler.EnvironmentCall(string(trace.FVMEnvGetAccountKey))()
+
 	if e.isTraceable() {
 		sp := e.ctx.Tracer.StartSpanFromParent(e.traceSpan, trace.FVMEnvGetAccountKey)
 		defer sp.Finish()
@@ -827,6 +886,8 @@ func (e *TransactionEnv) GetAccountKey(address runtime.Address, keyIndex int) (*
 // An error is returned if the specified account does not exist, the provided index is not valid,
 // or if the key revoking fails.
 func (e *TransactionEnv) RevokeAccountKey(address runtime.Address, keyIndex int) (*runtime.AccountKey, error) {
+	defer e.profiler.EnvironmentCall(string(trace.FVMEnvRemoveAccountKey))()
+
 	if e.isTraceable() {
 		sp := e.ctx.Tracer.StartSpanFromParent(e.traceSpan, trace.FVMEnvRemoveAccountKey)
 		defer sp.Finish()
@@ -836,6 +897,8 @@ func (e *TransactionEnv) RevokeAccountKey(address runtime.Address, keyIndex int)
 }
 
 func (e *TransactionEnv) UpdateAccountContractCode(address runtime.Address, name string, code []byte) (err error) {
+	defer e.profiler.EnvironmentCall(string(trace.FVMEnvUpdateAccountContractCode))()
+
 	if e.isTraceable() {
 		sp := e.ctx.Tracer.StartSpanFromParent(e.traceSpan, trace.FVMEnvUpdateAccountContractCode)
 		defer sp.Finish()
@@ -855,6 +918,8 @@ func (e *TransactionEnv) UpdateAccountContractCode(address runtime.Address, name
 }
 
 func (e *TransactionEnv) GetAccountContractCode(address runtime.Address, name string) (code []byte, err error) {
+	defer e.profiler.EnvironmentCall(string(trace.FVMEnvGetAccountContractCode))()
+
 	if e.isTraceable() {
 		sp := e.ctx.Tracer.StartSpanFromParent(e.traceSpan, trace.FVMEnvGetAccountContractCode)
 		defer sp.Finish()
@@ -872,6 +937,8 @@ func (e *TransactionEnv) GetAccountContractCode(address runtime.Address, name st
 }
 
 func (e *TransactionEnv) RemoveAccountContractCode(address runtime.Address, name string) (err error) {
+	defer e.profiler.EnvironmentCall(string(trace.FVMEnvRemoveAccountContractCode))()
+
 	if e.isTraceable() {
 		sp := e.ctx.Tracer.StartSpanFromParent(e.traceSpan, trace.FVMEnvRemoveAccountContractCode)
 		defer sp.Finish()
@@ -891,6 +958,8 @@ func (e *TransactionEnv) RemoveAccountContractCode(address runtime.Address, name
 }
 
 func (e *TransactionEnv) GetSigningAccounts() ([]runtime.Address, error) {
+	defer e.profiler.EnvironmentCall(string(trace.FVMEnvGetSigningAccounts))()
+
 	if e.isTraceable() && e.ctx.ExtensiveTracing {
 		sp := e.ctx.Tracer.StartSpanFromParent(e.traceSpan, trace.FVMEnvGetSigningAccounts)
 		defer sp.Finish()
@@ -915,6 +984,8 @@ func (e *TransactionEnv) ImplementationDebugLog(message string) error {
 }
 
 func (e *TransactionEnv) RecordTrace(operation string, location common.Location, duration time.Duration, logs []opentracing.LogRecord) {
+	e.profiler.RecordTrace(operation, location, duration)
+
 	if !e.isTraceable() {
 		return
 	}
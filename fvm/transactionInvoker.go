@@ -17,6 +17,7 @@ import (
 
 	"github.com/onflow/flow-go/fvm/errors"
 	"github.com/onflow/flow-go/fvm/extralog"
+	"github.com/onflow/flow-go/fvm/profiling"
 	"github.com/onflow/flow-go/fvm/programs"
 	"github.com/onflow/flow-go/fvm/state"
 	"github.com/onflow/flow-go/model/flow"
@@ -56,6 +57,16 @@ func (i *TransactionInvoker) Process(
 		blockHeight = ctx.BlockHeader.Height
 	}
 
+	var profiler *profiling.Profiler
+	if ctx.ProfilingEnabled {
+		// a single profiler is shared by all environments of the transaction,
+		// so that retries and fee deduction after failures are profiled too
+		profiler = profiling.NewProfiler()
+		defer func() {
+			proc.Profile = profiler.Profile()
+		}()
+	}
+
 	var env *TransactionEnv
 	var txError error
 	retry := false
@@ -64,6 +75,7 @@ func (i *TransactionInvoker) Process(
 	parentState := sth.State()
 	childState := sth.NewChild()
 	env = NewTransactionEnvironment(*ctx, vm, sth, programs, proc.Transaction, proc.TxIndex, span)
+	env.profiler = profiler
 	predeclaredValues := valueDeclarations(ctx, env)
 
 	defer func() {
@@ -116,10 +128,12 @@ func (i *TransactionInvoker) Process(
 
 			// reset env
 			env = NewTransactionEnvironment(*ctx, vm, sth, programs, proc.Transaction, proc.TxIndex, span)
+			env.profiler = profiler
 		}
 
 		location := common.TransactionLocation(proc.ID[:])
 
+		endInvocation := profiler.StartInvocation("transaction", location)
 		err := vm.Runtime.ExecuteTransaction(
 			runtime.Script{
 				Source:    proc.Transaction.Script,
@@ -131,6 +145,7 @@ func (i *TransactionInvoker) Process(
 				PredeclaredValues: predeclaredValues,
 			},
 		)
+		endInvocation()
 		if err != nil {
 			var interactionLimiExceededErr *errors.LedgerIntractionLimitExceededError
 			if errors.As(err, &interactionLimiExceededErr) {
@@ -198,6 +213,7 @@ func (i *TransactionInvoker) Process(
 
 		// reset env
 		env = NewTransactionEnvironment(*ctx, vm, sth, programs, proc.Transaction, proc.TxIndex, span)
+		env.profiler = profiler
 
 		// try to deduct fees again, to get the fee deduction events
 		feesError = i.deductTransactionFees(env, proc)
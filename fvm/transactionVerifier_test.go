@@ -1,12 +1,16 @@
 package fvm_test
 
 import (
+	"crypto/rand"
 	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/onflow/flow-go/crypto"
+	"github.com/onflow/flow-go/crypto/hash"
 	"github.com/onflow/flow-go/fvm"
+	fvmErrors "github.com/onflow/flow-go/fvm/errors"
 	"github.com/onflow/flow-go/fvm/programs"
 	"github.com/onflow/flow-go/fvm/state"
 	"github.com/onflow/flow-go/fvm/utils"
@@ -66,4 +70,90 @@ func TestTransactionVerification(t *testing.T) {
 		require.Error(t, err)
 		require.True(t, strings.Contains(err.Error(), "duplicate signatures are provided for the same key"))
 	})
+
+	t.Run("Ed25519 signed envelope and payload", func(t *testing.T) {
+		payer, payerKey := ed25519Account(t, accounts, "5678")
+		authorizer, authorizerKey := ed25519Account(t, accounts, "9abc")
+
+		tx := flow.TransactionBody{}
+		tx.SetProposalKey(authorizer, 0, 0)
+		tx.SetPayer(payer)
+		tx.AddAuthorizer(authorizer)
+
+		err := tx.SignPayload(authorizer, 0, authorizerKey.PrivateKey, hash.NewSHA3_256())
+		require.NoError(t, err)
+		err = tx.SignEnvelope(payer, 0, payerKey.PrivateKey, hash.NewSHA3_256())
+		require.NoError(t, err)
+		proc := fvm.Transaction(&tx, 0)
+
+		txVerifier := fvm.NewTransactionSignatureVerifier(1000)
+		err = txVerifier.Process(nil, &fvm.Context{}, proc, sth, programs.NewEmptyPrograms())
+		require.NoError(t, err)
+	})
+
+	t.Run("Ed25519 signed envelope with a different key", func(t *testing.T) {
+		payer, _ := ed25519Account(t, accounts, "def0")
+		_, otherKey := ed25519Account(t, accounts, "def1")
+
+		tx := flow.TransactionBody{}
+		tx.SetProposalKey(payer, 0, 0)
+		tx.SetPayer(payer)
+
+		err := tx.SignEnvelope(payer, 0, otherKey.PrivateKey, hash.NewSHA3_256())
+		require.NoError(t, err)
+		proc := fvm.Transaction(&tx, 0)
+
+		txVerifier := fvm.NewTransactionSignatureVerifier(1000)
+		err = txVerifier.Process(nil, &fvm.Context{}, proc, sth, programs.NewEmptyPrograms())
+		require.Error(t, err)
+
+		var envelopeError *fvmErrors.InvalidEnvelopeSignatureError
+		require.ErrorAs(t, err, &envelopeError)
+	})
+
+	t.Run("Ed25519 signed payload with a different hashing algorithm", func(t *testing.T) {
+		payer, payerKey := ed25519Account(t, accounts, "def2")
+		authorizer, authorizerKey := ed25519Account(t, accounts, "def3")
+
+		tx := flow.TransactionBody{}
+		tx.SetProposalKey(payer, 0, 0)
+		tx.SetPayer(payer)
+		tx.AddAuthorizer(authorizer)
+
+		// the account key uses SHA3-256
+		err := tx.SignPayload(authorizer, 0, authorizerKey.PrivateKey, hash.NewSHA2_256())
+		require.NoError(t, err)
+		err = tx.SignEnvelope(payer, 0, payerKey.PrivateKey, hash.NewSHA3_256())
+		require.NoError(t, err)
+		proc := fvm.Transaction(&tx, 0)
+
+		txVerifier := fvm.NewTransactionSignatureVerifier(1000)
+		err = txVerifier.Process(nil, &fvm.Context{}, proc, sth, programs.NewEmptyPrograms())
+		require.Error(t, err)
+
+		var payloadError *fvmErrors.InvalidPayloadSignatureError
+		require.ErrorAs(t, err, &payloadError)
+	})
+}
+
+// ed25519Account creates an account with a single Ed25519 key of full weight.
+func ed25519Account(t *testing.T, accounts *state.StatefulAccounts, address string) (flow.Address, flow.AccountPrivateKey) {
+	seed := make([]byte, crypto.KeyGenSeedMinLenEd25519)
+	_, err := rand.Read(seed)
+	require.NoError(t, err)
+
+	sk, err := crypto.GeneratePrivateKey(crypto.Ed25519, seed)
+	require.NoError(t, err)
+
+	privateKey := flow.AccountPrivateKey{
+		PrivateKey: sk,
+		SignAlgo:   crypto.Ed25519,
+		HashAlgo:   hash.SHA3_256,
+	}
+
+	addr := flow.HexToAddress(address)
+	err = accounts.Create([]flow.AccountPublicKey{privateKey.PublicKey(1000)}, addr)
+	require.NoError(t, err)
+
+	return addr, privateKey
 }
@@ -15,6 +15,7 @@ import (
 	sdktemplates "github.com/onflow/flow-go-sdk/templates"
 	"github.com/onflow/flow-go-sdk/test"
 
+	flowhotstuff "github.com/onflow/flow-go/consensus/hotstuff"
 	hotstuff "github.com/onflow/flow-go/consensus/hotstuff/mocks"
 	hotstuffmodel "github.com/onflow/flow-go/consensus/hotstuff/model"
 	hotstuffver "github.com/onflow/flow-go/consensus/hotstuff/verification"
@@ -97,7 +98,8 @@ func (s *Suite) TestEpochQuorumCertificate() {
 		state.On("Final").Return(snapshot)
 
 		// create QC voter object to be used for voting for the root QC contract
-		voter := epochs.NewRootQCVoter(zerolog.Logger{}, local, hotSigner, state, []module.QCContractClient{client})
+		newSigner := func(flow.ChainID) flowhotstuff.Signer { return hotSigner }
+		voter := epochs.NewRootQCVoter(zerolog.Logger{}, local, newSigner, state, []module.QCContractClient{client})
 
 		// create voter resource
 		s.CreateVoterResource(address, nodeID, stakingPrivKey.PublicKey(), signer)
@@ -0,0 +1,77 @@
+package simulator
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// DefaultPhaseLength is the default number of views of each of the three DKG phases.
+	DefaultPhaseLength = uint64(30)
+
+	// DefaultViewDuration is the default wall-clock time the simulator waits between
+	// two views, giving the nodes time to process the delivered messages.
+	DefaultViewDuration = 100 * time.Millisecond
+)
+
+// Config configures the node set, the epoch schedule, and the injected faults
+// of an epoch transition simulation.
+type Config struct {
+	ConsensusNodes       int           // number of consensus nodes taking part in the DKG
+	Clusters             int           // number of collection clusters
+	CollectorsPerCluster int           // number of collection nodes in each cluster
+	PhaseLength          uint64        // number of views of each DKG phase
+	ViewDuration         time.Duration // wall-clock time between two views
+	Faults               Faults
+}
+
+// Faults describes the failures injected into an epoch transition simulation.
+type Faults struct {
+	// DKGMessageDropRate is the probability, in [0,1], with which each private
+	// DKG message exchanged between consensus nodes is dropped by the network.
+	DKGMessageDropRate float64
+	// OfflineConsensusNodes is the number of consensus nodes that are registered
+	// as DKG participants but never take part in the DKG.
+	OfflineConsensusNodes int
+	// MissingQCVotes is the number of collection nodes in each cluster that do
+	// not submit their vote for the cluster root QC.
+	MissingQCVotes int
+	// Seed seeds the pseudo-random source used to drop DKG messages, so that a
+	// faulty run can be reproduced.
+	Seed int64
+}
+
+// DefaultConfig returns a configuration for a fault-free simulation with the given
+// number of consensus nodes and collection clusters.
+func DefaultConfig(consensusNodes, clusters, collectorsPerCluster int) Config {
+	return Config{
+		ConsensusNodes:       consensusNodes,
+		Clusters:             clusters,
+		CollectorsPerCluster: collectorsPerCluster,
+		PhaseLength:          DefaultPhaseLength,
+		ViewDuration:         DefaultViewDuration,
+	}
+}
+
+// validate checks that the configuration describes a network the simulator can run.
+func (c Config) validate() error {
+	if c.ConsensusNodes < 1 {
+		return fmt.Errorf("need at least one consensus node, got %d", c.ConsensusNodes)
+	}
+	if c.Clusters < 1 || c.CollectorsPerCluster < 1 {
+		return fmt.Errorf("need at least one collection cluster with one node, got %d clusters of %d nodes", c.Clusters, c.CollectorsPerCluster)
+	}
+	if c.PhaseLength == 0 {
+		return fmt.Errorf("phase length must be positive")
+	}
+	if c.Faults.DKGMessageDropRate < 0 || c.Faults.DKGMessageDropRate > 1 {
+		return fmt.Errorf("dkg message drop rate must be in [0,1], got %f", c.Faults.DKGMessageDropRate)
+	}
+	if c.Faults.OfflineConsensusNodes < 0 || c.Faults.OfflineConsensusNodes > c.ConsensusNodes {
+		return fmt.Errorf("invalid number of offline consensus nodes (%d of %d)", c.Faults.OfflineConsensusNodes, c.ConsensusNodes)
+	}
+	if c.Faults.MissingQCVotes < 0 || c.Faults.MissingQCVotes > c.CollectorsPerCluster {
+		return fmt.Errorf("invalid number of missing qc votes (%d of %d per cluster)", c.Faults.MissingQCVotes, c.CollectorsPerCluster)
+	}
+	return nil
+}
@@ -0,0 +1,339 @@
+package simulator
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+	"github.com/onflow/flow-core-contracts/lib/go/contracts"
+	"github.com/onflow/flow-core-contracts/lib/go/templates"
+
+	sdk "github.com/onflow/flow-go-sdk"
+	sdkcrypto "github.com/onflow/flow-go-sdk/crypto"
+	sdktemplates "github.com/onflow/flow-go-sdk/templates"
+	"github.com/onflow/flow-go-sdk/test"
+
+	"github.com/onflow/flow-go/crypto"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// deployContracts deploys the FlowDKG and FlowClusterQC contracts to the
+// emulator, and publishes the resources used by participants to interact with them.
+func (s *Simulator) deployContracts() {
+	s.dkgAccount = s.deployContract("FlowDKG", contracts.FlowDKG())
+	s.qcAccount = s.deployContract("FlowClusterQC", contracts.FlowQC())
+
+	s.env = templates.Environment{
+		DkgAddress:               s.dkgAccount.address.Hex(),
+		QuorumCertificateAddress: s.qcAccount.address.Hex(),
+	}
+
+	s.submitAsAdmin(templates.GeneratePublishDKGParticipantScript(s.env), s.dkgAccount)
+	s.submitAsAdmin(templates.GeneratePublishVoterScript(s.env), s.qcAccount)
+}
+
+// deployContract creates a new account holding the given contract.
+func (s *Simulator) deployContract(name string, code []byte) *account {
+	key, signer := test.AccountKeyGenerator().NewWithSigner()
+	address, err := s.blockchain.CreateAccount([]*sdk.AccountKey{key}, []sdktemplates.Contract{
+		{
+			Name:   name,
+			Source: string(code),
+		},
+	})
+	require.NoError(s.t, err)
+
+	return &account{
+		address: address,
+		key:     key,
+		signer:  signer,
+	}
+}
+
+// createAccount creates a new account for a node.
+func (s *Simulator) createAccount() *account {
+	key, signer := test.AccountKeyGenerator().NewWithSigner()
+	address, err := s.blockchain.CreateAccount([]*sdk.AccountKey{key}, []sdktemplates.Contract{})
+	require.NoError(s.t, err)
+
+	return &account{
+		address: address,
+		key:     key,
+		signer:  signer,
+	}
+}
+
+// startDKG starts the DKG in the FlowDKG contract with all consensus nodes,
+// including the offline ones, as participants.
+func (s *Simulator) startDKG() {
+	participantIDs := make([]cadence.Value, 0, len(s.consensusNodes))
+	for _, node := range s.consensusNodes {
+		participantID, err := cadence.NewString(node.participantID())
+		require.NoError(s.t, err)
+		participantIDs = append(participantIDs, participantID)
+	}
+
+	s.submitAsAdmin(templates.GenerateStartDKGScript(s.env), s.dkgAccount, cadence.NewArray(participantIDs))
+}
+
+// claimDKGParticipant creates the DKG participant resource of a consensus node.
+func (s *Simulator) claimDKGParticipant(node *consensusNode) {
+	publicKey, err := cadence.NewString(node.account.key.PublicKey.String())
+	require.NoError(s.t, err)
+
+	tx := sdk.NewTransaction().
+		SetScript(templates.GenerateCreateDKGParticipantScript(s.env)).
+		SetGasLimit(9999).
+		SetProposalKey(
+			s.blockchain.ServiceKey().Address,
+			s.blockchain.ServiceKey().Index,
+			s.blockchain.ServiceKey().SequenceNumber,
+		).
+		SetPayer(node.account.address).
+		AddAuthorizer(node.account.address)
+	require.NoError(s.t, tx.AddArgument(cadence.NewAddress(s.dkgAccount.address)))
+	require.NoError(s.t, tx.AddArgument(publicKey))
+
+	s.prepareAndSubmit(tx,
+		[]sdk.Address{node.account.address, s.blockchain.ServiceKey().Address, s.dkgAccount.address},
+		[]sdkcrypto.Signer{node.account.signer, s.blockchain.ServiceKey().Signer(), s.dkgAccount.signer},
+	)
+}
+
+// startVoting starts the root QC voting in the FlowClusterQC contract for the
+// clustering of the next epoch.
+func (s *Simulator) startVoting() {
+	clusterIndices := make([]cadence.Value, 0, len(s.clustering))
+	clusterNodeIDs := make([]cadence.Value, 0, len(s.clustering))
+	clusterNodeWeights := make([]cadence.Value, 0, len(s.clustering))
+	for index, cluster := range s.clustering {
+		nodeIDs := make([]cadence.Value, 0, len(cluster))
+		nodeWeights := make([]cadence.Value, 0, len(cluster))
+		for _, node := range cluster {
+			nodeID, err := cadence.NewString(node.NodeID.String())
+			require.NoError(s.t, err)
+			nodeIDs = append(nodeIDs, nodeID)
+			nodeWeights = append(nodeWeights, cadence.NewUInt64(node.Weight))
+		}
+		clusterIndices = append(clusterIndices, cadence.NewUInt16(uint16(index)))
+		clusterNodeIDs = append(clusterNodeIDs, cadence.NewArray(nodeIDs))
+		clusterNodeWeights = append(clusterNodeWeights, cadence.NewArray(nodeWeights))
+	}
+
+	s.submitAsAdmin(templates.GenerateStartVotingScript(s.env), s.qcAccount,
+		cadence.NewArray(clusterIndices),
+		cadence.NewArray(clusterNodeIDs),
+		cadence.NewArray(clusterNodeWeights),
+	)
+}
+
+// createVoter creates the root QC voter resource of a collection node.
+func (s *Simulator) createVoter(node *collectionNode) {
+	nodeID, err := cadence.NewString(node.identity.NodeID.String())
+	require.NoError(s.t, err)
+	stakingKey, err := cadence.NewString(hex.EncodeToString(node.stakingKey.PublicKey().Encode()))
+	require.NoError(s.t, err)
+
+	tx := sdk.NewTransaction().
+		SetScript(templates.GenerateCreateVoterScript(s.env)).
+		SetGasLimit(9999).
+		SetProposalKey(
+			s.blockchain.ServiceKey().Address,
+			s.blockchain.ServiceKey().Index,
+			s.blockchain.ServiceKey().SequenceNumber,
+		).
+		SetPayer(s.blockchain.ServiceKey().Address).
+		AddAuthorizer(node.account.address)
+	require.NoError(s.t, tx.AddArgument(cadence.NewAddress(s.qcAccount.address)))
+	require.NoError(s.t, tx.AddArgument(nodeID))
+	require.NoError(s.t, tx.AddArgument(stakingKey))
+
+	s.prepareAndSubmit(tx,
+		[]sdk.Address{s.blockchain.ServiceKey().Address, node.account.address},
+		[]sdkcrypto.Signer{s.blockchain.ServiceKey().Signer(), node.account.signer},
+	)
+}
+
+// dkgResult returns the DKG result the FlowDKG contract agreed on, or nil if no
+// result reached a majority of the final submissions.
+func (s *Simulator) dkgResult() (groupKey crypto.PublicKey, participantKeys []crypto.PublicKey, completed bool, err error) {
+	script := fmt.Sprintf(`
+	import FlowDKG from 0x%s
+
+	pub fun main(): [String?]? {
+		return FlowDKG.dkgCompleted()
+	} `,
+		s.env.DkgAddress,
+	)
+
+	value, ok := s.executeScript([]byte(script)).(cadence.Optional)
+	if !ok || value.Value == nil {
+		return nil, nil, false, nil
+	}
+	submission, ok := value.Value.(cadence.Array)
+	if !ok || len(submission.Values) == 0 {
+		return nil, nil, true, fmt.Errorf("unexpected dkg result: %v", value)
+	}
+
+	keys := make([]crypto.PublicKey, 0, len(submission.Values))
+	for i, item := range submission.Values {
+		optional, ok := item.(cadence.Optional)
+		if !ok || optional.Value == nil {
+			return nil, nil, true, fmt.Errorf("missing dkg key at index %d", i)
+		}
+		keyHex, ok := optional.Value.(cadence.String)
+		if !ok {
+			return nil, nil, true, fmt.Errorf("invalid dkg key at index %d: %v", i, optional.Value)
+		}
+		keyBytes, err := hex.DecodeString(string(keyHex))
+		if err != nil {
+			return nil, nil, true, fmt.Errorf("could not decode dkg key at index %d: %w", i, err)
+		}
+		key, err := crypto.DecodePublicKey(crypto.BLSBLS12381, keyBytes)
+		if err != nil {
+			return nil, nil, true, fmt.Errorf("could not decode dkg key at index %d: %w", i, err)
+		}
+		keys = append(keys, key)
+	}
+
+	// the first key is the group public key
+	return keys[0], keys[1:], true, nil
+}
+
+// clusterQC returns the root QC of the cluster with the given index, as it
+// would be included in the EpochCommit event, or nil if the cluster did not
+// reach quorum.
+func (s *Simulator) clusterQC(index uint) (*flow.ClusterQCVoteData, error) {
+	args := [][]byte{jsoncdc.MustEncode(cadence.NewUInt16(uint16(index)))}
+
+	complete, ok := s.executeScript(templates.GenerateGetClusterCompleteScript(s.env), args...).(cadence.Bool)
+	if !ok {
+		return nil, fmt.Errorf("unexpected cluster completion status")
+	}
+	if !complete {
+		return nil, nil
+	}
+
+	qc, ok := s.executeScript(templates.GenerateGenerateQuorumCertificateScript(s.env), args...).(cadence.Struct)
+	if !ok || len(qc.Fields) < 4 {
+		return nil, fmt.Errorf("unexpected cluster qc: %v", qc)
+	}
+	rawVotes, ok := qc.Fields[1].(cadence.Array)
+	if !ok {
+		return nil, fmt.Errorf("unexpected cluster qc votes: %v", qc.Fields[1])
+	}
+	rawVoterIDs, ok := qc.Fields[3].(cadence.Array)
+	if !ok {
+		return nil, fmt.Errorf("unexpected cluster qc voter ids: %v", qc.Fields[3])
+	}
+
+	signatures := make([]crypto.Signature, 0, len(rawVotes.Values))
+	for _, rawVote := range rawVotes.Values {
+		sig, err := hex.DecodeString(string(rawVote.(cadence.String)))
+		if err != nil {
+			return nil, fmt.Errorf("could not decode vote: %w", err)
+		}
+		signatures = append(signatures, sig)
+	}
+	voterIDs := make([]flow.Identifier, 0, len(rawVoterIDs.Values))
+	for _, rawVoterID := range rawVoterIDs.Values {
+		voterID, err := flow.HexStringToIdentifier(string(rawVoterID.(cadence.String)))
+		if err != nil {
+			return nil, fmt.Errorf("could not decode voter id: %w", err)
+		}
+		voterIDs = append(voterIDs, voterID)
+	}
+
+	// the service event conversion aggregates the votes in the same way
+	aggregated, err := crypto.AggregateBLSSignatures(signatures)
+	if err != nil {
+		return nil, fmt.Errorf("could not aggregate votes: %w", err)
+	}
+
+	return &flow.ClusterQCVoteData{
+		SigData:  aggregated,
+		VoterIDs: voterIDs,
+	}, nil
+}
+
+// sendDummyTx submits a transaction from the service account, forcing the
+// emulator to create and finalize a new block.
+func (s *Simulator) sendDummyTx() (*flow.Block, error) {
+	// we are using an account-creation transaction but it doesnt matter; we
+	// could be using anything other transaction
+	tx := sdktemplates.CreateAccount(
+		[]*sdk.AccountKey{test.AccountKeyGenerator().New()},
+		[]sdktemplates.Contract{},
+		s.blockchain.ServiceKey().Address).
+		SetProposalKey(
+			s.blockchain.ServiceKey().Address,
+			s.blockchain.ServiceKey().Index,
+			s.blockchain.ServiceKey().SequenceNumber).
+		SetPayer(s.blockchain.ServiceKey().Address)
+
+	latest, err := s.adminClient.GetLatestBlock(nil, true)
+	if err != nil {
+		return nil, fmt.Errorf("could not get latest block: %w", err)
+	}
+	tx.SetReferenceBlockID(latest.ID)
+	err = tx.SignEnvelope(s.blockchain.ServiceKey().Address, 0, s.blockchain.ServiceKey().Signer())
+	if err != nil {
+		return nil, fmt.Errorf("could not sign transaction: %w", err)
+	}
+
+	return s.adminClient.Submit(tx)
+}
+
+// submitAsAdmin submits a transaction authorized by the account holding the
+// admin resource of an epoch smart contract.
+func (s *Simulator) submitAsAdmin(script []byte, admin *account, args ...cadence.Value) {
+	tx := sdk.NewTransaction().
+		SetScript(script).
+		SetGasLimit(9999).
+		SetProposalKey(
+			s.blockchain.ServiceKey().Address,
+			s.blockchain.ServiceKey().Index,
+			s.blockchain.ServiceKey().SequenceNumber).
+		SetPayer(s.blockchain.ServiceKey().Address).
+		AddAuthorizer(admin.address)
+	for _, arg := range args {
+		require.NoError(s.t, tx.AddArgument(arg))
+	}
+
+	s.prepareAndSubmit(tx,
+		[]sdk.Address{s.blockchain.ServiceKey().Address, admin.address},
+		[]sdkcrypto.Signer{s.blockchain.ServiceKey().Signer(), admin.signer},
+	)
+}
+
+// prepareAndSubmit adds a block reference and signs a transaction before
+// submitting it via the admin emulator client.
+func (s *Simulator) prepareAndSubmit(tx *sdk.Transaction, signerAddresses []sdk.Address, signers []sdkcrypto.Signer) {
+	latest, err := s.adminClient.GetLatestBlock(nil, true)
+	require.NoError(s.t, err)
+	tx.SetReferenceBlockID(latest.ID)
+
+	// sign transaction with each signer
+	for i := len(signerAddresses) - 1; i >= 0; i-- {
+		if i == 0 {
+			err = tx.SignEnvelope(signerAddresses[i], 0, signers[i])
+		} else {
+			err = tx.SignPayload(signerAddresses[i], 0, signers[i])
+		}
+		require.NoError(s.t, err)
+	}
+
+	_, err = s.adminClient.Submit(tx)
+	require.NoError(s.t, err)
+}
+
+// executeScript runs a cadence script on the emulator blockchain.
+func (s *Simulator) executeScript(script []byte, args ...[]byte) cadence.Value {
+	result, err := s.blockchain.ExecuteScript(script, args)
+	require.NoError(s.t, err)
+	require.True(s.t, result.Succeeded(), "script failed: %v", result.Error)
+	return result.Value
+}
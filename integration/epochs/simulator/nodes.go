@@ -0,0 +1,62 @@
+package simulator
+
+import (
+	sdk "github.com/onflow/flow-go-sdk"
+	sdkcrypto "github.com/onflow/flow-go-sdk/crypto"
+
+	"github.com/onflow/flow-go/crypto"
+	dkgeng "github.com/onflow/flow-go/engine/consensus/dkg"
+	testmock "github.com/onflow/flow-go/engine/testutil/mock"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/epochs"
+	"github.com/onflow/flow-go/storage"
+)
+
+// account is a Flow account on the emulated chain, used by a node to interact
+// with the epoch smart contracts.
+type account struct {
+	address sdk.Address
+	key     *sdk.AccountKey
+	signer  sdkcrypto.Signer
+}
+
+// consensusNode is an in-process consensus node running only the engines
+// relevant to the DKG, ie. the MessagingEngine and the ReactorEngine.
+type consensusNode struct {
+	testmock.GenericNode
+	identity        *flow.Identity
+	account         *account
+	online          bool
+	dkgState        storage.DKGState
+	messagingEngine *dkgeng.MessagingEngine
+	reactorEngine   *dkgeng.ReactorEngine
+}
+
+// participantID is the identifier under which the node is registered in the
+// FlowDKG contract.
+func (n *consensusNode) participantID() string {
+	return n.account.key.PublicKey.String()
+}
+
+func (n *consensusNode) ready() {
+	<-n.messagingEngine.Ready()
+	<-n.reactorEngine.Ready()
+}
+
+func (n *consensusNode) done() {
+	<-n.messagingEngine.Done()
+	<-n.reactorEngine.Done()
+	// close database otherwise hitting "too many file open"
+	_ = n.PublicDB.Close()
+	_ = n.SecretsDB.Close()
+}
+
+// collectionNode is an in-process collection node running only the root QC
+// voter of its cluster.
+type collectionNode struct {
+	identity   *flow.Identity
+	stakingKey crypto.PrivateKey
+	account    *account
+	voter      *epochs.RootQCVoter
+	votes      bool // whether the node submits its root QC vote
+}
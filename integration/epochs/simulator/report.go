@@ -0,0 +1,87 @@
+package simulator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// ClusterReport summarizes the root QC voting of a single collection cluster.
+type ClusterReport struct {
+	Index    uint  // index of the cluster in the clustering of the next epoch
+	Size     int   // number of collection nodes in the cluster
+	Voted    int   // number of collection nodes that submitted their vote
+	Complete bool  // whether the ClusterQC contract reached quorum for the cluster
+	Err      error // reason the cluster QC could not be built, if any
+	Voters   []flow.Identifier
+}
+
+// Report is the outcome of an epoch transition simulation.
+type Report struct {
+	// Setup is the EpochSetup event for the next epoch emitted at the start of the setup phase.
+	Setup *flow.EpochSetup
+	// SetupErr is the reason the protocol state rejects Setup, if any.
+	SetupErr error
+
+	// DKGCompleted is true if the FlowDKG contract agreed on a DKG result.
+	DKGCompleted bool
+	// DKGErr is the reason the DKG result could not be used, if any.
+	DKGErr error
+
+	// Clusters summarizes the root QC voting of each cluster.
+	Clusters []ClusterReport
+
+	// Commit is the EpochCommit event for the next epoch, nil if the smart
+	// contracts never reached a state where the event would be emitted.
+	Commit *flow.EpochCommit
+	// CommitErr is the reason the protocol state rejects Commit, if any.
+	CommitErr error
+
+	// FallbackTriggered is true if the simulated transition results in epoch
+	// emergency fallback, and FallbackReason explains why.
+	FallbackTriggered bool
+	FallbackReason    string
+}
+
+// CommitValid returns true if the simulation produced an EpochCommit event that
+// the protocol state accepts.
+func (r *Report) CommitValid() bool {
+	return r.Commit != nil && r.CommitErr == nil
+}
+
+// fallback records that the epoch transition results in epoch emergency fallback.
+func (r *Report) fallback(reason string, args ...interface{}) {
+	r.FallbackTriggered = true
+	r.FallbackReason = fmt.Sprintf(reason, args...)
+}
+
+// String returns a human-readable summary of the report.
+func (r *Report) String() string {
+	var b strings.Builder
+	if r.Setup != nil {
+		fmt.Fprintf(&b, "epoch setup: counter=%d, participants=%d, clusters=%d\n", r.Setup.Counter, len(r.Setup.Participants), len(r.Setup.Assignments))
+	}
+	if r.SetupErr != nil {
+		fmt.Fprintf(&b, "epoch setup rejected: %v\n", r.SetupErr)
+	}
+	fmt.Fprintf(&b, "dkg completed: %t\n", r.DKGCompleted)
+	if r.DKGErr != nil {
+		fmt.Fprintf(&b, "dkg error: %v\n", r.DKGErr)
+	}
+	for _, cluster := range r.Clusters {
+		fmt.Fprintf(&b, "cluster %d: voted=%d/%d, complete=%t\n", cluster.Index, cluster.Voted, cluster.Size, cluster.Complete)
+		if cluster.Err != nil {
+			fmt.Fprintf(&b, "cluster %d error: %v\n", cluster.Index, cluster.Err)
+		}
+	}
+	fmt.Fprintf(&b, "epoch commit valid: %t\n", r.CommitValid())
+	if r.CommitErr != nil {
+		fmt.Fprintf(&b, "epoch commit rejected: %v\n", r.CommitErr)
+	}
+	fmt.Fprintf(&b, "epoch fallback triggered: %t", r.FallbackTriggered)
+	if r.FallbackTriggered {
+		fmt.Fprintf(&b, " (%s)", r.FallbackReason)
+	}
+	return b.String()
+}
@@ -0,0 +1,546 @@
+// Package simulator provides an in-process dry run of an epoch transition. It
+// runs consensus and collection node instances on the stub network against
+// the epoch smart contracts deployed to an emulator, steps through the staking,
+// setup and committed phases, and reports whether the resulting EpochCommit
+// event is valid or whether the transition ends in epoch emergency fallback.
+package simulator
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-core-contracts/lib/go/templates"
+	emulator "github.com/onflow/flow-emulator"
+
+	"github.com/onflow/flow-go/consensus/hotstuff"
+	"github.com/onflow/flow-go/consensus/hotstuff/verification"
+	"github.com/onflow/flow-go/engine"
+	dkgeng "github.com/onflow/flow-go/engine/consensus/dkg"
+	"github.com/onflow/flow-go/engine/testutil"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/flow/order"
+	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/module/dkg"
+	emulatormod "github.com/onflow/flow-go/module/emulator"
+	"github.com/onflow/flow-go/module/epochs"
+	"github.com/onflow/flow-go/module/local"
+	"github.com/onflow/flow-go/network/stub"
+	"github.com/onflow/flow-go/state/protocol"
+	protocolbadger "github.com/onflow/flow-go/state/protocol/badger"
+	"github.com/onflow/flow-go/state/protocol/events/gadgets"
+	protocolmock "github.com/onflow/flow-go/state/protocol/mock"
+	"github.com/onflow/flow-go/storage/badger"
+	"github.com/onflow/flow-go/utils/unittest"
+	"github.com/onflow/flow-go/utils/unittest/mocks"
+)
+
+// qcVoteTimeout is the maximum time a collection node spends submitting its root QC vote.
+const qcVoteTimeout = 30 * time.Second
+
+// Simulator runs a single epoch transition, from the staking phase of the
+// current epoch to the committed phase, with the configured faults injected.
+//
+// The simulator is a test harness: it fails the given test on any error that
+// is not a consequence of the injected faults.
+type Simulator struct {
+	t      *testing.T
+	log    zerolog.Logger
+	config Config
+	rng    *rand.Rand
+
+	chainID     flow.ChainID
+	hub         *stub.Hub // in-mem network
+	blockchain  *emulator.Blockchain
+	adminClient *emulatormod.EmulatorClient
+	env         templates.Environment
+	dkgAccount  *account // account holding the FlowDKG contract
+	qcAccount   *account // account holding the FlowClusterQC contract
+
+	identities      flow.IdentityList // all participants of the current and next epoch
+	clustering      flow.ClusterList  // clustering of the next epoch
+	consensusNodes  []*consensusNode
+	collectionNodes []*collectionNode
+
+	currentSetup *flow.EpochSetup
+	nextSetup    *flow.EpochSetup
+	status       *flow.EpochStatus
+	setupBlock   *flow.Header // first block of the setup phase
+}
+
+// New creates a simulator for the given configuration, deploys the epoch smart
+// contracts to a fresh emulator, and creates the accounts of all nodes.
+func New(t *testing.T, config Config) *Simulator {
+	require.NoError(t, config.validate())
+
+	blockchain, err := emulator.NewBlockchain(
+		emulator.WithTransactionExpiry(flow.DefaultTransactionExpiry),
+		emulator.WithStorageLimitEnabled(false),
+	)
+	require.NoError(t, err)
+
+	s := &Simulator{
+		t:           t,
+		log:         unittest.Logger().With().Str("component", "epoch_simulator").Logger(),
+		config:      config,
+		rng:         rand.New(rand.NewSource(config.Faults.Seed)),
+		chainID:     flow.Emulator,
+		hub:         stub.NewNetworkHub(),
+		blockchain:  blockchain,
+		adminClient: emulatormod.NewEmulatorClient(blockchain),
+	}
+
+	s.deployContracts()
+	s.createNodes()
+
+	return s
+}
+
+// createNodes creates the identities and accounts of all consensus and
+// collection nodes, along with the clustering of the next epoch.
+func (s *Simulator) createNodes() {
+	consensusIDs := unittest.IdentityListFixture(s.config.ConsensusNodes, unittest.WithRole(flow.RoleConsensus))
+	for i, id := range consensusIDs {
+		s.consensusNodes = append(s.consensusNodes, &consensusNode{
+			identity: id,
+			account:  s.createAccount(),
+			// the first nodes are the offline ones
+			online: i >= s.config.Faults.OfflineConsensusNodes,
+		})
+	}
+
+	collectorIDs := unittest.IdentityListFixture(s.config.Clusters*s.config.CollectorsPerCluster, unittest.WithRole(flow.RoleCollection))
+	for _, id := range collectorIDs {
+		stakingKey := unittest.StakingPrivKeyFixture()
+		id.StakingPubKey = stakingKey.PublicKey()
+		s.collectionNodes = append(s.collectionNodes, &collectionNode{
+			identity:   id,
+			stakingKey: stakingKey,
+			account:    s.createAccount(),
+		})
+	}
+
+	s.identities = unittest.CompleteIdentitySet(append(consensusIDs, collectorIDs...)...).Sort(order.Canonical)
+
+	clustering, err := flow.NewClusterList(unittest.ClusterAssignment(uint(s.config.Clusters), s.identities), collectorIDs)
+	require.NoError(s.t, err)
+	s.clustering = clustering
+
+	// the first collection nodes of each cluster are the ones not voting
+	for _, cluster := range s.clustering {
+		for i, id := range cluster {
+			for _, node := range s.collectionNodes {
+				if node.identity.NodeID == id.NodeID {
+					node.votes = i >= s.config.Faults.MissingQCVotes
+				}
+			}
+		}
+	}
+}
+
+// Run steps through the staking, setup and committed phases of the epoch
+// transition and reports its outcome.
+func (s *Simulator) Run() *Report {
+	report := &Report{}
+
+	s.stakingPhase()
+
+	ok := s.setupPhase(report)
+	if !ok {
+		return report
+	}
+
+	s.commitPhase(report)
+
+	s.log.Info().Msgf("epoch transition simulation completed:\n%s", report)
+	return report
+}
+
+// stakingPhase registers all nodes with the epoch smart contracts, then
+// determines the schedule of the current epoch and the EpochSetup event for
+// the next epoch, which the smart contracts emit at the end of the staking phase.
+//
+// The views of the current epoch are placed relative to the latest block of the
+// emulator, as every transaction submitted so far created a block.
+func (s *Simulator) stakingPhase() {
+	s.startDKG()
+	for _, node := range s.consensusNodes {
+		s.claimDKGParticipant(node)
+	}
+	s.startVoting()
+	for _, node := range s.collectionNodes {
+		s.createVoter(node)
+	}
+
+	latest, err := s.blockchain.GetLatestBlock()
+	require.NoError(s.t, err)
+
+	setupView := latest.Header.View
+	length := s.config.PhaseLength
+
+	s.currentSetup = &flow.EpochSetup{
+		Counter:            1,
+		FirstView:          0,
+		DKGPhase1FinalView: setupView + length,
+		DKGPhase2FinalView: setupView + 2*length,
+		DKGPhase3FinalView: setupView + 3*length,
+		FinalView:          setupView + 4*length,
+		Participants:       s.identities,
+		Assignments:        s.clustering.Assignments(),
+		RandomSource:       s.randomSource(),
+	}
+	s.nextSetup = &flow.EpochSetup{
+		Counter:      s.currentSetup.Counter + 1,
+		FirstView:    s.currentSetup.FinalView + 1,
+		FinalView:    s.currentSetup.FinalView + 4*length,
+		Participants: s.identities,
+		Assignments:  s.clustering.Assignments(),
+		RandomSource: s.randomSource(),
+	}
+	s.status = &flow.EpochStatus{
+		CurrentEpoch: flow.EventIDs{
+			SetupID:  s.currentSetup.ID(),
+			CommitID: unittest.IdentifierFixture(),
+		},
+	}
+	s.setupBlock = latest.Header
+
+	s.log.Info().
+		Uint64("setup_view", setupView).
+		Uint64("final_view", s.currentSetup.FinalView).
+		Int("consensus_nodes", len(s.consensusNodes)).
+		Int("collection_nodes", len(s.collectionNodes)).
+		Msg("staking phase completed")
+}
+
+// setupPhase emits the EpochSetup event, then runs the DKG among the online
+// consensus nodes and the root QC voting among the voting collection nodes
+// until the final view of the current epoch. It returns false if the protocol
+// state rejects the EpochSetup event, in which case there is no setup phase.
+func (s *Simulator) setupPhase(report *Report) bool {
+	report.Setup = s.nextSetup
+
+	err := protocolbadger.IsValidExtendingEpochSetup(s.nextSetup, s.currentSetup, s.status)
+	if err != nil {
+		report.SetupErr = err
+		if protocol.IsInvalidServiceEventError(err) {
+			report.fallback("invalid epoch setup event: %s", err)
+		}
+		return false
+	}
+	s.status.NextEpoch.SetupID = s.nextSetup.ID()
+
+	online := s.onlineConsensusNodes()
+	for _, node := range online {
+		s.startConsensusNode(node)
+	}
+	for _, node := range online {
+		node.ProtocolEvents.EpochSetupPhaseStarted(s.currentSetup.Counter, s.setupBlock)
+	}
+
+	voters := s.voters()
+
+	// drive the views forward with one transaction per view, delivering the
+	// private DKG messages in between: collection nodes submit their votes
+	// first, dummy transactions fill the remaining views
+	view := s.setupBlock.View
+	for view < s.currentSetup.FinalView {
+		time.Sleep(s.config.ViewDuration)
+
+		s.deliverDKGMessages()
+
+		if len(voters) > 0 {
+			s.vote(voters[0])
+			voters = voters[1:]
+		} else {
+			_, err := s.sendDummyTx()
+			if err != nil {
+				s.log.Warn().Err(err).Msg("could not create block")
+				continue
+			}
+		}
+
+		// the DKG contract clients of the consensus nodes also create blocks
+		latest, err := s.blockchain.GetLatestBlock()
+		require.NoError(s.t, err)
+		for _, node := range online {
+			node.ProtocolEvents.BlockFinalized(latest.Header)
+		}
+		view = latest.Header.View
+	}
+
+	for _, node := range online {
+		node.done()
+	}
+
+	return true
+}
+
+// commitPhase collects the DKG result and the cluster root QCs from the epoch
+// smart contracts, builds the EpochCommit event for the next epoch, and checks
+// it against the protocol state.
+func (s *Simulator) commitPhase(report *Report) {
+	groupKey, participantKeys, completed, err := s.dkgResult()
+	report.DKGCompleted = completed
+	report.DKGErr = err
+
+	qcs := make([]flow.ClusterQCVoteData, 0, len(s.clustering))
+	incomplete := 0
+	for index, cluster := range s.clustering {
+		clusterReport := ClusterReport{
+			Index: uint(index),
+			Size:  len(cluster),
+			Voted: s.votes(cluster),
+		}
+		qc, err := s.clusterQC(uint(index))
+		if err != nil {
+			clusterReport.Err = err
+		}
+		if qc != nil {
+			clusterReport.Complete = true
+			clusterReport.Voters = qc.VoterIDs
+			qcs = append(qcs, *qc)
+		} else {
+			incomplete++
+		}
+		report.Clusters = append(report.Clusters, clusterReport)
+	}
+
+	// the epoch smart contracts only emit the EpochCommit event once both the
+	// DKG and the root QC voting have completed; without it, the current epoch
+	// reaches its final view with no next epoch committed
+	switch {
+	case !completed:
+		report.fallback("dkg did not complete before the final view of epoch %d", s.currentSetup.Counter)
+		return
+	case err != nil:
+		report.fallback("dkg result is unusable: %s", err)
+		return
+	case incomplete > 0:
+		report.fallback("%d of %d clusters did not complete root qc voting before the final view of epoch %d", incomplete, len(s.clustering), s.currentSetup.Counter)
+		return
+	}
+
+	report.Commit = &flow.EpochCommit{
+		Counter:            s.nextSetup.Counter,
+		ClusterQCs:         qcs,
+		DKGGroupKey:        groupKey,
+		DKGParticipantKeys: participantKeys,
+	}
+
+	err = protocolbadger.IsValidExtendingEpochCommit(report.Commit, s.nextSetup, s.currentSetup, s.status)
+	if err != nil {
+		report.CommitErr = err
+		if protocol.IsInvalidServiceEventError(err) {
+			report.fallback("invalid epoch commit event: %s", err)
+		}
+		return
+	}
+	s.status.NextEpoch.CommitID = report.Commit.ID()
+}
+
+// startConsensusNode creates and starts the DKG engines of an online consensus node.
+func (s *Simulator) startConsensusNode(node *consensusNode) {
+	core := testutil.GenericNodeFromParticipants(s.t, s.hub, node.identity, s.identities, s.chainID)
+
+	// the viewsObserver is used by the reactor engine to subscribe to new views
+	// being finalized
+	viewsObserver := gadgets.NewViews()
+	core.ProtocolEvents.AddConsumer(viewsObserver)
+
+	// dkgState is used to store the private key resulting from the node's
+	// participation in the DKG run
+	dkgState, err := badger.NewDKGState(core.Metrics, core.SecretsDB)
+	require.NoError(s.t, err)
+
+	// brokerTunnel is used to communicate between the messaging engine and the
+	// DKG broker/controller
+	brokerTunnel := dkg.NewBrokerTunnel()
+
+	messagingEngine, err := dkgeng.NewMessagingEngine(core.Log, core.Net, core.Me, brokerTunnel)
+	require.NoError(s.t, err)
+
+	contractClient := dkg.NewClient(
+		core.Log,
+		emulatormod.NewEmulatorClient(s.blockchain),
+		node.account.signer,
+		s.dkgAccount.address.String(),
+		node.account.address.String(),
+		0,
+	)
+
+	// create a config with no delays, the simulated views are short
+	config := dkg.ControllerConfig{
+		BaseStartDelay:                0,
+		BaseHandleFirstBroadcastDelay: 0,
+	}
+
+	reactorEngine := dkgeng.NewReactorEngine(
+		core.Log,
+		core.Me,
+		core.State,
+		dkgState,
+		dkg.NewControllerFactory(
+			core.Log,
+			core.Me,
+			[]module.DKGContractClient{contractClient},
+			brokerTunnel,
+			config,
+		),
+		viewsObserver,
+	)
+	core.ProtocolEvents.AddConsumer(reactorEngine)
+
+	node.GenericNode = core
+	node.dkgState = dkgState
+	node.messagingEngine = messagingEngine
+	node.reactorEngine = reactorEngine
+
+	// the reactor engine reads the epoch schedule from the protocol state
+	state := s.epochState(s.setupBlock)
+	node.GenericNode.State = state
+	node.reactorEngine.State = state
+
+	node.ready()
+}
+
+// epochState returns a protocol state whose snapshot at the first block of the
+// setup phase returns the current and next epochs.
+func (s *Simulator) epochState(firstBlock *flow.Header) *protocolmock.MutableState {
+	currentEpoch := new(protocolmock.Epoch)
+	currentEpoch.On("Counter").Return(s.currentSetup.Counter, nil)
+	currentEpoch.On("InitialIdentities").Return(s.currentSetup.Participants, nil)
+	currentEpoch.On("DKGPhase1FinalView").Return(s.currentSetup.DKGPhase1FinalView, nil)
+	currentEpoch.On("DKGPhase2FinalView").Return(s.currentSetup.DKGPhase2FinalView, nil)
+	currentEpoch.On("DKGPhase3FinalView").Return(s.currentSetup.DKGPhase3FinalView, nil)
+	currentEpoch.On("FinalView").Return(s.currentSetup.FinalView, nil)
+	currentEpoch.On("FirstView").Return(s.currentSetup.FirstView, nil)
+	currentEpoch.On("RandomSource").Return(s.nextSetup.RandomSource, nil)
+
+	nextEpoch := new(protocolmock.Epoch)
+	nextEpoch.On("Counter").Return(s.nextSetup.Counter, nil)
+	nextEpoch.On("InitialIdentities").Return(s.nextSetup.Participants, nil)
+	nextEpoch.On("RandomSource").Return(s.nextSetup.RandomSource, nil)
+	nextEpoch.On("DKG").Return(nil, nil)
+	nextEpoch.On("FirstView").Return(s.nextSetup.FirstView, nil)
+	nextEpoch.On("FinalView").Return(s.nextSetup.FinalView, nil)
+
+	epochQuery := mocks.NewEpochQuery(s.t, s.currentSetup.Counter)
+	epochQuery.Add(currentEpoch)
+	epochQuery.Add(nextEpoch)
+	snapshot := new(protocolmock.Snapshot)
+	snapshot.On("Epochs").Return(epochQuery)
+	// nodes start in the staking phase and observe the start of the setup
+	// phase through the EpochSetupPhaseStarted event
+	snapshot.On("Phase").Return(flow.EpochPhaseStaking, nil)
+	snapshot.On("Head").Return(firstBlock, nil)
+	state := new(protocolmock.MutableState)
+	state.On("AtBlockID", firstBlock.ID()).Return(snapshot)
+	state.On("Final").Return(snapshot)
+
+	return state
+}
+
+// voters creates the root QC voters of all voting collection nodes.
+func (s *Simulator) voters() []*collectionNode {
+	voters := make([]*collectionNode, 0, len(s.collectionNodes))
+	for _, node := range s.collectionNodes {
+		if !node.votes {
+			continue
+		}
+
+		me, err := local.New(node.identity, node.stakingKey)
+		require.NoError(s.t, err)
+		client := epochs.NewQCContractClient(
+			s.log,
+			emulatormod.NewEmulatorClient(s.blockchain),
+			node.identity.NodeID,
+			node.account.address.String(),
+			0,
+			s.qcAccount.address.String(),
+			node.account.signer,
+		)
+		newSigner := func(clusterID flow.ChainID) hotstuff.Signer {
+			return verification.NewStakingSigner(me, clusterID)
+		}
+		node.voter = epochs.NewRootQCVoter(s.log, me, newSigner, s.voterState(), []module.QCContractClient{client})
+		voters = append(voters, node)
+	}
+	return voters
+}
+
+// vote submits the root QC vote of a collection node for the next epoch.
+func (s *Simulator) vote(node *collectionNode) {
+	nextEpoch := new(protocolmock.Epoch)
+	nextEpoch.On("Counter").Return(s.nextSetup.Counter, nil)
+	nextEpoch.On("Clustering").Return(s.clustering, nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), qcVoteTimeout)
+	defer cancel()
+	err := node.voter.Vote(ctx, nextEpoch)
+	require.NoError(s.t, err, "collection node %x could not vote", node.identity.NodeID)
+}
+
+// voterState returns a protocol state whose finalized snapshot is in the setup phase.
+func (s *Simulator) voterState() *protocolmock.State {
+	snapshot := new(protocolmock.Snapshot)
+	snapshot.On("Phase").Return(flow.EpochPhaseSetup, nil)
+	state := new(protocolmock.State)
+	state.On("Final").Return(snapshot)
+	return state
+}
+
+// votes returns the number of nodes in the cluster that submitted their root QC vote.
+func (s *Simulator) votes(cluster flow.IdentityList) int {
+	voted := 0
+	for _, node := range s.collectionNodes {
+		_, ok := cluster.ByNodeID(node.identity.NodeID)
+		if ok && node.votes {
+			voted++
+		}
+	}
+	return voted
+}
+
+// deliverDKGMessages delivers the pending private DKG messages, dropping each
+// of them with the configured probability.
+func (s *Simulator) deliverDKGMessages() {
+	online := s.onlineConsensusNodes()
+	if len(online) == 0 {
+		return
+	}
+
+	// all networks share the buffer of the hub, delivering through one of them
+	// delivers all pending messages
+	net, ok := s.hub.GetNetwork(online[0].identity.NodeID)
+	require.True(s.t, ok)
+	net.DeliverAllExcept(false, func(m *stub.PendingMessage) bool {
+		if m.Channel != engine.DKGCommittee {
+			return false
+		}
+		return s.rng.Float64() < s.config.Faults.DKGMessageDropRate
+	})
+}
+
+// onlineConsensusNodes returns the consensus nodes taking part in the DKG.
+func (s *Simulator) onlineConsensusNodes() []*consensusNode {
+	online := make([]*consensusNode, 0, len(s.consensusNodes))
+	for _, node := range s.consensusNodes {
+		if node.online {
+			online = append(online, node)
+		}
+	}
+	return online
+}
+
+// randomSource returns a random source for an EpochSetup event, drawn from the
+// seeded pseudo-random source of the simulator.
+func (s *Simulator) randomSource() []byte {
+	source := make([]byte, flow.EpochSetupRandomSourceLength)
+	_, _ = s.rng.Read(source)
+	return source
+}
@@ -0,0 +1,65 @@
+package simulator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestHappyPath checks that an epoch transition without faults results in a
+// valid EpochCommit event.
+func TestHappyPath(t *testing.T) {
+	report := New(t, DefaultConfig(4, 2, 3)).Run()
+
+	require.NoError(t, report.SetupErr)
+	assert.True(t, report.DKGCompleted)
+	assert.NoError(t, report.DKGErr)
+	for _, cluster := range report.Clusters {
+		assert.True(t, cluster.Complete)
+		assert.Len(t, cluster.Voters, cluster.Size)
+	}
+	require.NotNil(t, report.Commit)
+	assert.Len(t, report.Commit.DKGParticipantKeys, 4)
+	assert.True(t, report.CommitValid())
+	assert.False(t, report.FallbackTriggered)
+}
+
+// TestMissingQCVotes checks that epoch fallback is triggered when a cluster
+// does not reach quorum for its root QC.
+func TestMissingQCVotes(t *testing.T) {
+	config := DefaultConfig(4, 2, 3)
+	config.Faults.MissingQCVotes = 2
+	report := New(t, config).Run()
+
+	assert.True(t, report.DKGCompleted)
+	for _, cluster := range report.Clusters {
+		assert.Equal(t, 1, cluster.Voted)
+		assert.False(t, cluster.Complete)
+	}
+	assert.Nil(t, report.Commit)
+	assert.True(t, report.FallbackTriggered)
+}
+
+// TestOfflineConsensusNodes checks that epoch fallback is triggered when too
+// few consensus nodes take part in the DKG for it to complete.
+func TestOfflineConsensusNodes(t *testing.T) {
+	config := DefaultConfig(4, 1, 3)
+	config.Faults.OfflineConsensusNodes = 3
+	report := New(t, config).Run()
+
+	assert.False(t, report.DKGCompleted)
+	assert.Nil(t, report.Commit)
+	assert.True(t, report.FallbackTriggered)
+}
+
+// TestDroppedDKGMessages checks that epoch fallback is triggered when all
+// private DKG messages are lost.
+func TestDroppedDKGMessages(t *testing.T) {
+	config := DefaultConfig(4, 1, 3)
+	config.Faults.DKGMessageDropRate = 1
+	report := New(t, config).Run()
+
+	assert.Nil(t, report.Commit)
+	assert.True(t, report.FallbackTriggered)
+}
@@ -320,8 +320,7 @@ func prepareService(container testnet.ContainerConfig, i int) Service {
 			fmt.Sprintf("%s:/data:z", dataDir),
 		},
 		Environment: []string{
-			"JAEGER_AGENT_HOST=jaeger",
-			"JAEGER_AGENT_PORT=6831",
+			"OTEL_EXPORTER_OTLP_ENDPOINT=http://jaeger:4317",
 			// NOTE: these env vars are not set by default, but can be set [1] to enable binstat logging:
 			// [1] https://docs.docker.com/compose/environment-variables/#pass-environment-variables-to-containers
 			"BINSTAT_ENABLE",
@@ -404,6 +404,15 @@ func (l *Ledger) MostRecentTouchedState() (ledger.State, error) {
 	return ledger.State(root), err
 }
 
+// Trie returns the trie at the given state, if it is still held in memory.
+func (l *Ledger) Trie(state ledger.State) (*trie.MTrie, error) {
+	t, err := l.forest.GetTrie(ledger.RootHash(state))
+	if err != nil {
+		return nil, fmt.Errorf("cannot find the trie: %w", err)
+	}
+	return t, nil
+}
+
 // DumpTrieAsJSON export trie at specific state as JSONL (each line is JSON encoding of a payload)
 func (l *Ledger) DumpTrieAsJSON(state ledger.State, writer io.Writer) error {
 	fmt.Println(ledger.RootHash(state))
@@ -0,0 +1,139 @@
+package partition
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/bitutils"
+	"github.com/onflow/flow-go/ledger/common/utils"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/flattener"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/node"
+)
+
+// EncodeSubtrie encodes the nodes of the subtrie with the given root node, descendants first,
+// in the same format as the nodes of a checkpoint. Index 0 refers to a nil node.
+func EncodeSubtrie(root *node.Node) []byte {
+	var buf bytes.Buffer
+	scratch := make([]byte, 1024*4)
+	index := uint64(1)
+
+	var encode func(n *node.Node) uint64
+	encode = func(n *node.Node) uint64 {
+		if n == nil {
+			return 0
+		}
+		lchildIndex := encode(n.LeftChild())
+		rchildIndex := encode(n.RightChild())
+		buf.Write(flattener.EncodeNode(n, lchildIndex, rchildIndex, scratch))
+		index++
+		return index - 1
+	}
+	encode(root)
+
+	return buf.Bytes()
+}
+
+// DecodeSubtrie decodes the nodes of a subtrie encoded with EncodeSubtrie, and verifies
+// them against the given description. It returns the root node of the subtrie.
+func DecodeSubtrie(data []byte, subtrie Subtrie) (*node.Node, error) {
+	reader := bytes.NewReader(data)
+	scratch := make([]byte, 1024*4)
+
+	// nodes's element at index 0 is a special, meaning nil
+	nodes := []*node.Node{nil}
+	for reader.Len() > 0 {
+		n, err := flattener.ReadNode(reader, scratch, func(nodeIndex uint64) (*node.Node, error) {
+			if nodeIndex >= uint64(len(nodes)) {
+				return nil, fmt.Errorf("sequence of serialized nodes does not satisfy Descendents-First-Relationship")
+			}
+			return nodes[nodeIndex], nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("cannot read node %d: %w", len(nodes), err)
+		}
+		err = verifyNode(n)
+		if err != nil {
+			return nil, fmt.Errorf("invalid node %d: %w", len(nodes), err)
+		}
+		nodes = append(nodes, n)
+	}
+
+	// the root node is the last one, as descendants are encoded first
+	root := nodes[len(nodes)-1]
+	if root == nil {
+		return nil, fmt.Errorf("subtrie has no nodes")
+	}
+	if root.Height() != subtrie.Height {
+		return nil, fmt.Errorf("subtrie has height %d, expected %d", root.Height(), subtrie.Height)
+	}
+	if root.Hash() != subtrie.Hash {
+		return nil, fmt.Errorf("subtrie has hash %s, expected %s", root.Hash(), subtrie.Hash)
+	}
+	// the hashes were read along with the nodes, and are only trusted once recomputed
+	if !root.VerifyCachedHash() {
+		return nil, fmt.Errorf("subtrie hashes are invalid")
+	}
+	// the hash of a compactified leaf only covers the bits of its path below its height
+	err := verifyLeafPaths(root, subtrie.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	return root, nil
+}
+
+// verifyLeafPaths verifies that the paths of the leaves of the subtrie with the given root node
+// match their position in the trie, given by the path of the root node.
+func verifyLeafPaths(n *node.Node, path ledger.Path) error {
+	if n == nil {
+		return nil
+	}
+	if n.IsLeaf() {
+		leafPath := n.Path()
+		for i := 0; i < ledger.NodeMaxHeight-n.Height(); i++ {
+			if bitutils.ReadBit(leafPath[:], i) != bitutils.ReadBit(path[:], i) {
+				return fmt.Errorf("leaf with path %s is not in its position in the trie", leafPath)
+			}
+		}
+		return nil
+	}
+	err := verifyLeafPaths(n.LeftChild(), path)
+	if err != nil {
+		return err
+	}
+	bitutils.SetBit(path[:], ledger.NodeMaxHeight-n.Height())
+	return verifyLeafPaths(n.RightChild(), path)
+}
+
+// verifyNode verifies the values of the node which are not covered by its hash.
+func verifyNode(n *node.Node) error {
+	if n.IsLeaf() {
+		if n.RegCount() != 1 || n.MaxDepth() != 0 {
+			return fmt.Errorf("leaf has %d registers and max depth %d", n.RegCount(), n.MaxDepth())
+		}
+		return nil
+	}
+
+	var regCount uint64
+	var lMaxDepth, rMaxDepth uint16
+	for _, child := range []*node.Node{n.LeftChild(), n.RightChild()} {
+		if child == nil {
+			continue
+		}
+		if child.Height() != n.Height()-1 {
+			return fmt.Errorf("child has height %d, expected %d", child.Height(), n.Height()-1)
+		}
+		regCount += child.RegCount()
+	}
+	if n.LeftChild() != nil {
+		lMaxDepth = n.LeftChild().MaxDepth()
+	}
+	if n.RightChild() != nil {
+		rMaxDepth = n.RightChild().MaxDepth()
+	}
+	if n.RegCount() != regCount || n.MaxDepth() != utils.MaxUint16(lMaxDepth, rMaxDepth)+1 {
+		return fmt.Errorf("interim node has %d registers and max depth %d", n.RegCount(), n.MaxDepth())
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+// Package partition splits a trie into subtries which can be transferred and
+// verified independently, and assembles a trie from such subtries again.
+//
+// A trie is partitioned at a given depth: every subtrie is rooted either at a
+// node at that depth, or at a compactified leaf above it. The descriptions of
+// the subtries (their paths, heights and hashes) are sufficient to verify them
+// against the root hash of the trie before the subtries are transferred, and
+// every subtrie can then be verified against its description on its own.
+package partition
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/bitutils"
+	"github.com/onflow/flow-go/ledger/common/hash"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/node"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
+)
+
+// MaxDepth is the maximum depth a trie can be partitioned at, which limits
+// the number of subtries to 2^MaxDepth.
+const MaxDepth = 16
+
+// Subtrie describes a subtrie of a partitioned trie.
+type Subtrie struct {
+	// Path is the path of the subtrie's root node. Only the bits above
+	// the height of the subtrie are significant, the others are zero.
+	Path   ledger.Path
+	Height int
+	Hash   hash.Hash
+}
+
+// Partition splits the given trie at the given depth. It returns the descriptions
+// of the subtries ordered by path, and their root nodes. An empty trie has no subtries.
+func Partition(t *trie.MTrie, depth int) ([]Subtrie, []*node.Node, error) {
+	if depth < 0 || depth > MaxDepth {
+		return nil, nil, fmt.Errorf("invalid depth %d, must be between 0 and %d", depth, MaxDepth)
+	}
+
+	var subtries []Subtrie
+	var roots []*node.Node
+
+	var visit func(n *node.Node, path ledger.Path)
+	visit = func(n *node.Node, path ledger.Path) {
+		if n == nil {
+			return
+		}
+		if n.IsLeaf() || n.Height() == ledger.NodeMaxHeight-depth {
+			subtries = append(subtries, Subtrie{
+				Path:   path,
+				Height: n.Height(),
+				Hash:   n.Hash(),
+			})
+			roots = append(roots, n)
+			return
+		}
+		visit(n.LeftChild(), path)
+		bitutils.SetBit(path[:], ledger.NodeMaxHeight-n.Height())
+		visit(n.RightChild(), path)
+	}
+	visit(t.RootNode(), ledger.Path{})
+
+	return subtries, roots, nil
+}
+
+// VerifySubtries verifies that the given subtries are ordered by path, and form a trie
+// with the given root hash.
+func VerifySubtries(subtries []Subtrie, rootHash ledger.RootHash) error {
+	for i, subtrie := range subtries {
+		if subtrie.Height < ledger.NodeMaxHeight-MaxDepth || subtrie.Height > ledger.NodeMaxHeight {
+			return fmt.Errorf("invalid height %d of subtrie %d", subtrie.Height, i)
+		}
+		if i > 0 && bytes.Compare(subtries[i-1].Path[:], subtrie.Path[:]) >= 0 {
+			return fmt.Errorf("subtrie %d is not ordered by path", i)
+		}
+	}
+
+	h, err := computeHash(subtries, ledger.NodeMaxHeight)
+	if err != nil {
+		return err
+	}
+	if ledger.RootHash(h) != rootHash {
+		return fmt.Errorf("subtries have root hash %s, expected %s", ledger.RootHash(h), rootHash)
+	}
+	return nil
+}
+
+// computeHash computes the hash of the node at the given height, which contains the given subtries.
+func computeHash(subtries []Subtrie, height int) (hash.Hash, error) {
+	if len(subtries) == 0 {
+		return ledger.GetDefaultHashForHeight(height), nil
+	}
+	if len(subtries) == 1 && subtries[0].Height == height {
+		return subtries[0].Hash, nil
+	}
+	if height <= ledger.NodeMaxHeight-MaxDepth {
+		return hash.DummyHash, fmt.Errorf("subtries overlap at height %d", height)
+	}
+
+	right := splitIndex(subtries, height)
+	lHash, err := computeHash(subtries[:right], height-1)
+	if err != nil {
+		return hash.DummyHash, err
+	}
+	rHash, err := computeHash(subtries[right:], height-1)
+	if err != nil {
+		return hash.DummyHash, err
+	}
+	return hash.HashInterNode(lHash, rHash), nil
+}
+
+// splitIndex returns the index of the first of the given subtries, ordered by path, which
+// is in the right subtrie of the node at the given height.
+func splitIndex(subtries []Subtrie, height int) int {
+	return sort.Search(len(subtries), func(i int) bool {
+		return bitutils.ReadBit(subtries[i].Path[:], ledger.NodeMaxHeight-height) == 1
+	})
+}
+
+// Assemble assembles the trie formed by the given subtries and their root nodes. The subtries
+// must have been verified with VerifySubtries, and their root nodes with DecodeSubtrie.
+func Assemble(subtries []Subtrie, roots []*node.Node, rootHash ledger.RootHash) (*trie.MTrie, error) {
+	if len(subtries) != len(roots) {
+		return nil, fmt.Errorf("got %d root nodes for %d subtries", len(roots), len(subtries))
+	}
+
+	var build func(subtries []Subtrie, roots []*node.Node, height int) *node.Node
+	build = func(subtries []Subtrie, roots []*node.Node, height int) *node.Node {
+		if len(subtries) == 0 {
+			return nil
+		}
+		if len(subtries) == 1 && subtries[0].Height == height {
+			return roots[0]
+		}
+		right := splitIndex(subtries, height)
+		return node.NewInterimNode(height,
+			build(subtries[:right], roots[:right], height-1),
+			build(subtries[right:], roots[right:], height-1),
+		)
+	}
+
+	t, err := trie.NewMTrie(build(subtries, roots, ledger.NodeMaxHeight))
+	if err != nil {
+		return nil, fmt.Errorf("could not create trie: %w", err)
+	}
+	if t.RootHash() != rootHash {
+		return nil, fmt.Errorf("assembled trie has root hash %s, expected %s", t.RootHash(), rootHash)
+	}
+	return t, nil
+}
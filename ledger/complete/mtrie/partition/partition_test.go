@@ -0,0 +1,130 @@
+package partition_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/utils"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/node"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/partition"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
+)
+
+func randomTrie(t *testing.T, registers int) *trie.MTrie {
+	paths := utils.RandomPaths(registers)
+	payloads := make([]ledger.Payload, len(paths))
+	for i, p := range utils.RandomPayloads(len(paths), 1, 100) {
+		payloads[i] = *p
+	}
+	tr, err := trie.NewTrieWithUpdatedRegisters(trie.NewEmptyMTrie(), paths, payloads, true)
+	require.NoError(t, err)
+	return tr
+}
+
+// roundTrip partitions the trie, encodes and decodes all subtries, and assembles them again.
+func roundTrip(t *testing.T, tr *trie.MTrie, depth int) *trie.MTrie {
+	subtries, roots, err := partition.Partition(tr, depth)
+	require.NoError(t, err)
+	require.Len(t, roots, len(subtries))
+
+	err = partition.VerifySubtries(subtries, tr.RootHash())
+	require.NoError(t, err)
+
+	decoded := make([]*node.Node, len(subtries))
+	for i, subtrie := range subtries {
+		decoded[i], err = partition.DecodeSubtrie(partition.EncodeSubtrie(roots[i]), subtrie)
+		require.NoError(t, err)
+	}
+
+	assembled, err := partition.Assemble(subtries, decoded, tr.RootHash())
+	require.NoError(t, err)
+	return assembled
+}
+
+func TestPartition(t *testing.T) {
+	tr := randomTrie(t, 1000)
+
+	for _, depth := range []int{0, 1, 4, 8, partition.MaxDepth} {
+		t.Run(fmt.Sprintf("depth %d", depth), func(t *testing.T) {
+			assembled := roundTrip(t, tr, depth)
+			assert.True(t, assembled.Equals(tr))
+			assert.Equal(t, tr.AllocatedRegCount(), assembled.AllocatedRegCount())
+			assert.Equal(t, tr.MaxDepth(), assembled.MaxDepth())
+			assert.ElementsMatch(t, tr.AllPayloads(), assembled.AllPayloads())
+		})
+	}
+
+	t.Run("empty trie", func(t *testing.T) {
+		assembled := roundTrip(t, trie.NewEmptyMTrie(), 8)
+		assert.True(t, assembled.IsEmpty())
+	})
+
+	t.Run("single register", func(t *testing.T) {
+		tr := randomTrie(t, 1)
+		assembled := roundTrip(t, tr, 8)
+		assert.True(t, assembled.Equals(tr))
+	})
+
+	t.Run("invalid depth", func(t *testing.T) {
+		_, _, err := partition.Partition(tr, partition.MaxDepth+1)
+		assert.Error(t, err)
+	})
+}
+
+func TestVerifySubtries(t *testing.T) {
+	tr := randomTrie(t, 1000)
+	subtries, _, err := partition.Partition(tr, 8)
+	require.NoError(t, err)
+
+	t.Run("tampered hash", func(t *testing.T) {
+		tampered := append([]partition.Subtrie{}, subtries...)
+		tampered[3].Hash[0] ^= 1
+		assert.Error(t, partition.VerifySubtries(tampered, tr.RootHash()))
+	})
+
+	t.Run("missing subtrie", func(t *testing.T) {
+		assert.Error(t, partition.VerifySubtries(subtries[1:], tr.RootHash()))
+	})
+
+	t.Run("unordered subtries", func(t *testing.T) {
+		tampered := append([]partition.Subtrie{}, subtries...)
+		tampered[0], tampered[1] = tampered[1], tampered[0]
+		assert.Error(t, partition.VerifySubtries(tampered, tr.RootHash()))
+	})
+
+	t.Run("invalid height", func(t *testing.T) {
+		tampered := append([]partition.Subtrie{}, subtries...)
+		tampered[0].Height = 0
+		assert.Error(t, partition.VerifySubtries(tampered, tr.RootHash()))
+	})
+}
+
+func TestDecodeSubtrie(t *testing.T) {
+	tr := randomTrie(t, 1000)
+	subtries, roots, err := partition.Partition(tr, 4)
+	require.NoError(t, err)
+
+	encoded := partition.EncodeSubtrie(roots[0])
+
+	t.Run("tampered hash", func(t *testing.T) {
+		tampered := append([]byte{}, encoded...)
+		// the root node is encoded last, and its hash is followed by its two 8 bytes child indices
+		tampered[len(tampered)-20] ^= 1
+		_, err := partition.DecodeSubtrie(tampered, subtries[0])
+		assert.Error(t, err)
+	})
+
+	t.Run("wrong subtrie", func(t *testing.T) {
+		_, err := partition.DecodeSubtrie(encoded, subtries[1])
+		assert.Error(t, err)
+	})
+
+	t.Run("truncated", func(t *testing.T) {
+		_, err := partition.DecodeSubtrie(encoded[:len(encoded)-1], subtries[0])
+		assert.Error(t, err)
+	})
+}
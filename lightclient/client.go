@@ -0,0 +1,382 @@
+// Package lightclient implements a client verifying the finality of blocks of a
+// Flow chain without running a follower. Starting from a trusted protocol state
+// snapshot, the client walks forward through the headers of the chain, verifies
+// the quorum certificate of each block against the weighted staking keys of the
+// consensus committee, and follows epoch transitions by verifying the epoch
+// service events sealed by finalized blocks.
+package lightclient
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/onflow/flow-go/consensus/hotstuff"
+	"github.com/onflow/flow-go/consensus/hotstuff/model"
+	"github.com/onflow/flow-go/consensus/hotstuff/signature"
+	"github.com/onflow/flow-go/consensus/hotstuff/verification"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/state"
+	"github.com/onflow/flow-go/state/protocol"
+	bprotocol "github.com/onflow/flow-go/state/protocol/badger"
+	"github.com/onflow/flow-go/state/protocol/inmem"
+)
+
+var (
+	// ErrUnknownEpoch is returned when the light client doesn't know the committee
+	// for a view, because the epoch transition was not observed. This happens if
+	// the blocks sealing the epoch service events were not provided with their
+	// payload, in which case the client needs to be bootstrapped again.
+	ErrUnknownEpoch = errors.New("epoch is unknown to light client")
+
+	// ErrNotFinalized is returned when the finality of a block can't be proven.
+	ErrNotFinalized = errors.New("block is not finalized")
+)
+
+// Block is a block the light client is extended with. Only the header is
+// required. However, blocks sealing execution results with epoch service events
+// must be provided with their payload and the sealed results, for the client to
+// follow the epoch transition.
+type Block struct {
+	Header  *flow.Header
+	Payload *flow.Payload
+	Results []*flow.ExecutionResult // the results sealed by the payload
+}
+
+// pendingBlock is a block which is not yet finalized, with the service events of
+// the results it seals.
+type pendingBlock struct {
+	header *flow.Header
+	events []flow.ServiceEvent
+}
+
+// Client is a light client following the finalized chain.
+type Client struct {
+	mu                sync.RWMutex
+	chainID           flow.ChainID
+	newVerifier       func(hotstuff.Committee) hotstuff.Verifier
+	root              *flow.Header
+	finalized         *flow.Header
+	finalizedByHeight map[uint64]flow.Identifier
+	pending           []*pendingBlock // unfinalized descendants of the latest finalized block
+	current           *epoch
+	next              *epoch // nil until the next epoch is set up
+	fallback          bool   // whether epoch emergency fallback was triggered
+}
+
+// New creates a light client bootstrapped from the given trusted snapshot. The
+// head of the snapshot is considered finalized.
+func New(snapshot *inmem.Snapshot) (*Client, error) {
+	head, err := snapshot.Head()
+	if err != nil {
+		return nil, fmt.Errorf("could not get snapshot head: %w", err)
+	}
+	chainID, err := snapshot.Params().ChainID()
+	if err != nil {
+		return nil, fmt.Errorf("could not get chain ID: %w", err)
+	}
+	phase, err := snapshot.Phase()
+	if err != nil {
+		return nil, fmt.Errorf("could not get epoch phase: %w", err)
+	}
+
+	current, err := epochFromProtocol(snapshot.Epochs().Current(), true)
+	if err != nil {
+		return nil, fmt.Errorf("could not get current epoch: %w", err)
+	}
+	var next *epoch
+	if phase == flow.EpochPhaseSetup || phase == flow.EpochPhaseCommitted {
+		next, err = epochFromProtocol(snapshot.Epochs().Next(), phase == flow.EpochPhaseCommitted)
+		if err != nil {
+			return nil, fmt.Errorf("could not get next epoch: %w", err)
+		}
+	}
+
+	c := &Client{
+		chainID: chainID,
+		newVerifier: func(committee hotstuff.Committee) hotstuff.Verifier {
+			return verification.NewCombinedVerifier(committee, signature.NewConsensusSigDataPacker(committee))
+		},
+		root:              head,
+		finalized:         head,
+		finalizedByHeight: map[uint64]flow.Identifier{head.Height: head.ID()},
+		current:           current,
+		next:              next,
+	}
+
+	// the service events sealed by the head are not yet reflected by the epoch
+	// information of the snapshot, as they only take effect in its children
+	segment, err := snapshot.SealingSegment()
+	if err != nil {
+		return nil, fmt.Errorf("could not get sealing segment: %w", err)
+	}
+	events, err := sealedServiceEvents(segment)
+	if err != nil {
+		return nil, fmt.Errorf("could not get service events sealed by snapshot head: %w", err)
+	}
+	err = c.applyServiceEvents(events)
+	if err != nil {
+		return nil, fmt.Errorf("could not apply service events sealed by snapshot head: %w", err)
+	}
+
+	return c, nil
+}
+
+// Finalized returns the latest finalized header known to the light client.
+func (c *Client) Finalized() *flow.Header {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.finalized
+}
+
+// Extend extends the chain followed by the light client with the given block,
+// which must be the child of the latest block the client was extended with. The
+// quorum certificate of the parent contained in the header is verified, and
+// blocks reaching finality are finalized.
+// Expected errors during normal operations:
+//  * state.InvalidExtensionError if the block doesn't extend the chain or its QC is invalid
+//  * ErrUnknownEpoch if the epoch of the parent is unknown to the client
+func (c *Client) Extend(block *Block) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	header := block.Header
+	parent := c.finalized
+	if len(c.pending) > 0 {
+		parent = c.pending[len(c.pending)-1].header
+	}
+
+	if header.ChainID != c.chainID {
+		return state.NewInvalidExtensionErrorf("block has chain ID %s, but expected %s", header.ChainID, c.chainID)
+	}
+	parentID := parent.ID()
+	if header.ParentID != parentID {
+		return state.NewInvalidExtensionErrorf("block parent %x is not the latest block %x", header.ParentID, parentID)
+	}
+	if header.Height != parent.Height+1 {
+		return state.NewInvalidExtensionErrorf("block height %d is not parent height %d + 1", header.Height, parent.Height)
+	}
+	if header.View <= parent.View {
+		return state.NewInvalidExtensionErrorf("block view %d is not higher than parent view %d", header.View, parent.View)
+	}
+
+	// verify the QC for the parent against the committee of the parent's epoch
+	e, err := c.epochForView(parent.View)
+	if err != nil {
+		return err
+	}
+	qc := &flow.QuorumCertificate{
+		View:      parent.View,
+		BlockID:   header.ParentID,
+		SignerIDs: header.ParentVoterIDs,
+		SigData:   header.ParentVoterSigData,
+	}
+	// only the ID and view of the certified block are used to validate the QC
+	certified := &model.Block{
+		BlockID: parentID,
+		View:    parent.View,
+	}
+	err = e.qcValidator(c.newVerifier).ValidateQC(qc, certified)
+	if model.IsInvalidBlockError(err) {
+		return state.NewInvalidExtensionErrorf("invalid QC for block %x: %w", parentID, err)
+	}
+	if err != nil {
+		return fmt.Errorf("could not validate QC for block %x: %w", parentID, err)
+	}
+
+	pending := &pendingBlock{header: header}
+	if block.Payload != nil {
+		if block.Payload.Hash() != header.PayloadHash {
+			return state.NewInvalidExtensionErrorf("payload does not match payload hash of block %x", header.ID())
+		}
+		pending.events, err = serviceEventsOfSeals(block.Payload.Seals, block.Results)
+		if err != nil {
+			return fmt.Errorf("could not get service events sealed by block %x: %w", header.ID(), err)
+		}
+	}
+	c.pending = append(c.pending, pending)
+
+	return c.finalize()
+}
+
+// finalize finalizes the pending blocks reaching finality. A block is finalized
+// if it has a direct 2-chain on top of it, with the second block being
+// certified, i.e. b <- b' <- b'' <- b* with b'.View == b.View+1 and
+// b''.View == b.View+2, as in consensus/hotstuff/forks/finalizer.
+func (c *Client) finalize() error {
+	// chain[0] is the latest finalized block, chain[i] is pending[i-1]
+	chain := make([]*flow.Header, 0, len(c.pending)+1)
+	chain = append(chain, c.finalized)
+	for _, block := range c.pending {
+		chain = append(chain, block.header)
+	}
+
+	// find the highest pending block with a certified direct 2-chain on top of it
+	var finalizedIndex int
+	for i := len(chain) - 4; i > 0; i-- {
+		if chain[i+1].View == chain[i].View+1 && chain[i+2].View == chain[i].View+2 {
+			finalizedIndex = i
+			break
+		}
+	}
+
+	for _, block := range c.pending[:finalizedIndex] {
+		header := block.header
+
+		// switch to the next epoch with its first finalized block
+		if !c.current.containsView(header.View) && c.next != nil && c.next.containsView(header.View) {
+			c.current = c.next
+			c.next = nil
+		}
+
+		err := c.applyServiceEvents(block.events)
+		if err != nil {
+			return fmt.Errorf("could not apply service events sealed by block %x: %w", header.ID(), err)
+		}
+
+		c.finalized = header
+		c.finalizedByHeight[header.Height] = header.ID()
+	}
+	c.pending = c.pending[finalizedIndex:]
+
+	return nil
+}
+
+// epochForView returns the epoch containing the given view. When epoch emergency
+// fallback was triggered, the current epoch continues after its final view.
+func (c *Client) epochForView(view uint64) (*epoch, error) {
+	if c.current.containsView(view) {
+		return c.current, nil
+	}
+	if c.next != nil && c.next.committed() && c.next.containsView(view) {
+		return c.next, nil
+	}
+	if c.fallback && view > c.current.setup.FinalView {
+		return c.current, nil
+	}
+	return nil, fmt.Errorf("no committed epoch for view %d: %w", view, ErrUnknownEpoch)
+}
+
+// applyServiceEvents applies the epoch service events sealed by a finalized
+// block. The events are validated as by the protocol state, and an invalid event
+// triggers epoch emergency fallback, after which no further events are applied.
+func (c *Client) applyServiceEvents(events []flow.ServiceEvent) error {
+	for _, event := range events {
+		if c.fallback {
+			return nil
+		}
+
+		status := &flow.EpochStatus{
+			CurrentEpoch: c.current.eventIDs(),
+		}
+		if c.next != nil {
+			status.NextEpoch = c.next.eventIDs()
+		}
+
+		switch ev := event.Event.(type) {
+		case *flow.EpochSetup:
+			err := bprotocol.IsValidExtendingEpochSetup(ev, c.current.setup, status)
+			if protocol.IsInvalidServiceEventError(err) {
+				c.fallback = true
+				continue
+			}
+			if err != nil {
+				return fmt.Errorf("could not validate epoch setup: %w", err)
+			}
+			c.next = newEpoch(ev)
+
+		case *flow.EpochCommit:
+			if c.next == nil {
+				// a block sealing a commit without setup is invalid and can't be finalized
+				return fmt.Errorf("epoch commit for epoch %d without epoch setup", ev.Counter)
+			}
+			err := bprotocol.IsValidExtendingEpochCommit(ev, c.next.setup, c.current.setup, status)
+			if protocol.IsInvalidServiceEventError(err) {
+				c.fallback = true
+				continue
+			}
+			if err != nil {
+				return fmt.Errorf("could not validate epoch commit: %w", err)
+			}
+			err = c.next.commit(ev)
+			if err != nil {
+				return fmt.Errorf("could not commit epoch %d: %w", ev.Counter, err)
+			}
+		}
+	}
+	return nil
+}
+
+// VerifyHeaderFinalized verifies that the given header is finalized. The header
+// must not be older than the trusted snapshot the client was bootstrapped from.
+// Expected errors during normal operations:
+//  * ErrNotFinalized if the finality of the header can't be proven
+func (c *Client) VerifyHeaderFinalized(header *flow.Header) error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if header.Height < c.root.Height {
+		return fmt.Errorf("block height %d is below root height %d: %w", header.Height, c.root.Height, ErrNotFinalized)
+	}
+	finalizedID, ok := c.finalizedByHeight[header.Height]
+	if !ok {
+		return fmt.Errorf("no finalized block at height %d: %w", header.Height, ErrNotFinalized)
+	}
+	headerID := header.ID()
+	if headerID != finalizedID {
+		return fmt.Errorf("block %x conflicts with finalized block %x at height %d: %w", headerID, finalizedID, header.Height, ErrNotFinalized)
+	}
+	return nil
+}
+
+// VerifySealFinalized verifies that the given seal is included in the payload of
+// the given finalized block, which proves that the sealed execution result is
+// final.
+// Expected errors during normal operations:
+//  * ErrNotFinalized if the finality of the seal can't be proven
+func (c *Client) VerifySealFinalized(seal *flow.Seal, header *flow.Header, payload *flow.Payload) error {
+	err := c.VerifyHeaderFinalized(header)
+	if err != nil {
+		return err
+	}
+	if payload.Hash() != header.PayloadHash {
+		return fmt.Errorf("payload does not match payload hash of block %x: %w", header.ID(), ErrNotFinalized)
+	}
+	sealID := seal.ID()
+	for _, included := range payload.Seals {
+		if included.ID() == sealID {
+			return nil
+		}
+	}
+	return fmt.Errorf("seal %x is not included in block %x: %w", sealID, header.ID(), ErrNotFinalized)
+}
+
+// sealedServiceEvents returns the service events of the results sealed by the
+// highest block of the sealing segment.
+func sealedServiceEvents(segment *flow.SealingSegment) ([]flow.ServiceEvent, error) {
+	results := make([]*flow.ExecutionResult, 0, len(segment.ExecutionResults))
+	results = append(results, segment.ExecutionResults...)
+	for _, block := range segment.Blocks {
+		results = append(results, block.Payload.Results...)
+	}
+	return serviceEventsOfSeals(segment.Highest().Payload.Seals, results)
+}
+
+// serviceEventsOfSeals returns the service events of the results sealed by the
+// given seals, in the order of the seals. All sealed results must be provided.
+func serviceEventsOfSeals(seals []*flow.Seal, results []*flow.ExecutionResult) ([]flow.ServiceEvent, error) {
+	lookup := make(map[flow.Identifier]*flow.ExecutionResult, len(results))
+	for _, result := range results {
+		lookup[result.ID()] = result
+	}
+
+	var events []flow.ServiceEvent
+	for _, seal := range seals {
+		result, ok := lookup[seal.ResultID]
+		if !ok {
+			return nil, fmt.Errorf("missing result %x sealed by seal %x", seal.ResultID, seal.ID())
+		}
+		events = append(events, result.ServiceEvents...)
+	}
+	return events, nil
+}
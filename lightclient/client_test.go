@@ -0,0 +1,415 @@
+package lightclient
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/consensus/hotstuff"
+	"github.com/onflow/flow-go/consensus/hotstuff/mocks"
+	"github.com/onflow/flow-go/consensus/hotstuff/model"
+	"github.com/onflow/flow-go/crypto"
+	"github.com/onflow/flow-go/model/encodable"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/flow/filter"
+	"github.com/onflow/flow-go/model/flow/order"
+	"github.com/onflow/flow-go/state"
+	"github.com/onflow/flow-go/state/protocol/inmem"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// the current epoch of the test snapshot spans views [0, 100], its head has view 10
+const (
+	rootView  = 10
+	finalView = 100
+)
+
+// identitiesFixture returns a valid set of epoch participants with four
+// consensus nodes. The identities have no keys, as the QC verification is mocked.
+func identitiesFixture() flow.IdentityList {
+	roles := []flow.Role{
+		flow.RoleConsensus, flow.RoleConsensus, flow.RoleConsensus, flow.RoleConsensus,
+		flow.RoleCollection, flow.RoleExecution, flow.RoleVerification,
+	}
+	identities := make(flow.IdentityList, 0, len(roles))
+	for _, role := range roles {
+		nodeID := unittest.IdentifierFixture()
+		identities = append(identities, &flow.Identity{
+			NodeID:  nodeID,
+			Address: fmt.Sprintf("%x.flow:3569", nodeID[:4]),
+			Role:    role,
+			Weight:  100,
+		})
+	}
+	return identities.Sort(order.Canonical)
+}
+
+func setupFixture(counter, firstView, finalView uint64, identities flow.IdentityList) *flow.EpochSetup {
+	return &flow.EpochSetup{
+		Counter:      counter,
+		FirstView:    firstView,
+		FinalView:    finalView,
+		Participants: identities,
+		Assignments:  flow.AssignmentList{identities.Filter(filter.HasRole(flow.RoleCollection)).NodeIDs()},
+		RandomSource: unittest.SeedFixture(flow.EpochSetupRandomSourceLength),
+	}
+}
+
+// dkgKeyFixture returns a key standing in for a random beacon key. The QC
+// verification is mocked, so the key only needs to be encodable.
+func dkgKeyFixture() crypto.PublicKey {
+	return unittest.PrivateKeyFixture(crypto.Ed25519, crypto.KeyGenSeedMinLenEd25519).PublicKey()
+}
+
+func commitFixture(setup *flow.EpochSetup) *flow.EpochCommit {
+	consensus := setup.Participants.Filter(filter.IsValidDKGParticipant)
+	keys := make([]crypto.PublicKey, 0, len(consensus))
+	for range consensus {
+		keys = append(keys, dkgKeyFixture())
+	}
+	return &flow.EpochCommit{
+		Counter:            setup.Counter,
+		ClusterQCs:         make([]flow.ClusterQCVoteData, len(setup.Assignments)),
+		DKGGroupKey:        dkgKeyFixture(),
+		DKGParticipantKeys: keys,
+	}
+}
+
+// snapshotFixture returns a snapshot of the given committed epoch with a head
+// at view rootView, sealing the given results.
+func snapshotFixture(setup *flow.EpochSetup, results ...*flow.ExecutionResult) *inmem.Snapshot {
+	collectors := setup.Participants.Filter(filter.HasRole(flow.RoleCollection))
+	participants := make(map[flow.Identifier]flow.DKGParticipant)
+	for i, identity := range setup.Participants.Filter(filter.HasRole(flow.RoleConsensus)) {
+		participants[identity.NodeID] = flow.DKGParticipant{
+			Index:    uint(i),
+			KeyShare: dkgKeyFixture(),
+		}
+	}
+
+	payload := flow.EmptyPayload()
+	for _, result := range results {
+		payload.Seals = append(payload.Seals, unittest.Seal.Fixture(unittest.Seal.WithResult(result)))
+	}
+	head := unittest.BlockHeaderFixture()
+	head.ChainID = flow.Emulator
+	head.View = rootView
+	head.PayloadHash = payload.Hash()
+
+	return inmem.SnapshotFromEncodable(inmem.EncodableSnapshot{
+		Head: &head,
+		SealingSegment: &flow.SealingSegment{
+			Blocks:           []*flow.Block{{Header: &head, Payload: &payload}},
+			ExecutionResults: results,
+		},
+		Phase: flow.EpochPhaseStaking,
+		Epochs: inmem.EncodableEpochs{
+			Current: inmem.EncodableEpoch{
+				Counter:           setup.Counter,
+				FirstView:         setup.FirstView,
+				FinalView:         setup.FinalView,
+				RandomSource:      setup.RandomSource,
+				InitialIdentities: setup.Participants,
+				Clustering:        flow.ClusterList{collectors},
+				Clusters: []inmem.EncodableCluster{{
+					Index:   0,
+					Counter: setup.Counter,
+					Members: collectors,
+				}},
+				DKG: &inmem.EncodableDKG{
+					GroupKey:     encodable.RandomBeaconPubKey{PublicKey: dkgKeyFixture()},
+					Participants: participants,
+				},
+			},
+		},
+		Params: inmem.EncodableParams{
+			ChainID: flow.Emulator,
+		},
+	})
+}
+
+// clientFixture creates a light client verifying QCs with the given verifier.
+func clientFixture(t *testing.T, snapshot *inmem.Snapshot, verifier hotstuff.Verifier) *Client {
+	client, err := New(snapshot)
+	require.NoError(t, err)
+	client.newVerifier = func(hotstuff.Committee) hotstuff.Verifier {
+		return verifier
+	}
+	return client
+}
+
+func acceptingVerifier() *mocks.Verifier {
+	verifier := &mocks.Verifier{}
+	verifier.On("VerifyQC", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	return verifier
+}
+
+// childFixture returns a child of the given parent with the given view, whose
+// QC for the parent is signed by the given voters.
+func childFixture(parent *flow.Header, view uint64, voters flow.IdentityList) *flow.Header {
+	child := unittest.BlockHeaderWithParentFixture(parent)
+	child.View = view
+	child.ParentVoterIDs = voters.NodeIDs()
+	return &child
+}
+
+// extendViews extends the client with blocks for each of the given views and
+// returns the last block.
+func extendViews(t *testing.T, client *Client, parent *flow.Header, voters flow.IdentityList, views ...uint64) *flow.Header {
+	for _, view := range views {
+		child := childFixture(parent, view, voters)
+		require.NoError(t, client.Extend(&Block{Header: child}))
+		parent = child
+	}
+	return parent
+}
+
+func TestExtend_Finalization(t *testing.T) {
+	identities := identitiesFixture()
+	consensus := identities.Filter(filter.HasRole(flow.RoleConsensus))
+	snapshot := snapshotFixture(setupFixture(1, 0, finalView, identities))
+	root, err := snapshot.Head()
+	require.NoError(t, err)
+	client := clientFixture(t, snapshot, acceptingVerifier())
+
+	// root <- 11 <- 12 <- 13: the QC for 12 is not known yet
+	b11 := childFixture(root, 11, consensus)
+	b12 := childFixture(b11, 12, consensus)
+	b13 := childFixture(b12, 13, consensus)
+	for _, block := range []*flow.Header{b11, b12, b13} {
+		require.NoError(t, client.Extend(&Block{Header: block}))
+	}
+	assert.Equal(t, root.ID(), client.Finalized().ID())
+
+	// the QC for 13 in 14 finalizes 11
+	b14 := childFixture(b13, 14, consensus)
+	require.NoError(t, client.Extend(&Block{Header: b14}))
+	assert.Equal(t, b11.ID(), client.Finalized().ID())
+
+	// 16 finalizes 12, but 13 doesn't have a direct 2-chain on top of it
+	b16 := childFixture(b14, 16, consensus)
+	require.NoError(t, client.Extend(&Block{Header: b16}))
+	assert.Equal(t, b12.ID(), client.Finalized().ID())
+	b18 := extendViews(t, client, b16, consensus, 17, 18)
+	assert.Equal(t, b12.ID(), client.Finalized().ID())
+
+	// 19 finalizes 16 and all its ancestors
+	extendViews(t, client, b18, consensus, 19)
+	assert.Equal(t, b16.ID(), client.Finalized().ID())
+	for _, block := range []*flow.Header{root, b11, b12, b13, b14, b16} {
+		assert.NoError(t, client.VerifyHeaderFinalized(block))
+	}
+	assert.ErrorIs(t, client.VerifyHeaderFinalized(b18), ErrNotFinalized)
+}
+
+func TestExtend_InvalidQC(t *testing.T) {
+	identities := identitiesFixture()
+	consensus := identities.Filter(filter.HasRole(flow.RoleConsensus))
+	snapshot := snapshotFixture(setupFixture(1, 0, finalView, identities))
+	root, err := snapshot.Head()
+	require.NoError(t, err)
+
+	t.Run("invalid signature", func(t *testing.T) {
+		verifier := &mocks.Verifier{}
+		verifier.On("VerifyQC", mock.Anything, mock.Anything, mock.Anything).Return(model.ErrInvalidSignature)
+		client := clientFixture(t, snapshot, verifier)
+
+		err := client.Extend(&Block{Header: childFixture(root, 11, consensus)})
+		assert.True(t, state.IsInvalidExtensionError(err))
+	})
+
+	t.Run("insufficient weight", func(t *testing.T) {
+		client := clientFixture(t, snapshot, acceptingVerifier())
+
+		err := client.Extend(&Block{Header: childFixture(root, 11, consensus[:2])})
+		assert.True(t, state.IsInvalidExtensionError(err))
+	})
+
+	t.Run("signer outside of committee", func(t *testing.T) {
+		client := clientFixture(t, snapshot, acceptingVerifier())
+
+		voters := append(consensus[:3].Copy(), identities.Filter(filter.HasRole(flow.RoleExecution))...)
+		err := client.Extend(&Block{Header: childFixture(root, 11, voters)})
+		assert.True(t, state.IsInvalidExtensionError(err))
+	})
+
+	t.Run("verification failure", func(t *testing.T) {
+		exception := errors.New("exception")
+		verifier := &mocks.Verifier{}
+		verifier.On("VerifyQC", mock.Anything, mock.Anything, mock.Anything).Return(exception)
+		client := clientFixture(t, snapshot, verifier)
+
+		err := client.Extend(&Block{Header: childFixture(root, 11, consensus)})
+		assert.ErrorIs(t, err, exception)
+		assert.False(t, state.IsInvalidExtensionError(err))
+	})
+}
+
+func TestExtend_InvalidChain(t *testing.T) {
+	identities := identitiesFixture()
+	consensus := identities.Filter(filter.HasRole(flow.RoleConsensus))
+	snapshot := snapshotFixture(setupFixture(1, 0, finalView, identities))
+	root, err := snapshot.Head()
+	require.NoError(t, err)
+	client := clientFixture(t, snapshot, acceptingVerifier())
+
+	child := childFixture(root, 11, consensus)
+	child.ChainID = flow.Mainnet
+	assert.True(t, state.IsInvalidExtensionError(client.Extend(&Block{Header: child})))
+
+	child = childFixture(root, 11, consensus)
+	child.ParentID = unittest.IdentifierFixture()
+	assert.True(t, state.IsInvalidExtensionError(client.Extend(&Block{Header: child})))
+
+	child = childFixture(root, 11, consensus)
+	child.Height++
+	assert.True(t, state.IsInvalidExtensionError(client.Extend(&Block{Header: child})))
+
+	child = childFixture(root, rootView, consensus)
+	assert.True(t, state.IsInvalidExtensionError(client.Extend(&Block{Header: child})))
+
+	child = childFixture(root, 11, consensus)
+	child.PayloadHash = unittest.IdentifierFixture()
+	payload := flow.EmptyPayload()
+	assert.True(t, state.IsInvalidExtensionError(client.Extend(&Block{Header: child, Payload: &payload})))
+
+	// none of the invalid blocks were added
+	require.NoError(t, client.Extend(&Block{Header: childFixture(root, 11, consensus)}))
+}
+
+func TestExtend_EpochTransition(t *testing.T) {
+	identities := identitiesFixture()
+	consensus := identities.Filter(filter.HasRole(flow.RoleConsensus))
+	setup := setupFixture(1, 0, finalView, identities)
+
+	nextIdentities := identitiesFixture()
+	nextConsensus := nextIdentities.Filter(filter.HasRole(flow.RoleConsensus))
+	nextSetup := setupFixture(2, finalView+1, 2*finalView, nextIdentities)
+	nextCommit := commitFixture(nextSetup)
+
+	// the snapshot head seals the setup event, which takes effect in its children
+	setupResult := unittest.ExecutionResultFixture()
+	setupResult.ServiceEvents = []flow.ServiceEvent{nextSetup.ServiceEvent()}
+	snapshot := snapshotFixture(setup, setupResult)
+	root, err := snapshot.Head()
+	require.NoError(t, err)
+
+	// QCs must be signed by the committee of the epoch of the certified block
+	verifier := &mocks.Verifier{}
+	verifier.On("VerifyQC", mock.Anything, mock.Anything, mock.Anything).Return(
+		func(voters flow.IdentityList, _ []byte, block *model.Block) error {
+			committee := consensus
+			if block.View > finalView {
+				committee = nextConsensus
+			}
+			if len(voters.Filter(filter.HasNodeID(committee.NodeIDs()...))) != len(voters) {
+				return model.ErrInvalidSignature
+			}
+			return nil
+		})
+	client := clientFixture(t, snapshot, verifier)
+
+	// the next epoch is not yet committed
+	parent := extendViews(t, client, root, consensus, 11, 12, 13, 14)
+	assert.True(t, client.next.containsView(finalView+1))
+	assert.False(t, client.next.committed())
+
+	// seal the commit event with block 15
+	commitResult := unittest.ExecutionResultFixture()
+	commitResult.ServiceEvents = []flow.ServiceEvent{nextCommit.ServiceEvent()}
+	payload := flow.EmptyPayload()
+	payload.Seals = []*flow.Seal{unittest.Seal.Fixture(unittest.Seal.WithResult(commitResult))}
+	b15 := childFixture(parent, 15, consensus)
+	b15.PayloadHash = payload.Hash()
+	require.NoError(t, client.Extend(&Block{Header: b15, Payload: &payload, Results: []*flow.ExecutionResult{commitResult}}))
+
+	// the commit takes effect once block 15 is finalized
+	parent = extendViews(t, client, b15, consensus, 16, 17)
+	assert.False(t, client.next.committed())
+	parent = extendViews(t, client, parent, consensus, 18)
+	assert.True(t, client.next.committed())
+
+	// continue into the next epoch, which is signed by the next committee
+	for view := uint64(19); view <= finalView+1; view++ {
+		parent = extendViews(t, client, parent, consensus, view)
+	}
+	err = client.Extend(&Block{Header: childFixture(parent, finalView+2, consensus)})
+	assert.True(t, state.IsInvalidExtensionError(err))
+	parent = extendViews(t, client, parent, nextConsensus, finalView+2, finalView+3, finalView+4)
+
+	assert.Equal(t, uint64(finalView+1), client.Finalized().View)
+	assert.Equal(t, nextSetup.Counter, client.current.setup.Counter)
+	assert.Nil(t, client.next)
+}
+
+func TestExtend_UnknownEpoch(t *testing.T) {
+	identities := identitiesFixture()
+	consensus := identities.Filter(filter.HasRole(flow.RoleConsensus))
+	snapshot := snapshotFixture(setupFixture(1, 0, finalView, identities))
+	root, err := snapshot.Head()
+	require.NoError(t, err)
+	client := clientFixture(t, snapshot, acceptingVerifier())
+
+	parent := extendViews(t, client, root, consensus, 11, finalView, finalView+1)
+	err = client.Extend(&Block{Header: childFixture(parent, finalView+2, consensus)})
+	assert.ErrorIs(t, err, ErrUnknownEpoch)
+}
+
+// an invalid epoch setup event triggers epoch emergency fallback, after which
+// the committee of the current epoch continues past its final view
+func TestExtend_EpochFallback(t *testing.T) {
+	identities := identitiesFixture()
+	consensus := identities.Filter(filter.HasRole(flow.RoleConsensus))
+	setup := setupFixture(1, 0, finalView, identities)
+
+	invalidSetup := setupFixture(3, finalView+1, 2*finalView, identitiesFixture())
+	result := unittest.ExecutionResultFixture()
+	result.ServiceEvents = []flow.ServiceEvent{invalidSetup.ServiceEvent()}
+	snapshot := snapshotFixture(setup, result)
+	root, err := snapshot.Head()
+	require.NoError(t, err)
+	client := clientFixture(t, snapshot, acceptingVerifier())
+	assert.True(t, client.fallback)
+	assert.Nil(t, client.next)
+
+	parent := extendViews(t, client, root, consensus, 11, finalView, finalView+1, finalView+2, finalView+3, finalView+4)
+	assert.Equal(t, uint64(finalView+1), client.Finalized().View)
+	assert.NoError(t, client.VerifyHeaderFinalized(client.Finalized()))
+	assert.ErrorIs(t, client.VerifyHeaderFinalized(parent), ErrNotFinalized)
+}
+
+func TestVerifySealFinalized(t *testing.T) {
+	identities := identitiesFixture()
+	consensus := identities.Filter(filter.HasRole(flow.RoleConsensus))
+	snapshot := snapshotFixture(setupFixture(1, 0, finalView, identities))
+	root, err := snapshot.Head()
+	require.NoError(t, err)
+	client := clientFixture(t, snapshot, acceptingVerifier())
+
+	result := unittest.ExecutionResultFixture()
+	seal := unittest.Seal.Fixture(unittest.Seal.WithResult(result))
+	payload := flow.EmptyPayload()
+	payload.Seals = []*flow.Seal{seal}
+	block := childFixture(root, 11, consensus)
+	block.PayloadHash = payload.Hash()
+	require.NoError(t, client.Extend(&Block{Header: block}))
+
+	// the block is not finalized yet
+	assert.ErrorIs(t, client.VerifySealFinalized(seal, block, &payload), ErrNotFinalized)
+
+	extendViews(t, client, block, consensus, 12, 13, 14)
+	assert.NoError(t, client.VerifySealFinalized(seal, block, &payload))
+
+	// the seal is not included in the block
+	other := unittest.Seal.Fixture()
+	assert.ErrorIs(t, client.VerifySealFinalized(other, block, &payload), ErrNotFinalized)
+
+	// the payload is not the payload of the block
+	forged := flow.EmptyPayload()
+	forged.Seals = []*flow.Seal{seal, other}
+	assert.ErrorIs(t, client.VerifySealFinalized(other, block, &forged), ErrNotFinalized)
+}
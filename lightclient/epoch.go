@@ -0,0 +1,147 @@
+package lightclient
+
+import (
+	"fmt"
+
+	"github.com/onflow/flow-go/consensus/hotstuff"
+	"github.com/onflow/flow-go/consensus/hotstuff/model"
+	"github.com/onflow/flow-go/consensus/hotstuff/validator"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/flow/filter"
+	"github.com/onflow/flow-go/state/protocol"
+	"github.com/onflow/flow-go/state/protocol/inmem"
+)
+
+// epoch is the information about an epoch the light client needs to verify
+// the quorum certificates of blocks within the epoch.
+type epoch struct {
+	setup     *flow.EpochSetup
+	commitID  flow.Identifier   // zero until the epoch is committed
+	dkg       protocol.DKG      // nil until the epoch is committed
+	committee flow.IdentityList // consensus participants allowed to vote
+	validator hotstuff.Validator
+}
+
+func newEpoch(setup *flow.EpochSetup) *epoch {
+	return &epoch{
+		setup:     setup,
+		committee: setup.Participants.Filter(filter.IsVotingConsensusCommitteeMember),
+	}
+}
+
+// epochFromProtocol converts an epoch of the trusted snapshot. As the snapshot
+// doesn't contain the service events, the setup event is reconstructed from the
+// epoch information.
+func epochFromProtocol(from protocol.Epoch, committed bool) (*epoch, error) {
+	converted, err := inmem.FromEpoch(from)
+	if err != nil {
+		return nil, fmt.Errorf("could not convert epoch: %w", err)
+	}
+	enc := converted.Encodable()
+
+	setup := &flow.EpochSetup{
+		Counter:            enc.Counter,
+		FirstView:          enc.FirstView,
+		DKGPhase1FinalView: enc.DKGPhase1FinalView,
+		DKGPhase2FinalView: enc.DKGPhase2FinalView,
+		DKGPhase3FinalView: enc.DKGPhase3FinalView,
+		FinalView:          enc.FinalView,
+		Participants:       enc.InitialIdentities,
+		Assignments:        enc.Clustering.Assignments(),
+		RandomSource:       enc.RandomSource,
+	}
+	e := newEpoch(setup)
+	if !committed {
+		return e, nil
+	}
+
+	dkg, err := converted.DKG()
+	if err != nil {
+		return nil, fmt.Errorf("could not get dkg of committed epoch: %w", err)
+	}
+	e.dkg = dkg
+	// the snapshot doesn't contain the commit event. Its ID is only used to
+	// record that the epoch is committed, so the setup ID stands in for it.
+	e.commitID = setup.ID()
+	return e, nil
+}
+
+// commit marks the epoch as committed with the given EpochCommit service event.
+func (e *epoch) commit(commit *flow.EpochCommit) error {
+	committed, err := inmem.NewCommittedEpoch(e.setup, commit)
+	if err != nil {
+		return fmt.Errorf("could not create committed epoch: %w", err)
+	}
+	dkg, err := committed.DKG()
+	if err != nil {
+		return fmt.Errorf("could not get dkg of committed epoch: %w", err)
+	}
+	e.dkg = dkg
+	e.commitID = commit.ID()
+	return nil
+}
+
+func (e *epoch) committed() bool {
+	return e.dkg != nil
+}
+
+// eventIDs returns the IDs of the service events of the epoch observed so far.
+func (e *epoch) eventIDs() flow.EventIDs {
+	return flow.EventIDs{
+		SetupID:  e.setup.ID(),
+		CommitID: e.commitID,
+	}
+}
+
+func (e *epoch) containsView(view uint64) bool {
+	return e.setup.FirstView <= view && view <= e.setup.FinalView
+}
+
+// epochCommittee is the static consensus committee of a single epoch, as
+// defined by its setup and commit service events. As the light client doesn't
+// follow slashing or ejections within the epoch, the committee is the initial
+// committee of the epoch.
+type epochCommittee struct {
+	epoch *epoch
+}
+
+var _ hotstuff.Committee = (*epochCommittee)(nil)
+
+func (c *epochCommittee) Identities(_ flow.Identifier, selector flow.IdentityFilter) (flow.IdentityList, error) {
+	return c.epoch.committee.Filter(selector), nil
+}
+
+func (c *epochCommittee) Identity(_ flow.Identifier, participantID flow.Identifier) (*flow.Identity, error) {
+	identity, ok := c.epoch.committee.ByNodeID(participantID)
+	if !ok {
+		return nil, model.NewInvalidSignerErrorf("node %v is not an authorized hotstuff voting participant", participantID)
+	}
+	return identity, nil
+}
+
+// LeaderForView is not supported, as the light client only verifies QCs.
+func (c *epochCommittee) LeaderForView(uint64) (flow.Identifier, error) {
+	return flow.ZeroID, fmt.Errorf("leader selection is not supported by the light client")
+}
+
+// Self returns the zero ID, as the light client is not a consensus participant.
+func (c *epochCommittee) Self() flow.Identifier {
+	return flow.ZeroID
+}
+
+func (c *epochCommittee) DKG(flow.Identifier) (hotstuff.DKG, error) {
+	if c.epoch.dkg == nil {
+		return nil, protocol.ErrEpochNotCommitted
+	}
+	return c.epoch.dkg, nil
+}
+
+// qcValidator returns the validator for quorum certificates of the epoch. The
+// validator is only used to validate QCs, so no forks are required.
+func (e *epoch) qcValidator(newVerifier func(hotstuff.Committee) hotstuff.Verifier) hotstuff.Validator {
+	if e.validator == nil {
+		committee := &epochCommittee{epoch: e}
+		e.validator = validator.New(committee, nil, newVerifier(committee))
+	}
+	return e.validator
+}
@@ -124,7 +124,7 @@ func (a AccountPrivateKey) MarshalJSON() ([]byte, error) {
 // CompatibleAlgorithms returns true if the signature and hash algorithms are compatible.
 func CompatibleAlgorithms(sigAlgo crypto.SigningAlgorithm, hashAlgo hash.HashingAlgorithm) bool {
 	switch sigAlgo {
-	case crypto.ECDSAP256, crypto.ECDSASecp256k1:
+	case crypto.ECDSAP256, crypto.ECDSASecp256k1, crypto.Ed25519:
 		switch hashAlgo {
 		case hash.SHA2_256, hash.SHA3_256:
 			return true
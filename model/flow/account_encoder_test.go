@@ -1,6 +1,7 @@
 package flow_test
 
 import (
+	"crypto/rand"
 	"encoding/hex"
 	"testing"
 
@@ -59,3 +60,79 @@ func TestDecodeAccountPublicKey_Legacy(t *testing.T) {
 	// legacy account key should not be revoked
 	assert.False(t, accountKey.Revoked)
 }
+
+func TestAccountKeyEncoding_Ed25519(t *testing.T) {
+	seed := make([]byte, crypto.KeyGenSeedMinLenEd25519)
+	_, err := rand.Read(seed)
+	require.NoError(t, err)
+
+	privateKey, err := crypto.GeneratePrivateKey(crypto.Ed25519, seed)
+	require.NoError(t, err)
+
+	accountPrivateKey := flow.AccountPrivateKey{
+		PrivateKey: privateKey,
+		SignAlgo:   crypto.Ed25519,
+		HashAlgo:   hash.SHA3_256,
+	}
+	accountKey := accountPrivateKey.PublicKey(1000)
+	accountKey.SeqNumber = 42
+	require.NoError(t, accountKey.Validate())
+
+	t.Run("public key", func(t *testing.T) {
+		b, err := flow.EncodeAccountPublicKey(accountKey)
+		require.NoError(t, err)
+
+		decoded, err := flow.DecodeAccountPublicKey(b, 0)
+		require.NoError(t, err)
+
+		assert.True(t, accountKey.PublicKey.Equals(decoded.PublicKey))
+		assert.Equal(t, crypto.Ed25519, decoded.SignAlgo)
+		assert.Equal(t, hash.SHA3_256, decoded.HashAlgo)
+		assert.Equal(t, 1000, decoded.Weight)
+		assert.Equal(t, uint64(42), decoded.SeqNumber)
+	})
+
+	t.Run("runtime public key", func(t *testing.T) {
+		b, err := flow.EncodeRuntimeAccountPublicKey(accountKey)
+		require.NoError(t, err)
+
+		decoded, err := flow.DecodeRuntimeAccountPublicKey(b, 0)
+		require.NoError(t, err)
+
+		assert.True(t, accountKey.PublicKey.Equals(decoded.PublicKey))
+		assert.Equal(t, crypto.Ed25519, decoded.SignAlgo)
+		assert.Equal(t, hash.SHA3_256, decoded.HashAlgo)
+		assert.Equal(t, 1000, decoded.Weight)
+	})
+
+	t.Run("private key", func(t *testing.T) {
+		b, err := flow.EncodeAccountPrivateKey(accountPrivateKey)
+		require.NoError(t, err)
+
+		decoded, err := flow.DecodeAccountPrivateKey(b)
+		require.NoError(t, err)
+
+		assert.True(t, privateKey.Equals(decoded.PrivateKey))
+		assert.Equal(t, crypto.Ed25519, decoded.SignAlgo)
+		assert.Equal(t, hash.SHA3_256, decoded.HashAlgo)
+	})
+
+	t.Run("invalid public key", func(t *testing.T) {
+		w := struct {
+			PublicKey []byte
+			SignAlgo  uint
+			HashAlgo  uint
+			Weight    uint
+		}{
+			PublicKey: privateKey.Encode()[:crypto.PubKeyLenEd25519-1],
+			SignAlgo:  uint(crypto.Ed25519),
+			HashAlgo:  uint(hash.SHA3_256),
+			Weight:    1000,
+		}
+		b, err := rlp.EncodeToBytes(&w)
+		require.NoError(t, err)
+
+		_, err = flow.DecodeRuntimeAccountPublicKey(b, 0)
+		require.Error(t, err)
+	})
+}
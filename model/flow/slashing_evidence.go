@@ -0,0 +1,108 @@
+package flow
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// SlashingViolation is the type of a slashable protocol violation detected by HotStuff.
+type SlashingViolation uint8
+
+const (
+	// SlashingViolationDoubleVote is a replica voting for two different blocks at the same view.
+	SlashingViolationDoubleVote SlashingViolation = iota + 1
+	// SlashingViolationDoubleProposal is a leader proposing two different blocks at the same view.
+	SlashingViolationDoubleProposal
+	// SlashingViolationInvalidVote is a replica sending a vote with an invalid signature.
+	SlashingViolationInvalidVote
+	// SlashingViolationVoteForInvalidBlock is a replica voting for an invalid proposal.
+	SlashingViolationVoteForInvalidBlock
+)
+
+func (v SlashingViolation) String() string {
+	switch v {
+	case SlashingViolationDoubleVote:
+		return "double_vote"
+	case SlashingViolationDoubleProposal:
+		return "double_proposal"
+	case SlashingViolationInvalidVote:
+		return "invalid_vote"
+	case SlashingViolationVoteForInvalidBlock:
+		return "vote_for_invalid_block"
+	default:
+		return fmt.Sprintf("unknown_violation_%d", uint8(v))
+	}
+}
+
+// ParseSlashingViolation parses the string representation of a slashing violation.
+func ParseSlashingViolation(s string) (SlashingViolation, error) {
+	for v := SlashingViolationDoubleVote; v <= SlashingViolationVoteForInvalidBlock; v++ {
+		if v.String() == s {
+			return v, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown slashing violation: %s", s)
+}
+
+func (v SlashingViolation) MarshalJSON() ([]byte, error) {
+	return json.Marshal(v.String())
+}
+
+func (v *SlashingViolation) UnmarshalJSON(data []byte) error {
+	var s string
+	err := json.Unmarshal(data, &s)
+	if err != nil {
+		return err
+	}
+	*v, err = ParseSlashingViolation(s)
+	return err
+}
+
+// SignedVote is a signature by a consensus participant over a block at a view, as
+// carried by HotStuff votes and proposals. The signed message is the ID of the
+// struct {BlockID, View}, which is returned by Message. SigData is the signature
+// data exactly as it was received, encoded as in the votes and proposals of the
+// respective consensus committee.
+type SignedVote struct {
+	View     uint64
+	BlockID  Identifier
+	SignerID Identifier
+	SigData  []byte
+}
+
+// Message returns the message signed by the signer of the vote.
+func (v SignedVote) Message() []byte {
+	msg := MakeID(struct {
+		BlockID Identifier
+		View    uint64
+	}{
+		BlockID: v.BlockID,
+		View:    v.View,
+	})
+	return msg[:]
+}
+
+// SlashingEvidence is the evidence of a slashable violation of the consensus protocol,
+// containing the signed messages necessary for a third party to independently verify
+// the violation against the offender's staking and random beacon keys:
+//   - double vote: the two conflicting votes by the offender
+//   - double proposal: the two conflicting proposals by the offender, as signed votes
+//   - invalid vote: the vote with the invalid signature
+//   - vote for invalid block: the vote by the offender, followed by the invalid proposal
+type SlashingEvidence struct {
+	Violation  SlashingViolation
+	ChainID    ChainID
+	OffenderID Identifier
+	View       uint64
+	Votes      []SignedVote
+}
+
+// ID returns a unique identifier for the evidence.
+func (e *SlashingEvidence) ID() Identifier {
+	return MakeID(e)
+}
+
+// Checksum returns a checksum of the evidence.
+func (e *SlashingEvidence) Checksum() Identifier {
+	return MakeID(e)
+}
@@ -0,0 +1,13 @@
+package flow
+
+// TransactionRegisterSet contains the registers a transaction read and wrote
+// while it was executed.
+type TransactionRegisterSet struct {
+	TransactionID    Identifier
+	TransactionIndex uint32
+	// ReadSet contains the registers whose value the transaction read before
+	// writing them, if at all.
+	ReadSet []RegisterID
+	// WriteSet contains the registers the transaction wrote.
+	WriteSet []RegisterID
+}
@@ -0,0 +1,65 @@
+package flow
+
+import "github.com/onflow/flow-go/fvm/profiling"
+
+// TransactionSimulationOptions configures which checks are performed when
+// simulating a transaction. Skipping checks allows simulating transactions that
+// are not yet signed, or that use an outdated sequence number.
+type TransactionSimulationOptions struct {
+	// SkipSignatureCheck disables the verification of the payload and envelope signatures.
+	SkipSignatureCheck bool
+	// SkipSequenceNumberCheck disables the check and increment of the proposal key sequence number.
+	SkipSequenceNumberCheck bool
+	// Profile enables profiling the computation and the time used by the functions and
+	// environment calls of the transaction.
+	Profile bool
+}
+
+// StorageUsedChange is the change of the storage used by an account caused by
+// a simulated transaction, in bytes.
+type StorageUsedChange struct {
+	Address Address
+	Before  uint64
+	After   uint64
+}
+
+// Delta returns the difference in storage used, which is negative if the
+// transaction freed storage.
+func (c StorageUsedChange) Delta() int64 {
+	return int64(c.After) - int64(c.Before)
+}
+
+// Status codes of a simulated transaction, matching the status codes of the
+// transaction results served by execution nodes.
+const (
+	TransactionStatusCodeSucceeded uint = 0
+	TransactionStatusCodeFailed    uint = 1
+)
+
+// TransactionSimulationResult contains the artifacts generated by executing a
+// transaction against the execution state at a block, without committing its
+// effects.
+type TransactionSimulationResult struct {
+	// BlockID is the ID of the block whose execution state the transaction was simulated against.
+	BlockID Identifier
+	// StatusCode is TransactionStatusCodeSucceeded if the transaction executed
+	// successfully and TransactionStatusCodeFailed if it failed.
+	StatusCode uint
+	// ErrorMessage contains the error message if the transaction failed.
+	ErrorMessage string
+	// ComputationUsed is the computation used by the transaction.
+	ComputationUsed uint64
+	// Events contains the events the transaction would emit.
+	Events []Event
+	// StorageUsedChanges contains the change of storage used for every account the transaction writes to.
+	StorageUsedChanges []StorageUsedChange
+	// EstimatedFees are the fees the payer would be charged, as a UFix64 value.
+	EstimatedFees uint64
+	// Profile is the profile of the transaction, only set if profiling was requested.
+	Profile *profiling.Profile
+}
+
+// Failed returns true if the simulated transaction failed.
+func (r *TransactionSimulationResult) Failed() bool {
+	return r.StatusCode != TransactionStatusCodeSucceeded
+}
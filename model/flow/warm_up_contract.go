@@ -0,0 +1,11 @@
+package flow
+
+// WarmUpContract identifies a contract whose program was loaded while executing
+// blocks, together with the hash of its code. Programs can't be serialized, so the
+// programs of these contracts are parsed and checked again when the node starts,
+// to warm up the programs cache before the first blocks are executed.
+type WarmUpContract struct {
+	Address  Address
+	Name     string
+	CodeHash Identifier
+}
@@ -258,6 +258,12 @@ func CheckMachineAccountInfo(
 	if !accountKey.PublicKey.Equals(privKey.PublicKey()) {
 		return fmt.Errorf("machine account public key mismatch between local and on-chain")
 	}
+	if accountKey.Revoked {
+		return fmt.Errorf("machine account key (index %d) is revoked", accountKey.Index)
+	}
+	if accountKey.Weight < sdk.AccountKeyWeightThreshold {
+		return fmt.Errorf("machine account key weight is below signing threshold (%d < %d)", accountKey.Weight, sdk.AccountKeyWeightThreshold)
+	}
 
 	// THIRD - check that the balance is sufficient
 	balance := cadence.UFix64(account.Balance)
@@ -0,0 +1,161 @@
+package epochs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/onflow/cadence"
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/engine"
+	"github.com/onflow/flow-go/model/bootstrap"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module"
+)
+
+const (
+	// DefaultMachineAccountCheckInterval is the default interval between two
+	// checks of the machine account by the MachineAccountMonitor.
+	DefaultMachineAccountCheckInterval = time.Minute * 10
+
+	// machineAccountCheckTimeout bounds the time a single check of the machine account may take.
+	machineAccountCheckTimeout = time.Second * 30
+
+	// ufix64Factor is the scaling factor of cadence.UFix64, ie. the number of
+	// balance units per FLOW.
+	ufix64Factor = 100_000_000
+)
+
+// MachineAccountMonitor periodically checks the machine account of a collection
+// or consensus node through the access API, for the lifetime of the node.
+//
+// Unlike the MachineAccountConfigValidator, which only confirms the configuration
+// once at startup, the monitor detects problems that appear while the node is
+// running: a balance running low, a revoked key, or a key whose weight no longer
+// allows it to sign. It reports the balance, key weight and sequence number as
+// metrics, and logs a warning when the balance falls below the recommended
+// balance for the next epoch.
+type MachineAccountMonitor struct {
+	unit     *engine.Unit
+	log      zerolog.Logger
+	metrics  module.MachineAccountMetrics
+	client   module.SDKClientWrapper
+	config   MachineAccountValidatorConfig
+	role     flow.Role
+	info     bootstrap.NodeMachineAccountInfo
+	interval time.Duration
+}
+
+// NewMachineAccountMonitor returns a monitor checking the given machine account
+// every interval.
+func NewMachineAccountMonitor(
+	log zerolog.Logger,
+	metrics module.MachineAccountMetrics,
+	flowClient module.SDKClientWrapper,
+	role flow.Role,
+	info bootstrap.NodeMachineAccountInfo,
+	interval time.Duration,
+	opts ...MachineAccountValidatorConfigOption,
+) (*MachineAccountMonitor, error) {
+
+	if role != flow.RoleCollection && role != flow.RoleConsensus {
+		return nil, fmt.Errorf("invalid role (%s) must be one of [collection, consensus]", role.String())
+	}
+	if interval <= 0 {
+		return nil, fmt.Errorf("machine account check interval must be positive, got: %v", interval)
+	}
+
+	conf := DefaultMachineAccountValidatorConfig()
+	for _, apply := range opts {
+		apply(&conf)
+	}
+
+	monitor := &MachineAccountMonitor{
+		unit: engine.NewUnit(),
+		log: log.With().
+			Str("component", "machine_account_monitor").
+			Str("machine_account_address", info.Address).
+			Logger(),
+		metrics:  metrics,
+		client:   flowClient,
+		config:   conf,
+		role:     role,
+		info:     info,
+		interval: interval,
+	}
+	return monitor, nil
+}
+
+// Ready starts checking the machine account periodically.
+func (m *MachineAccountMonitor) Ready() <-chan struct{} {
+	m.unit.LaunchPeriodically(m.check, m.interval, 0)
+	return m.unit.Ready()
+}
+
+// Done stops checking the machine account.
+func (m *MachineAccountMonitor) Done() <-chan struct{} {
+	return m.unit.Done()
+}
+
+// check is a wrapper around checkMachineAccount with logging.
+func (m *MachineAccountMonitor) check() {
+	err := m.checkMachineAccount(m.unit.Ctx())
+	if err != nil {
+		m.log.Error().Err(err).Msg("could not check machine account")
+	}
+}
+
+// checkMachineAccount retrieves the machine account, reports its balance and
+// key status as metrics, and logs any misconfiguration. It returns an error only
+// if the account could not be retrieved.
+func (m *MachineAccountMonitor) checkMachineAccount(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, machineAccountCheckTimeout)
+	defer cancel()
+
+	account, err := m.client.GetAccount(ctx, m.info.SDKAddress())
+	if err != nil {
+		return fmt.Errorf("could not get machine account: %w", err)
+	}
+
+	balance := cadence.UFix64(account.Balance)
+	recommended := m.recommendedMinBalance()
+	m.metrics.AccountBalance(toFLOW(balance))
+	m.metrics.RecommendedMinBalance(toFLOW(recommended))
+
+	if len(account.Keys) > int(m.info.KeyIndex) {
+		key := account.Keys[m.info.KeyIndex]
+		weight := key.Weight
+		if key.Revoked {
+			weight = 0
+		}
+		m.metrics.AccountKeyWeight(weight)
+		m.metrics.AccountSequenceNumber(key.SequenceNumber)
+	} else {
+		m.metrics.AccountKeyWeight(0)
+	}
+
+	// CheckMachineAccountInfo logs non-critical issues itself, including a
+	// warning when the balance is below the recommended balance
+	err = CheckMachineAccountInfo(m.log, m.config, m.role, m.info, account)
+	m.metrics.IsMisconfigured(err != nil)
+	if err != nil {
+		m.log.Error().Err(err).Msg("critical machine account misconfiguration")
+	}
+
+	return nil
+}
+
+// recommendedMinBalance returns the balance the machine account should hold to
+// cover the epoch transactions of the node's role for the next epoch.
+func (m *MachineAccountMonitor) recommendedMinBalance() cadence.UFix64 {
+	if m.role == flow.RoleCollection {
+		return m.config.SoftMinBalanceLN
+	}
+	return m.config.SoftMinBalanceSN
+}
+
+// toFLOW converts a UFix64 balance to a floating point amount of FLOW.
+func toFLOW(balance cadence.UFix64) float64 {
+	return float64(balance) / ufix64Factor
+}
@@ -0,0 +1,92 @@
+package epochs
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go/model/flow"
+	modulemock "github.com/onflow/flow-go/module/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestMachineAccountMonitor tests that the machine account monitor reports the
+// state of the machine account as metrics on every check.
+func TestMachineAccountMonitor(t *testing.T) {
+
+	setup := func(t *testing.T, role flow.Role) (*MachineAccountMonitor, *sdk.Account, *modulemock.SDKClientWrapper, *modulemock.MachineAccountMetrics) {
+		local, remote := unittest.MachineAccountFixture(t)
+		client := new(modulemock.SDKClientWrapper)
+		metrics := new(modulemock.MachineAccountMetrics)
+		monitor, err := NewMachineAccountMonitor(unittest.Logger(), metrics, client, role, local, DefaultMachineAccountCheckInterval)
+		require.NoError(t, err)
+		return monitor, remote, client, metrics
+	}
+
+	t.Run("healthy account", func(t *testing.T) {
+		monitor, remote, client, metrics := setup(t, flow.RoleConsensus)
+		remote.Keys[0].SequenceNumber = 42
+		client.On("GetAccount", mock.Anything, monitor.info.SDKAddress()).Return(remote, nil).Once()
+
+		metrics.On("AccountBalance", 0.5).Once()
+		metrics.On("RecommendedMinBalance", toFLOW(defaultSoftMinBalanceSN)).Once()
+		metrics.On("AccountKeyWeight", 1000).Once()
+		metrics.On("AccountSequenceNumber", uint64(42)).Once()
+		metrics.On("IsMisconfigured", false).Once()
+
+		err := monitor.checkMachineAccount(context.Background())
+		require.NoError(t, err)
+		metrics.AssertExpectations(t)
+	})
+
+	t.Run("revoked key", func(t *testing.T) {
+		monitor, remote, client, metrics := setup(t, flow.RoleCollection)
+		remote.Keys[0].Revoked = true
+		client.On("GetAccount", mock.Anything, monitor.info.SDKAddress()).Return(remote, nil).Once()
+
+		metrics.On("AccountBalance", 0.5).Once()
+		metrics.On("RecommendedMinBalance", toFLOW(defaultSoftMinBalanceLN)).Once()
+		metrics.On("AccountKeyWeight", 0).Once()
+		metrics.On("AccountSequenceNumber", uint64(0)).Once()
+		metrics.On("IsMisconfigured", true).Once()
+
+		err := monitor.checkMachineAccount(context.Background())
+		require.NoError(t, err)
+		metrics.AssertExpectations(t)
+	})
+
+	t.Run("balance below hard minimum", func(t *testing.T) {
+		monitor, remote, client, metrics := setup(t, flow.RoleConsensus)
+		remote.Balance = uint64(defaultHardMinBalanceSN) - 1
+		client.On("GetAccount", mock.Anything, monitor.info.SDKAddress()).Return(remote, nil).Once()
+
+		metrics.On("AccountBalance", toFLOW(defaultHardMinBalanceSN-1)).Once()
+		metrics.On("RecommendedMinBalance", toFLOW(defaultSoftMinBalanceSN)).Once()
+		metrics.On("AccountKeyWeight", 1000).Once()
+		metrics.On("AccountSequenceNumber", uint64(0)).Once()
+		metrics.On("IsMisconfigured", true).Once()
+
+		err := monitor.checkMachineAccount(context.Background())
+		require.NoError(t, err)
+		metrics.AssertExpectations(t)
+	})
+
+	t.Run("unreachable access node", func(t *testing.T) {
+		monitor, _, client, metrics := setup(t, flow.RoleConsensus)
+		client.On("GetAccount", mock.Anything, monitor.info.SDKAddress()).Return(nil, fmt.Errorf("unavailable")).Once()
+
+		err := monitor.checkMachineAccount(context.Background())
+		require.Error(t, err)
+		metrics.AssertNotCalled(t, "AccountBalance", mock.Anything)
+	})
+
+	t.Run("invalid role", func(t *testing.T) {
+		local, _ := unittest.MachineAccountFixture(t)
+		_, err := NewMachineAccountMonitor(unittest.Logger(), new(modulemock.MachineAccountMetrics), new(modulemock.SDKClientWrapper), flow.RoleExecution, local, DefaultMachineAccountCheckInterval)
+		require.Error(t, err)
+	})
+}
@@ -52,6 +52,18 @@ func TestMachineAccountChecking(t *testing.T) {
 		err := CheckMachineAccountInfo(zerolog.Nop(), conf, flow.RoleConsensus, local, remote)
 		require.Error(t, err)
 	})
+	t.Run("revoked key", func(t *testing.T) {
+		local, remote := unittest.MachineAccountFixture(t)
+		remote.Keys[0].Revoked = true
+		err := CheckMachineAccountInfo(zerolog.Nop(), conf, flow.RoleConsensus, local, remote)
+		require.Error(t, err)
+	})
+	t.Run("key weight below threshold", func(t *testing.T) {
+		local, remote := unittest.MachineAccountFixture(t)
+		remote.Keys[0].Weight = 500
+		err := CheckMachineAccountInfo(zerolog.Nop(), conf, flow.RoleConsensus, local, remote)
+		require.Error(t, err)
+	})
 	t.Run("account without keys", func(t *testing.T) {
 		local, remote := unittest.MachineAccountFixture(t)
 		remote.Keys = nil
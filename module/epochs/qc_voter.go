@@ -40,7 +40,7 @@ const (
 type RootQCVoter struct {
 	log                       zerolog.Logger
 	me                        module.Local
-	signer                    hotstuff.Signer
+	newSigner                 func(clusterID flow.ChainID) hotstuff.Signer // creates the signer of the votes of a cluster
 	state                     protocol.State
 	qcContractClients         []module.QCContractClient // priority ordered array of client to the QC aggregator smart contract
 	lastSuccessfulClientIndex int                       // index of the contract client that was last successful during retries
@@ -49,10 +49,12 @@ type RootQCVoter struct {
 }
 
 // NewRootQCVoter returns a new root QC voter, configured for a particular epoch.
+// The votes for the root block of a cluster are signed by the signer created with
+// newSigner for the cluster.
 func NewRootQCVoter(
 	log zerolog.Logger,
 	me module.Local,
-	signer hotstuff.Signer,
+	newSigner func(clusterID flow.ChainID) hotstuff.Signer,
 	state protocol.State,
 	contractClients []module.QCContractClient,
 ) *RootQCVoter {
@@ -60,7 +62,7 @@ func NewRootQCVoter(
 	voter := &RootQCVoter{
 		log:               log.With().Str("module", "root_qc_voter").Logger(),
 		me:                me,
-		signer:            signer,
+		newSigner:         newSigner,
 		state:             state,
 		qcContractClients: contractClients,
 		wait:              time.Second * 10,
@@ -102,7 +104,7 @@ func (voter *RootQCVoter) Vote(ctx context.Context, epoch protocol.Epoch) error
 	// create a signable hotstuff model
 	signable := hotmodel.GenesisBlockFromFlow(root.Header)
 
-	vote, err := voter.signer.CreateVote(signable)
+	vote, err := voter.newSigner(root.Header.ChainID).CreateVote(signable)
 	if err != nil {
 		return fmt.Errorf("could not create vote for cluster root qc: %w", err)
 	}
@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 
+	flowhotstuff "github.com/onflow/flow-go/consensus/hotstuff"
 	hotstuff "github.com/onflow/flow-go/consensus/hotstuff/mocks"
 	"github.com/onflow/flow-go/model/flow"
 	flowmodule "github.com/onflow/flow-go/module"
@@ -83,7 +84,8 @@ func (suite *Suite) SetupTest() {
 	suite.epoch.On("Clustering").Return(suite.clustering, nil)
 	suite.signer.On("CreateVote", mock.Anything).Return(unittest.VoteFixture(), nil)
 
-	suite.voter = epochs.NewRootQCVoter(log, suite.local, suite.signer, suite.state, []flowmodule.QCContractClient{suite.client})
+	newSigner := func(flow.ChainID) flowhotstuff.Signer { return suite.signer }
+	suite.voter = epochs.NewRootQCVoter(log, suite.local, newSigner, suite.state, []flowmodule.QCContractClient{suite.client})
 }
 
 func TestRootQCVoter(t *testing.T) {
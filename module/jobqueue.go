@@ -38,6 +38,11 @@ type JobConsumer interface {
 
 	// Size returns the number of processing jobs in consumer.
 	Size() uint
+
+	// SetMaxProcessing updates the maximum number of jobs processed concurrently at runtime.
+	// Raising the limit picks up new jobs right away, while lowering it lets the jobs in
+	// progress finish before new ones are taken.
+	SetMaxProcessing(uint64)
 }
 
 type Job interface {
@@ -133,6 +133,35 @@ func (c *Consumer) Size() uint {
 	return uint(len(c.processings))
 }
 
+// SetMaxProcessing updates the maximum number of jobs the consumer processes concurrently.
+// When the limit is raised, the consumer immediately checks for new processable jobs. When it is
+// lowered, the jobs in progress are not interrupted, and no new job is taken until the number of
+// processing jobs drops below the new limit.
+// A zero limit is ignored, since the consumer would never make progress.
+func (c *Consumer) SetMaxProcessing(maxProcessing uint64) {
+	if maxProcessing == 0 {
+		c.log.Warn().Msg("ignoring zero max processing, consumer would never process jobs")
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	previous := c.maxProcessing
+	c.maxProcessing = maxProcessing
+
+	c.log.Info().
+		Uint64("previous", previous).
+		Uint64("current", maxProcessing).
+		Msg("max processing updated")
+
+	// processed index is only synced with storage once the consumer has started,
+	// so we must not check processable jobs before that.
+	if c.running && maxProcessing > previous {
+		c.checkProcessable()
+	}
+}
+
 // NotifyJobIsDone let the consumer know a job has been finished, so that consumer will take
 // the next job from the job queue if there are workers available. It returns the last processed job index.
 func (c *Consumer) NotifyJobIsDone(jobID module.JobID) uint64 {
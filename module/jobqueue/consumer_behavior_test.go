@@ -82,6 +82,14 @@ func TestConsumer(t *testing.T) {
 	t.Run("testStopRunning", testStopRunning)
 
 	t.Run("testConcurrency", testConcurrency)
+
+	// [+1, +2, +3, +4, +5, max=5] => [0#, 1!, 2!, 3!, 4!, 5!]
+	// when the max number of workers is raised, the buffered jobs are processed right away
+	t.Run("testRaiseMaxProcessing", testRaiseMaxProcessing)
+
+	// [+1, +2, +3, max=1, +4, 1*, 2*, 3*] => [0#, 1#, 2#, 3#, 4!]
+	// when the max number of workers is lowered, no new job is processed until the in-progress jobs drop below it
+	t.Run("testLowerMaxProcessing", testLowerMaxProcessing)
 }
 
 func testOnStartup(t *testing.T) {
@@ -496,6 +504,57 @@ func testConcurrency(t *testing.T) {
 	})
 }
 
+// [+1, +2, +3, +4, +5, max=5] => [0#, 1!, 2!, 3!, 4!, 5!]
+// when the max number of workers is raised, the buffered jobs are processed right away
+func testRaiseMaxProcessing(t *testing.T) {
+	runWith(t, func(c module.JobConsumer, cp storage.ConsumerProgress, w *mockWorker, j *jobqueue.MockJobs, db *badgerdb.DB) {
+		require.NoError(t, c.Start(DefaultIndex))
+		for i := 0; i < 5; i++ {
+			require.NoError(t, j.PushOne())
+			c.Check()
+		}
+
+		time.Sleep(1 * time.Millisecond)
+		w.AssertCalled(t, []int64{1, 2, 3})
+
+		c.SetMaxProcessing(5)
+
+		time.Sleep(1 * time.Millisecond)
+		w.AssertCalled(t, []int64{1, 2, 3, 4, 5})
+		assertProcessed(t, cp, 0)
+	})
+}
+
+// [+1, +2, +3, max=1, +4, 1*, 2*, 3*] => [0#, 1#, 2#, 3#, 4!]
+// when the max number of workers is lowered, no new job is processed until the in-progress jobs drop below it
+func testLowerMaxProcessing(t *testing.T) {
+	runWith(t, func(c module.JobConsumer, cp storage.ConsumerProgress, w *mockWorker, j *jobqueue.MockJobs, db *badgerdb.DB) {
+		require.NoError(t, c.Start(DefaultIndex))
+		for i := 0; i < 3; i++ {
+			require.NoError(t, j.PushOne())
+			c.Check()
+		}
+
+		c.SetMaxProcessing(1)
+
+		require.NoError(t, j.PushOne()) // +4
+		c.Check()
+
+		c.NotifyJobIsDone(jobqueue.JobIDAtIndex(1))
+		c.NotifyJobIsDone(jobqueue.JobIDAtIndex(2))
+
+		time.Sleep(1 * time.Millisecond)
+		w.AssertCalled(t, []int64{1, 2, 3})
+		assertProcessed(t, cp, 2)
+
+		c.NotifyJobIsDone(jobqueue.JobIDAtIndex(3))
+
+		time.Sleep(1 * time.Millisecond)
+		w.AssertCalled(t, []int64{1, 2, 3, 4})
+		assertProcessed(t, cp, 3)
+	})
+}
+
 type JobID = module.JobID
 type Job = module.Job
 
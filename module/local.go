@@ -32,3 +32,18 @@ type Local interface {
 	SignFunc([]byte, hash.Hasher, func(crypto.PrivateKey, []byte, hash.Hasher) (crypto.Signature,
 		error)) (crypto.Signature, error)
 }
+
+// VoteSigner is implemented by signers which build and sign the votes of the main consensus
+// and of the collection clusters themselves, such as remote signers protecting the node against
+// voting for different blocks at the same view.
+type VoteSigner interface {
+
+	// SignVote generates the signature of the vote of the main consensus for the block at the
+	// given view. It returns an error if a vote for a different block was already signed at the view.
+	SignVote(view uint64, blockID flow.Identifier) (crypto.Signature, error)
+
+	// SignClusterVote generates the signature of the vote of the consensus of the given collection
+	// cluster for the block at the given view. It returns an error if a vote for a different block
+	// was already signed at the view of the cluster chain.
+	SignClusterVote(clusterID flow.ChainID, view uint64, blockID flow.Identifier) (crypto.Signature, error)
+}
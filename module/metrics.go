@@ -258,6 +258,21 @@ type VerificationMetrics interface {
 	// OnResultApprovalDispatchedInNetwork increments a counter that keeps track of number of result approvals dispatched in the network
 	// by verifier engine.
 	OnResultApprovalDispatchedInNetworkByVerifier()
+
+	// SetSealedHeightLagAtBlockConsumer sets a gauge that keeps track of the number of blocks between the latest sealed height
+	// and the last height processed by the block consumer.
+	SetSealedHeightLagAtBlockConsumer(lag uint64)
+
+	// SetPendingChunksAtChunkConsumer sets a gauge that keeps track of the number of chunks in the chunks queue that are not
+	// yet processed by the chunk consumer.
+	SetPendingChunksAtChunkConsumer(pending uint64)
+
+	// SetCatchUpModeEnabled sets a gauge that is 1 while the verification node is in catch-up mode, and 0 otherwise.
+	SetCatchUpModeEnabled(enabled bool)
+
+	// OnSealedChunksSkippedAtAssigner increments a counter that keeps track of number of assigned chunks that assigner engine
+	// skips while catching up, since they belong to already sealed blocks.
+	OnSealedChunksSkippedAtAssigner(chunks int)
 }
 
 // LedgerMetrics provides an interface to record Ledger Storage metrics.
@@ -395,6 +410,18 @@ type ExecutionMetrics interface {
 	ExecutionBlockDataUploadStarted()
 
 	ExecutionBlockDataUploadFinished(dur time.Duration)
+
+	// ExecutionProgramsWarmedUp reports the number of programs of the warm-up list loaded when the node
+	// started, the number of stale contracts dropped from the list, and the time spent loading the programs
+	ExecutionProgramsWarmedUp(loaded int, stale int, duration time.Duration)
+
+	// ExecutionProgramsWarmUpHit reports a program loaded by the warm-up used for the first time by a
+	// block, along with the time it took to load it, which is the time saved by the warm-up
+	ExecutionProgramsWarmUpHit(saved time.Duration)
+
+	// ExecutionProgramsWarmUpMisses reports the number of contract programs loaded while executing a
+	// block, because they were not warmed up
+	ExecutionProgramsWarmUpMisses(misses int)
 }
 
 type TransactionMetrics interface {
@@ -424,3 +451,23 @@ type PingMetrics interface {
 	// NodeInfo tracks the software version, sealed height and hotstuff view of a node
 	NodeInfo(node *flow.Identity, nodeInfo string, version string, sealedHeight uint64, hotstuffCurView uint64)
 }
+
+// MachineAccountMetrics tracks the health of the machine account a collection or consensus node
+// uses to submit epoch transactions (root QC votes and DKG results).
+type MachineAccountMetrics interface {
+	// AccountBalance reports the current balance of the machine account, in FLOW.
+	AccountBalance(bal float64)
+
+	// RecommendedMinBalance reports the balance the machine account should hold to cover the
+	// transactions of the next epoch, in FLOW.
+	RecommendedMinBalance(bal float64)
+
+	// AccountKeyWeight reports the weight of the account key used by the node. It is zero if the key is revoked.
+	AccountKeyWeight(weight int)
+
+	// AccountSequenceNumber reports the sequence number of the account key used by the node.
+	AccountSequenceNumber(seqNum uint64)
+
+	// IsMisconfigured reports whether a critical misconfiguration of the machine account was detected.
+	IsMisconfigured(misconfigured bool)
+}
@@ -60,6 +60,12 @@ type ExecutionCollector struct {
 	executionStateDiskUsage          prometheus.Gauge
 	blockDataUploadsInProgress       prometheus.Gauge
 	blockDataUploadsDuration         prometheus.Histogram
+	programsWarmUpLoaded             prometheus.Gauge
+	programsWarmUpStale              prometheus.Gauge
+	programsWarmUpDuration           prometheus.Gauge
+	programsWarmUpHits               prometheus.Counter
+	programsWarmUpTimeSaved          prometheus.Counter
+	programsWarmUpMisses             prometheus.Counter
 }
 
 func NewExecutionCollector(tracer module.Tracer) *ExecutionCollector {
@@ -453,6 +459,48 @@ func NewExecutionCollector(tracer module.Tracer) *ExecutionCollector {
 			Name:      "execution_state_disk_usage",
 			Help:      "the disk usage of execution state",
 		}),
+
+		programsWarmUpLoaded: promauto.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespaceExecution,
+			Subsystem: subsystemRuntime,
+			Name:      "programs_warm_up_loaded",
+			Help:      "the number of programs of the warm-up list loaded when the node started",
+		}),
+
+		programsWarmUpStale: promauto.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespaceExecution,
+			Subsystem: subsystemRuntime,
+			Name:      "programs_warm_up_stale",
+			Help:      "the number of stale contracts dropped from the warm-up list when the node started",
+		}),
+
+		programsWarmUpDuration: promauto.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespaceExecution,
+			Subsystem: subsystemRuntime,
+			Name:      "programs_warm_up_duration_seconds",
+			Help:      "the time spent loading the programs of the warm-up list when the node started",
+		}),
+
+		programsWarmUpHits: promauto.NewCounter(prometheus.CounterOpts{
+			Namespace: namespaceExecution,
+			Subsystem: subsystemRuntime,
+			Name:      "programs_warm_up_hits_total",
+			Help:      "the number of programs loaded by the warm-up which were used by the executed blocks",
+		}),
+
+		programsWarmUpTimeSaved: promauto.NewCounter(prometheus.CounterOpts{
+			Namespace: namespaceExecution,
+			Subsystem: subsystemRuntime,
+			Name:      "programs_warm_up_time_saved_seconds_total",
+			Help:      "the time spent loading the programs of the warm-up list which were used by the executed blocks",
+		}),
+
+		programsWarmUpMisses: promauto.NewCounter(prometheus.CounterOpts{
+			Namespace: namespaceExecution,
+			Subsystem: subsystemRuntime,
+			Name:      "programs_warm_up_misses_total",
+			Help:      "the number of contract programs loaded while executing blocks because they were not warmed up",
+		}),
 	}
 
 	return ec
@@ -620,6 +668,27 @@ func (ec *ExecutionCollector) ExecutionBlockDataUploadFinished(dur time.Duration
 	ec.blockDataUploadsDuration.Observe(float64(dur.Milliseconds()))
 }
 
+// ExecutionProgramsWarmedUp reports the programs of the warm-up list loaded and the stale contracts
+// dropped from it when the node started, and the time spent loading the programs
+func (ec *ExecutionCollector) ExecutionProgramsWarmedUp(loaded int, stale int, duration time.Duration) {
+	ec.programsWarmUpLoaded.Set(float64(loaded))
+	ec.programsWarmUpStale.Set(float64(stale))
+	ec.programsWarmUpDuration.Set(duration.Seconds())
+}
+
+// ExecutionProgramsWarmUpHit reports a program loaded by the warm-up used for the first time by a
+// block, and the time it took to load it
+func (ec *ExecutionCollector) ExecutionProgramsWarmUpHit(saved time.Duration) {
+	ec.programsWarmUpHits.Inc()
+	ec.programsWarmUpTimeSaved.Add(saved.Seconds())
+}
+
+// ExecutionProgramsWarmUpMisses reports the number of contract programs loaded while executing a
+// block because they were not warmed up
+func (ec *ExecutionCollector) ExecutionProgramsWarmUpMisses(misses int) {
+	ec.programsWarmUpMisses.Add(float64(misses))
+}
+
 // TransactionParsed reports the time spent parsing a single transaction
 func (ec *ExecutionCollector) RuntimeTransactionParsed(dur time.Duration) {
 	ec.transactionParseTime.Observe(float64(dur))
@@ -89,6 +89,7 @@ const (
 	ResourceEvents                    = "events"                            // execution node
 	ResourceServiceEvents             = "service_events"                    // execution node
 	ResourceTransactionResults        = "transaction_results"               // execution node
+	ResourceTransactionRegisterSets   = "transaction_register_sets"         // execution node
 )
 
 const (
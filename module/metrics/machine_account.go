@@ -0,0 +1,70 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+type MachineAccountCollector struct {
+	accountBalance        prometheus.Gauge
+	recommendedMinBalance prometheus.Gauge
+	accountKeyWeight      prometheus.Gauge
+	accountSequenceNumber prometheus.Gauge
+	misconfigured         prometheus.Gauge
+}
+
+func NewMachineAccountCollector() *MachineAccountCollector {
+	mc := &MachineAccountCollector{
+		accountBalance: promauto.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespaceMachineAcct,
+			Name:      "balance",
+			Help:      "the last observed balance of this node's machine account, in units of FLOW",
+		}),
+		recommendedMinBalance: promauto.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespaceMachineAcct,
+			Name:      "recommended_min_balance",
+			Help:      "the recommended minimum balance of this node's machine account to cover the next epoch, in units of FLOW",
+		}),
+		accountKeyWeight: promauto.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespaceMachineAcct,
+			Name:      "key_weight",
+			Help:      "the weight of the account key used by this node, zero if the key is revoked",
+		}),
+		accountSequenceNumber: promauto.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespaceMachineAcct,
+			Name:      "sequence_number",
+			Help:      "the sequence number of the account key used by this node",
+		}),
+		misconfigured: promauto.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespaceMachineAcct,
+			Name:      "is_misconfigured",
+			Help:      "reported as a non-zero value when a critical misconfiguration of the machine account is detected",
+		}),
+	}
+
+	return mc
+}
+
+func (mc *MachineAccountCollector) AccountBalance(bal float64) {
+	mc.accountBalance.Set(bal)
+}
+
+func (mc *MachineAccountCollector) RecommendedMinBalance(bal float64) {
+	mc.recommendedMinBalance.Set(bal)
+}
+
+func (mc *MachineAccountCollector) AccountKeyWeight(weight int) {
+	mc.accountKeyWeight.Set(float64(weight))
+}
+
+func (mc *MachineAccountCollector) AccountSequenceNumber(seqNum uint64) {
+	mc.accountSequenceNumber.Set(float64(seqNum))
+}
+
+func (mc *MachineAccountCollector) IsMisconfigured(misconfigured bool) {
+	if misconfigured {
+		mc.misconfigured.Set(1)
+	} else {
+		mc.misconfigured.Set(0)
+	}
+}
@@ -11,6 +11,7 @@ const (
 	namespaceExecution    = "execution"
 	namespaceLoader       = "loader"
 	namespaceStateSync    = "state_synchronization"
+	namespaceMachineAcct  = "machine_account"
 )
 
 // Network subsystems represent the various layers of networking.
@@ -64,6 +65,7 @@ const (
 	subsystemVerifierEngine  = "verifier"
 	subsystemBlockConsumer   = "block_consumer"
 	subsystemChunkConsumer   = "chunk_consumer"
+	subsystemCatchUp         = "catch_up"
 )
 
 // State Synchronization Subsystems
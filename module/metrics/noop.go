@@ -108,6 +108,10 @@ func (nc *NoopCollector) OnChunkDataPackSentToFetcher()
 func (nc *NoopCollector) OnVerifiableChunkSentToVerifier()                                      {}
 func (nc *NoopCollector) OnBlockConsumerJobDone(uint64)                                         {}
 func (nc *NoopCollector) OnChunkConsumerJobDone(uint64)                                         {}
+func (nc *NoopCollector) SetSealedHeightLagAtBlockConsumer(lag uint64)                          {}
+func (nc *NoopCollector) SetPendingChunksAtChunkConsumer(pending uint64)                        {}
+func (nc *NoopCollector) SetCatchUpModeEnabled(enabled bool)                                    {}
+func (nc *NoopCollector) OnSealedChunksSkippedAtAssigner(chunks int)                            {}
 func (nc *NoopCollector) OnChunkDataPackResponseReceivedFromNetworkByRequester()                {}
 func (nc *NoopCollector) StartBlockReceivedToExecuted(blockID flow.Identifier)                  {}
 func (nc *NoopCollector) FinishBlockReceivedToExecuted(blockID flow.Identifier)                 {}
@@ -151,7 +155,15 @@ func (nc *NoopCollector) ExecutionSync(syncing bool)
 func (nc *NoopCollector) DiskSize(uint64)                                                       {}
 func (nc *NoopCollector) ExecutionBlockDataUploadStarted()                                      {}
 func (nc *NoopCollector) ExecutionBlockDataUploadFinished(dur time.Duration)                    {}
+func (nc *NoopCollector) ExecutionProgramsWarmedUp(int, int, time.Duration)                     {}
+func (nc *NoopCollector) ExecutionProgramsWarmUpHit(time.Duration)                              {}
+func (nc *NoopCollector) ExecutionProgramsWarmUpMisses(int)                                     {}
 func (nc *NoopCollector) ExecutionDataAddStarted()                                              {}
 func (nc *NoopCollector) ExecutionDataAddFinished(time.Duration, bool, uint64)                  {}
 func (nc *NoopCollector) ExecutionDataGetStarted()                                              {}
 func (nc *NoopCollector) ExecutionDataGetFinished(time.Duration, bool, uint64)                  {}
+func (nc *NoopCollector) AccountBalance(bal float64)                                            {}
+func (nc *NoopCollector) RecommendedMinBalance(bal float64)                                     {}
+func (nc *NoopCollector) AccountKeyWeight(weight int)                                           {}
+func (nc *NoopCollector) AccountSequenceNumber(seqNum uint64)                                   {}
+func (nc *NoopCollector) IsMisconfigured(misconfigured bool)                                    {}
@@ -12,12 +12,18 @@ type VerificationCollector struct {
 	// Job Consumers
 	lastProcessedBlockJobIndexBlockConsumer prometheus.Gauge
 	lastProcessedChunkJobIndexChunkConsumer prometheus.Gauge
+	sealedHeightLagBlockConsumer            prometheus.Gauge // blocks between the latest sealed height and the last processed block job
+	pendingChunksChunkConsumer              prometheus.Gauge // chunk jobs stored in chunks queue and not yet processed
+
+	// Catch-up Mode
+	catchUpModeEnabled prometheus.Gauge // 1 while verification node is catching up, 0 otherwise
 
 	// Assigner Engine
 	receivedFinalizedHeightAssigner prometheus.Gauge   // the last finalized height received by assigner engine
 	assignedChunkTotalAssigner      prometheus.Counter // total chunks assigned to this verification node
 	processedChunkTotalAssigner     prometheus.Counter // total chunks sent by assigner engine to chunk consumer (i.e., fetcher input)
 	receivedResultsTotalAssigner    prometheus.Counter // total execution results arrived at assigner
+	skippedSealedChunkTotalAssigner prometheus.Counter // total assigned chunks skipped by assigner engine while catching up
 
 	// Fetcher Engine
 	receivedAssignedChunkTotalFetcher  prometheus.Counter // total assigned chunks received by fetcher engine from assigner engine.
@@ -60,6 +66,28 @@ func NewVerificationCollector(tracer module.Tracer, registerer prometheus.Regist
 		Help:      "the last chunk job index processed by chunk consumer",
 	})
 
+	sealedHeightLagBlockConsumer := prometheus.NewGauge(prometheus.GaugeOpts{
+		Name:      "sealed_height_lag",
+		Namespace: namespaceVerification,
+		Subsystem: subsystemBlockConsumer,
+		Help:      "the number of blocks between the latest sealed height and the last height processed by block consumer",
+	})
+
+	pendingChunksChunkConsumer := prometheus.NewGauge(prometheus.GaugeOpts{
+		Name:      "pending_chunks",
+		Namespace: namespaceVerification,
+		Subsystem: subsystemChunkConsumer,
+		Help:      "the number of chunk jobs in chunks queue that are not yet processed by chunk consumer",
+	})
+
+	// Catch-up Mode
+	catchUpModeEnabled := prometheus.NewGauge(prometheus.GaugeOpts{
+		Name:      "enabled",
+		Namespace: namespaceVerification,
+		Subsystem: subsystemCatchUp,
+		Help:      "whether the verification node is catching up with the sealed height (1) or not (0)",
+	})
+
 	// Assigner Engine
 	receivedFinalizedHeightAssigner := prometheus.NewGauge(prometheus.GaugeOpts{
 		Name:      "finalized_height",
@@ -89,6 +117,13 @@ func NewVerificationCollector(tracer module.Tracer, registerer prometheus.Regist
 		Help:      "total number chunks sent by assigner engine to chunk consumer",
 	})
 
+	skippedSealedChunksTotalAssigner := prometheus.NewCounter(prometheus.CounterOpts{
+		Name:      "sealed_chunk_skipped_total",
+		Namespace: namespaceVerification,
+		Subsystem: subsystemAssignerEngine,
+		Help:      "total number of assigned chunks skipped by assigner engine while catching up since their block is already sealed",
+	})
+
 	// Fetcher Engine
 	receivedAssignedChunksTotalFetcher := prometheus.NewCounter(prometheus.CounterOpts{
 		Name:      "assigned_chunk_received_total",
@@ -178,12 +213,18 @@ func NewVerificationCollector(tracer module.Tracer, registerer prometheus.Regist
 		// job consumers
 		lastProcessedBlockJobIndexBlockConsumer,
 		lastProcessedChunkJobIndexChunkConsumer,
+		sealedHeightLagBlockConsumer,
+		pendingChunksChunkConsumer,
+
+		// catch-up mode
+		catchUpModeEnabled,
 
 		// assigner
 		receivedFinalizedHeightAssigner,
 		assignedChunksTotalAssigner,
 		sentChunksTotalAssigner,
 		receivedResultsTotalAssigner,
+		skippedSealedChunksTotalAssigner,
 
 		// fetcher engine
 		receivedAssignedChunksTotalFetcher,
@@ -208,12 +249,18 @@ func NewVerificationCollector(tracer module.Tracer, registerer prometheus.Regist
 		// job consumers
 		lastProcessedChunkJobIndexChunkConsumer: lastProcessedChunkJobIndexChunkConsumer,
 		lastProcessedBlockJobIndexBlockConsumer: lastProcessedBlockJobIndexBlockConsumer,
+		sealedHeightLagBlockConsumer:            sealedHeightLagBlockConsumer,
+		pendingChunksChunkConsumer:              pendingChunksChunkConsumer,
+
+		// catch-up mode
+		catchUpModeEnabled: catchUpModeEnabled,
 
 		// assigner
 		receivedFinalizedHeightAssigner: receivedFinalizedHeightAssigner,
 		assignedChunkTotalAssigner:      assignedChunksTotalAssigner,
 		processedChunkTotalAssigner:     sentChunksTotalAssigner,
 		receivedResultsTotalAssigner:    receivedResultsTotalAssigner,
+		skippedSealedChunkTotalAssigner: skippedSealedChunksTotalAssigner,
 
 		// fetcher
 		receivedAssignedChunkTotalFetcher:  receivedAssignedChunksTotalFetcher,
@@ -339,3 +386,30 @@ func (vc *VerificationCollector) OnBlockConsumerJobDone(processedIndex uint64) {
 func (vc *VerificationCollector) SetMaxChunkDataPackAttemptsForNextUnsealedHeightAtRequester(attempts uint64) {
 	vc.maxChunkDataPackRequestAttemptForNextUnsealedHeight.Set(float64(attempts))
 }
+
+// SetSealedHeightLagAtBlockConsumer sets a gauge that keeps track of the number of blocks between the latest sealed height
+// and the last height processed by the block consumer.
+func (vc *VerificationCollector) SetSealedHeightLagAtBlockConsumer(lag uint64) {
+	vc.sealedHeightLagBlockConsumer.Set(float64(lag))
+}
+
+// SetPendingChunksAtChunkConsumer sets a gauge that keeps track of the number of chunks in the chunks queue that are not
+// yet processed by the chunk consumer.
+func (vc *VerificationCollector) SetPendingChunksAtChunkConsumer(pending uint64) {
+	vc.pendingChunksChunkConsumer.Set(float64(pending))
+}
+
+// SetCatchUpModeEnabled sets a gauge that is 1 while the verification node is in catch-up mode, and 0 otherwise.
+func (vc *VerificationCollector) SetCatchUpModeEnabled(enabled bool) {
+	if enabled {
+		vc.catchUpModeEnabled.Set(1)
+		return
+	}
+	vc.catchUpModeEnabled.Set(0)
+}
+
+// OnSealedChunksSkippedAtAssigner increments a counter that keeps track of number of assigned chunks that assigner engine
+// skips while catching up, since they belong to already sealed blocks.
+func (vc *VerificationCollector) OnSealedChunksSkippedAtAssigner(chunks int) {
+	vc.skippedSealedChunkTotalAssigner.Add(float64(chunks))
+}
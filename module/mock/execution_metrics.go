@@ -59,6 +59,21 @@ func (_m *ExecutionMetrics) ExecutionLastExecutedBlockHeight(height uint64) {
 	_m.Called(height)
 }
 
+// ExecutionProgramsWarmUpHit provides a mock function with given fields: saved
+func (_m *ExecutionMetrics) ExecutionProgramsWarmUpHit(saved time.Duration) {
+	_m.Called(saved)
+}
+
+// ExecutionProgramsWarmUpMisses provides a mock function with given fields: misses
+func (_m *ExecutionMetrics) ExecutionProgramsWarmUpMisses(misses int) {
+	_m.Called(misses)
+}
+
+// ExecutionProgramsWarmedUp provides a mock function with given fields: loaded, stale, duration
+func (_m *ExecutionMetrics) ExecutionProgramsWarmedUp(loaded int, stale int, duration time.Duration) {
+	_m.Called(loaded, stale, duration)
+}
+
 // ExecutionScriptExecuted provides a mock function with given fields: dur, compUsed
 func (_m *ExecutionMetrics) ExecutionScriptExecuted(dur time.Duration, compUsed uint64) {
 	_m.Called(dur, compUsed)
@@ -31,6 +31,11 @@ func (_m *JobConsumer) NotifyJobIsDone(_a0 module.JobID) uint64 {
 	return r0
 }
 
+// SetMaxProcessing provides a mock function with given fields: _a0
+func (_m *JobConsumer) SetMaxProcessing(_a0 uint64) {
+	_m.Called(_a0)
+}
+
 // Size provides a mock function with given fields:
 func (_m *JobConsumer) Size() uint {
 	ret := _m.Called()
@@ -79,6 +79,11 @@ func (_m *VerificationMetrics) OnResultApprovalDispatchedInNetworkByVerifier() {
 	_m.Called()
 }
 
+// OnSealedChunksSkippedAtAssigner provides a mock function with given fields: chunks
+func (_m *VerificationMetrics) OnSealedChunksSkippedAtAssigner(chunks int) {
+	_m.Called(chunks)
+}
+
 // OnVerifiableChunkReceivedAtVerifierEngine provides a mock function with given fields:
 func (_m *VerificationMetrics) OnVerifiableChunkReceivedAtVerifierEngine() {
 	_m.Called()
@@ -89,7 +94,22 @@ func (_m *VerificationMetrics) OnVerifiableChunkSentToVerifier() {
 	_m.Called()
 }
 
+// SetCatchUpModeEnabled provides a mock function with given fields: enabled
+func (_m *VerificationMetrics) SetCatchUpModeEnabled(enabled bool) {
+	_m.Called(enabled)
+}
+
 // SetMaxChunkDataPackAttemptsForNextUnsealedHeightAtRequester provides a mock function with given fields: attempts
 func (_m *VerificationMetrics) SetMaxChunkDataPackAttemptsForNextUnsealedHeightAtRequester(attempts uint64) {
 	_m.Called(attempts)
 }
+
+// SetPendingChunksAtChunkConsumer provides a mock function with given fields: pending
+func (_m *VerificationMetrics) SetPendingChunksAtChunkConsumer(pending uint64) {
+	_m.Called(pending)
+}
+
+// SetSealedHeightLagAtBlockConsumer provides a mock function with given fields: lag
+func (_m *VerificationMetrics) SetSealedHeightLagAtBlockConsumer(lag uint64) {
+	_m.Called(lag)
+}
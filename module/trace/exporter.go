@@ -0,0 +1,75 @@
+package trace
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// Exporters supported by the node flags.
+const (
+	ExporterOTLP = "otlp"
+	ExporterFile = "file"
+)
+
+// NewOTLPExporter creates an exporter sending the spans to an OTLP collector
+// over gRPC. The collector endpoint, the TLS settings and the headers are
+// configured with the standard OTEL_EXPORTER_OTLP_* environment variables, the
+// default endpoint is localhost:4317.
+//
+// The connection is established in the background, so the node starts even if
+// the collector is unreachable.
+func NewOTLPExporter() (sdktrace.SpanExporter, error) {
+	return otlptracegrpc.New(context.Background())
+}
+
+// fileExporter writes the spans as JSON to a file, and closes the file on shutdown.
+type fileExporter struct {
+	sdktrace.SpanExporter
+	file *os.File
+}
+
+// NewFileExporter creates an exporter appending the spans to the given file as
+// JSON, one span per line. It is meant for local debugging, without a collector.
+func NewFileExporter(path string) (sdktrace.SpanExporter, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("could not open trace file: %w", err)
+	}
+
+	exporter, err := stdouttrace.New(stdouttrace.WithWriter(file))
+	if err != nil {
+		_ = file.Close()
+		return nil, fmt.Errorf("could not create file exporter: %w", err)
+	}
+
+	return &fileExporter{
+		SpanExporter: exporter,
+		file:         file,
+	}, nil
+}
+
+func (e *fileExporter) Shutdown(ctx context.Context) error {
+	err := e.SpanExporter.Shutdown(ctx)
+	if err != nil {
+		return err
+	}
+	return e.file.Close()
+}
+
+// NewExporter creates the exporter with the given name: ExporterOTLP or
+// ExporterFile, which writes to the given file.
+func NewExporter(name string, path string) (sdktrace.SpanExporter, error) {
+	switch name {
+	case ExporterOTLP:
+		return NewOTLPExporter()
+	case ExporterFile:
+		return NewFileExporter(path)
+	default:
+		return nil, fmt.Errorf("unknown trace exporter %q", name)
+	}
+}
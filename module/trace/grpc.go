@@ -0,0 +1,92 @@
+package trace
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// UnaryServerInterceptor returns a gRPC server interceptor starting a span for
+// every request, named after the full gRPC method name. If the client
+// propagated its span with UnaryClientInterceptor, the request span is a child
+// of it, so the trace spans both nodes. The request span is added to the
+// context of the handler, so the spans started by the handler are nested in it.
+func UnaryServerInterceptor(tracer opentracing.Tracer) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+
+		var parent opentracing.SpanContext
+		md, ok := metadata.FromIncomingContext(ctx)
+		if ok {
+			header := make(http.Header, len(md))
+			for key, values := range md {
+				for _, value := range values {
+					header.Add(key, value)
+				}
+			}
+			// without a propagated span, the request span is the root of a new trace
+			parent, _ = tracer.Extract(opentracing.HTTPHeaders, opentracing.HTTPHeadersCarrier(header))
+		}
+
+		span := tracer.StartSpan(info.FullMethod, ext.RPCServerOption(parent))
+		defer span.Finish()
+
+		resp, err := handler(opentracing.ContextWithSpan(ctx, span), req)
+		if err != nil {
+			ext.Error.Set(span, true)
+		}
+		return resp, err
+	}
+}
+
+// UnaryClientInterceptor returns a gRPC client interceptor propagating the span
+// of the request context to the server: it starts a span for the request,
+// named after the full gRPC method name, and sends its context along with the
+// request. Requests without a span in their context aren't traced.
+func UnaryClientInterceptor(tracer opentracing.Tracer) grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req interface{},
+		reply interface{},
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+
+		parent := opentracing.SpanFromContext(ctx)
+		if parent == nil {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+		if _, ok := parent.(*NoopSpan); ok {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+
+		span := tracer.StartSpan(method, opentracing.ChildOf(parent.Context()), ext.SpanKindRPCClient)
+		defer span.Finish()
+
+		header := make(http.Header)
+		err := tracer.Inject(span.Context(), opentracing.HTTPHeaders, opentracing.HTTPHeadersCarrier(header))
+		if err == nil {
+			for key, values := range header {
+				// gRPC metadata keys are lower case
+				ctx = metadata.AppendToOutgoingContext(ctx, strings.ToLower(key), strings.Join(values, ","))
+			}
+		}
+
+		err = invoker(ctx, method, req, reply, cc, opts...)
+		if err != nil {
+			ext.Error.Set(span, true)
+		}
+		return err
+	}
+}
@@ -0,0 +1,83 @@
+package trace
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+// SpanNameSampler samples spans with a ratio configured per span name. As the
+// decision is derived from the trace ID, spans of the same trace with the same
+// ratio are consistently sampled, and a span with a lower ratio than its parent
+// is sampled for a subset of the traces its parent is sampled for.
+//
+// A span whose parent isn't sampled isn't sampled either, so dropping a span
+// drops all the spans nested in it.
+type SpanNameSampler struct {
+	ratios   map[SpanName]float64
+	samplers map[SpanName]sdktrace.Sampler
+	fallback sdktrace.Sampler
+}
+
+var _ sdktrace.Sampler = (*SpanNameSampler)(nil)
+
+// NewSpanNameSampler creates a sampler sampling the spans with the given names
+// with the given ratios, and all other spans with the default ratio.
+// A ratio of 1 samples all spans, a ratio of 0 none.
+func NewSpanNameSampler(defaultRatio float64, ratios map[SpanName]float64) *SpanNameSampler {
+	samplers := make(map[SpanName]sdktrace.Sampler, len(ratios))
+	for name, ratio := range ratios {
+		samplers[name] = sdktrace.TraceIDRatioBased(ratio)
+	}
+	return &SpanNameSampler{
+		ratios:   ratios,
+		samplers: samplers,
+		fallback: sdktrace.TraceIDRatioBased(defaultRatio),
+	}
+}
+
+func (s *SpanNameSampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	parent := oteltrace.SpanContextFromContext(p.ParentContext)
+	if parent.IsValid() && !parent.IsSampled() {
+		return sdktrace.SamplingResult{
+			Decision:   sdktrace.Drop,
+			Tracestate: parent.TraceState(),
+		}
+	}
+
+	sampler, ok := s.samplers[SpanName(p.Name)]
+	if !ok {
+		sampler = s.fallback
+	}
+	return sampler.ShouldSample(p)
+}
+
+func (s *SpanNameSampler) Description() string {
+	names := make([]string, 0, len(s.ratios))
+	for name, ratio := range s.ratios {
+		names = append(names, fmt.Sprintf("%s=%g", name, ratio))
+	}
+	sort.Strings(names)
+	return fmt.Sprintf("SpanNameSampler{%s,default:%s}", strings.Join(names, ","), s.fallback.Description())
+}
+
+// ParseSamplingRatios parses the sampling ratios per span name, as given on the
+// command line, e.g. exe.ingestion.executeBlock=0.5
+func ParseSamplingRatios(ratios map[string]string) (map[SpanName]float64, error) {
+	parsed := make(map[SpanName]float64, len(ratios))
+	for name, value := range ratios {
+		ratio, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid sampling ratio for span %s: %w", name, err)
+		}
+		if ratio < 0 || ratio > 1 {
+			return nil, fmt.Errorf("sampling ratio for span %s must be between 0 and 1, got %v", name, ratio)
+		}
+		parsed[SpanName(name)] = ratio
+	}
+	return parsed, nil
+}
@@ -2,16 +2,22 @@ package trace
 
 import (
 	"context"
-	"io"
+	"encoding/binary"
+	"fmt"
 	"math/rand"
 	"time"
 
 	lru "github.com/hashicorp/golang-lru"
 	"github.com/opentracing/opentracing-go"
-	"github.com/opentracing/opentracing-go/log"
 	"github.com/rs/zerolog"
-	"github.com/uber/jaeger-client-go"
-	"github.com/uber/jaeger-client-go/config"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelbridge "go.opentelemetry.io/otel/bridge/opentracing"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+	oteltrace "go.opentelemetry.io/otel/trace"
 
 	"github.com/onflow/flow-go/model/flow"
 )
@@ -23,64 +29,114 @@ const EntityTypeBlock = "Block"
 const EntityTypeCollection = "Collection"
 const EntityTypeTransaction = "Transaction"
 
+// shutdownTimeout is the maximum time to flush the pending spans on shutdown.
+const shutdownTimeout = 5 * time.Second
+
 type SpanName string
 
 func (s SpanName) Child(subOp string) SpanName {
 	return SpanName(string(s) + "." + subOp)
 }
 
-// OpenTracer is the implementation of the Tracer interface
+// OpenTracer is the implementation of the Tracer interface built on the
+// OpenTelemetry SDK. The spans are created through the OpenTracing bridge of
+// OpenTelemetry, so the OpenTracing spans used throughout the code base are
+// exported as OpenTelemetry spans.
 type OpenTracer struct {
 	opentracing.Tracer
-	closer      io.Closer
+	bridge      *otelbridge.BridgeTracer
+	tracer      oteltrace.Tracer
+	provider    *sdktrace.TracerProvider
 	log         zerolog.Logger
 	spanCache   *lru.Cache
 	sensitivity uint
 	chainID     string
 }
 
-type traceLogger struct {
-	zerolog.Logger
+// TracerOption configures the OpenTelemetry tracer.
+type TracerOption func(*tracerConfig)
+
+type tracerConfig struct {
+	exporter sdktrace.SpanExporter
+	sampler  sdktrace.Sampler
 }
 
-func (t traceLogger) Error(msg string) {
-	t.Logger.Error().Msg(msg)
+// WithExporter sets the exporter the spans are sent to. By default, the spans
+// are exported to an OTLP collector, see NewOTLPExporter.
+func WithExporter(exporter sdktrace.SpanExporter) TracerOption {
+	return func(cfg *tracerConfig) {
+		cfg.exporter = exporter
+	}
 }
 
-// Infof logs a message at info priority
-func (t traceLogger) Infof(msg string, args ...interface{}) {
-	t.Debug().Msgf(msg, args...)
+// WithSampler sets the sampler deciding which spans are recorded, see
+// NewSpanNameSampler. By default, all spans are recorded.
+func WithSampler(sampler sdktrace.Sampler) TracerOption {
+	return func(cfg *tracerConfig) {
+		cfg.sampler = sampler
+	}
 }
 
 // NewTracer creates a new tracer.
 //
-// TODO (ramtin) : we might need to add a mutex lock (not sure if tracer itself is thread-safe)
+// The spans of the same entity are aggregated in a trace derived from the
+// entity ID, if the entity is sampled according to the given sensitivity.
 func NewTracer(log zerolog.Logger,
 	serviceName string,
 	chainID string,
-	sensitivity uint) (*OpenTracer, error) {
-	cfg, err := config.FromEnv()
-	if err != nil {
-		return nil, err
-	}
+	sensitivity uint,
+	opts ...TracerOption) (*OpenTracer, error) {
 
-	if cfg.ServiceName == "" {
-		cfg.ServiceName = serviceName
+	cfg := tracerConfig{
+		sampler: sdktrace.AlwaysSample(),
+	}
+	for _, apply := range opts {
+		apply(&cfg)
+	}
+	if cfg.exporter == nil {
+		exporter, err := NewOTLPExporter()
+		if err != nil {
+			return nil, fmt.Errorf("could not create OTLP exporter: %w", err)
+		}
+		cfg.exporter = exporter
 	}
 
-	tracer, closer, err := cfg.NewTracer(config.Logger(traceLogger{log}))
+	res, err := resource.Merge(
+		resource.Default(),
+		resource.NewSchemaless(
+			semconv.ServiceNameKey.String(serviceName),
+			attribute.String("chainID", chainID),
+		),
+	)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("could not create tracer resource: %w", err)
 	}
 
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(cfg.exporter),
+		sdktrace.WithSampler(cfg.sampler),
+		sdktrace.WithResource(res),
+	)
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+		log.Warn().Err(err).Msg("tracer error")
+	}))
+
+	bridge, wrapper := otelbridge.NewTracerPair(provider.Tracer(serviceName))
+	bridge.SetTextMapPropagator(propagation.TraceContext{})
+	bridge.SetWarningHandler(func(msg string) {
+		log.Debug().Msg(msg)
+	})
+
 	spanCache, err := lru.New(int(DefaultEntityCacheSize))
 	if err != nil {
 		return nil, err
 	}
 
 	t := &OpenTracer{
-		Tracer:      tracer,
-		closer:      closer,
+		Tracer:      bridge,
+		bridge:      bridge,
+		tracer:      wrapper.Tracer(serviceName),
+		provider:    provider,
 		log:         log,
 		spanCache:   spanCache,
 		sensitivity: sensitivity,
@@ -103,7 +159,12 @@ func (t *OpenTracer) Ready() <-chan struct{} {
 func (t *OpenTracer) Done() <-chan struct{} {
 	done := make(chan struct{})
 	go func() {
-		t.closer.Close()
+		ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		err := t.provider.Shutdown(ctx)
+		if err != nil {
+			t.log.Error().Err(err).Msg("could not flush pending spans")
+		}
 		close(done)
 	}()
 	return done
@@ -112,38 +173,44 @@ func (t *OpenTracer) Done() <-chan struct{} {
 // entityRootSpan returns the root span for the given entity from the cache
 // and if not exist it would construct it and cache it and return it
 // This should be used mostly for the very first span created for an entity on the service
-func (t *OpenTracer) entityRootSpan(entityID flow.Identifier, entityType string, opts ...opentracing.StartSpanOption) opentracing.Span {
+func (t *OpenTracer) entityRootSpan(entityID flow.Identifier, entityType string) opentracing.Span {
 	if span, ok := t.spanCache.Get(entityID); ok {
 		return span.(opentracing.Span)
 	}
 
-	// flow.Identifier to flow
-	traceID, err := jaeger.TraceIDFromString(entityID.String()[:32])
-	if err != nil {
-		// don't panic, gracefully move forward with background context
-		sp, _ := t.StartSpanFromContext(context.Background(), "entity tracing started")
-		return sp
-	}
-
-	ctx := jaeger.NewSpanContext(
-		traceID,
-		jaeger.SpanID(rand.Uint64()),
-		jaeger.SpanID(0),
-		true,
-		nil,
+	// the trace of an entity is identified by the entity ID, so the spans of the
+	// same entity are aggregated across nodes
+	var spanID oteltrace.SpanID
+	binary.BigEndian.PutUint64(spanID[:], rand.Uint64())
+	parent := oteltrace.NewSpanContext(oteltrace.SpanContextConfig{
+		TraceID:    EntityTraceID(entityID),
+		SpanID:     spanID,
+		TraceFlags: oteltrace.FlagsSampled,
+		Remote:     true,
+	})
+	ctx := oteltrace.ContextWithRemoteSpanContext(context.Background(), parent)
+
+	_, otelSpan := t.tracer.Start(ctx, entityType,
+		// keep full entityID
+		oteltrace.WithAttributes(attribute.String("entity_id", entityID.String())),
+		// set chainID as tag for filtering traces from different networks
+		oteltrace.WithAttributes(attribute.String("chainID", t.chainID)),
 	)
-	opts = append(opts, jaeger.SelfRef(ctx))
-	span := t.Tracer.StartSpan(string(entityType), opts...)
-	// keep full entityID
-	span.LogFields(log.String("entity_id", entityID.String()))
-	// set chainID as tag for filtering traces from different networks
-	span.SetTag("chainID", t.chainID)
-	t.spanCache.Add(entityID, span)
+	otelSpan.End() // finish span right away
 
-	span.Finish() // finish span right away
+	span := opentracing.SpanFromContext(t.bridge.ContextWithBridgeSpan(context.Background(), otelSpan))
+	t.spanCache.Add(entityID, span)
 	return span
 }
 
+// EntityTraceID returns the ID of the trace aggregating the spans of the given
+// entity, which is made of the first 16 bytes of the entity ID.
+func EntityTraceID(entityID flow.Identifier) oteltrace.TraceID {
+	var traceID oteltrace.TraceID
+	copy(traceID[:], entityID[:])
+	return traceID
+}
+
 func (t *OpenTracer) StartBlockSpan(
 	ctx context.Context,
 	blockID flow.Identifier,
@@ -233,7 +300,9 @@ func (t *OpenTracer) RecordSpanFromParent(
 	}
 	end := time.Now()
 	start := end.Add(-duration)
-	opts = append(opts, opentracing.FollowsFrom(span.Context()))
+	// OpenTelemetry only supports parent-child relations, the bridge turns a
+	// follows-from reference into a link to a span in another trace
+	opts = append(opts, opentracing.ChildOf(span.Context()))
 	opts = append(opts, opentracing.StartTime(start))
 	sp := t.Tracer.StartSpan(string(operationName), opts...)
 	sp.FinishWithOptions(opentracing.FinishOptions{FinishTime: end, LogRecords: logs})
@@ -0,0 +1,208 @@
+package trace
+
+import (
+	"context"
+	"crypto/rand"
+	"testing"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+func newTestTracer(t *testing.T, opts ...TracerOption) (*OpenTracer, *tracetest.InMemoryExporter) {
+	exporter := tracetest.NewInMemoryExporter()
+	opts = append([]TracerOption{WithExporter(exporter)}, opts...)
+	tracer, err := NewTracer(zerolog.Nop(), "test", "test", SensitivityCaptureAll, opts...)
+	require.NoError(t, err)
+	return tracer, exporter
+}
+
+// flush exports the pending spans. Shutting down the tracer would reset the
+// in-memory exporter.
+func flush(t *testing.T, tracer *OpenTracer) {
+	require.NoError(t, tracer.provider.ForceFlush(context.Background()))
+}
+
+// identifierFixture returns a random identifier, the unittest package can't be
+// used as it depends on this package.
+func identifierFixture(t *testing.T) flow.Identifier {
+	var id flow.Identifier
+	_, err := rand.Read(id[:])
+	require.NoError(t, err)
+	return id
+}
+
+func spanNames(spans tracetest.SpanStubs) []string {
+	names := make([]string, 0, len(spans))
+	for _, span := range spans {
+		names = append(names, span.Name)
+	}
+	return names
+}
+
+func TestEntitySpans(t *testing.T) {
+	tracer, exporter := newTestTracer(t)
+	blockID := identifierFixture(t)
+
+	span, ctx, sampled := tracer.StartBlockSpan(context.Background(), blockID, EXEExecuteBlock)
+	require.True(t, sampled)
+	child, _ := tracer.StartSpanFromContext(ctx, EXEExecuteBlock.Child("child"))
+	child.Finish()
+	span.Finish()
+
+	// the spans of the same entity are in the same trace
+	again, _, _ := tracer.StartBlockSpan(context.Background(), blockID, EXEBroadcastExecutionReceipt)
+	again.Finish()
+
+	flush(t, tracer)
+	spans := exporter.GetSpans()
+	assert.ElementsMatch(t, []string{
+		EntityTypeBlock,
+		string(EXEExecuteBlock),
+		string(EXEExecuteBlock.Child("child")),
+		string(EXEBroadcastExecutionReceipt),
+	}, spanNames(spans))
+	for _, span := range spans {
+		assert.Equal(t, EntityTraceID(blockID), span.SpanContext.TraceID())
+	}
+}
+
+func TestEntitySpans_Sensitivity(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tracer, err := NewTracer(zerolog.Nop(), "test", "test", 64, WithExporter(exporter))
+	require.NoError(t, err)
+
+	// an entity which isn't sampled with the highest sensitivity
+	var blockID flow.Identifier
+	for blockID = identifierFixture(t); blockID.IsSampled(64); blockID = identifierFixture(t) {
+	}
+
+	span, _, sampled := tracer.StartBlockSpan(context.Background(), blockID, EXEExecuteBlock)
+	assert.False(t, sampled)
+	span.Finish()
+
+	flush(t, tracer)
+	assert.Empty(t, exporter.GetSpans())
+}
+
+func TestSpanNameSampler(t *testing.T) {
+	sampler := NewSpanNameSampler(1, map[SpanName]float64{
+		EXEExecuteBlock: 0,
+	})
+	tracer, exporter := newTestTracer(t, WithSampler(sampler))
+
+	// a dropped span drops its children
+	dropped, _, _ := tracer.StartBlockSpan(context.Background(), identifierFixture(t), EXEExecuteBlock)
+	child := tracer.StartSpanFromParent(dropped, EXEExecuteBlock.Child("child"))
+	child.Finish()
+	dropped.Finish()
+
+	kept, _, _ := tracer.StartBlockSpan(context.Background(), identifierFixture(t), EXEBroadcastExecutionReceipt)
+	kept.Finish()
+
+	flush(t, tracer)
+	assert.ElementsMatch(t, []string{
+		EntityTypeBlock,
+		EntityTypeBlock,
+		string(EXEBroadcastExecutionReceipt),
+	}, spanNames(exporter.GetSpans()))
+}
+
+func TestParseSamplingRatios(t *testing.T) {
+	ratios, err := ParseSamplingRatios(map[string]string{
+		string(EXEExecuteBlock): "0.25",
+		string(CONBuilderBuildOn): "1",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[SpanName]float64{
+		EXEExecuteBlock:   0.25,
+		CONBuilderBuildOn: 1,
+	}, ratios)
+
+	_, err = ParseSamplingRatios(map[string]string{string(EXEExecuteBlock): "all"})
+	assert.Error(t, err)
+	_, err = ParseSamplingRatios(map[string]string{string(EXEExecuteBlock): "2"})
+	assert.Error(t, err)
+}
+
+// TestGRPCPropagation checks that the span of a request is propagated from the
+// client to the server.
+func TestGRPCPropagation(t *testing.T) {
+	clientTracer, clientExporter := newTestTracer(t)
+	serverTracer, serverExporter := newTestTracer(t)
+	method := "/flow.execution.ExecutionAPI/ExecuteScriptAtBlockID"
+
+	server := UnaryServerInterceptor(serverTracer)
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		// the spans started by the handler are nested in the request span
+		span, _ := serverTracer.StartSpanFromContext(ctx, "handler")
+		span.Finish()
+		return nil, nil
+	}
+	// the invoker passes the request metadata to the server
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		md, _ := metadata.FromOutgoingContext(ctx)
+		_, err := server(metadata.NewIncomingContext(context.Background(), md), req, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+		return err
+	}
+
+	blockID := identifierFixture(t)
+	span, ctx, _ := clientTracer.StartBlockSpan(context.Background(), blockID, "client")
+	err := UnaryClientInterceptor(clientTracer)(ctx, method, nil, nil, nil, invoker)
+	require.NoError(t, err)
+	span.Finish()
+
+	flush(t, clientTracer)
+	flush(t, serverTracer)
+
+	clientSpans := clientExporter.GetSpans()
+	serverSpans := serverExporter.GetSpans()
+	assert.ElementsMatch(t, []string{EntityTypeBlock, "client", method}, spanNames(clientSpans))
+	assert.ElementsMatch(t, []string{method, "handler"}, spanNames(serverSpans))
+
+	// the server spans continue the trace of the client
+	var request sdktrace.ReadOnlySpan
+	for _, span := range clientSpans.Snapshots() {
+		if span.Name() == method {
+			request = span
+		}
+	}
+	require.NotNil(t, request)
+	for _, span := range serverSpans {
+		assert.Equal(t, EntityTraceID(blockID), span.SpanContext.TraceID())
+		if span.Name == method {
+			assert.Equal(t, request.SpanContext().SpanID(), span.Parent.SpanID())
+		}
+	}
+}
+
+// TestGRPCPropagation_NoSpan checks that the server starts a new trace for
+// requests without a propagated span.
+func TestGRPCPropagation_NoSpan(t *testing.T) {
+	tracer, exporter := newTestTracer(t)
+	method := "/flow.access.AccessAPI/Ping"
+
+	var handlerSpan opentracing.Span
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		handlerSpan = opentracing.SpanFromContext(ctx)
+		return nil, nil
+	}
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.MD{})
+	_, err := UnaryServerInterceptor(tracer)(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+	require.NoError(t, err)
+	assert.NotNil(t, handlerSpan)
+
+	flush(t, tracer)
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, method, spans[0].Name)
+	assert.False(t, spans[0].Parent.IsValid())
+}
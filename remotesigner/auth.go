@@ -0,0 +1,105 @@
+package remotesigner
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// TokenLen is the length in bytes of the token authenticating the node to the signer.
+const TokenLen = 32
+
+// tokenMetadataKey is the gRPC metadata key carrying the authentication token.
+const tokenMetadataKey = "authorization"
+
+// GenerateToken generates a random authentication token and writes it hex encoded
+// to the given path, readable only by the owner. It fails if the file already exists.
+func GenerateToken(path string) ([]byte, error) {
+	token := make([]byte, TokenLen)
+	_, err := rand.Read(token)
+	if err != nil {
+		return nil, fmt.Errorf("could not generate token: %w", err)
+	}
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("could not create token file: %w", err)
+	}
+	defer file.Close()
+
+	_, err = file.WriteString(hex.EncodeToString(token))
+	if err != nil {
+		return nil, fmt.Errorf("could not write token file: %w", err)
+	}
+
+	return token, nil
+}
+
+// LoadToken reads a hex encoded authentication token from the given path.
+func LoadToken(path string) ([]byte, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read token file: %w", err)
+	}
+
+	token, err := hex.DecodeString(strings.TrimSpace(string(data)))
+	if err != nil {
+		return nil, fmt.Errorf("could not decode token: %w", err)
+	}
+	if len(token) != TokenLen {
+		return nil, fmt.Errorf("invalid token length (expected: %d, actual: %d)", TokenLen, len(token))
+	}
+
+	return token, nil
+}
+
+// tokenCredentials attaches the authentication token to every request of a client.
+type tokenCredentials struct {
+	token string
+}
+
+var _ credentials.PerRPCCredentials = (*tokenCredentials)(nil)
+
+func (c *tokenCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{tokenMetadataKey: c.token}, nil
+}
+
+// RequireTransportSecurity returns false, as the signer is only reachable through
+// a local unix socket.
+func (c *tokenCredentials) RequireTransportSecurity() bool {
+	return false
+}
+
+// WithToken returns a dial option authenticating all requests with the given token.
+func WithToken(token []byte) grpc.DialOption {
+	return grpc.WithPerRPCCredentials(&tokenCredentials{token: hex.EncodeToString(token)})
+}
+
+// TokenInterceptor returns a server option rejecting all requests which are not
+// authenticated with the given token.
+func TokenInterceptor(token []byte) grpc.ServerOption {
+	expected := []byte(hex.EncodeToString(token))
+
+	return grpc.UnaryInterceptor(func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		md, ok := metadata.FromIncomingContext(ctx)
+		if !ok {
+			return nil, status.Error(codes.Unauthenticated, "missing authentication token")
+		}
+		values := md.Get(tokenMetadataKey)
+		if len(values) != 1 || subtle.ConstantTimeCompare([]byte(values[0]), expected) != 1 {
+			return nil, status.Error(codes.Unauthenticated, "invalid authentication token")
+		}
+		return handler(ctx, req)
+	})
+}
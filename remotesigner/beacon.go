@@ -0,0 +1,76 @@
+package remotesigner
+
+import (
+	"fmt"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/crypto"
+	"github.com/onflow/flow-go/module"
+	pb "github.com/onflow/flow-go/remotesigner/remotesigner"
+)
+
+// RandomBeaconKeyStore provides the random beacon keys held by a remote signer
+// by view. The keys it returns implement module.VoteSigner, and only sign votes,
+// so that the signer refuses to sign votes for different blocks at the same view.
+//
+// The remote signer only holds the random beacon keys of epochs for which the DKG
+// succeeded and the key was confirmed valid, an epoch without a key is treated as
+// a failed DKG.
+type RandomBeaconKeyStore struct {
+	epochLookup module.EpochLookup // used to fetch epoch counter by view
+	client      pb.RemoteSignerClient
+
+	mu   sync.Mutex
+	keys map[uint64]*remoteKey // cache of keys by epoch, nil if the signer holds no key
+}
+
+var _ module.RandomBeaconKeyStore = (*RandomBeaconKeyStore)(nil)
+
+// RandomBeaconKeyStore returns a random beacon key store using the keys held by the
+// remote signer.
+func (c *Client) RandomBeaconKeyStore(epochLookup module.EpochLookup) *RandomBeaconKeyStore {
+	return &RandomBeaconKeyStore{
+		epochLookup: epochLookup,
+		client:      c.client,
+		keys:        make(map[uint64]*remoteKey),
+	}
+}
+
+// ByView returns the random beacon key for signing objects at a given view.
+// It returns:
+//  - (key, nil) if the remote signer holds a random beacon key for the epoch of the view
+//  - (nil, DKGFailError) if the remote signer holds no random beacon key for the epoch of the view
+//  - (nil, error) if there is any exception
+func (s *RandomBeaconKeyStore) ByView(view uint64) (crypto.PrivateKey, error) {
+	epoch, err := s.epochLookup.EpochForViewWithFallback(view)
+	if err != nil {
+		return nil, fmt.Errorf("could not get epoch by view %v: %w", view, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key, found := s.keys[epoch]
+	if !found {
+		key, err = fetchKey(s.client, &pb.KeyID{Type: pb.KeyType_RANDOM_BEACON, EpochCounter: epoch})
+		if status.Code(err) == codes.NotFound {
+			key, err = nil, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("could not get random beacon key for epoch counter %v from remote signer, at view %v: %w",
+				epoch, view, err)
+		}
+		s.keys[epoch] = key
+	}
+
+	// A nil key means that we don't have a Random Beacon key for this epoch.
+	if key == nil {
+		return nil, fmt.Errorf("DKG for epoch %v failed, at view %v: %w",
+			epoch, view, module.DKGFailError)
+	}
+
+	return key, nil
+}
@@ -0,0 +1,104 @@
+package remotesigner
+
+import (
+	"fmt"
+
+	"google.golang.org/grpc"
+
+	"github.com/onflow/flow-go/crypto"
+	"github.com/onflow/flow-go/crypto/hash"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/flow/filter"
+	"github.com/onflow/flow-go/module"
+	pb "github.com/onflow/flow-go/remotesigner/remotesigner"
+)
+
+// Client is the local node information of a node whose staking key is held by
+// a remote signer. It sends signing requests to the signer over an authenticated
+// unix socket, so that the private key never enters the node's process memory.
+type Client struct {
+	me      *flow.Identity
+	conn    *grpc.ClientConn
+	client  pb.RemoteSignerClient
+	staking *remoteKey
+}
+
+var _ module.Local = (*Client)(nil)
+var _ module.VoteSigner = (*Client)(nil)
+
+// NewClient connects to the remote signer listening on the given unix socket, using
+// the token to authenticate. It returns an error if the staking key held by the signer
+// doesn't match the staking key of the identity.
+func NewClient(id *flow.Identity, socket string, token []byte) (*Client, error) {
+	conn, err := grpc.Dial("unix:"+socket, grpc.WithInsecure(), WithToken(token)) //nolint:staticcheck
+	if err != nil {
+		return nil, fmt.Errorf("could not connect to remote signer: %w", err)
+	}
+
+	client := pb.NewRemoteSignerClient(conn)
+	staking, err := fetchKey(client, &pb.KeyID{Type: pb.KeyType_STAKING})
+	if err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("could not get staking key from remote signer: %w", err)
+	}
+
+	if !staking.PublicKey().Equals(id.StakingPubKey) {
+		_ = conn.Close()
+		return nil, fmt.Errorf("cannot initialize with mismatching keys, expect %v, but got %v",
+			id.StakingPubKey, staking.PublicKey())
+	}
+
+	c := &Client{
+		me:      id,
+		conn:    conn,
+		client:  client,
+		staking: staking,
+	}
+	return c, nil
+}
+
+func (c *Client) NodeID() flow.Identifier {
+	return c.me.NodeID
+}
+
+func (c *Client) Address() string {
+	return c.me.Address
+}
+
+func (c *Client) Sign(msg []byte, hasher hash.Hasher) (crypto.Signature, error) {
+	return c.staking.Sign(msg, hasher)
+}
+
+// SignVote signs the vote of the main consensus for the block at the given view
+// with the staking key. The remote signer refuses to vote for different blocks at
+// the same view, in which case an error wrapping ErrDoubleSign is returned.
+func (c *Client) SignVote(view uint64, blockID flow.Identifier) (crypto.Signature, error) {
+	return c.staking.SignVote(view, blockID)
+}
+
+// SignClusterVote signs the vote of the consensus of the given collection cluster for the
+// block at the given view with the staking key. The remote signer refuses to vote for
+// different blocks at the same view of the cluster chain, in which case an error wrapping
+// ErrDoubleSign is returned.
+func (c *Client) SignClusterVote(clusterID flow.ChainID, view uint64, blockID flow.Identifier) (crypto.Signature, error) {
+	return c.staking.SignClusterVote(clusterID, view, blockID)
+}
+
+func (c *Client) NotMeFilter() flow.IdentityFilter {
+	return filter.Not(filter.HasNodeID(c.NodeID()))
+}
+
+// SignFunc provides a signature oracle that given a message, a hasher, and a signing function, it
+// generates and returns a signature over the message using the node's private key
+// as well as the input hasher by invoking the given signing function. The signing
+// function is given a key which forwards signing to the remote signer, so it can
+// only use the key through its Sign method.
+func (c *Client) SignFunc(data []byte, hasher hash.Hasher, f func(crypto.PrivateKey, []byte, hash.Hasher) (crypto.Signature,
+	error)) (crypto.Signature, error) {
+	return f(c.staking, data, hasher)
+}
+
+// Close closes the connection to the remote signer.
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
@@ -0,0 +1,299 @@
+package remotesigner
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/consensus/hotstuff/verification"
+	"github.com/onflow/flow-go/crypto"
+	"github.com/onflow/flow-go/crypto/hash"
+	"github.com/onflow/flow-go/model/encoding"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/module/mock"
+	pb "github.com/onflow/flow-go/remotesigner/remotesigner"
+	storage "github.com/onflow/flow-go/storage/badger"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// the signer is agnostic of the signing algorithm, keys of any algorithm can be used in tests
+func keyFixture() crypto.PrivateKey {
+	return unittest.PrivateKeyFixture(crypto.Ed25519, crypto.KeyGenSeedMinLenEd25519)
+}
+
+// the BLS hashers require relic, tests hash the messages with a KMAC customized with the tag
+func testHasher(tag string) hash.Hasher {
+	hasher, err := hash.NewKMAC_128([]byte("remote signer test key"), []byte(tag), 32)
+	if err != nil {
+		panic(err)
+	}
+	return hasher
+}
+
+// runWithSigner starts a remote signer holding the given keys, and runs f with the
+// path of its socket and its authentication token.
+func runWithSigner(t *testing.T, stakingKey crypto.PrivateKey, beaconKeys map[uint64]crypto.PrivateKey, f func(socket string, token []byte)) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		db := unittest.TypedBadgerDB(t, filepath.Join(dir, "db"), storage.InitSlashingProtection)
+		defer db.Close()
+
+		runWithSignerDB(t, dir, db, stakingKey, beaconKeys, f)
+	})
+}
+
+func runWithSignerDB(t *testing.T, dir string, db *badger.DB, stakingKey crypto.PrivateKey, beaconKeys map[uint64]crypto.PrivateKey, f func(socket string, token []byte)) {
+	token, err := GenerateToken(filepath.Join(dir, "token"))
+	if err != nil {
+		// reuse the token of a previous signer using the same directory
+		token, err = LoadToken(filepath.Join(dir, "token"))
+	}
+	require.NoError(t, err)
+
+	protection, err := NewSlashingProtection(db)
+	require.NoError(t, err)
+
+	socket := filepath.Join(dir, "signer.sock")
+	listener, err := Listen(socket)
+	require.NoError(t, err)
+
+	server := grpc.NewServer(TokenInterceptor(token))
+	signer := NewServer(zerolog.Nop(), stakingKey, beaconKeys, protection)
+	signer.hasher = testHasher
+	pb.RegisterRemoteSignerServer(server, signer)
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	defer server.Stop()
+
+	f(socket, token)
+}
+
+func identityFixture(stakingKey crypto.PrivateKey) *flow.Identity {
+	return &flow.Identity{
+		NodeID:        unittest.IdentifierFixture(),
+		Address:       "localhost:3569",
+		Role:          flow.RoleConsensus,
+		StakingPubKey: stakingKey.PublicKey(),
+	}
+}
+
+func TestClient(t *testing.T) {
+	stakingKey := keyFixture()
+	identity := identityFixture(stakingKey)
+	hasher := testHasher(encoding.ExecutionReceiptTag)
+	msg := []byte("message")
+
+	runWithSigner(t, stakingKey, nil, func(socket string, token []byte) {
+		client, err := NewClient(identity, socket, token)
+		require.NoError(t, err)
+		defer client.Close()
+		client.staking.hasher = testHasher
+
+		assert.Equal(t, identity.NodeID, client.NodeID())
+		assert.Equal(t, identity.Address, client.Address())
+
+		t.Run("sign", func(t *testing.T) {
+			sig, err := client.Sign(msg, hasher)
+			require.NoError(t, err)
+
+			expected, err := stakingKey.Sign(msg, hasher)
+			require.NoError(t, err)
+			assert.Equal(t, expected, sig)
+
+			valid, err := stakingKey.PublicKey().Verify(sig, msg, hasher)
+			require.NoError(t, err)
+			assert.True(t, valid)
+		})
+
+		t.Run("sign votes", func(t *testing.T) {
+			// votes are only signed through SignVote and SignClusterVote
+			vote := verification.MakeVoteMessage(10, unittest.IdentifierFixture())
+			for _, tag := range []string{encoding.ConsensusVoteTag, encoding.RandomBeaconTag, encoding.CollectorVoteTag} {
+				_, err := client.Sign(vote, testHasher(tag))
+				require.Equal(t, codes.PermissionDenied, status.Code(errors.Unwrap(err)))
+			}
+		})
+
+		t.Run("sign unknown tag", func(t *testing.T) {
+			_, err := client.Sign(msg, hash.NewSHA3_256())
+			require.Error(t, err)
+
+			_, err = client.Sign(msg, testHasher("unknown"))
+			require.Error(t, err)
+		})
+
+		t.Run("sign func", func(t *testing.T) {
+			sig, err := client.SignFunc(msg, hasher, func(key crypto.PrivateKey, msg []byte, hasher hash.Hasher) (crypto.Signature, error) {
+				// the key material is never exposed
+				assert.Nil(t, key.Encode())
+				assert.Equal(t, stakingKey.Algorithm(), key.Algorithm())
+				assert.True(t, key.Equals(stakingKey))
+				return key.Sign(msg, hasher)
+			})
+			require.NoError(t, err)
+
+			valid, err := stakingKey.PublicKey().Verify(sig, msg, hasher)
+			require.NoError(t, err)
+			assert.True(t, valid)
+		})
+
+		t.Run("sign vote", func(t *testing.T) {
+			blockID := unittest.IdentifierFixture()
+			sig, err := client.SignVote(10, blockID)
+			require.NoError(t, err)
+			valid, err := stakingKey.PublicKey().Verify(sig, verification.MakeVoteMessage(10, blockID), testHasher(encoding.ConsensusVoteTag))
+			require.NoError(t, err)
+			assert.True(t, valid)
+
+			// signing the same vote again is allowed
+			_, err = client.SignVote(10, blockID)
+			require.NoError(t, err)
+
+			// voting for a different block at the same view is refused
+			_, err = client.SignVote(10, unittest.IdentifierFixture())
+			require.True(t, errors.Is(err, ErrDoubleSign))
+
+			// voting at a different view is allowed
+			_, err = client.SignVote(11, unittest.IdentifierFixture())
+			require.NoError(t, err)
+		})
+
+		t.Run("sign cluster vote", func(t *testing.T) {
+			blockID := unittest.IdentifierFixture()
+			sig, err := client.SignClusterVote("cluster-1", 10, blockID)
+			require.NoError(t, err)
+			valid, err := stakingKey.PublicKey().Verify(sig, verification.MakeVoteMessage(10, blockID), testHasher(encoding.CollectorVoteTag))
+			require.NoError(t, err)
+			assert.True(t, valid)
+
+			// signing the same vote again is allowed
+			_, err = client.SignClusterVote("cluster-1", 10, blockID)
+			require.NoError(t, err)
+
+			// voting for a different block at the same view of the cluster is refused
+			_, err = client.SignClusterVote("cluster-1", 10, unittest.IdentifierFixture())
+			require.True(t, errors.Is(err, ErrDoubleSign))
+
+			// the views of other clusters are independent, as the views restart with every epoch
+			_, err = client.SignClusterVote("cluster-2", 10, unittest.IdentifierFixture())
+			require.NoError(t, err)
+		})
+	})
+}
+
+func TestClient_MismatchingKey(t *testing.T) {
+	identity := identityFixture(keyFixture())
+
+	runWithSigner(t, keyFixture(), nil, func(socket string, token []byte) {
+		_, err := NewClient(identity, socket, token)
+		require.Error(t, err)
+	})
+}
+
+func TestClient_InvalidToken(t *testing.T) {
+	stakingKey := keyFixture()
+	identity := identityFixture(stakingKey)
+
+	runWithSigner(t, stakingKey, nil, func(socket string, token []byte) {
+		invalid := make([]byte, len(token))
+		copy(invalid, token)
+		invalid[0] ^= 0xff
+
+		_, err := NewClient(identity, socket, invalid)
+		require.Error(t, err)
+	})
+}
+
+// TestSlashingProtection_Restart checks that signed views are remembered when the signer restarts.
+func TestSlashingProtection_Restart(t *testing.T) {
+	stakingKey := keyFixture()
+	identity := identityFixture(stakingKey)
+
+	unittest.RunWithTempDir(t, func(dir string) {
+		db := unittest.TypedBadgerDB(t, filepath.Join(dir, "db"), storage.InitSlashingProtection)
+		defer db.Close()
+
+		runWithSignerDB(t, dir, db, stakingKey, nil, func(socket string, token []byte) {
+			client, err := NewClient(identity, socket, token)
+			require.NoError(t, err)
+			defer client.Close()
+
+			_, err = client.SignVote(10, unittest.IdentifierFixture())
+			require.NoError(t, err)
+		})
+
+		runWithSignerDB(t, dir, db, stakingKey, nil, func(socket string, token []byte) {
+			client, err := NewClient(identity, socket, token)
+			require.NoError(t, err)
+			defer client.Close()
+
+			_, err = client.SignVote(10, unittest.IdentifierFixture())
+			require.True(t, errors.Is(err, ErrDoubleSign))
+
+			_, err = client.SignVote(11, unittest.IdentifierFixture())
+			require.NoError(t, err)
+		})
+	})
+}
+
+func TestRandomBeaconKeyStore(t *testing.T) {
+	stakingKey := keyFixture()
+	beaconKey := keyFixture()
+	identity := identityFixture(stakingKey)
+	hasher := hash.NewSHA3_256()
+	msg := []byte("message")
+
+	epochLookup := new(mock.EpochLookup)
+	epochLookup.On("EpochForViewWithFallback", uint64(10)).Return(uint64(1), nil)
+	epochLookup.On("EpochForViewWithFallback", uint64(20)).Return(uint64(2), nil)
+
+	runWithSigner(t, stakingKey, map[uint64]crypto.PrivateKey{1: beaconKey}, func(socket string, token []byte) {
+		client, err := NewClient(identity, socket, token)
+		require.NoError(t, err)
+		defer client.Close()
+
+		store := client.RandomBeaconKeyStore(epochLookup)
+
+		t.Run("epoch with key", func(t *testing.T) {
+			key, err := store.ByView(10)
+			require.NoError(t, err)
+			assert.True(t, key.PublicKey().Equals(beaconKey.PublicKey()))
+
+			voter, ok := key.(module.VoteSigner)
+			require.True(t, ok)
+
+			blockID := unittest.IdentifierFixture()
+			sig, err := voter.SignVote(10, blockID)
+			require.NoError(t, err)
+			valid, err := beaconKey.PublicKey().Verify(sig, verification.MakeVoteMessage(10, blockID), testHasher(encoding.RandomBeaconTag))
+			require.NoError(t, err)
+			assert.True(t, valid)
+
+			// voting for a different block at the same view is refused
+			_, err = voter.SignVote(10, unittest.IdentifierFixture())
+			require.True(t, errors.Is(err, ErrDoubleSign))
+
+			// random beacon keys only sign votes
+			_, err = key.Sign(msg, hasher)
+			require.Error(t, err)
+
+			// the staking key is protected independently
+			_, err = client.SignVote(10, unittest.IdentifierFixture())
+			require.NoError(t, err)
+		})
+
+		t.Run("epoch without key", func(t *testing.T) {
+			_, err := store.ByView(20)
+			require.True(t, errors.Is(err, module.DKGFailError))
+		})
+	})
+}
@@ -0,0 +1,217 @@
+package remotesigner
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/crypto"
+	"github.com/onflow/flow-go/crypto/hash"
+	"github.com/onflow/flow-go/model/encoding"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module"
+	pb "github.com/onflow/flow-go/remotesigner/remotesigner"
+)
+
+// RequestTimeout is the timeout of requests to the remote signer.
+const RequestTimeout = 5 * time.Second
+
+// ErrDoubleSign is returned when a key is requested to sign a vote for a view
+// at which it already signed a vote for a different block.
+var ErrDoubleSign = errors.New("refusing to sign a conflicting vote for the same view")
+
+// domainTags are the domain tags of the messages signed by the nodes.
+var domainTags = []string{
+	encoding.RandomBeaconTag,
+	encoding.ConsensusVoteTag,
+	encoding.CollectorVoteTag,
+	encoding.ExecutionReceiptTag,
+	encoding.ResultApprovalTag,
+	encoding.SPOCKTag,
+	encoding.DKGMessageTag,
+}
+
+// tagProbe is the message hashed to identify the domain tag of a hasher.
+var tagProbe = []byte("remote signer domain tag probe")
+
+// remoteKey is a private key held by the remote signer. It implements crypto.PrivateKey,
+// so that it can be used in place of a local key, but never exposes the key material.
+//
+// Messages are sent to the signer with the domain tag of the provided hasher, and hashed
+// by the signer. Votes are built and hashed by the signer, see SignVote and SignClusterVote.
+type remoteKey struct {
+	client pb.RemoteSignerClient
+	id     *pb.KeyID
+	pk     crypto.PublicKey
+	hasher func(tag string) hash.Hasher // the hasher of the messages with a domain tag
+
+	tagsOnce sync.Once
+	tags     map[string]string // domain tags by the hex encoded hash of the probe
+}
+
+var _ crypto.PrivateKey = (*remoteKey)(nil)
+var _ module.VoteSigner = (*remoteKey)(nil)
+
+// fetchKey retrieves the public key of a key held by the remote signer.
+// It returns a status error with code NotFound if the signer does not hold the key.
+func fetchKey(client pb.RemoteSignerClient, id *pb.KeyID) (*remoteKey, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), RequestTimeout)
+	defer cancel()
+
+	resp, err := client.PublicKey(ctx, &pb.PublicKeyRequest{Key: id})
+	if err != nil {
+		return nil, err
+	}
+
+	pk, err := crypto.DecodePublicKey(crypto.SigningAlgorithm(resp.GetSigningAlgorithm()), resp.GetPublicKey())
+	if err != nil {
+		return nil, fmt.Errorf("could not decode public key: %w", err)
+	}
+
+	return &remoteKey{
+		client: client,
+		id:     id,
+		pk:     pk,
+		hasher: crypto.NewBLSKMAC,
+	}, nil
+}
+
+func (k *remoteKey) Algorithm() crypto.SigningAlgorithm {
+	return k.pk.Algorithm()
+}
+
+func (k *remoteKey) Size() int {
+	switch k.pk.Algorithm() {
+	case crypto.BLSBLS12381:
+		return crypto.PrKeyLenBLSBLS12381
+	case crypto.ECDSAP256:
+		return crypto.PrKeyLenECDSAP256
+	case crypto.ECDSASecp256k1:
+		return crypto.PrKeyLenECDSASecp256k1
+	case crypto.Ed25519:
+		return crypto.PrKeyLenEd25519
+	default:
+		return 0
+	}
+}
+
+func (k *remoteKey) String() string {
+	return fmt.Sprintf("remote %s key (public key: %s)", k.pk.Algorithm(), k.pk)
+}
+
+// Sign requests the remote signer to sign the message, hashed with the domain tag of
+// the hasher. The hasher must be the hasher of one of the domain tags of the protocol.
+// Only the staking key signs messages, random beacon keys only sign votes, and votes
+// must be signed with SignVote and SignClusterVote.
+func (k *remoteKey) Sign(msg []byte, hasher hash.Hasher) (crypto.Signature, error) {
+	if hasher == nil {
+		return nil, fmt.Errorf("hasher is nil")
+	}
+	if k.id.GetType() != pb.KeyType_STAKING {
+		return nil, fmt.Errorf("%s keys only sign votes", k.id.GetType())
+	}
+	tag, err := k.tag(hasher)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), RequestTimeout)
+	defer cancel()
+
+	resp, err := k.client.Sign(ctx, &pb.SignRequest{
+		Key:     k.id,
+		Message: msg,
+		Tag:     tag,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("remote signer failed to sign: %w", err)
+	}
+
+	return resp.GetSignature(), nil
+}
+
+// SignVote requests the remote signer to sign the vote of the main consensus for the
+// block at the given view. An error wrapping ErrDoubleSign is returned when the signer
+// already signed a vote for a different block at this view.
+func (k *remoteKey) SignVote(view uint64, blockID flow.Identifier) (crypto.Signature, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), RequestTimeout)
+	defer cancel()
+
+	resp, err := k.client.SignVote(ctx, &pb.SignVoteRequest{
+		Key:     k.id,
+		View:    view,
+		BlockId: blockID[:],
+	})
+	if status.Code(err) == codes.AlreadyExists {
+		return nil, fmt.Errorf("could not sign vote for view %d: %w", view, ErrDoubleSign)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("remote signer failed to sign vote: %w", err)
+	}
+
+	return resp.GetSignature(), nil
+}
+
+// SignClusterVote requests the remote signer to sign the vote of the consensus of the given
+// collection cluster for the block at the given view. An error wrapping ErrDoubleSign is
+// returned when the signer already signed a vote for a different block at this view of
+// the cluster chain.
+func (k *remoteKey) SignClusterVote(clusterID flow.ChainID, view uint64, blockID flow.Identifier) (crypto.Signature, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), RequestTimeout)
+	defer cancel()
+
+	resp, err := k.client.SignClusterVote(ctx, &pb.SignClusterVoteRequest{
+		Key:       k.id,
+		ClusterId: clusterID.String(),
+		View:      view,
+		BlockId:   blockID[:],
+	})
+	if status.Code(err) == codes.AlreadyExists {
+		return nil, fmt.Errorf("could not sign vote for view %d of cluster %s: %w", view, clusterID, ErrDoubleSign)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("remote signer failed to sign cluster vote: %w", err)
+	}
+
+	return resp.GetSignature(), nil
+}
+
+// tag returns the domain tag of the hasher, by comparing its hash of a probe message with
+// the hashes of the hashers of the domain tags of the protocol.
+func (k *remoteKey) tag(hasher hash.Hasher) (string, error) {
+	k.tagsOnce.Do(func() {
+		k.tags = make(map[string]string, len(domainTags))
+		for _, tag := range domainTags {
+			k.tags[hex.EncodeToString(k.hasher(tag).ComputeHash(tagProbe))] = tag
+		}
+	})
+
+	tag, ok := k.tags[hex.EncodeToString(hasher.ComputeHash(tagProbe))]
+	if !ok {
+		return "", fmt.Errorf("the remote signer only signs messages hashed with the domain tags of the protocol")
+	}
+	return tag, nil
+}
+
+func (k *remoteKey) PublicKey() crypto.PublicKey {
+	return k.pk
+}
+
+// Encode returns nil, as the key material is never exposed by the remote signer.
+func (k *remoteKey) Encode() []byte {
+	return nil
+}
+
+// Equals returns true if the other key has the same public key.
+func (k *remoteKey) Equals(other crypto.PrivateKey) bool {
+	if other == nil {
+		return false
+	}
+	return k.pk.Equals(other.PublicKey())
+}
@@ -0,0 +1,105 @@
+package remotesigner
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/model/flow"
+	pb "github.com/onflow/flow-go/remotesigner/remotesigner"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/storage/badger/operation"
+)
+
+// SlashingProtection keeps track of the digests signed by each key of the remote
+// signer for each consensus view, in order to refuse signing two different messages
+// for the same view.
+type SlashingProtection struct {
+	mu sync.Mutex
+	db *badger.DB
+}
+
+// NewSlashingProtection creates a slashing protection backed by the given database,
+// which must be initialized as a slashing protection database.
+func NewSlashingProtection(db *badger.DB) (*SlashingProtection, error) {
+	err := operation.EnsureSlashingProtectionDB(db)
+	if err != nil {
+		return nil, fmt.Errorf("invalid slashing protection database: %w", err)
+	}
+	return &SlashingProtection{db: db}, nil
+}
+
+// Protect records that the key is about to sign the digest at the given view.
+// Signing the same digest again is allowed, so that a node can recover a signature
+// it lost, for instance when restarting. It returns an error wrapping ErrDoubleSign
+// if the key already signed a different digest at this view.
+func (p *SlashingProtection) Protect(key *pb.KeyID, view uint64, digest []byte) error {
+	keyType := uint8(key.GetType())
+	epoch := key.GetEpochCounter()
+
+	return p.protect(
+		func(signed *[]byte) func(*badger.Txn) error {
+			return operation.RetrieveSignedDigest(keyType, epoch, view, signed)
+		},
+		operation.InsertSignedDigest(keyType, epoch, view, digest),
+		digest,
+		func(signed []byte) error {
+			return fmt.Errorf("%s key (epoch %d) already signed %x at view %d: %w",
+				key.GetType(), epoch, signed, view, ErrDoubleSign)
+		},
+	)
+}
+
+// ProtectCluster records that the staking key is about to sign the digest of a vote at
+// the given view of the consensus of a collection cluster. The views of cluster chains
+// restart with every epoch, so the signed views are recorded for each cluster. It returns
+// an error wrapping ErrDoubleSign if the key already signed a different digest at this
+// view of the cluster chain.
+func (p *SlashingProtection) ProtectCluster(clusterID flow.ChainID, view uint64, digest []byte) error {
+	return p.protect(
+		func(signed *[]byte) func(*badger.Txn) error {
+			return operation.RetrieveSignedClusterDigest(clusterID, view, signed)
+		},
+		operation.InsertSignedClusterDigest(clusterID, view, digest),
+		digest,
+		func(signed []byte) error {
+			return fmt.Errorf("staking key already signed %x at view %d of cluster %s: %w",
+				signed, view, clusterID, ErrDoubleSign)
+		},
+	)
+}
+
+// protect records the digest with insert, unless a digest was already signed, in which
+// case it returns the error built by conflict if the signed digest is different.
+func (p *SlashingProtection) protect(
+	retrieve func(*[]byte) func(*badger.Txn) error,
+	insert func(*badger.Txn) error,
+	digest []byte,
+	conflict func(signed []byte) error,
+) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.db.Update(func(txn *badger.Txn) error {
+		var signed []byte
+		err := retrieve(&signed)(txn)
+		if err == nil {
+			if bytes.Equal(signed, digest) {
+				return nil
+			}
+			return conflict(signed)
+		}
+		if !errors.Is(err, storage.ErrNotFound) {
+			return fmt.Errorf("could not retrieve signed digest: %w", err)
+		}
+
+		err = insert(txn)
+		if err != nil {
+			return fmt.Errorf("could not insert signed digest: %w", err)
+		}
+		return nil
+	})
+}
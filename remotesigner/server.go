@@ -0,0 +1,238 @@
+package remotesigner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/consensus/hotstuff/verification"
+	"github.com/onflow/flow-go/crypto"
+	"github.com/onflow/flow-go/crypto/hash"
+	"github.com/onflow/flow-go/model/encoding"
+	"github.com/onflow/flow-go/model/flow"
+	pb "github.com/onflow/flow-go/remotesigner/remotesigner"
+)
+
+// Server is the signer daemon. It holds the staking key and the random beacon keys
+// of a node, and signs messages on behalf of the node.
+type Server struct {
+	pb.UnimplementedRemoteSignerServer
+	log        zerolog.Logger
+	stakingKey crypto.PrivateKey
+	beaconKeys map[uint64]crypto.PrivateKey // random beacon keys by epoch counter
+	protection *SlashingProtection
+	hasher     func(tag string) hash.Hasher // the hasher of the messages with a domain tag
+}
+
+// NewServer creates a signer daemon holding the given staking key and random
+// beacon keys by epoch counter.
+func NewServer(
+	log zerolog.Logger,
+	stakingKey crypto.PrivateKey,
+	beaconKeys map[uint64]crypto.PrivateKey,
+	protection *SlashingProtection,
+) *Server {
+	return &Server{
+		log:        log.With().Str("component", "remote_signer").Logger(),
+		stakingKey: stakingKey,
+		beaconKeys: beaconKeys,
+		protection: protection,
+		hasher:     crypto.NewBLSKMAC,
+	}
+}
+
+// voteTags are the domain tags of votes, which are only signed through SignVote and
+// SignClusterVote so that the slashing protection always applies.
+var voteTags = map[string]struct{}{
+	encoding.ConsensusVoteTag: {},
+	encoding.RandomBeaconTag:  {},
+	encoding.CollectorVoteTag: {},
+}
+
+// voteTag returns the domain tag of the votes of the main consensus signed with the
+// given key type, as used by the hotstuff combined signers.
+func voteTag(keyType pb.KeyType) string {
+	if keyType == pb.KeyType_RANDOM_BEACON {
+		return encoding.RandomBeaconTag
+	}
+	return encoding.ConsensusVoteTag
+}
+
+// Listen listens on the unix socket at the given path. The socket is only
+// accessible to the user running the signer. An existing socket file is replaced.
+func Listen(socket string) (net.Listener, error) {
+	err := os.Remove(socket)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("could not remove existing socket: %w", err)
+	}
+
+	listener, err := net.Listen("unix", socket)
+	if err != nil {
+		return nil, fmt.Errorf("could not listen on socket: %w", err)
+	}
+
+	err = os.Chmod(socket, 0600)
+	if err != nil {
+		_ = listener.Close()
+		return nil, fmt.Errorf("could not restrict socket permissions: %w", err)
+	}
+
+	return listener, nil
+}
+
+func (s *Server) key(id *pb.KeyID) (crypto.PrivateKey, error) {
+	switch id.GetType() {
+	case pb.KeyType_STAKING:
+		return s.stakingKey, nil
+	case pb.KeyType_RANDOM_BEACON:
+		key, ok := s.beaconKeys[id.GetEpochCounter()]
+		if !ok {
+			return nil, status.Errorf(codes.NotFound, "no random beacon key for epoch %d", id.GetEpochCounter())
+		}
+		return key, nil
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unknown key type %d", id.GetType())
+	}
+}
+
+func (s *Server) PublicKey(ctx context.Context, in *pb.PublicKeyRequest) (*pb.PublicKeyResponse, error) {
+	key, err := s.key(in.GetKey())
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.PublicKeyResponse{
+		SigningAlgorithm: uint32(key.Algorithm()),
+		PublicKey:        key.PublicKey().Encode(),
+	}, nil
+}
+
+// Sign signs a message with the staking key, hashed with the domain tag of the request.
+// Random beacon keys are only used for the votes of the main consensus, so they can only
+// sign through SignVote. Messages with the tag of a vote are refused, as votes are only
+// signed through SignVote and SignClusterVote, which apply the slashing protection.
+func (s *Server) Sign(ctx context.Context, in *pb.SignRequest) (*pb.SignResponse, error) {
+	if in.GetKey().GetType() != pb.KeyType_STAKING {
+		return nil, status.Errorf(codes.InvalidArgument, "%s keys only sign votes", in.GetKey().GetType())
+	}
+	key, err := s.key(in.GetKey())
+	if err != nil {
+		return nil, err
+	}
+
+	tag := in.GetTag()
+	if tag == "" {
+		return nil, status.Error(codes.InvalidArgument, "empty domain tag")
+	}
+	if _, isVote := voteTags[tag]; isVote {
+		return nil, status.Errorf(codes.PermissionDenied, "votes (tag %s) are only signed through SignVote and SignClusterVote", tag)
+	}
+
+	sig, err := key.Sign(in.GetMessage(), s.hasher(tag))
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "could not sign: %v", err)
+	}
+
+	return &pb.SignResponse{
+		Signature: sig,
+	}, nil
+}
+
+// SignVote signs the vote of the main consensus for a block. The vote message is built
+// and hashed by the signer, and the slashing protection always applies: a vote for a
+// different block at a view the key already voted at is refused.
+func (s *Server) SignVote(ctx context.Context, in *pb.SignVoteRequest) (*pb.SignResponse, error) {
+	key, err := s.key(in.GetKey())
+	if err != nil {
+		return nil, err
+	}
+
+	blockID, err := flow.ByteSliceToId(in.GetBlockId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid block ID: %v", err)
+	}
+
+	log := s.log.With().
+		Str("key", in.GetKey().GetType().String()).
+		Uint64("epoch", in.GetKey().GetEpochCounter()).
+		Uint64("view", in.GetView()).
+		Hex("block_id", blockID[:]).
+		Logger()
+
+	return s.signVote(log, key, in.GetView(), blockID, voteTag(in.GetKey().GetType()), func(digest []byte) error {
+		return s.protection.Protect(in.GetKey(), in.GetView(), digest)
+	})
+}
+
+// SignClusterVote signs the vote of the consensus of a collection cluster for a block with
+// the staking key. The vote message is built and hashed by the signer, and the slashing
+// protection always applies: a vote for a different block at a view of the cluster chain
+// the key already voted at is refused.
+func (s *Server) SignClusterVote(ctx context.Context, in *pb.SignClusterVoteRequest) (*pb.SignResponse, error) {
+	if in.GetKey().GetType() != pb.KeyType_STAKING {
+		return nil, status.Errorf(codes.InvalidArgument, "%s keys do not sign cluster votes", in.GetKey().GetType())
+	}
+	key, err := s.key(in.GetKey())
+	if err != nil {
+		return nil, err
+	}
+
+	clusterID := flow.ChainID(in.GetClusterId())
+	if clusterID == "" {
+		return nil, status.Error(codes.InvalidArgument, "empty cluster ID")
+	}
+	blockID, err := flow.ByteSliceToId(in.GetBlockId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid block ID: %v", err)
+	}
+
+	log := s.log.With().
+		Str("cluster_id", clusterID.String()).
+		Uint64("view", in.GetView()).
+		Hex("block_id", blockID[:]).
+		Logger()
+
+	return s.signVote(log, key, in.GetView(), blockID, encoding.CollectorVoteTag, func(digest []byte) error {
+		return s.protection.ProtectCluster(clusterID, in.GetView(), digest)
+	})
+}
+
+// signVote builds the vote message for the block at the given view, records its digest
+// with protect and signs it.
+func (s *Server) signVote(
+	log zerolog.Logger,
+	key crypto.PrivateKey,
+	view uint64,
+	blockID flow.Identifier,
+	tag string,
+	protect func(digest []byte) error,
+) (*pb.SignResponse, error) {
+	msg := verification.MakeVoteMessage(view, blockID)
+	hasher := s.hasher(tag)
+	digest := hasher.ComputeHash(msg)
+
+	err := protect(digest)
+	if errors.Is(err, ErrDoubleSign) {
+		log.Warn().Err(err).Msg("refused to double-sign")
+		return nil, status.Error(codes.AlreadyExists, err.Error())
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("slashing protection failed")
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	sig, err := key.Sign(msg, hasher)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "could not sign: %v", err)
+	}
+
+	return &pb.SignResponse{
+		Signature: sig,
+	}, nil
+}
@@ -173,6 +173,49 @@ func TestBootstrapNonRoot(t *testing.T) {
 		})
 	})
 
+	// should be able to bootstrap from a historical snapshot, taken after the
+	// chain was extended and sealed beyond its reference block
+	// ROOT <- B1 <- B2(S1) <- B3 <- B4(S2) <- B5(S3) <- CHILD
+	t.Run("with historical block", func(t *testing.T) {
+		after := snapshotAfter(t, rootSnapshot, func(state *bprotocol.FollowerState) protocol.Snapshot {
+			block1 := unittest.BlockWithParentFixture(rootBlock)
+			buildBlock(t, state, block1)
+
+			receipt1, seal1 := unittest.ReceiptAndSealForBlock(block1)
+			block2 := unittest.BlockWithParentFixture(block1.Header)
+			block2.SetPayload(unittest.PayloadFixture(unittest.WithSeals(seal1), unittest.WithReceipts(receipt1)))
+			buildBlock(t, state, block2)
+
+			block3 := unittest.BlockWithParentFixture(block2.Header)
+			buildBlock(t, state, block3)
+
+			receipt2, seal2 := unittest.ReceiptAndSealForBlock(block2)
+			block4 := unittest.BlockWithParentFixture(block3.Header)
+			block4.SetPayload(unittest.PayloadFixture(unittest.WithSeals(seal2), unittest.WithReceipts(receipt2)))
+			buildBlock(t, state, block4)
+
+			receipt3, seal3 := unittest.ReceiptAndSealForBlock(block3)
+			block5 := unittest.BlockWithParentFixture(block4.Header)
+			block5.SetPayload(unittest.PayloadFixture(unittest.WithSeals(seal3), unittest.WithReceipts(receipt3)))
+			buildBlock(t, state, block5)
+
+			child := unittest.BlockWithParentFixture(block5.Header)
+			buildBlock(t, state, child)
+
+			// only finalized blocks are indexed by height
+			for _, block := range []*flow.Block{block1, block2, block3, block4, block5} {
+				require.NoError(t, state.Finalize(context.Background(), block.ID()))
+			}
+
+			return state.AtHeight(block2.Header.Height)
+		})
+
+		bootstrap(t, after, func(state *bprotocol.State, err error) {
+			require.NoError(t, err)
+			unittest.AssertSnapshotsEqual(t, after, state.Final())
+		})
+	})
+
 	t.Run("with setup next epoch", func(t *testing.T) {
 		after := snapshotAfter(t, rootSnapshot, func(state *bprotocol.FollowerState) protocol.Snapshot {
 			unittest.NewEpochBuilder(t, state).BuildEpoch()
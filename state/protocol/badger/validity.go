@@ -51,6 +51,13 @@ func isValidExtendingEpochSetup(extendingSetup *flow.EpochSetup, activeSetup *fl
 	return nil
 }
 
+// IsValidExtendingEpochSetup checks whether an epoch setup service event would be accepted
+// by the protocol state when extending the given active epoch. It returns an
+// InvalidServiceEventError if including the event would trigger epoch emergency fallback.
+func IsValidExtendingEpochSetup(extendingSetup *flow.EpochSetup, activeSetup *flow.EpochSetup, status *flow.EpochStatus) error {
+	return isValidExtendingEpochSetup(extendingSetup, activeSetup, status)
+}
+
 // isValidEpochSetup checks whether an epoch setup service event is intrinsically valid
 func isValidEpochSetup(setup *flow.EpochSetup) error {
 	return verifyEpochSetup(setup, true)
@@ -172,6 +179,13 @@ func isValidExtendingEpochCommit(extendingCommit *flow.EpochCommit, extendingSet
 	return nil
 }
 
+// IsValidExtendingEpochCommit checks whether an epoch commit service event would be accepted
+// by the protocol state when extending the given active epoch. It returns an
+// InvalidServiceEventError if including the event would trigger epoch emergency fallback.
+func IsValidExtendingEpochCommit(extendingCommit *flow.EpochCommit, extendingSetup *flow.EpochSetup, activeSetup *flow.EpochSetup, status *flow.EpochStatus) error {
+	return isValidExtendingEpochCommit(extendingCommit, extendingSetup, activeSetup, status)
+}
+
 // isValidEpochCommit checks whether an epoch commit service event is intrinsically valid.
 func isValidEpochCommit(commit *flow.EpochCommit, setup *flow.EpochSetup) error {
 
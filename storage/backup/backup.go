@@ -0,0 +1,167 @@
+package backup
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage/badger/operation"
+)
+
+// Checkpointer writes a checkpoint of the execution state at the given state
+// commitment.
+type Checkpointer func(ctx context.Context, commit flow.StateCommitment, w io.Writer) error
+
+// Progress is called with the number of files of a backup written so far, and
+// the total number of files of the backup.
+type Progress func(completed uint64, total uint64)
+
+// Create takes an online backup of the protocol database into the given
+// directory, which must not exist or be empty. On execution nodes, the chunk
+// data packs database and a checkpointer are given: the backup then also
+// includes the chunk data packs database and a checkpoint of the execution
+// state at the highest executed block of the protocol database backup.
+// The progress is reported after each file if a progress function is given,
+// and the backup is aborted with the error of the context once it is done.
+func Create(ctx context.Context, dir string, db *badger.DB, chunkDataPacksDB *badger.DB, checkpointer Checkpointer, progress Progress) (*Manifest, error) {
+	err := ensureEmptyDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	total := uint64(1)
+	if chunkDataPacksDB != nil {
+		total++
+	}
+	if checkpointer != nil {
+		total++
+	}
+	report := func(completed uint64) {
+		if progress != nil {
+			progress(completed, total)
+		}
+	}
+	report(0)
+
+	manifest := &Manifest{
+		Version:   ManifestVersion,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	var markers *operation.BackupMarkers
+	protocolFile, err := writeFile(dir, ProtocolDBFilename, func(w io.Writer) error {
+		var err error
+		markers, err = operation.Backup(ctx, db, w)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not back up protocol database: %w", err)
+	}
+	manifest.Files = append(manifest.Files, protocolFile)
+	report(uint64(len(manifest.Files)))
+	manifest.FinalizedHeight = markers.FinalizedHeight
+	manifest.SealedHeight = markers.SealedHeight
+
+	if chunkDataPacksDB != nil {
+		// the chunk data packs are only stored once their block is executed, so
+		// the backup taken after the protocol database backup has the chunk data
+		// packs of all blocks executed in it
+		chunkDataPacksFile, err := writeFile(dir, ChunkDataPacksDBFilename, func(w io.Writer) error {
+			_, err := operation.Backup(ctx, chunkDataPacksDB, w)
+			return err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("could not back up chunk data packs database: %w", err)
+		}
+		manifest.Files = append(manifest.Files, chunkDataPacksFile)
+		report(uint64(len(manifest.Files)))
+	}
+
+	if checkpointer != nil {
+		if markers.ExecutedBlockID == nil {
+			return nil, fmt.Errorf("protocol database has no executed block to checkpoint the execution state at")
+		}
+
+		// the header and the state commitment of an executed block never
+		// change, so they can be read after the backup
+		blockID := *markers.ExecutedBlockID
+		var header flow.Header
+		err = db.View(operation.RetrieveHeader(blockID, &header))
+		if err != nil {
+			return nil, fmt.Errorf("could not retrieve executed block %v: %w", blockID, err)
+		}
+		var commit flow.StateCommitment
+		err = db.View(operation.LookupStateCommitment(blockID, &commit))
+		if err != nil {
+			return nil, fmt.Errorf("could not retrieve state commitment of executed block %v: %w", blockID, err)
+		}
+
+		checkpointFile, err := writeFile(dir, CheckpointFilename, func(w io.Writer) error {
+			return checkpointer(ctx, commit, w)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("could not checkpoint execution state at %v: %w", commit, err)
+		}
+
+		manifest.Files = append(manifest.Files, checkpointFile)
+		report(uint64(len(manifest.Files)))
+		manifest.ExecutedBlockID = &blockID
+		manifest.ExecutedHeight = header.Height
+		manifest.StateCommitment = &commit
+	}
+
+	err = writeManifest(dir, manifest)
+	if err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// ensureEmptyDir creates the given directory, or checks it is empty if it exists.
+func ensureEmptyDir(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return os.MkdirAll(dir, 0700)
+	}
+	if err != nil {
+		return fmt.Errorf("could not read directory %s: %w", dir, err)
+	}
+	if len(entries) > 0 {
+		return fmt.Errorf("directory %s is not empty", dir)
+	}
+	return nil
+}
+
+// writeFile creates the file of the given name in the directory, writes it
+// with the given function, and returns its size and checksum.
+func writeFile(dir string, name string, write func(w io.Writer) error) (File, error) {
+	path := filepath.Join(dir, name)
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return File{}, fmt.Errorf("could not create %s: %w", path, err)
+	}
+	defer f.Close()
+
+	w := bufio.NewWriter(f)
+	err = write(w)
+	if err != nil {
+		return File{}, err
+	}
+	err = w.Flush()
+	if err != nil {
+		return File{}, fmt.Errorf("could not write %s: %w", path, err)
+	}
+	err = f.Sync()
+	if err != nil {
+		return File{}, fmt.Errorf("could not sync %s: %w", path, err)
+	}
+
+	return checksum(path)
+}
@@ -0,0 +1,178 @@
+package backup
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	badgermodel "github.com/onflow/flow-go/storage/badger/model"
+	"github.com/onflow/flow-go/storage/badger/operation"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// populate inserts the markers of an execution node into the database, and
+// returns the executed block and its state commitment.
+func populate(t *testing.T, db *badger.DB) (*flow.Header, flow.StateCommitment) {
+	header := unittest.BlockHeaderFixture()
+	commit := unittest.StateCommitmentFixture()
+	require.NoError(t, db.Update(func(tx *badger.Txn) error {
+		if err := operation.InsertFinalizedHeight(header.Height + 2)(tx); err != nil {
+			return err
+		}
+		if err := operation.InsertSealedHeight(header.Height - 1)(tx); err != nil {
+			return err
+		}
+		if err := operation.InsertHeader(header.ID(), &header)(tx); err != nil {
+			return err
+		}
+		if err := operation.IndexStateCommitment(header.ID(), commit)(tx); err != nil {
+			return err
+		}
+		return operation.InsertExecutedBlock(header.ID())(tx)
+	}))
+	return &header, commit
+}
+
+func TestBackupAndRestore(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		header, commit := populate(t, db)
+		checkpoint := unittest.RandomBytes(1024)
+
+		var checkpointed flow.StateCommitment
+		checkpointer := func(_ context.Context, c flow.StateCommitment, w io.Writer) error {
+			checkpointed = c
+			_, err := w.Write(checkpoint)
+			return err
+		}
+
+		unittest.RunWithTempDir(t, func(dir string) {
+			chunkDataPacksDB := unittest.BadgerDB(t, filepath.Join(dir, "chunk_data_packs"))
+			defer chunkDataPacksDB.Close()
+			chunkDataPack := unittest.ChunkDataPackFixture(unittest.IdentifierFixture())
+			require.NoError(t, chunkDataPacksDB.Update(operation.InsertChunkDataPack(&badgermodel.StoredChunkDataPack{
+				ChunkID:    chunkDataPack.ChunkID,
+				StartState: chunkDataPack.StartState,
+				Proof:      chunkDataPack.Proof,
+			})))
+
+			var progress []uint64
+			backupDir := filepath.Join(dir, "backup")
+			manifest, err := Create(context.Background(), backupDir, db, chunkDataPacksDB, checkpointer, func(completed uint64, total uint64) {
+				assert.Equal(t, uint64(3), total)
+				progress = append(progress, completed)
+			})
+			require.NoError(t, err)
+			assert.Equal(t, []uint64{0, 1, 2, 3}, progress)
+
+			assert.Equal(t, commit, checkpointed)
+			assert.Equal(t, header.Height+2, manifest.FinalizedHeight)
+			assert.Equal(t, header.Height-1, manifest.SealedHeight)
+			assert.Equal(t, header.Height, manifest.ExecutedHeight)
+			assert.Equal(t, header.ID(), *manifest.ExecutedBlockID)
+			assert.Equal(t, commit, *manifest.StateCommitment)
+			require.Len(t, manifest.Files, 3)
+
+			verified, err := Verify(backupDir)
+			require.NoError(t, err)
+			assert.Equal(t, manifest.Files, verified.Files)
+
+			unittest.RunWithBadgerDB(t, func(restored *badger.DB) {
+				require.NoError(t, RestoreProtocolDB(backupDir, verified, restored))
+
+				var restoredCommit flow.StateCommitment
+				require.NoError(t, restored.View(operation.LookupStateCommitment(header.ID(), &restoredCommit)))
+				assert.Equal(t, commit, restoredCommit)
+			})
+
+			unittest.RunWithBadgerDB(t, func(restored *badger.DB) {
+				require.NoError(t, RestoreChunkDataPacksDB(backupDir, verified, restored))
+
+				var restoredChunkDataPack badgermodel.StoredChunkDataPack
+				require.NoError(t, restored.View(operation.RetrieveChunkDataPack(chunkDataPack.ChunkID, &restoredChunkDataPack)))
+				assert.Equal(t, chunkDataPack.StartState, restoredChunkDataPack.StartState)
+			})
+
+			trieDir := filepath.Join(dir, "execution")
+			require.NoError(t, RestoreCheckpoint(backupDir, verified, trieDir))
+			restoredCheckpoint, err := os.ReadFile(filepath.Join(trieDir, CheckpointFilename))
+			require.NoError(t, err)
+			assert.Equal(t, checkpoint, restoredCheckpoint)
+
+			// the root checkpoint is never overwritten
+			require.Error(t, RestoreCheckpoint(backupDir, verified, trieDir))
+		})
+	})
+}
+
+func TestBackupWithoutCheckpoint(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		populate(t, db)
+
+		unittest.RunWithTempDir(t, func(dir string) {
+			manifest, err := Create(context.Background(), dir, db, nil, nil, nil)
+			require.NoError(t, err)
+			assert.Nil(t, manifest.ExecutedBlockID)
+			assert.Nil(t, manifest.StateCommitment)
+			require.Len(t, manifest.Files, 1)
+
+			_, err = Verify(dir)
+			require.NoError(t, err)
+
+			// a backup is only taken into an empty directory
+			_, err = Create(context.Background(), dir, db, nil, nil, nil)
+			require.Error(t, err)
+		})
+	})
+}
+
+func TestBackupCanceled(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		populate(t, db)
+
+		unittest.RunWithTempDir(t, func(dir string) {
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			_, err := Create(ctx, dir, db, nil, nil, nil)
+			require.ErrorIs(t, err, context.Canceled)
+
+			// no manifest is written for an aborted backup
+			_, err = os.Stat(filepath.Join(dir, ManifestFilename))
+			require.True(t, os.IsNotExist(err))
+		})
+	})
+}
+
+func TestVerifyCorruptedBackup(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		populate(t, db)
+
+		unittest.RunWithTempDir(t, func(dir string) {
+			_, err := Create(context.Background(), dir, db, nil, nil, nil)
+			require.NoError(t, err)
+
+			f, err := os.OpenFile(filepath.Join(dir, ProtocolDBFilename), os.O_APPEND|os.O_WRONLY, 0600)
+			require.NoError(t, err)
+			_, err = f.Write([]byte{0})
+			require.NoError(t, err)
+			require.NoError(t, f.Close())
+
+			_, err = Verify(dir)
+			require.Error(t, err)
+		})
+	})
+}
+
+func TestVerifyIncompleteBackup(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		_, err := Verify(dir)
+		require.Error(t, err)
+	})
+}
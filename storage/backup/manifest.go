@@ -0,0 +1,153 @@
+package backup
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/onflow/flow-go/model/bootstrap"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+const (
+	// ManifestVersion is the version of the manifest format.
+	ManifestVersion = 1
+
+	// ManifestFilename is the name of the manifest in the backup directory. It
+	// is written last, so a backup without manifest is incomplete.
+	ManifestFilename = "manifest.json"
+
+	// ProtocolDBFilename is the name of the backup of the protocol database,
+	// in the format of badger's DB.Backup.
+	ProtocolDBFilename = "protocol.badger.backup"
+
+	// ChunkDataPacksDBFilename is the name of the backup of the chunk data
+	// packs database of execution nodes, in the format of badger's DB.Backup.
+	ChunkDataPacksDBFilename = "chunk_data_packs.badger.backup"
+)
+
+// CheckpointFilename is the name of the ledger checkpoint of execution nodes,
+// it is restored as the root checkpoint of the execution state.
+var CheckpointFilename = bootstrap.FilenameWALRootCheckpoint
+
+// File is a file of the backup.
+type File struct {
+	Name   string `json:"name"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// Manifest describes a backup: the state of the node it was taken at, and
+// the checksums of its files.
+type Manifest struct {
+	Version         int       `json:"version"`
+	CreatedAt       time.Time `json:"created_at"`
+	FinalizedHeight uint64    `json:"finalized_height"`
+	SealedHeight    uint64    `json:"sealed_height"`
+
+	// the highest executed block and its state commitment, only set on
+	// execution nodes
+	ExecutedBlockID *flow.Identifier      `json:"executed_block_id,omitempty"`
+	ExecutedHeight  uint64                `json:"executed_height,omitempty"`
+	StateCommitment *flow.StateCommitment `json:"state_commitment,omitempty"`
+
+	Files []File `json:"files"`
+}
+
+// File returns the file of the backup with the given name, or nil if the
+// backup doesn't have it.
+func (m *Manifest) File(name string) *File {
+	for i := range m.Files {
+		if m.Files[i].Name == name {
+			return &m.Files[i]
+		}
+	}
+	return nil
+}
+
+// ReadManifest reads the manifest of the backup in the given directory.
+func ReadManifest(dir string) (*Manifest, error) {
+	data, err := os.ReadFile(filepath.Join(dir, ManifestFilename))
+	if err != nil {
+		return nil, fmt.Errorf("could not read manifest: %w", err)
+	}
+	var manifest Manifest
+	err = json.Unmarshal(data, &manifest)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode manifest: %w", err)
+	}
+	if manifest.Version != ManifestVersion {
+		return nil, fmt.Errorf("unsupported manifest version %d, expected %d", manifest.Version, ManifestVersion)
+	}
+	return &manifest, nil
+}
+
+// writeManifest writes the manifest into the given directory, through a
+// temporary file so an interrupted write doesn't leave a partial manifest.
+func writeManifest(dir string, manifest *Manifest) error {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("could not encode manifest: %w", err)
+	}
+	tmp := filepath.Join(dir, ManifestFilename+".tmp")
+	err = os.WriteFile(tmp, data, 0644)
+	if err != nil {
+		return fmt.Errorf("could not write manifest: %w", err)
+	}
+	return os.Rename(tmp, filepath.Join(dir, ManifestFilename))
+}
+
+// Verify reads the manifest of the backup in the given directory, and checks
+// the sizes and checksums of its files.
+func Verify(dir string) (*Manifest, error) {
+	manifest, err := ReadManifest(dir)
+	if err != nil {
+		return nil, err
+	}
+	if manifest.File(ProtocolDBFilename) == nil {
+		return nil, fmt.Errorf("backup has no protocol database")
+	}
+	if (manifest.File(CheckpointFilename) == nil) != (manifest.StateCommitment == nil) {
+		return nil, fmt.Errorf("backup must have both a checkpoint and its state commitment, or none")
+	}
+	if (manifest.File(ChunkDataPacksDBFilename) == nil) != (manifest.StateCommitment == nil) {
+		return nil, fmt.Errorf("backup must have both a checkpoint and the chunk data packs database, or none")
+	}
+
+	for _, expected := range manifest.Files {
+		actual, err := checksum(filepath.Join(dir, expected.Name))
+		if err != nil {
+			return nil, err
+		}
+		if actual.Size != expected.Size || actual.SHA256 != expected.SHA256 {
+			return nil, fmt.Errorf("file %s is corrupted: expected size %d and checksum %s, got size %d and checksum %s",
+				expected.Name, expected.Size, expected.SHA256, actual.Size, actual.SHA256)
+		}
+	}
+	return manifest, nil
+}
+
+// checksum returns the size and checksum of the given file.
+func checksum(path string) (File, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return File{}, fmt.Errorf("could not open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	hasher := sha256.New()
+	size, err := io.Copy(hasher, f)
+	if err != nil {
+		return File{}, fmt.Errorf("could not read %s: %w", path, err)
+	}
+	return File{
+		Name:   filepath.Base(path),
+		Size:   size,
+		SHA256: hex.EncodeToString(hasher.Sum(nil)),
+	}, nil
+}
@@ -0,0 +1,112 @@
+package backup
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage/badger/operation"
+)
+
+// maxPendingWrites is the number of pending writes when loading a backup.
+const maxPendingWrites = 256
+
+// RestoreProtocolDB loads the protocol database backup of a verified backup
+// into the given empty database, and checks the restored markers match the
+// manifest.
+func RestoreProtocolDB(dir string, manifest *Manifest, db *badger.DB) error {
+	f, err := os.Open(filepath.Join(dir, ProtocolDBFilename))
+	if err != nil {
+		return fmt.Errorf("could not open protocol database backup: %w", err)
+	}
+	defer f.Close()
+
+	err = db.Load(f, maxPendingWrites)
+	if err != nil {
+		return fmt.Errorf("could not load protocol database backup: %w", err)
+	}
+
+	var finalized, sealed uint64
+	err = db.View(operation.RetrieveFinalizedHeight(&finalized))
+	if err != nil {
+		return fmt.Errorf("could not retrieve restored finalized height: %w", err)
+	}
+	err = db.View(operation.RetrieveSealedHeight(&sealed))
+	if err != nil {
+		return fmt.Errorf("could not retrieve restored sealed height: %w", err)
+	}
+	if finalized != manifest.FinalizedHeight || sealed != manifest.SealedHeight {
+		return fmt.Errorf("restored finalized and sealed heights (%d, %d) don't match the manifest (%d, %d)",
+			finalized, sealed, manifest.FinalizedHeight, manifest.SealedHeight)
+	}
+
+	if manifest.ExecutedBlockID != nil {
+		var executed flow.Identifier
+		err = db.View(operation.RetrieveExecutedBlock(&executed))
+		if err != nil {
+			return fmt.Errorf("could not retrieve restored executed block: %w", err)
+		}
+		if executed != *manifest.ExecutedBlockID {
+			return fmt.Errorf("restored executed block %v doesn't match the manifest (%v)", executed, *manifest.ExecutedBlockID)
+		}
+	}
+
+	return nil
+}
+
+// RestoreChunkDataPacksDB loads the chunk data packs database backup of a
+// verified backup into the given empty database.
+func RestoreChunkDataPacksDB(dir string, manifest *Manifest, db *badger.DB) error {
+	if manifest.File(ChunkDataPacksDBFilename) == nil {
+		return fmt.Errorf("backup has no chunk data packs database")
+	}
+
+	f, err := os.Open(filepath.Join(dir, ChunkDataPacksDBFilename))
+	if err != nil {
+		return fmt.Errorf("could not open chunk data packs database backup: %w", err)
+	}
+	defer f.Close()
+
+	err = db.Load(f, maxPendingWrites)
+	if err != nil {
+		return fmt.Errorf("could not load chunk data packs database backup: %w", err)
+	}
+	return nil
+}
+
+// RestoreCheckpoint copies the checkpoint of a verified backup as the root
+// checkpoint of the given execution state directory, which must have no
+// checkpoint yet.
+func RestoreCheckpoint(dir string, manifest *Manifest, trieDir string) error {
+	if manifest.File(CheckpointFilename) == nil {
+		return fmt.Errorf("backup has no execution state checkpoint")
+	}
+
+	err := os.MkdirAll(trieDir, 0700)
+	if err != nil {
+		return fmt.Errorf("could not create execution state directory: %w", err)
+	}
+
+	src, err := os.Open(filepath.Join(dir, CheckpointFilename))
+	if err != nil {
+		return fmt.Errorf("could not open checkpoint: %w", err)
+	}
+	defer src.Close()
+
+	target := filepath.Join(trieDir, CheckpointFilename)
+	dst, err := os.OpenFile(target, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("could not create root checkpoint: %w", err)
+	}
+	defer dst.Close()
+
+	_, err = io.Copy(dst, src)
+	if err != nil {
+		return fmt.Errorf("could not copy checkpoint: %w", err)
+	}
+	return dst.Sync()
+}
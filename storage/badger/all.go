@@ -5,6 +5,8 @@ import (
 
 	"github.com/onflow/flow-go/module"
 	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/storage/operation/badgerimpl"
+	"github.com/onflow/flow-go/storage/store"
 )
 
 func InitAll(metrics module.CacheMetrics, db *badger.DB) *storage.All {
@@ -20,11 +22,12 @@ func InitAll(metrics module.CacheMetrics, db *badger.DB) *storage.All {
 	epochCommits := NewEpochCommits(metrics, db)
 	statuses := NewEpochStatuses(metrics, db)
 
-	commits := NewCommits(metrics, db)
+	kvdb := badgerimpl.ToDB(db)
+	commits := store.NewCommits(metrics, kvdb)
 	transactions := NewTransactions(metrics, db)
-	transactionResults := NewTransactionResults(metrics, db, 10000)
+	transactionResults := store.NewTransactionResults(metrics, kvdb, 10000)
 	collections := NewCollections(db, transactions)
-	events := NewEvents(metrics, db)
+	events := store.NewEvents(metrics, kvdb)
 	chunkDataPacks := NewChunkDataPacks(metrics, db, collections, 1000)
 
 	return &storage.All{
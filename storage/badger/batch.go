@@ -2,16 +2,29 @@ package badger
 
 import (
 	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/storage/operation/badgerimpl"
 )
 
+// Batch is a badger write batch. It also implements storage.ReaderBatchWriter,
+// so the stores built on the generic key-value store can write into the same
+// batch as the badger stores.
 type Batch struct {
+	db        *badger.DB
 	writer    *badger.WriteBatch
 	callbacks []func()
+
+	errCallbacks []func(error)
 }
 
+var _ storage.BatchStorage = (*Batch)(nil)
+var _ storage.ReaderBatchWriter = (*Batch)(nil)
+
 func NewBatch(db *badger.DB) *Batch {
 	batch := db.NewWriteBatch()
 	return &Batch{
+		db:        db,
 		writer:    batch,
 		callbacks: make([]func(), 0),
 	}
@@ -21,6 +34,17 @@ func (b *Batch) GetWriter() *badger.WriteBatch {
 	return b.writer
 }
 
+// GlobalReader returns a reader of the committed state of the database, it
+// doesn't see the writes of the batch.
+func (b *Batch) GlobalReader() storage.Reader {
+	return badgerimpl.ToReader(b.db)
+}
+
+// Writer returns the writer adding writes to the batch.
+func (b *Batch) Writer() storage.Writer {
+	return b.writer
+}
+
 // OnSucceed adds a callback to execute after the batch has
 // been successfully flushed.
 // useful for implementing the cache where we will only cache
@@ -29,11 +53,19 @@ func (b *Batch) OnSucceed(callback func()) {
 	b.callbacks = append(b.callbacks, callback)
 }
 
+// AddCallback adds a callback to execute with the result of the flush.
+func (b *Batch) AddCallback(callback func(error)) {
+	b.errCallbacks = append(b.errCallbacks, callback)
+}
+
 // Flush will call the badger Batch's Flush method, in
 // addition, it will call the callbacks added by
-// OnSucceed
+// OnSucceed and AddCallback
 func (b *Batch) Flush() error {
 	err := b.writer.Flush()
+	for _, callback := range b.errCallbacks {
+		callback(err)
+	}
 	if err != nil {
 		return err
 	}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/dgraph-io/badger/v2"
+	"github.com/rs/zerolog"
 
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module"
@@ -125,6 +126,59 @@ func (ch *ChunkDataPacks) retrieveCHDP(chunkID flow.Identifier) func(*badger.Txn
 	}
 }
 
+// MigrateChunkDataPacks moves all chunk data packs from the protocol database to the chunk data
+// packs database, in batches of the given size, and returns the number of chunk data packs moved.
+// Every batch is written to the chunk data packs database before it is removed from the protocol
+// database, so that an interrupted migration loses nothing and can be run again.
+func MigrateChunkDataPacks(log zerolog.Logger, from *badger.DB, to *badger.DB, batchSize int) (int, error) {
+	if batchSize < 1 {
+		return 0, fmt.Errorf("batch size must be positive, got %d", batchSize)
+	}
+
+	moved := 0
+	for {
+		var packs []*badgermodel.StoredChunkDataPack
+		err := from.View(operation.FindChunkDataPacks(batchSize, &packs))
+		if err != nil {
+			return moved, fmt.Errorf("could not find chunk data packs: %w", err)
+		}
+		if len(packs) == 0 {
+			return moved, nil
+		}
+
+		// chunk data packs of a previous, interrupted run may already be stored, so
+		// they are overwritten rather than inserted
+		writeBatch := to.NewWriteBatch()
+		for _, pack := range packs {
+			err := operation.BatchInsertChunkDataPack(pack)(writeBatch)
+			if err != nil {
+				writeBatch.Cancel()
+				return moved, fmt.Errorf("could not store chunk data pack %v: %w", pack.ChunkID, err)
+			}
+		}
+		err = writeBatch.Flush()
+		if err != nil {
+			return moved, fmt.Errorf("could not flush chunk data packs: %w", err)
+		}
+
+		err = operation.RetryOnConflict(from.Update, func(tx *badger.Txn) error {
+			for _, pack := range packs {
+				err := operation.RemoveChunkDataPack(pack.ChunkID)(tx)
+				if err != nil {
+					return fmt.Errorf("could not remove chunk data pack %v: %w", pack.ChunkID, err)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return moved, err
+		}
+
+		moved += len(packs)
+		log.Info().Int("moved", moved).Msg("chunk data packs moved")
+	}
+}
+
 func toStoredChunkDataPack(c *flow.ChunkDataPack) *badgermodel.StoredChunkDataPack {
 	sc := &badgermodel.StoredChunkDataPack{
 		ChunkID:     c.ChunkID,
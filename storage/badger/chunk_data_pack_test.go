@@ -7,11 +7,14 @@ import (
 	"time"
 
 	"github.com/dgraph-io/badger/v2"
+	"github.com/rs/zerolog"
 
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module/metrics"
 	"github.com/onflow/flow-go/storage"
 	badgerstorage "github.com/onflow/flow-go/storage/badger"
+	badgermodel "github.com/onflow/flow-go/storage/badger/model"
+	"github.com/onflow/flow-go/storage/badger/operation"
 	"github.com/onflow/flow-go/utils/unittest"
 
 	"github.com/stretchr/testify/assert"
@@ -79,6 +82,52 @@ func TestChunkDataPacks_MissingItem(t *testing.T) {
 	})
 }
 
+// TestMigrateChunkDataPacks evaluates that chunk data packs are moved from one database to another,
+// and that an interrupted migration can be run again.
+func TestMigrateChunkDataPacks(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(from *badger.DB) {
+		unittest.RunWithBadgerDB(t, func(to *badger.DB) {
+			packs := make([]*badgermodel.StoredChunkDataPack, 0, 25)
+			for i := 0; i < 25; i++ {
+				pack := &badgermodel.StoredChunkDataPack{
+					ChunkID:      unittest.IdentifierFixture(),
+					CollectionID: unittest.IdentifierFixture(),
+				}
+				require.NoError(t, from.Update(operation.InsertChunkDataPack(pack)))
+				packs = append(packs, pack)
+			}
+
+			// a chunk data pack already moved by an interrupted run
+			require.NoError(t, to.Update(operation.InsertChunkDataPack(packs[0])))
+
+			moved, err := badgerstorage.MigrateChunkDataPacks(zerolog.Nop(), from, to, 10)
+			require.NoError(t, err)
+			assert.Equal(t, len(packs), moved)
+
+			for _, pack := range packs {
+				var stored badgermodel.StoredChunkDataPack
+				require.NoError(t, to.View(operation.RetrieveChunkDataPack(pack.ChunkID, &stored)))
+				assert.Equal(t, pack.CollectionID, stored.CollectionID)
+			}
+
+			var remaining []*badgermodel.StoredChunkDataPack
+			require.NoError(t, from.View(operation.FindChunkDataPacks(1, &remaining)))
+			assert.Empty(t, remaining)
+
+			t.Run("migrating again is a no-op", func(t *testing.T) {
+				moved, err := badgerstorage.MigrateChunkDataPacks(zerolog.Nop(), from, to, 10)
+				require.NoError(t, err)
+				assert.Equal(t, 0, moved)
+			})
+
+			t.Run("invalid batch size", func(t *testing.T) {
+				_, err := badgerstorage.MigrateChunkDataPacks(zerolog.Nop(), from, to, 0)
+				assert.Error(t, err)
+			})
+		})
+	})
+}
+
 // WithChunkDataPacks is a test helper that generates specified number of chunk data packs, store them using the storeFunc, and
 // then evaluates whether they are successfully retrieved from storage.
 func WithChunkDataPacks(t *testing.T, chunks int, storeFunc func(*testing.T, []*flow.ChunkDataPack, *badgerstorage.ChunkDataPacks, *badger.DB)) {
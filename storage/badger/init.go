@@ -43,3 +43,39 @@ func InitSecret(opts badger.Options) (*badger.DB, error) {
 
 	return db, nil
 }
+
+// InitChunkDataPacks initializes the chunk data packs database of an execution node by
+// checking and setting the database type marker. If an existing, inconsistent type marker
+// is set, this method will return an error. Once a database type marker has been set using
+// these methods, the type cannot be changed.
+func InitChunkDataPacks(opts badger.Options) (*badger.DB, error) {
+
+	db, err := badger.Open(opts)
+	if err != nil {
+		return nil, fmt.Errorf("could not open db: %w", err)
+	}
+	err = db.Update(operation.InsertChunkDataPacksDBMarker)
+	if err != nil {
+		return nil, fmt.Errorf("could not assert db type: %w", err)
+	}
+
+	return db, nil
+}
+
+// InitSlashingProtection initializes the slashing protection database of a remote signer
+// by checking and setting the database type marker. If an existing, inconsistent type marker
+// is set, this method will return an error. Once a database type marker has been set using
+// these methods, the type cannot be changed.
+func InitSlashingProtection(opts badger.Options) (*badger.DB, error) {
+
+	db, err := badger.Open(opts)
+	if err != nil {
+		return nil, fmt.Errorf("could not open db: %w", err)
+	}
+	err = db.Update(operation.InsertSlashingProtectionDBMarker)
+	if err != nil {
+		return nil, fmt.Errorf("could not assert db type: %w", err)
+	}
+
+	return db, nil
+}
@@ -0,0 +1,97 @@
+package operation
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/vmihailenco/msgpack/v4"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// BackupMarkers are the markers of the protocol state found in a backup.
+type BackupMarkers struct {
+	FinalizedHeight uint64
+	SealedHeight    uint64
+	// ExecutedBlockID is the highest executed block, it is only set on execution nodes.
+	ExecutedBlockID *flow.Identifier
+}
+
+// Backup writes a consistent snapshot of the database to the given writer, in
+// the format of badger's DB.Backup, so it can be restored with DB.Load. It
+// returns the markers of the protocol state in the snapshot, as the database
+// can be updated while the backup is written. The backup is aborted with the
+// error of the context once it is done.
+func Backup(ctx context.Context, db *badger.DB, w io.Writer) (*BackupMarkers, error) {
+	finalizedKey := makePrefix(codeFinalizedHeight)
+	sealedKey := makePrefix(codeSealedHeight)
+	executedKey := makePrefix(codeExecutedBlock)
+
+	var mu sync.Mutex
+	var markers BackupMarkers
+	var markerErr error
+
+	decode := func(item *badger.Item, entity interface{}) {
+		err := item.Value(func(val []byte) error {
+			return msgpack.Unmarshal(val, entity)
+		})
+		if err != nil {
+			markerErr = fmt.Errorf("could not decode marker %x: %w", item.Key(), err)
+		}
+	}
+
+	stream := db.NewStream()
+	stream.LogPrefix = "operation.Backup"
+	// the keys are chosen at the read timestamp of the stream, so the markers
+	// are the ones of the snapshot. It is called concurrently.
+	stream.ChooseKey = func(item *badger.Item) bool {
+		key := item.Key()
+		if len(key) != 1 {
+			// all markers are single byte keys
+			return true
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch {
+		case bytes.Equal(key, finalizedKey):
+			decode(item, &markers.FinalizedHeight)
+		case bytes.Equal(key, sealedKey):
+			decode(item, &markers.SealedHeight)
+		case bytes.Equal(key, executedKey):
+			var blockID flow.Identifier
+			decode(item, &blockID)
+			markers.ExecutedBlockID = &blockID
+		}
+		return true
+	}
+
+	// badger streams backups with a background context, the stream is stopped
+	// by failing its writes instead
+	_, err := stream.Backup(&contextWriter{ctx: ctx, w: w}, 0)
+	if err != nil {
+		return nil, fmt.Errorf("could not stream backup: %w", err)
+	}
+	if markerErr != nil {
+		return nil, markerErr
+	}
+	return &markers, nil
+}
+
+// contextWriter is a writer failing with the error of the context once it is done.
+type contextWriter struct {
+	ctx context.Context
+	w   io.Writer
+}
+
+func (w *contextWriter) Write(p []byte) (int, error) {
+	if err := w.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return w.w.Write(p)
+}
@@ -1,7 +1,10 @@
 package operation
 
 import (
+	"fmt"
+
 	"github.com/dgraph-io/badger/v2"
+	"github.com/vmihailenco/msgpack/v4"
 
 	"github.com/onflow/flow-go/model/flow"
 	badgermodel "github.com/onflow/flow-go/storage/badger/model"
@@ -26,3 +29,28 @@ func RetrieveChunkDataPack(chunkID flow.Identifier, c *badgermodel.StoredChunkDa
 func RemoveChunkDataPack(chunkID flow.Identifier) func(*badger.Txn) error {
 	return remove(makePrefix(codeChunkDataPack, chunkID))
 }
+
+// FindChunkDataPacks retrieves up to the given number of chunk data packs, in the order of their chunk IDs.
+func FindChunkDataPacks(limit int, packs *[]*badgermodel.StoredChunkDataPack) func(*badger.Txn) error {
+	return func(tx *badger.Txn) error {
+		prefix := makePrefix(codeChunkDataPack)
+		opts := badger.DefaultIteratorOptions
+		opts.Prefix = prefix
+
+		it := tx.NewIterator(opts)
+		defer it.Close()
+
+		for it.Seek(prefix); it.ValidForPrefix(prefix) && len(*packs) < limit; it.Next() {
+			var c badgermodel.StoredChunkDataPack
+			err := it.Item().Value(func(val []byte) error {
+				return msgpack.Unmarshal(val, &c)
+			})
+			if err != nil {
+				return fmt.Errorf("could not decode chunk data pack: %w", err)
+			}
+			*packs = append(*packs, &c)
+		}
+
+		return nil
+	}
+}
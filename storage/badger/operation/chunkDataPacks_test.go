@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/onflow/flow-go/model/flow"
 	storagemodel "github.com/onflow/flow-go/storage/badger/model"
 	"github.com/onflow/flow-go/utils/unittest"
 )
@@ -48,3 +49,41 @@ func TestChunkDataPack(t *testing.T) {
 		})
 	})
 }
+
+func TestFindChunkDataPacks(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		var packs []*storagemodel.StoredChunkDataPack
+		err := db.View(FindChunkDataPacks(10, &packs))
+		require.NoError(t, err)
+		assert.Empty(t, packs)
+
+		expected := make(map[flow.Identifier]*storagemodel.StoredChunkDataPack)
+		for i := 0; i < 5; i++ {
+			c := &storagemodel.StoredChunkDataPack{
+				ChunkID:      unittest.IdentifierFixture(),
+				StartState:   unittest.StateCommitmentFixture(),
+				Proof:        []byte{'p'},
+				CollectionID: unittest.IdentifierFixture(),
+			}
+			require.NoError(t, db.Update(InsertChunkDataPack(c)))
+			expected[c.ChunkID] = c
+		}
+
+		t.Run("limit", func(t *testing.T) {
+			var packs []*storagemodel.StoredChunkDataPack
+			err := db.View(FindChunkDataPacks(3, &packs))
+			require.NoError(t, err)
+			assert.Len(t, packs, 3)
+		})
+
+		t.Run("all", func(t *testing.T) {
+			var packs []*storagemodel.StoredChunkDataPack
+			err := db.View(FindChunkDataPacks(10, &packs))
+			require.NoError(t, err)
+			require.Len(t, packs, len(expected))
+			for _, c := range packs {
+				assert.Equal(t, expected[c.ChunkID], c)
+			}
+		})
+	})
+}
@@ -376,6 +376,45 @@ func TestRemove(t *testing.T) {
 	})
 }
 
+func TestRemoveByPrefix(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		e := Entity{ID: 1337}
+		val, _ := msgpack.Marshal(e)
+
+		prefix := []byte{0x01, 0x02}
+		removed := [][]byte{{0x01, 0x02}, {0x01, 0x02, 0x03}, {0x01, 0x02, 0xff}}
+		kept := [][]byte{{0x01}, {0x01, 0x03}, {0x02, 0x02}}
+
+		_ = db.Update(func(tx *badger.Txn) error {
+			for _, key := range append(removed, kept...) {
+				err := tx.Set(key, val)
+				require.NoError(t, err)
+			}
+			return nil
+		})
+
+		err := db.Update(removeByPrefix(prefix))
+		require.NoError(t, err)
+
+		_ = db.View(func(tx *badger.Txn) error {
+			for _, key := range removed {
+				_, err := tx.Get(key)
+				assert.ErrorIs(t, err, badger.ErrKeyNotFound)
+			}
+			for _, key := range kept {
+				_, err := tx.Get(key)
+				assert.NoError(t, err)
+			}
+			return nil
+		})
+
+		t.Run("should not error when there is nothing to remove", func(t *testing.T) {
+			err := db.Update(removeByPrefix(prefix))
+			assert.NoError(t, err)
+		})
+	})
+}
+
 func TestIterateBoundaries(t *testing.T) {
 
 	// create range of keys covering all boundaries around our start/end values
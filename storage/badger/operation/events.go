@@ -86,3 +86,13 @@ func eventFilterIterationFunc(events *[]flow.Event, eventType flow.EventType) fu
 		return check, create, handle
 	}
 }
+
+// RemoveEventsByBlockID removes all events of the given block.
+func RemoveEventsByBlockID(blockID flow.Identifier) func(*badger.Txn) error {
+	return removeByPrefix(makePrefix(codeEvent, blockID))
+}
+
+// RemoveServiceEventsByBlockID removes all service events of the given block.
+func RemoveServiceEventsByBlockID(blockID flow.Identifier) func(*badger.Txn) error {
+	return removeByPrefix(makePrefix(codeServiceEvent, blockID))
+}
@@ -22,6 +22,12 @@ func IndexBlockHeight(height uint64, blockID flow.Identifier) func(*badger.Txn)
 	return insert(makePrefix(codeHeightToBlock, height), blockID)
 }
 
+// ReindexBlockHeight updates the block indexed at the given height, to repair
+// an inconsistent index.
+func ReindexBlockHeight(height uint64, blockID flow.Identifier) func(*badger.Txn) error {
+	return update(makePrefix(codeHeightToBlock, height), blockID)
+}
+
 // LookupBlockHeight retrieves finalized blocks by height.
 func LookupBlockHeight(height uint64, blockID *flow.Identifier) func(*badger.Txn) error {
 	return retrieve(makePrefix(codeHeightToBlock, height), blockID)
@@ -73,6 +79,11 @@ func LookupBlockIDByChunkID(chunkID flow.Identifier, blockID *flow.Identifier) f
 	return retrieve(makePrefix(codeIndexBlockByChunkID, chunkID), blockID)
 }
 
+// RemoveBlockIDByChunkID removes the index of a block by a chunk within that block.
+func RemoveBlockIDByChunkID(chunkID flow.Identifier) func(*badger.Txn) error {
+	return remove(makePrefix(codeIndexBlockByChunkID, chunkID))
+}
+
 // FindHeaders iterates through all headers, calling `filter` on each, and adding
 // them to the `found` slice if `filter` returned true
 func FindHeaders(filter func(header *flow.Header) bool, found *[]flow.Header) func(*badger.Txn) error {
@@ -49,3 +49,15 @@ func UpdateLastCompleteBlockHeight(height uint64) func(*badger.Txn) error {
 func RetrieveLastCompleteBlockHeight(height *uint64) func(*badger.Txn) error {
 	return retrieve(makePrefix(codeLastCompleteBlockHeight), height)
 }
+
+func InsertChunkDataPacksPrunedHeight(height uint64) func(*badger.Txn) error {
+	return insert(makePrefix(codeChunkDataPacksPruned), height)
+}
+
+func UpdateChunkDataPacksPrunedHeight(height uint64) func(*badger.Txn) error {
+	return update(makePrefix(codeChunkDataPacksPruned), height)
+}
+
+func RetrieveChunkDataPacksPrunedHeight(height *uint64) func(*badger.Txn) error {
+	return retrieve(makePrefix(codeChunkDataPacksPruned), height)
+}
@@ -16,6 +16,8 @@ func (marker dbTypeMarker) String() string {
 	return [...]string{
 		"dbMarkerPublic",
 		"dbMarkerSecret",
+		"dbMarkerChunkDataPacks",
+		"dbMarkerSlashingProtection",
 	}[marker]
 }
 
@@ -24,6 +26,10 @@ const (
 	dbMarkerPublic dbTypeMarker = iota
 	// dbMarkerSecret denotes the secrets database
 	dbMarkerSecret
+	// dbMarkerChunkDataPacks denotes the chunk data packs database of execution nodes
+	dbMarkerChunkDataPacks
+	// dbMarkerSlashingProtection denotes the slashing protection database of remote signers
+	dbMarkerSlashingProtection
 )
 
 func InsertPublicDBMarker(txn *badger.Txn) error {
@@ -34,6 +40,14 @@ func InsertSecretDBMarker(txn *badger.Txn) error {
 	return insertDBTypeMarker(dbMarkerSecret)(txn)
 }
 
+func InsertChunkDataPacksDBMarker(txn *badger.Txn) error {
+	return insertDBTypeMarker(dbMarkerChunkDataPacks)(txn)
+}
+
+func InsertSlashingProtectionDBMarker(txn *badger.Txn) error {
+	return insertDBTypeMarker(dbMarkerSlashingProtection)(txn)
+}
+
 func EnsurePublicDB(db *badger.DB) error {
 	return ensureDBWithType(db, dbMarkerPublic)
 }
@@ -42,6 +56,14 @@ func EnsureSecretDB(db *badger.DB) error {
 	return ensureDBWithType(db, dbMarkerSecret)
 }
 
+func EnsureChunkDataPacksDB(db *badger.DB) error {
+	return ensureDBWithType(db, dbMarkerChunkDataPacks)
+}
+
+func EnsureSlashingProtectionDB(db *badger.DB) error {
+	return ensureDBWithType(db, dbMarkerSlashingProtection)
+}
+
 // insertDBTypeMarker inserts a database type marker if none exists. If a marker
 // already exists in the database, this function will return an error if the
 // marker does not match the argument, or return nil if it matches.
@@ -47,6 +47,42 @@ func TestInsertRetrieveDBTypeMarker(t *testing.T) {
 				require.Error(t, err)
 			})
 		})
+
+		t.Run("chunk data packs", func(t *testing.T) {
+			unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+
+				// can insert db marker to empty DB
+				err := db.Update(operation.InsertChunkDataPacksDBMarker)
+				require.NoError(t, err)
+				// can insert db marker twice
+				err = db.Update(operation.InsertChunkDataPacksDBMarker)
+				require.NoError(t, err)
+				// ensure correct db type succeeds
+				err = operation.EnsureChunkDataPacksDB(db)
+				require.NoError(t, err)
+				// ensure other db type fails
+				err = operation.EnsurePublicDB(db)
+				require.Error(t, err)
+			})
+		})
+
+		t.Run("slashing protection", func(t *testing.T) {
+			unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+
+				// can insert db marker to empty DB
+				err := db.Update(operation.InsertSlashingProtectionDBMarker)
+				require.NoError(t, err)
+				// can insert db marker twice
+				err = db.Update(operation.InsertSlashingProtectionDBMarker)
+				require.NoError(t, err)
+				// ensure correct db type succeeds
+				err = operation.EnsureSlashingProtectionDB(db)
+				require.NoError(t, err)
+				// ensure other db type fails
+				err = operation.EnsureSecretDB(db)
+				require.Error(t, err)
+			})
+		})
 	})
 
 	t.Run("should fail to insert different db marker to non-empty db", func(t *testing.T) {
@@ -7,6 +7,7 @@ import (
 	"fmt"
 
 	"github.com/onflow/flow-go/model/flow"
+	kvoperation "github.com/onflow/flow-go/storage/operation"
 )
 
 const (
@@ -31,6 +32,7 @@ const (
 	codeExecutedBlock           = 23 // latest executed block with max height
 	codeRootHeight              = 24 // the height of the first loaded block
 	codeLastCompleteBlockHeight = 25 // the height of the last block for which all collections were received
+	codeChunkDataPacksPruned    = 26 // the height up to which chunk data packs were pruned
 
 	// codes for single entity storage
 	// 31 was used for identities before epochs
@@ -75,14 +77,24 @@ const (
 	codeJobQueue             = 71
 	codeJobQueuePointer      = 72
 
+	// codes related to remote signing
+	codeSignedDigest        = 80 // digest signed by a remote signer key, keyed by key and view
+	codeSignedClusterDigest = 81 // digest of a cluster vote signed by a remote signer, keyed by cluster and view
+
+	// codes related to slashing evidence
+	codeSlashingEvidence      = 85 // evidence of a slashable consensus violation, keyed by ID
+	codeIndexSlashingEvidence = 86 // index mapping view and evidence ID to evidence ID
+
 	// legacy codes (should be cleaned up)
 	codeChunkDataPack                = 100
-	codeCommit                       = 101
-	codeEvent                        = 102
+	codeCommit                       = kvoperation.CodeCommit
+	codeEvent                        = kvoperation.CodeEvent
 	codeExecutionStateInteractions   = 103
-	codeTransactionResult            = 104
+	codeTransactionResult            = kvoperation.CodeTransactionResult
 	codeFinalizedCluster             = 105
-	codeServiceEvent                 = 106
+	codeServiceEvent                 = kvoperation.CodeServiceEvent
+	codeTransactionRegisterSet       = 107
+	codeWarmUpContract               = 108
 	codeIndexCollection              = 200
 	codeIndexExecutionResultByBlock  = 202
 	codeIndexCollectionByTransaction = 203
@@ -120,6 +132,8 @@ func b(v interface{}) []byte {
 		return []byte{byte(i)}
 	case flow.Identifier:
 		return i[:]
+	case flow.Address:
+		return i[:]
 	case flow.ChainID:
 		return []byte(i)
 	default:
@@ -21,6 +21,11 @@ func RetrieveExecutionReceiptMeta(receiptID flow.Identifier, meta *flow.Executio
 	return retrieve(makePrefix(codeExecutionReceiptMeta, receiptID), meta)
 }
 
+// RemoveExecutionReceiptMeta removes an execution receipt meta by receipt ID
+func RemoveExecutionReceiptMeta(receiptID flow.Identifier) func(*badger.Txn) error {
+	return remove(makePrefix(codeExecutionReceiptMeta, receiptID))
+}
+
 // IndexOwnExecutionReceipt inserts an execution receipt ID keyed by block ID
 func IndexOwnExecutionReceipt(blockID flow.Identifier, receiptID flow.Identifier) func(*badger.Txn) error {
 	return insert(makePrefix(codeOwnBlockReceipt, blockID), receiptID)
@@ -36,6 +41,11 @@ func LookupOwnExecutionReceipt(blockID flow.Identifier, receiptID *flow.Identifi
 	return retrieve(makePrefix(codeOwnBlockReceipt, blockID), receiptID)
 }
 
+// RemoveOwnExecutionReceipt removes the index of the own execution receipt by block ID
+func RemoveOwnExecutionReceipt(blockID flow.Identifier) func(*badger.Txn) error {
+	return remove(makePrefix(codeOwnBlockReceipt, blockID))
+}
+
 // IndexExecutionReceipts inserts an execution receipt ID keyed by block ID and receipt ID.
 // one block could have multiple receipts, even if they are from the same executor
 func IndexExecutionReceipts(blockID, receiptID flow.Identifier) func(*badger.Txn) error {
@@ -53,6 +63,11 @@ func LookupExecutionReceipts(blockID flow.Identifier, receiptIDs *[]flow.Identif
 	return traverse(makePrefix(codeAllBlockReceipts, blockID), iterationFunc)
 }
 
+// RemoveExecutionReceiptIndex removes the index of an execution receipt by block ID and receipt ID
+func RemoveExecutionReceiptIndex(blockID, receiptID flow.Identifier) func(*badger.Txn) error {
+	return remove(makePrefix(codeAllBlockReceipts, blockID, receiptID))
+}
+
 // receiptIterationFunc returns an in iteration function which returns all receipt IDs found during traversal
 func receiptIterationFunc(receiptIDs *[]flow.Identifier) func() (checkFunc, createFunc, handleFunc) {
 	check := func(key []byte) bool {
@@ -21,6 +21,11 @@ func RetrieveExecutionResult(resultID flow.Identifier, result *flow.ExecutionRes
 	return retrieve(makePrefix(codeExecutionResult, resultID), result)
 }
 
+// RemoveExecutionResult removes an execution result by ID
+func RemoveExecutionResult(resultID flow.Identifier) func(*badger.Txn) error {
+	return remove(makePrefix(codeExecutionResult, resultID))
+}
+
 // IndexExecutionResult inserts an execution result ID keyed by block ID
 func IndexExecutionResult(blockID flow.Identifier, resultID flow.Identifier) func(*badger.Txn) error {
 	return insert(makePrefix(codeIndexExecutionResultByBlock, blockID), resultID)
@@ -40,3 +45,8 @@ func BatchIndexExecutionResult(blockID flow.Identifier, resultID flow.Identifier
 func LookupExecutionResult(blockID flow.Identifier, resultID *flow.Identifier) func(*badger.Txn) error {
 	return retrieve(makePrefix(codeIndexExecutionResultByBlock, blockID), resultID)
 }
+
+// RemoveExecutionResultIndex removes the index of an execution result by block
+func RemoveExecutionResultIndex(blockID flow.Identifier) func(*badger.Txn) error {
+	return remove(makePrefix(codeIndexExecutionResultByBlock, blockID))
+}
@@ -42,6 +42,12 @@ func IndexBlockSeal(blockID flow.Identifier, sealID flow.Identifier) func(*badge
 	return insert(makePrefix(codeBlockToSeal, blockID), sealID)
 }
 
+// ReindexBlockSeal updates the latest seal indexed for the given block, to repair
+// an inconsistent index.
+func ReindexBlockSeal(blockID flow.Identifier, sealID flow.Identifier) func(*badger.Txn) error {
+	return update(makePrefix(codeBlockToSeal, blockID), sealID)
+}
+
 func LookupBlockSeal(blockID flow.Identifier, sealID *flow.Identifier) func(*badger.Txn) error {
 	return retrieve(makePrefix(codeBlockToSeal, blockID), &sealID)
 }
@@ -0,0 +1,33 @@
+package operation
+
+import (
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// InsertSignedDigest records the digest signed by a remote signer key at a view.
+// The key is identified by its type and, for random beacon keys, its epoch counter.
+// Returns storage.ErrAlreadyExists if a digest was already signed at this view.
+func InsertSignedDigest(keyType uint8, epochCounter uint64, view uint64, digest []byte) func(*badger.Txn) error {
+	return insert(makePrefix(codeSignedDigest, keyType, epochCounter, view), digest)
+}
+
+// RetrieveSignedDigest retrieves the digest signed by a remote signer key at a view.
+func RetrieveSignedDigest(keyType uint8, epochCounter uint64, view uint64, digest *[]byte) func(*badger.Txn) error {
+	return retrieve(makePrefix(codeSignedDigest, keyType, epochCounter, view), digest)
+}
+
+// InsertSignedClusterDigest records the digest of the vote signed by a remote signer at a view
+// of the consensus of a collection cluster. The views of cluster chains restart with every epoch,
+// so the votes are recorded by cluster chain ID.
+// Returns storage.ErrAlreadyExists if a digest was already signed at this view.
+func InsertSignedClusterDigest(clusterID flow.ChainID, view uint64, digest []byte) func(*badger.Txn) error {
+	return insert(makePrefix(codeSignedClusterDigest, clusterID, view), digest)
+}
+
+// RetrieveSignedClusterDigest retrieves the digest of the vote signed by a remote signer at a view
+// of the consensus of a collection cluster.
+func RetrieveSignedClusterDigest(clusterID flow.ChainID, view uint64, digest *[]byte) func(*badger.Txn) error {
+	return retrieve(makePrefix(codeSignedClusterDigest, clusterID, view), digest)
+}
@@ -0,0 +1,61 @@
+package operation
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestInsertRetrieveSignedDigest(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		digest := unittest.RandomBytes(32)
+
+		err := db.Update(InsertSignedDigest(1, 3, 10, digest))
+		require.NoError(t, err)
+
+		var actual []byte
+		err = db.View(RetrieveSignedDigest(1, 3, 10, &actual))
+		require.NoError(t, err)
+		assert.Equal(t, digest, actual)
+
+		// a digest can only be recorded once for a view
+		err = db.Update(InsertSignedDigest(1, 3, 10, unittest.RandomBytes(32)))
+		require.True(t, errors.Is(err, storage.ErrAlreadyExists))
+
+		// other views and keys are independent
+		err = db.View(RetrieveSignedDigest(1, 3, 11, &actual))
+		require.True(t, errors.Is(err, storage.ErrNotFound))
+		err = db.View(RetrieveSignedDigest(0, 3, 10, &actual))
+		require.True(t, errors.Is(err, storage.ErrNotFound))
+	})
+}
+
+func TestInsertRetrieveSignedClusterDigest(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		digest := unittest.RandomBytes(32)
+
+		err := db.Update(InsertSignedClusterDigest("cluster-1", 10, digest))
+		require.NoError(t, err)
+
+		var actual []byte
+		err = db.View(RetrieveSignedClusterDigest("cluster-1", 10, &actual))
+		require.NoError(t, err)
+		assert.Equal(t, digest, actual)
+
+		// a digest can only be recorded once for a view
+		err = db.Update(InsertSignedClusterDigest("cluster-1", 10, unittest.RandomBytes(32)))
+		require.True(t, errors.Is(err, storage.ErrAlreadyExists))
+
+		// other views and clusters are independent
+		err = db.View(RetrieveSignedClusterDigest("cluster-1", 11, &actual))
+		require.True(t, errors.Is(err, storage.ErrNotFound))
+		err = db.View(RetrieveSignedClusterDigest("cluster-2", 10, &actual))
+		require.True(t, errors.Is(err, storage.ErrNotFound))
+	})
+}
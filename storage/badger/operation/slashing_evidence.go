@@ -0,0 +1,31 @@
+package operation
+
+import (
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// InsertSlashingEvidence inserts the evidence of a slashable consensus violation by its ID.
+func InsertSlashingEvidence(evidenceID flow.Identifier, evidence *flow.SlashingEvidence) func(*badger.Txn) error {
+	return insert(makePrefix(codeSlashingEvidence, evidenceID), evidence)
+}
+
+// RetrieveSlashingEvidence retrieves the evidence of a slashable consensus violation by its ID.
+func RetrieveSlashingEvidence(evidenceID flow.Identifier, evidence *flow.SlashingEvidence) func(*badger.Txn) error {
+	return retrieve(makePrefix(codeSlashingEvidence, evidenceID), evidence)
+}
+
+// IndexSlashingEvidence indexes the evidence of a slashable consensus violation by the view
+// of the violation. Multiple evidences can be indexed for the same view.
+func IndexSlashingEvidence(view uint64, evidenceID flow.Identifier) func(*badger.Txn) error {
+	return insert(makePrefix(codeIndexSlashingEvidence, view, evidenceID), evidenceID)
+}
+
+// LookupSlashingEvidenceByViewRange looks up the IDs of the evidences of slashable consensus
+// violations for views in the range [startView, endView], ordered by view.
+func LookupSlashingEvidenceByViewRange(startView uint64, endView uint64, evidenceIDs *[]flow.Identifier) func(*badger.Txn) error {
+	start := makePrefix(codeIndexSlashingEvidence, startView)
+	end := makePrefix(codeIndexSlashingEvidence, endView)
+	return iterate(start, end, lookup(evidenceIDs))
+}
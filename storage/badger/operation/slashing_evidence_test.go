@@ -0,0 +1,51 @@
+package operation
+
+import (
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestSlashingEvidenceInsertRetrieve(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		evidence := unittest.SlashingEvidenceFixture()
+
+		err := db.Update(InsertSlashingEvidence(evidence.ID(), evidence))
+		require.NoError(t, err)
+
+		var retrieved flow.SlashingEvidence
+		err = db.View(RetrieveSlashingEvidence(evidence.ID(), &retrieved))
+		require.NoError(t, err)
+
+		assert.Equal(t, evidence, &retrieved)
+	})
+}
+
+func TestSlashingEvidenceLookupByViewRange(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		ids := make(map[uint64][]flow.Identifier)
+		for view := uint64(10); view < 15; view++ {
+			// index two evidences per view
+			for i := 0; i < 2; i++ {
+				id := unittest.IdentifierFixture()
+				err := db.Update(IndexSlashingEvidence(view, id))
+				require.NoError(t, err)
+				ids[view] = append(ids[view], id)
+			}
+		}
+
+		var actual []flow.Identifier
+		err := db.View(LookupSlashingEvidenceByViewRange(11, 13, &actual))
+		require.NoError(t, err)
+		assert.ElementsMatch(t, append(append(ids[11], ids[12]...), ids[13]...), actual)
+
+		err = db.View(LookupSlashingEvidenceByViewRange(20, 30, &actual))
+		require.NoError(t, err)
+		assert.Empty(t, actual)
+	})
+}
@@ -0,0 +1,45 @@
+package operation
+
+import (
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// register sets are keyed by transaction index, so that traversing the register
+// sets of a block returns them in execution order
+func transactionRegisterSetPrefix(blockID flow.Identifier, registerSet *flow.TransactionRegisterSet) []byte {
+	return makePrefix(codeTransactionRegisterSet, blockID, registerSet.TransactionIndex)
+}
+
+func InsertTransactionRegisterSet(blockID flow.Identifier, registerSet *flow.TransactionRegisterSet) func(*badger.Txn) error {
+	return insert(transactionRegisterSetPrefix(blockID, registerSet), registerSet)
+}
+
+func BatchInsertTransactionRegisterSet(blockID flow.Identifier, registerSet *flow.TransactionRegisterSet) func(batch *badger.WriteBatch) error {
+	return batchInsert(transactionRegisterSetPrefix(blockID, registerSet), registerSet)
+}
+
+func LookupTransactionRegisterSetsByBlockID(blockID flow.Identifier, registerSets *[]flow.TransactionRegisterSet) func(*badger.Txn) error {
+
+	iterationFunc := func() (checkFunc, createFunc, handleFunc) {
+		check := func(_ []byte) bool {
+			return true
+		}
+		var val flow.TransactionRegisterSet
+		create := func() interface{} {
+			return &val
+		}
+		handle := func() error {
+			*registerSets = append(*registerSets, val)
+			return nil
+		}
+		return check, create, handle
+	}
+
+	return traverse(makePrefix(codeTransactionRegisterSet, blockID), iterationFunc)
+}
+
+func RemoveTransactionRegisterSetsByBlockID(blockID flow.Identifier) func(*badger.Txn) error {
+	return removeByPrefix(makePrefix(codeTransactionRegisterSet, blockID))
+}
@@ -39,3 +39,8 @@ func LookupTransactionResultsByBlockID(blockID flow.Identifier, txResults *[]flo
 
 	return traverse(makePrefix(codeTransactionResult, blockID), txErrIterFunc)
 }
+
+// RemoveTransactionResultsByBlockID removes the transaction results of all transactions of the given block.
+func RemoveTransactionResultsByBlockID(blockID flow.Identifier) func(*badger.Txn) error {
+	return removeByPrefix(makePrefix(codeTransactionResult, blockID))
+}
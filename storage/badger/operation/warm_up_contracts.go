@@ -0,0 +1,35 @@
+package operation
+
+import (
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+func InsertWarmUpContract(contract *flow.WarmUpContract) func(*badger.Txn) error {
+	return insert(makePrefix(codeWarmUpContract, contract.Address, contract.Name), contract)
+}
+
+func RemoveWarmUpContract(address flow.Address, name string) func(*badger.Txn) error {
+	return remove(makePrefix(codeWarmUpContract, address, name))
+}
+
+func LookupWarmUpContracts(contracts *[]flow.WarmUpContract) func(*badger.Txn) error {
+
+	iterationFunc := func() (checkFunc, createFunc, handleFunc) {
+		check := func(_ []byte) bool {
+			return true
+		}
+		var val flow.WarmUpContract
+		create := func() interface{} {
+			return &val
+		}
+		handle := func() error {
+			*contracts = append(*contracts, val)
+			return nil
+		}
+		return check, create, handle
+	}
+
+	return traverse(makePrefix(codeWarmUpContract), iterationFunc)
+}
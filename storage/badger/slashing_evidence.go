@@ -0,0 +1,82 @@
+package badger
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/storage/badger/operation"
+)
+
+// SlashingEvidence implements persistent storage for the evidence of slashable
+// consensus violations. Violations are rare, so the evidence is not cached.
+type SlashingEvidence struct {
+	db *badger.DB
+}
+
+var _ storage.SlashingEvidence = (*SlashingEvidence)(nil)
+
+func NewSlashingEvidence(db *badger.DB) *SlashingEvidence {
+	return &SlashingEvidence{
+		db: db,
+	}
+}
+
+func (s *SlashingEvidence) Store(evidence *flow.SlashingEvidence) error {
+	evidenceID := evidence.ID()
+	return operation.RetryOnConflict(s.db.Update, func(tx *badger.Txn) error {
+		err := operation.InsertSlashingEvidence(evidenceID, evidence)(tx)
+		if errors.Is(err, storage.ErrAlreadyExists) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("could not insert slashing evidence: %w", err)
+		}
+		err = operation.IndexSlashingEvidence(evidence.View, evidenceID)(tx)
+		if err != nil {
+			return fmt.Errorf("could not index slashing evidence: %w", err)
+		}
+		return nil
+	})
+}
+
+func (s *SlashingEvidence) ByID(evidenceID flow.Identifier) (*flow.SlashingEvidence, error) {
+	var evidence flow.SlashingEvidence
+	err := s.db.View(operation.RetrieveSlashingEvidence(evidenceID, &evidence))
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve slashing evidence: %w", err)
+	}
+	return &evidence, nil
+}
+
+func (s *SlashingEvidence) ByViewRange(startView uint64, endView uint64) ([]*flow.SlashingEvidence, error) {
+	if startView > endView {
+		return nil, fmt.Errorf("start view (%d) is greater than end view (%d)", startView, endView)
+	}
+
+	var evidences []*flow.SlashingEvidence
+	err := s.db.View(func(tx *badger.Txn) error {
+		var evidenceIDs []flow.Identifier
+		err := operation.LookupSlashingEvidenceByViewRange(startView, endView, &evidenceIDs)(tx)
+		if err != nil {
+			return fmt.Errorf("could not look up slashing evidence: %w", err)
+		}
+		evidences = make([]*flow.SlashingEvidence, 0, len(evidenceIDs))
+		for _, evidenceID := range evidenceIDs {
+			var evidence flow.SlashingEvidence
+			err = operation.RetrieveSlashingEvidence(evidenceID, &evidence)(tx)
+			if err != nil {
+				return fmt.Errorf("could not retrieve slashing evidence %x: %w", evidenceID, err)
+			}
+			evidences = append(evidences, &evidence)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return evidences, nil
+}
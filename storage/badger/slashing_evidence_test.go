@@ -0,0 +1,69 @@
+package badger_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+	badgerstorage "github.com/onflow/flow-go/storage/badger"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestSlashingEvidenceStoreRetrieve(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		store := badgerstorage.NewSlashingEvidence(db)
+		evidence := unittest.SlashingEvidenceFixture()
+
+		err := store.Store(evidence)
+		require.NoError(t, err)
+
+		// storing the same evidence again is a no-op
+		err = store.Store(evidence)
+		require.NoError(t, err)
+
+		actual, err := store.ByID(evidence.ID())
+		require.NoError(t, err)
+		assert.Equal(t, evidence, actual)
+
+		byView, err := store.ByViewRange(evidence.View, evidence.View)
+		require.NoError(t, err)
+		assert.Equal(t, []*flow.SlashingEvidence{evidence}, byView)
+	})
+}
+
+func TestSlashingEvidenceByViewRange(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		store := badgerstorage.NewSlashingEvidence(db)
+
+		evidences := make([]*flow.SlashingEvidence, 0, 5)
+		for view := uint64(100); view < 105; view++ {
+			evidence := unittest.SlashingEvidenceFixture(unittest.WithSlashingEvidenceView(view))
+			require.NoError(t, store.Store(evidence))
+			evidences = append(evidences, evidence)
+		}
+
+		actual, err := store.ByViewRange(101, 103)
+		require.NoError(t, err)
+		assert.Equal(t, evidences[1:4], actual)
+
+		actual, err = store.ByViewRange(0, 99)
+		require.NoError(t, err)
+		assert.Empty(t, actual)
+
+		_, err = store.ByViewRange(103, 101)
+		require.Error(t, err)
+	})
+}
+
+func TestSlashingEvidenceNotFound(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		store := badgerstorage.NewSlashingEvidence(db)
+		_, err := store.ByID(unittest.IdentifierFixture())
+		assert.True(t, errors.Is(err, storage.ErrNotFound))
+	})
+}
@@ -0,0 +1,84 @@
+package badger
+
+import (
+	"fmt"
+
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/storage/badger/operation"
+)
+
+type TransactionRegisterSets struct {
+	db    *badger.DB
+	cache *Cache
+}
+
+func NewTransactionRegisterSets(collector module.CacheMetrics, db *badger.DB, registerSetsCacheSize uint) *TransactionRegisterSets {
+	retrieve := func(key interface{}) func(tx *badger.Txn) (interface{}, error) {
+		blockID := key.(flow.Identifier)
+		var registerSets []flow.TransactionRegisterSet
+		return func(tx *badger.Txn) (interface{}, error) {
+			err := operation.LookupTransactionRegisterSetsByBlockID(blockID, &registerSets)(tx)
+			if err != nil {
+				return nil, handleError(err, flow.TransactionRegisterSet{})
+			}
+			return registerSets, nil
+		}
+	}
+
+	return &TransactionRegisterSets{
+		db: db,
+		cache: newCache(collector, metrics.ResourceTransactionRegisterSets,
+			withLimit(registerSetsCacheSize),
+			withStore(noopStore),
+			withRetrieve(retrieve)),
+	}
+}
+
+// BatchStore will store the register sets of the transactions of the given block in a batch
+func (r *TransactionRegisterSets) BatchStore(blockID flow.Identifier, registerSets []flow.TransactionRegisterSet, batch storage.BatchStorage) error {
+	writeBatch := batch.GetWriter()
+
+	for i := range registerSets {
+		err := operation.BatchInsertTransactionRegisterSet(blockID, &registerSets[i])(writeBatch)
+		if err != nil {
+			return fmt.Errorf("cannot batch insert transaction register set: %w", err)
+		}
+	}
+
+	batch.OnSucceed(func() {
+		r.cache.Insert(blockID, registerSets)
+	})
+	return nil
+}
+
+// ByBlockID returns the register sets of all transactions of the given block, ordered by transaction index.
+// It returns an empty list if no register sets were stored for the block.
+func (r *TransactionRegisterSets) ByBlockID(blockID flow.Identifier) ([]flow.TransactionRegisterSet, error) {
+	tx := r.db.NewTransaction(false)
+	defer tx.Discard()
+	val, err := r.cache.Get(blockID)(tx)
+	if err != nil {
+		return nil, err
+	}
+	return val.([]flow.TransactionRegisterSet), nil
+}
+
+// ByBlockIDTransactionID returns the register sets for the given block ID and transaction ID
+func (r *TransactionRegisterSets) ByBlockIDTransactionID(blockID flow.Identifier, txID flow.Identifier) (*flow.TransactionRegisterSet, error) {
+	registerSets, err := r.ByBlockID(blockID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, registerSet := range registerSets {
+		if registerSet.TransactionID == txID {
+			return &registerSet, nil
+		}
+	}
+	return nil, storage.ErrNotFound
+}
@@ -0,0 +1,79 @@
+package badger_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/storage"
+	bstorage "github.com/onflow/flow-go/storage/badger"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestBatchStoringTransactionRegisterSets(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		metrics := metrics.NewNoopCollector()
+		store := bstorage.NewTransactionRegisterSets(metrics, db, 100)
+
+		blockID := unittest.IdentifierFixture()
+		registerSets := make([]flow.TransactionRegisterSet, 0)
+		for i := 0; i < 300; i++ {
+			registerSets = append(registerSets, flow.TransactionRegisterSet{
+				TransactionID:    unittest.IdentifierFixture(),
+				TransactionIndex: uint32(i),
+				ReadSet: []flow.RegisterID{
+					flow.NewRegisterID("owner", "", fmt.Sprintf("read %d", i)),
+					flow.NewRegisterID("owner", "owner", "shared"),
+				},
+				WriteSet: []flow.RegisterID{
+					flow.NewRegisterID("owner", "", fmt.Sprintf("write %d", i)),
+				},
+			})
+		}
+
+		writeBatch := bstorage.NewBatch(db)
+		err := store.BatchStore(blockID, registerSets, writeBatch)
+		require.NoError(t, err)
+
+		err = writeBatch.Flush()
+		require.NoError(t, err)
+
+		actual, err := store.ByBlockID(blockID)
+		require.NoError(t, err)
+		assert.Equal(t, registerSets, actual)
+
+		// test loading from database, which must preserve the order of transactions
+		newStore := bstorage.NewTransactionRegisterSets(metrics, db, 100)
+		actual, err = newStore.ByBlockID(blockID)
+		require.NoError(t, err)
+		assert.Equal(t, registerSets, actual)
+
+		for _, registerSet := range registerSets {
+			actual, err := newStore.ByBlockIDTransactionID(blockID, registerSet.TransactionID)
+			require.NoError(t, err)
+			assert.Equal(t, registerSet, *actual)
+		}
+	})
+}
+
+func TestReadingNotStoredTransactionRegisterSets(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		metrics := metrics.NewNoopCollector()
+		store := bstorage.NewTransactionRegisterSets(metrics, db, 100)
+
+		blockID := unittest.IdentifierFixture()
+
+		registerSets, err := store.ByBlockID(blockID)
+		require.NoError(t, err)
+		assert.Empty(t, registerSets)
+
+		_, err = store.ByBlockIDTransactionID(blockID, unittest.IdentifierFixture())
+		assert.True(t, errors.Is(err, storage.ErrNotFound))
+	})
+}
@@ -0,0 +1,71 @@
+package badger
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/storage/badger/operation"
+)
+
+// WarmUpContracts is not backed by a cache, as the contracts are only read when
+// an execution node starts.
+type WarmUpContracts struct {
+	db *badger.DB
+}
+
+func NewWarmUpContracts(db *badger.DB) *WarmUpContracts {
+	return &WarmUpContracts{
+		db: db,
+	}
+}
+
+// Update stores the given contracts, replacing the ones stored for the same contracts, and removes
+// the given contracts, in a single transaction. Only the address and name of removed contracts are used.
+func (w *WarmUpContracts) Update(stored []*flow.WarmUpContract, removed []*flow.WarmUpContract) error {
+	err := operation.RetryOnConflict(w.db.Update, func(tx *badger.Txn) error {
+		for _, contract := range removed {
+			err := operation.RemoveWarmUpContract(contract.Address, contract.Name)(tx)
+			if err != nil && !errors.Is(err, storage.ErrNotFound) {
+				return fmt.Errorf("could not remove contract %s.%s: %w", contract.Address, contract.Name, err)
+			}
+		}
+		for _, contract := range stored {
+			err := operation.RemoveWarmUpContract(contract.Address, contract.Name)(tx)
+			if err != nil && !errors.Is(err, storage.ErrNotFound) {
+				return fmt.Errorf("could not remove previous contract %s.%s: %w", contract.Address, contract.Name, err)
+			}
+			err = operation.InsertWarmUpContract(contract)(tx)
+			if err != nil {
+				return fmt.Errorf("could not store contract %s.%s: %w", contract.Address, contract.Name, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("could not update warm-up contracts: %w", err)
+	}
+	return nil
+}
+
+// Remove removes the given contract. It is a no-op if the contract is not stored.
+func (w *WarmUpContracts) Remove(address flow.Address, name string) error {
+	err := operation.RetryOnConflict(w.db.Update, operation.RemoveWarmUpContract(address, name))
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return fmt.Errorf("could not remove contract %s.%s: %w", address, name, err)
+	}
+	return nil
+}
+
+// All returns all stored contracts
+func (w *WarmUpContracts) All() ([]flow.WarmUpContract, error) {
+	var contracts []flow.WarmUpContract
+	err := w.db.View(operation.LookupWarmUpContracts(&contracts))
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve contracts: %w", err)
+	}
+	return contracts, nil
+}
@@ -0,0 +1,51 @@
+package badger_test
+
+import (
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	bstorage "github.com/onflow/flow-go/storage/badger"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestWarmUpContracts(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		store := bstorage.NewWarmUpContracts(db)
+
+		contracts, err := store.All()
+		require.NoError(t, err)
+		assert.Empty(t, contracts)
+
+		address := unittest.AddressFixture()
+		first := flow.WarmUpContract{Address: address, Name: "A", CodeHash: unittest.IdentifierFixture()}
+		second := flow.WarmUpContract{Address: address, Name: "B", CodeHash: unittest.IdentifierFixture()}
+
+		require.NoError(t, store.Update([]*flow.WarmUpContract{&first, &second}, nil))
+
+		contracts, err = store.All()
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []flow.WarmUpContract{first, second}, contracts)
+
+		// storing a contract again replaces the previous one, removing a contract which
+		// is not stored is a no-op
+		updated := flow.WarmUpContract{Address: address, Name: "A", CodeHash: unittest.IdentifierFixture()}
+		third := flow.WarmUpContract{Address: address, Name: "C"}
+		require.NoError(t, store.Update([]*flow.WarmUpContract{&updated}, []*flow.WarmUpContract{&second, &third}))
+
+		contracts, err = store.All()
+		require.NoError(t, err)
+		assert.Equal(t, []flow.WarmUpContract{updated}, contracts)
+
+		require.NoError(t, store.Remove(address, "A"))
+		// removing a contract which is not stored is a no-op
+		require.NoError(t, store.Remove(address, "C"))
+
+		contracts, err = store.All()
+		require.NoError(t, err)
+		assert.Empty(t, contracts)
+	})
+}
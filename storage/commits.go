@@ -11,7 +11,7 @@ type Commits interface {
 	Store(blockID flow.Identifier, commit flow.StateCommitment) error
 
 	// BatchStore will store a commit in a given batch
-	BatchStore(blockID flow.Identifier, commit flow.StateCommitment, batch BatchStorage) error
+	BatchStore(blockID flow.Identifier, commit flow.StateCommitment, batch ReaderBatchWriter) error
 
 	// ByBlockID will retrieve a commit by its ID from persistent storage.
 	ByBlockID(blockID flow.Identifier) (flow.StateCommitment, error)
@@ -15,11 +15,11 @@ type Commits struct {
 }
 
 // BatchStore provides a mock function with given fields: blockID, commit, batch
-func (_m *Commits) BatchStore(blockID flow.Identifier, commit flow.StateCommitment, batch storage.BatchStorage) error {
+func (_m *Commits) BatchStore(blockID flow.Identifier, commit flow.StateCommitment, batch storage.ReaderBatchWriter) error {
 	ret := _m.Called(blockID, commit, batch)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(flow.Identifier, flow.StateCommitment, storage.BatchStorage) error); ok {
+	if rf, ok := ret.Get(0).(func(flow.Identifier, flow.StateCommitment, storage.ReaderBatchWriter) error); ok {
 		r0 = rf(blockID, commit, batch)
 	} else {
 		r0 = ret.Error(0)
@@ -0,0 +1,34 @@
+package badgerimpl
+
+import (
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/storage"
+)
+
+// ToDB returns the given badger database as a generic key-value store.
+func ToDB(db *badger.DB) storage.DB {
+	return &dbStore{db: db}
+}
+
+type dbStore struct {
+	db *badger.DB
+}
+
+var _ storage.DB = (*dbStore)(nil)
+
+func (b *dbStore) Reader() storage.Reader {
+	return ToReader(b.db)
+}
+
+func (b *dbStore) WithReaderBatchWriter(fn func(storage.ReaderBatchWriter) error) error {
+	batch := NewReaderBatchWriter(b.db)
+
+	err := fn(batch)
+	if err != nil {
+		batch.Cancel()
+		return err
+	}
+
+	return batch.Commit()
+}
@@ -0,0 +1,72 @@
+package badgerimpl
+
+import (
+	"bytes"
+
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/storage/operation"
+)
+
+type badgerIterator struct {
+	tx         *badger.Txn
+	iter       *badger.Iterator
+	lowerBound []byte
+	upperBound []byte // exclusive, nil if unbounded
+}
+
+var _ storage.Iterator = (*badgerIterator)(nil)
+
+func newBadgerIterator(db *badger.DB, startPrefix, endPrefix []byte, ops storage.IteratorOption) *badgerIterator {
+	options := badger.DefaultIteratorOptions
+	if ops.IterateKeyOnly {
+		options.PrefetchValues = false
+	}
+
+	tx := db.NewTransaction(false)
+	iter := tx.NewIterator(options)
+
+	return &badgerIterator{
+		tx:         tx,
+		iter:       iter,
+		lowerBound: startPrefix,
+		upperBound: operation.PrefixUpperBound(endPrefix),
+	}
+}
+
+// First seeks to the smallest key of the range, and returns whether it is valid.
+func (i *badgerIterator) First() bool {
+	i.iter.Seek(i.lowerBound)
+	return i.Valid()
+}
+
+// Valid returns whether the iterator is positioned at a key of the range.
+func (i *badgerIterator) Valid() bool {
+	if !i.iter.Valid() {
+		return false
+	}
+	if i.upperBound == nil {
+		return true
+	}
+	return bytes.Compare(i.iter.Item().Key(), i.upperBound) < 0
+}
+
+// Next advances the iterator to the next key.
+func (i *badgerIterator) Next() {
+	i.iter.Next()
+}
+
+// IterItem returns the item the iterator is positioned at.
+func (i *badgerIterator) IterItem() storage.IterItem {
+	return i.iter.Item()
+}
+
+var _ storage.IterItem = (*badger.Item)(nil)
+
+// Close closes the iterator and discards its read transaction.
+func (i *badgerIterator) Close() error {
+	i.iter.Close()
+	i.tx.Discard()
+	return nil
+}
@@ -0,0 +1,60 @@
+package badgerimpl
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/storage"
+)
+
+type dbReader struct {
+	db *badger.DB
+}
+
+var _ storage.Reader = (*dbReader)(nil)
+
+// ToReader returns a reader of the committed state of the given database.
+func ToReader(db *badger.DB) storage.Reader {
+	return &dbReader{db: db}
+}
+
+// noopCloser is returned with the values, which are copied out of their
+// transaction and don't need to be released.
+type noopCloser struct{}
+
+func (noopCloser) Close() error { return nil }
+
+// Get returns the value stored under the given key, or storage.ErrNotFound if
+// the key doesn't exist.
+func (b *dbReader) Get(key []byte) ([]byte, io.Closer, error) {
+	tx := b.db.NewTransaction(false)
+	defer tx.Discard()
+
+	item, err := tx.Get(key)
+	if errors.Is(err, badger.ErrKeyNotFound) {
+		return nil, nil, storage.ErrNotFound
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not load data: %w", err)
+	}
+
+	value, err := item.ValueCopy(nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not load value: %w", err)
+	}
+
+	return value, noopCloser{}, nil
+}
+
+// NewIter returns an iterator over all keys with a prefix between the given
+// start and end prefixes, both included.
+func (b *dbReader) NewIter(startPrefix, endPrefix []byte, ops storage.IteratorOption) (storage.Iterator, error) {
+	if bytes.Compare(startPrefix, endPrefix) > 0 {
+		return nil, fmt.Errorf("start prefix %x is bigger than end prefix %x", startPrefix, endPrefix)
+	}
+	return newBadgerIterator(b.db, startPrefix, endPrefix, ops), nil
+}
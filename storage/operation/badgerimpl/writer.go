@@ -0,0 +1,71 @@
+package badgerimpl
+
+import (
+	"sync"
+
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/storage"
+)
+
+// ReaderBatchWriter is a batch of writes committed atomically with a badger
+// write batch, reading the committed state of the database.
+type ReaderBatchWriter struct {
+	globalReader storage.Reader
+	batch        *badger.WriteBatch
+
+	mu        sync.Mutex
+	callbacks []func(error)
+}
+
+var _ storage.ReaderBatchWriter = (*ReaderBatchWriter)(nil)
+
+// NewReaderBatchWriter returns a new batch of writes to the given database.
+func NewReaderBatchWriter(db *badger.DB) *ReaderBatchWriter {
+	return &ReaderBatchWriter{
+		globalReader: ToReader(db),
+		batch:        db.NewWriteBatch(),
+	}
+}
+
+// GlobalReader returns a reader of the committed state of the database, it
+// doesn't see the writes of the batch.
+func (b *ReaderBatchWriter) GlobalReader() storage.Reader {
+	return b.globalReader
+}
+
+// Writer returns the writer adding writes to the batch.
+func (b *ReaderBatchWriter) Writer() storage.Writer {
+	return b.batch
+}
+
+var _ storage.Writer = (*badger.WriteBatch)(nil)
+
+// AddCallback adds a function called with the error of the commit once the
+// batch is committed.
+func (b *ReaderBatchWriter) AddCallback(callback func(error)) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.callbacks = append(b.callbacks, callback)
+}
+
+// Commit flushes the batch to the database, and calls the callbacks with the
+// result.
+func (b *ReaderBatchWriter) Commit() error {
+	err := b.batch.Flush()
+	b.notifyCallbacks(err)
+	return err
+}
+
+// Cancel discards the writes of the batch, the callbacks aren't called.
+func (b *ReaderBatchWriter) Cancel() {
+	b.batch.Cancel()
+}
+
+func (b *ReaderBatchWriter) notifyCallbacks(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, callback := range b.callbacks {
+		callback(err)
+	}
+}
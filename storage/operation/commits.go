@@ -0,0 +1,25 @@
+package operation
+
+import (
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+)
+
+// IndexStateCommitment indexes a state commitment.
+//
+// State commitments are keyed by the block whose execution results in the state with the given commit.
+func IndexStateCommitment(blockID flow.Identifier, commit flow.StateCommitment) func(storage.Writer) error {
+	return UpsertByKey(makePrefix(CodeCommit, blockID), commit)
+}
+
+// LookupStateCommitment gets a state commitment keyed by block ID
+//
+// State commitments are keyed by the block whose execution results in the state with the given commit.
+func LookupStateCommitment(blockID flow.Identifier, commit *flow.StateCommitment) func(storage.Reader) error {
+	return RetrieveByKey(makePrefix(CodeCommit, blockID), commit)
+}
+
+// RemoveStateCommitment removes the state commitment by block ID
+func RemoveStateCommitment(blockID flow.Identifier) func(storage.Writer) error {
+	return RemoveByKey(makePrefix(CodeCommit, blockID))
+}
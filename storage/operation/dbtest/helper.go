@@ -0,0 +1,35 @@
+package dbtest
+
+import (
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/storage/operation/badgerimpl"
+	"github.com/onflow/flow-go/storage/operation/pebbleimpl"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// RunWithDB runs the given test against an empty database of each storage
+// backend, as a sub-test named after the backend.
+func RunWithDB(t *testing.T, f func(t *testing.T, db storage.DB)) {
+	t.Run("badger", func(t *testing.T) {
+		unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+			f(t, badgerimpl.ToDB(db))
+		})
+	})
+
+	t.Run("pebble", func(t *testing.T) {
+		unittest.RunWithTempDir(t, func(dir string) {
+			db, err := pebbleimpl.Open(dir)
+			require.NoError(t, err)
+			defer func() {
+				assert.NoError(t, db.Close())
+			}()
+			f(t, pebbleimpl.ToDB(db))
+		})
+	})
+}
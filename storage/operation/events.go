@@ -0,0 +1,87 @@
+package operation
+
+import (
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+)
+
+func eventPrefix(prefix byte, blockID flow.Identifier, event flow.Event) []byte {
+	return makePrefix(prefix, blockID, event.TransactionID, event.TransactionIndex, event.EventIndex)
+}
+
+func InsertEvent(blockID flow.Identifier, event flow.Event) func(storage.Writer) error {
+	return UpsertByKey(eventPrefix(CodeEvent, blockID, event), event)
+}
+
+func InsertServiceEvent(blockID flow.Identifier, event flow.Event) func(storage.Writer) error {
+	return UpsertByKey(eventPrefix(CodeServiceEvent, blockID, event), event)
+}
+
+func RetrieveEvents(blockID flow.Identifier, transactionID flow.Identifier, events *[]flow.Event) func(storage.Reader) error {
+	iterationFunc := eventIterationFunc(events)
+	return Traverse(makePrefix(CodeEvent, blockID, transactionID), iterationFunc)
+}
+
+func LookupEventsByBlockID(blockID flow.Identifier, events *[]flow.Event) func(storage.Reader) error {
+	iterationFunc := eventIterationFunc(events)
+	return Traverse(makePrefix(CodeEvent, blockID), iterationFunc)
+}
+
+func LookupServiceEventsByBlockID(blockID flow.Identifier, events *[]flow.Event) func(storage.Reader) error {
+	iterationFunc := eventIterationFunc(events)
+	return Traverse(makePrefix(CodeServiceEvent, blockID), iterationFunc)
+}
+
+func LookupEventsByBlockIDEventType(blockID flow.Identifier, eventType flow.EventType, events *[]flow.Event) func(storage.Reader) error {
+	iterationFunc := eventFilterIterationFunc(events, eventType)
+	return Traverse(makePrefix(CodeEvent, blockID), iterationFunc)
+}
+
+// RemoveEventsByBlockID removes the events of the given block.
+func RemoveEventsByBlockID(blockID flow.Identifier) func(storage.ReaderBatchWriter) error {
+	return RemoveByPrefix(makePrefix(CodeEvent, blockID))
+}
+
+// RemoveServiceEventsByBlockID removes the service events of the given block.
+func RemoveServiceEventsByBlockID(blockID flow.Identifier) func(storage.ReaderBatchWriter) error {
+	return RemoveByPrefix(makePrefix(CodeServiceEvent, blockID))
+}
+
+// eventIterationFunc returns an in iteration function which returns all events found during traversal or iteration
+func eventIterationFunc(events *[]flow.Event) IterationFunc {
+	return func() (CheckFunc, CreateFunc, HandleFunc) {
+		check := func(key []byte) bool {
+			return true
+		}
+		var val flow.Event
+		create := func() interface{} {
+			return &val
+		}
+		handle := func() error {
+			*events = append(*events, val)
+			return nil
+		}
+		return check, create, handle
+	}
+}
+
+// eventFilterIterationFunc returns an iteration function which filters the result by the given event type in the handleFunc
+func eventFilterIterationFunc(events *[]flow.Event, eventType flow.EventType) IterationFunc {
+	return func() (CheckFunc, CreateFunc, HandleFunc) {
+		check := func(key []byte) bool {
+			return true
+		}
+		var val flow.Event
+		create := func() interface{} {
+			return &val
+		}
+		handle := func() error {
+			// filter out all events not of type eventType
+			if val.Type == eventType {
+				*events = append(*events, val)
+			}
+			return nil
+		}
+		return check, create, handle
+	}
+}
@@ -0,0 +1,46 @@
+package pebbleimpl
+
+import (
+	"fmt"
+
+	"github.com/cockroachdb/pebble"
+
+	"github.com/onflow/flow-go/storage"
+)
+
+// Open opens the pebble database in the given directory, creating it if it
+// doesn't exist yet.
+func Open(dir string) (*pebble.DB, error) {
+	db, err := pebble.Open(dir, &pebble.Options{})
+	if err != nil {
+		return nil, fmt.Errorf("could not open pebble database at %v: %w", dir, err)
+	}
+	return db, nil
+}
+
+// ToDB returns the given pebble database as a generic key-value store.
+func ToDB(db *pebble.DB) storage.DB {
+	return &dbStore{db: db}
+}
+
+type dbStore struct {
+	db *pebble.DB
+}
+
+var _ storage.DB = (*dbStore)(nil)
+
+func (b *dbStore) Reader() storage.Reader {
+	return ToReader(b.db)
+}
+
+func (b *dbStore) WithReaderBatchWriter(fn func(storage.ReaderBatchWriter) error) error {
+	batch := NewReaderBatchWriter(b.db)
+
+	err := fn(batch)
+	if err != nil {
+		batch.Cancel()
+		return err
+	}
+
+	return batch.Commit()
+}
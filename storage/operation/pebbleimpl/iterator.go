@@ -0,0 +1,69 @@
+package pebbleimpl
+
+import (
+	"github.com/cockroachdb/pebble"
+
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/storage/operation"
+)
+
+type pebbleIterator struct {
+	iter *pebble.Iterator
+}
+
+var _ storage.Iterator = (*pebbleIterator)(nil)
+
+// newPebbleIterator returns an iterator bounded to the given prefixes. Pebble
+// always loads the values with the keys, so the key-only option has no effect.
+func newPebbleIterator(db *pebble.DB, startPrefix, endPrefix []byte, _ storage.IteratorOption) *pebbleIterator {
+	iter := db.NewIter(&pebble.IterOptions{
+		LowerBound: startPrefix,
+		UpperBound: operation.PrefixUpperBound(endPrefix),
+	})
+	return &pebbleIterator{iter: iter}
+}
+
+// First seeks to the smallest key of the range, and returns whether it is valid.
+func (i *pebbleIterator) First() bool {
+	return i.iter.First()
+}
+
+// Valid returns whether the iterator is positioned at a key of the range.
+func (i *pebbleIterator) Valid() bool {
+	return i.iter.Valid()
+}
+
+// Next advances the iterator to the next key.
+func (i *pebbleIterator) Next() {
+	i.iter.Next()
+}
+
+// IterItem returns the item the iterator is positioned at.
+func (i *pebbleIterator) IterItem() storage.IterItem {
+	return pebbleIterItem{iter: i.iter}
+}
+
+// Close closes the iterator.
+func (i *pebbleIterator) Close() error {
+	return i.iter.Close()
+}
+
+// pebbleIterItem is the item the wrapped iterator is positioned at, it is only
+// valid until the iterator is moved.
+type pebbleIterItem struct {
+	iter *pebble.Iterator
+}
+
+var _ storage.IterItem = pebbleIterItem{}
+
+func (i pebbleIterItem) Key() []byte {
+	return i.iter.Key()
+}
+
+func (i pebbleIterItem) KeyCopy(dst []byte) []byte {
+	return append(dst[:0], i.iter.Key()...)
+}
+
+func (i pebbleIterItem) Value(fn func(val []byte) error) error {
+	return fn(i.iter.Value())
+}
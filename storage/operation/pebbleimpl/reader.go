@@ -0,0 +1,45 @@
+package pebbleimpl
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/cockroachdb/pebble"
+
+	"github.com/onflow/flow-go/storage"
+)
+
+type dbReader struct {
+	db *pebble.DB
+}
+
+var _ storage.Reader = (*dbReader)(nil)
+
+// ToReader returns a reader of the committed state of the given database.
+func ToReader(db *pebble.DB) storage.Reader {
+	return &dbReader{db: db}
+}
+
+// Get returns the value stored under the given key, or storage.ErrNotFound if
+// the key doesn't exist. The value is only valid until the closer is closed.
+func (b *dbReader) Get(key []byte) ([]byte, io.Closer, error) {
+	value, closer, err := b.db.Get(key)
+	if errors.Is(err, pebble.ErrNotFound) {
+		return nil, nil, storage.ErrNotFound
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not load data: %w", err)
+	}
+	return value, closer, nil
+}
+
+// NewIter returns an iterator over all keys with a prefix between the given
+// start and end prefixes, both included.
+func (b *dbReader) NewIter(startPrefix, endPrefix []byte, ops storage.IteratorOption) (storage.Iterator, error) {
+	if bytes.Compare(startPrefix, endPrefix) > 0 {
+		return nil, fmt.Errorf("start prefix %x is bigger than end prefix %x", startPrefix, endPrefix)
+	}
+	return newPebbleIterator(b.db, startPrefix, endPrefix, ops), nil
+}
@@ -0,0 +1,83 @@
+package pebbleimpl
+
+import (
+	"sync"
+
+	"github.com/cockroachdb/pebble"
+
+	"github.com/onflow/flow-go/storage"
+)
+
+// ReaderBatchWriter is a batch of writes committed atomically with a pebble
+// batch, reading the committed state of the database.
+type ReaderBatchWriter struct {
+	globalReader storage.Reader
+	batch        *pebble.Batch
+
+	mu        sync.Mutex
+	callbacks []func(error)
+}
+
+var _ storage.ReaderBatchWriter = (*ReaderBatchWriter)(nil)
+
+// NewReaderBatchWriter returns a new batch of writes to the given database.
+func NewReaderBatchWriter(db *pebble.DB) *ReaderBatchWriter {
+	return &ReaderBatchWriter{
+		globalReader: ToReader(db),
+		batch:        db.NewBatch(),
+	}
+}
+
+// GlobalReader returns a reader of the committed state of the database, it
+// doesn't see the writes of the batch.
+func (b *ReaderBatchWriter) GlobalReader() storage.Reader {
+	return b.globalReader
+}
+
+// Writer returns the writer adding writes to the batch.
+func (b *ReaderBatchWriter) Writer() storage.Writer {
+	return b
+}
+
+// Set adds setting the given key to the batch.
+func (b *ReaderBatchWriter) Set(key, value []byte) error {
+	return b.batch.Set(key, value, pebble.Sync)
+}
+
+// Delete adds deleting the given key to the batch.
+func (b *ReaderBatchWriter) Delete(key []byte) error {
+	return b.batch.Delete(key, pebble.Sync)
+}
+
+// AddCallback adds a function called with the error of the commit once the
+// batch is committed.
+func (b *ReaderBatchWriter) AddCallback(callback func(error)) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.callbacks = append(b.callbacks, callback)
+}
+
+// Commit writes the batch to the database, and calls the callbacks with the
+// result. The batch can't be used afterwards.
+func (b *ReaderBatchWriter) Commit() error {
+	err := b.batch.Commit(pebble.Sync)
+	b.notifyCallbacks(err)
+	closeErr := b.batch.Close()
+	if err != nil {
+		return err
+	}
+	return closeErr
+}
+
+// Cancel discards the writes of the batch, the callbacks aren't called.
+func (b *ReaderBatchWriter) Cancel() {
+	_ = b.batch.Close()
+}
+
+func (b *ReaderBatchWriter) notifyCallbacks(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, callback := range b.callbacks {
+		callback(err)
+	}
+}
@@ -0,0 +1,70 @@
+package operation
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// The codes and the key encoding are shared with storage/badger/operation, which
+// uses the codes defined here, so the operations of both packages read and write
+// the same keys.
+const (
+	CodeCommit            = 101
+	CodeEvent             = 102
+	CodeTransactionResult = 104
+	CodeServiceEvent      = 106
+)
+
+func makePrefix(code byte, keys ...interface{}) []byte {
+	prefix := make([]byte, 1)
+	prefix[0] = code
+	for _, key := range keys {
+		prefix = append(prefix, b(key)...)
+	}
+	return prefix
+}
+
+func b(v interface{}) []byte {
+	switch i := v.(type) {
+	case uint8:
+		return []byte{i}
+	case uint32:
+		b := make([]byte, 4)
+		binary.BigEndian.PutUint32(b, i)
+		return b
+	case uint64:
+		b := make([]byte, 8)
+		binary.BigEndian.PutUint64(b, i)
+		return b
+	case string:
+		return []byte(i)
+	case flow.Role:
+		return []byte{byte(i)}
+	case flow.Identifier:
+		return i[:]
+	case flow.Address:
+		return i[:]
+	case flow.ChainID:
+		return []byte(i)
+	default:
+		panic(fmt.Sprintf("unsupported type to convert (%T)", v))
+	}
+}
+
+// PrefixUpperBound returns the smallest key bigger than all keys with the given
+// prefix, or nil if there is no such key, i.e. the prefix consists of 0xff bytes
+// only. It is the exclusive upper bound of an iteration over the prefix.
+func PrefixUpperBound(prefix []byte) []byte {
+	end := make([]byte, len(prefix))
+	copy(end, prefix)
+	for i := len(end) - 1; i >= 0; i-- {
+		// increment the last byte which isn't 0xff, and drop the bytes after it
+		end[i] = end[i] + 1
+		if end[i] != 0 {
+			return end[:i+1]
+		}
+	}
+	return nil
+}
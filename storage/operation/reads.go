@@ -0,0 +1,137 @@
+package operation
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/vmihailenco/msgpack/v4"
+
+	"github.com/onflow/flow-go/storage"
+)
+
+// CheckFunc is called during key iteration in order to check whether we should
+// process the given key-value pair. It can be used to avoid loading the value
+// if its not of interest, as well as storing the key for the current iteration
+// step.
+type CheckFunc func(key []byte) bool
+
+// CreateFunc returns a pointer to an initialized entity that we can potentially
+// decode the next value into during an iteration.
+type CreateFunc func() interface{}
+
+// HandleFunc is a function that starts the processing of the current key-value
+// pair during an iteration. It should be called after the key was checked and
+// the entity was decoded.
+type HandleFunc func() error
+
+// IterationFunc is a function provided to the iteration functions, called for
+// each iteration step to initialize the functions to check the key, to create
+// the decode target and to process the current key-value pair.
+type IterationFunc func() (CheckFunc, CreateFunc, HandleFunc)
+
+// IterateKeys iterates over the key-value pairs with a key prefix between the
+// given start and end prefixes, both included, in ascending key order.
+//
+// On each iteration, it will call the iteration function to initialize
+// functions specific to processing the given key-value pair. If the key-only
+// option is set, the values are neither loaded nor decoded, and only the check
+// function is called.
+func IterateKeys(r storage.Reader, startPrefix []byte, endPrefix []byte, iterFunc IterationFunc, opt storage.IteratorOption) error {
+	if len(startPrefix) == 0 {
+		return fmt.Errorf("startPrefix prefix is empty")
+	}
+	if len(endPrefix) == 0 {
+		return fmt.Errorf("endPrefix prefix is empty")
+	}
+	if bytes.Compare(startPrefix, endPrefix) > 0 {
+		return fmt.Errorf("startPrefix key must be less than or equal to endPrefix key")
+	}
+
+	it, err := r.NewIter(startPrefix, endPrefix, opt)
+	if err != nil {
+		return fmt.Errorf("can not create iterator: %w", err)
+	}
+	defer it.Close()
+
+	for it.First(); it.Valid(); it.Next() {
+		item := it.IterItem()
+		key := item.Key()
+
+		// initialize processing functions for iteration
+		check, create, handle := iterFunc()
+
+		// check if we should process the item at all
+		ok := check(key)
+		if !ok || opt.IterateKeyOnly {
+			continue
+		}
+
+		// process the actual item
+		err := item.Value(func(val []byte) error {
+
+			// decode into the entity
+			entity := create()
+			err := msgpack.Unmarshal(val, entity)
+			if err != nil {
+				return fmt.Errorf("could not decode entity: %w", err)
+			}
+
+			// process the entity
+			err = handle()
+			if err != nil {
+				return fmt.Errorf("could not handle entity: %w", err)
+			}
+
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("could not process value: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Traverse iterates over all key-value pairs with the given prefix.
+func Traverse(prefix []byte, iterFunc IterationFunc) func(storage.Reader) error {
+	return func(r storage.Reader) error {
+		return IterateKeys(r, prefix, prefix, iterFunc, storage.DefaultIteratorOptions())
+	}
+}
+
+// KeyExists returns whether the given key exists.
+func KeyExists(r storage.Reader, key []byte) (bool, error) {
+	_, closer, err := r.Get(key)
+	if errors.Is(err, storage.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("could not check key: %w", err)
+	}
+	defer closer.Close()
+	return true, nil
+}
+
+// RetrieveByKey will retrieve the binary data under the given key and decode
+// it into the given entity. The provided entity needs to be a pointer to an
+// initialized entity of the correct type.
+// Error returns:
+//   * storage.ErrNotFound if the key does not exist
+//   * generic error in case of unexpected failure from the database layer, or failure
+//     to decode an existing database value
+func RetrieveByKey(key []byte, entity interface{}) func(storage.Reader) error {
+	return func(r storage.Reader) error {
+		val, closer, err := r.Get(key)
+		if err != nil {
+			return err
+		}
+		defer closer.Close()
+
+		err = msgpack.Unmarshal(val, entity)
+		if err != nil {
+			return fmt.Errorf("could not decode entity: %w", err)
+		}
+		return nil
+	}
+}
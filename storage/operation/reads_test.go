@@ -0,0 +1,131 @@
+package operation_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/storage/operation"
+	"github.com/onflow/flow-go/storage/operation/dbtest"
+)
+
+type entity struct {
+	ID uint64
+}
+
+func TestRetrieveByKey(t *testing.T) {
+	dbtest.RunWithDB(t, func(t *testing.T, db storage.DB) {
+		key := []byte{0x01, 0x02}
+
+		var actual entity
+		err := operation.RetrieveByKey(key, &actual)(db.Reader())
+		assert.True(t, errors.Is(err, storage.ErrNotFound))
+
+		expected := entity{ID: 1337}
+		err = db.WithReaderBatchWriter(storage.OnlyWriter(operation.UpsertByKey(key, expected)))
+		require.NoError(t, err)
+
+		err = operation.RetrieveByKey(key, &actual)(db.Reader())
+		require.NoError(t, err)
+		assert.Equal(t, expected, actual)
+
+		exists, err := operation.KeyExists(db.Reader(), key)
+		require.NoError(t, err)
+		assert.True(t, exists)
+
+		err = db.WithReaderBatchWriter(storage.OnlyWriter(operation.RemoveByKey(key)))
+		require.NoError(t, err)
+
+		exists, err = operation.KeyExists(db.Reader(), key)
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+}
+
+// TestIterateKeys checks that the iteration includes all keys with a prefix
+// between the start and end prefixes, and no other key.
+func TestIterateKeys(t *testing.T) {
+	dbtest.RunWithDB(t, func(t *testing.T, db storage.DB) {
+		keys := [][]byte{
+			{0x09, 0xff},
+			{0x10},
+			{0x10, 0x00},
+			{0x10, 0xff},
+			{0x11, 0x00, 0x01},
+			{0x19},
+			{0x19, 0xff, 0xff},
+			{0x20},
+		}
+		err := db.WithReaderBatchWriter(func(rw storage.ReaderBatchWriter) error {
+			for i, key := range keys {
+				err := operation.UpsertByKey(key, entity{ID: uint64(i)})(rw.Writer())
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		require.NoError(t, err)
+
+		var found []entity
+		var foundKeys [][]byte
+		err = operation.IterateKeys(db.Reader(), []byte{0x10}, []byte{0x19}, func() (operation.CheckFunc, operation.CreateFunc, operation.HandleFunc) {
+			var e entity
+			return func(key []byte) bool {
+					foundKeys = append(foundKeys, append([]byte(nil), key...))
+					return true
+				}, func() interface{} {
+					return &e
+				}, func() error {
+					found = append(found, e)
+					return nil
+				}
+		}, storage.DefaultIteratorOptions())
+		require.NoError(t, err)
+
+		assert.Equal(t, keys[1:7], foundKeys)
+		assert.Equal(t, []entity{{ID: 1}, {ID: 2}, {ID: 3}, {ID: 4}, {ID: 5}, {ID: 6}}, found)
+
+		// the start prefix can't be bigger than the end prefix
+		err = operation.IterateKeys(db.Reader(), []byte{0x19}, []byte{0x10}, nil, storage.DefaultIteratorOptions())
+		assert.Error(t, err)
+	})
+}
+
+func TestRemoveByPrefix(t *testing.T) {
+	dbtest.RunWithDB(t, func(t *testing.T, db storage.DB) {
+		keys := [][]byte{{0x10}, {0x10, 0x01}, {0x10, 0xff, 0x01}, {0x11}}
+		err := db.WithReaderBatchWriter(func(rw storage.ReaderBatchWriter) error {
+			for _, key := range keys {
+				err := operation.UpsertByKey(key, entity{})(rw.Writer())
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		require.NoError(t, err)
+
+		err = db.WithReaderBatchWriter(operation.RemoveByPrefix([]byte{0x10}))
+		require.NoError(t, err)
+
+		for _, key := range keys[:3] {
+			exists, err := operation.KeyExists(db.Reader(), key)
+			require.NoError(t, err)
+			assert.False(t, exists)
+		}
+		exists, err := operation.KeyExists(db.Reader(), keys[3])
+		require.NoError(t, err)
+		assert.True(t, exists)
+	})
+}
+
+func TestPrefixUpperBound(t *testing.T) {
+	assert.Equal(t, []byte{0x11}, operation.PrefixUpperBound([]byte{0x10}))
+	assert.Equal(t, []byte{0x11}, operation.PrefixUpperBound([]byte{0x10, 0xff}))
+	assert.Equal(t, []byte{0x10, 0x02}, operation.PrefixUpperBound([]byte{0x10, 0x01}))
+	assert.Nil(t, operation.PrefixUpperBound([]byte{0xff, 0xff}))
+}
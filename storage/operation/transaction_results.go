@@ -0,0 +1,39 @@
+package operation
+
+import (
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+)
+
+func InsertTransactionResult(blockID flow.Identifier, transactionResult *flow.TransactionResult) func(storage.Writer) error {
+	return UpsertByKey(makePrefix(CodeTransactionResult, blockID, transactionResult.TransactionID), transactionResult)
+}
+
+func RetrieveTransactionResult(blockID flow.Identifier, transactionID flow.Identifier, transactionResult *flow.TransactionResult) func(storage.Reader) error {
+	return RetrieveByKey(makePrefix(CodeTransactionResult, blockID, transactionID), transactionResult)
+}
+
+func LookupTransactionResultsByBlockID(blockID flow.Identifier, txResults *[]flow.TransactionResult) func(storage.Reader) error {
+
+	txErrIterFunc := func() (CheckFunc, CreateFunc, HandleFunc) {
+		check := func(_ []byte) bool {
+			return true
+		}
+		var val flow.TransactionResult
+		create := func() interface{} {
+			return &val
+		}
+		handle := func() error {
+			*txResults = append(*txResults, val)
+			return nil
+		}
+		return check, create, handle
+	}
+
+	return Traverse(makePrefix(CodeTransactionResult, blockID), txErrIterFunc)
+}
+
+// RemoveTransactionResultsByBlockID removes the transaction results of all transactions of the given block.
+func RemoveTransactionResultsByBlockID(blockID flow.Identifier) func(storage.ReaderBatchWriter) error {
+	return RemoveByPrefix(makePrefix(CodeTransactionResult, blockID))
+}
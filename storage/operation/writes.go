@@ -0,0 +1,65 @@
+package operation
+
+import (
+	"fmt"
+
+	"github.com/vmihailenco/msgpack/v4"
+
+	"github.com/onflow/flow-go/storage"
+)
+
+// UpsertByKey will encode the given entity using msgpack and will insert the
+// resulting binary data under the provided key. If the key already exists, the
+// value will be overwritten.
+func UpsertByKey(key []byte, val interface{}) func(storage.Writer) error {
+	return func(w storage.Writer) error {
+		value, err := msgpack.Marshal(val)
+		if err != nil {
+			return fmt.Errorf("could not encode value: %w", err)
+		}
+
+		err = w.Set(key, value)
+		if err != nil {
+			return fmt.Errorf("could not store data: %w", err)
+		}
+
+		return nil
+	}
+}
+
+// RemoveByKey removes the entity with the given key, if it exists. If it doesn't
+// exist, this is a no-op.
+func RemoveByKey(key []byte) func(storage.Writer) error {
+	return func(w storage.Writer) error {
+		err := w.Delete(key)
+		if err != nil {
+			return fmt.Errorf("could not delete item: %w", err)
+		}
+		return nil
+	}
+}
+
+// RemoveByPrefix removes all the entities whose keys have the given prefix. If
+// there is no such entity, this is a no-op.
+func RemoveByPrefix(prefix []byte) func(storage.ReaderBatchWriter) error {
+	return func(rw storage.ReaderBatchWriter) error {
+		var keys [][]byte
+		err := IterateKeys(rw.GlobalReader(), prefix, prefix, func() (CheckFunc, CreateFunc, HandleFunc) {
+			return func(key []byte) bool {
+				keys = append(keys, append([]byte(nil), key...))
+				return false
+			}, nil, nil
+		}, storage.IteratorOption{IterateKeyOnly: true})
+		if err != nil {
+			return fmt.Errorf("could not find keys to remove: %w", err)
+		}
+
+		for _, key := range keys {
+			err := rw.Writer().Delete(key)
+			if err != nil {
+				return fmt.Errorf("could not delete key: %w", err)
+			}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,116 @@
+package storage
+
+import (
+	"io"
+)
+
+// The interfaces below abstract the key-value store the stores persist their
+// data in, so the stores built on them work with any storage backend, such as
+// Badger or Pebble. See storage/operation for the operations on top of them.
+// Only the commits, events, service events and transaction results stores in
+// storage/store are built on them so far, and the nodes still open their
+// databases with Badger. The Pebble implementation is only exercised by the
+// tests of the operations and stores until the other stores are ported.
+
+// Iterator iterates over the key-value pairs of a key range in ascending key
+// order.
+type Iterator interface {
+	// First seeks to the smallest key of the range, and returns whether it is
+	// valid. It must be called before any other method.
+	First() bool
+
+	// Valid returns whether the iterator is positioned at a key-value pair.
+	Valid() bool
+
+	// Next advances the iterator to the next key-value pair.
+	Next()
+
+	// IterItem returns the key-value pair the iterator is positioned at.
+	IterItem() IterItem
+
+	// Close releases the resources of the iterator.
+	Close() error
+}
+
+// IterItem is a key-value pair of an iteration.
+type IterItem interface {
+	// Key returns the key of the item. It is only valid until the iterator is
+	// moved, use KeyCopy to keep it.
+	Key() []byte
+
+	// KeyCopy returns a copy of the key, reusing the given slice if it is big enough.
+	KeyCopy(dst []byte) []byte
+
+	// Value calls the given function with the value of the item. The value is
+	// only valid within the function.
+	Value(func(val []byte) error) error
+}
+
+// IteratorOption configures an iteration.
+type IteratorOption struct {
+	// IterateKeyOnly skips loading the values of the items, which is faster
+	// for the backends storing values separately from keys, such as Badger.
+	IterateKeyOnly bool
+}
+
+// DefaultIteratorOptions returns the options loading both keys and values.
+func DefaultIteratorOptions() IteratorOption {
+	return IteratorOption{
+		IterateKeyOnly: false,
+	}
+}
+
+// Reader reads the key-value store.
+type Reader interface {
+	// Get returns the value stored under the given key, or ErrNotFound if the
+	// key doesn't exist. The value is only valid until the closer is closed.
+	Get(key []byte) (value []byte, closer io.Closer, err error)
+
+	// NewIter returns an iterator over all keys with a prefix between the
+	// given start and end prefixes, both included. The start prefix must not
+	// be bigger than the end prefix.
+	NewIter(startPrefix, endPrefix []byte, ops IteratorOption) (Iterator, error)
+}
+
+// Writer writes to the key-value store.
+type Writer interface {
+	// Set stores the given value under the given key, overwriting any
+	// existing value.
+	Set(key, value []byte) error
+
+	// Delete removes the given key. Deleting a key which doesn't exist is a no-op.
+	Delete(key []byte) error
+}
+
+// ReaderBatchWriter writes a batch of changes atomically, and reads the
+// committed state of the key-value store.
+type ReaderBatchWriter interface {
+	// GlobalReader reads the committed state of the store, it doesn't see the
+	// writes of the batch.
+	GlobalReader() Reader
+
+	// Writer adds writes to the batch.
+	Writer() Writer
+
+	// AddCallback adds a function called once the batch is committed, with
+	// the error of the commit. It is used to update caches only once the
+	// changes are persisted.
+	AddCallback(func(error))
+}
+
+// DB is a key-value store.
+type DB interface {
+	// Reader reads the committed state of the store.
+	Reader() Reader
+
+	// WithReaderBatchWriter creates a batch, passes it to the given function,
+	// and commits it if the function returns no error.
+	WithReaderBatchWriter(func(ReaderBatchWriter) error) error
+}
+
+// OnlyWriter adapts a function taking a writer to a function taking a batch.
+func OnlyWriter(fn func(Writer) error) func(ReaderBatchWriter) error {
+	return func(rw ReaderBatchWriter) error {
+		return fn(rw.Writer())
+	}
+}
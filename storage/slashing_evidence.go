@@ -0,0 +1,21 @@
+package storage
+
+import (
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// SlashingEvidence represents persistent storage for the evidence of slashable
+// consensus violations.
+type SlashingEvidence interface {
+
+	// Store inserts the evidence and indexes it by view. Storing the same
+	// evidence more than once is a no-op.
+	Store(evidence *flow.SlashingEvidence) error
+
+	// ByID retrieves the evidence by its ID.
+	ByID(evidenceID flow.Identifier) (*flow.SlashingEvidence, error)
+
+	// ByViewRange retrieves all evidence for violations at views in the range
+	// [startView, endView], ordered by view.
+	ByViewRange(startView uint64, endView uint64) ([]*flow.SlashingEvidence, error)
+}
@@ -0,0 +1,108 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+
+	lru "github.com/hashicorp/golang-lru"
+
+	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/storage"
+)
+
+const DefaultCacheSize = uint(1000)
+
+type retrieveFunc func(r storage.Reader, key interface{}) (interface{}, error)
+
+func withLimit(limit uint) func(*Cache) {
+	return func(c *Cache) {
+		c.limit = limit
+	}
+}
+
+func withRetrieve(retrieve retrieveFunc) func(*Cache) {
+	return func(c *Cache) {
+		c.retrieve = retrieve
+	}
+}
+
+func noRetrieve(r storage.Reader, key interface{}) (interface{}, error) {
+	return nil, fmt.Errorf("no retrieve function for cache get available")
+}
+
+// Cache is a LRU cache in front of the reads of a store. Unlike the cache of
+// the badger stores, it doesn't store resources itself: the stores write their
+// resources in a batch, and insert them into the cache once it is committed.
+type Cache struct {
+	metrics  module.CacheMetrics
+	limit    uint
+	retrieve retrieveFunc
+	resource string
+	cache    *lru.Cache
+}
+
+func newCache(collector module.CacheMetrics, resourceName string, options ...func(*Cache)) *Cache {
+	c := Cache{
+		metrics:  collector,
+		limit:    DefaultCacheSize,
+		retrieve: noRetrieve,
+		resource: resourceName,
+	}
+	for _, option := range options {
+		option(&c)
+	}
+	c.cache, _ = lru.New(int(c.limit))
+	c.metrics.CacheEntries(c.resource, uint(c.cache.Len()))
+	return &c
+}
+
+// Get will try to retrieve the resource from cache first, and then from the
+// given reader.
+func (c *Cache) Get(r storage.Reader, key interface{}) (interface{}, error) {
+
+	// check if we have it in the cache
+	resource, cached := c.cache.Get(key)
+	if cached {
+		c.metrics.CacheHit(c.resource)
+		return resource, nil
+	}
+
+	// get it from the database
+	resource, err := c.retrieve(r, key)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			c.metrics.CacheNotFound(c.resource)
+		}
+		return nil, fmt.Errorf("could not retrieve resource: %w", err)
+	}
+
+	c.metrics.CacheMiss(c.resource)
+
+	// cache the resource and eject least recently used one if we reached limit
+	c.Insert(key, resource)
+
+	return resource, nil
+}
+
+func (c *Cache) Remove(key interface{}) {
+	c.cache.Remove(key)
+}
+
+// Insert will add an resource directly to the cache with the given ID
+func (c *Cache) Insert(key interface{}, resource interface{}) {
+	// cache the resource and eject least recently used one if we reached limit
+	evicted := c.cache.Add(key, resource)
+	if !evicted {
+		c.metrics.CacheEntries(c.resource, uint(c.cache.Len()))
+	}
+}
+
+// InsertOnCommit inserts the resource into the cache once the given batch is
+// committed successfully.
+func (c *Cache) InsertOnCommit(rw storage.ReaderBatchWriter, key interface{}, resource interface{}) {
+	rw.AddCallback(func(err error) {
+		if err == nil {
+			c.Insert(key, resource)
+		}
+	})
+}
@@ -0,0 +1,63 @@
+package store
+
+import (
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/storage/operation"
+)
+
+type Commits struct {
+	db    storage.DB
+	cache *Cache
+}
+
+var _ storage.Commits = (*Commits)(nil)
+
+func NewCommits(collector module.CacheMetrics, db storage.DB) *Commits {
+
+	retrieve := func(r storage.Reader, key interface{}) (interface{}, error) {
+		blockID := key.(flow.Identifier)
+		var commit flow.StateCommitment
+		err := operation.LookupStateCommitment(blockID, &commit)(r)
+		return commit, err
+	}
+
+	c := &Commits{
+		db: db,
+		cache: newCache(collector, metrics.ResourceCommit,
+			withLimit(100),
+			withRetrieve(retrieve),
+		),
+	}
+
+	return c
+}
+
+// Store will store a commit in the persistent storage. Storing the same commit
+// again is a no-op.
+func (c *Commits) Store(blockID flow.Identifier, commit flow.StateCommitment) error {
+	return c.db.WithReaderBatchWriter(func(rw storage.ReaderBatchWriter) error {
+		return c.BatchStore(blockID, commit, rw)
+	})
+}
+
+// BatchStore will store a commit in the given batch, the commit is cached once
+// the batch is committed.
+func (c *Commits) BatchStore(blockID flow.Identifier, commit flow.StateCommitment, rw storage.ReaderBatchWriter) error {
+	err := operation.IndexStateCommitment(blockID, commit)(rw.Writer())
+	if err != nil {
+		return err
+	}
+	c.cache.InsertOnCommit(rw, blockID, commit)
+	return nil
+}
+
+func (c *Commits) ByBlockID(blockID flow.Identifier) (flow.StateCommitment, error) {
+	val, err := c.cache.Get(c.db.Reader(), blockID)
+	if err != nil {
+		return flow.DummyStateCommitment, err
+	}
+	return val.(flow.StateCommitment), nil
+}
@@ -0,0 +1,65 @@
+package store_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/storage"
+	badgeroperation "github.com/onflow/flow-go/storage/badger/operation"
+	"github.com/onflow/flow-go/storage/operation/badgerimpl"
+	"github.com/onflow/flow-go/storage/operation/dbtest"
+	"github.com/onflow/flow-go/storage/store"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestCommitsStoreAndRetrieve tests that a commit can be stored, retrieved and attempted to be stored again without an error
+func TestCommitsStoreAndRetrieve(t *testing.T) {
+	dbtest.RunWithDB(t, func(t *testing.T, db storage.DB) {
+		metrics := metrics.NewNoopCollector()
+		commits := store.NewCommits(metrics, db)
+
+		// attempt to get a invalid commit
+		_, err := commits.ByBlockID(unittest.IdentifierFixture())
+		assert.True(t, errors.Is(err, storage.ErrNotFound))
+
+		// store a commit in db
+		blockID := unittest.IdentifierFixture()
+		expected := unittest.StateCommitmentFixture()
+		err = commits.Store(blockID, expected)
+		require.NoError(t, err)
+
+		// retrieve the commit by ID
+		actual, err := commits.ByBlockID(blockID)
+		require.NoError(t, err)
+		assert.Equal(t, expected, actual)
+
+		// re-insert the commit - should be idempotent
+		err = commits.Store(blockID, expected)
+		require.NoError(t, err)
+
+		// test loading from database
+		actual, err = store.NewCommits(metrics, db).ByBlockID(blockID)
+		require.NoError(t, err)
+		assert.Equal(t, expected, actual)
+	})
+}
+
+// TestCommitsBadgerCompatibility checks that the commits indexed by the badger
+// operations can be read through the generic store, as both share the keys.
+func TestCommitsBadgerCompatibility(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		blockID := unittest.IdentifierFixture()
+		expected := unittest.StateCommitmentFixture()
+		err := db.Update(badgeroperation.IndexStateCommitment(blockID, expected))
+		require.NoError(t, err)
+
+		actual, err := store.NewCommits(metrics.NewNoopCollector(), badgerimpl.ToDB(db)).ByBlockID(blockID)
+		require.NoError(t, err)
+		assert.Equal(t, expected, actual)
+	})
+}
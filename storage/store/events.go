@@ -1,42 +1,42 @@
-package badger
+package store
 
 import (
 	"fmt"
 
-	"github.com/dgraph-io/badger/v2"
-
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module"
 	"github.com/onflow/flow-go/module/metrics"
 	"github.com/onflow/flow-go/storage"
-	"github.com/onflow/flow-go/storage/badger/operation"
+	"github.com/onflow/flow-go/storage/operation"
 )
 
 type Events struct {
-	db    *badger.DB
+	db    storage.DB
 	cache *Cache
 }
 
-func NewEvents(collector module.CacheMetrics, db *badger.DB) *Events {
-	retrieve := func(key interface{}) func(tx *badger.Txn) (interface{}, error) {
+var _ storage.Events = (*Events)(nil)
+
+func NewEvents(collector module.CacheMetrics, db storage.DB) *Events {
+	retrieve := func(r storage.Reader, key interface{}) (interface{}, error) {
 		blockID := key.(flow.Identifier)
 		var events []flow.Event
-		return func(tx *badger.Txn) (interface{}, error) {
-			err := operation.LookupEventsByBlockID(blockID, &events)(tx)
-			return events, handleError(err, flow.Event{})
+		err := operation.LookupEventsByBlockID(blockID, &events)(r)
+		if err != nil {
+			return nil, fmt.Errorf("could not retrieve events: %w", err)
 		}
+		return events, nil
 	}
 
 	return &Events{
 		db: db,
 		cache: newCache(collector, metrics.ResourceEvents,
-			withStore(noopStore),
 			withRetrieve(retrieve)),
 	}
 }
 
-func (e *Events) BatchStore(blockID flow.Identifier, blockEvents []flow.EventsList, batch storage.BatchStorage) error {
-	writeBatch := batch.GetWriter()
+func (e *Events) BatchStore(blockID flow.Identifier, blockEvents []flow.EventsList, rw storage.ReaderBatchWriter) error {
+	writer := rw.Writer()
 
 	// pre-allocating and indexing slice is faster than appending
 	sliceSize := 0
@@ -50,7 +50,7 @@ func (e *Events) BatchStore(blockID flow.Identifier, blockEvents []flow.EventsLi
 
 	for _, events := range blockEvents {
 		for _, event := range events {
-			err := operation.BatchInsertEvent(blockID, event)(writeBatch)
+			err := operation.InsertEvent(blockID, event)(writer)
 			if err != nil {
 				return fmt.Errorf("cannot batch insert event: %w", err)
 			}
@@ -59,18 +59,13 @@ func (e *Events) BatchStore(blockID flow.Identifier, blockEvents []flow.EventsLi
 		}
 	}
 
-	callback := func() {
-		e.cache.Insert(blockID, combinedEvents)
-	}
-	batch.OnSucceed(callback)
+	e.cache.InsertOnCommit(rw, blockID, combinedEvents)
 	return nil
 }
 
 // ByBlockID returns the events for the given block ID
 func (e *Events) ByBlockID(blockID flow.Identifier) ([]flow.Event, error) {
-	tx := e.db.NewTransaction(false)
-	defer tx.Discard()
-	val, err := e.cache.Get(blockID)(tx)
+	val, err := e.cache.Get(e.db.Reader(), blockID)
 	if err != nil {
 		return nil, err
 	}
@@ -81,7 +76,7 @@ func (e *Events) ByBlockID(blockID flow.Identifier) ([]flow.Event, error) {
 func (e *Events) ByBlockIDTransactionID(blockID flow.Identifier, txID flow.Identifier) ([]flow.Event, error) {
 	events, err := e.ByBlockID(blockID)
 	if err != nil {
-		return nil, handleError(err, flow.Event{})
+		return nil, err
 	}
 
 	var matched []flow.Event
@@ -97,7 +92,7 @@ func (e *Events) ByBlockIDTransactionID(blockID flow.Identifier, txID flow.Ident
 func (e *Events) ByBlockIDEventType(blockID flow.Identifier, eventType flow.EventType) ([]flow.Event, error) {
 	events, err := e.ByBlockID(blockID)
 	if err != nil {
-		return nil, handleError(err, flow.Event{})
+		return nil, err
 	}
 
 	var matched []flow.Event
@@ -110,49 +105,46 @@ func (e *Events) ByBlockIDEventType(blockID flow.Identifier, eventType flow.Even
 }
 
 type ServiceEvents struct {
-	db    *badger.DB
+	db    storage.DB
 	cache *Cache
 }
 
-func NewServiceEvents(collector module.CacheMetrics, db *badger.DB) *ServiceEvents {
-	retrieve := func(key interface{}) func(tx *badger.Txn) (interface{}, error) {
+var _ storage.ServiceEvents = (*ServiceEvents)(nil)
+
+func NewServiceEvents(collector module.CacheMetrics, db storage.DB) *ServiceEvents {
+	retrieve := func(r storage.Reader, key interface{}) (interface{}, error) {
 		blockID := key.(flow.Identifier)
 		var events []flow.Event
-		return func(tx *badger.Txn) (interface{}, error) {
-			err := operation.LookupServiceEventsByBlockID(blockID, &events)(tx)
-			return events, handleError(err, flow.Event{})
+		err := operation.LookupServiceEventsByBlockID(blockID, &events)(r)
+		if err != nil {
+			return nil, fmt.Errorf("could not retrieve service events: %w", err)
 		}
+		return events, nil
 	}
 
 	return &ServiceEvents{
 		db: db,
 		cache: newCache(collector, metrics.ResourceEvents,
-			withStore(noopStore),
 			withRetrieve(retrieve)),
 	}
 }
 
-func (e *ServiceEvents) BatchStore(blockID flow.Identifier, events []flow.Event, batch storage.BatchStorage) error {
-	writeBatch := batch.GetWriter()
+func (e *ServiceEvents) BatchStore(blockID flow.Identifier, events []flow.Event, rw storage.ReaderBatchWriter) error {
+	writer := rw.Writer()
 	for _, event := range events {
-		err := operation.BatchInsertServiceEvent(blockID, event)(writeBatch)
+		err := operation.InsertServiceEvent(blockID, event)(writer)
 		if err != nil {
 			return fmt.Errorf("cannot batch insert service event: %w", err)
 		}
 	}
 
-	callback := func() {
-		e.cache.Insert(blockID, events)
-	}
-	batch.OnSucceed(callback)
+	e.cache.InsertOnCommit(rw, blockID, events)
 	return nil
 }
 
 // ByBlockID returns the events for the given block ID
 func (e *ServiceEvents) ByBlockID(blockID flow.Identifier) ([]flow.Event, error) {
-	tx := e.db.NewTransaction(false)
-	defer tx.Discard()
-	val, err := e.cache.Get(blockID)(tx)
+	val, err := e.cache.Get(e.db.Reader(), blockID)
 	if err != nil {
 		return nil, err
 	}
@@ -1,22 +1,23 @@
-package badger_test
+package store_test
 
 import (
 	"testing"
 
-	"github.com/dgraph-io/badger/v2"
 	"github.com/stretchr/testify/require"
 
 	"github.com/onflow/flow-go/fvm/systemcontracts"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module/metrics"
-	badgerstorage "github.com/onflow/flow-go/storage/badger"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/storage/operation/dbtest"
+	"github.com/onflow/flow-go/storage/store"
 	"github.com/onflow/flow-go/utils/unittest"
 )
 
 func TestEventStoreRetrieve(t *testing.T) {
-	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+	dbtest.RunWithDB(t, func(t *testing.T, db storage.DB) {
 		metrics := metrics.NewNoopCollector()
-		store := badgerstorage.NewEvents(metrics, db)
+		events := store.NewEvents(metrics, db)
 
 		blockID := unittest.IdentifierFixture()
 		tx1ID := unittest.IdentifierFixture()
@@ -31,16 +32,14 @@ func TestEventStoreRetrieve(t *testing.T) {
 			{evt2_1},
 		}
 
-		batch := badgerstorage.NewBatch(db)
 		// store event
-		err := store.BatchStore(blockID, expected, batch)
-		require.NoError(t, err)
-
-		err = batch.Flush()
+		err := db.WithReaderBatchWriter(func(rw storage.ReaderBatchWriter) error {
+			return events.BatchStore(blockID, expected, rw)
+		})
 		require.NoError(t, err)
 
 		// retrieve by blockID
-		actual, err := store.ByBlockID(blockID)
+		actual, err := events.ByBlockID(blockID)
 		require.NoError(t, err)
 		require.Len(t, actual, 3)
 		require.Contains(t, actual, evt1_1)
@@ -48,33 +47,33 @@ func TestEventStoreRetrieve(t *testing.T) {
 		require.Contains(t, actual, evt2_1)
 
 		// retrieve by blockID and event type
-		actual, err = store.ByBlockIDEventType(blockID, flow.EventAccountCreated)
+		actual, err = events.ByBlockIDEventType(blockID, flow.EventAccountCreated)
 		require.NoError(t, err)
 		require.Len(t, actual, 2)
 		require.Contains(t, actual, evt1_1)
 		require.Contains(t, actual, evt1_2)
 
-		actual, err = store.ByBlockIDEventType(blockID, flow.EventAccountUpdated)
+		actual, err = events.ByBlockIDEventType(blockID, flow.EventAccountUpdated)
 		require.NoError(t, err)
 		require.Len(t, actual, 1)
 		require.Contains(t, actual, evt2_1)
 
-		events, err := systemcontracts.ServiceEventsForChain(flow.Emulator)
+		serviceEvents, err := systemcontracts.ServiceEventsForChain(flow.Emulator)
 		require.NoError(t, err)
 
-		actual, err = store.ByBlockIDEventType(blockID, events.EpochSetup.EventType())
+		actual, err = events.ByBlockIDEventType(blockID, serviceEvents.EpochSetup.EventType())
 		require.NoError(t, err)
 		require.Len(t, actual, 0)
 
 		// retrieve by blockID and transaction id
-		actual, err = store.ByBlockIDTransactionID(blockID, tx1ID)
+		actual, err = events.ByBlockIDTransactionID(blockID, tx1ID)
 		require.NoError(t, err)
 		require.Len(t, actual, 1)
 		require.Contains(t, actual, evt1_1)
 
 		// test loading from database
 
-		newStore := badgerstorage.NewEvents(metrics, db)
+		newStore := store.NewEvents(metrics, db)
 		actual, err = newStore.ByBlockID(blockID)
 		require.NoError(t, err)
 		require.Len(t, actual, 3)
@@ -85,27 +84,49 @@ func TestEventStoreRetrieve(t *testing.T) {
 }
 
 func TestEventRetrieveWithoutStore(t *testing.T) {
-	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+	dbtest.RunWithDB(t, func(t *testing.T, db storage.DB) {
 		metrics := metrics.NewNoopCollector()
-		store := badgerstorage.NewEvents(metrics, db)
+		events := store.NewEvents(metrics, db)
 
 		blockID := unittest.IdentifierFixture()
 		txID := unittest.IdentifierFixture()
 
 		// retrieve by blockID
-		events, err := store.ByBlockID(blockID)
+		actual, err := events.ByBlockID(blockID)
 		require.NoError(t, err)
-		require.True(t, len(events) == 0)
+		require.True(t, len(actual) == 0)
 
 		// retrieve by blockID and event type
-		events, err = store.ByBlockIDEventType(blockID, flow.EventAccountCreated)
+		actual, err = events.ByBlockIDEventType(blockID, flow.EventAccountCreated)
 		require.NoError(t, err)
-		require.True(t, len(events) == 0)
+		require.True(t, len(actual) == 0)
 
 		// retrieve by blockID and transaction id
-		events, err = store.ByBlockIDTransactionID(blockID, txID)
+		actual, err = events.ByBlockIDTransactionID(blockID, txID)
 		require.NoError(t, err)
-		require.True(t, len(events) == 0)
+		require.True(t, len(actual) == 0)
+
+	})
+}
 
+// TestEventsNotCachedOnFailedBatch checks that the events of a batch which
+// isn't committed are neither cached nor persisted.
+func TestEventsNotCachedOnFailedBatch(t *testing.T) {
+	dbtest.RunWithDB(t, func(t *testing.T, db storage.DB) {
+		events := store.NewServiceEvents(metrics.NewNoopCollector(), db)
+
+		blockID := unittest.IdentifierFixture()
+		event := unittest.EventFixture(flow.EventAccountCreated, 0, 0, unittest.IdentifierFixture(), 0)
+
+		err := db.WithReaderBatchWriter(func(rw storage.ReaderBatchWriter) error {
+			err := events.BatchStore(blockID, []flow.Event{event}, rw)
+			require.NoError(t, err)
+			return storage.ErrAlreadyExists
+		})
+		require.ErrorIs(t, err, storage.ErrAlreadyExists)
+
+		actual, err := events.ByBlockID(blockID)
+		require.NoError(t, err)
+		require.Empty(t, actual)
 	})
 }
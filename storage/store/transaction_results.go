@@ -1,22 +1,22 @@
-package badger
+package store
 
 import (
 	"fmt"
 
-	"github.com/dgraph-io/badger/v2"
-
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module"
 	"github.com/onflow/flow-go/module/metrics"
 	"github.com/onflow/flow-go/storage"
-	"github.com/onflow/flow-go/storage/badger/operation"
+	"github.com/onflow/flow-go/storage/operation"
 )
 
 type TransactionResults struct {
-	db    *badger.DB
+	db    storage.DB
 	cache *Cache
 }
 
+var _ storage.TransactionResults = (*TransactionResults)(nil)
+
 func KeyFromBlockIDTransactionID(blockID flow.Identifier, txID flow.Identifier) string {
 	return fmt.Sprintf("%x%x", blockID, txID)
 }
@@ -37,44 +37,43 @@ func KeyToBlockIDTransactionID(key string) (flow.Identifier, flow.Identifier, er
 	return blockID, txID, nil
 }
 
-func NewTransactionResults(collector module.CacheMetrics, db *badger.DB, transactionResultsCacheSize uint) *TransactionResults {
-	retrieve := func(key interface{}) func(tx *badger.Txn) (interface{}, error) {
-		var txResult flow.TransactionResult
-		return func(tx *badger.Txn) (interface{}, error) {
-
-			blockID, txID, err := KeyToBlockIDTransactionID(key.(string))
-			if err != nil {
-				return nil, fmt.Errorf("could not convert key: %w", err)
-			}
+func NewTransactionResults(collector module.CacheMetrics, db storage.DB, transactionResultsCacheSize uint) *TransactionResults {
+	retrieve := func(r storage.Reader, key interface{}) (interface{}, error) {
+		blockID, txID, err := KeyToBlockIDTransactionID(key.(string))
+		if err != nil {
+			return nil, fmt.Errorf("could not convert key: %w", err)
+		}
 
-			err = operation.RetrieveTransactionResult(blockID, txID, &txResult)(tx)
-			if err != nil {
-				return nil, handleError(err, flow.TransactionResult{})
-			}
-			return txResult, nil
+		var txResult flow.TransactionResult
+		err = operation.RetrieveTransactionResult(blockID, txID, &txResult)(r)
+		if err != nil {
+			return nil, err
 		}
+		return txResult, nil
 	}
 	return &TransactionResults{
 		db: db,
 		cache: newCache(collector, metrics.ResourceTransactionResults,
 			withLimit(transactionResultsCacheSize),
-			withStore(noopStore),
 			withRetrieve(retrieve)),
 	}
 }
 
 // BatchStore will store the transaction results for the given block ID in a batch
-func (tr *TransactionResults) BatchStore(blockID flow.Identifier, transactionResults []flow.TransactionResult, batch storage.BatchStorage) error {
-	writeBatch := batch.GetWriter()
+func (tr *TransactionResults) BatchStore(blockID flow.Identifier, transactionResults []flow.TransactionResult, rw storage.ReaderBatchWriter) error {
+	writer := rw.Writer()
 
-	for _, result := range transactionResults {
-		err := operation.BatchInsertTransactionResult(blockID, &result)(writeBatch)
+	for i := range transactionResults {
+		err := operation.InsertTransactionResult(blockID, &transactionResults[i])(writer)
 		if err != nil {
 			return fmt.Errorf("cannot batch insert tx result: %w", err)
 		}
 	}
 
-	batch.OnSucceed(func() {
+	rw.AddCallback(func(err error) {
+		if err != nil {
+			return
+		}
 		for _, result := range transactionResults {
 			key := KeyFromBlockIDTransactionID(blockID, result.TransactionID)
 			// cache for each transaction, so that it's faster to retrieve
@@ -86,16 +85,14 @@ func (tr *TransactionResults) BatchStore(blockID flow.Identifier, transactionRes
 
 // ByBlockIDTransactionID returns the runtime transaction result for the given block ID and transaction ID
 func (tr *TransactionResults) ByBlockIDTransactionID(blockID flow.Identifier, txID flow.Identifier) (*flow.TransactionResult, error) {
-	tx := tr.db.NewTransaction(false)
-	defer tx.Discard()
 	key := KeyFromBlockIDTransactionID(blockID, txID)
-	val, err := tr.cache.Get(key)(tx)
+	val, err := tr.cache.Get(tr.db.Reader(), key)
 	if err != nil {
 		return nil, err
 	}
 	transactionResult, ok := val.(flow.TransactionResult)
 	if !ok {
-		return nil, fmt.Errorf("could not convert transaction result: %w", err)
+		return nil, fmt.Errorf("could not convert transaction result: %T", val)
 	}
 	return &transactionResult, nil
 }
@@ -1,26 +1,25 @@
-package badger_test
+package store_test
 
 import (
 	"errors"
 	"fmt"
 	"testing"
 
-	"github.com/dgraph-io/badger/v2"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module/metrics"
 	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/storage/operation/dbtest"
+	"github.com/onflow/flow-go/storage/store"
 	"github.com/onflow/flow-go/utils/unittest"
-
-	bstorage "github.com/onflow/flow-go/storage/badger"
 )
 
 func TestBatchStoringTransactionResults(t *testing.T) {
-	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+	dbtest.RunWithDB(t, func(t *testing.T, db storage.DB) {
 		metrics := metrics.NewNoopCollector()
-		store := bstorage.NewTransactionResults(metrics, db, 1000)
+		results := store.NewTransactionResults(metrics, db, 1000)
 
 		blockID := unittest.IdentifierFixture()
 		txResults := make([]flow.TransactionResult, 0)
@@ -32,21 +31,19 @@ func TestBatchStoringTransactionResults(t *testing.T) {
 			}
 			txResults = append(txResults, expected)
 		}
-		writeBatch := bstorage.NewBatch(db)
-		err := store.BatchStore(blockID, txResults, writeBatch)
-		require.NoError(t, err)
-
-		err = writeBatch.Flush()
+		err := db.WithReaderBatchWriter(func(rw storage.ReaderBatchWriter) error {
+			return results.BatchStore(blockID, txResults, rw)
+		})
 		require.NoError(t, err)
 
 		for _, txResult := range txResults {
-			actual, err := store.ByBlockIDTransactionID(blockID, txResult.TransactionID)
+			actual, err := results.ByBlockIDTransactionID(blockID, txResult.TransactionID)
 			require.Nil(t, err)
 			assert.Equal(t, txResult, *actual)
 		}
 
 		// test loading from database
-		newStore := bstorage.NewTransactionResults(metrics, db, 1000)
+		newStore := store.NewTransactionResults(metrics, db, 1000)
 		for _, txResult := range txResults {
 			actual, err := newStore.ByBlockIDTransactionID(blockID, txResult.TransactionID)
 			require.Nil(t, err)
@@ -56,14 +53,14 @@ func TestBatchStoringTransactionResults(t *testing.T) {
 }
 
 func TestReadingNotStoreTransaction(t *testing.T) {
-	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+	dbtest.RunWithDB(t, func(t *testing.T, db storage.DB) {
 		metrics := metrics.NewNoopCollector()
-		store := bstorage.NewTransactionResults(metrics, db, 1000)
+		results := store.NewTransactionResults(metrics, db, 1000)
 
 		blockID := unittest.IdentifierFixture()
 		txID := unittest.IdentifierFixture()
 
-		_, err := store.ByBlockIDTransactionID(blockID, txID)
+		_, err := results.ByBlockIDTransactionID(blockID, txID)
 		assert.True(t, errors.Is(err, storage.ErrNotFound))
 	})
 }
@@ -71,8 +68,8 @@ func TestReadingNotStoreTransaction(t *testing.T) {
 func TestKeyConversion(t *testing.T) {
 	blockID := unittest.IdentifierFixture()
 	txID := unittest.IdentifierFixture()
-	key := bstorage.KeyFromBlockIDTransactionID(blockID, txID)
-	bID, tID, err := bstorage.KeyToBlockIDTransactionID(key)
+	key := store.KeyFromBlockIDTransactionID(blockID, txID)
+	bID, tID, err := store.KeyToBlockIDTransactionID(key)
 	require.NoError(t, err)
 	require.Equal(t, blockID, bID)
 	require.Equal(t, txID, tID)
@@ -0,0 +1,17 @@
+package storage
+
+import "github.com/onflow/flow-go/model/flow"
+
+// TransactionRegisterSets represents persistent storage for the registers read
+// and written by executed transactions.
+type TransactionRegisterSets interface {
+
+	// BatchStore inserts the register sets of the transactions of the given block into a batch
+	BatchStore(blockID flow.Identifier, registerSets []flow.TransactionRegisterSet, batch BatchStorage) error
+
+	// ByBlockID returns the register sets of all transactions of the given block, ordered by transaction index
+	ByBlockID(blockID flow.Identifier) ([]flow.TransactionRegisterSet, error)
+
+	// ByBlockIDTransactionID returns the register sets for the given block ID and transaction ID
+	ByBlockIDTransactionID(blockID flow.Identifier, transactionID flow.Identifier) (*flow.TransactionRegisterSet, error)
+}
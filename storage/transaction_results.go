@@ -6,7 +6,7 @@ import "github.com/onflow/flow-go/model/flow"
 type TransactionResults interface {
 
 	// BatchStore inserts a batch of transaction result into a batch
-	BatchStore(blockID flow.Identifier, transactionResults []flow.TransactionResult, batch BatchStorage) error
+	BatchStore(blockID flow.Identifier, transactionResults []flow.TransactionResult, batch ReaderBatchWriter) error
 
 	// ByBlockIDTransactionID returns the transaction result for the given block ID and transaction ID
 	ByBlockIDTransactionID(blockID flow.Identifier, transactionID flow.Identifier) (*flow.TransactionResult, error)
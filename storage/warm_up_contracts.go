@@ -0,0 +1,18 @@
+package storage
+
+import "github.com/onflow/flow-go/model/flow"
+
+// WarmUpContracts represents persistent storage for the contracts whose programs
+// are loaded to warm up the programs cache when an execution node starts.
+type WarmUpContracts interface {
+
+	// Update stores the given contracts, replacing the ones stored for the same contracts, and removes
+	// the given contracts, in a single transaction. Only the address and name of removed contracts are used.
+	Update(stored []*flow.WarmUpContract, removed []*flow.WarmUpContract) error
+
+	// Remove removes the given contract. It is a no-op if the contract is not stored.
+	Remove(address flow.Address, name string) error
+
+	// All returns all stored contracts
+	All() ([]flow.WarmUpContract, error)
+}
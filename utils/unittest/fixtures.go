@@ -67,10 +67,12 @@ func InvalidAddressFixture() flow.Address {
 }
 
 func InvalidFormatSignature() flow.TransactionSignature {
+	sig := make([]byte, crypto.SignatureLenECDSAP256) // zero r is an invalid ECDSA signature
+	sig[len(sig)-1] = 0xff                            // non-canonical S is an invalid Ed25519 signature
 	return flow.TransactionSignature{
 		Address:     AddressFixture(),
 		SignerIndex: 0,
-		Signature:   make([]byte, crypto.SignatureLenECDSAP256), // zero signature is invalid
+		Signature:   sig,
 		KeyIndex:    1,
 	}
 }
@@ -1617,6 +1619,37 @@ func VoteForBlockFixture(block *hotstuff.Block, opts ...func(vote *hotstuff.Vote
 	return vote
 }
 
+// SlashingEvidenceFixture returns the evidence of a double vote.
+func SlashingEvidenceFixture(opts ...func(*flow.SlashingEvidence)) *flow.SlashingEvidence {
+	view := uint64(rand.Uint32())
+	offenderID := IdentifierFixture()
+	evidence := &flow.SlashingEvidence{
+		Violation:  flow.SlashingViolationDoubleVote,
+		ChainID:    flow.Emulator,
+		OffenderID: offenderID,
+		View:       view,
+		Votes: []flow.SignedVote{
+			{View: view, BlockID: IdentifierFixture(), SignerID: offenderID, SigData: RandomBytes(128)},
+			{View: view, BlockID: IdentifierFixture(), SignerID: offenderID, SigData: RandomBytes(128)},
+		},
+	}
+
+	for _, opt := range opts {
+		opt(evidence)
+	}
+
+	return evidence
+}
+
+func WithSlashingEvidenceView(view uint64) func(*flow.SlashingEvidence) {
+	return func(evidence *flow.SlashingEvidence) {
+		evidence.View = view
+		for i := range evidence.Votes {
+			evidence.Votes[i].View = view
+		}
+	}
+}
+
 func VoteWithStakingSig() func(*hotstuff.Vote) {
 	return func(vote *hotstuff.Vote) {
 		vote.SigData = append([]byte{byte(hotstuffroot.SigTypeStaking)}, vote.SigData...)